@@ -1,6 +1,7 @@
 package key
 
 import (
+	"context"
 	"io"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -21,6 +23,7 @@ const (
 
 type Config struct {
 	Database database.Config
+	KMS      kms.Config
 }
 
 func New() *cobra.Command {
@@ -29,7 +32,11 @@ func New() *cobra.Command {
 		Short: "manage encryption keys",
 	}
 	AddMasterKeyFlag(cmd)
-	cmd.AddCommand(newKey())
+	cmd.AddCommand(
+		newKey(),
+		newRotateMaster(),
+		newWrapMaster(),
+	)
 	return cmd
 }
 
@@ -69,7 +76,7 @@ new -f keys.yaml key2=anotherkey`,
 			if err != nil {
 				return err
 			}
-			storage, err := keyStorage(config.Database, masterKey)
+			storage, err := keyStorage(cmd.Context(), config, masterKey)
 			if err != nil {
 				return err
 			}
@@ -122,10 +129,14 @@ func openFile(fileName string) (io.Reader, error) {
 	return file, nil
 }
 
-func keyStorage(config database.Config, masterKey string) (crypto.KeyStorage, error) {
-	db, err := database.Connect(config, false)
+func keyStorage(ctx context.Context, config *Config, masterKey string) (*cryptoDB.Database, error) {
+	keyManagement, err := config.KMS.NewKMS()
 	if err != nil {
 		return nil, err
 	}
-	return cryptoDB.NewKeyStorage(db, masterKey)
+	db, err := database.Connect(config.Database, false)
+	if err != nil {
+		return nil, err
+	}
+	return cryptoDB.NewKeyStorage(ctx, db, masterKey, keyManagement)
 }
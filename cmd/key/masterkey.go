@@ -28,10 +28,16 @@ func AddMasterKeyFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool(flagMasterKeyEnv, false, "read masterkey for en/decryption keys from environment variable (ZITADEL_MASTERKEY)")
 }
 
+// MasterKey returns the master key provided by the flags,
+// it might be wrapped by the configured KMS.
 func MasterKey(cmd *cobra.Command) (string, error) {
-	masterKeyFile, _ := cmd.Flags().GetString(flagMasterKey)
-	masterKeyFromArg, _ := cmd.Flags().GetString(flagMasterKeyArg)
-	masterKeyFromEnv, _ := cmd.Flags().GetBool(flagMasterKeyEnv)
+	return masterKeyFromFlags(cmd, flagMasterKey, flagMasterKeyArg, flagMasterKeyEnv, envMasterKey)
+}
+
+func masterKeyFromFlags(cmd *cobra.Command, fileFlag, argFlag, envFlag, env string) (string, error) {
+	masterKeyFile, _ := cmd.Flags().GetString(fileFlag)
+	masterKeyFromArg, _ := cmd.Flags().GetString(argFlag)
+	masterKeyFromEnv, _ := cmd.Flags().GetBool(envFlag)
 	if err := checkSingleFlag(masterKeyFile, masterKeyFromArg, masterKeyFromEnv); err != nil {
 		return "", err
 	}
@@ -39,7 +45,7 @@ func MasterKey(cmd *cobra.Command) (string, error) {
 		return masterKeyFromArg, nil
 	}
 	if masterKeyFromEnv {
-		return os.Getenv(envMasterKey), nil
+		return os.Getenv(env), nil
 	}
 	data, err := os.ReadFile(masterKeyFile)
 	if err != nil {
@@ -0,0 +1,142 @@
+package key
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagNewMasterKey    = "newMasterkeyFile"
+	flagNewMasterKeyArg = "newMasterkey"
+	flagNewMasterKeyEnv = "newMasterkeyFromEnv"
+	envNewMasterKey     = "ZITADEL_NEW_MASTERKEY"
+)
+
+func newRotateMaster() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-master",
+		Short: "rewraps all encryption keys",
+		Long: `rewraps all encryption keys with a new master key or the configured KMS
+The keys are read using the current master key and the configured KMS.
+If a KMS is configured, all keys are rewrapped by the KMS,
+this also moves keys encrypted by the master key to the KMS and rewraps them with the latest version of the KMS key.
+Otherwise all keys are rewrapped by the new master key, which must be used afterwards.
+The keys are read and rewrapped in a single transaction, which locks them against concurrent rotations.
+ZITADEL must be stopped during the rotation and started with the new master key afterwards:
+a running ZITADEL encrypts the keys it creates with its current master key, which could not be read after the rotation.
+Requirements:
+- database
+- downtime of ZITADEL (all instances, including setup and mirror) during the rotation
+- the pkcs11 KMS requires a ZITADEL binary built with cgo (CGO_ENABLED=1)`,
+		Example: `rotate-master --masterkeyFromEnv --newMasterkeyFile new_masterkey.txt
+rotate-master --masterkeyFromEnv --config kms.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			masterKey, err := MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			newMasterKey, err := newMasterKeyFromFlags(cmd, config.KMS.Type != kms.TypeNone)
+			if err != nil {
+				return err
+			}
+			count, err := rotateMaster(cmd.Context(), config, masterKey, newMasterKey)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "rewrapped %d keys\n", count)
+			return err
+		},
+	}
+	cmd.Flags().String(flagNewMasterKey, "", "path to the new masterkey")
+	cmd.Flags().String(flagNewMasterKeyArg, "", "new masterkey as argument")
+	cmd.Flags().Bool(flagNewMasterKeyEnv, false, "read the new masterkey from environment variable ("+envNewMasterKey+")")
+	return cmd
+}
+
+// newMasterKeyFromFlags returns the new master key,
+// it must not be provided if a KMS is configured as the keys are wrapped by the KMS
+func newMasterKeyFromFlags(cmd *cobra.Command, hasKMS bool) (string, error) {
+	if !hasKMS {
+		return masterKeyFromFlags(cmd, flagNewMasterKey, flagNewMasterKeyArg, flagNewMasterKeyEnv, envNewMasterKey)
+	}
+	for _, flag := range []string{flagNewMasterKey, flagNewMasterKeyArg, flagNewMasterKeyEnv} {
+		if cmd.Flags().Changed(flag) {
+			return "", zerrors.ThrowInvalidArgument(nil, "KEY-Gt4kw", "the keys are rewrapped by the configured kms, no new masterkey must be provided")
+		}
+	}
+	return "", nil
+}
+
+func rotateMaster(ctx context.Context, config *Config, masterKey, newMasterKey string) (int, error) {
+	keyManagement, err := config.KMS.NewKMS()
+	if err != nil {
+		return 0, err
+	}
+	db, err := database.Connect(config.Database, false)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	current, err := cryptoDB.NewKeyStorage(ctx, db, masterKey, keyManagement)
+	if err != nil {
+		return 0, err
+	}
+	if newMasterKey == "" {
+		newMasterKey = masterKey
+	}
+	rotated, err := cryptoDB.NewKeyStorage(ctx, db, newMasterKey, keyManagement)
+	if err != nil {
+		return 0, err
+	}
+	return rotated.RewrapKeys(ctx, current)
+}
+
+func newWrapMaster() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wrap-master",
+		Short: "wraps the master key by the configured KMS",
+		Long: `wraps the master key by the configured KMS and prints the wrapped master key
+The wrapped master key can be provided instead of the plain master key to all commands,
+it is unwrapped by the configured KMS on startup.`,
+		Example: `wrap-master --masterkeyFromEnv --config kms.yaml > masterkey.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			masterKey, err := MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			keyManagement, err := config.KMS.NewKMS()
+			if err != nil {
+				return err
+			}
+			if keyManagement == nil {
+				return zerrors.ThrowPreconditionFailed(nil, "KEY-Pz8nd", "no kms configured")
+			}
+			if kms.IsWrapped(masterKey) {
+				return zerrors.ThrowPreconditionFailed(nil, "KEY-Rm1qa", "master key is already wrapped")
+			}
+			wrapped, err := kms.WrapString(cmd.Context(), keyManagement, masterKey)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), wrapped)
+			return err
+		},
+	}
+}
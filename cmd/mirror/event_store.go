@@ -6,6 +6,9 @@ import (
 	_ "embed"
 	"errors"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/stdlib"
@@ -21,7 +24,11 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-var shouldIgnorePrevious bool
+var (
+	shouldIgnorePrevious bool
+	isContinuous         bool
+	continuousInterval   time.Duration
+)
 
 func eventstoreCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -29,7 +36,11 @@ func eventstoreCmd() *cobra.Command {
 		Short: "mirrors the eventstore of an instance from one database to another",
 		Long: `mirrors the eventstore of an instance from one database to another
 ZITADEL needs to be initialized and set up with the --for-mirror flag
-Migrate only copies events2 and unique constraints`,
+Migrate only copies events2 and unique constraints
+
+In continuous mode new events of the source are mirrored in the given interval until the command is stopped (SIGINT or SIGTERM).
+To cut over, stop ZITADEL on the source, then stop the command.
+The remaining events and the unique constraints are mirrored before the command exits.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			config := mustNewMigrationConfig(viper.GetViper())
 			copyEventstore(cmd.Context(), config)
@@ -38,10 +49,16 @@ Migrate only copies events2 and unique constraints`,
 
 	cmd.Flags().BoolVar(&shouldReplace, "replace", false, "allow delete unique constraints of defined instances before copy")
 	cmd.Flags().BoolVar(&shouldIgnorePrevious, "ignore-previous", false, "ignores previous migrations of the events table")
+	continuousFlags(cmd)
 
 	return cmd
 }
 
+func continuousFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&isContinuous, "continuous", false, "mirrors new events until the command is stopped, the unique constraints are replaced afterwards")
+	cmd.Flags().DurationVar(&continuousInterval, "interval", 10*time.Second, "duration between the mirror iterations in continuous mode")
+}
+
 func copyEventstore(ctx context.Context, config *Migration) {
 	sourceClient, err := db.Connect(config.Source, false)
 	logging.OnError(err).Fatal("unable to connect to source database")
@@ -51,10 +68,52 @@ func copyEventstore(ctx context.Context, config *Migration) {
 	logging.OnError(err).Fatal("unable to connect to destination database")
 	defer destClient.Close()
 
-	copyEvents(ctx, sourceClient, destClient, config.EventBulkSize)
+	if isContinuous {
+		err = copyEventsContinuously(ctx, sourceClient, destClient, config.EventBulkSize)
+		logging.OnError(err).Fatal("unable to mirror remaining events, run the command again to copy them")
+		// unique constraints of the destination are outdated after the first iteration
+		shouldReplace = true
+	} else {
+		err = copyEvents(ctx, sourceClient, destClient, config.EventBulkSize)
+		logging.OnError(err).Fatal("unable to mirror events")
+	}
 	copyUniqueConstraints(ctx, sourceClient, destClient)
 }
 
+// continuousMaxBackoff is the maximum duration between the retries of failed iterations in continuous mode.
+const continuousMaxBackoff = 5 * time.Minute
+
+// copyEventsContinuously mirrors the events of the source in the configured interval
+// until the process receives SIGINT or SIGTERM.
+// Each iteration resumes from the last successful mirror, so only new events are copied.
+// A failed iteration is retried with an exponential backoff, starting at the interval.
+// After the signal a last iteration copies the events pushed until the cut-over.
+func copyEventsContinuously(ctx context.Context, source, dest *db.DB, bulkSize uint32) error {
+	stop, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	wait := continuousInterval
+	for {
+		if err := copyEvents(ctx, source, dest, bulkSize); err != nil {
+			wait = min(wait*2, max(continuousMaxBackoff, continuousInterval))
+			logging.WithFields("retry_in", wait).WithError(err).Warn("mirror iteration failed")
+		} else {
+			// only the first successful iteration is allowed to ignore previous migrations
+			shouldIgnorePrevious = false
+			wait = continuousInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop.Done():
+			timer.Stop()
+			logging.Info("mirror stopped, copy remaining events")
+			return copyEvents(ctx, source, dest, bulkSize)
+		case <-timer.C:
+		}
+	}
+}
+
 func positionQuery(db *db.DB) string {
 	switch db.Type() {
 	case "postgres":
@@ -67,18 +126,27 @@ func positionQuery(db *db.DB) string {
 	}
 }
 
-func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
+// copyEvents mirrors the events since the last successful migration.
+// Failed migrations are recorded and the error is returned, so the next call copies the events again.
+func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) error {
 	start := time.Now()
-	reader, writer := io.Pipe()
 
 	migrationID, err := id.SonyFlakeGenerator().Next()
-	logging.OnError(err).Fatal("unable to generate migration id")
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MIGRA-Hq3vd", "unable to generate migration id")
+	}
 
 	sourceConn, err := source.Conn(ctx)
-	logging.OnError(err).Fatal("unable to acquire source connection")
+	if err != nil {
+		return zerrors.ThrowUnknown(err, "MIGRA-Ws8ke", "unable to acquire source connection")
+	}
+	defer sourceConn.Close()
 
 	destConn, err := dest.Conn(ctx)
-	logging.OnError(err).Fatal("unable to acquire dest connection")
+	if err != nil {
+		return zerrors.ThrowUnknown(err, "MIGRA-Lp5zn", "unable to acquire dest connection")
+	}
+	defer destConn.Close()
 
 	sourceES := eventstore.NewEventstoreFromOne(postgres.New(source, &postgres.Config{
 		MaxRetries: 3,
@@ -88,13 +156,19 @@ func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
 	}))
 
 	previousMigration, err := queryLastSuccessfulMigration(ctx, destinationES, source.DatabaseName())
-	logging.OnError(err).Fatal("unable to query latest successful migration")
+	if err != nil {
+		return zerrors.ThrowUnknown(err, "MIGRA-Rc6tb", "unable to query latest successful migration")
+	}
 
 	maxPosition, err := writeMigrationStart(ctx, sourceES, migrationID, dest.DatabaseName())
-	logging.OnError(err).Fatal("unable to write migration started event")
+	if err != nil {
+		return zerrors.ThrowUnknown(err, "MIGRA-Gm2yf", "unable to write migration started event")
+	}
 
 	logging.WithFields("from", previousMigration.Position, "to", maxPosition).Info("start event migration")
 
+	reader, writer := io.Pipe()
+
 	nextPos := make(chan bool, 1)
 	pos := make(chan float64, 1)
 	errs := make(chan error, 3)
@@ -174,12 +248,15 @@ func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
 	})
 
 	close(errs)
-	writeCopyEventsDone(ctx, destinationES, migrationID, source.DatabaseName(), maxPosition, errs)
+	if err = writeCopyEventsDone(ctx, destinationES, migrationID, source.DatabaseName(), maxPosition, errs); err != nil {
+		return err
+	}
 
 	logging.WithFields("took", time.Since(start), "count", eventCount).Info("events migrated")
+	return nil
 }
 
-func writeCopyEventsDone(ctx context.Context, es *eventstore.EventStore, id, source string, position float64, errs <-chan error) {
+func writeCopyEventsDone(ctx context.Context, es *eventstore.EventStore, id, source string, position float64, errs <-chan error) error {
 	joinedErrs := make([]error, 0, len(errs))
 	for err := range errs {
 		joinedErrs = append(joinedErrs, err)
@@ -187,14 +264,17 @@ func writeCopyEventsDone(ctx context.Context, es *eventstore.EventStore, id, sou
 	err := errors.Join(joinedErrs...)
 
 	if err != nil {
-		logging.WithError(err).Error("unable to mirror events")
-		err := writeMigrationFailed(ctx, es, id, source, err)
-		logging.OnError(err).Fatal("unable to write failed event")
-		return
+		if failedErr := writeMigrationFailed(ctx, es, id, source, err); failedErr != nil {
+			return errors.Join(err, zerrors.ThrowUnknown(failedErr, "MIGRA-Nb4xs", "unable to write failed event"))
+		}
+		return err
 	}
 
 	err = writeMigrationSucceeded(ctx, es, id, source, position)
-	logging.OnError(err).Fatal("unable to write failed event")
+	if err != nil {
+		return zerrors.ThrowUnknown(err, "MIGRA-Tk9we", "unable to write succeeded event")
+	}
+	return nil
 }
 
 func copyUniqueConstraints(ctx context.Context, source, dest *db.DB) {
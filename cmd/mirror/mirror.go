@@ -67,6 +67,7 @@ Order of execution:
 * auth.auth_requests
 * eventstore.unique_constraints
 The flag should be provided if you want to execute the mirror command multiple times so that the static data are also mirrored to prevent inconsistent states.`)
+	continuousFlags(cmd)
 	migrateProjectionsFlags(cmd)
 
 	cmd.AddCommand(
@@ -91,10 +92,12 @@ func instanceClause() string {
 	if isSystem {
 		return "WHERE instance_id <> ''"
 	}
-	for i := range instanceIDs {
-		instanceIDs[i] = "'" + instanceIDs[i] + "'"
+	// the clause is built on every call, so the configured ids must not be modified
+	quoted := make([]string, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		quoted[i] = "'" + instanceID + "'"
 	}
 
 	// COPY does not allow parameters so we need to set them directly
-	return "WHERE instance_id IN (" + strings.Join(instanceIDs, ", ") + ")"
+	return "WHERE instance_id IN (" + strings.Join(quoted, ", ") + ")"
 }
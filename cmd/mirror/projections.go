@@ -19,6 +19,7 @@ import (
 	admin_view "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing/view"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
 	auth_handler "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/handler"
@@ -29,9 +30,11 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/pii"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 	"github.com/zitadel/zitadel/internal/i18n"
@@ -70,6 +73,7 @@ type ProjectionsConfig struct {
 	Projections    projection.Config
 	Notifications  handlers.WorkerConfig
 	EncryptionKeys *encryption.EncryptionKeyConfig
+	KMS            kms.Config
 	SystemAPIUsers map[string]*internal_authz.SystemAPIUser
 	Eventstore     *eventstore.Config
 	Caches         *connector.CachesConfig
@@ -88,6 +92,7 @@ type ProjectionsConfig struct {
 	Telemetry       *handlers.TelemetryPusherConfig
 	Login           login.Config
 	OIDC            oidc.Config
+	SAML            saml.Config
 	WebAuthNName    string
 	DefaultInstance command.InstanceSetup
 	AssetStorage    static_config.AssetStorageConfig
@@ -108,7 +113,10 @@ func projections(
 	client, err := database.Connect(config.Destination, false)
 	logging.OnError(err).Fatal("unable to connect to database")
 
-	keyStorage, err := crypto_db.NewKeyStorage(client, masterKey)
+	keyManagement, err := config.KMS.NewKMS()
+	logging.OnError(err).Fatal("unable to start kms")
+
+	keyStorage, err := crypto_db.NewKeyStorage(ctx, client, masterKey, keyManagement)
 	logging.OnError(err).Fatal("cannot start key storage")
 
 	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
@@ -119,6 +127,7 @@ func projections(
 
 	config.Eventstore.Querier = old_es.NewCRDB(client)
 	config.Eventstore.Pusher = new_es.NewEventstore(client)
+	config.Eventstore.PIIEncryption = pii.NewEncryption(keyStorage, config.Eventstore.PII.KeyCacheDuration, config.Eventstore.PII.KeyCacheSize)
 	es := eventstore.NewEventstore(config.Eventstore)
 	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, &es_v4_pg.Config{
 		MaxRetries: config.Eventstore.MaxRetries,
@@ -205,8 +214,10 @@ func projections(
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["backchannel"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notificationsciba"],
 		config.Notifications,
 		*config.Telemetry,
+		config.SAML.BackChannelLogout,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
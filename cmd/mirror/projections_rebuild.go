@@ -0,0 +1,118 @@
+package mirror
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/pii"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+// NewProjections returns the command to maintain the projections of a running system
+func NewProjections() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "maintains the projections of ZITADEL",
+	}
+
+	cmd.AddCommand(
+		rebuildProjectionCmd(),
+	)
+
+	return cmd
+}
+
+func rebuildProjectionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild [projection]",
+		Short: "rebuilds a projection without downtime",
+		Long: `rebuilds a projection without downtime
+
+The events are projected into shadow tables (e.g. projections.users14_shadow) in parallel to the running system.
+The shadow tracks its own position in projections.current_states.
+As soon as the shadow caught up with all instances, it atomically replaces the live tables
+and the running handlers continue from the position of the shadow.
+
+The projection is identified by its name, e.g. projections.users14.
+Projections based on views cannot be rebuilt.
+The swap fails if other objects (e.g. views or foreign keys of other tables) depend on the live tables.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := mustNewRebuildProjectionConfig(viper.GetViper())
+
+			masterKey, err := key.MasterKey(cmd)
+			logging.OnError(err).Fatal("unable to read master key")
+
+			rebuildProjection(cmd.Context(), config, masterKey, args[0])
+		},
+	}
+
+	key.AddMasterKeyFlag(cmd)
+
+	return cmd
+}
+
+type RebuildProjectionConfig struct {
+	Database       database.Config
+	Projections    projection.Config
+	Eventstore     *eventstore.Config
+	EncryptionKeys *encryption.EncryptionKeyConfig
+	KMS            kms.Config
+	SystemAPIUsers map[string]*internal_authz.SystemAPIUser
+
+	Log *logging.Config
+}
+
+func mustNewRebuildProjectionConfig(v *viper.Viper) *RebuildProjectionConfig {
+	config := new(RebuildProjectionConfig)
+	mustNewConfig(v, config)
+
+	err := config.Log.SetLogger()
+	logging.OnError(err).Fatal("unable to set logger")
+
+	return config
+}
+
+func rebuildProjection(ctx context.Context, config *RebuildProjectionConfig, masterKey, name string) {
+	start := time.Now()
+
+	client, err := database.Connect(config.Database, false)
+	logging.OnError(err).Fatal("unable to connect to database")
+
+	keyManagement, err := config.KMS.NewKMS()
+	logging.OnError(err).Fatal("unable to start kms")
+
+	keyStorage, err := crypto_db.NewKeyStorage(ctx, client, masterKey, keyManagement)
+	logging.OnError(err).Fatal("cannot start key storage")
+
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to read encryption keys")
+
+	config.Eventstore.Querier = old_es.NewCRDB(client)
+	esV3 := new_es.NewEventstore(client)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.Searcher = esV3
+	config.Eventstore.PIIEncryption = pii.NewEncryption(keyStorage, config.Eventstore.PII.KeyCacheDuration, config.Eventstore.PII.KeyCacheSize)
+	es := eventstore.NewEventstore(config.Eventstore)
+
+	err = projection.Create(ctx, client, es, config.Projections, keys.OIDC, keys.SAML, config.SystemAPIUsers)
+	logging.OnError(err).Fatal("unable to create projections")
+
+	err = projection.Rebuild(ctx, name)
+	logging.WithFields("projection", name).OnError(err).Fatal("unable to rebuild projection")
+
+	logging.WithFields("projection", name, "took", time.Since(start)).Info("projection rebuilt")
+}
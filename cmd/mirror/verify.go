@@ -2,10 +2,17 @@ package mirror
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	_ "embed"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,15 +23,30 @@ import (
 	"github.com/zitadel/zitadel/internal/query/projection"
 )
 
+var (
+	shouldVerifyAggregates  bool
+	shouldVerifyProjections bool
+)
+
 func verifyCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "verify",
 		Short: "counts if source and dest have the same amount of entries",
+		Long: `counts if source and dest have the same amount of entries
+
+The flags enable additional checks:
+--aggregates compares the latest sequence of each aggregate in the eventstore
+--projections compares the hashes of the rows of each projection, columns containing positions are ignored`,
 		Run: func(cmd *cobra.Command, args []string) {
 			config := mustNewMigrationConfig(viper.GetViper())
 			verifyMigration(cmd.Context(), config)
 		},
 	}
+
+	cmd.Flags().BoolVar(&shouldVerifyAggregates, "aggregates", false, "compares the latest sequence of each aggregate")
+	cmd.Flags().BoolVar(&shouldVerifyProjections, "projections", false, "compares the hashes of the rows of each projection")
+
+	return cmd
 }
 
 var schemas = []string{
@@ -57,6 +79,216 @@ func verifyMigration(ctx context.Context, config *Migration) {
 			entry.WithField("diff", destCount-sourceCount).Info("unequal count")
 		}
 	}
+
+	if shouldVerifyAggregates {
+		verifyAggregateSequences(ctx, sourceClient, destClient)
+	}
+	if shouldVerifyProjections {
+		verifyProjectionHashes(ctx, sourceClient, destClient)
+	}
+}
+
+type aggregateKey struct {
+	instanceID    string
+	aggregateType string
+	aggregateID   string
+}
+
+func verifyAggregateSequences(ctx context.Context, source, dest *database.DB) {
+	start := time.Now()
+	stmt := "SELECT instance_id, aggregate_type, aggregate_id, MAX(sequence) FROM eventstore.events2 " + instanceClause() + " GROUP BY instance_id, aggregate_type, aggregate_id"
+
+	sequences := make(map[aggregateKey]uint64)
+	err := source.QueryContext(
+		ctx,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var (
+					key      aggregateKey
+					sequence uint64
+				)
+				if err := r.Scan(&key.instanceID, &key.aggregateType, &key.aggregateID, &sequence); err != nil {
+					return err
+				}
+				sequences[key] = sequence
+			}
+			return r.Err()
+		},
+		stmt,
+	)
+	logging.OnError(err).Fatal("unable to query sequences of source")
+
+	var unequal int
+	err = dest.QueryContext(
+		ctx,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var (
+					key      aggregateKey
+					sequence uint64
+				)
+				if err := r.Scan(&key.instanceID, &key.aggregateType, &key.aggregateID, &sequence); err != nil {
+					return err
+				}
+				sourceSequence := sequences[key]
+				delete(sequences, key)
+				if sourceSequence == sequence {
+					continue
+				}
+				unequal++
+				logging.WithFields("instance", key.instanceID, "aggregate_type", key.aggregateType, "aggregate_id", key.aggregateID, "source", sourceSequence, "dest", sequence).Info("unequal sequence")
+			}
+			return r.Err()
+		},
+		stmt,
+	)
+	logging.OnError(err).Fatal("unable to query sequences of destination")
+
+	// the remaining aggregates are missing in the destination
+	for key, sequence := range sequences {
+		unequal++
+		logging.WithFields("instance", key.instanceID, "aggregate_type", key.aggregateType, "aggregate_id", key.aggregateID, "source", sequence, "dest", 0).Info("unequal sequence")
+	}
+
+	logging.WithFields("took", time.Since(start), "unequal", unequal).Info("aggregate sequences verified")
+}
+
+// unhashedTables contain the state of the projection handlers
+// which differs between source and destination by design
+var unhashedTables = []string{
+	projection.CurrentStateTable,
+	projection.LocksTable,
+	projection.FailedEventsTable,
+}
+
+// volatileColumns are ignored when hashing the rows of projections,
+// positions are reassigned when events are mirrored
+var volatileColumns = []string{
+	"position",
+	"last_updated",
+	"filter_offset",
+}
+
+func verifyProjectionHashes(ctx context.Context, source, dest *database.DB) {
+	for _, table := range getTables(ctx, dest, "projections") {
+		if slices.Contains(unhashedTables, table) {
+			continue
+		}
+		sourceHash := hashRows(ctx, source, table)
+		destHash := hashRows(ctx, dest, table)
+
+		entry := logging.WithFields("table", table, "dest", destHash, "source", sourceHash)
+		if sourceHash == destHash {
+			entry.Debug("equal hash")
+			continue
+		}
+		entry.Info("unequal hash")
+	}
+}
+
+// hashRows returns a hash of all rows of the table.
+// The hashes of the rows are summed up, so the result is independent of the order of rows and columns.
+func hashRows(ctx context.Context, client *database.DB, table string) string {
+	var sum [2]uint64
+	err := client.QueryContext(
+		ctx,
+		func(r *sql.Rows) error {
+			columns, err := r.Columns()
+			if err != nil {
+				return err
+			}
+			values := make([]any, len(columns))
+			pointers := make([]any, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			for r.Next() {
+				if err := r.Scan(pointers...); err != nil {
+					return err
+				}
+				hash := sha256.New()
+				for i, column := range columns {
+					if slices.Contains(volatileColumns, column) {
+						continue
+					}
+					fmt.Fprintf(hash, "%s=%s;", column, hashValue(values[i]))
+				}
+				rowHash := hash.Sum(nil)
+				sum[0] += binary.BigEndian.Uint64(rowHash[:8])
+				sum[1] += binary.BigEndian.Uint64(rowHash[8:16])
+			}
+			return r.Err()
+		},
+		fmt.Sprintf("SELECT * FROM %s %s", table, tableInstanceClause(table)),
+	)
+	logging.WithFields("table", table, "db", client.DatabaseName()).OnError(err).Error("unable to hash rows")
+
+	return fmt.Sprintf("%016x%016x", sum[0], sum[1])
+}
+
+// hashValue formats the values the same way for postgres and cockroach
+func hashValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		return v.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+	case int64:
+		return hashText(strconv.FormatInt(v, 10))
+	case int32:
+		return hashText(strconv.FormatInt(int64(v), 10))
+	case float64:
+		return hashText(strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		return hashText(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case []byte:
+		return hashText(string(v))
+	case string:
+		return hashText(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// hashText normalizes the text representations which differ between postgres and cockroach:
+// decimals are scanned as text with the scale of the database (e.g. 1.50 and 1.5)
+// and the keys and whitespace of json differ.
+func hashText(text string) string {
+	if number, ok := new(big.Rat).SetString(text); ok {
+		return number.RatString()
+	}
+	if !strings.HasPrefix(text, "{") && !strings.HasPrefix(text, "[") {
+		return text
+	}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var object any
+	if err := decoder.Decode(&object); err != nil || decoder.More() {
+		return text
+	}
+	normalized, err := json.Marshal(normalizeJSONNumbers(object))
+	if err != nil {
+		return text
+	}
+	return string(normalized)
+}
+
+// normalizeJSONNumbers replaces the numbers of the json with their normalized text,
+// the keys of objects are sorted by [json.Marshal].
+func normalizeJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return hashText(v.String())
+	case map[string]any:
+		for key, field := range v {
+			v[key] = normalizeJSONNumbers(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJSONNumbers(item)
+		}
+	}
+	return value
 }
 
 func getTables(ctx context.Context, dest *database.DB, schema string) (tables []string) {
@@ -99,23 +331,26 @@ func getViews(ctx context.Context, dest *database.DB, schema string) (tables []s
 	return tables
 }
 
-func countEntries(ctx context.Context, client *database.DB, table string) (count int) {
-	instanceClause := instanceClause()
+// tableInstanceClause returns the instance clause if the table contains the instance_id column
+func tableInstanceClause(table string) string {
 	noInstanceIDColumn := []string{
 		projection.InstanceProjectionTable,
 		projection.SystemFeatureTable,
 		cryptoDatabase.EncryptionKeysTable,
 	}
 	if slices.Contains(noInstanceIDColumn, table) {
-		instanceClause = ""
+		return ""
 	}
+	return instanceClause()
+}
 
+func countEntries(ctx context.Context, client *database.DB, table string) (count int) {
 	err := client.QueryRowContext(
 		ctx,
 		func(r *sql.Row) error {
 			return r.Scan(&count)
 		},
-		fmt.Sprintf("SELECT COUNT(*) FROM %s %s", table, instanceClause),
+		fmt.Sprintf("SELECT COUNT(*) FROM %s %s", table, tableInstanceClause(table)),
 	)
 	logging.WithFields("table", table, "db", client.DatabaseName()).OnError(err).Error("unable to count")
 
@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -34,6 +35,7 @@ type FirstInstance struct {
 	smtpEncryptionKey *crypto.KeyConfig
 	oidcEncryptionKey *crypto.KeyConfig
 	masterKey         string
+	kms               kms.KMS
 	db                *database.DB
 	es                *eventstore.Eventstore
 	defaults          systemdefaults.SystemDefaults
@@ -134,7 +136,7 @@ func (mig *FirstInstance) Execute(ctx context.Context, _ eventstore.Event) error
 }
 
 func (mig *FirstInstance) verifyEncryptionKeys(ctx context.Context) (*crypto_db.Database, error) {
-	keyStorage, err := crypto_db.NewKeyStorage(mig.db, mig.masterKey)
+	keyStorage, err := crypto_db.NewKeyStorage(ctx, mig.db, mig.masterKey, mig.kms)
 	if err != nil {
 		return nil, fmt.Errorf("cannot start key storage: %w", err)
 	}
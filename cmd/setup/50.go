@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 50.sql
+	addOIDCAppRequirePAR string
+)
+
+type Apps7OIDCConfigsRequirePAR struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsRequirePAR) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOIDCAppRequirePAR)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsRequirePAR) String() string {
+	return "50_apps7_oidc_configs_require_par"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 52.sql
+	addOIDCAppCIBANotificationURI string
+)
+
+type Apps7OIDCConfigsCIBANotificationURI struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsCIBANotificationURI) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOIDCAppCIBANotificationURI)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsCIBANotificationURI) String() string {
+	return "52_apps7_oidc_configs_ciba_notification_uri"
+}
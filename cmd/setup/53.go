@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 53.sql
+	addProjectAuthorizationDetailsTypes string
+)
+
+type Projects4AuthorizationDetailsTypes struct {
+	dbClient *database.DB
+}
+
+func (mig *Projects4AuthorizationDetailsTypes) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addProjectAuthorizationDetailsTypes)
+	return err
+}
+
+func (mig *Projects4AuthorizationDetailsTypes) String() string {
+	return "53_projects4_authorization_details_types"
+}
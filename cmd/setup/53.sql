@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS projections.projects4 ADD COLUMN IF NOT EXISTS authorization_details_types TEXT[];
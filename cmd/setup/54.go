@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 54.sql
+	createArchivedAggregates string
+)
+
+type CreateArchivedAggregates struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateArchivedAggregates) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createArchivedAggregates)
+	return err
+}
+
+func (mig *CreateArchivedAggregates) String() string {
+	return "54_create_archived_aggregates"
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS eventstore.archived_aggregates (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , object_name TEXT NOT NULL
+    , event_count INT8 NOT NULL
+    , "position" DECIMAL NOT NULL
+    , archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id)
+);
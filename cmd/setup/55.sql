@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , name TEXT NOT NULL
+    , revision INT2 NOT NULL
+    , "sequence" INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , payload JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id, name)
+);
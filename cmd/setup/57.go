@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 57.sql
+	addIDPSAMLKeys string
+)
+
+type IDPTemplate6SAMLKeys struct {
+	dbClient *database.DB
+}
+
+func (mig *IDPTemplate6SAMLKeys) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addIDPSAMLKeys)
+	return err
+}
+
+func (mig *IDPTemplate6SAMLKeys) String() string {
+	return "57_idp_templates6_saml_add_metadata_url_and_additional_keys"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 58.sql
+	addIDPLDAPGroupMapping string
+)
+
+type IDPTemplate6LDAPGroupMapping struct {
+	dbClient *database.DB
+}
+
+func (mig *IDPTemplate6LDAPGroupMapping) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addIDPLDAPGroupMapping)
+	return err
+}
+
+func (mig *IDPTemplate6LDAPGroupMapping) String() string {
+	return "58_idp_templates6_ldap3_add_group_mapping_and_directory_sync"
+}
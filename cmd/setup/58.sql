@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.idp_templates6_ldap3 ADD COLUMN IF NOT EXISTS group_mapping JSONB;
+ALTER TABLE IF EXISTS projections.idp_templates6_ldap3 ADD COLUMN IF NOT EXISTS directory_sync BOOLEAN DEFAULT FALSE;
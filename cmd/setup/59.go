@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 59.sql
+	addIDPKerberos string
+)
+
+type IDPTemplate6Kerberos struct {
+	dbClient *database.DB
+}
+
+func (mig *IDPTemplate6Kerberos) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addIDPKerberos)
+	return err
+}
+
+func (mig *IDPTemplate6Kerberos) String() string {
+	return "59_idp_templates6_kerberos"
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_kerberos (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , service_principal TEXT
+    , keytab JSONB NOT NULL
+
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_kerberos_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
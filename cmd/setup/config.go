@@ -14,11 +14,13 @@ import (
 	"github.com/zitadel/zitadel/internal/actions"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -39,6 +41,7 @@ type Config struct {
 	ExternalSecure  bool
 	Log             *logging.Config
 	EncryptionKeys  *encryption.EncryptionKeyConfig
+	KMS             kms.Config
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
@@ -48,6 +51,7 @@ type Config struct {
 	InitProjections InitProjections
 	AssetStorage    static_config.AssetStorageConfig
 	OIDC            oidc.Config
+	SAML            saml.Config
 	Login           login.Config
 	WebAuthNName    string
 	Telemetry       *handlers.TelemetryPusherConfig
@@ -138,6 +142,16 @@ type Steps struct {
 	s47FillMembershipFields                 *FillMembershipFields
 	s48Apps7SAMLConfigsLoginVersion         *Apps7SAMLConfigsLoginVersion
 	s49InitPermittedOrgsFunction            *InitPermittedOrgsFunction
+	s50Apps7OIDCConfigsRequirePAR           *Apps7OIDCConfigsRequirePAR
+	s51Apps7OIDCConfigsRequireDPoP          *Apps7OIDCConfigsRequireDPoP
+	s52Apps7OIDCConfigsCIBANotificationURI  *Apps7OIDCConfigsCIBANotificationURI
+	s53Projects4AuthorizationDetailsTypes   *Projects4AuthorizationDetailsTypes
+	s54CreateArchivedAggregates             *CreateArchivedAggregates
+	s55CreateSnapshots                      *CreateSnapshots
+	s56IDPTemplate6ClaimMapping             *IDPTemplate6ClaimMapping
+	s57IDPTemplate6SAMLKeys                 *IDPTemplate6SAMLKeys
+	s58IDPTemplate6LDAPGroupMapping         *IDPTemplate6LDAPGroupMapping
+	s59IDPTemplate6Kerberos                 *IDPTemplate6Kerberos
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
@@ -27,9 +27,11 @@ import (
 	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/pii"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 	"github.com/zitadel/zitadel/internal/i18n"
@@ -106,10 +108,17 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	dbClient, err := database.Connect(config.Database, false)
 	logging.OnError(err).Fatal("unable to connect to database")
 
+	keyManagement, err := config.KMS.NewKMS()
+	logging.OnError(err).Fatal("unable to start kms")
+
+	keyStorage, err := cryptoDB.NewKeyStorage(ctx, dbClient, masterKey, keyManagement)
+	logging.OnError(err).Fatal("unable to start key storage")
+
 	config.Eventstore.Querier = old_es.NewCRDB(dbClient)
 	esV3 := new_es.NewEventstore(dbClient)
 	config.Eventstore.Pusher = esV3
 	config.Eventstore.Searcher = esV3
+	config.Eventstore.PIIEncryption = pii.NewEncryption(keyStorage, config.Eventstore.PII.KeyCacheDuration, config.Eventstore.PII.KeyCacheSize)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 
 	logging.OnError(err).Fatal("unable to start eventstore")
@@ -126,6 +135,7 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.FirstInstance.smtpEncryptionKey = config.EncryptionKeys.SMTP
 	steps.FirstInstance.oidcEncryptionKey = config.EncryptionKeys.OIDC
 	steps.FirstInstance.masterKey = masterKey
+	steps.FirstInstance.kms = keyManagement
 	steps.FirstInstance.db = dbClient
 	steps.FirstInstance.es = eventstoreClient
 	steps.FirstInstance.defaults = config.SystemDefaults
@@ -175,6 +185,16 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.s47FillMembershipFields = &FillMembershipFields{eventstore: eventstoreClient}
 	steps.s48Apps7SAMLConfigsLoginVersion = &Apps7SAMLConfigsLoginVersion{dbClient: dbClient}
 	steps.s49InitPermittedOrgsFunction = &InitPermittedOrgsFunction{eventstoreClient: dbClient}
+	steps.s50Apps7OIDCConfigsRequirePAR = &Apps7OIDCConfigsRequirePAR{dbClient: dbClient}
+	steps.s51Apps7OIDCConfigsRequireDPoP = &Apps7OIDCConfigsRequireDPoP{dbClient: dbClient}
+	steps.s52Apps7OIDCConfigsCIBANotificationURI = &Apps7OIDCConfigsCIBANotificationURI{dbClient: dbClient}
+	steps.s53Projects4AuthorizationDetailsTypes = &Projects4AuthorizationDetailsTypes{dbClient: dbClient}
+	steps.s54CreateArchivedAggregates = &CreateArchivedAggregates{dbClient: dbClient}
+	steps.s55CreateSnapshots = &CreateSnapshots{dbClient: dbClient}
+	steps.s56IDPTemplate6ClaimMapping = &IDPTemplate6ClaimMapping{dbClient: dbClient}
+	steps.s57IDPTemplate6SAMLKeys = &IDPTemplate6SAMLKeys{dbClient: dbClient}
+	steps.s58IDPTemplate6LDAPGroupMapping = &IDPTemplate6LDAPGroupMapping{dbClient: dbClient}
+	steps.s59IDPTemplate6Kerberos = &IDPTemplate6Kerberos{dbClient: dbClient}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -260,6 +280,16 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s42Apps7OIDCConfigsLoginVersion,
 		steps.s43CreateFieldsDomainIndex,
 		steps.s48Apps7SAMLConfigsLoginVersion,
+		steps.s50Apps7OIDCConfigsRequirePAR,
+		steps.s51Apps7OIDCConfigsRequireDPoP,
+		steps.s52Apps7OIDCConfigsCIBANotificationURI,
+		steps.s53Projects4AuthorizationDetailsTypes,
+		steps.s54CreateArchivedAggregates,
+		steps.s55CreateSnapshots,
+		steps.s56IDPTemplate6ClaimMapping,
+		steps.s57IDPTemplate6SAMLKeys,
+		steps.s58IDPTemplate6LDAPGroupMapping,
+		steps.s59IDPTemplate6Kerberos,
 	} {
 		mustExecuteMigration(ctx, eventstoreClient, step, "migration failed")
 	}
@@ -273,6 +303,7 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 			dbClient,
 			dbClient,
 			masterKey,
+			keyManagement,
 			config,
 		)
 	}
@@ -341,11 +372,12 @@ func initProjections(
 	queryDBClient,
 	projectionDBClient *database.DB,
 	masterKey string,
+	keyManagement kms.KMS,
 	config *Config,
 ) {
 	logging.Info("init-projections is currently in beta")
 
-	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	keyStorage, err := cryptoDB.NewKeyStorage(ctx, queryDBClient, masterKey, keyManagement)
 	logging.OnError(err).Fatal("unable to start key storage")
 
 	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
@@ -481,8 +513,10 @@ func initProjections(
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["backchannel"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notificationsciba"],
 		config.Notifications,
 		*config.Telemetry,
+		config.SAML.BackChannelLogout,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
@@ -64,6 +64,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/robots_txt"
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/scim"
+	scim_provisioning "github.com/zitadel/zitadel/internal/api/scim/provisioning"
+	scim_resources "github.com/zitadel/zitadel/internal/api/scim/resources"
 	"github.com/zitadel/zitadel/internal/api/scim/schemas"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/console/path"
@@ -79,10 +81,14 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/archive"
+	"github.com/zitadel/zitadel/internal/eventstore/pii"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	target_execution "github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
+	idp_jobs "github.com/zitadel/zitadel/internal/idp/jobs"
 	"github.com/zitadel/zitadel/internal/integration/sink"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
@@ -92,6 +98,8 @@ import (
 	"github.com/zitadel/zitadel/internal/net"
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/queue"
 	"github.com/zitadel/zitadel/internal/static"
 	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
 	es_v4_pg "github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
@@ -150,7 +158,11 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		return fmt.Errorf("cannot start DB client for queries: %w", err)
 	}
 
-	keyStorage, err := cryptoDB.NewKeyStorage(dbClient, masterKey)
+	keyManagement, err := config.KMS.NewKMS()
+	if err != nil {
+		return fmt.Errorf("cannot start kms: %w", err)
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(ctx, dbClient, masterKey, keyManagement)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
@@ -161,7 +173,17 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 
 	config.Eventstore.Pusher = new_es.NewEventstore(dbClient)
 	config.Eventstore.Searcher = new_es.NewEventstore(dbClient)
+	config.Eventstore.SnapshotStore = new_es.NewEventstore(dbClient)
 	config.Eventstore.Querier = old_es.NewCRDB(dbClient)
+	config.Eventstore.PIIEncryption = pii.NewEncryption(keyStorage, config.Eventstore.PII.KeyCacheDuration, config.Eventstore.PII.KeyCacheSize)
+	var archiveStorage archive.Storage
+	if config.EventArchive.Enabled {
+		archiveStorage, err = config.EventArchive.Storage.NewStorage()
+		if err != nil {
+			return fmt.Errorf("cannot start event archive: %w", err)
+		}
+		config.Eventstore.Querier = archive.NewQuerier(config.Eventstore.Querier, dbClient, archiveStorage)
+	}
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(dbClient, &es_v4_pg.Config{
 		MaxRetries: config.Eventstore.MaxRetries,
@@ -267,14 +289,68 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
+	scimProvisioningUsers := scim_resources.NewUserProvisioningMapper(queries, &config.SCIM)
+
+	// the queue is only supported on postgres, without queue the targets are called without retries
+	var executionQueue *queue.Queue
+	if dbClient.Type() == "postgres" {
+		executionQueue = queue.NewWithConfig(dbClient, config.Queue)
+		executionQueue.AddWorkers(target_execution.NewWorker(*config.Executions, queries))
+		executionQueue.AddWorkers(scim_provisioning.NewWorker(&config.SCIM.Provisioning, commands, queries, scimProvisioningUsers, eventstoreClient))
+		if config.IDPJobs.SAMLMetadataRefreshInterval > 0 {
+			executionQueue.AddWorkers(idp_jobs.NewSAMLMetadataWorker(queries, commands))
+			executionQueue.AddPeriodicJob(config.IDPJobs.SAMLMetadataRefreshInterval, new(idp_jobs.SAMLMetadataRefresh))
+		}
+		if config.IDPJobs.LDAPDirectorySyncInterval > 0 {
+			executionQueue.AddWorkers(idp_jobs.NewLDAPDirectoryWorker(queries, commands))
+			executionQueue.AddPeriodicJob(config.IDPJobs.LDAPDirectorySyncInterval, new(idp_jobs.LDAPDirectorySync))
+		}
+		if config.EventArchive.Enabled {
+			executionQueue.AddWorkers(archive.NewWorker(archive.NewArchiver(dbClient, archiveStorage, config.EventArchive)))
+			executionQueue.AddPeriodicJob(config.EventArchive.Interval, new(archive.ArchiveEvents))
+		}
+	} else if config.EventArchive.Enabled {
+		logging.Warn("events are only archived on postgres, archived events are still replayed")
+	}
+	if err = executionQueue.Start(ctx); err != nil {
+		return fmt.Errorf("cannot start queue: %w", err)
+	}
+	defer func() {
+		logging.OnError(executionQueue.Stop(ctx)).Error("unable to stop queue")
+	}()
+
+	// a nil queue must not be passed as non-nil interface
+	var scimProvisioningQueue scim_provisioning.Queue
+	if executionQueue != nil {
+		scimProvisioningQueue = executionQueue
+	}
+	target_execution.NewEventExecutions(
+		projection.ApplyCustomConfig(config.Projections.Customizations["eventexecutions"]),
+		queries,
+		eventstoreClient.EventTypes(),
+	).Start(ctx)
+
+	scim_provisioning.NewProvisioner(
+		ctx,
+		projection.ApplyCustomConfig(config.Projections.Customizations["scimprovisioning"]),
+		&config.SCIM.Provisioning,
+		commands,
+		queries,
+		scimProvisioningUsers,
+		eventstoreClient,
+		scimProvisioningQueue,
+	).Start(ctx)
+
 	notification.Register(
 		ctx,
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["backchannel"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notificationsciba"],
 		config.Notifications,
 		*config.Telemetry,
+		config.SAML.BackChannelLogout,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
@@ -311,6 +387,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys,
 		permissionCheck,
 		cacheConnectors,
+		executionQueue,
 	)
 	if err != nil {
 		return err
@@ -356,6 +433,7 @@ func startAPIs(
 	keys *encryption.EncryptionKeys,
 	permissionCheck domain.PermissionCheck,
 	cacheConnectors connector.Connectors,
+	executionQueue *queue.Queue,
 ) (*api.API, error) {
 	repo := struct {
 		authz_repo.Repository
@@ -394,7 +472,15 @@ func startAPIs(
 		http_util.WithMaxAge(int(math.Floor(config.Quotas.Access.ExhaustedCookieMaxAge.Seconds()))),
 	)
 	limitingAccessInterceptor := middleware.NewAccessInterceptor(accessSvc, exhaustedCookieHandler, &config.Quotas.Access.AccessConfig)
-	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.ExternalDomain, append(config.InstanceHostHeaders, config.PublicHostHeaders...), limitingAccessInterceptor)
+	// a nil queue must not be passed as non-nil interface
+	var (
+		targetQueue     target_execution.Queue
+		failedJobsQueue target_execution.JobQueue
+	)
+	if executionQueue != nil {
+		targetQueue, failedJobsQueue = executionQueue, executionQueue
+	}
+	apis, err := api.New(ctx, config.Port, router, queries, verifier, config.InternalAuthZ, tlsConfig, config.ExternalDomain, append(config.InstanceHostHeaders, config.PublicHostHeaders...), limitingAccessInterceptor, targetQueue)
 	if err != nil {
 		return nil, fmt.Errorf("error creating api %w", err)
 	}
@@ -460,7 +546,7 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, idp_v2.CreateServer(commands, queries, permissionCheck)); err != nil {
 		return nil, err
 	}
-	if err := apis.RegisterService(ctx, action_v3_alpha.CreateServer(config.SystemDefaults, commands, queries, domain.AllFunctions, apis.ListGrpcMethods, apis.ListGrpcServices)); err != nil {
+	if err := apis.RegisterService(ctx, action_v3_alpha.CreateServer(config.SystemDefaults, commands, queries, failedJobsQueue, domain.AllFunctions, apis.ListGrpcMethods, apis.ListGrpcServices)); err != nil {
 		return nil, err
 	}
 	if err := apis.RegisterService(ctx, userschema_v3_alpha.CreateServer(config.SystemDefaults, commands, queries)); err != nil {
@@ -500,7 +586,7 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(openapi.HandlerPrefix, openAPIHandler)
 
-	oidcServer, err := oidc.NewServer(ctx, config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor, config.Log.Slog(), config.SystemDefaults.SecretHasher)
+	oidcServer, err := oidc.NewServer(ctx, config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor, config.Log.Slog(), config.SystemDefaults.SecretHasher, cacheConnectors)
 	if err != nil {
 		return nil, fmt.Errorf("unable to start oidc provider: %w", err)
 	}
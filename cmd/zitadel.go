@@ -57,6 +57,7 @@ func New(out io.Writer, in io.Reader, args []string, server chan<- *start.Server
 		start.NewStartFromInit(server),
 		start.NewStartFromSetup(server),
 		mirror.New(&configFiles),
+		mirror.NewProjections(),
 		key.New(),
 		ready.New(),
 	)
@@ -98,6 +98,10 @@ func checkPermissionContext(req interface{}, fieldName, roleContextID string) bo
 }
 
 func getFieldFromReq(req interface{}, field string) string {
+	// requests of streaming calls are not available during authorization
+	if req == nil {
+		return ""
+	}
 	v := reflect.Indirect(reflect.ValueOf(req)).FieldByName(field)
 	if reflect.ValueOf(v).IsZero() {
 		return ""
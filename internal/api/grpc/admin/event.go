@@ -6,12 +6,17 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	event_grpc "github.com/zitadel/zitadel/internal/api/grpc/event"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
 const (
 	maxLimit = 1000
+
+	subscriptionBatchSize    = 100
+	subscriptionPollInterval = time.Second
 )
 
 func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest) (*admin_pb.ListEventsResponse, error) {
@@ -27,6 +32,22 @@ func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest)
 	return admin_pb.EventsToPb(ctx, events)
 }
 
+func (s *Server) SubscribeEvents(req *admin_pb.SubscribeEventsRequest, stream admin_pb.AdminService_SubscribeEventsServer) error {
+	return s.query.SubscribeEvents(stream.Context(), subscribeEventsRequestToSubscription(req), func(event *query.Event, cursor query.EventCursor) error {
+		pb, err := event_grpc.EventToPb(event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&admin_pb.SubscribeEventsResponse{
+			Event: pb,
+			Cursor: &admin_pb.EventCursor{
+				Position: cursor.Position,
+				Offset:   cursor.Offset,
+			},
+		})
+	})
+}
+
 func (s *Server) ListEventTypes(ctx context.Context, in *admin_pb.ListEventTypesRequest) (*admin_pb.ListEventTypesResponse, error) {
 	eventTypes := s.query.SearchEventTypes(ctx)
 	return admin_pb.EventTypesToPb(eventTypes), nil
@@ -109,6 +130,32 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 	return builder, nil
 }
 
+func subscribeEventsRequestToSubscription(req *admin_pb.SubscribeEventsRequest) *query.EventSubscription {
+	eventTypes := make([]eventstore.EventType, len(req.GetEventTypes()))
+	for i, eventType := range req.GetEventTypes() {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(req.GetAggregateTypes()))
+	for i, aggregateType := range req.GetAggregateTypes() {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = aggregateTypesFromEventTypes(eventTypes)
+	}
+	slices.Sort(aggregateTypes)
+	return &query.EventSubscription{
+		AggregateTypes: slices.Compact(aggregateTypes),
+		AggregateID:    req.GetAggregateId(),
+		EventTypes:     eventTypes,
+		Cursor: query.EventCursor{
+			Position: req.GetCursor().GetPosition(),
+			Offset:   req.GetCursor().GetOffset(),
+		},
+		BatchSize:    subscriptionBatchSize,
+		PollInterval: subscriptionPollInterval,
+	}
+}
+
 func aggregateTypesFromEventTypes(eventTypes []eventstore.EventType) []eventstore.AggregateType {
 	aggregateTypes := make([]eventstore.AggregateType, 0, len(eventTypes))
 
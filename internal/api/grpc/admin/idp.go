@@ -426,6 +426,27 @@ func (s *Server) UpdateAppleProvider(ctx context.Context, req *admin_pb.UpdateAp
 	}, nil
 }
 
+func (s *Server) AddKerberosProvider(ctx context.Context, req *admin_pb.AddKerberosProviderRequest) (*admin_pb.AddKerberosProviderResponse, error) {
+	id, details, err := s.command.AddInstanceKerberosProvider(ctx, addKerberosProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddKerberosProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateKerberosProvider(ctx context.Context, req *admin_pb.UpdateKerberosProviderRequest) (*admin_pb.UpdateKerberosProviderResponse, error) {
+	details, err := s.command.UpdateInstanceKerberosProvider(ctx, req.Id, updateKerberosProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateKerberosProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddSAMLProvider(ctx context.Context, req *admin_pb.AddSAMLProviderRequest) (*admin_pb.AddSAMLProviderResponse, error) {
 	id, details, err := s.command.AddInstanceSAMLProvider(ctx, addSAMLProviderToCommand(req))
 	if err != nil {
@@ -457,6 +478,47 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *adm
 	}, nil
 }
 
+func (s *Server) AddSAMLProviderKey(ctx context.Context, req *admin_pb.AddSAMLProviderKeyRequest) (*admin_pb.AddSAMLProviderKeyResponse, error) {
+	keyID, details, err := s.command.AddInstanceSAMLProviderKey(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+		KeyId:   keyID,
+	}, nil
+}
+
+func (s *Server) ActivateSAMLProviderKey(ctx context.Context, req *admin_pb.ActivateSAMLProviderKeyRequest) (*admin_pb.ActivateSAMLProviderKeyResponse, error) {
+	details, err := s.command.ActivateInstanceSAMLProviderKey(ctx, req.Id, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ActivateSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveSAMLProviderKey(ctx context.Context, req *admin_pb.RemoveSAMLProviderKeyRequest) (*admin_pb.RemoveSAMLProviderKeyResponse, error) {
+	details, err := s.command.RemoveInstanceSAMLProviderKey(ctx, req.Id, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RefreshSAMLProviderMetadata(ctx context.Context, req *admin_pb.RefreshSAMLProviderMetadataRequest) (*admin_pb.RefreshSAMLProviderMetadataResponse, error) {
+	details, err := s.command.RefreshInstanceSAMLProviderMetadata(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RefreshSAMLProviderMetadataResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProviderRequest) (*admin_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteInstanceProvider(ctx, req.Id)
 	if err != nil {
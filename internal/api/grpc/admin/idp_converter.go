@@ -215,6 +215,7 @@ func addGenericOAuthProviderToCommand(req *admin_pb.AddGenericOAuthProviderReque
 		UserEndpoint:          req.UserEndpoint,
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -229,6 +230,7 @@ func updateGenericOAuthProviderToCommand(req *admin_pb.UpdateGenericOAuthProvide
 		UserEndpoint:          req.UserEndpoint,
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -241,6 +243,7 @@ func addGenericOIDCProviderToCommand(req *admin_pb.AddGenericOIDCProviderRequest
 		ClientSecret:     req.ClientSecret,
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -253,6 +256,7 @@ func updateGenericOIDCProviderToCommand(req *admin_pb.UpdateGenericOIDCProviderR
 		ClientSecret:     req.ClientSecret,
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -425,6 +429,8 @@ func addLDAPProviderToCommand(req *admin_pb.AddLDAPProviderRequest) command.LDAP
 		Timeout:           req.Timeout.AsDuration(),
 		RootCA:            req.RootCa,
 		LDAPAttributes:    idp_grpc.LDAPAttributesToCommand(req.Attributes),
+		GroupMapping:      idp_grpc.LDAPGroupMappingToCommand(req.GroupMapping),
+		DirectorySync:     req.DirectorySync,
 		IDPOptions:        idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -442,6 +448,8 @@ func updateLDAPProviderToCommand(req *admin_pb.UpdateLDAPProviderRequest) comman
 		UserFilters:       req.UserFilters,
 		Timeout:           req.Timeout.AsDuration(),
 		LDAPAttributes:    idp_grpc.LDAPAttributesToCommand(req.Attributes),
+		GroupMapping:      idp_grpc.LDAPGroupMappingToCommand(req.GroupMapping),
+		DirectorySync:     req.DirectorySync,
 		IDPOptions:        idp_grpc.OptionsToCommand(req.ProviderOptions),
 		RootCA:            req.RootCa,
 	}
@@ -471,6 +479,24 @@ func updateAppleProviderToCommand(req *admin_pb.UpdateAppleProviderRequest) comm
 	}
 }
 
+func addKerberosProviderToCommand(req *admin_pb.AddKerberosProviderRequest) command.KerberosProvider {
+	return command.KerberosProvider{
+		Name:             req.Name,
+		ServicePrincipal: req.ServicePrincipal,
+		Keytab:           req.Keytab,
+		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateKerberosProviderToCommand(req *admin_pb.UpdateKerberosProviderRequest) command.KerberosProvider {
+	return command.KerberosProvider{
+		Name:             req.Name,
+		ServicePrincipal: req.ServicePrincipal,
+		Keytab:           req.Keytab,
+		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
 func addSAMLProviderToCommand(req *admin_pb.AddSAMLProviderRequest) *command.SAMLProvider {
 	var nameIDFormat *domain.SAMLNameIDFormat
 	if req.NameIdFormat != nil {
@@ -484,6 +510,7 @@ func addSAMLProviderToCommand(req *admin_pb.AddSAMLProviderRequest) *command.SAM
 		WithSignedRequest:             req.WithSignedRequest,
 		NameIDFormat:                  nameIDFormat,
 		TransientMappingAttributeName: req.GetTransientMappingAttributeName(),
+		ClaimMapping:                  idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:                    idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -501,6 +528,7 @@ func updateSAMLProviderToCommand(req *admin_pb.UpdateSAMLProviderRequest) *comma
 		WithSignedRequest:             req.WithSignedRequest,
 		NameIDFormat:                  nameIDFormat,
 		TransientMappingAttributeName: req.GetTransientMappingAttributeName(),
+		ClaimMapping:                  idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:                    idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -46,6 +46,7 @@ func EventToPb(event *query.Event) (response *eventpb.Event, err error) {
 		CreationDate: timestamppb.New(event.CreationDate),
 		Payload:      payload,
 		Type:         EventTypeToPb(event.Type),
+		Position:     event.Position,
 	}, nil
 }
 
@@ -313,6 +313,45 @@ func LDAPAttributesToCommand(attributes *idp_pb.LDAPAttributes) idp.LDAPAttribut
 	}
 }
 
+func LDAPGroupMappingToCommand(mapping *idp_pb.LDAPGroupMapping) *domain.LDAPGroupMapping {
+	if mapping == nil {
+		return nil
+	}
+	groups := make([]*domain.LDAPGroupRole, len(mapping.Groups))
+	for i, group := range mapping.Groups {
+		groups[i] = &domain.LDAPGroupRole{
+			Group:     group.Group,
+			ProjectID: group.ProjectId,
+			RoleKeys:  group.RoleKeys,
+		}
+	}
+	return &domain.LDAPGroupMapping{
+		GroupAttribute: mapping.GroupAttribute,
+		Groups:         groups,
+	}
+}
+
+func ClaimMappingToCommand(mapping *idp_pb.ClaimMapping) *domain.IDPClaimMapping {
+	if mapping == nil {
+		return nil
+	}
+	return &domain.IDPClaimMapping{
+		PreferredUsername: mapping.PreferredUsername,
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		AvatarURL:         mapping.AvatarUrl,
+		Profile:           mapping.Profile,
+		Metadata:          mapping.Metadata,
+	}
+}
+
 func AzureADTenantToCommand(tenant *idp_pb.AzureADTenant) string {
 	if tenant == nil {
 		return string(azuread.CommonTenant)
@@ -414,6 +453,8 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return idp_pb.ProviderType_PROVIDER_TYPE_SAML
+	case domain.IDPTypeKerberos:
+		return idp_pb.ProviderType_PROVIDER_TYPE_KERBEROS
 	case domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -479,6 +520,10 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		samlConfigToPb(providerConfig, config.SAMLIDPTemplate)
 		return providerConfig
 	}
+	if config.KerberosIDPTemplate != nil {
+		kerberosConfigToPb(providerConfig, config.KerberosIDPTemplate)
+		return providerConfig
+	}
 	return providerConfig
 }
 
@@ -504,6 +549,7 @@ func oauthConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OAut
 			UserEndpoint:          template.UserEndpoint,
 			Scopes:                template.Scopes,
 			IdAttribute:           template.IDAttribute,
+			ClaimMapping:          claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -515,6 +561,7 @@ func oidcConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OIDCI
 			Issuer:           template.Issuer,
 			Scopes:           template.Scopes,
 			IsIdTokenMapping: template.IsIDTokenMapping,
+			ClaimMapping:     claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -622,6 +669,8 @@ func ldapConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.LDAPI
 			Timeout:           timeout,
 			RootCa:            template.RootCA,
 			Attributes:        ldapAttributesToPb(template.LDAPAttributes),
+			GroupMapping:      ldapGroupMappingToPb(template.GroupMapping),
+			DirectorySync:     template.DirectorySync,
 		},
 	}
 }
@@ -644,6 +693,45 @@ func ldapAttributesToPb(attributes idp.LDAPAttributes) *idp_pb.LDAPAttributes {
 	}
 }
 
+func claimMappingToPb(mapping *domain.IDPClaimMapping) *idp_pb.ClaimMapping {
+	if mapping.IsZero() {
+		return nil
+	}
+	return &idp_pb.ClaimMapping{
+		PreferredUsername: mapping.PreferredUsername,
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		AvatarUrl:         mapping.AvatarURL,
+		Profile:           mapping.Profile,
+		Metadata:          mapping.Metadata,
+	}
+}
+
+func ldapGroupMappingToPb(mapping *domain.LDAPGroupMapping) *idp_pb.LDAPGroupMapping {
+	if mapping.IsZero() {
+		return nil
+	}
+	groups := make([]*idp_pb.LDAPGroupRole, len(mapping.Groups))
+	for i, group := range mapping.Groups {
+		groups[i] = &idp_pb.LDAPGroupRole{
+			Group:     group.Group,
+			ProjectId: group.ProjectID,
+			RoleKeys:  group.RoleKeys,
+		}
+	}
+	return &idp_pb.LDAPGroupMapping{
+		GroupAttribute: mapping.GroupAttribute,
+		Groups:         groups,
+	}
+}
+
 func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.AppleIDPTemplate) {
 	providerConfig.Config = &idp_pb.ProviderConfig_Apple{
 		Apple: &idp_pb.AppleConfig{
@@ -655,6 +743,14 @@ func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.Appl
 	}
 }
 
+func kerberosConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.KerberosIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Kerberos{
+		Kerberos: &idp_pb.KerberosConfig{
+			ServicePrincipal: template.ServicePrincipal,
+		},
+	}
+}
+
 func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLIDPTemplate) {
 	nameIDFormat := idp_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_PERSISTENT
 	if template.NameIDFormat.Valid {
@@ -667,10 +763,21 @@ func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLI
 			WithSignedRequest:             template.WithSignedRequest,
 			NameIdFormat:                  nameIDFormat,
 			TransientMappingAttributeName: gu.Ptr(template.TransientMappingAttributeName),
+			ClaimMapping:                  claimMappingToPb(template.ClaimMapping),
+			MetadataUrl:                   template.MetadataURL,
+			AdditionalKeyIds:              samlKeyIDs(template.AdditionalKeys),
 		},
 	}
 }
 
+func samlKeyIDs(keys domain.SAMLKeys) []string {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
+
 func bindingToPb(binding string) idp_pb.SAMLBinding {
 	switch binding {
 	case "":
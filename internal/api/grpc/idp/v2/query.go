@@ -82,6 +82,8 @@ func idpTypeToPb(idpType domain.IDPType) idp_pb.IDPType {
 		return idp_pb.IDPType_IDP_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return idp_pb.IDPType_IDP_TYPE_SAML
+	case domain.IDPTypeKerberos:
+		return idp_pb.IDPType_IDP_TYPE_KERBEROS
 	case domain.IDPTypeUnspecified:
 		return idp_pb.IDPType_IDP_TYPE_UNSPECIFIED
 	default:
@@ -147,6 +149,10 @@ func configToPb(config *query.IDPTemplate) *idp_pb.IDPConfig {
 		samlConfigToPb(idpConfig, config.SAMLIDPTemplate)
 		return idpConfig
 	}
+	if config.KerberosIDPTemplate != nil {
+		kerberosConfigToPb(idpConfig, config.KerberosIDPTemplate)
+		return idpConfig
+	}
 	return idpConfig
 }
 
@@ -172,6 +178,7 @@ func oauthConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.OAuthIDPTempla
 			UserEndpoint:          template.UserEndpoint,
 			Scopes:                template.Scopes,
 			IdAttribute:           template.IDAttribute,
+			ClaimMapping:          claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -183,6 +190,7 @@ func oidcConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.OIDCIDPTemplate
 			Issuer:           template.Issuer,
 			Scopes:           template.Scopes,
 			IsIdTokenMapping: template.IsIDTokenMapping,
+			ClaimMapping:     claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -290,6 +298,8 @@ func ldapConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.LDAPIDPTemplate
 			Timeout:           timeout,
 			RootCa:            template.RootCA,
 			Attributes:        ldapAttributesToPb(template.LDAPAttributes),
+			GroupMapping:      ldapGroupMappingToPb(template.GroupMapping),
+			DirectorySync:     template.DirectorySync,
 		},
 	}
 }
@@ -312,6 +322,45 @@ func ldapAttributesToPb(attributes idp_rp.LDAPAttributes) *idp_pb.LDAPAttributes
 	}
 }
 
+func claimMappingToPb(mapping *domain.IDPClaimMapping) *idp_pb.ClaimMapping {
+	if mapping.IsZero() {
+		return nil
+	}
+	return &idp_pb.ClaimMapping{
+		PreferredUsername: mapping.PreferredUsername,
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		AvatarUrl:         mapping.AvatarURL,
+		Profile:           mapping.Profile,
+		Metadata:          mapping.Metadata,
+	}
+}
+
+func ldapGroupMappingToPb(mapping *domain.LDAPGroupMapping) *idp_pb.LDAPGroupMapping {
+	if mapping.IsZero() {
+		return nil
+	}
+	groups := make([]*idp_pb.LDAPGroupRole, len(mapping.Groups))
+	for i, group := range mapping.Groups {
+		groups[i] = &idp_pb.LDAPGroupRole{
+			Group:     group.Group,
+			ProjectId: group.ProjectID,
+			RoleKeys:  group.RoleKeys,
+		}
+	}
+	return &idp_pb.LDAPGroupMapping{
+		GroupAttribute: mapping.GroupAttribute,
+		Groups:         groups,
+	}
+}
+
 func appleConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.AppleIDPTemplate) {
 	idpConfig.Config = &idp_pb.IDPConfig_Apple{
 		Apple: &idp_pb.AppleConfig{
@@ -323,6 +372,14 @@ func appleConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.AppleIDPTempla
 	}
 }
 
+func kerberosConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.KerberosIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Kerberos{
+		Kerberos: &idp_pb.KerberosConfig{
+			ServicePrincipal: template.ServicePrincipal,
+		},
+	}
+}
+
 func samlConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.SAMLIDPTemplate) {
 	nameIDFormat := idp_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_PERSISTENT
 	if template.NameIDFormat.Valid {
@@ -335,6 +392,7 @@ func samlConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.SAMLIDPTemplate
 			WithSignedRequest:             template.WithSignedRequest,
 			NameIdFormat:                  nameIDFormat,
 			TransientMappingAttributeName: gu.Ptr(template.TransientMappingAttributeName),
+			ClaimMapping:                  claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -418,6 +418,27 @@ func (s *Server) UpdateAppleProvider(ctx context.Context, req *mgmt_pb.UpdateApp
 	}, nil
 }
 
+func (s *Server) AddKerberosProvider(ctx context.Context, req *mgmt_pb.AddKerberosProviderRequest) (*mgmt_pb.AddKerberosProviderResponse, error) {
+	id, details, err := s.command.AddOrgKerberosProvider(ctx, authz.GetCtxData(ctx).OrgID, addKerberosProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddKerberosProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateKerberosProvider(ctx context.Context, req *mgmt_pb.UpdateKerberosProviderRequest) (*mgmt_pb.UpdateKerberosProviderResponse, error) {
+	details, err := s.command.UpdateOrgKerberosProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateKerberosProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateKerberosProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddSAMLProvider(ctx context.Context, req *mgmt_pb.AddSAMLProviderRequest) (*mgmt_pb.AddSAMLProviderResponse, error) {
 	id, details, err := s.command.AddOrgSAMLProvider(ctx, authz.GetCtxData(ctx).OrgID, addSAMLProviderToCommand(req))
 	if err != nil {
@@ -449,6 +470,47 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *mgm
 	}, nil
 }
 
+func (s *Server) AddSAMLProviderKey(ctx context.Context, req *mgmt_pb.AddSAMLProviderKeyRequest) (*mgmt_pb.AddSAMLProviderKeyResponse, error) {
+	keyID, details, err := s.command.AddOrgSAMLProviderKey(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+		KeyId:   keyID,
+	}, nil
+}
+
+func (s *Server) ActivateSAMLProviderKey(ctx context.Context, req *mgmt_pb.ActivateSAMLProviderKeyRequest) (*mgmt_pb.ActivateSAMLProviderKeyResponse, error) {
+	details, err := s.command.ActivateOrgSAMLProviderKey(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ActivateSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveSAMLProviderKey(ctx context.Context, req *mgmt_pb.RemoveSAMLProviderKeyRequest) (*mgmt_pb.RemoveSAMLProviderKeyResponse, error) {
+	details, err := s.command.RemoveOrgSAMLProviderKey(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveSAMLProviderKeyResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RefreshSAMLProviderMetadata(ctx context.Context, req *mgmt_pb.RefreshSAMLProviderMetadataRequest) (*mgmt_pb.RefreshSAMLProviderMetadataResponse, error) {
+	details, err := s.command.RefreshOrgSAMLProviderMetadata(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RefreshSAMLProviderMetadataResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProviderRequest) (*mgmt_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteOrgProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
 	if err != nil {
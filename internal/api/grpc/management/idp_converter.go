@@ -208,6 +208,7 @@ func addGenericOAuthProviderToCommand(req *mgmt_pb.AddGenericOAuthProviderReques
 		UserEndpoint:          req.UserEndpoint,
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -222,6 +223,7 @@ func updateGenericOAuthProviderToCommand(req *mgmt_pb.UpdateGenericOAuthProvider
 		UserEndpoint:          req.UserEndpoint,
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -234,6 +236,7 @@ func addGenericOIDCProviderToCommand(req *mgmt_pb.AddGenericOIDCProviderRequest)
 		ClientSecret:     req.ClientSecret,
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -246,6 +249,7 @@ func updateGenericOIDCProviderToCommand(req *mgmt_pb.UpdateGenericOIDCProviderRe
 		ClientSecret:     req.ClientSecret,
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -418,6 +422,8 @@ func addLDAPProviderToCommand(req *mgmt_pb.AddLDAPProviderRequest) command.LDAPP
 		Timeout:           req.Timeout.AsDuration(),
 		RootCA:            req.RootCa,
 		LDAPAttributes:    idp_grpc.LDAPAttributesToCommand(req.Attributes),
+		GroupMapping:      idp_grpc.LDAPGroupMappingToCommand(req.GroupMapping),
+		DirectorySync:     req.DirectorySync,
 		IDPOptions:        idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -435,6 +441,8 @@ func updateLDAPProviderToCommand(req *mgmt_pb.UpdateLDAPProviderRequest) command
 		UserFilters:       req.UserFilters,
 		Timeout:           req.Timeout.AsDuration(),
 		LDAPAttributes:    idp_grpc.LDAPAttributesToCommand(req.Attributes),
+		GroupMapping:      idp_grpc.LDAPGroupMappingToCommand(req.GroupMapping),
+		DirectorySync:     req.DirectorySync,
 		IDPOptions:        idp_grpc.OptionsToCommand(req.ProviderOptions),
 		RootCA:            req.RootCa,
 	}
@@ -464,6 +472,24 @@ func updateAppleProviderToCommand(req *mgmt_pb.UpdateAppleProviderRequest) comma
 	}
 }
 
+func addKerberosProviderToCommand(req *mgmt_pb.AddKerberosProviderRequest) command.KerberosProvider {
+	return command.KerberosProvider{
+		Name:             req.Name,
+		ServicePrincipal: req.ServicePrincipal,
+		Keytab:           req.Keytab,
+		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateKerberosProviderToCommand(req *mgmt_pb.UpdateKerberosProviderRequest) command.KerberosProvider {
+	return command.KerberosProvider{
+		Name:             req.Name,
+		ServicePrincipal: req.ServicePrincipal,
+		Keytab:           req.Keytab,
+		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
 func addSAMLProviderToCommand(req *mgmt_pb.AddSAMLProviderRequest) *command.SAMLProvider {
 	var nameIDFormat *domain.SAMLNameIDFormat
 	if req.NameIdFormat != nil {
@@ -477,6 +503,7 @@ func addSAMLProviderToCommand(req *mgmt_pb.AddSAMLProviderRequest) *command.SAML
 		WithSignedRequest:             req.WithSignedRequest,
 		NameIDFormat:                  nameIDFormat,
 		TransientMappingAttributeName: req.GetTransientMappingAttributeName(),
+		ClaimMapping:                  idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:                    idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -494,6 +521,7 @@ func updateSAMLProviderToCommand(req *mgmt_pb.UpdateSAMLProviderRequest) *comman
 		WithSignedRequest:             req.WithSignedRequest,
 		NameIDFormat:                  nameIDFormat,
 		TransientMappingAttributeName: req.GetTransientMappingAttributeName(),
+		ClaimMapping:                  idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:                    idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
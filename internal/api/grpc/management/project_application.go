@@ -8,9 +8,11 @@ import (
 	change_grpc "github.com/zitadel/zitadel/internal/api/grpc/change"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	project_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -245,6 +247,51 @@ func (s *Server) RegenerateAPIClientSecret(ctx context.Context, req *mgmt_pb.Reg
 	}, nil
 }
 
+func (s *Server) SetAppSCIMProvisioning(ctx context.Context, req *mgmt_pb.SetAppSCIMProvisioningRequest) (*mgmt_pb.SetAppSCIMProvisioningResponse, error) {
+	details, err := s.command.SetApplicationSCIMProvisioning(ctx, req.ProjectId, req.AppId, authz.GetCtxData(ctx).OrgID, &command.SCIMProvisioning{
+		Endpoint: req.Endpoint,
+		Token:    req.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetAppSCIMProvisioningResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveAppSCIMProvisioning(ctx context.Context, req *mgmt_pb.RemoveAppSCIMProvisioningRequest) (*mgmt_pb.RemoveAppSCIMProvisioningResponse, error) {
+	details, err := s.command.RemoveApplicationSCIMProvisioning(ctx, req.ProjectId, req.AppId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveAppSCIMProvisioningResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListAppSCIMProvisioningUserStates(ctx context.Context, req *mgmt_pb.ListAppSCIMProvisioningUserStatesRequest) (*mgmt_pb.ListAppSCIMProvisioningUserStatesResponse, error) {
+	provisioning, err := s.query.SCIMProvisioningByAppID(ctx, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	if provisioning.ProjectID != req.ProjectId || provisioning.ResourceOwner != authz.GetCtxData(ctx).OrgID {
+		return nil, zerrors.ThrowNotFound(nil, "MANAG-f6u2lq9yte", "Errors.Project.App.SCIMProvisioning.NotExisting")
+	}
+	queries, err := ListAppSCIMProvisioningUserStatesRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	states, err := s.query.SearchSCIMProvisioningUserStates(ctx, false, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListAppSCIMProvisioningUserStatesResponse{
+		Result:  project_grpc.SCIMProvisioningUserStatesToPb(states.UserStates, provisioning.ResourceOwner),
+		Details: object_grpc.ToListDetails(states.Count, states.Sequence, states.LastRun),
+	}, nil
+}
+
 func (s *Server) GetAppKey(ctx context.Context, req *mgmt_pb.GetAppKeyRequest) (*mgmt_pb.GetAppKeyResponse, error) {
 	resourceOwner, err := query.NewAuthNKeyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
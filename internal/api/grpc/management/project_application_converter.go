@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
+	app_pb "github.com/zitadel/zitadel/pkg/grpc/app"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -64,6 +65,9 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) (*domain.OIDCApp,
 		BackChannelLogoutURI:     req.GetBackChannelLogoutUri(),
 		LoginVersion:             loginVersion,
 		LoginBaseURI:             loginBaseURI,
+		RequirePAR:               req.GetRequirePar(),
+		RequireDPoP:              req.GetRequireDpop(),
+		CIBANotificationURI:      req.GetCibaNotificationUri(),
 	}, nil
 }
 
@@ -128,6 +132,9 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		BackChannelLogoutURI:     app.BackChannelLogoutUri,
 		LoginVersion:             loginVersion,
 		LoginBaseURI:             loginBaseURI,
+		RequirePAR:               app.GetRequirePar(),
+		RequireDPoP:              app.GetRequireDpop(),
+		CIBANotificationURI:      app.GetCibaNotificationUri(),
 	}, nil
 }
 
@@ -201,3 +208,27 @@ func ListAPIClientKeysRequestToQuery(ctx context.Context, req *mgmt_pb.ListAppKe
 		},
 	}, nil
 }
+
+func ListAppSCIMProvisioningUserStatesRequestToQuery(req *mgmt_pb.ListAppSCIMProvisioningUserStatesRequest) (*query.SCIMProvisioningUserStateSearchQueries, error) {
+	appID, err := query.NewSCIMProvisioningUserStateAppIDSearchQuery(req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{appID}
+	if req.State != app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_UNSPECIFIED {
+		state, err := query.NewSCIMProvisioningUserStateStateSearchQuery(app_grpc.SCIMProvisioningStateToDomain(req.State))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, state)
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.SCIMProvisioningUserStateSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -77,6 +77,44 @@ func (s *Server) AuthorizeOrDenyDeviceAuthorization(ctx context.Context, req *oi
 	return &oidc_pb.AuthorizeOrDenyDeviceAuthorizationResponse{}, nil
 }
 
+func (s *Server) GetBackchannelAuthenticationRequest(ctx context.Context, req *oidc_pb.GetBackchannelAuthenticationRequestRequest) (*oidc_pb.GetBackchannelAuthenticationRequestResponse, error) {
+	id, err := s.cibaRequestIDFromID(req.GetBackchannelAuthenticationId())
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.query.CIBARequestByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	return &oidc_pb.GetBackchannelAuthenticationRequestResponse{
+		BackchannelAuthenticationRequest: &oidc_pb.BackchannelAuthenticationRequest{
+			Id:             req.GetBackchannelAuthenticationId(),
+			ClientId:       request.ClientID,
+			Scope:          request.Scopes,
+			UserId:         request.UserID,
+			BindingMessage: request.BindingMessage,
+			ExpirationDate: timestamppb.New(request.Expires),
+		},
+	}, nil
+}
+
+func (s *Server) AuthorizeOrDenyBackchannelAuthentication(ctx context.Context, req *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest) (_ *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationResponse, err error) {
+	id, err := s.cibaRequestIDFromID(req.GetBackchannelAuthenticationId())
+	if err != nil {
+		return nil, err
+	}
+	switch req.GetDecision().(type) {
+	case *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest_Session:
+		_, err = s.command.ApproveCIBARequestWithSession(ctx, id, req.GetSession().GetSessionId(), req.GetSession().GetSessionToken())
+	case *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest_Deny:
+		_, err = s.command.CancelCIBARequest(ctx, id, domain.CIBARequestCanceledDenied)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &oidc_pb.AuthorizeOrDenyBackchannelAuthenticationResponse{}, nil
+}
+
 func authRequestToPb(a *query.AuthRequest) *oidc_pb.AuthRequest {
 	pba := &oidc_pb.AuthRequest{
 		Id:           a.ID,
@@ -261,3 +299,12 @@ func (s *Server) deviceCodeFromID(deviceAuthID string) (string, error) {
 	}
 	return s.encryption.DecryptString(decoded, s.encryption.EncryptionKeyID())
 }
+
+// cibaRequestIDFromID decrypts the opaque ID the user was notified with to the ID (auth_req_id) of the backchannel authentication request.
+func (s *Server) cibaRequestIDFromID(backchannelAuthenticationID string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(backchannelAuthenticationID)
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "OIDCv2-Kc8pw", "Errors.CIBARequest.NotFound")
+	}
+	return s.encryption.DecryptString(decoded, s.encryption.EncryptionKeyID())
+}
@@ -65,6 +65,9 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
 			BackChannelLogoutUri:     app.BackChannelLogoutURI,
 			LoginVersion:             loginVersionToPb(app.LoginVersion, app.LoginBaseURI),
+			RequirePar:               app.RequirePAR,
+			RequireDpop:              app.RequireDPoP,
+			CibaNotificationUri:      app.CIBANotificationURI,
 		},
 	}
 }
@@ -158,6 +161,8 @@ func OIDCGrantTypesFromModel(grantTypes []domain.OIDCGrantType) []app_pb.OIDCGra
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE
 		case domain.OIDCGrantTypeTokenExchange:
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE
+		case domain.OIDCGrantTypeCIBA:
+			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_CIBA
 		}
 	}
 	return oidcGrantTypes
@@ -180,6 +185,8 @@ func OIDCGrantTypesToDomain(grantTypes []app_pb.OIDCGrantType) []domain.OIDCGran
 			oidcGrantTypes[i] = domain.OIDCGrantTypeDeviceCode
 		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE:
 			oidcGrantTypes[i] = domain.OIDCGrantTypeTokenExchange
+		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_CIBA:
+			oidcGrantTypes[i] = domain.OIDCGrantTypeCIBA
 		}
 	}
 	return oidcGrantTypes
@@ -342,3 +349,43 @@ func LoginVersionToDomain(version *app_pb.LoginVersion) (domain.LoginVersion, st
 		return domain.LoginVersionUnspecified, "", nil
 	}
 }
+
+func SCIMProvisioningUserStatesToPb(states []*query.SCIMProvisioningUserState, resourceOwner string) []*app_pb.SCIMProvisioningUserState {
+	s := make([]*app_pb.SCIMProvisioningUserState, len(states))
+	for i, state := range states {
+		s[i] = &app_pb.SCIMProvisioningUserState{
+			UserId:   state.UserID,
+			Details:  object_grpc.ToViewDetailsPb(state.Sequence, state.CreationDate, state.ChangeDate, resourceOwner),
+			RemoteId: state.RemoteID,
+			State:    SCIMProvisioningStateToPb(state.State),
+			Error:    state.Error,
+		}
+	}
+	return s
+}
+
+func SCIMProvisioningStateToPb(state domain.SCIMProvisioningState) app_pb.SCIMProvisioningState {
+	switch state {
+	case domain.SCIMProvisioningStateProvisioned:
+		return app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_PROVISIONED
+	case domain.SCIMProvisioningStateDeactivated:
+		return app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_DEACTIVATED
+	case domain.SCIMProvisioningStateFailed:
+		return app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_FAILED
+	default:
+		return app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_UNSPECIFIED
+	}
+}
+
+func SCIMProvisioningStateToDomain(state app_pb.SCIMProvisioningState) domain.SCIMProvisioningState {
+	switch state {
+	case app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_PROVISIONED:
+		return domain.SCIMProvisioningStateProvisioned
+	case app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_DEACTIVATED:
+		return domain.SCIMProvisioningStateDeactivated
+	case app_pb.SCIMProvisioningState_SCIM_PROVISIONING_STATE_FAILED:
+		return domain.SCIMProvisioningStateFailed
+	default:
+		return domain.SCIMProvisioningStateUnspecified
+	}
+}
@@ -18,13 +18,14 @@ func ProjectViewsToPb(projects []*query.Project) []*proj_pb.Project {
 
 func ProjectViewToPb(project *query.Project) *proj_pb.Project {
 	return &proj_pb.Project{
-		Id:                     project.ID,
-		State:                  projectStateToPb(project.State),
-		Name:                   project.Name,
-		PrivateLabelingSetting: privateLabelingSettingToPb(project.PrivateLabelingSetting),
-		HasProjectCheck:        project.HasProjectCheck,
-		ProjectRoleAssertion:   project.ProjectRoleAssertion,
-		ProjectRoleCheck:       project.ProjectRoleCheck,
+		Id:                        project.ID,
+		State:                     projectStateToPb(project.State),
+		Name:                      project.Name,
+		PrivateLabelingSetting:    privateLabelingSettingToPb(project.PrivateLabelingSetting),
+		HasProjectCheck:           project.HasProjectCheck,
+		ProjectRoleAssertion:      project.ProjectRoleAssertion,
+		ProjectRoleCheck:          project.ProjectRoleCheck,
+		AuthorizationDetailsTypes: project.AuthorizationDetailsTypes,
 		Details: object.ToViewDetailsPb(
 			project.Sequence,
 			project.CreationDate,
@@ -0,0 +1,91 @@
+package action
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	action "github.com/zitadel/zitadel/pkg/grpc/resources/action/v3alpha"
+)
+
+const defaultFailedExecutionsLimit = 100
+
+func (s *Server) SearchFailedExecutions(ctx context.Context, req *action.SearchFailedExecutionsRequest) (*action.SearchFailedExecutionsResponse, error) {
+	if err := checkActionsEnabled(ctx); err != nil {
+		return nil, err
+	}
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultFailedExecutionsLimit
+	}
+	failed, err := execution.SearchFailedRequests(ctx, s.queue, limit, int64(req.GetAfterId()))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*action.FailedExecution, len(failed))
+	for i, request := range failed {
+		result[i], err = failedRequestToPb(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &action.SearchFailedExecutionsResponse{
+		Result: result,
+	}, nil
+}
+
+func (s *Server) GetFailedExecution(ctx context.Context, req *action.GetFailedExecutionRequest) (*action.GetFailedExecutionResponse, error) {
+	if err := checkActionsEnabled(ctx); err != nil {
+		return nil, err
+	}
+	failed, err := execution.GetFailedRequest(ctx, s.queue, int64(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	failedExecution, err := failedRequestToPb(failed)
+	if err != nil {
+		return nil, err
+	}
+	return &action.GetFailedExecutionResponse{
+		FailedExecution: failedExecution,
+	}, nil
+}
+
+func (s *Server) RedeliverFailedExecution(ctx context.Context, req *action.RedeliverFailedExecutionRequest) (*action.RedeliverFailedExecutionResponse, error) {
+	if err := checkActionsEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if err := execution.RedeliverFailedRequest(ctx, s.queue, int64(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &action.RedeliverFailedExecutionResponse{}, nil
+}
+
+func failedRequestToPb(request *execution.FailedRequest) (*action.FailedExecution, error) {
+	payload := new(structpb.Struct)
+	if err := payload.UnmarshalJSON(request.Body); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTION-q3x9w1jb6e", "Errors.Internal")
+	}
+	errs := make([]*action.FailedAttempt, len(request.Errors))
+	for i, attempt := range request.Errors {
+		errs[i] = &action.FailedAttempt{
+			Attempt: attempt.Attempt,
+			Date:    timestamppb.New(attempt.At),
+			Error:   attempt.Error,
+		}
+	}
+	return &action.FailedExecution{
+		Id:           uint64(request.ID),
+		ExecutionId:  request.ExecutionID,
+		TargetId:     request.TargetID,
+		Payload:      payload,
+		Attempts:     request.Attempts,
+		MaxAttempts:  request.MaxAttempts,
+		CreationDate: timestamppb.New(request.CreatedAt),
+		FailureDate:  timestamppb.New(request.FailedAt),
+		Errors:       errs,
+	}, nil
+}
@@ -99,6 +99,13 @@ func targetToPb(t *query.Target) *action.GetTarget {
 		},
 		SigningKey: t.SigningKey,
 	}
+	if t.RetryPolicy != (domain.TargetRetryPolicy{}) {
+		target.Config.RetryPolicy = &action.RetryPolicy{
+			MaxAttempts:    uint32(t.RetryPolicy.MaxAttempts),
+			InitialBackoff: durationpb.New(t.RetryPolicy.InitialBackoff),
+			MaxBackoff:     durationpb.New(t.RetryPolicy.MaxBackoff),
+		}
+	}
 	switch t.TargetType {
 	case domain.TargetTypeWebhook:
 		target.Config.TargetType = &action.Target_RestWebhook{RestWebhook: &action.SetRESTWebhook{InterruptOnError: t.InterruptOnError}}
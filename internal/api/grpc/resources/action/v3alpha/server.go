@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	action "github.com/zitadel/zitadel/pkg/grpc/resources/action/v3alpha"
@@ -21,6 +22,7 @@ type Server struct {
 	systemDefaults      systemdefaults.SystemDefaults
 	command             *command.Commands
 	query               *query.Queries
+	queue               execution.JobQueue
 	ListActionFunctions func() []string
 	ListGRPCMethods     func() []string
 	ListGRPCServices    func() []string
@@ -32,6 +34,7 @@ func CreateServer(
 	systemDefaults systemdefaults.SystemDefaults,
 	command *command.Commands,
 	query *query.Queries,
+	queue execution.JobQueue,
 	listActionFunctions func() []string,
 	listGRPCMethods func() []string,
 	listGRPCServices func() []string,
@@ -40,6 +43,7 @@ func CreateServer(
 		systemDefaults:      systemDefaults,
 		command:             command,
 		query:               query,
+		queue:               queue,
 		ListActionFunctions: listActionFunctions,
 		ListGRPCMethods:     listGRPCMethods,
 		ListGRPCServices:    listGRPCServices,
@@ -82,6 +82,7 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 		Endpoint:         reqTarget.GetEndpoint(),
 		Timeout:          reqTarget.GetTimeout().AsDuration(),
 		InterruptOnError: interruptOnError,
+		RetryPolicy:      retryPolicyToDomain(reqTarget.GetRetryPolicy()),
 	}
 }
 
@@ -120,5 +121,18 @@ func patchTargetToCommand(req *action.PatchTargetRequest) *command.ChangeTarget
 	if reqTarget.Timeout != nil {
 		target.Timeout = gu.Ptr(reqTarget.GetTimeout().AsDuration())
 	}
+	target.RetryPolicy = retryPolicyToDomain(reqTarget.GetRetryPolicy())
 	return target
 }
+
+func retryPolicyToDomain(policy *action.RetryPolicy) *domain.TargetRetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &domain.TargetRetryPolicy{
+		// the max attempts are validated to fit into an uint8
+		MaxAttempts:    uint8(policy.GetMaxAttempts()),
+		InitialBackoff: policy.GetInitialBackoff().AsDuration(),
+		MaxBackoff:     policy.GetMaxBackoff().AsDuration(),
+	}
+}
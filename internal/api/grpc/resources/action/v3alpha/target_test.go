@@ -88,6 +88,34 @@ func Test_createTargetToCommand(t *testing.T) {
 				InterruptOnError: true,
 			},
 		},
+		{
+			name: "retry policy",
+			args: args{&action.Target{
+				Name:     "target 1",
+				Endpoint: "https://example.com/hooks/1",
+				TargetType: &action.Target_RestAsync{
+					RestAsync: &action.SetRESTAsync{},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+				RetryPolicy: &action.RetryPolicy{
+					MaxAttempts:    5,
+					InitialBackoff: durationpb.New(time.Second),
+					MaxBackoff:     durationpb.New(time.Minute),
+				},
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeAsync,
+				Endpoint:         "https://example.com/hooks/1",
+				Timeout:          10 * time.Second,
+				InterruptOnError: false,
+				RetryPolicy: &domain.TargetRetryPolicy{
+					MaxAttempts:    5,
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Minute,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,6 +247,21 @@ func Test_updateTargetToCommand(t *testing.T) {
 				InterruptOnError: gu.Ptr(true),
 			},
 		},
+		{
+			name: "retry policy",
+			args: args{&action.PatchTarget{
+				RetryPolicy: &action.RetryPolicy{
+					MaxAttempts:    5,
+					InitialBackoff: durationpb.New(time.Second),
+				},
+			}},
+			want: &command.ChangeTarget{
+				RetryPolicy: &domain.TargetRetryPolicy{
+					MaxAttempts:    5,
+					InitialBackoff: time.Second,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
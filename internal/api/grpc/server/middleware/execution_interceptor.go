@@ -17,12 +17,14 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func ExecutionHandler(queries *query.Queries) grpc.UnaryServerInterceptor {
+// ExecutionHandler calls the targets of the executions defined for the request and the response.
+// Failed calls to async and webhook targets are retried through the queue, if it's set.
+func ExecutionHandler(queries *query.Queries, queue execution.Queue) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		requestTargets, responseTargets := queryTargets(ctx, queries, info.FullMethod)
 
 		// call targets otherwise return req
-		handledReq, err := executeTargetsForRequest(ctx, requestTargets, info.FullMethod, req)
+		handledReq, err := executeTargetsForRequest(ctx, requestTargets, info.FullMethod, req, queue)
 		if err != nil {
 			return nil, err
 		}
@@ -32,11 +34,11 @@ func ExecutionHandler(queries *query.Queries) grpc.UnaryServerInterceptor {
 			return nil, err
 		}
 
-		return executeTargetsForResponse(ctx, responseTargets, info.FullMethod, handledReq, response)
+		return executeTargetsForResponse(ctx, responseTargets, info.FullMethod, handledReq, response, queue)
 	}
 }
 
-func executeTargetsForRequest(ctx context.Context, targets []execution.Target, fullMethod string, req interface{}) (_ interface{}, err error) {
+func executeTargetsForRequest(ctx context.Context, targets []execution.Target, fullMethod string, req interface{}, queue execution.Queue) (_ interface{}, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer span.EndWithError(err)
 
@@ -55,10 +57,10 @@ func executeTargetsForRequest(ctx context.Context, targets []execution.Target, f
 		Request:    req,
 	}
 
-	return execution.CallTargets(ctx, targets, info)
+	return execution.CallTargets(ctx, targets, info, queue)
 }
 
-func executeTargetsForResponse(ctx context.Context, targets []execution.Target, fullMethod string, req, resp interface{}) (_ interface{}, err error) {
+func executeTargetsForResponse(ctx context.Context, targets []execution.Target, fullMethod string, req, resp interface{}, queue execution.Queue) (_ interface{}, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer span.EndWithError(err)
 
@@ -78,7 +80,7 @@ func executeTargetsForResponse(ctx context.Context, targets []execution.Target,
 		Response:   resp,
 	}
 
-	return execution.CallTargets(ctx, targets, info)
+	return execution.CallTargets(ctx, targets, info, queue)
 }
 
 type ExecutionQueries interface {
@@ -27,6 +27,7 @@ type mockExecutionTarget struct {
 	Timeout          time.Duration
 	InterruptOnError bool
 	SigningKey       string
+	RetryPolicy      domain.TargetRetryPolicy
 }
 
 func (e *mockExecutionTarget) SetEndpoint(endpoint string) {
@@ -53,6 +54,9 @@ func (e *mockExecutionTarget) GetExecutionID() string {
 func (e *mockExecutionTarget) GetSigningKey() string {
 	return e.SigningKey
 }
+func (e *mockExecutionTarget) GetRetryPolicy() domain.TargetRetryPolicy {
+	return e.RetryPolicy
+}
 
 type mockContentRequest struct {
 	Content string
@@ -584,6 +588,7 @@ func Test_executeTargetsForGRPCFullMethod_request(t *testing.T) {
 				tt.args.executionTargets,
 				tt.args.fullMethod,
 				tt.args.req,
+				nil,
 			)
 
 			if tt.res.wantErr {
@@ -783,6 +788,7 @@ func Test_executeTargetsForGRPCFullMethod_response(t *testing.T) {
 				tt.args.fullMethod,
 				tt.args.req,
 				tt.args.resp,
+				nil,
 			)
 
 			if tt.res.wantErr {
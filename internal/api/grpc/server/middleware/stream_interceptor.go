@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel/internal/i18n"
+)
+
+// StreamInterceptor runs the unary interceptor for server streaming calls.
+// The interceptor is called with a nil request, as the request is only received by the handler.
+// Checks based on fields of the request (e.g. the check_field_name of the auth option)
+// therefore have to be done by the handler.
+// The context returned by the interceptor is passed to the stream.
+func StreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := interceptor(
+			stream.Context(),
+			nil,
+			&grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod},
+			func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return nil, handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+			},
+		)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// TranslationStreamHandler translates the errors and the localized fields of every sent message.
+func TranslationStreamHandler() grpc.StreamServerInterceptor {
+	translation := StreamInterceptor(TranslationHandler())
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return translation(srv, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return handler(srv, &translationStream{ServerStream: stream})
+		})
+	}
+}
+
+type translationStream struct {
+	grpc.ServerStream
+	translator *i18n.Translator
+}
+
+func (s *translationStream) SendMsg(m interface{}) error {
+	if loc, ok := m.(localizers); ok && m != nil {
+		if s.translator == nil {
+			s.translator, _ = getTranslator(s.Context())
+		}
+		translateFields(s.Context(), loc, s.translator)
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testCtxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	errInterceptor := errors.New("interceptor")
+	errHandler := errors.New("handler")
+	tests := []struct {
+		name        string
+		interceptor grpc.UnaryServerInterceptor
+		handlerErr  error
+		wantCalled  bool
+		wantErr     error
+	}{
+		{
+			name: "context passed to stream",
+			interceptor: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				assert.Nil(t, req)
+				assert.Equal(t, "/service/Method", info.FullMethod)
+				return handler(context.WithValue(ctx, testCtxKey{}, "value"), req)
+			},
+			wantCalled: true,
+		},
+		{
+			name: "handler error",
+			interceptor: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(context.WithValue(ctx, testCtxKey{}, "value"), req)
+			},
+			handlerErr: errHandler,
+			wantCalled: true,
+			wantErr:    errHandler,
+		},
+		{
+			name: "interceptor error",
+			interceptor: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return nil, errInterceptor
+			},
+			wantErr: errInterceptor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			err := StreamInterceptor(tt.interceptor)(
+				nil,
+				&mockServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{FullMethod: "/service/Method", IsServerStream: true},
+				func(srv interface{}, stream grpc.ServerStream) error {
+					called = true
+					assert.Equal(t, "value", stream.Context().Value(testCtxKey{}))
+					return tt.handlerErr
+				},
+			)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalled, called)
+		})
+	}
+}
+
+type mockValidator struct {
+	err error
+}
+
+func (v *mockValidator) Validate() error {
+	return v.err
+}
+
+type mockRecvStream struct {
+	mockServerStream
+	err error
+}
+
+func (s *mockRecvStream) RecvMsg(interface{}) error {
+	return s.err
+}
+
+func TestValidationStreamHandler(t *testing.T) {
+	errRecv := errors.New("recv")
+	tests := []struct {
+		name     string
+		recvErr  error
+		msg      interface{}
+		wantErr  error
+		wantCode codes.Code
+	}{
+		{
+			name:    "receive error",
+			recvErr: errRecv,
+			msg:     &mockValidator{},
+			wantErr: errRecv,
+		},
+		{
+			name: "no validator",
+			msg:  new(string),
+		},
+		{
+			name: "valid",
+			msg:  &mockValidator{},
+		},
+		{
+			name:     "invalid",
+			msg:      &mockValidator{err: errors.New("invalid")},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationStreamHandler()(
+				nil,
+				&mockRecvStream{mockServerStream: mockServerStream{ctx: context.Background()}, err: tt.recvErr},
+				&grpc.StreamServerInfo{FullMethod: "/service/Method", IsServerStream: true},
+				func(srv interface{}, stream grpc.ServerStream) error {
+					return stream.RecvMsg(tt.msg)
+				},
+			)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	grpc_api "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/query"
@@ -41,6 +42,7 @@ func CreateServer(
 	externalDomain string,
 	tlsConfig *tls.Config,
 	accessSvc *logstore.Service[*record.AccessLog],
+	executionQueue execution.Queue,
 ) *grpc.Server {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeTotalCount, metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode}
 	serverOptions := []grpc.ServerOption{
@@ -56,12 +58,27 @@ func CreateServer(
 				middleware.AuthorizationInterceptor(verifier, authConfig),
 				middleware.TranslationHandler(),
 				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
-				middleware.ExecutionHandler(queries),
+				middleware.ExecutionHandler(queries, executionQueue),
 				middleware.ValidationHandler(),
 				middleware.ServiceHandler(),
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.StreamInterceptor(middleware.CallDurationHandler()),
+				middleware.StreamInterceptor(middleware.MetricsHandler(metricTypes, grpc_api.Probes...)),
+				middleware.StreamInterceptor(middleware.InstanceInterceptor(queries, externalDomain, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName)),
+				middleware.StreamInterceptor(middleware.AccessStorageInterceptor(accessSvc)),
+				middleware.StreamInterceptor(middleware.ErrorHandler()),
+				middleware.StreamInterceptor(middleware.LimitsInterceptor(system_pb.SystemService_ServiceDesc.ServiceName)),
+				middleware.StreamInterceptor(middleware.AuthorizationInterceptor(verifier, authConfig)),
+				middleware.TranslationStreamHandler(),
+				middleware.StreamInterceptor(middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName)),
+				middleware.ValidationStreamHandler(),
+				middleware.StreamInterceptor(middleware.ServiceHandler()),
+			),
+		),
 		grpc.StatsHandler(middleware.DefaultTracingServer()),
 	}
 	if tlsConfig != nil {
@@ -1852,6 +1852,93 @@ func TestServer_DeleteUser(t *testing.T) {
 	}
 }
 
+func TestServer_ForgetUser(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		req     *user.ForgetUserRequest
+		prepare func(request *user.ForgetUserRequest)
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *user.ForgetUserResponse
+		wantErr bool
+	}{
+		{
+			name: "forget, not existing",
+			args: args{
+				ctx: CTX,
+				req: &user.ForgetUserRequest{
+					UserId: "notexisting",
+				},
+				prepare: func(request *user.ForgetUserRequest) {},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forget, no permission",
+			args: args{
+				ctx: UserCTX,
+				req: &user.ForgetUserRequest{},
+				prepare: func(request *user.ForgetUserRequest) {
+					request.UserId = Instance.CreateHumanUser(CTX).GetUserId()
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "forget human, ok",
+			args: args{
+				ctx: CTX,
+				req: &user.ForgetUserRequest{},
+				prepare: func(request *user.ForgetUserRequest) {
+					request.UserId = Instance.CreateHumanUser(CTX).GetUserId()
+				},
+			},
+			want: &user.ForgetUserResponse{
+				Details: &object.Details{
+					ChangeDate:    timestamppb.Now(),
+					ResourceOwner: Instance.DefaultOrg.Id,
+				},
+			},
+		},
+		{
+			name: "forget deleted user, ok",
+			args: args{
+				ctx: CTX,
+				req: &user.ForgetUserRequest{},
+				prepare: func(request *user.ForgetUserRequest) {
+					request.UserId = Instance.CreateHumanUser(CTX).GetUserId()
+					_, err := Client.DeleteUser(CTX, &user.DeleteUserRequest{UserId: request.UserId})
+					require.NoError(t, err)
+				},
+			},
+			want: &user.ForgetUserResponse{
+				Details: &object.Details{
+					ChangeDate:    timestamppb.Now(),
+					ResourceOwner: Instance.DefaultOrg.Id,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.prepare(tt.args.req)
+
+			got, err := Client.ForgetUser(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			integration.AssertDetails(t, tt.want, got)
+
+			_, err = Client.GetUserByID(CTX, &user.GetUserByIDRequest{UserId: tt.args.req.GetUserId()})
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestServer_StartIdentityProviderIntent(t *testing.T) {
 	idpResp := Instance.AddGenericOAuthProvider(IamCTX, Instance.DefaultOrg.Id)
 	orgIdpResp := Instance.AddOrgGenericOAuthProvider(CTX, Instance.DefaultOrg.Id)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"maps"
+	"slices"
 
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -284,6 +286,20 @@ func (s *Server) DeleteUser(ctx context.Context, req *user.DeleteUserRequest) (_
 	}, nil
 }
 
+func (s *Server) ForgetUser(ctx context.Context, req *user.ForgetUserRequest) (_ *user.ForgetUserResponse, err error) {
+	memberships, grants, err := s.removeUserDependencies(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ForgetUser(ctx, req.GetUserId(), "", memberships, grants...)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ForgetUserResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
 	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
 	if err != nil {
@@ -500,6 +516,13 @@ func idpIntentToIDPIntentPb(intent *command.IDPIntentWriteModel, alg crypto.Encr
 		},
 		UserId: intent.UserID,
 	}
+	mapped, err := idp.MappedAttributesFromJSON(intent.IDPUser)
+	if err != nil {
+		return nil, err
+	}
+	if mapped != nil {
+		information.AddHumanUser = mappedAttributesToAddHumanUserPb(mapped, intent)
+	}
 	if intent.IDPIDToken != "" || intent.IDPAccessToken != nil {
 		information.IdpInformation.Access, err = idpOAuthTokensToPb(intent.IDPIDToken, intent.IDPAccessToken, alg)
 		if err != nil {
@@ -526,6 +549,53 @@ func idpIntentToIDPIntentPb(intent *command.IDPIntentWriteModel, alg crypto.Encr
 	return information, nil
 }
 
+// mappedAttributesToAddHumanUserPb returns the request to create the user with the attributes and metadata
+// mapped by the claim mapping of the identity provider, linked to the user of the identity provider.
+func mappedAttributesToAddHumanUserPb(mapped *idp.MappedAttributes, intent *command.IDPIntentWriteModel) *user.AddHumanUserRequest {
+	req := &user.AddHumanUserRequest{
+		Profile: &user.SetHumanProfile{
+			GivenName:  mapped.FirstName,
+			FamilyName: mapped.LastName,
+		},
+		Email: &user.SetHumanEmail{
+			Email: mapped.Email,
+		},
+		IdpLinks: []*user.IDPLink{
+			{
+				IdpId:    intent.IDPID,
+				UserId:   intent.IDPUserID,
+				UserName: intent.IDPUserName,
+			},
+		},
+	}
+	if mapped.PreferredUsername != "" {
+		req.Username = &mapped.PreferredUsername
+	}
+	if mapped.NickName != "" {
+		req.Profile.NickName = &mapped.NickName
+	}
+	if mapped.DisplayName != "" {
+		req.Profile.DisplayName = &mapped.DisplayName
+	}
+	if mapped.PreferredLanguage != "" {
+		req.Profile.PreferredLanguage = &mapped.PreferredLanguage
+	}
+	if mapped.EmailVerified {
+		req.Email.Verification = &user.SetHumanEmail_IsVerified{IsVerified: true}
+	}
+	if mapped.Phone != "" {
+		req.Phone = &user.SetHumanPhone{Phone: mapped.Phone}
+		if mapped.PhoneVerified {
+			req.Phone.Verification = &user.SetHumanPhone_IsVerified{IsVerified: true}
+		}
+	}
+	keys := slices.Sorted(maps.Keys(mapped.Metadata))
+	for _, key := range keys {
+		req.Metadata = append(req.Metadata, &user.SetMetadataEntry{Key: key, Value: mapped.Metadata[key]})
+	}
+	return req
+}
+
 func idpOAuthTokensToPb(idpIDToken string, idpAccessToken *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (_ *user.IDPInformation_Oauth, err error) {
 	var idToken *string
 	if idpIDToken != "" {
@@ -195,6 +195,83 @@ func Test_idpIntentToIDPIntentPb(t *testing.T) {
 				},
 				err: nil,
 			},
+		}, {
+			"successful oauth with mapped attributes",
+			args{
+				intent: &command.IDPIntentWriteModel{
+					WriteModel: eventstore.WriteModel{
+						AggregateID:       "intentID",
+						ProcessedSequence: 123,
+						ResourceOwner:     "ro",
+						InstanceID:        "instanceID",
+						ChangeDate:        time.Date(2019, 4, 1, 1, 1, 1, 1, time.Local),
+					},
+					IDPID:       "idpID",
+					IDPUser:     []byte(`{"userID": "idpUserID", "zitadelMappedAttributes": {"preferredUsername": "mapped", "firstName": "Given", "lastName": "Family", "email": "mail@example.com", "emailVerified": true, "metadata": {"unit": "ZW5naW5lZXJpbmc="}}}`),
+					IDPUserID:   "idpUserID",
+					IDPUserName: "username",
+					IDPAccessToken: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("accessToken"),
+					},
+					State: domain.IDPIntentStateSucceeded,
+				},
+				alg: decryption(nil),
+			},
+			res{
+				resp: &user.RetrieveIdentityProviderIntentResponse{
+					Details: &object_pb.Details{
+						Sequence:      123,
+						ChangeDate:    timestamppb.New(time.Date(2019, 4, 1, 1, 1, 1, 1, time.Local)),
+						ResourceOwner: "ro",
+					},
+					IdpInformation: &user.IDPInformation{
+						Access: &user.IDPInformation_Oauth{
+							Oauth: &user.IDPOAuthAccessInformation{
+								AccessToken: "accessToken",
+							},
+						},
+						IdpId:    "idpID",
+						UserId:   "idpUserID",
+						UserName: "username",
+						RawInformation: func() *structpb.Struct {
+							s, err := structpb.NewStruct(map[string]interface{}{
+								"userID": "idpUserID",
+								"zitadelMappedAttributes": map[string]interface{}{
+									"preferredUsername": "mapped",
+									"firstName":         "Given",
+									"lastName":          "Family",
+									"email":             "mail@example.com",
+									"emailVerified":     true,
+									"metadata":          map[string]interface{}{"unit": "ZW5naW5lZXJpbmc="},
+								},
+							})
+							require.NoError(t, err)
+							return s
+						}(),
+					},
+					AddHumanUser: &user.AddHumanUserRequest{
+						Username: gu.Ptr("mapped"),
+						Profile: &user.SetHumanProfile{
+							GivenName:  "Given",
+							FamilyName: "Family",
+						},
+						Email: &user.SetHumanEmail{
+							Email:        "mail@example.com",
+							Verification: &user.SetHumanEmail_IsVerified{IsVerified: true},
+						},
+						Metadata: []*user.SetMetadataEntry{
+							{Key: "unit", Value: []byte("engineering")},
+						},
+						IdpLinks: []*user.IDPLink{
+							{IdpId: "idpID", UserId: "idpUserID", UserName: "username"},
+						},
+					},
+				},
+				err: nil,
+			},
 		}, {
 			"successful ldap",
 			args{
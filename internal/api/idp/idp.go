@@ -183,12 +183,11 @@ func (h *Handler) handleMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sp, err := samlProvider.GetSP()
+	metadata, err := samlProvider.Metadata()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	metadata := sp.ServiceProvider.Metadata()
 
 	internalUI, _ := strconv.ParseBool(r.URL.Query().Get(paramInternalUI))
 	h.assertionConsumerServices(ctx, metadata, internalUI)
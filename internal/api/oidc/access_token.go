@@ -21,20 +21,22 @@ import (
 )
 
 type accessToken struct {
-	tokenID           string
-	userID            string
-	resourceOwner     string
-	subject           string
-	preferredLanguage *language.Tag
-	clientID          string
-	audience          []string
-	scope             []string
-	authMethods       []domain.UserAuthMethodType
-	authTime          time.Time
-	tokenCreation     time.Time
-	tokenExpiration   time.Time
-	isPAT             bool
-	actor             *domain.TokenActor
+	tokenID              string
+	userID               string
+	resourceOwner        string
+	subject              string
+	preferredLanguage    *language.Tag
+	clientID             string
+	audience             []string
+	scope                []string
+	authMethods          []domain.UserAuthMethodType
+	authTime             time.Time
+	tokenCreation        time.Time
+	tokenExpiration      time.Time
+	isPAT                bool
+	actor                *domain.TokenActor
+	dpopJKT              string
+	authorizationDetails domain.AuthorizationDetails
 }
 
 var ErrInvalidTokenFormat = errors.New("invalid token format")
@@ -100,29 +102,27 @@ func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken
 
 func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenReadModel) *accessToken {
 	return &accessToken{
-		tokenID:           tokenID,
-		userID:            token.UserID,
-		resourceOwner:     token.ResourceOwner,
-		subject:           subject,
-		preferredLanguage: token.PreferredLanguage,
-		clientID:          token.ClientID,
-		audience:          token.Audience,
-		scope:             token.Scope,
-		authMethods:       token.AuthMethods,
-		authTime:          token.AuthTime,
-		tokenCreation:     token.AccessTokenCreation,
-		tokenExpiration:   token.AccessTokenExpiration,
-		actor:             token.Actor,
+		tokenID:              tokenID,
+		userID:               token.UserID,
+		resourceOwner:        token.ResourceOwner,
+		subject:              subject,
+		preferredLanguage:    token.PreferredLanguage,
+		clientID:             token.ClientID,
+		audience:             token.Audience,
+		scope:                token.Scope,
+		authMethods:          token.AuthMethods,
+		authTime:             token.AuthTime,
+		tokenCreation:        token.AccessTokenCreation,
+		tokenExpiration:      token.AccessTokenExpiration,
+		actor:                token.Actor,
+		dpopJKT:              token.DPoPJKT,
+		authorizationDetails: token.AuthorizationDetails,
 	}
 }
 
 func (s *Server) assertClientScopesForPAT(ctx context.Context, token *accessToken, clientID, projectID string) error {
 	token.audience = append(token.audience, clientID, projectID)
-	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
-	if err != nil {
-		return zerrors.ThrowInternal(err, "OIDC-Cyc78", "Errors.Internal")
-	}
-	roles, err := s.query.SearchProjectRoles(ctx, authz.GetFeatures(ctx).TriggerIntrospectionProjections, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectIDQuery}})
+	roles, err := s.query.ProjectRolesByProjectID(ctx, authz.GetFeatures(ctx).TriggerIntrospectionProjections, projectID)
 	if err != nil {
 		return err
 	}
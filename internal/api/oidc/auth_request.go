@@ -97,20 +97,21 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		return nil, err
 	}
 	authRequest := &command.AuthRequest{
-		LoginClient:      loginClient,
-		ClientID:         req.ClientID,
-		RedirectURI:      req.RedirectURI,
-		State:            req.State,
-		Nonce:            req.Nonce,
-		Scope:            scope,
-		Audience:         audience,
-		NeedRefreshToken: slices.Contains(scope, oidc.ScopeOfflineAccess),
-		ResponseType:     ResponseTypeToBusiness(req.ResponseType),
-		ResponseMode:     ResponseModeToBusiness(req.ResponseMode),
-		CodeChallenge:    CodeChallengeToBusiness(req.CodeChallenge, req.CodeChallengeMethod),
-		Prompt:           PromptToBusiness(req.Prompt),
-		UILocales:        UILocalesToBusiness(req.UILocales),
-		MaxAge:           MaxAgeToBusiness(req.MaxAge),
+		LoginClient:          loginClient,
+		ClientID:             req.ClientID,
+		RedirectURI:          req.RedirectURI,
+		State:                req.State,
+		Nonce:                req.Nonce,
+		Scope:                scope,
+		Audience:             audience,
+		NeedRefreshToken:     slices.Contains(scope, oidc.ScopeOfflineAccess),
+		ResponseType:         ResponseTypeToBusiness(req.ResponseType),
+		ResponseMode:         ResponseModeToBusiness(req.ResponseMode),
+		CodeChallenge:        CodeChallengeToBusiness(req.CodeChallenge, req.CodeChallengeMethod),
+		Prompt:               PromptToBusiness(req.Prompt),
+		UILocales:            UILocalesToBusiness(req.UILocales),
+		MaxAge:               MaxAgeToBusiness(req.MaxAge),
+		AuthorizationDetails: authorizationDetailsFromContext(ctx),
 	}
 	if req.LoginHint != "" {
 		authRequest.LoginHint = &req.LoginHint
@@ -454,11 +455,7 @@ func (o *OPStorage) assertProjectRoleScopes(ctx context.Context, clientID string
 	if !project.ProjectRoleAssertion {
 		return scopes, nil
 	}
-	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(project.ID)
-	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "OIDC-Cyc78", "Errors.Internal")
-	}
-	roles, err := o.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectIDQuery}})
+	roles, err := o.query.ProjectRolesByProjectID(ctx, true, project.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -477,11 +474,7 @@ func (o *OPStorage) assertProjectRoleScopesByProject(ctx context.Context, projec
 	if !project.ProjectRoleAssertion {
 		return scopes, nil
 	}
-	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(project.ID)
-	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "OIDC-Cyc78", "Errors.Internal")
-	}
-	roles, err := o.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectIDQuery}})
+	roles, err := o.query.ProjectRolesByProjectID(ctx, true, project.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -493,11 +486,7 @@ func (o *OPStorage) assertProjectRoleScopesByProject(ctx context.Context, projec
 
 func (o *OPStorage) assertClientScopesForPAT(ctx context.Context, token *model.TokenView, clientID, projectID string) error {
 	token.Audience = append(token.Audience, clientID)
-	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
-	if err != nil {
-		return zerrors.ThrowInternal(err, "OIDC-Cyc78", "Errors.Internal")
-	}
-	roles, err := o.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectIDQuery}})
+	roles, err := o.query.ProjectRolesByProjectID(ctx, true, projectID)
 	if err != nil {
 		return err
 	}
@@ -562,12 +551,17 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 	if !ok {
 		return "", zerrors.ThrowInternal(nil, "OIDC-waeN6", "Error.Internal")
 	}
+	if client.client.RequireDPoP {
+		return "", errDPoPImplicitFlow()
+	}
 
 	session, state, err := s.command.CreateOIDCSessionFromAuthRequest(
 		setContextUserSystem(ctx),
 		req.GetID(),
 		implicitFlowComplianceChecker(),
 		slices.Contains(client.GrantTypes(), oidc.GrantTypeRefreshToken),
+		"",
+		nil,
 	)
 	if err != nil {
 		return "", err
@@ -648,6 +642,11 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 	if !ok {
 		return zerrors.ThrowInternal(nil, "OIDC-waeN6", "Error.Internal")
 	}
+	if client.client.RequireDPoP {
+		err = errDPoPImplicitFlow()
+		op.AuthRequestError(w, r, authReq, err, authorizer)
+		return err
+	}
 
 	scope := authReq.GetScopes()
 	session, err := s.command.CreateOIDCSession(ctx,
@@ -667,6 +666,7 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		authReq.SessionID,
 		authReq.oidc().ResponseType,
+		"",
 	)
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
@@ -0,0 +1,185 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// authorizationDetailsParam is the parameter of Rich Authorization Requests (RFC 9396)
+	// on authorization, pushed authorization and token requests.
+	// It's also used as claim in access tokens and introspection responses and as field of the token response.
+	authorizationDetailsParam = "authorization_details"
+)
+
+func errInvalidAuthorizationDetails() *oidc.Error {
+	return &oidc.Error{ErrorType: "invalid_authorization_details"}
+}
+
+// parseAuthorizationDetails parses the authorization_details parameter
+// and checks that the project of the client allows the requested types.
+func parseAuthorizationDetails(ctx context.Context, client op.Client, param string) (domain.AuthorizationDetails, error) {
+	if param == "" {
+		return nil, nil
+	}
+	c, ok := client.(*Client)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-w3fk7xq0zm", "Errors.Internal")
+	}
+	details, err := domain.ParseAuthorizationDetails(param)
+	if err == nil {
+		err = details.CheckAllowedTypes(c.client.AuthorizationDetailsTypes)
+	}
+	if err != nil {
+		return nil, errInvalidAuthorizationDetails().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("invalid authorization_details")
+	}
+	return details, nil
+}
+
+type authorizationDetailsKey struct{}
+
+// authorizationDetailsFromContext returns the approved authorization_details of the current authorization request.
+func authorizationDetailsFromContext(ctx context.Context) domain.AuthorizationDetails {
+	details, _ := ctx.Value(authorizationDetailsKey{}).(domain.AuthorizationDetails)
+	return details
+}
+
+// authorizationDetailsToContext parses the authorization_details of the authorization request,
+// lets them be approved by the Actions v2 function and passes the result in the context,
+// so they can be stored with the auth request.
+func (s *Server) authorizationDetailsToContext(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest]) (_ context.Context, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	details, err := parseAuthorizationDetails(ctx, r.Client, r.Form.Get(authorizationDetailsParam))
+	if err != nil || len(details) == 0 {
+		return ctx, err
+	}
+	client := r.Client.(*Client)
+	if err = checkAuthorizationDetailsLoginVersion(ctx, client); err != nil {
+		return ctx, err
+	}
+	details, err = s.approveAuthorizationDetails(ctx, client, r.Data, details)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, authorizationDetailsKey{}, details), nil
+}
+
+// checkAuthorizationDetailsLoginVersion rejects authorization_details of clients using the login (v1),
+// which can't store them with its auth requests.
+// Same as [OPStorage.CreateAuthRequest], the login v2 is used if it's configured for the client,
+// required by the instance or the login client is passed in the header.
+func checkAuthorizationDetailsLoginVersion(ctx context.Context, client *Client) error {
+	if client.client.LoginVersion == domain.LoginVersion2 {
+		return nil
+	}
+	if headers, _ := http_utils.HeadersFromCtx(ctx); headers.Get(LoginClientHeader) != "" {
+		return nil
+	}
+	return errInvalidAuthorizationDetails().WithDescription("authorization_details are only supported for applications using the login v2")
+}
+
+// approveAuthorizationDetails calls the targets of the [domain.FunctionAuthorizationDetailsApproval] execution.
+// The targets might restrict the authorization details or deny them.
+// If there are no targets, the requested authorization details are returned.
+func (s *Server) approveAuthorizationDetails(ctx context.Context, client *Client, authReq *oidc.AuthRequest, details domain.AuthorizationDetails) (_ domain.AuthorizationDetails, err error) {
+	targets, err := s.query.TargetsByExecutionID(ctx, []string{exec_repo.ID(domain.ExecutionTypeFunction, domain.FunctionAuthorizationDetailsApproval)})
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return details, nil
+	}
+	executionTargets := make([]execution.Target, len(targets))
+	for i, target := range targets {
+		executionTargets[i] = target
+	}
+	info := &ContextInfoAuthorizationDetails{
+		InstanceID:           authz.GetInstance(ctx).InstanceID(),
+		ClientID:             client.GetID(),
+		ProjectID:            client.client.ProjectID,
+		Scope:                authReq.Scopes,
+		LoginHint:            authReq.LoginHint,
+		AuthorizationDetails: details,
+	}
+	if _, err = execution.CallTargets(ctx, executionTargets, info, nil); err != nil {
+		return nil, err
+	}
+	if info.Denied {
+		return nil, oidc.ErrAccessDenied().WithDescription("authorization_details denied")
+	}
+	if err = info.AuthorizationDetails.Validate(); err == nil {
+		err = info.AuthorizationDetails.CheckAllowedTypes(client.client.AuthorizationDetailsTypes)
+	}
+	if err != nil {
+		return nil, errInvalidAuthorizationDetails().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("invalid authorization_details returned by action")
+	}
+	return info.AuthorizationDetails, nil
+}
+
+var _ execution.ContextInfo = &ContextInfoAuthorizationDetails{}
+
+// ContextInfoAuthorizationDetails is sent to the targets of the [domain.FunctionAuthorizationDetailsApproval] execution.
+// Targets of type call can respond with the (modified) object to restrict the authorization details
+// or set denied to reject the authorization request.
+type ContextInfoAuthorizationDetails struct {
+	InstanceID           string                      `json:"instanceID,omitempty"`
+	ClientID             string                      `json:"clientID,omitempty"`
+	ProjectID            string                      `json:"projectID,omitempty"`
+	Scope                []string                    `json:"scope,omitempty"`
+	LoginHint            string                      `json:"loginHint,omitempty"`
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
+	Denied               bool                        `json:"denied,omitempty"`
+}
+
+func (c *ContextInfoAuthorizationDetails) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfoAuthorizationDetails) SetHTTPResponseBody(resp []byte) error {
+	if !json.Valid(resp) {
+		return zerrors.ThrowPreconditionFailed(nil, "OIDC-p7ru2c5xne", "Errors.Execution.ResponseIsNotValidJSON")
+	}
+	return json.Unmarshal(resp, c)
+}
+
+func (c *ContextInfoAuthorizationDetails) GetContent() interface{} {
+	return c
+}
+
+// accessTokenResponse extends the token response by the authorization_details
+// the access token was issued for (RFC 9396, section 7).
+type accessTokenResponse struct {
+	*oidc.AccessTokenResponse
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorization_details,omitempty"`
+}
+
+// withAuthorizationDetails returns the token response including the authorization_details of the session.
+func withAuthorizationDetails(session *command.OIDCSession) func(*oidc.AccessTokenResponse, error) (any, error) {
+	return func(resp *oidc.AccessTokenResponse, err error) (any, error) {
+		if err != nil || len(session.AuthorizationDetails) == 0 {
+			return resp, err
+		}
+		return &accessTokenResponse{
+			AccessTokenResponse:  resp,
+			AuthorizationDetails: session.AuthorizationDetails,
+		}, nil
+	}
+}
@@ -0,0 +1,66 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_checkAuthorizationDetailsLoginVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		loginVersion domain.LoginVersion
+		loginClient  string
+		wantErr      bool
+	}{
+		{
+			name:         "login v1",
+			loginVersion: domain.LoginVersion1,
+			wantErr:      true,
+		},
+		{
+			name:         "login unspecified",
+			loginVersion: domain.LoginVersionUnspecified,
+			wantErr:      true,
+		},
+		{
+			name:         "login v2",
+			loginVersion: domain.LoginVersion2,
+		},
+		{
+			name:         "login client header",
+			loginVersion: domain.LoginVersion1,
+			loginClient:  "loginClient",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/oauth/v2/authorize", nil)
+			if tt.loginClient != "" {
+				r.Header.Set(LoginClientHeader, tt.loginClient)
+			}
+			var ctx context.Context
+			http_utils.CopyHeadersToContext(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				ctx = r.Context()
+			})).ServeHTTP(httptest.NewRecorder(), r)
+
+			err := checkAuthorizationDetailsLoginVersion(ctx, &Client{client: &query.OIDCClient{LoginVersion: tt.loginVersion}})
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var oidcErr *oidc.Error
+			require.ErrorAs(t, err, &oidcErr)
+			assert.Equal(t, errInvalidAuthorizationDetails().ErrorType, oidcErr.ErrorType)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// GrantTypeCIBA is the grant type used by clients to poll for the tokens
+	// of a Client-Initiated Backchannel Authentication request.
+	GrantTypeCIBA oidc.GrantType = "urn:openid:params:grant-type:ciba"
+
+	CIBADefaultLifetime     = 5 * time.Minute
+	CIBADefaultPollInterval = 5 * time.Second
+
+	// BackchannelAuthenticationPath is the path of the login (v2), where the user approves or denies the request.
+	// The opaque ID of the request is passed as [BackchannelAuthenticationParam].
+	BackchannelAuthenticationPath  = "/backchannel-authentication"
+	BackchannelAuthenticationParam = "id"
+	CIBADefaultLoginURL            = BackchannelAuthenticationPath + "?" + BackchannelAuthenticationParam + "="
+
+	// cibaBindingMessageMaxLength limits the binding message,
+	// as it has to be displayed on the authentication device of the user.
+	cibaBindingMessageMaxLength = 100
+)
+
+// backchannelTokenDeliveryModes are the supported delivery modes.
+// Clients with a notification URI use the ping mode, all others the poll mode.
+var backchannelTokenDeliveryModes = []string{"poll", "ping"}
+
+type CIBAConfig struct {
+	Lifetime     time.Duration
+	PollInterval time.Duration
+	// DefaultLoginURL is the URL the user is notified with, if the client has no specific login base URI.
+	// Relative URLs are resolved against the requested domain.
+	DefaultLoginURL string
+}
+
+// withDefaults returns a copy of the config, setting sane defaults for empty values.
+// Safe to call when c is nil.
+func (c *CIBAConfig) withDefaults() CIBAConfig {
+	out := CIBAConfig{
+		Lifetime:        CIBADefaultLifetime,
+		PollInterval:    CIBADefaultPollInterval,
+		DefaultLoginURL: CIBADefaultLoginURL,
+	}
+	if c == nil {
+		return out
+	}
+	if c.Lifetime != 0 {
+		out.Lifetime = c.Lifetime
+	}
+	if c.PollInterval != 0 {
+		out.PollInterval = c.PollInterval
+	}
+	if c.DefaultLoginURL != "" {
+		out.DefaultLoginURL = c.DefaultLoginURL
+	}
+	return out
+}
+
+type backchannelAuthenticationResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	ExpiresIn int64  `json:"expires_in"`
+	Interval  int64  `json:"interval,omitempty"`
+}
+
+func errUnknownUserID() *oidc.Error {
+	return &oidc.Error{ErrorType: "unknown_user_id"}
+}
+
+func errInvalidBindingMessage() *oidc.Error {
+	return &oidc.Error{ErrorType: "invalid_binding_message"}
+}
+
+// backchannelAuthenticationInterceptor serves the backchannel authentication endpoint
+// and the CIBA grant of the token endpoint.
+// Both are not supported by the [op.Server], so they are served as a middleware,
+// same as the Pushed Authorization Request endpoint.
+func (s *Server) backchannelAuthenticationInterceptor(next http.Handler) http.Handler {
+	interceptor := op.NewIssuerInterceptor(s.IssuerFromRequest)
+	authHandler := interceptor.HandlerFunc(s.backchannelAuthenticationHandler)
+	tokenHandler := interceptor.HandlerFunc(s.cibaTokenHandler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.backchannelAuthEndpoint != nil && r.URL.Path == s.backchannelAuthEndpoint.Relative() {
+			authHandler(w, r)
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == s.Endpoints().Token.Relative() {
+			if err := r.ParseForm(); err == nil && oidc.GrantType(r.PostForm.Get("grant_type")) == GrantTypeCIBA {
+				tokenHandler(w, r)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) backchannelAuthenticationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	resp, err := s.BackchannelAuthentication(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// BackchannelAuthentication authenticates the client and starts a Client-Initiated Backchannel Authentication
+// for the user identified by the login_hint or id_token_hint.
+// The returned auth_req_id is used by the client to get the tokens, once the user approved the request.
+func (s *Server) BackchannelAuthentication(ctx context.Context, r *http.Request) (_ *backchannelAuthenticationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	client, err := s.verifyCIBAClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range []string{"login_hint_token", "user_code", "request"} {
+		if r.PostForm.Has(param) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("%s is not supported", param)
+		}
+	}
+	scopes := strings.Fields(r.PostForm.Get("scope"))
+	if !slices.Contains(scopes, oidc.ScopeOpenID) {
+		return nil, oidc.ErrInvalidScope().WithDescription("the scope openid is required")
+	}
+	bindingMessage := r.PostForm.Get("binding_message")
+	if utf8.RuneCountInString(bindingMessage) > cibaBindingMessageMaxLength {
+		return nil, errInvalidBindingMessage().WithDescription("binding_message must not exceed %d characters", cibaBindingMessageMaxLength)
+	}
+	clientNotificationToken := r.PostForm.Get("client_notification_token")
+	if client.client.CIBANotificationURI != "" && clientNotificationToken == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_notification_token is required")
+	}
+	lifetime, err := s.cibaRequestLifetime(r.PostForm.Get("requested_expiry"))
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.cibaUserFromHint(ctx, r.PostForm.Get("login_hint"), r.PostForm.Get("id_token_hint"))
+	if err != nil {
+		return nil, err
+	}
+
+	storage, ok := s.Provider().Storage().(*OPStorage)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-v3kz8r1qwd", "Errors.Internal")
+	}
+	scopes, audience, err := storage.createAuthRequestScopeAndAudience(ctx, client.GetID(), scopes)
+	if err != nil {
+		return nil, err
+	}
+	request := &command.CIBARequest{
+		ClientID:         client.GetID(),
+		UserID:           user.ID,
+		UserOrgID:        user.ResourceOwner,
+		Scopes:           scopes,
+		Audience:         audience,
+		BindingMessage:   bindingMessage,
+		Expires:          time.Now().Add(lifetime),
+		NeedRefreshToken: slices.Contains(scopes, oidc.ScopeOfflineAccess),
+		LoginURL:         s.cibaLoginURL(ctx, client),
+	}
+	if client.client.CIBANotificationURI != "" {
+		request.NotificationURI = client.client.CIBANotificationURI
+		request.ClientNotificationToken = clientNotificationToken
+	}
+	if _, err = s.command.AddCIBARequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return &backchannelAuthenticationResponse{
+		AuthReqID: request.ID,
+		ExpiresIn: int64(lifetime.Seconds()),
+		Interval:  int64(s.ciba.PollInterval.Seconds()),
+	}, nil
+}
+
+// cibaLoginURL returns the URL of the login the user is notified with.
+// The notification appends the opaque ID of the request, so the auth_req_id is never exposed to the user agent.
+func (s *Server) cibaLoginURL(ctx context.Context, client *Client) string {
+	if client.client.LoginBaseURI == nil || client.client.LoginBaseURI.URL().String() == "" {
+		if strings.HasPrefix(s.ciba.DefaultLoginURL, "/") {
+			return http_utils.DomainContext(ctx).Origin() + s.ciba.DefaultLoginURL
+		}
+		return s.ciba.DefaultLoginURL
+	}
+	return client.client.LoginBaseURI.URL().JoinPath(BackchannelAuthenticationPath).String() + "?" + BackchannelAuthenticationParam + "="
+}
+
+// verifyCIBAClient authenticates the client of the request
+// and checks if it is allowed to use the CIBA grant.
+func (s *Server) verifyCIBAClient(ctx context.Context, r *http.Request) (*Client, error) {
+	cc, err := clientCredentialsFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	opClient, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+		Data:   cc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client, ok := opClient.(*Client)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-n8wq2e5ylt", "Error.Internal")
+	}
+	if !op.ValidateGrantType(client, GrantTypeCIBA) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the ciba grant")
+	}
+	return client, nil
+}
+
+// cibaRequestLifetime returns the lifetime of the request,
+// which can be shortened by the client using the requested_expiry parameter.
+func (s *Server) cibaRequestLifetime(requestedExpiry string) (time.Duration, error) {
+	if requestedExpiry == "" {
+		return s.ciba.Lifetime, nil
+	}
+	seconds, err := strconv.ParseInt(requestedExpiry, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0, oidc.ErrInvalidRequest().WithDescription("invalid requested_expiry")
+	}
+	return min(time.Duration(seconds)*time.Second, s.ciba.Lifetime), nil
+}
+
+// cibaUserFromHint returns the active user identified by exactly one of the hints.
+// An expired id_token_hint is accepted, as it is only used to identify the user.
+func (s *Server) cibaUserFromHint(ctx context.Context, loginHint, idTokenHint string) (*query.User, error) {
+	if (loginHint == "") == (idTokenHint == "") {
+		return nil, oidc.ErrInvalidRequest().WithDescription("exactly one of login_hint or id_token_hint is required")
+	}
+	var (
+		user *query.User
+		err  error
+	)
+	if loginHint != "" {
+		user, err = s.query.GetUserByLoginName(ctx, false, loginHint)
+	} else {
+		claims, verifyErr := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, idTokenHint, s.Provider().IDTokenHintVerifier(ctx))
+		if verifyErr != nil && !errors.As(verifyErr, new(op.IDTokenHintExpiredError)) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid id_token_hint").WithParent(verifyErr)
+		}
+		user, err = s.query.GetUserByID(ctx, false, claims.Subject)
+	}
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return nil, errUnknownUserID().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+		}
+		return nil, err
+	}
+	if user.State != domain.UserStateActive {
+		return nil, errUnknownUserID()
+	}
+	return user, nil
+}
+
+func (s *Server) cibaTokenHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.CIBAToken(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// CIBAToken returns the tokens of an approved backchannel authentication request.
+// Clients using the poll mode call it repeatedly, clients using the ping mode once they were notified.
+func (s *Server) CIBAToken(ctx context.Context, r *http.Request) (_ *oidc.AccessTokenResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		span.EndWithError(err)
+		err = oidcError(err)
+	}()
+
+	client, err := s.verifyCIBAClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	authReqID := r.PostForm.Get("auth_req_id")
+	if authReqID == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("auth_req_id missing")
+	}
+	if err = checkTokenPollInterval(ctx, s.tokenPolls, authReqID, s.ciba.PollInterval, time.Now()); err != nil {
+		return nil, err
+	}
+	dpopJKT, err := dpopJKTFromTokenRequest(ctx, s.dpopProofs, &op.Request[struct{}]{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+	}, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.command.CreateOIDCSessionFromCIBA(ctx, authReqID, client.GetID(), dpopJKT)
+	if err == nil {
+		return s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, oidc.ErrSlowDown().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+	}
+
+	var target command.CIBARequestStateError
+	if errors.As(err, &target) {
+		switch domain.CIBARequestState(target) {
+		case domain.CIBARequestStateInitiated:
+			return nil, oidc.ErrAuthorizationPending()
+		case domain.CIBARequestStateExpired:
+			return nil, oidc.ErrExpiredDeviceCode()
+		case domain.CIBARequestStateDenied:
+			return nil, oidc.ErrAccessDenied()
+		}
+	}
+	return nil, oidc.ErrInvalidGrant().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+}
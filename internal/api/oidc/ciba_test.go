@@ -0,0 +1,84 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCIBAConfig_withDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *CIBAConfig
+		want   CIBAConfig
+	}{
+		{
+			name:   "nil",
+			config: nil,
+			want:   CIBAConfig{Lifetime: CIBADefaultLifetime, PollInterval: CIBADefaultPollInterval, DefaultLoginURL: CIBADefaultLoginURL},
+		},
+		{
+			name:   "empty",
+			config: &CIBAConfig{},
+			want:   CIBAConfig{Lifetime: CIBADefaultLifetime, PollInterval: CIBADefaultPollInterval, DefaultLoginURL: CIBADefaultLoginURL},
+		},
+		{
+			name:   "set",
+			config: &CIBAConfig{Lifetime: time.Minute, PollInterval: time.Second, DefaultLoginURL: "https://login.example.com/ciba?id="},
+			want:   CIBAConfig{Lifetime: time.Minute, PollInterval: time.Second, DefaultLoginURL: "https://login.example.com/ciba?id="},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.withDefaults())
+		})
+	}
+}
+
+func TestServer_cibaRequestLifetime(t *testing.T) {
+	s := &Server{ciba: CIBAConfig{Lifetime: 5 * time.Minute}}
+	tests := []struct {
+		name            string
+		requestedExpiry string
+		want            time.Duration
+		wantErr         bool
+	}{
+		{
+			name: "default",
+			want: 5 * time.Minute,
+		},
+		{
+			name:            "shorter",
+			requestedExpiry: "60",
+			want:            time.Minute,
+		},
+		{
+			name:            "longer than configured",
+			requestedExpiry: "3600",
+			want:            5 * time.Minute,
+		},
+		{
+			name:            "negative",
+			requestedExpiry: "-1",
+			wantErr:         true,
+		},
+		{
+			name:            "invalid",
+			requestedExpiry: "soon",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.cibaRequestLifetime(tt.requestedExpiry)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
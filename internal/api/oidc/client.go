@@ -802,25 +802,7 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 	if projectID != "" {
 		roleAudience = append(roleAudience, projectID)
 	}
-	projectQuery, err := query.NewUserGrantProjectIDsSearchQuery(roleAudience)
-	if err != nil {
-		return nil, nil, err
-	}
-	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
-	if err != nil {
-		return nil, nil, err
-	}
-	activeQuery, err := query.NewUserGrantStateQuery(domain.UserGrantStateActive)
-	if err != nil {
-		return nil, nil, err
-	}
-	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{
-		Queries: []query.SearchQuery{
-			projectQuery,
-			userIDQuery,
-			activeQuery,
-		},
-	}, true)
+	grants, err := o.query.ActiveUserGrantsOfUser(ctx, userID, roleAudience, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,307 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector"
+)
+
+const (
+	// DPoPTokenType is the token type of access tokens which are bound to a DPoP key (RFC 9449).
+	DPoPTokenType = "DPoP"
+
+	dpopHeader    = "DPoP"
+	dpopProofType = "dpop+jwt"
+	// dpopProofMaxAge is the maximum age of a DPoP proof, based on its iat claim.
+	dpopProofMaxAge = time.Minute
+	// dpopProofClockSkew allows proofs of clients with clocks slightly ahead.
+	dpopProofClockSkew = 5 * time.Second
+)
+
+// dpopSigningAlgs are the asymmetric algorithms accepted for DPoP proofs.
+var dpopSigningAlgs = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+func dpopSigningAlgValues() []string {
+	algs := make([]string, len(dpopSigningAlgs))
+	for i, alg := range dpopSigningAlgs {
+		algs[i] = string(alg)
+	}
+	return algs
+}
+
+type dpopProofClaims struct {
+	JWTID           string    `json:"jti"`
+	HTTPMethod      string    `json:"htm"`
+	HTTPURI         string    `json:"htu"`
+	IssuedAt        oidc.Time `json:"iat"`
+	AccessTokenHash string    `json:"ath,omitempty"`
+}
+
+// errDPoPImplicitFlow is returned for clients requiring DPoP in the implicit flow,
+// where tokens are issued by the authorization endpoint and cannot be bound to a DPoP key.
+func errDPoPImplicitFlow() *oidc.Error {
+	return oidc.ErrUnauthorizedClient().WithDescription("DPoP is required, which is not supported by the implicit flow")
+}
+
+func errInvalidDPoPProof() *oidc.Error {
+	return &oidc.Error{ErrorType: "invalid_dpop_proof"}
+}
+
+type dpopProofIndex int
+
+const (
+	dpopProofIndexUnspecified dpopProofIndex = iota
+	dpopProofIndexID
+)
+
+// usedDPoPProof is a DPoP proof which was already accepted.
+// It is stored for the lifetime of proofs to reject replays.
+type usedDPoPProof struct {
+	InstanceID string
+	JKT        string
+	JWTID      string
+}
+
+// Keys implements [cache.Entry]
+func (p *usedDPoPProof) Keys(i dpopProofIndex) []string {
+	if i == dpopProofIndexID {
+		return []string{usedDPoPProofKey(p.InstanceID, p.JKT, p.JWTID)}
+	}
+	return nil
+}
+
+// usedDPoPProofKey scopes the jti to the key, as clients only generate unique ids for their own key.
+func usedDPoPProofKey(instanceID, jkt, jti string) string {
+	return instanceID + ":" + jkt + ":" + jti
+}
+
+// startDPoPProofCache starts the cache of the used proofs, which must be shared by all processes to detect replays.
+// Without a configured connector replayed proofs are not detected.
+func startDPoPProofCache(background context.Context, connectors connector.Connectors) (cache.AdderCache[dpopProofIndex, string, *usedDPoPProof], error) {
+	conf := connectors.Config.DPoPProofs
+	if conf == nil || conf.Connector == cache.ConnectorUnspecified {
+		logging.Warn("no cache connector configured for DPoP proofs, replayed proofs are not detected")
+	}
+	return connector.StartAdderCache[dpopProofIndex, string, *usedDPoPProof](background, []dpopProofIndex{dpopProofIndexID}, cache.PurposeDPoPProof, conf, connectors)
+}
+
+// verifyDPoPProof verifies the signature and claims of a DPoP proof and returns
+// the base64url encoded SHA-256 thumbprint of its key (jkt).
+// The htm and htu claims are only checked if a method and uri are passed,
+// the ath claim is only checked if an access token is passed.
+// The jti of accepted proofs is stored in the cache and proofs with a used jti are rejected.
+func verifyDPoPProof(ctx context.Context, usedProofs cache.AdderCache[dpopProofIndex, string, *usedDPoPProof], proof, method, uri, accessToken string, now time.Time) (string, error) {
+	jws, err := jose.ParseSigned(proof, dpopSigningAlgs)
+	if err != nil {
+		return "", errInvalidDPoPProof().WithParent(err).WithDescription("malformed DPoP proof")
+	}
+	if len(jws.Signatures) != 1 {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof must have exactly one signature")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", errInvalidDPoPProof().WithDescription("invalid DPoP proof type %q", typ)
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.IsPublic() || !key.Valid() {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof must contain a public jwk")
+	}
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return "", errInvalidDPoPProof().WithParent(err).WithDescription("invalid DPoP proof signature")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", errInvalidDPoPProof().WithParent(err).WithDescription("malformed DPoP proof claims")
+	}
+	if claims.JWTID == "" {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof jti missing")
+	}
+	if method != "" && claims.HTTPMethod != method {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof htm does not match the request")
+	}
+	if uri != "" && !equalHTU(claims.HTTPURI, uri) {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof htu does not match the request")
+	}
+	issuedAt := claims.IssuedAt.AsTime()
+	if issuedAt.Before(now.Add(-dpopProofMaxAge)) || issuedAt.After(now.Add(dpopProofClockSkew)) {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof expired or issued in the future")
+	}
+	if accessToken != "" && claims.AccessTokenHash != accessTokenHash(accessToken) {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof ath does not match the access token")
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", errInvalidDPoPProof().WithParent(err).WithDescription("invalid DPoP proof jwk")
+	}
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+	used := &usedDPoPProof{
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		JKT:        jkt,
+		JWTID:      claims.JWTID,
+	}
+	// the proof is stored atomically, so concurrent requests with the same proof can't both pass
+	added, err := usedProofs.Add(ctx, used)
+	if err != nil {
+		return "", oidc.ErrServerError().WithParent(err).WithDescription("unable to check the DPoP proof for replays")
+	}
+	if !added {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof already used")
+	}
+	return jkt, nil
+}
+
+func accessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// equalHTU compares the htu claim with the uri of the request, ignoring query and fragment.
+func equalHTU(htu, uri string) bool {
+	got, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	want, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(got.Scheme, want.Scheme) &&
+		strings.EqualFold(got.Host, want.Host) &&
+		got.Path == want.Path
+}
+
+// dpopRequestURI returns the absolute uri of the request based on the issuer.
+func dpopRequestURI(ctx context.Context, u *url.URL) string {
+	issuer, err := url.Parse(op.IssuerFromContext(ctx))
+	if err != nil {
+		return u.String()
+	}
+	return (&url.URL{Scheme: issuer.Scheme, Host: issuer.Host, Path: u.Path}).String()
+}
+
+func dpopProof(header http.Header) (string, error) {
+	proofs := header.Values(dpopHeader)
+	switch len(proofs) {
+	case 0:
+		return "", nil
+	case 1:
+		return proofs[0], nil
+	default:
+		return "", errInvalidDPoPProof().WithDescription("multiple DPoP proofs")
+	}
+}
+
+// dpopJKTFromTokenRequest verifies the DPoP proof of a token request and returns the thumbprint of its key,
+// the issued tokens are bound to.
+// An empty thumbprint is returned if the request has no proof and required is false.
+func dpopJKTFromTokenRequest[T any](ctx context.Context, usedProofs cache.AdderCache[dpopProofIndex, string, *usedDPoPProof], r *op.Request[T], required bool) (string, error) {
+	proof, err := dpopProof(r.Header)
+	if err != nil {
+		return "", err
+	}
+	if proof == "" {
+		if required {
+			return "", errInvalidDPoPProof().WithDescription("DPoP proof required")
+		}
+		return "", nil
+	}
+	return verifyDPoPProof(ctx, usedProofs, proof, r.Method, dpopRequestURI(ctx, r.URL), "", time.Now())
+}
+
+func accessTokenType(dpopJKT string) string {
+	if dpopJKT != "" {
+		return DPoPTokenType
+	}
+	return oidc.BearerToken
+}
+
+// confirmationClaim returns the cnf claim of tokens bound to a DPoP key.
+func confirmationClaim(dpopJKT string) map[string]any {
+	return map[string]any{"jkt": dpopJKT}
+}
+
+type dpopSchemeKey struct{}
+
+// dpopAuthorizationInterceptor accepts access tokens presented with the DPoP authorization scheme.
+// The [op.Server] only reads Bearer tokens, so the scheme is rewritten and the use of the DPoP scheme
+// is kept in the context for the verification of the token binding.
+func dpopAuthorizationInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if ok && strings.EqualFold(scheme, DPoPTokenType) {
+			r.Header.Set("Authorization", oidc.PrefixBearer+token)
+			r = r.WithContext(context.WithValue(r.Context(), dpopSchemeKey{}, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func dpopSchemeFromContext(ctx context.Context) bool {
+	used, _ := ctx.Value(dpopSchemeKey{}).(bool)
+	return used
+}
+
+// verifyAccessTokenDPoP verifies that an access token bound to a DPoP key is presented
+// with the DPoP authorization scheme and a proof of the bound key.
+func (s *Server) verifyAccessTokenDPoP(ctx context.Context, token *accessToken, rawToken string, r *op.Request[oidc.UserInfoRequest]) error {
+	if token.dpopJKT == "" {
+		return nil
+	}
+	if !dpopSchemeFromContext(ctx) {
+		return errInvalidDPoPProof().WithDescription("DPoP bound access token must be presented with the DPoP scheme")
+	}
+	proof, err := dpopProof(r.Header)
+	if err != nil {
+		return err
+	}
+	if proof == "" {
+		return errInvalidDPoPProof().WithDescription("DPoP proof required")
+	}
+	return s.checkDPoPBinding(ctx, token.dpopJKT, proof, r.Method, dpopRequestURI(ctx, r.URL), rawToken)
+}
+
+// verifyIntrospectionDPoP verifies the DPoP proof a resource server forwards with the introspection request.
+// As the proof was created for the request to the resource server, its htm and htu claims are not checked.
+// Resource servers which do not forward the proof must check the returned cnf claim themselves.
+func (s *Server) verifyIntrospectionDPoP(ctx context.Context, token *accessToken, rawToken string, header http.Header) error {
+	if token.dpopJKT == "" {
+		return nil
+	}
+	proof, err := dpopProof(header)
+	if err != nil || proof == "" {
+		return err
+	}
+	return s.checkDPoPBinding(ctx, token.dpopJKT, proof, "", "", rawToken)
+}
+
+func (s *Server) checkDPoPBinding(ctx context.Context, dpopJKT, proof, method, uri, rawToken string) error {
+	jkt, err := verifyDPoPProof(ctx, s.dpopProofs, proof, method, uri, rawToken, time.Now())
+	if err != nil {
+		return err
+	}
+	if jkt != dpopJKT {
+		return errInvalidDPoPProof().WithDescription("DPoP proof key does not match the token binding")
+	}
+	return nil
+}
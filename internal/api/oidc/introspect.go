@@ -105,6 +105,9 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 	if err = validateIntrospectionAudience(token.audience, client.clientID, client.projectID); err != nil {
 		return nil, err
 	}
+	if err = s.verifyIntrospectionDPoP(ctx, token.accessToken, r.Data.Token, r.Header); err != nil {
+		return nil, err
+	}
 	userInfo, err := s.userInfo(
 		token.userID,
 		token.scope,
@@ -132,6 +135,19 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Actor:                           actorDomainToClaims(token.actor),
 	}
 	introspectionResp.SetUserInfo(userInfo)
+	if token.dpopJKT != "" {
+		introspectionResp.TokenType = DPoPTokenType
+		if introspectionResp.Claims == nil {
+			introspectionResp.Claims = make(map[string]any, 1)
+		}
+		introspectionResp.Claims["cnf"] = confirmationClaim(token.dpopJKT)
+	}
+	if len(token.authorizationDetails) > 0 {
+		if introspectionResp.Claims == nil {
+			introspectionResp.Claims = make(map[string]any, 1)
+		}
+		introspectionResp.Claims[authorizationDetailsParam] = token.authorizationDetails
+	}
 	return op.NewResponse(introspectionResp), nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
@@ -38,21 +39,25 @@ type Config struct {
 	JWKSCacheControlMaxAge            time.Duration
 	CustomEndpoints                   *EndpointConfig
 	DeviceAuth                        *DeviceAuthorizationConfig
+	CIBA                              *CIBAConfig
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
 	PublicKeyCacheMaxAge              time.Duration
 	DefaultBackChannelLogoutLifetime  time.Duration
+	PushedAuthRequestLifetime         time.Duration
 }
 
 type EndpointConfig struct {
-	Auth          *Endpoint
-	Token         *Endpoint
-	Introspection *Endpoint
-	Userinfo      *Endpoint
-	Revocation    *Endpoint
-	EndSession    *Endpoint
-	Keys          *Endpoint
-	DeviceAuth    *Endpoint
+	Auth            *Endpoint
+	Token           *Endpoint
+	Introspection   *Endpoint
+	Userinfo        *Endpoint
+	Revocation      *Endpoint
+	EndSession      *Endpoint
+	Keys            *Endpoint
+	DeviceAuth      *Endpoint
+	PushedAuth      *Endpoint
+	BackchannelAuth *Endpoint
 }
 
 type Endpoint struct {
@@ -114,6 +119,7 @@ func NewServer(
 	accessHandler *middleware.AccessInterceptor,
 	fallbackLogger *slog.Logger,
 	hashConfig crypto.HashConfig,
+	cacheConnectors connector.Connectors,
 ) (*Server, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
@@ -141,28 +147,42 @@ func NewServer(
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "OIDC-Aij4e", "cannot create secret hasher")
 	}
+	dpopProofs, err := startDPoPProofCache(ctx, cacheConnectors)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-Dp0Rq", "cannot start dpop proof cache")
+	}
+	tokenPolls, err := startTokenPollCache(ctx, cacheConnectors)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-Tp3wz", "cannot start token poll cache")
+	}
 	server := &Server{
 		LegacyServer: op.NewLegacyServer(&Provider{
 			Provider:          provider,
 			accessTokenKeySet: accessTokenKeySet,
 			idTokenHintKeySet: idTokenHintKeySet,
 		}, endpoints(config.CustomEndpoints)),
-		repo:                       repo,
-		query:                      query,
-		command:                    command,
-		accessTokenKeySet:          accessTokenKeySet,
-		idTokenHintKeySet:          idTokenHintKeySet,
-		defaultLoginURL:            fmt.Sprintf("%s%s?%s=", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID),
-		defaultLoginURLV2:          config.DefaultLoginURLV2,
-		defaultLogoutURLV2:         config.DefaultLogoutURLV2,
-		defaultAccessTokenLifetime: config.DefaultAccessTokenLifetime,
-		defaultIdTokenLifetime:     config.DefaultIdTokenLifetime,
-		jwksCacheControlMaxAge:     config.JWKSCacheControlMaxAge,
-		fallbackLogger:             fallbackLogger,
-		hasher:                     hasher,
-		encAlg:                     encryptionAlg,
-		opCrypto:                   op.NewAESCrypto(opConfig.CryptoKey),
-		assetAPIPrefix:             assets.AssetAPI(),
+		repo:                        repo,
+		query:                       query,
+		command:                     command,
+		accessTokenKeySet:           accessTokenKeySet,
+		idTokenHintKeySet:           idTokenHintKeySet,
+		defaultLoginURL:             fmt.Sprintf("%s%s?%s=", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID),
+		defaultLoginURLV2:           config.DefaultLoginURLV2,
+		defaultLogoutURLV2:          config.DefaultLogoutURLV2,
+		defaultAccessTokenLifetime:  config.DefaultAccessTokenLifetime,
+		defaultIdTokenLifetime:      config.DefaultIdTokenLifetime,
+		jwksCacheControlMaxAge:      config.JWKSCacheControlMaxAge,
+		pushedAuthorizationEndpoint: pushedAuthorizationEndpoint(config.CustomEndpoints),
+		pushedAuthRequestLifetime:   pushedAuthRequestLifetime(config.PushedAuthRequestLifetime),
+		backchannelAuthEndpoint:     backchannelAuthEndpoint(config.CustomEndpoints),
+		ciba:                        config.CIBA.withDefaults(),
+		dpopProofs:                  dpopProofs,
+		tokenPolls:                  tokenPolls,
+		fallbackLogger:              fallbackLogger,
+		hasher:                      hasher,
+		encAlg:                      encryptionAlg,
+		opCrypto:                    op.NewAESCrypto(opConfig.CryptoKey),
+		assetAPIPrefix:              assets.AssetAPI(),
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	server.Handler = op.RegisterLegacyServer(server,
@@ -177,6 +197,9 @@ func NewServer(
 			http_utils.CopyHeadersToContext,
 			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(config.CustomEndpoints)),
 			middleware.ActivityHandler,
+			dpopAuthorizationInterceptor,
+			server.pushedAuthorizationInterceptor,
+			server.backchannelAuthenticationInterceptor,
 		))
 
 	return server, nil
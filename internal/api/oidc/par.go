@@ -0,0 +1,216 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	PushedAuthRequestDefaultLifetime = time.Minute
+
+	// RequestURIPrefix is the prefix of the request_uri returned by the Pushed Authorization Request endpoint (RFC 9126).
+	RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	requestURIParam  = "request_uri"
+)
+
+// clientCredentialParams are not stored with the pushed authorization request,
+// as they are only used for the authentication of the client at the endpoint.
+var clientCredentialParams = []string{"client_secret", "client_assertion", "client_assertion_type"}
+
+type pushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// pushedAuthorizationInterceptor serves the Pushed Authorization Request endpoint.
+// The endpoint is not part of the [op.Server] and its router does not allow to register
+// additional routes once the middlewares are set, so it is served as the last middleware.
+func (s *Server) pushedAuthorizationInterceptor(next http.Handler) http.Handler {
+	handler := op.NewIssuerInterceptor(s.IssuerFromRequest).HandlerFunc(s.pushedAuthorizationHandler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.pushedAuthorizationEndpoint == nil || r.URL.Path != s.pushedAuthorizationEndpoint.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+func (s *Server) pushedAuthorizationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	resp, err := s.PushedAuthorization(r.Context(), r)
+	if err != nil {
+		op.WriteError(w, r, err, s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+// PushedAuthorization authenticates the client and stores the parameters of the authorization request.
+// The client can then start the authorization at the authorization endpoint using the returned request_uri.
+func (s *Server) PushedAuthorization(ctx context.Context, r *http.Request) (_ *pushedAuthorizationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	cc, err := clientCredentialsFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+		Data:   cc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.PostForm.Has(requestURIParam) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri must not be provided")
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if authReq.ClientID != "" && authReq.ClientID != client.GetID() {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	// parameters of a request object are validated once it is parsed at the authorization endpoint
+	if authReq.RequestParam == "" {
+		if err = validatePushedAuthRequest(client, authReq); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = parseAuthorizationDetails(ctx, client, r.PostForm.Get(authorizationDetailsParam)); err != nil {
+		return nil, err
+	}
+
+	parameters := make(url.Values, len(r.PostForm))
+	for key, values := range r.PostForm {
+		parameters[key] = values
+	}
+	for _, param := range clientCredentialParams {
+		parameters.Del(param)
+	}
+	parameters.Set("client_id", client.GetID())
+
+	pushed, err := s.command.AddPushedAuthRequest(ctx, client.GetID(), parameters, time.Now().Add(s.pushedAuthRequestLifetime))
+	if err != nil {
+		return nil, err
+	}
+	return &pushedAuthorizationResponse{
+		RequestURI: RequestURIPrefix + pushed.ID,
+		ExpiresIn:  int64(time.Until(pushed.Expiration).Round(time.Second).Seconds()),
+	}, nil
+}
+
+func validatePushedAuthRequest(client op.Client, authReq *oidc.AuthRequest) error {
+	if authReq.RedirectURI == "" {
+		return op.ErrAuthReqMissingRedirectURI
+	}
+	if _, err := op.ValidateAuthReqScopes(client, authReq.Scopes); err != nil {
+		return err
+	}
+	if err := op.ValidateAuthReqRedirectURI(client, authReq.RedirectURI, authReq.ResponseType); err != nil {
+		return err
+	}
+	return op.ValidateAuthReqResponseType(client, authReq.ResponseType)
+}
+
+// clientCredentialsFromRequest reads the client credentials from the form and the basic auth header,
+// which takes precedence.
+func clientCredentialsFromRequest(r *http.Request) (*op.ClientCredentials, error) {
+	cc := &op.ClientCredentials{
+		ClientID:            r.PostForm.Get("client_id"),
+		ClientSecret:        r.PostForm.Get("client_secret"),
+		ClientAssertion:     r.PostForm.Get("client_assertion"),
+		ClientAssertionType: r.PostForm.Get("client_assertion_type"),
+	}
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		var err error
+		if cc.ClientID, err = url.QueryUnescape(clientID); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		if cc.ClientSecret, err = url.QueryUnescape(clientSecret); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	}
+	if cc.ClientID == "" && cc.ClientAssertion == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id or client_assertion must be provided")
+	}
+	if cc.ClientAssertion != "" && cc.ClientAssertionType != oidc.ClientAssertionTypeJWTAssertion {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid client_assertion_type %s", cc.ClientAssertionType)
+	}
+	return cc, nil
+}
+
+// resolvePushedAuthRequest replaces the parameters of the authorization request with the parameters
+// of the pushed authorization request, if the request references one by its request_uri.
+// It returns whether the request was pushed.
+func (s *Server) resolvePushedAuthRequest(ctx context.Context, r *op.Request[oidc.AuthRequest]) (bool, error) {
+	requestURI := r.Form.Get(requestURIParam)
+	if requestURI == "" {
+		return false, nil
+	}
+	id, ok := strings.CutPrefix(requestURI, RequestURIPrefix)
+	if !ok || id == "" {
+		return false, oidc.ErrInvalidRequest().WithDescription("invalid request_uri")
+	}
+	if r.Data.ClientID == "" {
+		return false, oidc.ErrInvalidRequest().WithParent(op.ErrAuthReqMissingClientID).WithDescription(op.ErrAuthReqMissingClientID.Error())
+	}
+	parameters, err := s.command.UsePushedAuthRequest(ctx, id, r.Data.ClientID)
+	if err != nil {
+		if zerrors.IsNotFound(err) || zerrors.IsPreconditionFailed(err) {
+			return false, oidc.ErrInvalidRequest().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("invalid or expired request_uri")
+		}
+		return false, err
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, parameters); err != nil {
+		return false, oidc.ErrInvalidRequest().WithDescription("error decoding pushed authorization request").WithParent(err)
+	}
+	r.Data = authReq
+	r.Form = parameters
+	return true, nil
+}
+
+// checkPushedAuthRequestRequired returns an error if the client requires
+// the authorization to be started by a pushed authorization request.
+func checkPushedAuthRequestRequired(client op.Client, pushed bool) error {
+	if pushed {
+		return nil
+	}
+	if c, ok := client.(*Client); ok && c.client.RequirePAR {
+		return oidc.ErrInvalidRequest().WithDescription("pushed authorization request required")
+	}
+	return nil
+}
+
+func pushedAuthRequestLifetime(lifetime time.Duration) time.Duration {
+	if lifetime == 0 {
+		return PushedAuthRequestDefaultLifetime
+	}
+	return lifetime
+}
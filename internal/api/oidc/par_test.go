@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_clientCredentialsFromRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      url.Values
+		basicAuth []string
+		want      *op.ClientCredentials
+		wantErr   bool
+	}{
+		{
+			name:    "missing client",
+			form:    url.Values{"redirect_uri": {"https://example.com/callback"}},
+			wantErr: true,
+		},
+		{
+			name: "form",
+			form: url.Values{"client_id": {"client"}, "client_secret": {"secret"}},
+			want: &op.ClientCredentials{ClientID: "client", ClientSecret: "secret"},
+		},
+		{
+			name:      "basic auth",
+			form:      url.Values{"client_id": {"other"}},
+			basicAuth: []string{"client%40project", "secret"},
+			want:      &op.ClientCredentials{ClientID: "client@project", ClientSecret: "secret"},
+		},
+		{
+			name: "client assertion",
+			form: url.Values{"client_assertion": {"jwt"}, "client_assertion_type": {oidc.ClientAssertionTypeJWTAssertion}},
+			want: &op.ClientCredentials{ClientAssertion: "jwt", ClientAssertionType: oidc.ClientAssertionTypeJWTAssertion},
+		},
+		{
+			name:    "invalid client assertion type",
+			form:    url.Values{"client_assertion": {"jwt"}, "client_assertion_type": {"unknown"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/oauth/v2/par", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicAuth != nil {
+				r.SetBasicAuth(tt.basicAuth[0], tt.basicAuth[1])
+			}
+			require.NoError(t, r.ParseForm())
+
+			got, err := clientCredentialsFromRequest(r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_checkPushedAuthRequestRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  op.Client
+		pushed  bool
+		wantErr bool
+	}{
+		{
+			name:   "not required",
+			client: &Client{client: &query.OIDCClient{}},
+		},
+		{
+			name:   "required, pushed",
+			client: &Client{client: &query.OIDCClient{RequirePAR: true}},
+			pushed: true,
+		},
+		{
+			name:    "required, not pushed",
+			client:  &Client{client: &query.OIDCClient{RequirePAR: true}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPushedAuthRequestRequired(tt.client, tt.pushed)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/i18n"
@@ -36,6 +37,13 @@ type Server struct {
 	defaultIdTokenLifetime     time.Duration
 	jwksCacheControlMaxAge     time.Duration
 
+	pushedAuthorizationEndpoint *op.Endpoint
+	pushedAuthRequestLifetime   time.Duration
+	backchannelAuthEndpoint     *op.Endpoint
+	ciba                        CIBAConfig
+	dpopProofs                  cache.AdderCache[dpopProofIndex, string, *usedDPoPProof]
+	tokenPolls                  cache.AdderCache[tokenPollIndex, string, *tokenPoll]
+
 	fallbackLogger      *slog.Logger
 	hasher              *crypto.Hasher
 	signingKeyAlgorithm string
@@ -88,6 +96,20 @@ func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
 	return endpoints
 }
 
+func pushedAuthorizationEndpoint(endpointConfig *EndpointConfig) *op.Endpoint {
+	if endpointConfig != nil && endpointConfig.PushedAuth != nil {
+		return op.NewEndpointWithURL(endpointConfig.PushedAuth.Path, endpointConfig.PushedAuth.URL)
+	}
+	return op.NewEndpoint("/oauth/v2/par")
+}
+
+func backchannelAuthEndpoint(endpointConfig *EndpointConfig) *op.Endpoint {
+	if endpointConfig != nil && endpointConfig.BackchannelAuth != nil {
+		return op.NewEndpointWithURL(endpointConfig.BackchannelAuth.Path, endpointConfig.BackchannelAuth.URL)
+	}
+	return op.NewEndpoint("/oauth/v2/bc-authorize")
+}
+
 func (s *Server) getLogger(ctx context.Context) *slog.Logger {
 	if logger, ok := logging.FromContext(ctx); ok {
 		return logger
@@ -127,20 +149,43 @@ func (s *Server) Discovery(ctx context.Context, r *op.Request[struct{}]) (_ *op.
 	if len(allowedLanguages) == 0 {
 		allowedLanguages = i18n.SupportedLanguages()
 	}
-	return op.NewResponse(s.createDiscoveryConfig(ctx, allowedLanguages)), nil
+	issuer := op.IssuerFromContext(ctx)
+	return op.NewResponse(&discoveryConfiguration{
+		DiscoveryConfiguration:                 s.createDiscoveryConfig(ctx, allowedLanguages),
+		PushedAuthorizationRequestEndpoint:     s.pushedAuthorizationEndpoint.Absolute(issuer),
+		DPoPSigningAlgValuesSupported:          dpopSigningAlgValues(),
+		BackchannelAuthenticationEndpoint:      s.backchannelAuthEndpoint.Absolute(issuer),
+		BackchannelTokenDeliveryModesSupported: backchannelTokenDeliveryModes,
+		BackchannelUserCodeParameterSupported:  false,
+	}), nil
 }
 
 func (s *Server) VerifyAuthRequest(ctx context.Context, r *op.Request[oidc.AuthRequest]) (_ *op.ClientRequest[oidc.AuthRequest], err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.VerifyAuthRequest(ctx, r)
+	pushed, err := s.resolvePushedAuthRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := s.LegacyServer.VerifyAuthRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkPushedAuthRequestRequired(cr.Client, pushed); err != nil {
+		return nil, err
+	}
+	return cr, nil
 }
 
 func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest]) (_ *op.Redirect, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	ctx, err = s.authorizationDetailsToContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 	return s.LegacyServer.Authorize(ctx, r)
 }
 
@@ -165,6 +210,19 @@ func (s *Server) EndSession(ctx context.Context, r *op.Request[oidc.EndSessionRe
 	return s.LegacyServer.EndSession(ctx, r)
 }
 
+// discoveryConfiguration extends the [oidc.DiscoveryConfiguration]
+// with the metadata of the Pushed Authorization Request endpoint (RFC 9126),
+// the supported DPoP algorithms (RFC 9449)
+// and the metadata of Client-Initiated Backchannel Authentication (CIBA).
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	PushedAuthorizationRequestEndpoint     string   `json:"pushed_authorization_request_endpoint,omitempty"`
+	DPoPSigningAlgValuesSupported          []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	BackchannelAuthenticationEndpoint      string   `json:"backchannel_authentication_endpoint,omitempty"`
+	BackchannelTokenDeliveryModesSupported []string `json:"backchannel_token_delivery_modes_supported,omitempty"`
+	BackchannelUserCodeParameterSupported  bool     `json:"backchannel_user_code_parameter_supported"`
+}
+
 func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales oidc.Locales) *oidc.DiscoveryConfiguration {
 	issuer := op.IssuerFromContext(ctx)
 	backChannelLogoutSupported := authz.GetInstance(ctx).Features().EnableBackChannelLogout
@@ -186,7 +244,7 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 			string(oidc.ResponseModeFragment),
 			string(oidc.ResponseModeFormPost),
 		},
-		GrantTypesSupported:                                op.GrantTypes(s.Provider()),
+		GrantTypesSupported:                                append(op.GrantTypes(s.Provider()), GrantTypeCIBA),
 		SubjectTypesSupported:                              op.SubjectTypes(s.Provider()),
 		IDTokenSigningAlgValuesSupported:                   supportedSigningAlgs(ctx),
 		RequestObjectSigningAlgValuesSupported:             op.RequestObjectSigAlgorithms(s.Provider()),
@@ -36,7 +36,7 @@ func (s *Server) accessTokenResponseFromSession(ctx context.Context, client op.C
 	getSigner := s.getSignerOnce()
 
 	resp := &oidc.AccessTokenResponse{
-		TokenType:    oidc.BearerToken,
+		TokenType:    accessTokenType(session.DPoPJKT),
 		RefreshToken: session.RefreshToken,
 		ExpiresIn:    timeToOIDCExpiresIn(session.Expiration),
 		State:        state,
@@ -202,6 +202,18 @@ func (s *Server) createJWT(ctx context.Context, client op.Client, session *comma
 	)
 	claims.Actor = actorDomainToClaims(session.Actor)
 	claims.Claims = userInfo.Claims
+	if session.DPoPJKT != "" {
+		if claims.Claims == nil {
+			claims.Claims = make(map[string]any, 1)
+		}
+		claims.Claims["cnf"] = confirmationClaim(session.DPoPJKT)
+	}
+	if len(session.AuthorizationDetails) > 0 {
+		if claims.Claims == nil {
+			claims.Claims = make(map[string]any, 1)
+		}
+		claims.Claims[authorizationDetailsParam] = session.AuthorizationDetails
+	}
 
 	return crypto.Sign(claims, signer)
 }
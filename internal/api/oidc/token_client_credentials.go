@@ -22,6 +22,10 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 	if !ok {
 		return nil, zerrors.ThrowInternal(nil, "OIDC-ga0EP", "Error.Internal")
 	}
+	dpopJKT, err := dpopJKTFromTokenRequest(ctx, s.dpopProofs, r.Request, false)
+	if err != nil {
+		return nil, err
+	}
 	scope, err := op.ValidateAuthReqScopes(client, r.Data.Scope)
 	if err != nil {
 		return nil, err
@@ -48,6 +52,7 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
 	)
 	if err != nil {
 		return nil, err
@@ -53,6 +53,11 @@ func (s *Server) tokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 		return nil, zerrors.ThrowInternal(nil, "OIDC-eShi5", "Error.Internal")
 	}
 
+	dpopJKT, err := dpopJKTFromTokenRequest(ctx, s.dpopProofs, r.Request, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
+
 	subjectToken, err := s.verifyExchangeToken(ctx, client, r.Data.SubjectToken, r.Data.SubjectTokenType, oidc.AllTokenTypes...)
 	if err != nil {
 		return nil, oidc.ErrInvalidRequest().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("subject_token invalid")
@@ -82,7 +87,7 @@ func (s *Server) tokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 		return nil, err
 	}
 
-	resp, err := s.createExchangeTokens(ctx, r.Data.RequestedTokenType, client, subjectToken, actorToken, audience, scopes)
+	resp, err := s.createExchangeTokens(ctx, r.Data.RequestedTokenType, client, subjectToken, actorToken, audience, scopes, dpopJKT)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +222,7 @@ func validateTokenExchangeAudience(requestedAudience, subjectAudience, actorAudi
 // The actorToken is used to set the new token's auth time AMR and actor.
 // Both tokens may point to the same object (subjectToken) in case of a regular Token Exchange.
 // When the subject and actor Tokens point to different objects, the new tokens will be for impersonation / delegation.
-func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenType, client *Client, subjectToken, actorToken *exchangeToken, audience, scopes []string) (_ *oidc.TokenExchangeResponse, err error) {
+func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenType, client *Client, subjectToken, actorToken *exchangeToken, audience, scopes []string, dpopJKT string) (_ *oidc.TokenExchangeResponse, err error) {
 	getUserInfo := s.getUserInfo(subjectToken.userID, client.client.ProjectID, client.client.ProjectRoleAssertion, client.IDTokenUserinfoClaimsAssertion(), scopes)
 	getSigner := s.getSignerOnce()
 
@@ -235,13 +240,13 @@ func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenT
 	var sessionID string
 	switch tokenType {
 	case oidc.AccessTokenType, "":
-		resp.AccessToken, resp.RefreshToken, sessionID, resp.ExpiresIn, err = s.createExchangeAccessToken(ctx, client, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
-		resp.TokenType = oidc.BearerToken
+		resp.AccessToken, resp.RefreshToken, sessionID, resp.ExpiresIn, err = s.createExchangeAccessToken(ctx, client, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor, dpopJKT)
+		resp.TokenType = accessTokenType(dpopJKT)
 		resp.IssuedTokenType = oidc.AccessTokenType
 
 	case oidc.JWTTokenType:
-		resp.AccessToken, resp.RefreshToken, resp.ExpiresIn, err = s.createExchangeJWT(ctx, client, getUserInfo, client.client.AccessTokenRoleAssertion, getSigner, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
-		resp.TokenType = oidc.BearerToken
+		resp.AccessToken, resp.RefreshToken, resp.ExpiresIn, err = s.createExchangeJWT(ctx, client, getUserInfo, client.client.AccessTokenRoleAssertion, getSigner, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor, dpopJKT)
+		resp.TokenType = accessTokenType(dpopJKT)
 		resp.IssuedTokenType = oidc.JWTTokenType
 
 	case oidc.IDTokenType:
@@ -280,6 +285,7 @@ func (s *Server) createExchangeAccessToken(
 	preferredLanguage *language.Tag,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
+	dpopJKT string,
 ) (accessToken, refreshToken, sessionID string, exp uint64, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -301,6 +307,7 @@ func (s *Server) createExchangeAccessToken(
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
 	)
 	if err != nil {
 		return "", "", "", 0, err
@@ -327,6 +334,7 @@ func (s *Server) createExchangeJWT(
 	preferredLanguage *language.Tag,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
+	dpopJKT string,
 ) (accessToken string, refreshToken string, exp uint64, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -348,6 +356,7 @@ func (s *Server) createExchangeJWT(
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
 	)
 	accessToken, err = s.createJWT(ctx, client, session, getUserInfo, roleAssertion, getSigner)
 	if err != nil {
@@ -21,6 +21,10 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		err = oidcError(err)
 	}()
 
+	dpopJKT, err := dpopJKTFromTokenRequest(ctx, s.dpopProofs, r, false)
+	if err != nil {
+		return nil, err
+	}
 	user, err := s.verifyJWTProfile(ctx, r.Data)
 	if err != nil {
 		return nil, err
@@ -58,6 +62,7 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector"
+)
+
+type tokenPollIndex int
+
+const (
+	tokenPollIndexUnspecified tokenPollIndex = iota
+	tokenPollIndexWindow
+)
+
+// tokenPoll is an accepted token request of a client polling for the result
+// of a device authorization or backchannel authentication request.
+// Polls are counted in windows of half the poll interval.
+type tokenPoll struct {
+	InstanceID  string
+	RequestHash string
+	Window      int64
+}
+
+// Keys implements [cache.Entry].
+// An accepted poll occupies its window and the following one,
+// so the next poll of the request is only accepted after at least half the interval
+// and always accepted after the full interval.
+func (p *tokenPoll) Keys(i tokenPollIndex) []string {
+	if i == tokenPollIndexWindow {
+		return []string{
+			tokenPollKey(p.InstanceID, p.RequestHash, p.Window),
+			tokenPollKey(p.InstanceID, p.RequestHash, p.Window+1),
+		}
+	}
+	return nil
+}
+
+func tokenPollKey(instanceID, requestHash string, window int64) string {
+	return instanceID + ":" + requestHash + ":" + strconv.FormatInt(window, 10)
+}
+
+// tokenPollRequestHash hashes the device code or auth_req_id,
+// so the secrets of the requests are not stored in the cache.
+func tokenPollRequestHash(requestID string) string {
+	hash := sha256.Sum256([]byte(requestID))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// startTokenPollCache starts the cache of the accepted polls, which must be shared by all processes to enforce the poll interval.
+// Without a configured connector the poll interval is not enforced.
+func startTokenPollCache(background context.Context, connectors connector.Connectors) (cache.AdderCache[tokenPollIndex, string, *tokenPoll], error) {
+	conf := connectors.Config.TokenPolls
+	if conf == nil || conf.Connector == cache.ConnectorUnspecified {
+		logging.Warn("no cache connector configured for token polls, the poll interval of device authorization and ciba requests is not enforced")
+	}
+	return connector.StartAdderCache[tokenPollIndex, string, *tokenPoll](background, []tokenPollIndex{tokenPollIndexWindow}, cache.PurposeTokenPoll, conf, connectors)
+}
+
+// checkTokenPollInterval returns a slow_down error if the client polls for the tokens of the request faster than the interval
+// (RFC 8628, section 3.5 and OpenID Connect CIBA, section 11).
+// Polls less than half the interval apart are always rejected,
+// polls at least the interval apart are always accepted.
+// Rejected polls are not counted, so a client which slows down is not penalized further.
+// If the cache fails, the poll is accepted, as the interval only protects against excessive polling.
+func checkTokenPollInterval(ctx context.Context, polls cache.AdderCache[tokenPollIndex, string, *tokenPoll], requestID string, interval time.Duration, now time.Time) error {
+	window := interval / 2
+	if window <= 0 {
+		return nil
+	}
+	added, err := polls.Add(ctx, &tokenPoll{
+		InstanceID:  authz.GetInstance(ctx).InstanceID(),
+		RequestHash: tokenPollRequestHash(requestID),
+		Window:      now.UnixNano() / int64(window),
+	})
+	if err != nil {
+		logging.WithError(err).Warn("unable to check the token poll interval")
+		return nil
+	}
+	if !added {
+		return oidc.ErrSlowDown().WithDescription("the client must poll at most every %d seconds", int64(interval.Seconds()))
+	}
+	return nil
+}
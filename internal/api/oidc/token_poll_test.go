@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+)
+
+func newTokenPollCache() cache.AdderCache[tokenPollIndex, string, *tokenPoll] {
+	return &tokenPollCache{
+		PrunerCache: gomap.NewCache[tokenPollIndex, string, *tokenPoll](context.Background(), []tokenPollIndex{tokenPollIndexWindow}, cache.Config{}),
+	}
+}
+
+// tokenPollCache adds the polls to a memory cache, which is only shared by the tests of a single process.
+type tokenPollCache struct {
+	cache.PrunerCache[tokenPollIndex, string, *tokenPoll]
+	mutex sync.Mutex
+}
+
+func (c *tokenPollCache) Add(ctx context.Context, poll *tokenPoll) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, key := range poll.Keys(tokenPollIndexWindow) {
+		if _, ok := c.Get(ctx, tokenPollIndexWindow, key); ok {
+			return false, nil
+		}
+	}
+	c.Set(ctx, poll)
+	return true, nil
+}
+
+func Test_checkTokenPollInterval(t *testing.T) {
+	const interval = 5 * time.Second
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name      string
+		polls     []time.Duration
+		wantSlows []bool
+	}{
+		{
+			name:      "poll at interval",
+			polls:     []time.Duration{0, interval, 2 * interval, 3 * interval},
+			wantSlows: []bool{false, false, false, false},
+		},
+		{
+			name:      "poll at interval, not aligned to the windows",
+			polls:     []time.Duration{2400 * time.Millisecond, 2400*time.Millisecond + interval},
+			wantSlows: []bool{false, false},
+		},
+		{
+			name:      "poll too fast",
+			polls:     []time.Duration{0, time.Second, 2 * time.Second},
+			wantSlows: []bool{false, true, true},
+		},
+		{
+			name:      "poll too fast, across windows",
+			polls:     []time.Duration{2400 * time.Millisecond, 2600 * time.Millisecond},
+			wantSlows: []bool{false, true},
+		},
+		{
+			name:      "poll after slow down",
+			polls:     []time.Duration{0, time.Second, interval + 5*time.Second},
+			wantSlows: []bool{false, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := newTokenPollCache()
+			ctx := authz.WithInstanceID(context.Background(), "instance")
+			for i, poll := range tt.polls {
+				err := checkTokenPollInterval(ctx, polls, "deviceCode", interval, start.Add(poll))
+				if !tt.wantSlows[i] {
+					require.NoError(t, err, "poll %d", i)
+					continue
+				}
+				var oidcErr *oidc.Error
+				require.ErrorAs(t, err, &oidcErr, "poll %d", i)
+				assert.Equal(t, oidc.SlowDown, oidcErr.ErrorType)
+			}
+		})
+	}
+}
+
+func Test_checkTokenPollInterval_requests(t *testing.T) {
+	polls := newTokenPollCache()
+	now := time.Now()
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+
+	require.NoError(t, checkTokenPollInterval(ctx, polls, "authReqID1", 5*time.Second, now))
+	// the interval is tracked per request and instance
+	require.NoError(t, checkTokenPollInterval(ctx, polls, "authReqID2", 5*time.Second, now))
+	require.NoError(t, checkTokenPollInterval(authz.WithInstanceID(context.Background(), "other"), polls, "authReqID1", 5*time.Second, now))
+	require.Error(t, checkTokenPollInterval(ctx, polls, "authReqID1", 5*time.Second, now))
+}
+
+func Test_checkTokenPollInterval_cacheError(t *testing.T) {
+	err := checkTokenPollInterval(context.Background(), failingTokenPollCache{}, "deviceCode", 5*time.Second, time.Now())
+	require.NoError(t, err)
+}
+
+type failingTokenPollCache struct {
+	cache.AdderCache[tokenPollIndex, string, *tokenPoll]
+}
+
+func (failingTokenPollCache) Add(context.Context, *tokenPoll) (bool, error) {
+	return false, errors.New("connection refused")
+}
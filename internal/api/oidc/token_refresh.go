@@ -26,12 +26,22 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 		return nil, zerrors.ThrowInternal(nil, "OIDC-ga0EP", "Error.Internal")
 	}
 
-	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker())
+	dpopJKT, err := dpopJKTFromTokenRequest(ctx, s.dpopProofs, r.Request, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationDetails, err := parseAuthorizationDetails(ctx, client, r.Form.Get(authorizationDetailsParam))
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker(client, dpopJKT), dpopJKT, authorizationDetails)
 	if err == nil {
-		return response(s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion))
+		return response(withAuthorizationDetails(session)(s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion)))
 	} else if errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "OIDCS-JOI23", "Errors.OIDCSession.RefreshTokenInvalid")) {
 		// We try again for v1 tokens when we encountered specific parsing error
-		return s.refreshTokenV1(ctx, client, r)
+		return s.refreshTokenV1(ctx, client, r, dpopJKT)
 	}
 	return nil, err
 }
@@ -41,7 +51,7 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 // This "upgrades" existing v1 sessions to v2 session without requiring users to re-login.
 //
 // This function can be removed when we retire the v1 token repo.
-func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
+func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest], dpopJKT string) (_ *op.Response, err error) {
 	refreshToken, err := s.repo.RefreshTokenByToken(ctx, r.Data.RefreshToken)
 	if err != nil {
 		return nil, err
@@ -70,6 +80,7 @@ func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.Clien
 		true,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
 	)
 	if err != nil {
 		return nil, err
@@ -85,8 +96,12 @@ func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.Clien
 }
 
 // refreshTokenComplianceChecker validates that the requested scope is a subset of the original auth request scope.
-func refreshTokenComplianceChecker() command.RefreshTokenComplianceChecker {
+// Refresh tokens of public clients are bound to the DPoP key the session was created with (RFC 9449, section 5).
+func refreshTokenComplianceChecker(client op.Client, dpopJKT string) command.RefreshTokenComplianceChecker {
 	return func(_ context.Context, model *command.OIDCSessionWriteModel, requestedScope []string) ([]string, error) {
+		if client.AuthMethod() == oidc.AuthMethodNone && model.DPoPJKT != "" && model.DPoPJKT != dpopJKT {
+			return nil, errInvalidDPoPProof().WithDescription("DPoP proof key does not match the refresh token binding")
+		}
 		return validateRefreshTokenScopes(model.Scope, requestedScope)
 	}
 }
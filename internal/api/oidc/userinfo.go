@@ -44,6 +44,9 @@ func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoReques
 	if err != nil {
 		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("access token invalid").WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError), http.StatusUnauthorized)
 	}
+	if err = s.verifyAccessTokenDPoP(ctx, token, r.Data.AccessToken, r); err != nil {
+		return nil, op.NewStatusError(err, http.StatusUnauthorized)
+	}
 
 	var (
 		projectID string
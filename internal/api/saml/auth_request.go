@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/saml/pkg/provider"
 	"github.com/zitadel/saml/pkg/provider/models"
 	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
@@ -46,6 +47,7 @@ func (p *Provider) CreateResponse(ctx context.Context, authReq models.AuthReques
 		samlComplianceChecker(),
 		samlResponse.Id,
 		p.Expiration(),
+		p.sessionLogout(ctx, authReq.GetIssuer(), samlResponse),
 	); err != nil {
 		return "", "", err
 	}
@@ -53,6 +55,28 @@ func (p *Provider) CreateResponse(ctx context.Context, authReq models.AuthReques
 	return createResponse(samlResponse, authReq.GetBindingType(), authReq.GetAccessConsumerServiceURL(), resp.RelayState, resp.SigAlg, resp.Signature)
 }
 
+// sessionLogout returns the information needed for the single logout at the service provider,
+// nil is returned if the service provider does not support single logout.
+func (p *Provider) sessionLogout(ctx context.Context, entityID string, samlResponse *samlp.ResponseType) *command.SAMLSessionLogout {
+	assertion := samlResponse.Assertion
+	if assertion.Subject == nil || assertion.Subject.NameID == nil {
+		return nil
+	}
+	sp, err := p.storage.GetEntityByID(ctx, entityID)
+	if err != nil || len(sp.Metadata.SPSSODescriptor.SingleLogoutService) == 0 {
+		return nil
+	}
+	logout := &command.SAMLSessionLogout{
+		Issuer:       assertion.Issuer.Text,
+		NameID:       assertion.Subject.NameID.Text,
+		NameIDFormat: assertion.Subject.NameID.Format,
+	}
+	if len(assertion.AuthnStatement) > 0 {
+		logout.SessionIndex = assertion.AuthnStatement[0].SessionIndex
+	}
+	return logout
+}
+
 func createResponse(samlResponse interface{}, binding, acs, relayState, sigAlg, sig string) (string, string, error) {
 	respData, err := xml.Marshal(samlResponse)
 	if err != nil {
@@ -73,7 +73,7 @@ func (p *Storage) getCertificateAndKey(ctx context.Context, usage crypto.KeyUsag
 	}
 
 	if len(certs.Certificates) > 0 {
-		return p.certificateToCertificateAndKey(selectCertificate(certs.Certificates))
+		return CertificateToCertificateAndKey(selectCertificate(certs.Certificates), p.encAlg)
 	}
 
 	var position float64
@@ -169,8 +169,9 @@ func (p *Storage) getMaxKeySequence(ctx context.Context) (float64, error) {
 	)
 }
 
-func (p *Storage) certificateToCertificateAndKey(certificate query.Certificate) (_ *key.CertificateAndKey, err error) {
-	keyData, err := crypto.Decrypt(certificate.Key(), p.encAlg)
+// CertificateToCertificateAndKey decrypts the key of the certificate for signing.
+func CertificateToCertificateAndKey(certificate query.Certificate, encAlg crypto.EncryptionAlgorithm) (_ *key.CertificateAndKey, err error) {
+	keyData, err := crypto.Decrypt(certificate.Key(), encAlg)
 	if err != nil {
 		return nil, err
 	}
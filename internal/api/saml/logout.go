@@ -0,0 +1,438 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/key"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	samlxml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SOAPBinding = "urn:oasis:names:tc:SAML:2.0:bindings:SOAP"
+
+	logoutReasonUser          = "urn:oasis:names:tc:SAML:2.0:logout:user"
+	samlVersion               = "2.0"
+	defaultSignatureAlgorithm = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	timeFormat                = "2006-01-02T15:04:05.999Z"
+)
+
+var logoutPostTemplate = template.Must(template.New("logout").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<noscript><p>Note: Since your browser does not support JavaScript, you must press the Continue button once to proceed.</p></noscript>
+<form method="post" action="{{ .URL }}">
+<input type="hidden" name="{{ .Parameter }}" value="{{ .Message }}" />
+{{- if .RelayState }}
+<input type="hidden" name="RelayState" value="{{ .RelayState }}" />
+{{- end }}
+<noscript><input type="submit" value="Continue" /></noscript>
+</form>
+</body>
+</html>`))
+
+// LogoutRequest is sent to the service providers to terminate the session of the user.
+// In contrast to [samlp.LogoutRequestType], the elements are ordered as defined by the schema,
+// as service providers might reject the request otherwise.
+type LogoutRequest struct {
+	XMLName      xml.Name                `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+	Id           string                  `xml:"ID,attr"`
+	Version      string                  `xml:"Version,attr"`
+	IssueInstant string                  `xml:"IssueInstant,attr"`
+	Destination  string                  `xml:"Destination,attr,omitempty"`
+	Reason       string                  `xml:"Reason,attr,omitempty"`
+	NotOnOrAfter string                  `xml:"NotOnOrAfter,attr,omitempty"`
+	Issuer       *saml.NameIDType        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Signature    *xml_dsig.SignatureType `xml:"Signature"`
+	NameID       *saml.NameIDType        `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+	SessionIndex []string                `xml:"SessionIndex,omitempty"`
+}
+
+// NewLogoutRequest creates a LogoutRequest for the user (nameID) signed with the provided certificate and key.
+func NewLogoutRequest(issuer, destination, nameID, nameIDFormat, sessionIndex string, lifetime time.Duration, certAndKey *key.CertificateAndKey) (*LogoutRequest, error) {
+	request := newLogoutRequest(provider.NewID(), issuer, destination, nameID, nameIDFormat, sessionIndex, lifetime)
+	signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, defaultSignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	request.Signature, err = signature.Create(signer, request)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func newLogoutRequest(id, issuer, destination, nameID, nameIDFormat, sessionIndex string, lifetime time.Duration) *LogoutRequest {
+	now := time.Now().UTC()
+	request := &LogoutRequest{
+		Id:           id,
+		Version:      samlVersion,
+		IssueInstant: now.Format(timeFormat),
+		Destination:  destination,
+		Reason:       logoutReasonUser,
+		NotOnOrAfter: now.Add(lifetime).Format(timeFormat),
+		Issuer: &saml.NameIDType{
+			Format: "urn:oasis:names:tc:SAML:2.0:nameid-format:entity",
+			Text:   issuer,
+		},
+		NameID: &saml.NameIDType{
+			Format: nameIDFormat,
+			Text:   nameID,
+		},
+	}
+	if sessionIndex != "" {
+		request.SessionIndex = []string{sessionIndex}
+	}
+	return request
+}
+
+// HttpHandler serves the single logout endpoint, all other endpoints are served by the library.
+func (p *Provider) HttpHandler() http.Handler {
+	next := p.Provider.HttpHandler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == p.logoutEndpoint {
+			p.logoutHandler.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *Provider) newLogoutHandler(interceptors []provider.HttpInterceptor) http.Handler {
+	handler := http.Handler(http.HandlerFunc(p.handleLogout))
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		handler = interceptors[i](handler)
+	}
+	return provider.NewIssuerInterceptor(p.IssuerFromRequest).Handler(handler)
+}
+
+type logoutRequestParams struct {
+	request    *samlp.LogoutRequestType
+	binding    string
+	relayState string
+	signed     bool
+}
+
+// handleLogout handles the LogoutRequest of a service provider (HTTP-Redirect and HTTP-POST binding).
+// The sessions of the user are terminated and the other service providers are notified
+// through the user agent if they have no SOAP endpoint for the back-channel.
+// The LogoutResponse is sent after the other service providers responded, see [Provider.handleLogoutResponse].
+func (p *Provider) handleLogout(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer span.End()
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse form: %v", err), http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("SAMLResponse") != "" {
+		p.handleLogoutResponse(ctx, w, r)
+		return
+	}
+	params, sp, err := p.parseLogoutRequest(ctx, r)
+	if err != nil {
+		logging.WithError(err).Info("invalid saml logout request")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	responseURL, binding := logoutResponseEndpoint(sp.Metadata.SPSSODescriptor.SingleLogoutService, params.binding)
+	if responseURL == "" {
+		http.Error(w, "no single logout service registered for the service provider", http.StatusBadRequest)
+		return
+	}
+
+	status, message := provider.StatusCodeSuccess, ""
+	var chain *command.SAMLFrontChannelLogoutWriteModel
+	if err = checkLogoutRequestValidity(params.request, time.Now()); err != nil {
+		status, message = provider.StatusCodeRequestDenied, err.Error()
+	} else if chain, err = p.terminateSessions(ctx, params, p.frontChannelLogout(ctx, params, responseURL, binding)); err != nil {
+		status, message = logoutErrorStatus(err)
+	}
+	if chain != nil {
+		p.continueFrontChannelLogout(ctx, w, r, chain)
+		return
+	}
+
+	err = p.sendLogoutResponse(ctx, w, r, p.newLogoutResponse(ctx, params.request.Id, responseURL, status, message), responseURL, binding, params.relayState)
+	if err != nil {
+		logging.WithError(err).Error("unable to send saml logout response")
+		http.Error(w, "unable to send logout response", http.StatusInternalServerError)
+	}
+}
+
+// errUnsignedLogoutWithoutSession is returned if an unsigned LogoutRequest
+// was not sent by a user agent with a session of the user.
+var errUnsignedLogoutWithoutSession = errors.New("unsigned request requires a session of the user")
+
+func logoutErrorStatus(err error) (status, message string) {
+	if errors.Is(err, errUnsignedLogoutWithoutSession) {
+		return provider.StatusCodeRequestDenied, err.Error()
+	}
+	logging.WithError(err).Error("unable to terminate sessions on saml logout request")
+	return provider.StatusCodeResponder, "unable to terminate sessions"
+}
+
+func (p *Provider) parseLogoutRequest(ctx context.Context, r *http.Request) (*logoutRequestParams, *serviceprovider.ServiceProvider, error) {
+	samlRequest := r.Form.Get("SAMLRequest")
+	if samlRequest == "" {
+		return nil, nil, fmt.Errorf("no SAMLRequest provided")
+	}
+	params := &logoutRequestParams{
+		binding:    provider.PostBinding,
+		relayState: r.Form.Get("RelayState"),
+	}
+	if r.Method == http.MethodGet {
+		params.binding = provider.RedirectBinding
+	}
+	data, err := decodeMessage(r, samlRequest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	params.request = new(samlp.LogoutRequestType)
+	if err = xml.Unmarshal(data, params.request); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	if params.request.Issuer == nil || params.request.Issuer.Text == "" || params.request.NameID == nil || params.request.NameID.Text == "" {
+		return nil, nil, fmt.Errorf("issuer and nameID of the request are required")
+	}
+	sp, err := p.storage.GetEntityByID(ctx, params.request.Issuer.Text)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find registered service provider: %w", err)
+	}
+	if params.signed, err = verifyLogoutRequestSignature(sp, params, r.Form, data); err != nil {
+		return nil, nil, fmt.Errorf("failed to verify signature: %w", err)
+	}
+	return params, sp, nil
+}
+
+// verifyLogoutRequestSignature verifies the signature of the request and returns if the request was signed.
+// A signature is required if the service provider has a certificate registered.
+// Unsigned requests are only accepted from the user agent of the user, see [Provider.terminateSessions].
+func verifyLogoutRequestSignature(sp *serviceprovider.ServiceProvider, params *logoutRequestParams, form url.Values, data []byte) (bool, error) {
+	hasCertificate := len(samlxml.GetCertsFromKeyDescriptors(sp.Metadata.SPSSODescriptor.KeyDescriptor)) > 0
+	if params.binding == provider.RedirectBinding && form.Get("Signature") != "" {
+		return true, sp.ValidateRedirectSignature(form.Get("SAMLRequest"), params.relayState, form.Get("SigAlg"), form.Get("Signature"))
+	}
+	if params.binding == provider.PostBinding && params.request.Signature != nil {
+		return true, sp.ValidatePostSignature(string(data))
+	}
+	if hasCertificate {
+		return false, fmt.Errorf("signature required but missing")
+	}
+	return false, nil
+}
+
+func checkLogoutRequestValidity(request *samlp.LogoutRequestType, now time.Time) error {
+	if request.NotOnOrAfter == "" {
+		return nil
+	}
+	notOnOrAfter, err := time.Parse(time.RFC3339, request.NotOnOrAfter)
+	if err != nil {
+		return fmt.Errorf("invalid NotOnOrAfter: %w", err)
+	}
+	if !now.Before(notOnOrAfter) {
+		return fmt.Errorf("request expired")
+	}
+	return nil
+}
+
+func decodeMessage(r *http.Request, message string) ([]byte, error) {
+	encoding := r.Form.Get("SAMLEncoding")
+	if r.Method == http.MethodGet && encoding == "" {
+		encoding = samlxml.EncodingDeflate
+	}
+	return samlxml.InflateAndDecode(encoding, true, message)
+}
+
+// terminateSessions terminates the sessions registered for the service provider.
+// If none is registered, the user authenticated through login V1 and the sessions of the user agent are terminated.
+// The issuer and nameID of an unsigned request can't be trusted, so it must be sent by the user agent (session cookie)
+// of the user and only the sessions of the user agent are terminated.
+// If the logout has to be propagated through the user agent, the started front-channel logout is returned.
+func (p *Provider) terminateSessions(ctx context.Context, params *logoutRequestParams, frontChannel *command.SAMLFrontChannelLogout) (*command.SAMLFrontChannelLogoutWriteModel, error) {
+	ctx = setContextUserSystem(ctx)
+	request := params.request
+	if params.signed {
+		found, chain, err := p.command.SAMLLogoutRequested(ctx, request.Issuer.Text, request.NameID.Text, request.SessionIndex, frontChannel)
+		if err != nil || found {
+			return chain, err
+		}
+	}
+	userAgentID, userID, sessions, err := p.userAgentSessions(ctx, request.NameID.Text)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		if !params.signed {
+			return nil, errUnsignedLogoutWithoutSession
+		}
+		return nil, nil
+	}
+	return nil, p.command.HumansSignOut(authz.SetCtxData(ctx, authz.CtxData{UserID: userID}), userAgentID, sessions)
+}
+
+// userAgentSessions returns the sessions of the user (identified by the login name) on the user agent of the request.
+func (p *Provider) userAgentSessions(ctx context.Context, loginName string) (userAgentID, userID string, _ []command.HumanSignOutSession, err error) {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return "", "", nil, nil
+	}
+	user, err := p.storage.query.GetUserByLoginName(ctx, false, loginName)
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return "", "", nil, nil
+		}
+		return "", "", nil, err
+	}
+	sessions, err := p.storage.repo.UserSessionsByAgentID(ctx, userAgentID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	sessions = slices.DeleteFunc(sessions, func(session command.HumanSignOutSession) bool {
+		return session.UserID != user.ID
+	})
+	return userAgentID, user.ID, sessions, nil
+}
+
+// logoutResponseEndpoint returns the endpoint to send the LogoutResponse to,
+// preferring the binding used by the service provider for the request.
+func logoutResponseEndpoint(services []md.EndpointType, requestBinding string) (string, string) {
+	var location, binding string
+	for _, service := range services {
+		if service.Binding != provider.RedirectBinding && service.Binding != provider.PostBinding {
+			continue
+		}
+		if location != "" && service.Binding != requestBinding {
+			continue
+		}
+		location, binding = service.ResponseLocation, service.Binding
+		if location == "" {
+			location = service.Location
+		}
+		if binding == requestBinding {
+			break
+		}
+	}
+	return location, binding
+}
+
+func (p *Provider) newLogoutResponse(ctx context.Context, requestID, destination, status, message string) *samlp.LogoutResponseType {
+	return &samlp.LogoutResponseType{
+		Id:           provider.NewID(),
+		InResponseTo: requestID,
+		Version:      samlVersion,
+		IssueInstant: time.Now().UTC().Format(p.Timeformat()),
+		Destination:  destination,
+		Issuer: &saml.NameIDType{
+			Format: "urn:oasis:names:tc:SAML:2.0:nameid-format:entity",
+			Text:   p.entityID(ctx),
+		},
+		Status: samlp.StatusType{
+			StatusCode:    samlp.StatusCodeType{Value: status},
+			StatusMessage: message,
+		},
+	}
+}
+
+func (p *Provider) entityID(ctx context.Context) string {
+	return p.metadataEndpoint.Absolute(provider.IssuerFromContext(ctx))
+}
+
+func (p *Provider) sendLogoutResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, response *samlp.LogoutResponseType, responseURL, binding, relayState string) error {
+	certAndKey, err := p.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return err
+	}
+	if binding == provider.PostBinding {
+		signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, p.signatureAlgorithm)
+		if err != nil {
+			return err
+		}
+		if response.Signature, err = signature.Create(signer, response); err != nil {
+			return err
+		}
+	}
+	data, err := samlxml.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return p.sendLogoutMessage(w, r, "SAMLResponse", data, responseURL, binding, relayState, certAndKey)
+}
+
+// sendLogoutMessage sends the (already signed if POST binding) message as parameter (SAMLRequest or SAMLResponse)
+// through the user agent to the url.
+func (p *Provider) sendLogoutMessage(w http.ResponseWriter, r *http.Request, parameter string, data []byte, url, binding, relayState string, certAndKey *key.CertificateAndKey) error {
+	if binding == provider.PostBinding {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		return logoutPostTemplate.Execute(w, map[string]string{
+			"URL":        url,
+			"Parameter":  parameter,
+			"Message":    base64.StdEncoding.EncodeToString(data),
+			"RelayState": relayState,
+		})
+	}
+	location, err := p.signedRedirectURL(parameter, data, url, relayState, certAndKey)
+	if err != nil {
+		return err
+	}
+	http.Redirect(w, r, location, http.StatusFound)
+	return nil
+}
+
+func (p *Provider) signedRedirectURL(parameter string, data []byte, responseURL, relayState string, certAndKey *key.CertificateAndKey) (string, error) {
+	encoded, err := samlxml.DeflateAndBase64(data)
+	if err != nil {
+		return "", err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(certAndKey.Certificate, certAndKey.Key)
+	if err != nil {
+		return "", err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, p.signatureAlgorithm)
+	if err != nil {
+		return "", err
+	}
+	query := buildRedirectQuery(parameter, string(encoded), relayState, p.signatureAlgorithm)
+	sig, err := signature.CreateRedirect(signingContext, query)
+	if err != nil {
+		return "", err
+	}
+	separator := "?"
+	if strings.Contains(responseURL, "?") {
+		separator = "&"
+	}
+	return responseURL + separator + query + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// buildRedirectQuery returns the query of the HTTP-Redirect binding to be signed.
+// In contrast to [provider.BuildRedirectQuery], the message can also be a SAMLRequest.
+func buildRedirectQuery(parameter, message, relayState, sigAlg string) string {
+	query := parameter + "=" + url.QueryEscape(message)
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	return query + "&SigAlg=" + url.QueryEscape(sigAlg)
+}
@@ -0,0 +1,228 @@
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	samlxml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// frontChannelLogoutRequestLifetime is the lifetime of a LogoutRequest sent through the user agent.
+const frontChannelLogoutRequestLifetime = 5 * time.Minute
+
+// frontChannelLogout returns the LogoutRequest of the service provider to propagate the logout through the user agent
+// to the other service providers with a single logout service for the HTTP-Redirect or HTTP-POST binding.
+// If back-channel logout is enabled, service providers with a SOAP endpoint are notified through the back-channel instead.
+func (p *Provider) frontChannelLogout(ctx context.Context, params *logoutRequestParams, responseURL, binding string) *command.SAMLFrontChannelLogout {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil
+	}
+	return &command.SAMLFrontChannelLogout{
+		UserAgentID:  userAgentID,
+		InResponseTo: params.request.Id,
+		ResponseURL:  responseURL,
+		Binding:      binding,
+		RelayState:   params.relayState,
+		Supported: func(entityID string) bool {
+			sp, err := p.storage.GetEntityByID(ctx, entityID)
+			if err != nil {
+				logging.WithFields("entityID", entityID).OnError(err).Info("unable to get service provider for saml front-channel logout")
+				return false
+			}
+			services := sp.Metadata.SPSSODescriptor.SingleLogoutService
+			if p.backChannelLogout && hasSOAPEndpoint(services) {
+				return false
+			}
+			endpoint, _ := frontChannelLogoutEndpoint(services)
+			return endpoint != ""
+		},
+	}
+}
+
+func hasSOAPEndpoint(services []md.EndpointType) bool {
+	for _, service := range services {
+		if service.Binding == SOAPBinding {
+			return true
+		}
+	}
+	return false
+}
+
+// frontChannelLogoutEndpoint returns the endpoint to send the LogoutRequest to through the user agent,
+// preferring the HTTP-Redirect binding.
+func frontChannelLogoutEndpoint(services []md.EndpointType) (string, string) {
+	var location, binding string
+	for _, service := range services {
+		switch service.Binding {
+		case provider.RedirectBinding:
+			return service.Location, service.Binding
+		case provider.PostBinding:
+			if location == "" {
+				location, binding = service.Location, service.Binding
+			}
+		}
+	}
+	return location, binding
+}
+
+// continueFrontChannelLogout sends the next LogoutRequest through the user agent.
+// Service providers, which can't be requested, are marked as failed and skipped.
+// After all service providers responded, the LogoutResponse is sent to the service provider which requested the logout.
+func (p *Provider) continueFrontChannelLogout(ctx context.Context, w http.ResponseWriter, r *http.Request, chain *command.SAMLFrontChannelLogoutWriteModel) {
+	for request := chain.Next(); request != nil; request = chain.Next() {
+		failure, err := p.sendFrontChannelLogoutRequest(ctx, w, r, chain.AggregateID, request)
+		if err != nil {
+			logging.WithError(err).Error("unable to send saml logout request through the user agent")
+			http.Error(w, "unable to send logout request", http.StatusInternalServerError)
+			return
+		}
+		if failure == "" {
+			return
+		}
+		logging.WithFields("entityID", request.EntityID, "reason", failure).Info("saml front-channel logout failed")
+		chain, err = p.command.SAMLFrontChannelLogoutResponded(setContextUserSystem(ctx), chain.AggregateID, chain.UserAgentID, request.EntityID, request.RequestID, failure)
+		if err != nil {
+			logging.WithError(err).Error("unable to continue saml front-channel logout")
+			http.Error(w, "unable to continue logout", http.StatusInternalServerError)
+			return
+		}
+	}
+	err := p.sendLogoutResponse(ctx, w, r, p.newLogoutResponse(ctx, chain.InResponseTo, chain.ResponseURL, provider.StatusCodeSuccess, ""), chain.ResponseURL, chain.Binding, chain.RelayState)
+	if err != nil {
+		logging.WithError(err).Error("unable to send saml logout response")
+		http.Error(w, "unable to send logout response", http.StatusInternalServerError)
+	}
+}
+
+// sendFrontChannelLogoutRequest sends the LogoutRequest through the user agent with the session ID as RelayState.
+// If the service provider cannot be requested, the reason is returned as failure.
+func (p *Provider) sendFrontChannelLogoutRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, sessionID string, request *command.SAMLFrontChannelLogoutRequest) (failure string, err error) {
+	sp, err := p.storage.GetEntityByID(ctx, request.EntityID)
+	if zerrors.IsNotFound(err) {
+		return "service provider not found", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	endpoint, binding := frontChannelLogoutEndpoint(sp.Metadata.SPSSODescriptor.SingleLogoutService)
+	if endpoint == "" {
+		return "service provider has no single logout service for the user agent", nil
+	}
+	certAndKey, err := p.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	logoutRequest := newLogoutRequest(request.RequestID, request.Issuer, endpoint, request.NameID, request.NameIDFormat, request.SessionIndex, frontChannelLogoutRequestLifetime)
+	if binding == provider.PostBinding {
+		signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, p.signatureAlgorithm)
+		if err != nil {
+			return "", err
+		}
+		if logoutRequest.Signature, err = signature.Create(signer, logoutRequest); err != nil {
+			return "", err
+		}
+	}
+	data, err := samlxml.Marshal(logoutRequest)
+	if err != nil {
+		return "", err
+	}
+	return "", p.sendLogoutMessage(w, r, "SAMLRequest", data, endpoint, binding, sessionID, certAndKey)
+}
+
+// handleLogoutResponse handles the LogoutResponse of a service provider to a LogoutRequest sent through the user agent
+// and continues the front-channel logout with the session ID of the RelayState.
+func (p *Provider) handleLogoutResponse(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	response, sp, err := parseLogoutResponse(ctx, p.storage, r)
+	if err != nil {
+		logging.WithError(err).Info("invalid saml logout response")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var failure string
+	if response.Status.StatusCode.Value != provider.StatusCodeSuccess {
+		failure = fmt.Sprintf("service provider responded with status %s: %s", response.Status.StatusCode.Value, response.Status.StatusMessage)
+	}
+	userAgentID, _ := middleware.UserAgentIDFromCtx(ctx)
+	chain, err := p.command.SAMLFrontChannelLogoutResponded(setContextUserSystem(ctx), r.Form.Get("RelayState"), userAgentID, sp.GetEntityID(), response.InResponseTo, failure)
+	if err != nil {
+		logging.WithError(err).Info("unexpected saml logout response")
+		http.Error(w, "unexpected logout response", http.StatusBadRequest)
+		return
+	}
+	p.continueFrontChannelLogout(ctx, w, r, chain)
+}
+
+func parseLogoutResponse(ctx context.Context, storage *Storage, r *http.Request) (*samlp.LogoutResponseType, *serviceprovider.ServiceProvider, error) {
+	data, err := decodeMessage(r, r.Form.Get("SAMLResponse"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	response := new(samlp.LogoutResponseType)
+	if err = xml.Unmarshal(data, response); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if response.Issuer == nil || response.Issuer.Text == "" || response.InResponseTo == "" {
+		return nil, nil, fmt.Errorf("issuer and InResponseTo of the response are required")
+	}
+	sp, err := storage.GetEntityByID(ctx, response.Issuer.Text)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find registered service provider: %w", err)
+	}
+	if err = verifyLogoutResponseSignature(sp, r, response, data); err != nil {
+		return nil, nil, fmt.Errorf("failed to verify signature: %w", err)
+	}
+	return response, sp, nil
+}
+
+// verifyLogoutResponseSignature verifies the signature of the response,
+// which is required if the service provider has a certificate registered.
+// Responses of service providers without certificate are bound to the request by its ID and the user agent.
+func verifyLogoutResponseSignature(sp *serviceprovider.ServiceProvider, r *http.Request, response *samlp.LogoutResponseType, data []byte) error {
+	certs, err := signature.ParseCertificates(samlxml.GetCertsFromKeyDescriptors(sp.Metadata.SPSSODescriptor.KeyDescriptor))
+	if err != nil {
+		return err
+	}
+	if r.Method == http.MethodGet && r.Form.Get("Signature") != "" {
+		return verifyRedirectResponseSignature(certs, r.Form)
+	}
+	if r.Method != http.MethodGet && response.Signature != nil {
+		return sp.ValidatePostSignature(string(data))
+	}
+	if len(certs) > 0 {
+		return fmt.Errorf("signature required but missing")
+	}
+	return nil
+}
+
+func verifyRedirectResponseSignature(certs []*x509.Certificate, form url.Values) error {
+	sig, err := base64.StdEncoding.DecodeString(form.Get("Signature"))
+	if err != nil {
+		return err
+	}
+	query := []byte(buildRedirectQuery("SAMLResponse", form.Get("SAMLResponse"), form.Get("RelayState"), form.Get("SigAlg")))
+	err = errors.New("no certificate registered")
+	for _, cert := range certs {
+		if err = signature.ValidateRedirect(form.Get("SigAlg"), query, sig, cert.PublicKey); err == nil {
+			return nil
+		}
+	}
+	return err
+}
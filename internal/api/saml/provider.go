@@ -3,6 +3,7 @@ package saml
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/zitadel/saml/pkg/provider"
 
@@ -26,11 +27,26 @@ const (
 type Config struct {
 	ProviderConfig    *provider.Config
 	DefaultLoginURLV2 string
+	BackChannelLogout BackChannelLogoutConfig
+}
+
+// BackChannelLogoutConfig configures the LogoutRequests sent to the service providers through the SOAP binding.
+type BackChannelLogoutConfig struct {
+	Enabled bool
+	// Timeout of a single request to the service provider
+	Timeout time.Duration
 }
 
 type Provider struct {
 	*provider.Provider
 	command *command.Commands
+	storage *Storage
+
+	logoutEndpoint     string
+	logoutHandler      http.Handler
+	metadataEndpoint   provider.Endpoint
+	signatureAlgorithm string
+	backChannelLogout  bool
 }
 
 func NewProvider(
@@ -64,18 +80,19 @@ func NewProvider(
 		return nil, err
 	}
 
+	interceptors := []provider.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
+		http_utils.CopyHeadersToContext,
+		middleware.ActivityHandler,
+	}
 	options := []provider.Option{
-		provider.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
-			http_utils.CopyHeadersToContext,
-			middleware.ActivityHandler,
-		),
-		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
+		provider.WithHttpInterceptors(interceptors...),
+		provider.WithCustomTimeFormat(timeFormat),
 	}
 	if !externalSecure {
 		options = append(options, provider.WithAllowInsecure())
@@ -90,10 +107,17 @@ func NewProvider(
 	if err != nil {
 		return nil, err
 	}
-	return &Provider{
-		p,
-		command,
-	}, nil
+	prov := &Provider{
+		Provider:           p,
+		command:            command,
+		storage:            provStorage,
+		logoutEndpoint:     logoutEndpoint(conf.ProviderConfig),
+		metadataEndpoint:   metadataEndpoint(conf.ProviderConfig),
+		signatureAlgorithm: signatureAlgorithm(conf.ProviderConfig),
+		backChannelLogout:  conf.BackChannelLogout.Enabled,
+	}
+	prov.logoutHandler = prov.newLogoutHandler(interceptors)
+	return prov, nil
 }
 
 func newStorage(
@@ -120,6 +144,27 @@ func newStorage(
 	}, nil
 }
 
+func logoutEndpoint(config *provider.Config) string {
+	if config.IDPConfig != nil && config.IDPConfig.Endpoints != nil && config.IDPConfig.Endpoints.SingleLogOut != nil {
+		return config.IDPConfig.Endpoints.SingleLogOut.Relative()
+	}
+	return provider.NewEndpoint(provider.DefaultSingleLogOutEndpoint).Relative()
+}
+
+func metadataEndpoint(config *provider.Config) provider.Endpoint {
+	if config.Metadata != nil {
+		return *config.Metadata
+	}
+	return provider.NewEndpoint(provider.DefaultMetadataEndpoint)
+}
+
+func signatureAlgorithm(config *provider.Config) string {
+	if config.IDPConfig != nil && config.IDPConfig.SignatureAlgorithm != "" {
+		return config.IDPConfig.SignatureAlgorithm
+	}
+	return defaultSignatureAlgorithm
+}
+
 func publicAuthPathPrefixes(config *provider.Config) []string {
 	metadataEndpoint := HandlerPrefix + provider.DefaultMetadataEndpoint
 	certificateEndpoint := HandlerPrefix + provider.DefaultCertificateEndpoint
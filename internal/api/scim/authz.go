@@ -28,6 +28,27 @@ var AuthMapping = authz.MethodMapping{
 	"DELETE:/scim/v2/" + http.OrgIdInPathVariable + "/Users/{id}": {
 		Permission: domain.PermissionUserDelete,
 	},
+	"POST:/scim/v2/" + http.OrgIdInPathVariable + "/Groups": {
+		Permission: domain.PermissionProjectRoleWrite,
+	},
+	"POST:/scim/v2/" + http.OrgIdInPathVariable + "/Groups/.search": {
+		Permission: domain.PermissionProjectRoleRead,
+	},
+	"GET:/scim/v2/" + http.OrgIdInPathVariable + "/Groups": {
+		Permission: domain.PermissionProjectRoleRead,
+	},
+	"GET:/scim/v2/" + http.OrgIdInPathVariable + "/Groups/{id}": {
+		Permission: domain.PermissionProjectRoleRead,
+	},
+	"PUT:/scim/v2/" + http.OrgIdInPathVariable + "/Groups/{id}": {
+		Permission: domain.PermissionProjectRoleWrite,
+	},
+	"PATCH:/scim/v2/" + http.OrgIdInPathVariable + "/Groups/{id}": {
+		Permission: domain.PermissionProjectRoleWrite,
+	},
+	"DELETE:/scim/v2/" + http.OrgIdInPathVariable + "/Groups/{id}": {
+		Permission: domain.PermissionProjectRoleDelete,
+	},
 	"POST:/scim/v2/" + http.OrgIdInPathVariable + "/Bulk": {
 		Permission: "authenticated",
 	},
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type Config struct {
 	DocumentationUrl      string
 	AuthenticationSchemes []*ServiceProviderConfigAuthenticationScheme
@@ -7,12 +9,36 @@ type Config struct {
 	PhoneVerified         bool
 	MaxRequestBodySize    int64
 	Bulk                  BulkConfig
+	Groups                GroupsConfig
+	Provisioning          ProvisioningConfig
 }
 
 type BulkConfig struct {
 	MaxOperationsCount int
 }
 
+// GroupsConfig configures how scim groups are mapped:
+// each group is a role of the project with the name ProjectName in the organization of the request,
+// the members of a group are the users granted this role.
+type GroupsConfig struct {
+	ProjectName string
+}
+
+// ProvisioningConfig configures the provisioning of the users granted on a project
+// to the scim service providers of its applications.
+type ProvisioningConfig struct {
+	// Timeout of a call to a scim service provider
+	Timeout time.Duration
+	// Workers is the amount of users synced in parallel, only used if the queue is available
+	Workers uint8
+	// MaxAttempts is the amount of attempts to sync a user, after which the job is discarded
+	MaxAttempts uint8
+	// InitialBackoff is the delay of the first retry, it is doubled on every further retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries
+	MaxBackoff time.Duration
+}
+
 type ServiceProviderConfigAuthenticationScheme struct {
 	Name             string `json:"name"`
 	Description      string `json:"description"`
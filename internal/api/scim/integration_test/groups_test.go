@@ -0,0 +1,145 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources"
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/internal/integration/scim"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2"
+)
+
+func TestGroups_lifecycle(t *testing.T) {
+	member1 := Instance.CreateHumanUser(CTX)
+	member2 := Instance.CreateHumanUser(CTX)
+	defer func() {
+		for _, userID := range []string{member1.UserId, member2.UserId} {
+			_, err := Instance.Client.UserV2.DeleteUser(CTX, &user.DeleteUserRequest{UserId: userID})
+			require.NoError(t, err)
+		}
+	}()
+
+	// the display name is not url-safe, the id must be
+	displayName := "group / " + gofakeit.LetterN(10)
+	created, err := Instance.Client.SCIM.Groups.Create(CTX, Instance.DefaultOrg.Id, []byte(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": %q,
+		"members": [{ "value": %q }]
+	}`, displayName, member1.UserId)))
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.NotEqual(t, displayName, created.ID)
+	assert.Equal(t, displayName, created.DisplayName)
+
+	retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		fetched, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, created.ID)
+		require.NoError(tt, err)
+		assert.Equal(tt, []string{member1.UserId}, groupMemberIDs(fetched))
+	}, retryDuration, tick)
+
+	// add the second member and remove the first one
+	err = Instance.Client.SCIM.Groups.Update(CTX, Instance.DefaultOrg.Id, created.ID, []byte(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{ "op": "add", "path": "members", "value": [{ "value": %q }] },
+			{ "op": "remove", "path": "members[value eq %q]" }
+		]
+	}`, member2.UserId, member1.UserId)))
+	require.NoError(t, err)
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		fetched, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, created.ID)
+		require.NoError(tt, err)
+		assert.Equal(tt, []string{member2.UserId}, groupMemberIDs(fetched))
+	}, retryDuration, tick)
+
+	_, err = Instance.Client.SCIM.Groups.Create(CTX, Instance.DefaultOrg.Id, []byte(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": %q
+	}`, displayName)))
+	scim.RequireScimError(t, http.StatusConflict, err)
+
+	replaced, err := Instance.Client.SCIM.Groups.Replace(CTX, Instance.DefaultOrg.Id, created.ID, []byte(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "renamed %s",
+		"members": []
+	}`, displayName)))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, replaced.ID)
+	assert.Equal(t, "renamed "+displayName, replaced.DisplayName)
+	assert.Empty(t, replaced.Members)
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		list, err := Instance.Client.SCIM.Groups.List(CTX, Instance.DefaultOrg.Id, &scim.ListRequest{
+			Filter: gu.Ptr(fmt.Sprintf(`displayName eq "renamed %s"`, displayName)),
+		})
+		require.NoError(tt, err)
+		require.Len(tt, list.Resources, 1)
+		assert.Equal(tt, created.ID, list.Resources[0].ID)
+	}, retryDuration, tick)
+
+	err = Instance.Client.SCIM.Groups.Delete(CTX, Instance.DefaultOrg.Id, created.ID)
+	require.NoError(t, err)
+
+	_, err = Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, created.ID)
+	scim.RequireScimError(t, http.StatusNotFound, err)
+}
+
+func TestGroups_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		orgID       string
+		errorStatus int
+	}{
+		{
+			name:        "not authenticated",
+			ctx:         context.Background(),
+			errorStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "unknown group id",
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "another org",
+			orgID:       SecondaryOrganization.OrganizationId,
+			errorStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = CTX
+			}
+
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = Instance.DefaultOrg.Id
+			}
+			_, err := Instance.Client.SCIM.Groups.Get(ctx, orgID, "unknown")
+			scim.RequireScimError(t, tt.errorStatus, err)
+		})
+	}
+}
+
+func groupMemberIDs(group *resources.ScimGroup) []string {
+	ids := make([]string, len(group.Members))
+	for i, member := range group.Members {
+		ids[i] = member.Value
+	}
+	return ids
+}
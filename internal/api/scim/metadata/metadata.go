@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
@@ -30,6 +31,15 @@ const (
 	KeyAddresses                Key = KeyPrefix + "addresses"
 	KeyEntitlements             Key = KeyPrefix + "entitlements"
 	KeyRoles                    Key = KeyPrefix + "roles"
+
+	// keys of the attributes of the enterprise user extension
+	keyPrefixEnterprise     = KeyPrefix + "enterprise:"
+	KeyEmployeeNumber   Key = keyPrefixEnterprise + "employeeNumber"
+	KeyCostCenter       Key = keyPrefixEnterprise + "costCenter"
+	KeyOrganization     Key = keyPrefixEnterprise + "organization"
+	KeyDivision         Key = keyPrefixEnterprise + "division"
+	KeyDepartment       Key = keyPrefixEnterprise + "department"
+	KeyManager          Key = keyPrefixEnterprise + "manager"
 )
 
 var (
@@ -47,6 +57,12 @@ var (
 		KeyAddresses,
 		KeyEntitlements,
 		KeyRoles,
+		KeyEmployeeNumber,
+		KeyCostCenter,
+		KeyOrganization,
+		KeyDivision,
+		KeyDepartment,
+		KeyManager,
 	}
 
 	AttributePathToMetadataKeys = map[string][]Key{
@@ -64,6 +80,14 @@ var (
 		"addresses":            {KeyAddresses},
 		"entitlements":         {KeyEntitlements},
 		"roles":                {KeyRoles},
+		strings.ToLower(string(schemas.IdEnterpriseUser)): {
+			KeyEmployeeNumber,
+			KeyCostCenter,
+			KeyOrganization,
+			KeyDivision,
+			KeyDepartment,
+			KeyManager,
+		},
 	}
 )
 
@@ -0,0 +1,214 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+)
+
+const (
+	contentTypeScim = "application/scim+json"
+	usersPath       = "/" + string(schemas.UsersResourceType)
+)
+
+var (
+	errNotFound = errors.New("scim user not found")
+	errConflict = errors.New("scim user already exists")
+	// errMissingID is returned if the service provider does not return the id of a created user
+	errMissingID = errors.New("scim service provider returned no id for the created user")
+)
+
+// StatusError is returned if the scim service provider responds with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+	Detail     string
+}
+
+func (e *StatusError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("scim service provider responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("scim service provider responded with status %d: %s", e.StatusCode, e.Detail)
+}
+
+type patchRequest struct {
+	Schemas    []schemas.ScimSchemaType `json:"schemas"`
+	Operations []*patchOperation        `json:"Operations"`
+}
+
+type patchOperation struct {
+	Operation string `json:"op"`
+	Path      string `json:"path,omitempty"`
+	Value     any    `json:"value"`
+}
+
+// remoteUser contains the attributes of the users returned by the service provider which are used,
+// other attributes are ignored to tolerate deviations of service providers from the schema.
+type remoteUser struct {
+	ID string `json:"id"`
+}
+
+type remoteUserList struct {
+	Resources []*remoteUser `json:"Resources"`
+}
+
+type errorResponse struct {
+	Detail string `json:"detail"`
+}
+
+// client calls the Users endpoint of a scim service provider.
+type client struct {
+	http     *http.Client
+	endpoint string
+	token    string
+}
+
+func newClient(endpoint, token string, timeout time.Duration) *client {
+	return &client{
+		http:     &http.Client{Timeout: timeout},
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+	}
+}
+
+// createUser creates the user and returns the id assigned by the service provider.
+// errConflict is returned if a user with the same userName already exists.
+func (c *client) createUser(ctx context.Context, user *resources.ScimUser) (string, error) {
+	body, err := userAttributes(user)
+	if err != nil {
+		return "", err
+	}
+	body["schemas"] = user.GetSchemas()
+	created := new(remoteUser)
+	if err = c.do(ctx, http.MethodPost, usersPath, body, created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", errMissingID
+	}
+	return created.ID, nil
+}
+
+// updateUser replaces the attributes of the user with the given remote id.
+// errNotFound is returned if the user does not exist at the service provider.
+func (c *client) updateUser(ctx context.Context, remoteID string, user *resources.ScimUser) error {
+	value, err := userAttributes(user)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPatch, usersPath+"/"+url.PathEscape(remoteID), &patchRequest{
+		Schemas: []schemas.ScimSchemaType{schemas.IdPatchOperation},
+		Operations: []*patchOperation{{
+			Operation: "replace",
+			Value:     value,
+		}},
+	}, nil)
+}
+
+// deactivateUser sets the user with the given remote id inactive.
+// A user which does not exist at the service provider is ignored.
+func (c *client) deactivateUser(ctx context.Context, remoteID string) error {
+	err := c.do(ctx, http.MethodPatch, usersPath+"/"+url.PathEscape(remoteID), &patchRequest{
+		Schemas: []schemas.ScimSchemaType{schemas.IdPatchOperation},
+		Operations: []*patchOperation{{
+			Operation: "replace",
+			Path:      "active",
+			Value:     false,
+		}},
+	}, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// findUserByUserName returns the remote id of the user with the userName,
+// errNotFound is returned if no such user exists.
+func (c *client) findUserByUserName(ctx context.Context, userName string) (string, error) {
+	filter := url.Values{"filter": []string{fmt.Sprintf("userName eq %q", userName)}}
+	list := new(remoteUserList)
+	if err := c.do(ctx, http.MethodGet, usersPath+"?"+filter.Encode(), nil, list); err != nil {
+		return "", err
+	}
+	if len(list.Resources) == 0 || list.Resources[0] == nil || list.Resources[0].ID == "" {
+		return "", errNotFound
+	}
+	return list.Resources[0].ID, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", contentTypeScim)
+	if body != nil {
+		req.Header.Set("Content-Type", contentTypeScim)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return errConflict
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		scimErr := new(errorResponse)
+		_ = json.Unmarshal(respBody, scimErr)
+		return &StatusError{StatusCode: resp.StatusCode, Detail: scimErr.Detail}
+	}
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+// userAttributes returns the attributes of the user which are sent to the service provider,
+// the schemas and the read-only attributes are omitted.
+func userAttributes(user *resources.ScimUser) (map[string]any, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	value := make(map[string]any)
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	delete(value, "schemas")
+	delete(value, "meta")
+	delete(value, "id")
+	if value["preferredLanguage"] == language.Und.String() {
+		delete(value, "preferredLanguage")
+	}
+	return value, nil
+}
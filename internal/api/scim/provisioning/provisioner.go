@@ -0,0 +1,461 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	scim_config "github.com/zitadel/zitadel/internal/api/scim/config"
+	"github.com/zitadel/zitadel/internal/api/scim/resources"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// ProvisionerProjectionName is the name under which the position of the provisioner is stored
+	ProvisionerProjectionName = "projections.scim_provisioning"
+
+	// provisionerUserID is the creator of the events pushed by the provisioner
+	provisionerUserID = "SCIM_PROVISIONING"
+	grantsPageSize    = 100
+)
+
+type Queries interface {
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk bool) (*query.UserGrants, error)
+	SCIMProvisioningByAppID(ctx context.Context, appID string) (*query.SCIMProvisioning, error)
+	SCIMProvisioningsByProjectIDs(ctx context.Context, shouldTriggerBulk bool, projectIDs []string) (*query.SCIMProvisionings, error)
+	SearchSCIMProvisioningUserStates(ctx context.Context, shouldTriggerBulk bool, queries *query.SCIMProvisioningUserStateSearchQueries) (*query.SCIMProvisioningUserStates, error)
+}
+
+type Commands interface {
+	SCIMProvisioningUserSynced(ctx context.Context, projectID, appID, resourceOwner, userID, remoteID string, state domain.SCIMProvisioningState) error
+	SCIMProvisioningUserFailed(ctx context.Context, projectID, appID, resourceOwner, userID, message string) error
+}
+
+// UserMapper maps a zitadel user to the scim user sent to the service providers
+type UserMapper interface {
+	ProvisioningUser(ctx context.Context, userID string) (*resources.ScimUser, error)
+}
+
+// EventFilter is used to resolve the user of grant events which do not contain the user id
+type EventFilter interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+var (
+	_ handler.Projection            = (*provisioner)(nil)
+	_ handler.LatestPositionStarter = (*provisioner)(nil)
+)
+
+// provisioner pushes the users granted on a project to the scim service providers configured on the apps of the project.
+//
+// Every change of a user or of a grant of the user leads to a full sync of the user to all apps:
+// the user is created or updated at the apps of the projects the user is granted on
+// and deactivated at the apps the user was provisioned to before but is not granted on anymore.
+// The result is stored per user and app as event on the project.
+//
+// If a queue is provided, the sync is queued as [UserSync] job per user
+// and failed calls to a service provider are retried with backoff by the [Worker].
+// Otherwise the user is synced by the provisioner and failed calls are recorded
+// but not retried until the next change of the user.
+type provisioner struct {
+	commands    Commands
+	queries     Queries
+	users       UserMapper
+	events      EventFilter
+	queue       Queue
+	timeout     time.Duration
+	maxAttempts uint8
+}
+
+func NewProvisioner(
+	ctx context.Context,
+	handlerConfig handler.Config,
+	config *scim_config.ProvisioningConfig,
+	commands Commands,
+	queries Queries,
+	users UserMapper,
+	events EventFilter,
+	queue Queue,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &handlerConfig, &provisioner{
+		commands:    commands,
+		queries:     queries,
+		users:       users,
+		events:      events,
+		queue:       queue,
+		timeout:     config.Timeout,
+		maxAttempts: config.MaxAttempts,
+	})
+}
+
+// Name implements [handler.Projection]
+func (*provisioner) Name() string {
+	return ProvisionerProjectionName
+}
+
+// StartAtLatestPosition implements [handler.LatestPositionStarter],
+// the history of the instance is not replayed to the service providers on the first start.
+// Existing users are provisioned as soon as a provisioning is set on their project.
+func (*provisioner) StartAtLatestPosition() bool {
+	return true
+}
+
+// Reducers implements [handler.Projection]
+func (p *provisioner) Reducers() []handler.AggregateReducer {
+	userEventTypes := []eventstore.EventType{
+		user.HumanAddedType,
+		user.HumanRegisteredType,
+		user.UserV1AddedType,
+		user.UserV1RegisteredType,
+		user.HumanProfileChangedType,
+		user.UserV1ProfileChangedType,
+		user.HumanEmailChangedType,
+		user.UserV1EmailChangedType,
+		user.HumanEmailVerifiedType,
+		user.HumanPhoneChangedType,
+		user.UserV1PhoneChangedType,
+		user.HumanPhoneRemovedType,
+		user.UserUserNameChangedType,
+		user.UserDeactivatedType,
+		user.UserReactivatedType,
+		user.UserLockedType,
+		user.UserUnlockedType,
+		user.UserRemovedType,
+		user.MetadataSetType,
+		user.MetadataRemovedType,
+		user.MetadataRemovedAllType,
+	}
+	userReducers := make([]handler.EventReducer, len(userEventTypes))
+	for i, typ := range userEventTypes {
+		userReducers[i] = handler.EventReducer{Event: typ, Reduce: p.reduceUserChanged}
+	}
+	return []handler.AggregateReducer{
+		{
+			Aggregate:     user.AggregateType,
+			EventReducers: userReducers,
+		},
+		{
+			Aggregate: usergrant.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{Event: usergrant.UserGrantAddedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantChangedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantCascadeChangedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantRemovedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantCascadeRemovedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantDeactivatedType, Reduce: p.reduceUserGrantChanged},
+				{Event: usergrant.UserGrantReactivatedType, Reduce: p.reduceUserGrantChanged},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{Event: project.SCIMProvisioningSetType, Reduce: p.reduceProvisioningSet},
+			},
+		},
+	}
+}
+
+func (p *provisioner) reduceUserChanged(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := provisionerContext(event)
+		return p.scheduleSync(ctx, event.Aggregate().ID)
+	}), nil
+}
+
+func (p *provisioner) reduceUserGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := provisionerContext(event)
+		userID, err := p.grantUserID(ctx, event)
+		if err != nil || userID == "" {
+			return err
+		}
+		return p.scheduleSync(ctx, userID)
+	}), nil
+}
+
+func (p *provisioner) reduceProvisioningSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.SCIMProvisioningSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewStatement(e, func(handler.Executer, string) error {
+		ctx := provisionerContext(e)
+		userIDs, err := p.projectUserIDs(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		// a failing user must not prevent the sync of the other users
+		var errs []error
+		for _, userID := range userIDs {
+			if err := p.scheduleSync(ctx, userID); err != nil {
+				logging.WithFields("project", e.Aggregate().ID, "user", userID).WithError(err).Warn("unable to sync scim provisioning of user")
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}), nil
+}
+
+// scheduleSync queues the sync of the user if a queue is provided or syncs the user otherwise.
+func (p *provisioner) scheduleSync(ctx context.Context, userID string) error {
+	if p.queue != nil {
+		return p.queue.Insert(ctx, &UserSync{
+			InstanceID:    authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetCtxData(ctx).OrgID,
+			UserID:        userID,
+			MaxAttempts:   p.maxAttempts,
+		})
+	}
+	// the failed calls are recorded and synced again on the next change of the user
+	_, err := p.syncUser(ctx, userID)
+	return err
+}
+
+// syncUser provisions the user to the apps of all projects the user is granted on
+// and deactivates the user at the apps of the projects the user is no longer granted on.
+// A failed call to a service provider does not prevent the calls to the other service providers,
+// the failed calls are recorded and returned, err is returned if the sync could not be completed.
+func (p *provisioner) syncUser(ctx context.Context, userID string) (failedCalls []error, err error) {
+	scimUser, err := p.users.ProvisioningUser(ctx, userID)
+	if err != nil && !zerrors.IsNotFound(err) {
+		return nil, err
+	}
+	var provisionings []*query.SCIMProvisioning
+	if scimUser != nil {
+		provisionings, err = p.grantedProvisionings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	states, err := p.userStates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, provisioning := range provisionings {
+		failed, err := p.provision(ctx, provisioning, userID, states[provisioning.AppID], scimUser)
+		if err != nil {
+			return nil, err
+		}
+		if failed != nil {
+			failedCalls = append(failedCalls, failed)
+		}
+	}
+	for appID, state := range states {
+		if state.RemoteID == "" || state.State == domain.SCIMProvisioningStateDeactivated {
+			continue
+		}
+		if slices.ContainsFunc(provisionings, func(provisioning *query.SCIMProvisioning) bool {
+			return provisioning.AppID == appID
+		}) {
+			continue
+		}
+		failed, err := p.deprovision(ctx, appID, userID, state.RemoteID)
+		if err != nil {
+			return nil, err
+		}
+		if failed != nil {
+			failedCalls = append(failedCalls, failed)
+		}
+	}
+	return failedCalls, nil
+}
+
+// provision creates or updates the user at the service provider,
+// failed is the error of the call to the service provider.
+func (p *provisioner) provision(ctx context.Context, provisioning *query.SCIMProvisioning, userID string, state *query.SCIMProvisioningUserState, scimUser *resources.ScimUser) (failed, err error) {
+	c := newClient(provisioning.Endpoint, provisioning.Token, p.timeout)
+	var remoteID string
+	if state != nil {
+		remoteID = state.RemoteID
+	}
+	remoteID, failed = c.upsertUser(ctx, remoteID, scimUser)
+	if failed != nil {
+		return failed, p.failed(ctx, provisioning.ProjectID, provisioning.AppID, provisioning.ResourceOwner, userID, failed)
+	}
+	syncedState := domain.SCIMProvisioningStateProvisioned
+	if scimUser.Active != nil && !bool(*scimUser.Active) {
+		syncedState = domain.SCIMProvisioningStateDeactivated
+	}
+	return nil, ignoreNotFound(p.commands.SCIMProvisioningUserSynced(ctx, provisioning.ProjectID, provisioning.AppID, provisioning.ResourceOwner, userID, remoteID, syncedState))
+}
+
+// deprovision deactivates the user at the service provider,
+// failed is the error of the call to the service provider.
+func (p *provisioner) deprovision(ctx context.Context, appID, userID, remoteID string) (failed, err error) {
+	provisioning, err := p.queries.SCIMProvisioningByAppID(ctx, appID)
+	if zerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if failed = newClient(provisioning.Endpoint, provisioning.Token, p.timeout).deactivateUser(ctx, remoteID); failed != nil {
+		return failed, p.failed(ctx, provisioning.ProjectID, provisioning.AppID, provisioning.ResourceOwner, userID, failed)
+	}
+	return nil, ignoreNotFound(p.commands.SCIMProvisioningUserSynced(ctx, provisioning.ProjectID, provisioning.AppID, provisioning.ResourceOwner, userID, remoteID, domain.SCIMProvisioningStateDeactivated))
+}
+
+// failed records the failed call to the service provider.
+func (p *provisioner) failed(ctx context.Context, projectID, appID, resourceOwner, userID string, cause error) error {
+	logging.WithFields("app", appID, "user", userID).WithError(cause).Info("scim provisioning failed")
+	return ignoreNotFound(p.commands.SCIMProvisioningUserFailed(ctx, projectID, appID, resourceOwner, userID, cause.Error()))
+}
+
+// grantedProvisionings returns the scim provisionings of the apps of all projects the user has an active grant on.
+func (p *provisioner) grantedProvisionings(ctx context.Context, userID string) ([]*query.SCIMProvisioning, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	stateQuery, err := query.NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery, stateQuery}}, true)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]string, 0, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if !slices.Contains(projectIDs, grant.ProjectID) {
+			projectIDs = append(projectIDs, grant.ProjectID)
+		}
+	}
+	if len(projectIDs) == 0 {
+		return nil, nil
+	}
+	provisionings, err := p.queries.SCIMProvisioningsByProjectIDs(ctx, true, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	return provisionings.Provisionings, nil
+}
+
+// userStates returns the provisioning states of the user mapped by app id.
+func (p *provisioner) userStates(ctx context.Context, userID string) (map[string]*query.SCIMProvisioningUserState, error) {
+	userIDQuery, err := query.NewSCIMProvisioningUserStateUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	states, err := p.queries.SearchSCIMProvisioningUserStates(ctx, true, &query.SCIMProvisioningUserStateSearchQueries{Queries: []query.SearchQuery{userIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	statesByApp := make(map[string]*query.SCIMProvisioningUserState, len(states.UserStates))
+	for _, state := range states.UserStates {
+		statesByApp[state.AppID] = state
+	}
+	return statesByApp, nil
+}
+
+// projectUserIDs returns the ids of the users with an active grant on the project.
+func (p *provisioner) projectUserIDs(ctx context.Context, projectID string) ([]string, error) {
+	projectIDQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	stateQuery, err := query.NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0)
+	for offset := uint64(0); ; offset += grantsPageSize {
+		grants, err := p.queries.UserGrants(ctx, &query.UserGrantsQueries{
+			SearchRequest: query.SearchRequest{Offset: offset, Limit: grantsPageSize},
+			Queries:       []query.SearchQuery{projectIDQuery, stateQuery},
+		}, offset == 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range grants.UserGrants {
+			if !slices.Contains(userIDs, grant.UserID) {
+				userIDs = append(userIDs, grant.UserID)
+			}
+		}
+		if len(grants.UserGrants) < grantsPageSize {
+			return userIDs, nil
+		}
+	}
+}
+
+// grantUserID returns the id of the user of the grant,
+// events which do not contain the user id are resolved through the added event of the grant.
+func (p *provisioner) grantUserID(ctx context.Context, event eventstore.Event) (string, error) {
+	switch e := event.(type) {
+	case *usergrant.UserGrantAddedEvent:
+		return e.UserID, nil
+	case *usergrant.UserGrantChangedEvent:
+		return e.UserID, nil
+	case *usergrant.UserGrantRemovedEvent:
+		return e.UserID, nil
+	}
+	events, err := p.events.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(event.Aggregate().InstanceID).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		AggregateIDs(event.Aggregate().ID).
+		EventTypes(usergrant.UserGrantAddedType).
+		Builder(),
+	)
+	if err != nil {
+		return "", err
+	}
+	for _, added := range events {
+		if e, ok := added.(*usergrant.UserGrantAddedEvent); ok {
+			return e.UserID, nil
+		}
+	}
+	return "", nil
+}
+
+func provisionerContext(event eventstore.Event) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: provisionerUserID, OrgID: event.Aggregate().ResourceOwner})
+}
+
+func ignoreNotFound(err error) error {
+	if zerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func assertEvent[T eventstore.Event](event eventstore.Event) (T, error) {
+	e, ok := event.(T)
+	if !ok {
+		return e, zerrors.ThrowInvalidArgumentf(nil, "SCIM-r5nq8x2wjd", "reduce.wrong.event.type %T", event)
+	}
+	return e, nil
+}
+
+// upsertUser updates the user at the service provider if it was provisioned before or creates it otherwise.
+// A user which already exists with the same userName is taken over.
+func (c *client) upsertUser(ctx context.Context, remoteID string, user *resources.ScimUser) (string, error) {
+	if remoteID != "" {
+		err := c.updateUser(ctx, remoteID, user)
+		if !errors.Is(err, errNotFound) {
+			return remoteID, err
+		}
+	}
+	remoteID, err := c.createUser(ctx, user)
+	if !errors.Is(err, errConflict) {
+		return remoteID, err
+	}
+	remoteID, err = c.findUserByUserName(ctx, user.UserName)
+	if err != nil {
+		return "", err
+	}
+	return remoteID, c.updateUser(ctx, remoteID, user)
+}
@@ -0,0 +1,359 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type mockQueries struct {
+	grants        []*query.UserGrant
+	provisionings []*query.SCIMProvisioning
+	states        []*query.SCIMProvisioningUserState
+}
+
+func (m *mockQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{UserGrants: m.grants}, nil
+}
+
+func (m *mockQueries) SCIMProvisioningByAppID(_ context.Context, appID string) (*query.SCIMProvisioning, error) {
+	for _, provisioning := range m.provisionings {
+		if provisioning.AppID == appID {
+			return provisioning, nil
+		}
+	}
+	return nil, zerrors.ThrowNotFound(nil, "TEST-8xkq2", "not found")
+}
+
+func (m *mockQueries) SCIMProvisioningsByProjectIDs(_ context.Context, _ bool, projectIDs []string) (*query.SCIMProvisionings, error) {
+	provisionings := make([]*query.SCIMProvisioning, 0)
+	for _, provisioning := range m.provisionings {
+		for _, projectID := range projectIDs {
+			if provisioning.ProjectID == projectID {
+				provisionings = append(provisionings, provisioning)
+			}
+		}
+	}
+	return &query.SCIMProvisionings{Provisionings: provisionings}, nil
+}
+
+func (m *mockQueries) SearchSCIMProvisioningUserStates(context.Context, bool, *query.SCIMProvisioningUserStateSearchQueries) (*query.SCIMProvisioningUserStates, error) {
+	return &query.SCIMProvisioningUserStates{UserStates: m.states}, nil
+}
+
+type syncResult struct {
+	appID    string
+	remoteID string
+	state    domain.SCIMProvisioningState
+	error    string
+}
+
+type mockCommands struct {
+	results []syncResult
+}
+
+func (m *mockCommands) SCIMProvisioningUserSynced(_ context.Context, _, appID, _, _, remoteID string, state domain.SCIMProvisioningState) error {
+	m.results = append(m.results, syncResult{appID: appID, remoteID: remoteID, state: state})
+	return nil
+}
+
+func (m *mockCommands) SCIMProvisioningUserFailed(_ context.Context, _, appID, _, _, message string) error {
+	m.results = append(m.results, syncResult{appID: appID, state: domain.SCIMProvisioningStateFailed, error: message})
+	return nil
+}
+
+type mockUsers struct {
+	user *resources.ScimUser
+}
+
+func (m *mockUsers) ProvisioningUser(context.Context, string) (*resources.ScimUser, error) {
+	if m.user == nil {
+		return nil, zerrors.ThrowNotFound(nil, "TEST-d93mf", "not found")
+	}
+	return m.user, nil
+}
+
+type request struct {
+	method string
+	path   string
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+// serviceProvider records the requests and responds with the responses in order
+func serviceProvider(t *testing.T, requests *[]request, responses ...response) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		*requests = append(*requests, request{method: r.Method, path: r.URL.RequestURI()})
+
+		require.Less(t, len(*requests)-1, len(responses), "unexpected request %s %s", r.Method, r.URL)
+		resp := responses[len(*requests)-1]
+		w.WriteHeader(resp.status)
+		_, _ = io.WriteString(w, resp.body)
+	}))
+}
+
+func Test_provisioner_syncUser(t *testing.T) {
+	scimUser := &resources.ScimUser{
+		Resource:   &schemas.Resource{Schemas: []schemas.ScimSchemaType{schemas.IdUser}},
+		ExternalID: "user-id",
+		UserName:   "gigi",
+		Active:     schemas.NewRelaxedBool(true),
+	}
+	grant := &query.UserGrant{UserID: "user-id", ProjectID: "project-id"}
+	tests := []struct {
+		name         string
+		user         *resources.ScimUser
+		grants       []*query.UserGrant
+		states       []*query.SCIMProvisioningUserState
+		responses    []response
+		wantRequests []string
+		wantResults  []syncResult
+		wantFailed   int
+	}{
+		{
+			name:      "create",
+			user:      scimUser,
+			grants:    []*query.UserGrant{grant},
+			responses: []response{{status: http.StatusCreated, body: `{"id": "remote-id"}`}},
+			wantRequests: []string{
+				"POST /Users",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateProvisioned}},
+		},
+		{
+			name:      "update",
+			user:      scimUser,
+			grants:    []*query.UserGrant{grant},
+			states:    []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateProvisioned}},
+			responses: []response{{status: http.StatusOK}},
+			wantRequests: []string{
+				"PATCH /Users/remote-id",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateProvisioned}},
+		},
+		{
+			name:   "update removed remote user, create",
+			user:   scimUser,
+			grants: []*query.UserGrant{grant},
+			states: []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateProvisioned}},
+			responses: []response{
+				{status: http.StatusNotFound},
+				{status: http.StatusCreated, body: `{"id": "remote-id-2"}`},
+			},
+			wantRequests: []string{
+				"PATCH /Users/remote-id",
+				"POST /Users",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id-2", state: domain.SCIMProvisioningStateProvisioned}},
+		},
+		{
+			name:   "create existing user, take over",
+			user:   scimUser,
+			grants: []*query.UserGrant{grant},
+			responses: []response{
+				{status: http.StatusConflict},
+				{status: http.StatusOK, body: `{"Resources": [{"id": "remote-id"}]}`},
+				{status: http.StatusOK},
+			},
+			wantRequests: []string{
+				"POST /Users",
+				"GET /Users?filter=userName+eq+%22gigi%22",
+				"PATCH /Users/remote-id",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateProvisioned}},
+		},
+		{
+			name: "inactive user, deactivated",
+			user: &resources.ScimUser{
+				ExternalID: "user-id",
+				UserName:   "gigi",
+				Active:     schemas.NewRelaxedBool(false),
+			},
+			grants:    []*query.UserGrant{grant},
+			states:    []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateProvisioned}},
+			responses: []response{{status: http.StatusOK}},
+			wantRequests: []string{
+				"PATCH /Users/remote-id",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateDeactivated}},
+		},
+		{
+			name:      "grant removed, deactivate",
+			user:      scimUser,
+			states:    []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateProvisioned}},
+			responses: []response{{status: http.StatusOK}},
+			wantRequests: []string{
+				"PATCH /Users/remote-id",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateDeactivated}},
+		},
+		{
+			name:      "user removed, deactivate",
+			states:    []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateProvisioned}},
+			responses: []response{{status: http.StatusOK}},
+			wantRequests: []string{
+				"PATCH /Users/remote-id",
+			},
+			wantResults: []syncResult{{appID: "app-id", remoteID: "remote-id", state: domain.SCIMProvisioningStateDeactivated}},
+		},
+		{
+			name:   "already deactivated, ignored",
+			states: []*query.SCIMProvisioningUserState{{AppID: "app-id", RemoteID: "remote-id", State: domain.SCIMProvisioningStateDeactivated}},
+		},
+		{
+			name:      "service provider error, failed",
+			user:      scimUser,
+			grants:    []*query.UserGrant{grant},
+			responses: []response{{status: http.StatusBadRequest, body: `{"detail": "invalid userName"}`}},
+			wantRequests: []string{
+				"POST /Users",
+			},
+			wantResults: []syncResult{{appID: "app-id", state: domain.SCIMProvisioningStateFailed, error: "scim service provider responded with status 400: invalid userName"}},
+			wantFailed:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make([]request, 0)
+			server := serviceProvider(t, &requests, tt.responses...)
+			defer server.Close()
+
+			commands := new(mockCommands)
+			p := &provisioner{
+				commands: commands,
+				queries: &mockQueries{
+					grants: tt.grants,
+					provisionings: []*query.SCIMProvisioning{{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						ResourceOwner: "org-id",
+						Endpoint:      server.URL + "/",
+						Token:         "token",
+					}},
+					states: tt.states,
+				},
+				users:   &mockUsers{user: tt.user},
+				timeout: time.Second,
+			}
+			failedCalls, err := p.syncUser(context.Background(), "user-id")
+			require.NoError(t, err)
+			assert.Len(t, failedCalls, tt.wantFailed)
+
+			var gotRequests []string
+			for _, req := range requests {
+				gotRequests = append(gotRequests, req.method+" "+req.path)
+			}
+			assert.Equal(t, tt.wantRequests, gotRequests)
+			assert.Equal(t, tt.wantResults, commands.results)
+		})
+	}
+}
+
+func Test_userAttributes(t *testing.T) {
+	user := &resources.ScimUser{
+		Resource:   &schemas.Resource{Schemas: []schemas.ScimSchemaType{schemas.IdUser}},
+		ID:         "id",
+		ExternalID: "user-id",
+		UserName:   "gigi",
+		Active:     schemas.NewRelaxedBool(true),
+	}
+	got, err := userAttributes(user)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"externalId": "user-id",
+		"userName":   "gigi",
+		"active":     true,
+	}, got)
+}
+
+type mockQueue struct {
+	inserted []*UserSync
+	err      error
+}
+
+func (q *mockQueue) Insert(_ context.Context, args river.JobArgs, _ ...queue.InsertOpt) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.inserted = append(q.inserted, args.(*UserSync))
+	return nil
+}
+
+func Test_provisioner_reduceProvisioningSet(t *testing.T) {
+	jobs := new(mockQueue)
+	p := &provisioner{
+		queries: &mockQueries{grants: []*query.UserGrant{
+			{UserID: "user1", ProjectID: "project-id"},
+			{UserID: "user2", ProjectID: "project-id"},
+			{UserID: "user1", ProjectID: "project-id"},
+		}},
+		queue:       jobs,
+		maxAttempts: 3,
+	}
+	aggregate := &project.NewAggregate("project-id", "org-id").Aggregate
+	aggregate.InstanceID = "instance"
+	event := project.NewSCIMProvisioningSetEvent(context.Background(), aggregate, "app-id", "https://sp.example.com", nil)
+	stmt, err := p.reduceProvisioningSet(event)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Execute(nil, p.Name()))
+	assert.Equal(t, []*UserSync{
+		{InstanceID: "instance", ResourceOwner: "org-id", UserID: "user1", MaxAttempts: 3},
+		{InstanceID: "instance", ResourceOwner: "org-id", UserID: "user2", MaxAttempts: 3},
+	}, jobs.inserted)
+
+	jobs.err = errors.New("insert failed")
+	assert.ErrorIs(t, stmt.Execute(nil, p.Name()), jobs.err)
+}
+
+func Test_retryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "network error",
+			err:  errors.New("connection refused"),
+			want: true,
+		},
+		{
+			name: "rejected",
+			err:  &StatusError{StatusCode: http.StatusBadRequest},
+		},
+		{
+			name: "too many requests",
+			err:  &StatusError{StatusCode: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			name: "server error",
+			err:  &StatusError{StatusCode: http.StatusBadGateway},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryable(tt.err))
+		})
+	}
+}
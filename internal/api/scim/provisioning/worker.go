@@ -0,0 +1,147 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	scim_config "github.com/zitadel/zitadel/internal/api/scim/config"
+	"github.com/zitadel/zitadel/internal/queue"
+)
+
+const (
+	QueueName = "scim_provisioning"
+
+	DefaultMaxAttempts    uint8 = 10
+	DefaultInitialBackoff       = time.Second
+	DefaultMaxBackoff           = time.Hour
+)
+
+// Queue is used to sync the users in jobs, which are retried if a call to a service provider fails.
+// If no queue is provided, the users are synced by the provisioner and failed calls are not retried.
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
+}
+
+// UserSync is the job to sync a user to the scim service providers of the projects the user is granted on.
+// The user is read when the job is worked, so every attempt syncs the current state of the user.
+type UserSync struct {
+	InstanceID    string `json:"instanceID"`
+	ResourceOwner string `json:"resourceOwner"`
+	UserID        string `json:"userID"`
+	MaxAttempts   uint8  `json:"maxAttempts"`
+}
+
+func (*UserSync) Kind() string {
+	return "scim_provisioning_user_sync"
+}
+
+// InsertOpts implements [river.JobArgsWithInsertOpts]
+func (s *UserSync) InsertOpts() river.InsertOpts {
+	maxAttempts := s.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return river.InsertOpts{
+		Queue:       QueueName,
+		MaxAttempts: int(maxAttempts),
+	}
+}
+
+var _ river.Worker[*UserSync] = (*Worker)(nil)
+
+// Worker syncs the users of the [UserSync] jobs from the queue.
+type Worker struct {
+	river.WorkerDefaults[*UserSync]
+
+	config      *scim_config.ProvisioningConfig
+	provisioner *provisioner
+}
+
+func NewWorker(
+	config *scim_config.ProvisioningConfig,
+	commands Commands,
+	queries Queries,
+	users UserMapper,
+	events EventFilter,
+) *Worker {
+	return &Worker{
+		config: config,
+		provisioner: &provisioner{
+			commands: commands,
+			queries:  queries,
+			users:    users,
+			events:   events,
+			timeout:  config.Timeout,
+		},
+	}
+}
+
+// Register implements [queue.Worker]
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: int(w.config.Workers),
+	}
+}
+
+// Work implements [river.Worker]
+// Failed calls are recorded on the provisioning state of the user and the job is retried,
+// unless the service providers rejected the user.
+func (w *Worker) Work(ctx context.Context, job *river.Job[*UserSync]) error {
+	ctx = authz.WithInstanceID(ctx, job.Args.InstanceID)
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: provisionerUserID, OrgID: job.Args.ResourceOwner})
+	failedCalls, err := w.provisioner.syncUser(ctx, job.Args.UserID)
+	if err != nil || len(failedCalls) == 0 {
+		return err
+	}
+	err = errors.Join(failedCalls...)
+	for _, failed := range failedCalls {
+		if retryable(failed) {
+			return err
+		}
+	}
+	return river.JobCancel(err)
+}
+
+// NextRetry implements [river.Worker]
+func (w *Worker) NextRetry(job *river.Job[*UserSync]) time.Time {
+	return time.Now().Add(w.backoff(job.Attempt))
+}
+
+// backoff calculates the exponential backoff for the given attempt, starting with 1
+func (w *Worker) backoff(attempt int) time.Duration {
+	initialBackoff, maxBackoff := w.config.InitialBackoff, w.config.MaxBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = DefaultInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	backoff := float64(initialBackoff) * math.Pow(2, float64(attempt-1))
+	if backoff > float64(maxBackoff) {
+		return maxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// retryable returns false if the service provider rejected the request,
+// as the same request will be rejected again until the user changes.
+func retryable(err error) bool {
+	statusErr := new(StatusError)
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	switch statusErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return statusErr.StatusCode >= http.StatusInternalServerError
+}
+
+var _ queue.Worker = (*Worker)(nil)
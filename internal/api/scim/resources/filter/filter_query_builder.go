@@ -57,6 +57,42 @@ func (m FieldPathMapping) Resolve(path string) (*QueryFieldInfo, error) {
 	return info, nil
 }
 
+// ResolveAttrPath resolves the field of an attribute path as it is used by sortBy,
+// the path can be prefixed with the urn of the schema or of a mapped schema extension.
+func (m FieldPathMapping) ResolveAttrPath(schema schemas.ScimSchemaType, attrPath string) (*QueryFieldInfo, error) {
+	path, err := ParsePath(attrPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == nil || path.AttrPath == nil {
+		return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgumentf(nil, "SCIM-FF434", "Invalid attribute path %s", attrPath))
+	}
+
+	b := &queryBuilder{
+		schema:           schema,
+		fieldPathMapping: m,
+	}
+	fieldPath, err := b.reduceAttrPaths([]*AttrPath{path.AttrPath})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Resolve(fieldPath)
+}
+
+// hasExtension returns true if the mapping contains attributes of the schema extension with the urn prefix,
+// the attributes of extensions are mapped with the lowercase urn as prefix (e.g. urn:foo:bar:user:attr).
+func (m FieldPathMapping) hasExtension(urnPrefix string) bool {
+	urnPrefix = strings.ToLower(urnPrefix)
+	for fieldPath := range m {
+		if strings.HasPrefix(fieldPath, urnPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *Filter) BuildQuery(ctx context.Context, schema schemas.ScimSchemaType, fieldPathColumnMapping FieldPathMapping) (query.SearchQuery, error) {
 	builder := &queryBuilder{
 		ctx:              ctx,
@@ -350,8 +386,17 @@ func (b *queryBuilder) reduceAttrPaths(attrPaths []*AttrPath) (fieldPath string,
 
 	sb := strings.Builder{}
 
+	// the urn of the schema extension of the attribute, empty for attributes of the schema itself
+	var extensionUrn string
 	for _, p := range attrPaths {
-		if err = p.validateSchema(b.schema); err != nil {
+		if b.isExtension(p.UrnAttributePrefix) {
+			urn := strings.ToLower(*p.UrnAttributePrefix)
+			if extensionUrn != "" && extensionUrn != urn {
+				err = serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-FF436", "Invalid filter expression: mixed urn attribute prefixes"))
+				return fieldPath, err
+			}
+			extensionUrn = urn
+		} else if err = p.validateSchema(b.schema); err != nil {
 			return
 		}
 
@@ -361,5 +406,11 @@ func (b *queryBuilder) reduceAttrPaths(attrPaths []*AttrPath) (fieldPath string,
 
 	fieldPath = sb.String()
 	fieldPath = strings.TrimRight(fieldPath, ".") // trim very last '.'
-	return fieldPath, err
+	return extensionUrn + fieldPath, err
+}
+
+func (b *queryBuilder) isExtension(urnPrefix *string) bool {
+	return urnPrefix != nil &&
+		*urnPrefix != string(b.schema)+":" &&
+		b.fieldPathMapping.hasExtension(*urnPrefix)
 }
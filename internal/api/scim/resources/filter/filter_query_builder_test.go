@@ -41,6 +41,18 @@ var fieldPathColumnMapping = FieldPathMapping{
 		Column:    query.HumanEmailCol,
 		FieldType: FieldTypeString,
 	},
+	// a sub attribute of a multi-valued attribute without a column
+	"emails.type": {
+		FieldType: FieldTypeCustom,
+		BuildMappedQuery: func(ctx context.Context, compareValue *CompValue, op *CompareOp) (query.SearchQuery, error) {
+			return query.NewNotNullQuery(query.HumanEmailCol)
+		},
+	},
+	// a field of a schema extension
+	"urn:ietf:params:scim:schemas:extension:enterprise:2.0:user:employeenumber": {
+		Column:    query.HumanNickNameCol,
+		FieldType: FieldTypeString,
+	},
 	// pseudo field to test number queries
 	"age": {
 		Column:    query.HumanGenderCol,
@@ -317,6 +329,37 @@ func TestFilter_BuildQuery(t *testing.T) {
 			filter: `active eq true`,
 			want:   test.Must(query.NewTextQuery(query.UserUsernameCol, "fooBar", query.TextContains)),
 		},
+		{
+			name:   "value path with mapped sub attribute",
+			filter: `emails[type eq "work" and value co "@example.com"]`,
+			want: test.Must(query.NewAndQuery(
+				test.Must(query.NewNotNullQuery(query.HumanEmailCol)),
+				test.Must(query.NewTextQuery(query.HumanEmailCol, "@example.com", query.TextContains)),
+			)),
+		},
+		{
+			name:   "timestamp range",
+			filter: `meta.lastModified ge "2011-05-13T04:42:34Z" and meta.lastModified lt "2011-05-14T04:42:34Z"`,
+			want: test.Must(query.NewAndQuery(
+				test.Must(query.NewTimestampQuery(query.UserChangeDateCol, time.Date(2011, 5, 13, 4, 42, 34, 0, time.UTC), query.TimestampGreaterOrEquals)),
+				test.Must(query.NewTimestampQuery(query.UserChangeDateCol, time.Date(2011, 5, 14, 4, 42, 34, 0, time.UTC), query.TimestampLess)),
+			)),
+		},
+		{
+			name:   "extension attribute",
+			filter: `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber eq "701984"`,
+			want:   test.Must(query.NewTextQuery(query.HumanNickNameCol, "701984", query.TextEquals)),
+		},
+		{
+			name:    "unknown extension attribute",
+			filter:  `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:costCenter eq "4130"`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown extension",
+			filter:  `urn:ietf:params:scim:schemas:extension:foo:2.0:User:employeeNumber eq "701984"`,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -340,6 +383,7 @@ func Test_queryBuilder_reduceAttrPaths(t *testing.T) {
 	tests := []struct {
 		name          string
 		schema        string
+		mapping       FieldPathMapping
 		attrPaths     []*AttrPath
 		wantFieldPath string
 		wantErr       bool
@@ -479,11 +523,56 @@ func Test_queryBuilder_reduceAttrPaths(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "extension urn",
+			schema:  "urn:foo:bar",
+			mapping: FieldPathMapping{"urn:foo:ext:foo.bar": {}},
+			attrPaths: []*AttrPath{
+				{
+					UrnAttributePrefix: gu.Ptr("urn:foo:ext:"),
+					AttrName:           "foo",
+					SubAttr:            gu.Ptr("bar"),
+				},
+			},
+			wantFieldPath: "urn:foo:ext:foo.bar",
+		},
+		{
+			name:    "nested extension urn",
+			schema:  "urn:foo:bar",
+			mapping: FieldPathMapping{"urn:foo:ext:foo.bar": {}},
+			attrPaths: []*AttrPath{
+				{
+					UrnAttributePrefix: gu.Ptr("urn:foo:ext:"),
+					AttrName:           "foo",
+				},
+				{
+					AttrName: "bar",
+				},
+			},
+			wantFieldPath: "urn:foo:ext:foo.bar",
+		},
+		{
+			name:    "mixed extension urns",
+			schema:  "urn:foo:bar",
+			mapping: FieldPathMapping{"urn:foo:ext:foo.bar": {}, "urn:foo:ext2:bar": {}},
+			attrPaths: []*AttrPath{
+				{
+					UrnAttributePrefix: gu.Ptr("urn:foo:ext:"),
+					AttrName:           "foo",
+				},
+				{
+					UrnAttributePrefix: gu.Ptr("urn:foo:ext2:"),
+					AttrName:           "bar",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := &queryBuilder{
-				schema: schemas.ScimSchemaType(tt.schema),
+				schema:           schemas.ScimSchemaType(tt.schema),
+				fieldPathMapping: tt.mapping,
 			}
 			gotFieldPath, err := b.reduceAttrPaths(tt.attrPaths)
 			if (err != nil) != tt.wantErr {
@@ -496,3 +585,55 @@ func Test_queryBuilder_reduceAttrPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldPathMapping_ResolveAttrPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrPath string
+		want     *QueryFieldInfo
+		wantErr  bool
+	}{
+		{
+			name:     "simple",
+			attrPath: "userName",
+			want:     fieldPathColumnMapping["username"],
+		},
+		{
+			name:     "sub attribute",
+			attrPath: "name.familyName",
+			want:     fieldPathColumnMapping["name.familyname"],
+		},
+		{
+			name:     "schema urn",
+			attrPath: "urn:ietf:params:scim:schemas:core:2.0:User:meta.lastModified",
+			want:     fieldPathColumnMapping["meta.lastmodified"],
+		},
+		{
+			name:     "extension urn",
+			attrPath: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber",
+			want:     fieldPathColumnMapping["urn:ietf:params:scim:schemas:extension:enterprise:2.0:user:employeenumber"],
+		},
+		{
+			name:     "unknown",
+			attrPath: "foo",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown urn",
+			attrPath: "urn:foo:bar:userName",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fieldPathColumnMapping.ResolveAttrPath(schemas.IdUser, tt.attrPath)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
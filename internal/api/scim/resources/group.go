@@ -0,0 +1,467 @@
+package resources
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	scim_config "github.com/zitadel/zitadel/internal/api/scim/config"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/patch"
+	scim_schemas "github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/api/scim/serrors"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// groupsProjectIDSuffix is appended to the id of the organization to build the id of the project
+// which holds the roles representing the scim groups of the organization.
+const groupsProjectIDSuffix = "-scim-groups"
+
+// GroupsHandler maps scim groups to the roles of a project of the organization.
+// The id of a group is the key of the role, which is generated on creation,
+// so it is url-safe and does not change if the group is renamed.
+// The members of a group are the users with a user grant containing this role.
+type GroupsHandler struct {
+	command         *command.Commands
+	query           *query.Queries
+	config          *scim_config.Config
+	filterEvaluator *filter.Evaluator
+	schema          *scim_schemas.ResourceSchema
+}
+
+type ScimGroup struct {
+	*scim_schemas.Resource `scim:"ignoreInSchema"`
+	ID                     string             `json:"id" scim:"ignoreInSchema"`
+	DisplayName            string             `json:"displayName,omitempty" scim:"required,unique"`
+	Members                []*ScimGroupMember `json:"members,omitempty"`
+}
+
+type ScimGroupMember struct {
+	Value   string `json:"value,omitempty" scim:"required"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+func NewGroupsHandler(
+	command *command.Commands,
+	query *query.Queries,
+	config *scim_config.Config) ResourceHandler[*ScimGroup] {
+	return &GroupsHandler{
+		command,
+		query,
+		config,
+		filter.NewEvaluator(scim_schemas.IdGroup),
+		scim_schemas.BuildSchema(scim_schemas.SchemaBuilderArgs{
+			ID:           scim_schemas.IdGroup,
+			Name:         scim_schemas.GroupResourceType,
+			EndpointName: scim_schemas.GroupsResourceType,
+			Description:  "Group",
+			Resource:     new(ScimGroup),
+		}),
+	}
+}
+
+func (g *ScimGroup) GetResource() *scim_schemas.Resource {
+	return g.Resource
+}
+
+func (g *ScimGroup) GetSchemas() []scim_schemas.ScimSchemaType {
+	if g.Resource == nil {
+		return nil
+	}
+
+	return g.Resource.Schemas
+}
+
+func (h *GroupsHandler) Schema() *scim_schemas.ResourceSchema {
+	return h.schema
+}
+
+func (h *GroupsHandler) NewResource() *ScimGroup {
+	return new(ScimGroup)
+}
+
+func (h *GroupsHandler) Create(ctx context.Context, group *ScimGroup) (*ScimGroup, error) {
+	if group.DisplayName == "" {
+		return nil, serrors.ThrowInvalidValue(zerrors.ThrowInvalidArgument(nil, "SCIM-Gdn1", "displayName is required"))
+	}
+
+	orgID := authz.GetCtxData(ctx).OrgID
+	projectID, err := h.ensureGroupsProject(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkDisplayNameUnique(ctx, projectID, "", group.DisplayName); err != nil {
+		return nil, err
+	}
+
+	key, err := id.SonyFlakeGenerator().Next()
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := h.command.AddProjectRole(ctx, &domain.ProjectRole{
+		ObjectRoot:  models.ObjectRoot{AggregateID: projectID},
+		Key:         key,
+		DisplayName: group.DisplayName,
+	}, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.changeMembers(ctx, role, nil, group.Members); err != nil {
+		return nil, err
+	}
+
+	group.ID = role.Key
+	group.Resource = buildResource(ctx, h, &domain.ObjectDetails{
+		ID:           role.Key,
+		Sequence:     role.Sequence,
+		EventDate:    role.ChangeDate,
+		CreationDate: role.CreationDate,
+	})
+	return group, nil
+}
+
+func (h *GroupsHandler) Replace(ctx context.Context, id string, group *ScimGroup) (*ScimGroup, error) {
+	if group.DisplayName == "" {
+		return nil, serrors.ThrowInvalidValue(zerrors.ThrowInvalidArgument(nil, "SCIM-Gdn2", "displayName is required"))
+	}
+
+	role, grants, err := h.queryGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.changeGroup(ctx, role, grants, group); err != nil {
+		return nil, err
+	}
+	return h.Get(ctx, id)
+}
+
+func (h *GroupsHandler) Update(ctx context.Context, id string, operations patch.OperationCollection) error {
+	role, grants, err := h.queryGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	group := h.mapToScimGroup(ctx, role, grants)
+	if err = operations.Apply(&groupPatcher{handler: h}, group); err != nil {
+		return err
+	}
+
+	if group.DisplayName == "" {
+		return serrors.ThrowInvalidValue(zerrors.ThrowInvalidArgument(nil, "SCIM-Gdn3", "displayName is required"))
+	}
+	return h.changeGroup(ctx, role, grants, group)
+}
+
+func (h *GroupsHandler) Delete(ctx context.Context, id string) error {
+	role, grants, err := h.queryGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.command.RemoveProjectRoleWithMembers(ctx, role.ProjectID, role.Key, authz.GetCtxData(ctx).OrgID, userGrantsToIDs(grants)...)
+	return err
+}
+
+func (h *GroupsHandler) Get(ctx context.Context, id string) (*ScimGroup, error) {
+	role, grants, err := h.queryGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.mapToScimGroup(ctx, role, grants), nil
+}
+
+func (h *GroupsHandler) List(ctx context.Context, request *ListRequest) (*ListResponse[*ScimGroup], error) {
+	q, err := h.buildListQuery(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := h.query.SearchProjectRoles(ctx, false, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Count == 0 {
+		return NewListResponse(roles.SearchResponse.Count, q.SearchRequest, make([]*ScimGroup, 0)), nil
+	}
+
+	keys := make([]string, len(roles.ProjectRoles))
+	for i, role := range roles.ProjectRoles {
+		keys[i] = role.Key
+	}
+
+	grants, err := h.queryGroupGrants(ctx, groupsProjectID(authz.GetCtxData(ctx).OrgID), false, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	scimGroups := make([]*ScimGroup, len(roles.ProjectRoles))
+	for i, role := range roles.ProjectRoles {
+		scimGroups[i] = h.mapToScimGroup(ctx, role, grants)
+	}
+	return NewListResponse(roles.SearchResponse.Count, q.SearchRequest, scimGroups), nil
+}
+
+func (h *GroupsHandler) ensureGroupsProject(ctx context.Context, orgID string) (string, error) {
+	projectID := groupsProjectID(orgID)
+	_, err := h.command.AddProjectWithID(ctx, &domain.Project{
+		Name:                 h.config.Groups.ProjectName,
+		ProjectRoleAssertion: true,
+	}, orgID, projectID)
+	if err != nil && !zerrors.IsErrorAlreadyExists(err) {
+		return "", err
+	}
+	return projectID, nil
+}
+
+func (h *GroupsHandler) queryGroup(ctx context.Context, id string) (*query.ProjectRole, []*query.UserGrant, error) {
+	projectID := groupsProjectID(authz.GetCtxData(ctx).OrgID)
+	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyQuery, err := query.NewProjectRoleKeySearchQuery(query.TextEquals, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := h.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{
+		Queries: []query.SearchQuery{projectIDQuery, keyQuery},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(roles.ProjectRoles) == 0 {
+		return nil, nil, zerrors.ThrowNotFound(nil, "SCIM-Grp1", "Errors.Project.Role.NotExisting")
+	}
+
+	grants, err := h.queryGroupGrants(ctx, projectID, true, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return roles.ProjectRoles[0], grants, nil
+}
+
+// queryGroupGrants returns the user grants of the project containing at least one of the keys.
+func (h *GroupsHandler) queryGroupGrants(ctx context.Context, projectID string, shouldTriggerBulk bool, keys ...string) ([]*query.UserGrant, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	projectIDQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceOwnerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	rolesQuery, err := query.NewUserGrantContainsRolesSearchQuery(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := h.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{projectIDQuery, resourceOwnerQuery, rolesQuery},
+	}, shouldTriggerBulk)
+	if err != nil {
+		return nil, err
+	}
+	return grants.UserGrants, nil
+}
+
+func (h *GroupsHandler) changeGroup(ctx context.Context, role *query.ProjectRole, grants []*query.UserGrant, group *ScimGroup) error {
+	if group.DisplayName != role.DisplayName {
+		if err := h.checkDisplayNameUnique(ctx, role.ProjectID, role.Key, group.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	return h.changeMembers(ctx, &domain.ProjectRole{
+		ObjectRoot:  models.ObjectRoot{AggregateID: role.ProjectID},
+		Key:         role.Key,
+		DisplayName: group.DisplayName,
+		Group:       role.Group,
+	}, grants, group.Members)
+}
+
+// checkDisplayNameUnique returns an already exists error
+// if another group than the one with the key has the display name.
+func (h *GroupsHandler) checkDisplayNameUnique(ctx context.Context, projectID, key, displayName string) error {
+	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return err
+	}
+
+	displayNameQuery, err := query.NewProjectRoleDisplayNameSearchQuery(query.TextEquals, displayName)
+	if err != nil {
+		return err
+	}
+
+	roles, err := h.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{
+		Queries: []query.SearchQuery{projectIDQuery, displayNameQuery},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles.ProjectRoles {
+		if role.Key != key {
+			return zerrors.ThrowAlreadyExists(nil, "SCIM-Grp2", "Errors.Project.Role.AlreadyExists")
+		}
+	}
+	return nil
+}
+
+// changeMembers grants the role to the added members and revokes it from the removed members.
+// grants are the current user grants containing the role.
+// The changes of the role and all members are applied at once.
+func (h *GroupsHandler) changeMembers(ctx context.Context, role *domain.ProjectRole, grants []*query.UserGrant, members []*ScimGroupMember) error {
+	added, removed := diffMembers(grants, members)
+	change := &command.ProjectRoleMembers{
+		Role:            role,
+		Added:           make([]*command.ProjectRoleMember, len(added)),
+		RemovedGrantIDs: userGrantsToIDs(removed),
+	}
+	for i, userID := range added {
+		grantID, err := h.memberGrantID(ctx, role.AggregateID, userID)
+		if err != nil {
+			return err
+		}
+		change.Added[i] = &command.ProjectRoleMember{UserID: userID, GrantID: grantID}
+	}
+
+	_, err := h.command.ChangeProjectRoleMembers(ctx, change, authz.GetCtxData(ctx).OrgID)
+	return err
+}
+
+// memberGrantID returns the id of the user grant of the user on the project,
+// a user has at most one grant per project, the role is added to it.
+func (h *GroupsHandler) memberGrantID(ctx context.Context, projectID, userID string) (string, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return "", err
+	}
+
+	projectIDQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	resourceOwnerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return "", err
+	}
+
+	grants, err := h.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userIDQuery, projectIDQuery, resourceOwnerQuery},
+	}, true)
+	if err != nil {
+		return "", err
+	}
+	if len(grants.UserGrants) == 0 {
+		return "", nil
+	}
+	return grants.UserGrants[0].ID, nil
+}
+
+func (h *GroupsHandler) mapToScimGroup(ctx context.Context, role *query.ProjectRole, grants []*query.UserGrant) *ScimGroup {
+	group := &ScimGroup{
+		Resource: buildResource(ctx, h, &domain.ObjectDetails{
+			ID:           role.Key,
+			Sequence:     role.Sequence,
+			EventDate:    role.ChangeDate,
+			CreationDate: role.CreationDate,
+		}),
+		ID:          role.Key,
+		DisplayName: role.DisplayName,
+	}
+
+	for _, grant := range grants {
+		if !slices.Contains(grant.Roles, role.Key) {
+			continue
+		}
+
+		group.Members = append(group.Members, &ScimGroupMember{
+			Value:   grant.UserID,
+			Display: grant.DisplayName,
+			Type:    string(scim_schemas.UserResourceType),
+		})
+	}
+	return group
+}
+
+// diffMembers returns the ids of the users to add to the group
+// and the grants of the members to remove from the group.
+func diffMembers(grants []*query.UserGrant, members []*ScimGroupMember) (added []string, removed []*query.UserGrant) {
+	desired := make(map[string]bool, len(members))
+	for _, member := range members {
+		if member == nil || member.Value == "" {
+			continue
+		}
+
+		desired[member.Value] = true
+	}
+
+	for _, grant := range grants {
+		if desired[grant.UserID] {
+			delete(desired, grant.UserID)
+			continue
+		}
+
+		removed = append(removed, grant)
+	}
+
+	for _, member := range members {
+		if member != nil && desired[member.Value] {
+			added = append(added, member.Value)
+			delete(desired, member.Value)
+		}
+	}
+	return added, removed
+}
+
+func groupsProjectID(orgID string) string {
+	return orgID + groupsProjectIDSuffix
+}
+
+type groupPatcher struct {
+	handler *GroupsHandler
+}
+
+func (p *groupPatcher) FilterEvaluator() *filter.Evaluator {
+	return p.handler.filterEvaluator
+}
+
+// Added implements [patch.ResourcePatcher],
+// the changes are detected after all operations are applied.
+func (p *groupPatcher) Added([]string) error {
+	return nil
+}
+
+// Replaced implements [patch.ResourcePatcher],
+// the changes are detected after all operations are applied.
+func (p *groupPatcher) Replaced([]string) error {
+	return nil
+}
+
+// Removed implements [patch.ResourcePatcher],
+// the changes are detected after all operations are applied.
+func (p *groupPatcher) Removed([]string) error {
+	return nil
+}
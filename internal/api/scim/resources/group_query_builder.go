@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// groupFieldPathColumnMapping maps lowercase json field names of the scim group to the matching column in the projection
+// members are not supported, as they are stored in the user grants.
+var groupFieldPathColumnMapping = filter.FieldPathMapping{
+	"meta.created": {
+		Column:    query.ProjectRoleColumnCreationDate,
+		FieldType: filter.FieldTypeTimestamp,
+	},
+	"meta.lastmodified": {
+		Column:    query.ProjectRoleColumnChangeDate,
+		FieldType: filter.FieldTypeTimestamp,
+	},
+	"id": {
+		Column:    query.ProjectRoleColumnKey,
+		FieldType: filter.FieldTypeString,
+	},
+	"displayname": {
+		Column:    query.ProjectRoleColumnDisplayName,
+		FieldType: filter.FieldTypeString,
+	},
+}
+
+func (h *GroupsHandler) buildListQuery(ctx context.Context, request *ListRequest) (*query.ProjectRoleSearchQueries, error) {
+	searchRequest, err := request.toSearchRequest(h.schema.ID, query.ProjectRoleColumnKey, groupFieldPathColumnMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &query.ProjectRoleSearchQueries{
+		SearchRequest: searchRequest,
+	}
+
+	// the groups of an organization are the roles of its groups project
+	orgID := authz.GetCtxData(ctx).OrgID
+	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(groupsProjectID(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	orgIDQuery, err := query.NewProjectRoleResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Queries = append(q.Queries, projectIDQuery, orgIDQuery)
+
+	if request.Filter == nil {
+		return q, nil
+	}
+
+	filterQuery, err := request.Filter.BuildQuery(ctx, h.schema.ID, groupFieldPathColumnMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Queries = append(q.Queries, filterQuery)
+	return q, nil
+}
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/patch"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/test"
+)
+
+func Test_diffMembers(t *testing.T) {
+	grant1 := &query.UserGrant{ID: "grant1", UserID: "user1"}
+	grant2 := &query.UserGrant{ID: "grant2", UserID: "user2"}
+	tests := []struct {
+		name        string
+		grants      []*query.UserGrant
+		members     []*ScimGroupMember
+		wantAdded   []string
+		wantRemoved []*query.UserGrant
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "add members",
+			members:   []*ScimGroupMember{{Value: "user1"}, {Value: "user2"}},
+			wantAdded: []string{"user1", "user2"},
+		},
+		{
+			name:        "remove all members",
+			grants:      []*query.UserGrant{grant1, grant2},
+			wantRemoved: []*query.UserGrant{grant1, grant2},
+		},
+		{
+			name:        "add and remove members",
+			grants:      []*query.UserGrant{grant1, grant2},
+			members:     []*ScimGroupMember{{Value: "user2"}, {Value: "user3"}},
+			wantAdded:   []string{"user3"},
+			wantRemoved: []*query.UserGrant{grant1},
+		},
+		{
+			name:      "duplicate and empty members",
+			members:   []*ScimGroupMember{{Value: "user1"}, nil, {Value: ""}, {Value: "user1"}},
+			wantAdded: []string{"user1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffMembers(tt.grants, tt.members)
+			assert.Equal(t, tt.wantAdded, added)
+			assert.Equal(t, tt.wantRemoved, removed)
+		})
+	}
+}
+
+func Test_groupPatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      *patch.Operation
+		want    *ScimGroup
+		wantErr bool
+	}{
+		{
+			name: "add members",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeAdd,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`[{ "value": "user3" }]`),
+			},
+			want: &ScimGroup{
+				DisplayName: "group",
+				Members:     []*ScimGroupMember{{Value: "user1"}, {Value: "user2"}, {Value: "user3"}},
+			},
+		},
+		{
+			name: "remove member by filter",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath(`members[value eq "user1"]`)),
+			},
+			want: &ScimGroup{
+				DisplayName: "group",
+				Members:     []*ScimGroupMember{{Value: "user2"}},
+			},
+		},
+		{
+			name: "replace members",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeReplace,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`[{ "value": "user4" }]`),
+			},
+			want: &ScimGroup{
+				DisplayName: "group",
+				Members:     []*ScimGroupMember{{Value: "user4"}},
+			},
+		},
+		{
+			name: "replace display name",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeReplace,
+				Value:     json.RawMessage(`{ "displayName": "renamed" }`),
+			},
+			want: &ScimGroup{
+				DisplayName: "renamed",
+				Members:     []*ScimGroupMember{{Value: "user1"}, {Value: "user2"}},
+			},
+		},
+		{
+			name: "unknown path",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeAdd,
+				Path:      test.Must(filter.ParsePath("owners")),
+				Value:     json.RawMessage(`[{ "value": "user3" }]`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := &ScimGroup{
+				DisplayName: "group",
+				Members:     []*ScimGroupMember{{Value: "user1"}, {Value: "user2"}},
+			}
+			patcher := &groupPatcher{handler: &GroupsHandler{filterEvaluator: filter.NewEvaluator(schemas.IdGroup)}}
+			err := patch.OperationCollection{tt.op}.Apply(patcher, group)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, group)
+		})
+	}
+}
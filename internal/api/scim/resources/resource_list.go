@@ -109,7 +109,7 @@ func (adapter *ResourceHandlerAdapter[T]) readListRequest(r *http.Request) (*Lis
 	return request, request.validate()
 }
 
-func (r *ListRequest) toSearchRequest(defaultSortCol query.Column, fieldPathColumnMapping filter.FieldPathMapping) (query.SearchRequest, error) {
+func (r *ListRequest) toSearchRequest(schema schemas.ScimSchemaType, defaultSortCol query.Column, fieldPathColumnMapping filter.FieldPathMapping) (query.SearchRequest, error) {
 	sr := query.SearchRequest{
 		Offset: uint64(r.StartIndex - 1), // start index is 1 based
 		Limit:  uint64(r.Count),
@@ -119,7 +119,8 @@ func (r *ListRequest) toSearchRequest(defaultSortCol query.Column, fieldPathColu
 	if r.SortBy == "" {
 		// set a default sort to ensure consistent results
 		sr.SortingColumn = defaultSortCol
-	} else if sortCol, err := fieldPathColumnMapping.Resolve(r.SortBy); err != nil || sortCol.FieldType == filter.FieldTypeCustom {
+	} else if sortCol, err := fieldPathColumnMapping.ResolveAttrPath(schema, r.SortBy); err != nil || sortCol.Column == (query.Column{}) {
+		// fields without a column (e.g. attributes stored in the metadata) are not sortable
 		return sr, serrors.ThrowInvalidValue(zerrors.ThrowInvalidArgument(err, "SCIM-SRT1", "SortBy field is unknown or not supported"))
 	} else {
 		sr.SortingColumn = sortCol.Column
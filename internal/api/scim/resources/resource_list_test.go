@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 func TestListRequest_validate(t *testing.T) {
@@ -77,3 +80,82 @@ func TestListRequest_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestListRequest_toSearchRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *ListRequest
+		want    query.SearchRequest
+		wantErr bool
+	}{
+		{
+			name: "default sort",
+			req: &ListRequest{
+				StartIndex: 1,
+				Count:      10,
+				SortOrder:  ListRequestSortOrderAsc,
+			},
+			want: query.SearchRequest{
+				Limit:         10,
+				Asc:           true,
+				SortingColumn: query.UserIDCol,
+			},
+		},
+		{
+			name: "sort by attribute",
+			req: &ListRequest{
+				StartIndex: 11,
+				Count:      10,
+				SortBy:     "name.familyName",
+				SortOrder:  ListRequestSortOrderDsc,
+			},
+			want: query.SearchRequest{
+				Offset:        10,
+				Limit:         10,
+				SortingColumn: query.HumanLastNameCol,
+			},
+		},
+		{
+			name: "sort by attribute with schema urn",
+			req: &ListRequest{
+				StartIndex: 1,
+				SortBy:     "urn:ietf:params:scim:schemas:core:2.0:User:meta.lastModified",
+				SortOrder:  ListRequestSortOrderAsc,
+			},
+			want: query.SearchRequest{
+				Asc:           true,
+				SortingColumn: query.UserChangeDateCol,
+			},
+		},
+		{
+			name: "sort by metadata attribute",
+			req: &ListRequest{
+				StartIndex: 1,
+				SortBy:     "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department",
+				SortOrder:  ListRequestSortOrderAsc,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sort by unknown attribute",
+			req: &ListRequest{
+				StartIndex: 1,
+				SortBy:     "foo",
+				SortOrder:  ListRequestSortOrderAsc,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.toSearchRequest(schemas.IdUser, query.UserIDCol, fieldPathColumnMapping)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
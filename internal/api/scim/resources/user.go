@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"slices"
 
 	"golang.org/x/text/language"
 
@@ -48,6 +49,23 @@ type ScimUser struct {
 	Photos                 []*ScimPhoto                  `json:"photos,omitempty"`
 	Entitlements           []*ScimEntitlement            `json:"entitlements,omitempty"`
 	Roles                  []*ScimRole                   `json:"roles,omitempty"`
+	EnterpriseUser         *ScimEnterpriseUser           `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty" scim:"ignoreInSchema"`
+}
+
+// ScimEnterpriseUser the attributes of the enterprise user schema extension,
+// see https://datatracker.ietf.org/doc/html/rfc7643#section-4.3
+type ScimEnterpriseUser struct {
+	EmployeeNumber string                     `json:"employeeNumber,omitempty"`
+	CostCenter     string                     `json:"costCenter,omitempty"`
+	Organization   string                     `json:"organization,omitempty"`
+	Division       string                     `json:"division,omitempty"`
+	Department     string                     `json:"department,omitempty"`
+	Manager        *ScimEnterpriseUserManager `json:"manager,omitempty"`
+}
+
+type ScimEnterpriseUserManager struct {
+	// Value the id of the manager
+	Value string `json:"value,omitempty"`
 }
 
 type ScimEntitlement struct {
@@ -111,6 +129,20 @@ func NewUsersHandler(
 	query *query.Queries,
 	userCodeAlg crypto.EncryptionAlgorithm,
 	config *scim_config.Config) ResourceHandler[*ScimUser] {
+	return newUsersHandler(command, query, userCodeAlg, config)
+}
+
+// NewUserProvisioningMapper returns a handler which maps users to the scim user resource
+// sent to the scim service providers of the applications, see [UsersHandler.ProvisioningUser].
+func NewUserProvisioningMapper(query *query.Queries, config *scim_config.Config) *UsersHandler {
+	return newUsersHandler(nil, query, nil, config)
+}
+
+func newUsersHandler(
+	command *command.Commands,
+	query *query.Queries,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	config *scim_config.Config) *UsersHandler {
 	return &UsersHandler{
 		command,
 		query,
@@ -127,6 +159,22 @@ func NewUsersHandler(
 	}
 }
 
+// enterpriseUser returns the enterprise extension of the user, an empty extension if it is not set.
+func (u *ScimUser) enterpriseUser() *ScimEnterpriseUser {
+	if u.EnterpriseUser == nil {
+		return new(ScimEnterpriseUser)
+	}
+	return u.EnterpriseUser
+}
+
+// addExtensionSchemas adds the ids of the set schema extensions to the schemas of the user.
+func (u *ScimUser) addExtensionSchemas() {
+	if u.Resource == nil || u.EnterpriseUser == nil || slices.Contains(u.Resource.Schemas, scim_schemas.IdEnterpriseUser) {
+		return
+	}
+	u.Resource.Schemas = append(u.Resource.Schemas, scim_schemas.IdEnterpriseUser)
+}
+
 func (u *ScimUser) GetResource() *scim_schemas.Resource {
 	return u.Resource
 }
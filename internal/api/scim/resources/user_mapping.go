@@ -195,6 +195,7 @@ func (h *UsersHandler) mapPrimaryPhone(scimUser *ScimUser) *command.Phone {
 func (h *UsersHandler) mapAddCommandToScimUser(ctx context.Context, user *ScimUser, addHuman *command.AddHuman) {
 	user.ID = addHuman.Details.ID
 	user.Resource = buildResource(ctx, h, addHuman.Details)
+	user.addExtensionSchemas()
 	user.Password = nil
 
 	// ZITADEL supports only one (primary) phone number or email.
@@ -222,6 +223,7 @@ func (h *UsersHandler) mapAddCommandToScimUser(ctx context.Context, user *ScimUs
 func (h *UsersHandler) mapChangeCommandToScimUser(ctx context.Context, user *ScimUser, changeHuman *command.ChangeHuman) {
 	user.ID = changeHuman.Details.ID
 	user.Resource = buildResource(ctx, h, changeHuman.Details)
+	user.addExtensionSchemas()
 	user.Password = nil
 
 	// ZITADEL supports only one (primary) phone number or email.
@@ -298,6 +300,34 @@ func (h *UsersHandler) mapToScimUser(ctx context.Context, user *query.User, md m
 	return scimUser
 }
 
+// ProvisioningUser maps the human user to the scim user resource sent to the scim service providers of applications.
+// The id is assigned by the service provider and therefore left empty,
+// the id of the user in zitadel is sent as externalId.
+func (h *UsersHandler) ProvisioningUser(ctx context.Context, userID string) (*ScimUser, error) {
+	user, err := h.query.GetUserByID(ctx, true, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Type != domain.UserTypeHuman {
+		return nil, zerrors.ThrowNotFound(nil, "SCIM-w9m3kx0qf2", "Errors.Users.NotFound")
+	}
+
+	md, err := h.queryMetadataForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser := h.mapToScimUser(ctx, user, md)
+	// the location and meta data of the resource are assigned by the service provider
+	scimUser.Resource = &schemas.Resource{
+		Schemas: scimUser.GetSchemas(),
+	}
+	scimUser.ID = ""
+	scimUser.ExternalID = user.ID
+	return scimUser, nil
+}
+
 func (h *UsersHandler) mapWriteModelToScimUser(ctx context.Context, user *command.UserV2WriteModel) *ScimUser {
 	scimUser := &ScimUser{
 		Resource:          h.buildResourceForWriteModel(ctx, user),
@@ -346,6 +376,7 @@ func (h *UsersHandler) mapAndValidateMetadata(ctx context.Context, user *ScimUse
 	user.Name.MiddleName = extractScalarMetadata(ctx, md, metadata.KeyMiddleName)
 	user.Name.HonorificPrefix = extractScalarMetadata(ctx, md, metadata.KeyHonorificPrefix)
 	user.Name.HonorificSuffix = extractScalarMetadata(ctx, md, metadata.KeyHonorificSuffix)
+	mapEnterpriseUserMetadata(ctx, user, md)
 
 	if user.Locale != "" {
 		_, err := language.Parse(user.Locale)
@@ -384,6 +415,28 @@ func (h *UsersHandler) mapAndValidateMetadata(ctx context.Context, user *ScimUse
 	}
 }
 
+// mapEnterpriseUserMetadata sets the enterprise extension of the user if at least one of its attributes is set.
+func mapEnterpriseUserMetadata(ctx context.Context, user *ScimUser, md map[metadata.ScopedKey][]byte) {
+	enterpriseUser := &ScimEnterpriseUser{
+		EmployeeNumber: extractScalarMetadata(ctx, md, metadata.KeyEmployeeNumber),
+		CostCenter:     extractScalarMetadata(ctx, md, metadata.KeyCostCenter),
+		Organization:   extractScalarMetadata(ctx, md, metadata.KeyOrganization),
+		Division:       extractScalarMetadata(ctx, md, metadata.KeyDivision),
+		Department:     extractScalarMetadata(ctx, md, metadata.KeyDepartment),
+	}
+	if manager := extractScalarMetadata(ctx, md, metadata.KeyManager); manager != "" {
+		enterpriseUser.Manager = &ScimEnterpriseUserManager{Value: manager}
+	}
+
+	if *enterpriseUser == (ScimEnterpriseUser{}) {
+		user.EnterpriseUser = nil
+		return
+	}
+
+	user.EnterpriseUser = enterpriseUser
+	user.addExtensionSchemas()
+}
+
 func (h *UsersHandler) buildResourceForQuery(ctx context.Context, user *query.User) *schemas.Resource {
 	return &schemas.Resource{
 		ID:      user.ID,
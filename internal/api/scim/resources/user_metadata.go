@@ -154,7 +154,13 @@ func getValueForMetadataKey(user *ScimUser, key metadata.Key) ([]byte, error) {
 		metadata.KeyHonorificSuffix,
 		metadata.KeyMiddleName,
 		metadata.KeyExternalId,
-		metadata.KeyProvisioningDomain:
+		metadata.KeyProvisioningDomain,
+		metadata.KeyEmployeeNumber,
+		metadata.KeyCostCenter,
+		metadata.KeyOrganization,
+		metadata.KeyDivision,
+		metadata.KeyDepartment,
+		metadata.KeyManager:
 		valueStr := value.(string)
 		if valueStr == "" {
 			return nil, nil
@@ -223,6 +229,21 @@ func getRawValueForMetadataKey(user *ScimUser, key metadata.Key) interface{} {
 		return user.Locale
 	case metadata.KeyTimezone:
 		return user.Timezone
+	case metadata.KeyEmployeeNumber:
+		return user.enterpriseUser().EmployeeNumber
+	case metadata.KeyCostCenter:
+		return user.enterpriseUser().CostCenter
+	case metadata.KeyOrganization:
+		return user.enterpriseUser().Organization
+	case metadata.KeyDivision:
+		return user.enterpriseUser().Division
+	case metadata.KeyDepartment:
+		return user.enterpriseUser().Department
+	case metadata.KeyManager:
+		if user.enterpriseUser().Manager == nil {
+			return ""
+		}
+		return user.enterpriseUser().Manager.Value
 	case metadata.KeyProvisioningDomain:
 		break
 	}
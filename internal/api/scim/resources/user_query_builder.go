@@ -2,10 +2,12 @@ package resources
 
 import (
 	"context"
+	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/scim/metadata"
 	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
 	"github.com/zitadel/zitadel/internal/api/scim/serrors"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -33,6 +35,18 @@ var fieldPathColumnMapping = filter.FieldPathMapping{
 		FieldType:       filter.FieldTypeString,
 		CaseInsensitive: true,
 	},
+	"displayname": {
+		Column:    query.HumanDisplayNameCol,
+		FieldType: filter.FieldTypeString,
+	},
+	"nickname": {
+		Column:    query.HumanNickNameCol,
+		FieldType: filter.FieldTypeString,
+	},
+	"preferredlanguage": {
+		Column:    query.HumanPreferredLanguageCol,
+		FieldType: filter.FieldTypeString,
+	},
 	"name.familyname": {
 		Column:    query.HumanLastNameCol,
 		FieldType: filter.FieldTypeString,
@@ -49,7 +63,33 @@ var fieldPathColumnMapping = filter.FieldPathMapping{
 		Column:    query.HumanEmailCol,
 		FieldType: filter.FieldTypeString,
 	},
+	"emails.type": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMultiValuedTypeQueryBuilder(query.HumanEmailCol),
+	},
+	"emails.primary": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMultiValuedPrimaryQueryBuilder(query.HumanEmailCol),
+	},
+	"phonenumbers": {
+		Column:    query.HumanPhoneCol,
+		FieldType: filter.FieldTypeString,
+	},
+	"phonenumbers.value": {
+		Column:    query.HumanPhoneCol,
+		FieldType: filter.FieldTypeString,
+	},
+	"phonenumbers.type": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMultiValuedTypeQueryBuilder(query.HumanPhoneCol),
+	},
+	"phonenumbers.primary": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMultiValuedPrimaryQueryBuilder(query.HumanPhoneCol),
+	},
 	"active": {
+		// the column is only used for sorting
+		Column:           query.UserStateCol,
 		FieldType:        filter.FieldTypeCustom,
 		BuildMappedQuery: buildActiveUserStateQuery,
 	},
@@ -57,10 +97,69 @@ var fieldPathColumnMapping = filter.FieldPathMapping{
 		FieldType:        filter.FieldTypeCustom,
 		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyExternalId),
 	},
+	"name.middlename": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyMiddleName),
+	},
+	"name.honorificprefix": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyHonorificPrefix),
+	},
+	"name.honorificsuffix": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyHonorificSuffix),
+	},
+	"profileurl": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyProfileUrl),
+	},
+	"title": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyTitle),
+	},
+	"locale": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyLocale),
+	},
+	"timezone": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyTimezone),
+	},
+	enterpriseUserFieldPrefix + "employeenumber": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyEmployeeNumber),
+	},
+	enterpriseUserFieldPrefix + "costcenter": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyCostCenter),
+	},
+	enterpriseUserFieldPrefix + "organization": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyOrganization),
+	},
+	enterpriseUserFieldPrefix + "division": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyDivision),
+	},
+	enterpriseUserFieldPrefix + "department": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyDepartment),
+	},
+	enterpriseUserFieldPrefix + "manager": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyManager),
+	},
+	enterpriseUserFieldPrefix + "manager.value": {
+		FieldType:        filter.FieldTypeCustom,
+		BuildMappedQuery: newMetadataQueryBuilder(metadata.KeyManager),
+	},
 }
 
+// enterpriseUserFieldPrefix is the prefix of the fields of the enterprise user extension in the fieldPathColumnMapping
+var enterpriseUserFieldPrefix = strings.ToLower(string(schemas.IdEnterpriseUser)) + ":"
+
 func (h *UsersHandler) buildListQuery(ctx context.Context, request *ListRequest) (*query.UserSearchQueries, error) {
-	searchRequest, err := request.toSearchRequest(query.UserIDCol, fieldPathColumnMapping)
+	searchRequest, err := request.toSearchRequest(h.schema.ID, query.UserIDCol, fieldPathColumnMapping)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +214,12 @@ func buildMetadataQuery(ctx context.Context, key metadata.Key, value *filter.Com
 		comparisonOperator = query.BytesEquals
 	case op.NotEqual:
 		comparisonOperator = query.BytesNotEquals
+	case op.StartsWith:
+		comparisonOperator = query.BytesStartsWith
+	case op.EndsWith:
+		comparisonOperator = query.BytesEndsWith
+	case op.Contains:
+		comparisonOperator = query.BytesContains
 	default:
 		return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-EXid1", "invalid filter expression: unsupported comparison operator"))
 	}
@@ -123,6 +228,45 @@ func buildMetadataQuery(ctx context.Context, key metadata.Key, value *filter.Com
 	return query.NewUserMetadataExistsQuery(scopedKey, []byte(*value.StringValue), query.TextEquals, comparisonOperator)
 }
 
+// newMultiValuedTypeQueryBuilder builds queries for the type of multi-valued attributes (emails, phoneNumbers).
+// ZITADEL stores only one (primary) value without a type,
+// therefore a filter for any type matches users which have a value.
+func newMultiValuedTypeQueryBuilder(col query.Column) filter.MappedQueryBuilderFunc {
+	return func(_ context.Context, compareValue *filter.CompValue, op *filter.CompareOp) (query.SearchQuery, error) {
+		if !op.Equal {
+			return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-MVt1", "invalid filter expression: type unsupported comparison operator"))
+		}
+
+		if compareValue.StringValue == nil {
+			return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-MVt2", "invalid filter expression: type unsupported comparison value"))
+		}
+
+		return query.NewNotNullQuery(col)
+	}
+}
+
+// newMultiValuedPrimaryQueryBuilder builds queries for the primary flag of multi-valued attributes (emails, phoneNumbers).
+// ZITADEL stores only one value which is always the primary one.
+func newMultiValuedPrimaryQueryBuilder(col query.Column) filter.MappedQueryBuilderFunc {
+	return func(_ context.Context, compareValue *filter.CompValue, op *filter.CompareOp) (query.SearchQuery, error) {
+		if !op.Equal && !op.NotEqual {
+			return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-MVp1", "invalid filter expression: primary unsupported comparison operator"))
+		}
+
+		if !compareValue.BooleanTrue && !compareValue.BooleanFalse {
+			return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-MVp2", "invalid filter expression: primary unsupported comparison value"))
+		}
+
+		primary := compareValue.BooleanTrue && op.Equal || compareValue.BooleanFalse && op.NotEqual
+		if primary {
+			return query.NewNotNullQuery(col)
+		}
+
+		// there are no non-primary values, the query must not match any user
+		return query.NewIsNullQuery(query.UserIDCol)
+	}
+}
+
 func buildActiveUserStateQuery(_ context.Context, compareValue *filter.CompValue, op *filter.CompareOp) (query.SearchQuery, error) {
 	if !op.Equal && !op.NotEqual {
 		return nil, serrors.ThrowInvalidFilter(zerrors.ThrowInvalidArgument(nil, "SCIM-MGdg", "invalid filter expression: active unsupported comparison operator"))
@@ -42,10 +42,34 @@ func Test_buildMetadataQuery(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "unsupported operator",
+			name:    "starts with",
 			key:     "foo",
 			value:   &filter.CompValue{StringValue: gu.Ptr("bar")},
 			op:      &filter.CompareOp{StartsWith: true},
+			want:    test.Must(query.NewUserMetadataExistsQuery("foo", []byte("bar"), query.TextEquals, query.BytesStartsWith)),
+			wantErr: false,
+		},
+		{
+			name:    "ends with",
+			key:     "foo",
+			value:   &filter.CompValue{StringValue: gu.Ptr("bar")},
+			op:      &filter.CompareOp{EndsWith: true},
+			want:    test.Must(query.NewUserMetadataExistsQuery("foo", []byte("bar"), query.TextEquals, query.BytesEndsWith)),
+			wantErr: false,
+		},
+		{
+			name:    "contains",
+			key:     "foo",
+			value:   &filter.CompValue{StringValue: gu.Ptr("bar")},
+			op:      &filter.CompareOp{Contains: true},
+			want:    test.Must(query.NewUserMetadataExistsQuery("foo", []byte("bar"), query.TextEquals, query.BytesContains)),
+			wantErr: false,
+		},
+		{
+			name:    "unsupported operator",
+			key:     "foo",
+			value:   &filter.CompValue{StringValue: gu.Ptr("bar")},
+			op:      &filter.CompareOp{GreaterThan: true},
 			wantErr: true,
 		},
 		{
@@ -142,3 +166,97 @@ func Test_buildActiveUserStateQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_newMultiValuedTypeQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name         string
+		compareValue *filter.CompValue
+		compOp       *filter.CompareOp
+		want         query.SearchQuery
+		wantErr      bool
+	}{
+		{
+			name:         "eq",
+			compareValue: &filter.CompValue{StringValue: gu.Ptr("work")},
+			compOp:       &filter.CompareOp{Equal: true},
+			want:         test.Must(query.NewNotNullQuery(query.HumanEmailCol)),
+		},
+		{
+			name:         "invalid operator",
+			compareValue: &filter.CompValue{StringValue: gu.Ptr("work")},
+			compOp:       &filter.CompareOp{NotEqual: true},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid comp value",
+			compareValue: &filter.CompValue{BooleanTrue: true},
+			compOp:       &filter.CompareOp{Equal: true},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newMultiValuedTypeQueryBuilder(query.HumanEmailCol)(context.Background(), tt.compareValue, tt.compOp)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newMultiValuedPrimaryQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name         string
+		compareValue *filter.CompValue
+		compOp       *filter.CompareOp
+		want         query.SearchQuery
+		wantErr      bool
+	}{
+		{
+			name:         "eq true",
+			compareValue: &filter.CompValue{BooleanTrue: true},
+			compOp:       &filter.CompareOp{Equal: true},
+			want:         test.Must(query.NewNotNullQuery(query.HumanPhoneCol)),
+		},
+		{
+			name:         "ne false",
+			compareValue: &filter.CompValue{BooleanFalse: true},
+			compOp:       &filter.CompareOp{NotEqual: true},
+			want:         test.Must(query.NewNotNullQuery(query.HumanPhoneCol)),
+		},
+		{
+			name:         "eq false",
+			compareValue: &filter.CompValue{BooleanFalse: true},
+			compOp:       &filter.CompareOp{Equal: true},
+			want:         test.Must(query.NewIsNullQuery(query.UserIDCol)),
+		},
+		{
+			name:         "invalid operator",
+			compareValue: &filter.CompValue{BooleanTrue: true},
+			compOp:       &filter.CompareOp{Contains: true},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid comp value",
+			compareValue: &filter.CompValue{StringValue: gu.Ptr("foo")},
+			compOp:       &filter.CompareOp{Equal: true},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newMultiValuedPrimaryQueryBuilder(query.HumanPhoneCol)(context.Background(), tt.compareValue, tt.compOp)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -17,11 +17,14 @@ const (
 	idPrefixMessages        = "urn:ietf:params:scim:api:messages:2.0:"
 	idPrefixCore            = "urn:ietf:params:scim:schemas:core:2.0:"
 	idPrefixZitadelMessages = "urn:ietf:params:scim:api:zitadel:messages:2.0:"
+	idPrefixExtension       = "urn:ietf:params:scim:schemas:extension:"
 
 	IdUser                  ScimSchemaType = idPrefixCore + "User"
+	IdGroup                 ScimSchemaType = idPrefixCore + "Group"
 	IdServiceProviderConfig ScimSchemaType = idPrefixCore + "ServiceProviderConfig"
 	IdResourceType          ScimSchemaType = idPrefixCore + "ResourceType"
 	IdSchema                ScimSchemaType = idPrefixCore + "Schema"
+	IdEnterpriseUser        ScimSchemaType = idPrefixExtension + "enterprise:2.0:User"
 	IdListResponse          ScimSchemaType = idPrefixMessages + "ListResponse"
 	IdPatchOperation        ScimSchemaType = idPrefixMessages + "PatchOp"
 	IdSearchRequest         ScimSchemaType = idPrefixMessages + "SearchRequest"
@@ -33,6 +36,9 @@ const (
 	UserResourceType  ScimResourceTypeSingular = "User"
 	UsersResourceType ScimResourceTypePlural   = "Users"
 
+	GroupResourceType  ScimResourceTypeSingular = "Group"
+	GroupsResourceType ScimResourceTypePlural   = "Groups"
+
 	ServiceProviderConfigResourceType  ScimResourceTypeSingular = "ServiceProviderConfig"
 	ServiceProviderConfigsResourceType ScimResourceTypePlural   = "ServiceProviderConfig"
 
@@ -47,10 +47,13 @@ func buildHandler(
 	usersHandler := sresources.NewResourceHandlerAdapter(sresources.NewUsersHandler(command, query, userCodeAlg, cfg))
 	mapResource(router, middleware, usersHandler)
 
+	groupsHandler := sresources.NewResourceHandlerAdapter(sresources.NewGroupsHandler(command, query, cfg))
+	mapResource(router, middleware, groupsHandler)
+
 	bulkHandler := sresources.NewBulkHandler(cfg.Bulk, usersHandler)
 	router.Handle("/"+zhttp.OrgIdInPathVariable+"/Bulk", middleware(handleJsonResponse(bulkHandler.BulkFromHttp))).Methods(http.MethodPost)
 
-	serviceProviderHandler := newServiceProviderHandler(cfg, usersHandler)
+	serviceProviderHandler := newServiceProviderHandler(cfg, usersHandler, groupsHandler)
 	router.Handle("/"+zhttp.OrgIdInPathVariable+"/ServiceProviderConfig", middleware(handleJsonResponse(serviceProviderHandler.GetConfig))).Methods(http.MethodGet)
 	router.Handle("/"+zhttp.OrgIdInPathVariable+"/ResourceTypes", middleware(handleJsonResponse(serviceProviderHandler.ListResourceTypes))).Methods(http.MethodGet)
 	router.Handle("/"+zhttp.OrgIdInPathVariable+"/ResourceTypes/{name}", middleware(handleResourceResponse(serviceProviderHandler.GetResourceType))).Methods(http.MethodGet)
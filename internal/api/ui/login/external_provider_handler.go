@@ -3,6 +3,7 @@ package login
 import (
 	"context"
 	"errors"
+	"maps"
 	"net/http"
 	"net/url"
 	"slices"
@@ -28,6 +29,7 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/kerberos"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
@@ -180,6 +182,8 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.appleProvider(r.Context(), identityProvider)
 	case domain.IDPTypeLDAP:
 		provider, err = l.ldapProvider(r.Context(), identityProvider)
+	case domain.IDPTypeKerberos:
+		provider, err = l.kerberosProvider(r.Context(), identityProvider)
 	case domain.IDPTypeSAML:
 		provider, err = l.samlProvider(r.Context(), identityProvider)
 	case domain.IDPTypeUnspecified:
@@ -350,6 +354,7 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		}
 	case domain.IDPTypeJWT,
 		domain.IDPTypeLDAP,
+		domain.IDPTypeKerberos,
 		domain.IDPTypeUnspecified:
 		fallthrough
 	default:
@@ -492,6 +497,7 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
+	l.syncLDAPUserGrants(r.Context(), authReq, provider, externalUser)
 	callback(w, r, authReq)
 }
 
@@ -773,9 +779,31 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if len(externalUser.Groups) > 0 {
+		provider, err := l.getIDPByID(r, externalUser.IDPConfigID)
+		logging.WithFields("authReq", authReq.ID, "idp", externalUser.IDPConfigID).OnError(err).Error("unable to get idp for ldap grants sync")
+		if err == nil {
+			l.syncLDAPUserGrants(r.Context(), authReq, provider, externalUser)
+		}
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
+// syncLDAPUserGrants grants the user the project roles mapped to its LDAP groups.
+// A failed sync does not prevent the login, the grants are corrected by the next login or directory sync.
+func (l *Login) syncLDAPUserGrants(ctx context.Context, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser) {
+	if provider.LDAPIDPTemplate == nil || provider.LDAPIDPTemplate.GroupMapping.IsZero() {
+		return
+	}
+	// the mapping of an organization's identity provider is restricted to projects of (or granted to) the organization
+	var orgID string
+	if provider.OwnerType == domain.IdentityProviderTypeOrg {
+		orgID = provider.ResourceOwner
+	}
+	err := l.command.SyncLDAPUserGrants(setContext(ctx, orgID), authReq.UserID, orgID, provider.LDAPIDPTemplate.GroupMapping, externalUser.Groups)
+	logging.WithFields("authReq", authReq.ID, "user", authReq.UserID).OnError(err).Error("unable to sync ldap user grants")
+}
+
 // updateExternalUser will update the existing user (email, phone, profile) with data provided by the IDP
 func (l *Login) updateExternalUser(ctx context.Context, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) error {
 	user, err := l.query.GetUserByID(ctx, true, authReq.UserID)
@@ -966,6 +994,9 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 	if identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute != "" {
 		opts = append(opts, ldap.WithProfileAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute))
 	}
+	if !identityProvider.LDAPIDPTemplate.GroupMapping.IsZero() {
+		opts = append(opts, ldap.WithGroupAttribute(identityProvider.LDAPIDPTemplate.GroupMapping.GroupAttribute))
+	}
 	return ldap.New(
 		identityProvider.Name,
 		identityProvider.Servers,
@@ -1005,11 +1036,14 @@ func (l *Login) oidcProvider(ctx context.Context, identityProvider *query.IDPTem
 	if err != nil {
 		return nil, err
 	}
-	opts := make([]openid.ProviderOpts, 1, 2)
+	opts := make([]openid.ProviderOpts, 1, 3)
 	opts[0] = openid.WithSelectAccount()
 	if identityProvider.OIDCIDPTemplate.IsIDTokenMapping {
 		opts = append(opts, openid.WithIDTokenMapping())
 	}
+	if !identityProvider.OIDCIDPTemplate.ClaimMapping.IsZero() {
+		opts = append(opts, openid.WithClaimMapping(identityProvider.OIDCIDPTemplate.ClaimMapping))
+	}
 	return openid.New(identityProvider.Name,
 		identityProvider.OIDCIDPTemplate.Issuer,
 		identityProvider.OIDCIDPTemplate.ClientID,
@@ -1047,6 +1081,10 @@ func (l *Login) oauthProvider(ctx context.Context, identityProvider *query.IDPTe
 		RedirectURL: l.baseURL(ctx) + EndpointExternalLoginCallback,
 		Scopes:      identityProvider.OAuthIDPTemplate.Scopes,
 	}
+	opts := make([]oauth.ProviderOpts, 0, 1)
+	if !identityProvider.OAuthIDPTemplate.ClaimMapping.IsZero() {
+		opts = append(opts, oauth.WithClaimMapping(identityProvider.OAuthIDPTemplate.ClaimMapping))
+	}
 	return oauth.New(
 		config,
 		identityProvider.Name,
@@ -1054,6 +1092,7 @@ func (l *Login) oauthProvider(ctx context.Context, identityProvider *query.IDPTe
 		func() idp.User {
 			return oauth.NewUserMapper(identityProvider.OAuthIDPTemplate.IDAttribute)
 		},
+		opts...,
 	)
 }
 
@@ -1062,7 +1101,18 @@ func (l *Login) samlProvider(ctx context.Context, identityProvider *query.IDPTem
 	if err != nil {
 		return nil, err
 	}
-	opts := make([]saml.ProviderOpts, 0, 6)
+	opts := make([]saml.ProviderOpts, 0, 8)
+	if len(identityProvider.SAMLIDPTemplate.AdditionalKeys) > 0 {
+		additionalKeys := make([]*saml.Key, len(identityProvider.SAMLIDPTemplate.AdditionalKeys))
+		for i, additionalKey := range identityProvider.SAMLIDPTemplate.AdditionalKeys {
+			decrypted, err := crypto.Decrypt(additionalKey.Key, l.idpConfigAlg)
+			if err != nil {
+				return nil, err
+			}
+			additionalKeys[i] = &saml.Key{Certificate: additionalKey.Certificate, Key: decrypted}
+		}
+		opts = append(opts, saml.WithAdditionalKeys(additionalKeys...))
+	}
 	if identityProvider.SAMLIDPTemplate.WithSignedRequest {
 		opts = append(opts, saml.WithSignedRequest())
 	}
@@ -1075,6 +1125,9 @@ func (l *Login) samlProvider(ctx context.Context, identityProvider *query.IDPTem
 	if identityProvider.SAMLIDPTemplate.TransientMappingAttributeName != "" {
 		opts = append(opts, saml.WithTransientMappingAttributeName(identityProvider.SAMLIDPTemplate.TransientMappingAttributeName))
 	}
+	if !identityProvider.SAMLIDPTemplate.ClaimMapping.IsZero() {
+		opts = append(opts, saml.WithClaimMapping(identityProvider.SAMLIDPTemplate.ClaimMapping))
+	}
 	opts = append(opts,
 		saml.WithEntityID(http_utils.DomainContext(ctx).Origin()+"/idps/"+identityProvider.ID+"/saml/metadata"),
 		saml.WithCustomRequestTracker(
@@ -1201,6 +1254,19 @@ func (l *Login) appleProvider(ctx context.Context, identityProvider *query.IDPTe
 	)
 }
 
+func (l *Login) kerberosProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*kerberos.Provider, error) {
+	keytab, err := crypto.Decrypt(identityProvider.KerberosIDPTemplate.Keytab, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return kerberos.New(
+		identityProvider.Name,
+		identityProvider.KerberosIDPTemplate.ServicePrincipal,
+		keytab,
+		l.baseURL(ctx)+EndpointKerberosLogin+"?"+QueryAuthRequestID+"=",
+	)
+}
+
 func (l *Login) appendUserGrants(ctx context.Context, userGrants []*domain.UserGrant, resourceOwner string) error {
 	if len(userGrants) == 0 {
 		return nil
@@ -1273,7 +1339,7 @@ func tokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 }
 
 func mapIDPUserToExternalUser(user idp.User, id string) *domain.ExternalUser {
-	return &domain.ExternalUser{
+	externalUser := &domain.ExternalUser{
 		IDPConfigID:       id,
 		ExternalUserID:    user.GetID(),
 		PreferredUsername: user.GetPreferredUsername(),
@@ -1287,6 +1353,16 @@ func mapIDPUserToExternalUser(user idp.User, id string) *domain.ExternalUser {
 		Phone:             user.GetPhone(),
 		IsPhoneVerified:   user.IsPhoneVerified(),
 	}
+	if mappedUser, ok := user.(idp.UserWithMetadata); ok {
+		metadata := mappedUser.GetMetadata()
+		for _, key := range slices.Sorted(maps.Keys(metadata)) {
+			externalUser.Metadatas = append(externalUser.Metadatas, &domain.Metadata{Key: key, Value: metadata[key]})
+		}
+	}
+	if userWithGroups, ok := user.(idp.UserWithGroups); ok {
+		externalUser.Groups = userWithGroups.GetGroups()
+	}
+	return externalUser
 }
 
 func mapExternalUserToLoginUser(externalUser *domain.ExternalUser, mustBeDomain bool) (*domain.Human, *domain.UserIDPLink, []*domain.Metadata) {
@@ -0,0 +1,105 @@
+package login
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/kerberos"
+)
+
+const (
+	tmplKerberosLogin = "kerberos_login"
+
+	negotiateScheme = "Negotiate"
+)
+
+// handleKerberos verifies the Kerberos ticket the browser sends using SPNEGO (HTTP Negotiate).
+// If the request does not contain a ticket, the browser is challenged to provide one.
+// Browsers which are not able to (e.g. not domain-joined clients) will display the response,
+// which automatically falls back to the normal login.
+func (l *Login) handleKerberos(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.ensureAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	token, ok := negotiateToken(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", negotiateScheme)
+		w.WriteHeader(http.StatusUnauthorized)
+		l.renderKerberosLogin(w, r, authReq, nil)
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.kerberosFallback(w, r, authReq, err)
+		return
+	}
+	provider, err := l.kerberosProvider(r.Context(), identityProvider)
+	if err != nil {
+		l.kerberosFallback(w, r, authReq, err)
+		return
+	}
+	session := provider.GetSession(token)
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		if _, _, actionErr := l.runPostExternalAuthenticationActions(new(domain.ExternalUser), nil, authReq, r, nil, err); actionErr != nil {
+			logging.WithError(err).Error("both external user authentication and action post authentication failed")
+		}
+		logging.WithFields(
+			"instance", authz.GetInstance(r.Context()).InstanceID(),
+			"providerID", identityProvider.ID,
+		).WithError(err).Info("kerberos authentication failed")
+		l.kerberosFallback(w, r, authReq, err)
+		return
+	}
+	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
+}
+
+func (l *Login) renderKerberosLogin(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	temp := l.renderer.Templates[tmplKerberosLogin]
+	translator := l.getTranslator(r.Context(), authReq)
+	data := l.getUserData(r, authReq, translator, "Kerberos.Title", "Kerberos.Description", err)
+	l.renderer.RenderTemplate(w, r, translator, temp, data, nil)
+}
+
+// handleKerberosFallback is called by the page rendered for browsers not providing a Kerberos ticket.
+func (l *Login) handleKerberosFallback(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.ensureAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.kerberosFallback(w, r, authReq, kerberos.ErrNoToken)
+}
+
+// kerberosFallback resets the selected Kerberos provider and renders the normal login.
+// The error is always passed to prevent an automatic redirect back to the provider,
+// in case it's the only one allowed.
+func (l *Login) kerberosFallback(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if resetErr := l.authRepo.ResetSelectedIDP(r.Context(), authReq.ID, userAgentID); resetErr != nil {
+		l.renderError(w, r, authReq, resetErr)
+		return
+	}
+	l.renderLogin(w, r, authReq, WrapIdPError(err))
+}
+
+// negotiateToken returns the decoded token of an `Authorization: Negotiate <token>` header
+func negotiateToken(r *http.Request) ([]byte, bool) {
+	scheme, encoded, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, negotiateScheme) {
+		return nil, false
+	}
+	token, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(token) == 0 {
+		return nil, false
+	}
+	return token, true
+}
@@ -0,0 +1,65 @@
+package login
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_negotiateToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantToken []byte
+		wantOK    bool
+	}{
+		{
+			"no header",
+			"",
+			nil,
+			false,
+		},
+		{
+			"other scheme",
+			"Basic dXNlcjpwYXNzd29yZA==",
+			nil,
+			false,
+		},
+		{
+			"missing token",
+			"Negotiate",
+			nil,
+			false,
+		},
+		{
+			"invalid encoding",
+			"Negotiate %%%",
+			nil,
+			false,
+		},
+		{
+			"token",
+			"Negotiate dG9rZW4=",
+			[]byte("token"),
+			true,
+		},
+		{
+			"case insensitive scheme",
+			"negotiate dG9rZW4=",
+			[]byte("token"),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/login/kerberos", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			token, ok := negotiateToken(r)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantToken, token)
+		})
+	}
+}
@@ -20,6 +20,13 @@ type loginData struct {
 	Register  bool   `schema:"register"`
 }
 
+type loginPageData struct {
+	userData
+	// CredentialAssertionData is the base64 encoded challenge to login with a passkey,
+	// which is only set if passwordless login is allowed
+	CredentialAssertionData string
+}
+
 func LoginLink(origin, orgID string) string {
 	return externalLink(origin) + EndpointLogin + "?orgID=" + orgID
 }
@@ -96,7 +103,10 @@ func (l *Login) renderLogin(w http.ResponseWriter, r *http.Request, authReq *dom
 		return
 	}
 	translator := l.getTranslator(r.Context(), authReq)
-	data := l.getUserData(r, authReq, translator, "Login.Title", "Login.Description", err)
+	data := &loginPageData{
+		userData:                l.getUserData(r, authReq, translator, "Login.Title", "Login.Description", err),
+		CredentialAssertionData: l.beginDiscoverablePasswordlessLogin(r, authReq),
+	}
 	funcs := map[string]interface{}{
 		"hasUsernamePasswordLogin": func() bool {
 			return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowUsernamePassword
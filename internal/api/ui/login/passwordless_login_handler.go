@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
@@ -81,3 +83,62 @@ func (l *Login) handlePasswordlessVerification(w http.ResponseWriter, r *http.Re
 	}
 	l.renderNextStep(w, r, authReq)
 }
+
+// beginDiscoverablePasswordlessLogin creates a challenge for a passwordless login without knowing the user,
+// so the login page can offer passkeys (e.g. by autofill of the login name input).
+// An empty string is returned if passwordless login is not possible.
+func (l *Login) beginDiscoverablePasswordlessLogin(r *http.Request, authReq *domain.AuthRequest) string {
+	if authReq == nil || authReq.LoginPolicy == nil || authReq.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return ""
+	}
+	// reuse a pending challenge, so rendering the page again (e.g. after an error)
+	// does not invalidate the challenge of a passkey prompt already shown
+	if authReq.PasswordlessChallenge != nil {
+		return base64.RawURLEncoding.EncodeToString(authReq.PasswordlessChallenge.CredentialAssertionData)
+	}
+	webAuthNLogin, err := l.authRepo.BeginDiscoverablePasswordlessLogin(r.Context(), authReq.ID, authReq.AgentID)
+	if err != nil {
+		logging.WithFields("authRequest", authReq.ID).WithError(err).Warn("unable to begin discoverable passwordless login")
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(webAuthNLogin.CredentialAssertionData)
+}
+
+// handlePasswordlessDiscoverableLogin verifies the passkey selected on the login page
+// and resolves the user from it.
+func (l *Login) handlePasswordlessDiscoverableLogin(w http.ResponseWriter, r *http.Request) {
+	formData := new(webAuthNFormData)
+	authReq, err := l.ensureAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	credData, err := base64.URLEncoding.DecodeString(formData.CredentialData)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	err = l.authRepo.VerifyDiscoverablePasswordless(r.Context(), authReq.ID, authReq.AgentID, credData, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		// the challenge was consumed by the verification, so a new one is needed
+		authReq.PasswordlessChallenge = nil
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	// the user is now known, so reload the auth request for the actions and next step
+	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+
+	metadata, err := l.runPostInternalAuthenticationActions(authReq, r, authMethodPasswordless, nil)
+	if err == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	}
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
@@ -82,6 +82,7 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
 		tmplLDAPLogin:                    "ldap_login.html",
+		tmplKerberosLogin:                "kerberos_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
 		tmplDeviceAuthAction:             "device_action.html",
 	}
@@ -155,6 +156,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"passwordlessPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPasswordlessPrompt)
 		},
+		"passwordlessDiscoverableUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordlessDiscoverable)
+		},
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
@@ -239,6 +243,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"ldapUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointLDAPCallback)
 		},
+		"kerberosFallbackUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointKerberosFallback)
+		},
 		"linkingUserPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointLinkingUserPrompt)
 		},
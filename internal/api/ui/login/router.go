@@ -19,9 +19,12 @@ const (
 	EndpointJWTCallback                   = "/login/jwt/callback"
 	EndpointLDAPLogin                     = "/login/ldap"
 	EndpointLDAPCallback                  = "/login/ldap/callback"
+	EndpointKerberosLogin                 = "/login/kerberos"
+	EndpointKerberosFallback              = "/login/kerberos/fallback"
 	EndpointPasswordlessLogin             = "/login/passwordless"
 	EndpointPasswordlessRegistration      = "/login/passwordless/init"
 	EndpointPasswordlessPrompt            = "/login/passwordless/prompt"
+	EndpointPasswordlessDiscoverable      = "/login/passwordless/discoverable"
 	EndpointLoginName                     = "/loginname"
 	EndpointUserSelection                 = "/userselection"
 	EndpointChangeUsername                = "/username/change"
@@ -85,6 +88,7 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointPasswordlessRegistration, login.handlePasswordlessRegistration).Methods(http.MethodGet)
 	router.HandleFunc(EndpointPasswordlessRegistration, login.handlePasswordlessRegistrationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointPasswordlessPrompt, login.handlePasswordlessPrompt).Methods(http.MethodPost)
+	router.HandleFunc(EndpointPasswordlessDiscoverable, login.handlePasswordlessDiscoverableLogin).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLoginName, login.handleLoginName).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLoginName, login.handleLoginNameCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointUserSelection, login.handleSelectUser).Methods(http.MethodPost)
@@ -124,6 +128,8 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
+	router.HandleFunc(EndpointKerberosLogin, login.handleKerberos).Methods(http.MethodGet)
+	router.HandleFunc(EndpointKerberosFallback, login.handleKerberosFallback).Methods(http.MethodPost)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
 	router.HandleFunc(EndpointDeviceAuth, login.handleDeviceAuthUserCode).Methods(http.MethodGet, http.MethodPost)
 	router.HandleFunc(EndpointDeviceAuthAction, login.handleDeviceAuthAction).Methods(http.MethodGet, http.MethodPost)
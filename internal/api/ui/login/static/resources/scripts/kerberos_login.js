@@ -0,0 +1,8 @@
+// the page is only shown if the browser could not provide a Kerberos ticket,
+// so directly continue with the normal login
+document.addEventListener('DOMContentLoaded', function () {
+    let form = document.getElementsByTagName('form')[0];
+    if (form) {
+        form.submit();
+    }
+});
@@ -0,0 +1,86 @@
+let passkeyAbortController;
+
+document.addEventListener("DOMContentLoaded", function () {
+  checkWebauthnSupported("btn-passkey-login", passkeyLogin);
+  startConditionalPasskeyLogin();
+});
+
+// startConditionalPasskeyLogin offers the passkeys of the user
+// in the autofill of the login name input, if supported by the browser
+function startConditionalPasskeyLogin() {
+  if (
+    !window.PublicKeyCredential ||
+    !PublicKeyCredential.isConditionalMediationAvailable
+  ) {
+    return;
+  }
+  PublicKeyCredential.isConditionalMediationAvailable().then(function (
+    available
+  ) {
+    if (available) {
+      getPasskey("conditional");
+    }
+  });
+}
+
+function passkeyLogin() {
+  document.getElementById("wa-error").classList.add("hidden");
+  getPasskey("optional");
+}
+
+function getPasskey(mediation) {
+  // only a single request can be pending, so abort a possible autofill request
+  if (passkeyAbortController) {
+    passkeyAbortController.abort();
+  }
+  passkeyAbortController = new AbortController();
+
+  let form = document.getElementById("passkey-form");
+  let makeAssertionOptions = JSON.parse(
+    atob(form.elements["credentialAssertionData"].value)
+  );
+  makeAssertionOptions.publicKey.challenge = bufferDecode(
+    makeAssertionOptions.publicKey.challenge,
+    "publicKey.challenge"
+  );
+  navigator.credentials
+    .get({
+      mediation: mediation,
+      publicKey: makeAssertionOptions.publicKey,
+      signal: passkeyAbortController.signal,
+    })
+    .then(function (credential) {
+      verifyPasskeyAssertion(form, credential);
+    })
+    .catch(function (err) {
+      if (err.name === "AbortError") {
+        return;
+      }
+      webauthnError(err);
+    });
+}
+
+function verifyPasskeyAssertion(form, assertedCredential) {
+  let authData = new Uint8Array(assertedCredential.response.authenticatorData);
+  let clientDataJSON = new Uint8Array(
+    assertedCredential.response.clientDataJSON
+  );
+  let rawId = new Uint8Array(assertedCredential.rawId);
+  let sig = new Uint8Array(assertedCredential.response.signature);
+  let userHandle = new Uint8Array(assertedCredential.response.userHandle);
+
+  let data = JSON.stringify({
+    id: assertedCredential.id,
+    rawId: bufferEncode(rawId),
+    type: assertedCredential.type,
+    response: {
+      authenticatorData: bufferEncode(authData),
+      clientDataJSON: bufferEncode(clientDataJSON),
+      signature: bufferEncode(sig),
+      userHandle: bufferEncode(userHandle),
+    },
+  });
+
+  form.elements["credentialData"].value = btoa(data);
+  form.submit();
+}
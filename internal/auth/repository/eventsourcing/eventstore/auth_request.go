@@ -25,6 +25,7 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
 	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -524,6 +525,77 @@ func (repo *AuthRequestRepo) VerifyPasswordless(ctx context.Context, userID, res
 	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request)
 }
 
+// BeginDiscoverablePasswordlessLogin creates a passwordless challenge without requiring a user
+// and stores it on the auth request.
+// The user will be resolved from the passkey used to sign it (see VerifyDiscoverablePasswordless).
+func (repo *AuthRequestRepo) BeginDiscoverablePasswordlessLogin(ctx context.Context, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	if request.LoginPolicy == nil || request.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "EVENT-Gm2qa", "Errors.User.WebAuthN.PasswordlessNotAllowed")
+	}
+	login, err = repo.Command.HumanBeginDiscoverablePasswordlessLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	request.PasswordlessChallenge = login
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+// VerifyDiscoverablePasswordless verifies the passkey assertion for the challenge created by BeginDiscoverablePasswordlessLogin.
+// The user is resolved from the user handle of the assertion and set on the auth request if the verification succeeded.
+func (repo *AuthRequestRepo) VerifyDiscoverablePasswordless(ctx context.Context, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return err
+	}
+	challenge := request.PasswordlessChallenge
+	if challenge == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Xo4vd", "Errors.User.WebAuthN.NoChallenge")
+	}
+	// a challenge must only be used once
+	request.PasswordlessChallenge = nil
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return err
+	}
+	userID, err := webauthn.UserIDFromAssertion(credentialData)
+	if err != nil {
+		return err
+	}
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
+	if err != nil {
+		return err
+	}
+	if request.RequestedOrgID != "" && request.RequestedOrgID != user.ResourceOwner {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Pk9sw", "Errors.User.NotAllowedOrg")
+	}
+	if err = repo.checkLoginPolicyWithResourceOwner(ctx, request, user.ResourceOwner); err != nil {
+		return err
+	}
+	if request.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Qb7ne", "Errors.User.WebAuthN.PasswordlessNotAllowed")
+	}
+	err = repo.Command.HumanFinishDiscoverablePasswordlessLogin(ctx, user.ID, user.ResourceOwner, credentialData, challenge, request.WithCurrentInfo(info))
+	if err != nil {
+		return err
+	}
+	username := user.UserName
+	if request.RequestedOrgID == "" {
+		username = user.PreferredLoginName
+	}
+	request.SetUserInfo(user.ID, username, user.PreferredLoginName, user.DisplayName, user.AvatarKey, user.ResourceOwner)
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
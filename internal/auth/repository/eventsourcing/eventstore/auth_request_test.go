@@ -3,11 +3,13 @@ package eventstore
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -233,6 +235,23 @@ func (m *mockViewUser) UserByID(context.Context, string, string) (*user_view_mod
 	}, nil
 }
 
+type mockViewUserState struct {
+	State         user_model.UserState
+	ResourceOwner string
+}
+
+func (m *mockViewUserState) UserByID(_ context.Context, userID, _ string) (*user_view_model.UserView, error) {
+	return &user_view_model.UserView{
+		ID:            userID,
+		State:         int32(m.State),
+		ResourceOwner: m.ResourceOwner,
+		UserName:      "UserName",
+		HumanView: &user_view_model.HumanView{
+			FirstName: "FirstName",
+		},
+	}, nil
+}
+
 type mockViewOrg struct {
 	State domain.OrgState
 }
@@ -3252,3 +3271,170 @@ func TestAuthRequestRepo_VerifyPassword_IgnoreUnknownUsernames(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRequestRepo_VerifyDiscoverablePasswordless(t *testing.T) {
+	authRequest := func(requestedOrgID string, challenge *domain.WebAuthNLogin) *domain.AuthRequest {
+		a := &domain.AuthRequest{
+			ID:             "authRequestID",
+			AgentID:        "userAgentID",
+			RequestedOrgID: requestedOrgID,
+			LoginPolicy: &domain.LoginPolicy{
+				AllowUsernamePassword: true,
+				PasswordlessType:      domain.PasswordlessTypeAllowed,
+			},
+			AllowedExternalIDPs: []*domain.IDPProvider{
+				{
+					Type:        domain.IdentityProviderTypeSystem,
+					IDPConfigID: "idpConfig1",
+					Name:        "IdP",
+					IDPType:     domain.IDPTypeOIDC,
+					IDPState:    domain.IDPConfigStateActive,
+				},
+			},
+			LabelPolicy: &domain.LabelPolicy{
+				State:   domain.LabelPolicyStateActive,
+				Default: true,
+			},
+			PrivacyPolicy: &domain.PrivacyPolicy{
+				State:   domain.PolicyStateActive,
+				Default: true,
+			},
+			LockoutPolicy: &domain.LockoutPolicy{
+				Default: true,
+			},
+			PasswordAgePolicy:     &domain.PasswordAgePolicy{},
+			DefaultTranslations:   []*domain.CustomText{{}},
+			OrgTranslations:       []*domain.CustomText{{}},
+			PasswordlessChallenge: challenge,
+		}
+		policyOrgID := requestedOrgID
+		if policyOrgID == "" {
+			policyOrgID = "instance1"
+		}
+		a.SetPolicyOrgID(policyOrgID)
+		return a
+	}
+	challenge := &domain.WebAuthNLogin{
+		Challenge:        "challenge",
+		UserVerification: domain.UserVerificationRequirementRequired,
+	}
+	// expectChallengeConsumed expects the challenge to be removed from the auth request before the user is checked
+	expectChallengeConsumed := func(t *testing.T, requestedOrgID string) cache.AuthRequestCache {
+		m := mock.NewMockAuthRequestCache(gomock.NewController(t))
+		m.EXPECT().GetAuthRequestByID(gomock.Any(), "authRequestID").Return(authRequest(requestedOrgID, challenge), nil)
+		m.EXPECT().CacheAuthRequest(gomock.Any(), gomock.Any())
+		m.EXPECT().UpdateAuthRequest(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *domain.AuthRequest) error {
+			assert.Nil(t, request.PasswordlessChallenge)
+			assert.Empty(t, request.UserID)
+			return nil
+		})
+		return m
+	}
+
+	type fields struct {
+		AuthRequests     func(*testing.T) cache.AuthRequestCache
+		UserViewProvider userViewProvider
+		OrgViewProvider  orgViewProvider
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "challenge already used",
+			fields: fields{
+				AuthRequests: func(t *testing.T) cache.AuthRequestCache {
+					m := mock.NewMockAuthRequestCache(gomock.NewController(t))
+					m.EXPECT().GetAuthRequestByID(gomock.Any(), "authRequestID").Return(authRequest("", nil), nil)
+					m.EXPECT().CacheAuthRequest(gomock.Any(), gomock.Any())
+					return m
+				},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "EVENT-Xo4vd", "Errors.User.WebAuthN.NoChallenge"),
+		},
+		{
+			name: "user of another organization",
+			fields: fields{
+				AuthRequests: func(t *testing.T) cache.AuthRequestCache {
+					return expectChallengeConsumed(t, "org1")
+				},
+				UserViewProvider: &mockViewUserState{State: user_model.UserStateActive, ResourceOwner: "org2"},
+				OrgViewProvider:  &mockViewOrg{State: domain.OrgStateActive},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "EVENT-Pk9sw", "Errors.User.NotAllowedOrg"),
+		},
+		{
+			name: "user locked",
+			fields: fields{
+				AuthRequests: func(t *testing.T) cache.AuthRequestCache {
+					return expectChallengeConsumed(t, "")
+				},
+				UserViewProvider: &mockViewUserState{State: user_model.UserStateLocked, ResourceOwner: "org1"},
+				OrgViewProvider:  &mockViewOrg{State: domain.OrgStateActive},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked"),
+		},
+		{
+			name: "user inactive",
+			fields: fields{
+				AuthRequests: func(t *testing.T) cache.AuthRequestCache {
+					return expectChallengeConsumed(t, "")
+				},
+				UserViewProvider: &mockViewUserState{State: user_model.UserStateInactive, ResourceOwner: "org1"},
+				OrgViewProvider:  &mockViewOrg{State: domain.OrgStateActive},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.NotActive"),
+		},
+		{
+			name: "organization inactive",
+			fields: fields{
+				AuthRequests: func(t *testing.T) cache.AuthRequestCache {
+					return expectChallengeConsumed(t, "")
+				},
+				UserViewProvider: &mockViewUserState{State: user_model.UserStateActive, ResourceOwner: "org1"},
+				OrgViewProvider:  &mockViewOrg{State: domain.OrgStateInactive},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "EVENT-Zws3s", "Errors.User.NotActive"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &AuthRequestRepo{
+				AuthRequests:      tt.fields.AuthRequests(t),
+				UserViewProvider:  tt.fields.UserViewProvider,
+				UserEventProvider: &mockEventUser{},
+				OrgViewProvider:   tt.fields.OrgViewProvider,
+			}
+			err := repo.VerifyDiscoverablePasswordless(authz.NewMockContext("instance1", "", ""), "authRequestID", "userAgentID", testDiscoverableAssertion(t, "user1"), &domain.BrowserInfo{UserAgent: "useragent"})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// testDiscoverableAssertion returns an unsigned assertion with the user handle of the user,
+// which is enough for the checks before the signature is verified.
+func testDiscoverableAssertion(t *testing.T, userHandle string) []byte {
+	clientData, err := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": "challenge",
+		"origin":    "https://example.com",
+	})
+	require.NoError(t, err)
+	// rp id hash (32 bytes), flags (user present and verified) and sign count (4 bytes)
+	authData := append(make([]byte, 32), 0x05, 0, 0, 0, 1)
+	rawID := base64.RawURLEncoding.EncodeToString([]byte("credentialID"))
+	data, err := json.Marshal(map[string]any{
+		"id":    rawID,
+		"rawId": rawID,
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString([]byte("signature")),
+			"userHandle":        base64.RawURLEncoding.EncodeToString([]byte(userHandle)),
+		},
+	})
+	require.NoError(t, err)
+	return data
+}
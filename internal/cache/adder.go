@@ -0,0 +1,19 @@
+package cache
+
+import "context"
+
+// Adder is an optional [Cache] interface,
+// implemented by the connectors which share the objects between all processes.
+type Adder[V any] interface {
+	// Add stores the object, if none of its keys maps to an existing object.
+	// It returns false if a key exists, the object is not stored in that case.
+	// Keys of expired objects, which are not yet pruned, may be considered existing.
+	// The check and the storage are atomic for all processes using the cache.
+	Add(ctx context.Context, value V) (bool, error)
+}
+
+// AdderCache is a [Cache] to store values which must only be used once, like the ids of proofs.
+type AdderCache[I, K comparable, V Entry[I, K]] interface {
+	Cache[I, K, V]
+	Adder[V]
+}
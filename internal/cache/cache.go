@@ -18,6 +18,14 @@ const (
 	PurposeMilestones
 	PurposeOrganization
 	PurposeIdPFormCallback
+	PurposeOIDCClient
+	PurposeProject
+	PurposeApp
+	PurposeOIDCUserInfo
+	PurposeProjectRoles
+	PurposeUserGrants
+	PurposeDPoPProof
+	PurposeTokenPoll
 )
 
 // Cache stores objects with a value of type `V`.
@@ -96,6 +104,12 @@ const (
 type Config struct {
 	Connector Connector
 
+	// LocalTier serves objects from the memory connector first
+	// and falls back to the configured Connector on a miss.
+	// The memory connector must be enabled with invalidation broadcast.
+	// Nil disables the local tier.
+	LocalTier *LocalTierConfig
+
 	// Age since an object was added to the cache,
 	// after which the object is considered invalid.
 	// 0 disables max age checks.
@@ -110,3 +124,18 @@ type Config struct {
 	// By default only errors are logged to stdout.
 	Log *logging.Config
 }
+
+// LocalTierConfig configures the memory cache in front of a remote connector.
+// The ages are typically shorter than the ages of the remote cache,
+// as each process holds its own copy of the objects.
+type LocalTierConfig struct {
+	// Age since an object was added to the local tier,
+	// after which the object is obtained again from the remote cache.
+	// 0 disables max age checks.
+	MaxAge time.Duration
+
+	// Age since last use (Get) of an object in the local tier,
+	// after which the object is obtained again from the remote cache.
+	// 0 disables last use age checks.
+	LastUseAge time.Duration
+}
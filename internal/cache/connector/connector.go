@@ -3,13 +3,17 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
 	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/cache/connector/pg"
 	"github.com/zitadel/zitadel/internal/cache/connector/redis"
+	"github.com/zitadel/zitadel/internal/cache/connector/tiered"
 	"github.com/zitadel/zitadel/internal/database"
 )
 
@@ -23,6 +27,14 @@ type CachesConfig struct {
 	Milestones       *cache.Config
 	Organization     *cache.Config
 	IdPFormCallbacks *cache.Config
+	OIDCClient       *cache.Config
+	Project          *cache.Config
+	App              *cache.Config
+	OIDCUserInfo     *cache.Config
+	ProjectRoles     *cache.Config
+	UserGrants       *cache.Config
+	DPoPProofs       *cache.Config
+	TokenPolls       *cache.Config
 }
 
 type Connectors struct {
@@ -36,20 +48,120 @@ func StartConnectors(conf *CachesConfig, client *database.DB) (Connectors, error
 	if conf == nil {
 		return Connectors{}, nil
 	}
-	return Connectors{
+	connectors := Connectors{
 		Config:   *conf,
-		Memory:   gomap.NewConnector(conf.Connectors.Memory),
 		Postgres: pg.NewConnector(conf.Connectors.Postgres, client),
 		Redis:    redis.NewConnector(conf.Connectors.Redis),
-	}, nil
+	}
+	if connectors.Redis != nil {
+		warnRedisDBRange(conf.Connectors.Redis.DBOffset)
+	}
+	broadcaster, err := memoryBroadcaster(conf.Connectors.Memory, client, connectors.Redis)
+	if err != nil {
+		return Connectors{}, err
+	}
+	connectors.Memory = gomap.NewConnector(conf.Connectors.Memory, broadcaster)
+	return connectors, nil
+}
+
+// redisDefaultDatabases is the amount of databases provided by the default configuration of a Redis server.
+const redisDefaultDatabases = 16
+
+// warnRedisDBRange warns if the databases used by the caches exceed the default amount of databases of a Redis server.
+// Each purpose uses the database [redis.Config.DBOffset] + purpose.
+func warnRedisDBRange(offset int) {
+	purposes := cache.PurposeValues()
+	lastDB := offset + int(purposes[len(purposes)-1])
+	if lastDB < redisDefaultDatabases {
+		return
+	}
+	logging.WithFields("db_offset", offset, "last_db", lastDB).Warnf("redis caches use databases beyond the default %d databases of a redis server, lower the DBOffset or increase the databases of the server", redisDefaultDatabases)
+}
+
+// memoryBroadcaster returns the broadcaster for invalidations of the memory caches.
+// It returns nil if the memory connector is disabled or has no invalidation configured.
+func memoryBroadcaster(conf gomap.Config, client *database.DB, redisConnector *redis.Connector) (gomap.Broadcaster, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+	switch conf.Invalidation.Connector {
+	case cache.ConnectorUnspecified:
+		return nil, nil
+	case cache.ConnectorPostgres:
+		if client == nil || client.Type() != "postgres" {
+			return nil, errors.New("memory cache invalidation: postgres connector requires a postgres database")
+		}
+		return pg.NewBroadcaster(client.Pool), nil
+	case cache.ConnectorRedis:
+		if redisConnector == nil {
+			return nil, errors.New("memory cache invalidation: redis connector not enabled")
+		}
+		return redis.NewBroadcaster(redisConnector), nil
+	case cache.ConnectorMemory:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("memory cache invalidation: connector %q not supported", conf.Invalidation.Connector)
+	}
 }
 
 func StartCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Context, indices []I, purpose cache.Purpose, conf *cache.Config, connectors Connectors) (cache.Cache[I, K, V], error) {
 	if conf == nil || conf.Connector == cache.ConnectorUnspecified {
 		return noop.NewCache[I, K, V](), nil
 	}
+	withLocalTier := conf.LocalTier != nil && conf.Connector != cache.ConnectorMemory
+	if withLocalTier && connectors.Memory == nil {
+		return nil, fmt.Errorf("local tier of cache %q requires the memory connector", purpose)
+	}
+	// without broadcast the local tiers of the other processes would serve invalidated objects until they expire
+	if withLocalTier && !connectors.Memory.Broadcasts() {
+		return nil, fmt.Errorf("local tier of cache %q requires the invalidation broadcast of the memory connector", purpose)
+	}
+	c, err := startCache[I, K, V](background, indices, purpose, conf, connectors)
+	if err != nil {
+		return nil, err
+	}
+	if !withLocalTier {
+		return withMetrics(c, purpose, conf.Connector), nil
+	}
+	localConf := cache.Config{
+		Connector:  cache.ConnectorMemory,
+		MaxAge:     conf.LocalTier.MaxAge,
+		LastUseAge: conf.LocalTier.LastUseAge,
+		Log:        conf.Log,
+	}
+	local := gomap.NewBroadcastCache[I, K, V](background, indices, purpose, localConf, connectors.Memory)
+	connectors.Memory.Config.StartAutoPrune(background, local, purpose)
+	return withMetrics(tiered.NewCache(local, c), purpose, conf.Connector), nil
+}
+
+// StartAdderCache starts a cache which adds objects atomically for all processes.
+// Only the postgres and redis connectors share the objects between the processes,
+// the memory connector and the local tier are therefore not supported.
+// If no connector is configured, every object is added.
+func StartAdderCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Context, indices []I, purpose cache.Purpose, conf *cache.Config, connectors Connectors) (cache.AdderCache[I, K, V], error) {
+	if conf == nil || conf.Connector == cache.ConnectorUnspecified {
+		return noop.NewAdderCache[I, K, V](), nil
+	}
+	if conf.Connector != cache.ConnectorPostgres && conf.Connector != cache.ConnectorRedis {
+		return nil, fmt.Errorf("cache %q requires the postgres or redis connector", purpose)
+	}
+	if conf.LocalTier != nil {
+		return nil, fmt.Errorf("cache %q does not support a local tier", purpose)
+	}
+	c, err := startCache[I, K, V](background, indices, purpose, conf, connectors)
+	if err != nil {
+		return nil, err
+	}
+	adder, ok := c.(cache.AdderCache[I, K, V])
+	if !ok {
+		return nil, fmt.Errorf("cache connector %q does not support adding objects", conf.Connector)
+	}
+	return adder, nil
+}
+
+func startCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Context, indices []I, purpose cache.Purpose, conf *cache.Config, connectors Connectors) (cache.Cache[I, K, V], error) {
 	if conf.Connector == cache.ConnectorMemory && connectors.Memory != nil {
-		c := gomap.NewCache[I, K, V](background, indices, *conf)
+		c := gomap.NewBroadcastCache[I, K, V](background, indices, purpose, *conf, connectors.Memory)
 		connectors.Memory.Config.StartAutoPrune(background, c, purpose)
 		return c, nil
 	}
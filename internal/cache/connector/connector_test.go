@@ -0,0 +1,96 @@
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+)
+
+type testIndex int
+
+const testIndexID testIndex = 1
+
+type testObject struct {
+	ID string
+}
+
+func (o *testObject) Keys(testIndex) []string {
+	return []string{o.ID}
+}
+
+func TestStartCache_localTier(t *testing.T) {
+	conf := &cache.Config{
+		Connector: cache.ConnectorRedis,
+		MaxAge:    time.Hour,
+		LocalTier: &cache.LocalTierConfig{MaxAge: time.Minute},
+	}
+	tests := []struct {
+		name    string
+		memory  *gomap.Connector
+		wantErr string
+	}{
+		{
+			name:    "without memory connector",
+			wantErr: `local tier of cache "organization" requires the memory connector`,
+		},
+		{
+			name:    "without broadcast",
+			memory:  gomap.NewConnector(gomap.Config{Enabled: true}, nil),
+			wantErr: `local tier of cache "organization" requires the invalidation broadcast of the memory connector`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := StartCache[testIndex, string, *testObject](context.Background(), []testIndex{testIndexID}, cache.PurposeOrganization, conf, Connectors{Memory: tt.memory})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStartAdderCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *cache.Config
+		wantErr string
+	}{
+		{
+			name: "no connector, every object added",
+		},
+		{
+			name:    "memory connector",
+			conf:    &cache.Config{Connector: cache.ConnectorMemory},
+			wantErr: `cache "d_po_p_proof" requires the postgres or redis connector`,
+		},
+		{
+			name: "local tier",
+			conf: &cache.Config{
+				Connector: cache.ConnectorRedis,
+				LocalTier: &cache.LocalTierConfig{MaxAge: time.Minute},
+			},
+			wantErr: `cache "d_po_p_proof" does not support a local tier`,
+		},
+		{
+			name:    "connector not enabled",
+			conf:    &cache.Config{Connector: cache.ConnectorPostgres},
+			wantErr: `cache connector "postgres" not enabled`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := StartAdderCache[testIndex, string, *testObject](context.Background(), []testIndex{testIndexID}, cache.PurposeDPoPProof, tt.conf, Connectors{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			added, err := c.Add(context.Background(), &testObject{ID: "id"})
+			assert.NoError(t, err)
+			assert.True(t, added)
+		})
+	}
+}
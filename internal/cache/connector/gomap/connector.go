@@ -7,17 +7,31 @@ import (
 type Config struct {
 	Enabled   bool
 	AutoPrune cache.AutoPruneConfig
+	// Invalidation broadcasts invalidations to the memory caches of all ZITADEL processes.
+	Invalidation InvalidationConfig
 }
 
 type Connector struct {
-	Config cache.AutoPruneConfig
+	Config       cache.AutoPruneConfig
+	invalidation *invalidation
 }
 
-func NewConnector(config Config) *Connector {
+// NewConnector returns the connector for memory caches.
+// If a broadcaster is passed, invalidations are propagated to the memory caches of all processes.
+func NewConnector(config Config, broadcaster Broadcaster) *Connector {
 	if !config.Enabled {
 		return nil
 	}
-	return &Connector{
+	c := &Connector{
 		Config: config.AutoPrune,
 	}
+	if broadcaster != nil {
+		c.invalidation = newInvalidation(broadcaster, config.Invalidation.Channel)
+	}
+	return c
+}
+
+// Broadcasts returns true if invalidations are propagated to the memory caches of all processes.
+func (c *Connector) Broadcasts() bool {
+	return c != nil && c.invalidation != nil
 }
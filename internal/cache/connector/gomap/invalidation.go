@@ -0,0 +1,224 @@
+package gomap
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type InvalidationConfig struct {
+	// Connector used to broadcast invalidations to the memory caches of all ZITADEL processes.
+	// Can be "postgres" (LISTEN / NOTIFY on the configured database) or "redis" (pub/sub on the redis connector).
+	// Empty disables the broadcast, so invalidations are only applied to the local cache.
+	Connector cache.Connector
+	// Channel on which the invalidations are broadcasted.
+	Channel string
+}
+
+// Broadcaster distributes invalidation messages to all subscribed processes.
+type Broadcaster interface {
+	// Publish sends the payload to all subscribers of the channel.
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe calls handle for every payload published on the channel
+	// until the context is done.
+	// Subscribe blocks and is expected to reconnect on connection errors.
+	// subscribed is called every time the subscription is established,
+	// as messages published while the subscription was interrupted are lost.
+	Subscribe(ctx context.Context, channel string, subscribed func(), handle func(payload []byte))
+}
+
+type invalidationOperation string
+
+const (
+	invalidationOperationInvalidate invalidationOperation = "invalidate"
+	invalidationOperationDelete     invalidationOperation = "delete"
+	invalidationOperationTruncate   invalidationOperation = "truncate"
+)
+
+type invalidationMessage struct {
+	Node      string                `json:"node"`
+	Purpose   cache.Purpose         `json:"purpose"`
+	Operation invalidationOperation `json:"op"`
+	Index     json.RawMessage       `json:"index,omitempty"`
+	Keys      json.RawMessage       `json:"keys,omitempty"`
+}
+
+// invalidation fans out the messages received from the [Broadcaster]
+// to the memory caches of each purpose.
+type invalidation struct {
+	broadcaster Broadcaster
+	channel     string
+	// node identifies the current process, so it can ignore its own messages.
+	node string
+
+	start    sync.Once
+	mutex    sync.RWMutex
+	handlers map[cache.Purpose]func(ctx context.Context, msg *invalidationMessage)
+}
+
+func newInvalidation(broadcaster Broadcaster, channel string) *invalidation {
+	node := make([]byte, 16)
+	_, err := rand.Read(node)
+	logging.OnError(err).Panic("unable to generate cache node id")
+	return &invalidation{
+		broadcaster: broadcaster,
+		channel:     channel,
+		node:        hex.EncodeToString(node),
+		handlers:    make(map[cache.Purpose]func(ctx context.Context, msg *invalidationMessage)),
+	}
+}
+
+func (i *invalidation) register(background context.Context, purpose cache.Purpose, handler func(ctx context.Context, msg *invalidationMessage)) {
+	i.mutex.Lock()
+	i.handlers[purpose] = handler
+	i.mutex.Unlock()
+
+	i.start.Do(func() {
+		go i.broadcaster.Subscribe(background, i.channel,
+			func() {
+				i.truncate(background)
+			},
+			func(payload []byte) {
+				i.handle(background, payload)
+			},
+		)
+	})
+}
+
+// truncate all local caches, because invalidations might have been missed
+// before the subscription was (re)established.
+func (i *invalidation) truncate(ctx context.Context) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	for purpose, handler := range i.handlers {
+		handler(ctx, &invalidationMessage{
+			Purpose:   purpose,
+			Operation: invalidationOperationTruncate,
+		})
+	}
+}
+
+func (i *invalidation) handle(ctx context.Context, payload []byte) {
+	msg := new(invalidationMessage)
+	if err := json.Unmarshal(payload, msg); err != nil {
+		logging.WithError(err).WithField("channel", i.channel).Warn("invalid cache invalidation message")
+		return
+	}
+	if msg.Node == i.node {
+		return
+	}
+	i.mutex.RLock()
+	handler, ok := i.handlers[msg.Purpose]
+	i.mutex.RUnlock()
+	if !ok {
+		return
+	}
+	handler(ctx, msg)
+}
+
+func (i *invalidation) publish(ctx context.Context, purpose cache.Purpose, operation invalidationOperation, index, keys any) error {
+	msg := &invalidationMessage{
+		Node:      i.node,
+		Purpose:   purpose,
+		Operation: operation,
+	}
+	var err error
+	if index != nil {
+		if msg.Index, err = json.Marshal(index); err != nil {
+			return err
+		}
+	}
+	if keys != nil {
+		if msg.Keys, err = json.Marshal(keys); err != nil {
+			return err
+		}
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return i.broadcaster.Publish(ctx, i.channel, payload)
+}
+
+// broadcastCache applies invalidations, deletes and truncates to the local cache
+// and broadcasts them to the memory caches of the same purpose in all other processes.
+type broadcastCache[I, K comparable, V cache.Entry[I, K]] struct {
+	cache.PrunerCache[I, K, V]
+	purpose      cache.Purpose
+	invalidation *invalidation
+}
+
+// NewBroadcastCache returns an in-memory Cache like [NewCache].
+// If the connector is configured with invalidation,
+// calls to Invalidate, Delete and Truncate are propagated to the memory caches of all processes.
+func NewBroadcastCache[I, K comparable, V cache.Entry[I, K]](background context.Context, indices []I, purpose cache.Purpose, config cache.Config, connector *Connector) cache.PrunerCache[I, K, V] {
+	local := NewCache[I, K, V](background, indices, config)
+	if connector == nil || connector.invalidation == nil {
+		return local
+	}
+	c := &broadcastCache[I, K, V]{
+		PrunerCache:  local,
+		purpose:      purpose,
+		invalidation: connector.invalidation,
+	}
+	connector.invalidation.register(background, purpose, c.apply)
+	return c
+}
+
+func (c *broadcastCache[I, K, V]) Invalidate(ctx context.Context, index I, keys ...K) error {
+	if err := c.PrunerCache.Invalidate(ctx, index, keys...); err != nil {
+		return err
+	}
+	return c.invalidation.publish(ctx, c.purpose, invalidationOperationInvalidate, index, keys)
+}
+
+func (c *broadcastCache[I, K, V]) Delete(ctx context.Context, index I, keys ...K) error {
+	if err := c.PrunerCache.Delete(ctx, index, keys...); err != nil {
+		return err
+	}
+	return c.invalidation.publish(ctx, c.purpose, invalidationOperationDelete, index, keys)
+}
+
+func (c *broadcastCache[I, K, V]) Truncate(ctx context.Context) error {
+	if err := c.PrunerCache.Truncate(ctx); err != nil {
+		return err
+	}
+	return c.invalidation.publish(ctx, c.purpose, invalidationOperationTruncate, nil, nil)
+}
+
+// apply a message received from another process to the local cache.
+func (c *broadcastCache[I, K, V]) apply(ctx context.Context, msg *invalidationMessage) {
+	var (
+		index I
+		keys  []K
+		err   error
+	)
+	if len(msg.Index) > 0 {
+		err = json.Unmarshal(msg.Index, &index)
+	}
+	if err == nil && len(msg.Keys) > 0 {
+		err = json.Unmarshal(msg.Keys, &keys)
+	}
+	if err != nil {
+		logging.WithError(err).WithField("purpose", c.purpose).Warn("unable to decode cache invalidation message")
+		return
+	}
+	switch msg.Operation {
+	case invalidationOperationInvalidate:
+		err = c.PrunerCache.Invalidate(ctx, index, keys...)
+	case invalidationOperationDelete:
+		err = c.PrunerCache.Delete(ctx, index, keys...)
+	case invalidationOperationTruncate:
+		err = c.PrunerCache.Truncate(ctx)
+	default:
+		logging.WithFields("purpose", c.purpose, "operation", msg.Operation).Warn("unknown cache invalidation operation")
+		return
+	}
+	logging.OnError(err).WithField("purpose", c.purpose).Warn("unable to apply cache invalidation")
+}
@@ -0,0 +1,124 @@
+package gomap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+// testBroadcaster delivers published messages to all subscribers, including the publisher.
+type testBroadcaster struct {
+	mutex       sync.Mutex
+	subscribers []func(payload []byte)
+	resubscribe []func()
+}
+
+func (b *testBroadcaster) Publish(_ context.Context, _ string, payload []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, handle := range b.subscribers {
+		handle(payload)
+	}
+	return nil
+}
+
+func (b *testBroadcaster) Subscribe(ctx context.Context, _ string, subscribed func(), handle func(payload []byte)) {
+	subscribed()
+	b.mutex.Lock()
+	b.subscribers = append(b.subscribers, handle)
+	b.resubscribe = append(b.resubscribe, subscribed)
+	b.mutex.Unlock()
+	<-ctx.Done()
+}
+
+// reconnect simulates a reconnect of all subscriptions
+func (b *testBroadcaster) reconnect() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, subscribed := range b.resubscribe {
+		subscribed()
+	}
+}
+
+func (b *testBroadcaster) subscribed(n int) func() bool {
+	return func() bool {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		return len(b.subscribers) == n
+	}
+}
+
+func Test_broadcastCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broadcaster := new(testBroadcaster)
+	config := Config{Enabled: true, Invalidation: InvalidationConfig{Channel: "test"}}
+	// each connector represents a separate ZITADEL process
+	node1 := NewBroadcastCache[testIndex, string, *testObject](ctx, testIndices, cache.PurposeOrganization, cache.Config{}, NewConnector(config, broadcaster))
+	node2 := NewBroadcastCache[testIndex, string, *testObject](ctx, testIndices, cache.PurposeOrganization, cache.Config{}, NewConnector(config, broadcaster))
+	otherPurpose := NewBroadcastCache[testIndex, string, *testObject](ctx, testIndices, cache.PurposeAuthzInstance, cache.Config{}, NewConnector(config, broadcaster))
+	require.Eventually(t, broadcaster.subscribed(3), time.Second, time.Millisecond)
+
+	set := func(objects ...*testObject) {
+		for _, c := range []cache.Cache[testIndex, string, *testObject]{node1, node2, otherPurpose} {
+			for _, obj := range objects {
+				c.Set(ctx, obj)
+			}
+		}
+	}
+	obj1 := &testObject{id: "id1", names: []string{"foo"}}
+	obj2 := &testObject{id: "id2", names: []string{"bar"}}
+
+	t.Run("invalidate", func(t *testing.T) {
+		set(obj1, obj2)
+		require.NoError(t, node1.Invalidate(ctx, testIndexName, "foo"))
+
+		_, ok := node2.Get(ctx, testIndexID, "id1")
+		assert.False(t, ok)
+		_, ok = node2.Get(ctx, testIndexID, "id2")
+		assert.True(t, ok)
+		_, ok = otherPurpose.Get(ctx, testIndexID, "id1")
+		assert.True(t, ok)
+	})
+	t.Run("delete", func(t *testing.T) {
+		set(obj1, obj2)
+		require.NoError(t, node2.Delete(ctx, testIndexID, "id2"))
+
+		_, ok := node1.Get(ctx, testIndexID, "id2")
+		assert.False(t, ok)
+		_, ok = node1.Get(ctx, testIndexName, "bar")
+		assert.True(t, ok)
+	})
+	t.Run("truncate", func(t *testing.T) {
+		set(obj1, obj2)
+		require.NoError(t, node1.Truncate(ctx))
+
+		_, ok := node2.Get(ctx, testIndexID, "id1")
+		assert.False(t, ok)
+		_, ok = node2.Get(ctx, testIndexID, "id2")
+		assert.False(t, ok)
+		_, ok = otherPurpose.Get(ctx, testIndexID, "id1")
+		assert.True(t, ok)
+	})
+	t.Run("reconnect", func(t *testing.T) {
+		set(obj1, obj2)
+		broadcaster.reconnect()
+
+		for _, c := range []cache.Cache[testIndex, string, *testObject]{node1, node2, otherPurpose} {
+			_, ok := c.Get(ctx, testIndexID, "id1")
+			assert.False(t, ok)
+		}
+	})
+}
+
+func TestNewBroadcastCache_withoutInvalidation(t *testing.T) {
+	c := NewBroadcastCache[testIndex, string, *testObject](context.Background(), testIndices, cache.PurposeOrganization, cache.Config{}, NewConnector(Config{Enabled: true}, nil))
+	assert.IsType(t, &mapCache[testIndex, string, *testObject]{}, c)
+}
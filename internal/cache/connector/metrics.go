@@ -0,0 +1,57 @@
+package connector
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	CacheHitCounter             = "zitadel.cache.hits"
+	CacheHitCounterDescription  = "Amount of objects found in a cache"
+	CacheMissCounter            = "zitadel.cache.misses"
+	CacheMissCounterDescription = "Amount of objects not found in a cache"
+
+	cachePurposeLabel   = "purpose"
+	cacheConnectorLabel = "connector"
+)
+
+var registerCacheCounters = sync.OnceFunc(func() {
+	err := metrics.RegisterCounter(CacheHitCounter, CacheHitCounterDescription)
+	logging.WithFields("metric", CacheHitCounter).OnError(err).Panic("unable to register counter")
+	err = metrics.RegisterCounter(CacheMissCounter, CacheMissCounterDescription)
+	logging.WithFields("metric", CacheMissCounter).OnError(err).Panic("unable to register counter")
+})
+
+// metricsCache counts the hits and misses of Get per purpose and connector.
+type metricsCache[I, K comparable, V cache.Entry[I, K]] struct {
+	cache.Cache[I, K, V]
+	labels map[string]attribute.Value
+}
+
+func withMetrics[I, K comparable, V cache.Entry[I, K]](c cache.Cache[I, K, V], purpose cache.Purpose, connector cache.Connector) cache.Cache[I, K, V] {
+	registerCacheCounters()
+	return &metricsCache[I, K, V]{
+		Cache: c,
+		labels: map[string]attribute.Value{
+			cachePurposeLabel:   attribute.StringValue(purpose.String()),
+			cacheConnectorLabel: attribute.StringValue(connector.String()),
+		},
+	}
+}
+
+func (c *metricsCache[I, K, V]) Get(ctx context.Context, index I, key K) (V, bool) {
+	value, ok := c.Cache.Get(ctx, index, key)
+	counter := CacheMissCounter
+	if ok {
+		counter = CacheHitCounter
+	}
+	err := metrics.AddCount(ctx, counter, 1, c.labels)
+	logging.WithFields("metric", counter).OnError(err).Debug("incrementing counter metric failed")
+	return value, ok
+}
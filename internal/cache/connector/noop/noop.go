@@ -13,7 +13,13 @@ func NewCache[I, K comparable, V cache.Entry[I, K]]() cache.Cache[I, K, V] {
 	return noop[I, K, V]{}
 }
 
+// NewAdderCache returns a cache that does nothing and adds every object.
+func NewAdderCache[I, K comparable, V cache.Entry[I, K]]() cache.AdderCache[I, K, V] {
+	return noop[I, K, V]{}
+}
+
 func (noop[I, K, V]) Set(context.Context, V)                          {}
+func (noop[I, K, V]) Add(context.Context, V) (bool, error)            { return true, nil }
 func (noop[I, K, V]) Get(context.Context, I, K) (value V, ok bool)    { return }
 func (noop[I, K, V]) Invalidate(context.Context, I, ...K) (err error) { return }
 func (noop[I, K, V]) Delete(context.Context, I, ...K) (err error)     { return }
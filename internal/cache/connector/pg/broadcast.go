@@ -0,0 +1,70 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zitadel/logging"
+)
+
+const broadcastReconnectDelay = time.Second
+
+// Broadcaster publishes and subscribes messages using LISTEN / NOTIFY of PostgreSQL.
+type Broadcaster struct {
+	pool *pgxpool.Pool
+}
+
+func NewBroadcaster(pool *pgxpool.Pool) *Broadcaster {
+	return &Broadcaster{pool: pool}
+}
+
+func (b *Broadcaster) Publish(ctx context.Context, channel string, payload []byte) error {
+	_, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", channel, string(payload))
+	return err
+}
+
+// Subscribe listens on the channel using a dedicated connection.
+// The connection is re-established after errors until the context is done,
+// subscribed is called every time the channel is listened on.
+func (b *Broadcaster) Subscribe(ctx context.Context, channel string, subscribed func(), handle func(payload []byte)) {
+	for {
+		err := b.listen(ctx, channel, subscribed, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		logging.WithError(err).WithField("channel", channel).Warn("cache invalidation listener failed, reconnecting")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(broadcastReconnectDelay):
+		}
+	}
+}
+
+func (b *Broadcaster) listen(ctx context.Context, channel string, subscribed func(), handle func(payload []byte)) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection is closed instead of being released to the pool,
+	// so the LISTEN does not leak to other users of the pool.
+	defer func() {
+		//nolint:errcheck
+		conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	subscribed()
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle([]byte(notification.Payload))
+	}
+}
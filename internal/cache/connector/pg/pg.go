@@ -22,6 +22,8 @@ var (
 	createPartitionTmpl  = template.Must(template.New("create_partition").Parse(createPartitionQuery))
 	//go:embed set.sql
 	setQuery string
+	//go:embed add.sql
+	addQuery string
 	//go:embed get.sql
 	getQuery string
 	//go:embed invalidate.sql
@@ -95,6 +97,30 @@ func (c *pgCache[I, K, V]) set(ctx context.Context, entry V) (err error) {
 	return nil
 }
 
+// uniqueViolation is the error code returned by PostgreSQL if a unique constraint is violated.
+const uniqueViolation = "23505"
+
+// Add implements [cache.Adder].
+// Keys of expired objects are considered existing until the objects are pruned.
+func (c *pgCache[I, K, V]) Add(ctx context.Context, entry V) (added bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	keys := c.indexKeysFromEntry(entry)
+	c.logger.DebugContext(ctx, "pg cache add", "index_key", keys)
+
+	_, err = c.connector.Exec(ctx, addQuery, c.purpose.String(), keys, entry)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.SQLState() == uniqueViolation {
+		return false, nil
+	}
+	if err != nil {
+		c.logger.ErrorContext(ctx, "pg cache add", "err", err)
+		return false, err
+	}
+	return true, nil
+}
+
 func (c *pgCache[I, K, V]) Get(ctx context.Context, index I, key K) (value V, ok bool) {
 	value, err := c.get(ctx, index, key)
 	if err == nil {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,6 +170,66 @@ func Test_pgCache_Set(t *testing.T) {
 	}
 }
 
+func Test_pgCache_Add(t *testing.T) {
+	queryExpect := regexp.QuoteMeta(addQuery)
+	entry := &testObject{
+		ID:   "id1",
+		Name: []string{"foo"},
+	}
+	keys := []indexKey[testIndex, string]{
+		{IndexID: testIndexID, IndexKey: "id1"},
+		{IndexID: testIndexName, IndexKey: "foo"},
+	}
+	tests := []struct {
+		name    string
+		expect  func(pgxmock.PgxCommonIface)
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "error",
+			expect: func(ppi pgxmock.PgxCommonIface) {
+				ppi.ExpectExec(queryExpect).
+					WithArgs(cachePurpose.String(), keys, entry).
+					WillReturnError(pgx.ErrTxClosed)
+			},
+			wantErr: pgx.ErrTxClosed,
+		},
+		{
+			name: "key exists",
+			expect: func(ppi pgxmock.PgxCommonIface) {
+				ppi.ExpectExec(queryExpect).
+					WithArgs(cachePurpose.String(), keys, entry).
+					WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+			},
+			want: false,
+		},
+		{
+			name: "success",
+			expect: func(ppi pgxmock.PgxCommonIface) {
+				ppi.ExpectExec(queryExpect).
+					WithArgs(cachePurpose.String(), keys, entry).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, pool := prepareCache(t, cache.Config{})
+			defer pool.Close()
+			tt.expect(pool)
+
+			got, err := c.(cache.Adder[*testObject]).Add(context.Background(), entry)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+
+			err = pool.ExpectationsWereMet()
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func Test_pgCache_Get(t *testing.T) {
 	queryExpect := regexp.QuoteMeta(getQuery)
 	type args struct {
@@ -0,0 +1,15 @@
+-- KEYS: [1]: object_id; [>1]: index keys.
+-- Returns 0 if an index key maps to a valid object,
+-- otherwise the object is stored by the appended set script.
+for i = 2, #KEYS do
+    local existing_id = getCall("GET", KEYS[i])
+    if not (existing_id == nil) then
+        local expiry = getCall("HGET", existing_id, "expiry")
+        local expired = not (expiry == nil) and tonumber(expiry) > 0 and getTime() > tonumber(expiry)
+        if redis.call("EXISTS", existing_id) == 1 and not expired then
+            return 0
+        end
+        -- the object expired, but there are keys that need to be cleaned up
+        remove(existing_id)
+    end
+end
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Broadcaster publishes and subscribes messages using redis pub/sub.
+type Broadcaster struct {
+	connector *Connector
+}
+
+func NewBroadcaster(connector *Connector) *Broadcaster {
+	return &Broadcaster{connector: connector}
+}
+
+func (b *Broadcaster) Publish(ctx context.Context, channel string, payload []byte) error {
+	return b.connector.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe receives the messages of the channel until the context is done.
+// The redis client takes care of reconnecting the subscription,
+// subscribed is called for every confirmation of the subscription, which is sent again after a reconnect.
+func (b *Broadcaster) Subscribe(ctx context.Context, channel string, subscribed func(), handle func(payload []byte)) {
+	sub := b.connector.Subscribe(ctx, channel)
+	//nolint:errcheck
+	defer sub.Close()
+
+	messages := sub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				if msg.Kind == "subscribe" {
+					subscribed()
+				}
+			case *redis.Message:
+				handle([]byte(msg.Payload))
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster_Subscribe(t *testing.T) {
+	server := miniredis.RunT(t)
+	connector := NewConnector(Config{
+		Enabled:          true,
+		Network:          "tcp",
+		Addr:             server.Addr(),
+		DisableIndentity: true,
+	})
+	t.Cleanup(func() {
+		connector.Close()
+		server.Close()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var subscribed atomic.Int32
+	payloads := make(chan string, 1)
+	broadcaster := NewBroadcaster(connector)
+	go broadcaster.Subscribe(ctx, "channel",
+		func() { subscribed.Add(1) },
+		func(payload []byte) { payloads <- string(payload) },
+	)
+	require.Eventually(t, func() bool { return subscribed.Load() == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, broadcaster.Publish(ctx, "channel", []byte("payload")))
+	select {
+	case payload := <-payloads:
+		assert.Equal(t, "payload", payload)
+	case <-time.After(time.Second):
+		t.Fatal("payload not received")
+	}
+}
@@ -28,6 +28,7 @@ type Config struct {
 	Password string
 	// Each ZITADEL cache uses an incremental DB namespace.
 	// This option offsets the first DB so it doesn't conflict with other databases on the same server.
+	// The highest DB used is the offset plus the value of the last cache purpose.
 	// Note that ZITADEL uses FLUSHDB command to truncate a cache.
 	// This can have destructive consequences when overlapping DB namespaces are used.
 	DBOffset int
@@ -26,13 +26,17 @@ var (
 	removeComponent string
 	//go:embed set.lua
 	setScript string
+	//go:embed add.lua
+	addScript string
 	//go:embed get.lua
 	getScript string
 	//go:embed invalidate.lua
 	invalidateScript string
 
 	// Don't mind the creative "import"
-	setParsed        = redis.NewScript(strings.Join([]string{selectComponent, utilComponent, setScript}, "\n"))
+	setParsed = redis.NewScript(strings.Join([]string{selectComponent, utilComponent, setScript}, "\n"))
+	// the add script returns early if a key exists, otherwise it runs the set script and returns 1.
+	addParsed        = redis.NewScript(strings.Join([]string{selectComponent, utilComponent, removeComponent, addScript, setScript, "return 1"}, "\n"))
 	getParsed        = redis.NewScript(strings.Join([]string{selectComponent, utilComponent, removeComponent, getScript}, "\n"))
 	invalidateParsed = redis.NewScript(strings.Join([]string{selectComponent, utilComponent, removeComponent, invalidateScript}, "\n"))
 )
@@ -91,6 +95,32 @@ func (c *redisCache[I, K, V]) set(ctx context.Context, value V) (objectID string
 	return objectID, nil
 }
 
+// Add implements [cache.Adder].
+func (c *redisCache[I, K, V]) Add(ctx context.Context, value V) (added bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	keys := []string{uuid.NewString()}
+	for _, index := range c.indices {
+		keys = append(keys, c.redisIndexKeys(index, value.Keys(index)...)...)
+	}
+	var buf strings.Builder
+	if err = json.NewEncoder(&buf).Encode(value); err != nil {
+		return false, err
+	}
+	result, err := addParsed.Run(ctx, c.connector, keys,
+		c.db,                                   // DB namespace
+		buf.String(),                           // object
+		int64(c.config.LastUseAge/time.Second), // usage_lifetime
+		int64(c.config.MaxAge/time.Second),     // max_age,
+	).Int()
+	if err != nil {
+		c.logger.ErrorContext(ctx, "redis cache add", "err", err)
+		return false, err
+	}
+	return result == 1, nil
+}
+
 func (c *redisCache[I, K, V]) Get(ctx context.Context, index I, key K) (value V, ok bool) {
 	var (
 		obj any
@@ -167,6 +167,81 @@ func Test_redisCache_set(t *testing.T) {
 	}
 }
 
+func Test_redisCache_Add(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      cache.Config
+		preparation func(t *testing.T, c cache.Cache[testIndex, string, *testObject], s *miniredis.Miniredis)
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:   "connection error",
+			config: cache.Config{},
+			preparation: func(_ *testing.T, _ cache.Cache[testIndex, string, *testObject], s *miniredis.Miniredis) {
+				s.RequireAuth("foobar")
+			},
+			wantErr: true,
+		},
+		{
+			name:        "added",
+			config:      cache.Config{},
+			preparation: func(*testing.T, cache.Cache[testIndex, string, *testObject], *miniredis.Miniredis) {},
+			want:        true,
+		},
+		{
+			name:   "key exists",
+			config: cache.Config{},
+			preparation: func(t *testing.T, c cache.Cache[testIndex, string, *testObject], s *miniredis.Miniredis) {
+				c.Set(context.Background(), &testObject{
+					ID:   "two",
+					Name: []string{"foo"},
+				})
+			},
+			want: false,
+		},
+		{
+			name: "key of expired object",
+			config: cache.Config{
+				MaxAge: time.Minute,
+			},
+			preparation: func(t *testing.T, c cache.Cache[testIndex, string, *testObject], s *miniredis.Miniredis) {
+				c.Set(context.Background(), &testObject{
+					ID:   "two",
+					Name: []string{"foo"},
+				})
+				s.FastForward(2 * time.Minute)
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, server := prepareCache(t, tt.config)
+			tt.preparation(t, c, server)
+
+			got, err := c.(cache.Adder[*testObject]).Add(context.Background(), &testObject{
+				ID:   "one",
+				Name: []string{"foo"},
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			obj, ok := c.Get(context.Background(), testIndexName, "foo")
+			require.True(t, ok)
+			if tt.want {
+				assert.Equal(t, "one", obj.ID)
+				return
+			}
+			assert.Equal(t, "two", obj.ID)
+		})
+	}
+}
+
 func Test_redisCache_Get(t *testing.T) {
 	type args struct {
 		ctx   context.Context
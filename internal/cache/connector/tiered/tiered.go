@@ -0,0 +1,69 @@
+// Package tiered provides a cache which serves objects from a local cache
+// and falls back to a remote cache on a miss.
+package tiered
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type tieredCache[I, K comparable, V cache.Entry[I, K]] struct {
+	local  cache.PrunerCache[I, K, V]
+	remote cache.Cache[I, K, V]
+}
+
+// NewCache returns a cache which gets objects from the local cache first.
+// On a miss the object is obtained from the remote cache and added to the local cache.
+// Set, Invalidate, Delete and Truncate are applied to both tiers.
+func NewCache[I, K comparable, V cache.Entry[I, K]](local cache.PrunerCache[I, K, V], remote cache.Cache[I, K, V]) cache.PrunerCache[I, K, V] {
+	return &tieredCache[I, K, V]{
+		local:  local,
+		remote: remote,
+	}
+}
+
+func (c *tieredCache[I, K, V]) Get(ctx context.Context, index I, key K) (value V, ok bool) {
+	if value, ok = c.local.Get(ctx, index, key); ok {
+		return value, true
+	}
+	if value, ok = c.remote.Get(ctx, index, key); ok {
+		c.local.Set(ctx, value)
+	}
+	return value, ok
+}
+
+func (c *tieredCache[I, K, V]) Set(ctx context.Context, value V) {
+	c.remote.Set(ctx, value)
+	c.local.Set(ctx, value)
+}
+
+// Invalidate the object in the remote cache first,
+// so a concurrent Get can not populate the local cache with the outdated object from the remote cache.
+func (c *tieredCache[I, K, V]) Invalidate(ctx context.Context, index I, keys ...K) error {
+	return errors.Join(
+		c.remote.Invalidate(ctx, index, keys...),
+		c.local.Invalidate(ctx, index, keys...),
+	)
+}
+
+func (c *tieredCache[I, K, V]) Delete(ctx context.Context, index I, keys ...K) error {
+	return errors.Join(
+		c.remote.Delete(ctx, index, keys...),
+		c.local.Delete(ctx, index, keys...),
+	)
+}
+
+func (c *tieredCache[I, K, V]) Truncate(ctx context.Context) error {
+	return errors.Join(
+		c.remote.Truncate(ctx),
+		c.local.Truncate(ctx),
+	)
+}
+
+// Prune the local cache.
+// The remote cache is pruned by the auto prune routine of its own connector.
+func (c *tieredCache[I, K, V]) Prune(ctx context.Context) error {
+	return c.local.Prune(ctx)
+}
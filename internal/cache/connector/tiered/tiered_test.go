@@ -0,0 +1,151 @@
+package tiered
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+)
+
+type testIndex int
+
+const (
+	testIndexID testIndex = iota
+	testIndexName
+)
+
+var testIndices = []testIndex{
+	testIndexID,
+	testIndexName,
+}
+
+type testObject struct {
+	id    string
+	names []string
+}
+
+func (o *testObject) Keys(index testIndex) []string {
+	switch index {
+	case testIndexID:
+		return []string{o.id}
+	case testIndexName:
+		return o.names
+	default:
+		return nil
+	}
+}
+
+func newTestCaches(t *testing.T) (c cache.PrunerCache[testIndex, string, *testObject], local, remote cache.PrunerCache[testIndex, string, *testObject]) {
+	t.Helper()
+	local = gomap.NewCache[testIndex, string, *testObject](context.Background(), testIndices, cache.Config{})
+	remote = gomap.NewCache[testIndex, string, *testObject](context.Background(), testIndices, cache.Config{})
+	return NewCache(local, remote), local, remote
+}
+
+func Test_tieredCache_Get(t *testing.T) {
+	obj := &testObject{id: "id", names: []string{"foo", "bar"}}
+	tests := []struct {
+		name      string
+		setLocal  bool
+		setRemote bool
+		want      *testObject
+		wantOK    bool
+		wantLocal bool
+	}{
+		{
+			name: "miss",
+		},
+		{
+			name:      "local hit",
+			setLocal:  true,
+			want:      obj,
+			wantOK:    true,
+			wantLocal: true,
+		},
+		{
+			name:      "remote hit populates local",
+			setRemote: true,
+			want:      obj,
+			wantOK:    true,
+			wantLocal: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c, local, remote := newTestCaches(t)
+			if tt.setLocal {
+				local.Set(ctx, obj)
+			}
+			if tt.setRemote {
+				remote.Set(ctx, obj)
+			}
+			got, ok := c.Get(ctx, testIndexName, "foo")
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			_, ok = local.Get(ctx, testIndexID, "id")
+			assert.Equal(t, tt.wantLocal, ok)
+		})
+	}
+}
+
+func Test_tieredCache_Set(t *testing.T) {
+	ctx := context.Background()
+	c, local, remote := newTestCaches(t)
+	c.Set(ctx, &testObject{id: "id"})
+
+	_, ok := local.Get(ctx, testIndexID, "id")
+	assert.True(t, ok)
+	_, ok = remote.Get(ctx, testIndexID, "id")
+	assert.True(t, ok)
+}
+
+func Test_tieredCache_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	c, local, remote := newTestCaches(t)
+	c.Set(ctx, &testObject{id: "id", names: []string{"foo"}})
+	require.NoError(t, c.Invalidate(ctx, testIndexName, "foo"))
+
+	_, ok := local.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	_, ok = remote.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	_, ok = c.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+}
+
+func Test_tieredCache_Delete(t *testing.T) {
+	ctx := context.Background()
+	c, local, remote := newTestCaches(t)
+	c.Set(ctx, &testObject{id: "id", names: []string{"foo"}})
+	require.NoError(t, c.Delete(ctx, testIndexName, "foo"))
+
+	_, ok := local.Get(ctx, testIndexName, "foo")
+	assert.False(t, ok)
+	_, ok = remote.Get(ctx, testIndexName, "foo")
+	assert.False(t, ok)
+	_, ok = c.Get(ctx, testIndexID, "id")
+	assert.True(t, ok)
+}
+
+func Test_tieredCache_Truncate(t *testing.T) {
+	ctx := context.Background()
+	c, local, remote := newTestCaches(t)
+	c.Set(ctx, &testObject{id: "id"})
+	require.NoError(t, c.Truncate(ctx))
+
+	_, ok := local.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	_, ok = remote.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+}
+
+func Test_tieredCache_Invalidate_unknownIndex(t *testing.T) {
+	c, _, _ := newTestCaches(t)
+	err := c.Invalidate(context.Background(), 99, "foo")
+	assert.ErrorIs(t, err, cache.NewIndexUnknownErr(testIndex(99)))
+}
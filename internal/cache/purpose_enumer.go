@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _PurposeName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callback"
+const _PurposeName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackoidc_clientprojectappoidc_user_infoproject_rolesuser_grantsd_po_p_prooftoken_poll"
 
-var _PurposeIndex = [...]uint8{0, 11, 25, 35, 47, 65}
+var _PurposeIndex = [...]uint8{0, 11, 25, 35, 47, 65, 76, 83, 86, 100, 113, 124, 136, 146}
 
-const _PurposeLowerName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callback"
+const _PurposeLowerName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackoidc_clientprojectappoidc_user_infoproject_rolesuser_grantsd_po_p_prooftoken_poll"
 
 func (i Purpose) String() string {
 	if i < 0 || i >= Purpose(len(_PurposeIndex)-1) {
@@ -29,21 +29,45 @@ func _PurposeNoOp() {
 	_ = x[PurposeMilestones-(2)]
 	_ = x[PurposeOrganization-(3)]
 	_ = x[PurposeIdPFormCallback-(4)]
+	_ = x[PurposeOIDCClient-(5)]
+	_ = x[PurposeProject-(6)]
+	_ = x[PurposeApp-(7)]
+	_ = x[PurposeOIDCUserInfo-(8)]
+	_ = x[PurposeProjectRoles-(9)]
+	_ = x[PurposeUserGrants-(10)]
+	_ = x[PurposeDPoPProof-(11)]
+	_ = x[PurposeTokenPoll-(12)]
 }
 
-var _PurposeValues = []Purpose{PurposeUnspecified, PurposeAuthzInstance, PurposeMilestones, PurposeOrganization, PurposeIdPFormCallback}
+var _PurposeValues = []Purpose{PurposeUnspecified, PurposeAuthzInstance, PurposeMilestones, PurposeOrganization, PurposeIdPFormCallback, PurposeOIDCClient, PurposeProject, PurposeApp, PurposeOIDCUserInfo, PurposeProjectRoles, PurposeUserGrants, PurposeDPoPProof, PurposeTokenPoll}
 
 var _PurposeNameToValueMap = map[string]Purpose{
-	_PurposeName[0:11]:       PurposeUnspecified,
-	_PurposeLowerName[0:11]:  PurposeUnspecified,
-	_PurposeName[11:25]:      PurposeAuthzInstance,
-	_PurposeLowerName[11:25]: PurposeAuthzInstance,
-	_PurposeName[25:35]:      PurposeMilestones,
-	_PurposeLowerName[25:35]: PurposeMilestones,
-	_PurposeName[35:47]:      PurposeOrganization,
-	_PurposeLowerName[35:47]: PurposeOrganization,
-	_PurposeName[47:65]:      PurposeIdPFormCallback,
-	_PurposeLowerName[47:65]: PurposeIdPFormCallback,
+	_PurposeName[0:11]:         PurposeUnspecified,
+	_PurposeLowerName[0:11]:    PurposeUnspecified,
+	_PurposeName[11:25]:        PurposeAuthzInstance,
+	_PurposeLowerName[11:25]:   PurposeAuthzInstance,
+	_PurposeName[25:35]:        PurposeMilestones,
+	_PurposeLowerName[25:35]:   PurposeMilestones,
+	_PurposeName[35:47]:        PurposeOrganization,
+	_PurposeLowerName[35:47]:   PurposeOrganization,
+	_PurposeName[47:65]:        PurposeIdPFormCallback,
+	_PurposeLowerName[47:65]:   PurposeIdPFormCallback,
+	_PurposeName[65:76]:        PurposeOIDCClient,
+	_PurposeLowerName[65:76]:   PurposeOIDCClient,
+	_PurposeName[76:83]:        PurposeProject,
+	_PurposeLowerName[76:83]:   PurposeProject,
+	_PurposeName[83:86]:        PurposeApp,
+	_PurposeLowerName[83:86]:   PurposeApp,
+	_PurposeName[86:100]:       PurposeOIDCUserInfo,
+	_PurposeLowerName[86:100]:  PurposeOIDCUserInfo,
+	_PurposeName[100:113]:      PurposeProjectRoles,
+	_PurposeLowerName[100:113]: PurposeProjectRoles,
+	_PurposeName[113:124]:      PurposeUserGrants,
+	_PurposeLowerName[113:124]: PurposeUserGrants,
+	_PurposeName[124:136]:      PurposeDPoPProof,
+	_PurposeLowerName[124:136]: PurposeDPoPProof,
+	_PurposeName[136:146]:      PurposeTokenPoll,
+	_PurposeLowerName[136:146]: PurposeTokenPoll,
 }
 
 var _PurposeNames = []string{
@@ -52,6 +76,14 @@ var _PurposeNames = []string{
 	_PurposeName[25:35],
 	_PurposeName[35:47],
 	_PurposeName[47:65],
+	_PurposeName[65:76],
+	_PurposeName[76:83],
+	_PurposeName[83:86],
+	_PurposeName[86:100],
+	_PurposeName[100:113],
+	_PurposeName[113:124],
+	_PurposeName[124:136],
+	_PurposeName[136:146],
 }
 
 // PurposeString retrieves an enum value from the enum constants string name.
@@ -179,6 +179,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("12345678"),
 								},
+								nil,
 							),
 						),
 					),
@@ -234,6 +235,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("12345678"),
 								},
+								nil,
 							),
 						),
 					),
@@ -289,6 +291,7 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("12345678"),
 								},
+								nil,
 							),
 						),
 					),
@@ -861,6 +864,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 								KeyID:      "id",
 								Crypted:    []byte("12345678"),
 							},
+							nil,
 						),
 					),
 					expectPushFailed(
@@ -961,6 +965,7 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("12345678"),
 								},
+								nil,
 							),
 						),
 					),
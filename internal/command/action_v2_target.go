@@ -21,6 +21,7 @@ type AddTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	RetryPolicy      *domain.TargetRetryPolicy
 
 	SigningKey string
 }
@@ -36,7 +37,23 @@ func (a *AddTarget) IsValid() error {
 	if err != nil || a.Endpoint == "" {
 		return zerrors.ThrowInvalidArgument(err, "COMMAND-1r2k6qo6wg", "Errors.Target.InvalidURL")
 	}
+	if err := isValidRetryPolicy(a.RetryPolicy); err != nil {
+		return err
+	}
+
+	return nil
+}
 
+func isValidRetryPolicy(policy *domain.TargetRetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-gq8rl0h3bx", "Errors.Target.InvalidRetryPolicy")
+	}
+	if policy.MaxBackoff > 0 && policy.MaxBackoff < policy.InitialBackoff {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-w6bfy2o0qk", "Errors.Target.InvalidRetryPolicy")
+	}
 	return nil
 }
 
@@ -76,6 +93,7 @@ func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner
 		add.Timeout,
 		add.InterruptOnError,
 		code.Crypted,
+		add.RetryPolicy,
 	))
 	if err != nil {
 		return nil, err
@@ -94,6 +112,7 @@ type ChangeTarget struct {
 	Endpoint         *string
 	Timeout          *time.Duration
 	InterruptOnError *bool
+	RetryPolicy      *domain.TargetRetryPolicy
 
 	ExpirationSigningKey bool
 	SigningKey           *string
@@ -115,7 +134,7 @@ func (a *ChangeTarget) IsValid() error {
 			return zerrors.ThrowInvalidArgument(err, "COMMAND-jsbaera7b6", "Errors.Target.InvalidURL")
 		}
 	}
-	return nil
+	return isValidRetryPolicy(a.RetryPolicy)
 }
 
 func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resourceOwner string) (*domain.ObjectDetails, error) {
@@ -151,6 +170,7 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 		change.Endpoint,
 		change.Timeout,
 		change.InterruptOnError,
+		change.RetryPolicy,
 		changedSigningKey,
 	)
 	if changedEvent == nil {
@@ -20,6 +20,7 @@ type TargetWriteModel struct {
 	Timeout          time.Duration
 	InterruptOnError bool
 	SigningKey       *crypto.CryptoValue
+	RetryPolicy      *domain.TargetRetryPolicy
 
 	State domain.TargetState
 }
@@ -44,6 +45,7 @@ func (wm *TargetWriteModel) Reduce() error {
 			wm.Timeout = e.Timeout
 			wm.State = domain.TargetActive
 			wm.SigningKey = e.SigningKey
+			wm.RetryPolicy = e.RetryPolicy
 		case *target.ChangedEvent:
 			if e.Name != nil {
 				wm.Name = *e.Name
@@ -63,6 +65,9 @@ func (wm *TargetWriteModel) Reduce() error {
 			if e.SigningKey != nil {
 				wm.SigningKey = e.SigningKey
 			}
+			if e.RetryPolicy != nil {
+				wm.RetryPolicy = e.RetryPolicy
+			}
 		case *target.RemovedEvent:
 			wm.State = domain.TargetRemoved
 		}
@@ -90,6 +95,7 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	endpoint *string,
 	timeout *time.Duration,
 	interruptOnError *bool,
+	retryPolicy *domain.TargetRetryPolicy,
 	signingKey *crypto.CryptoValue,
 ) *target.ChangedEvent {
 	changes := make([]target.Changes, 0)
@@ -108,6 +114,9 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	if interruptOnError != nil && wm.InterruptOnError != *interruptOnError {
 		changes = append(changes, target.ChangeInterruptOnError(*interruptOnError))
 	}
+	if retryPolicy != nil && (wm.RetryPolicy == nil || *wm.RetryPolicy != *retryPolicy) {
+		changes = append(changes, target.ChangeRetryPolicy(retryPolicy))
+	}
 	// if signingkey is set, update it as it is encrypted
 	if signingKey != nil {
 		changes = append(changes, target.ChangeSigningKey(signingKey))
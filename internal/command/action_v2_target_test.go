@@ -141,6 +141,7 @@ func TestCommands_AddTarget(t *testing.T) {
 								KeyID:      "id",
 								Crypted:    []byte("12345678"),
 							},
+							nil,
 						),
 					),
 				),
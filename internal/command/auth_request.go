@@ -29,6 +29,8 @@ type AuthRequest struct {
 	LoginHint        *string
 	HintUserID       *string
 	NeedRefreshToken bool
+	// AuthorizationDetails are the authorization_details (RFC 9396) the client requested.
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 type CurrentAuthRequest struct {
@@ -73,6 +75,7 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 		authRequest.LoginHint,
 		authRequest.HintUserID,
 		authRequest.NeedRefreshToken,
+		authRequest.AuthorizationDetails,
 	))
 	if err != nil {
 		return nil, err
@@ -164,22 +167,23 @@ func (c *Commands) AddAuthRequestCode(ctx context.Context, authRequestID, code s
 func authRequestWriteModelToCurrentAuthRequest(writeModel *AuthRequestWriteModel) (_ *CurrentAuthRequest) {
 	return &CurrentAuthRequest{
 		AuthRequest: &AuthRequest{
-			ID:            writeModel.AggregateID,
-			LoginClient:   writeModel.LoginClient,
-			ClientID:      writeModel.ClientID,
-			RedirectURI:   writeModel.RedirectURI,
-			State:         writeModel.State,
-			Nonce:         writeModel.Nonce,
-			Scope:         writeModel.Scope,
-			Audience:      writeModel.Audience,
-			ResponseType:  writeModel.ResponseType,
-			ResponseMode:  writeModel.ResponseMode,
-			CodeChallenge: writeModel.CodeChallenge,
-			Prompt:        writeModel.Prompt,
-			UILocales:     writeModel.UILocales,
-			MaxAge:        writeModel.MaxAge,
-			LoginHint:     writeModel.LoginHint,
-			HintUserID:    writeModel.HintUserID,
+			ID:                   writeModel.AggregateID,
+			LoginClient:          writeModel.LoginClient,
+			ClientID:             writeModel.ClientID,
+			RedirectURI:          writeModel.RedirectURI,
+			State:                writeModel.State,
+			Nonce:                writeModel.Nonce,
+			Scope:                writeModel.Scope,
+			Audience:             writeModel.Audience,
+			ResponseType:         writeModel.ResponseType,
+			ResponseMode:         writeModel.ResponseMode,
+			CodeChallenge:        writeModel.CodeChallenge,
+			Prompt:               writeModel.Prompt,
+			UILocales:            writeModel.UILocales,
+			MaxAge:               writeModel.MaxAge,
+			LoginHint:            writeModel.LoginHint,
+			HintUserID:           writeModel.HintUserID,
+			AuthorizationDetails: writeModel.AuthorizationDetails,
 		},
 		SessionID:   writeModel.SessionID,
 		UserID:      writeModel.UserID,
@@ -15,27 +15,28 @@ type AuthRequestWriteModel struct {
 	eventstore.WriteModel
 	aggregate *eventstore.Aggregate
 
-	LoginClient      string
-	ClientID         string
-	RedirectURI      string
-	State            string
-	Nonce            string
-	Scope            []string
-	Audience         []string
-	ResponseType     domain.OIDCResponseType
-	ResponseMode     domain.OIDCResponseMode
-	CodeChallenge    *domain.OIDCCodeChallenge
-	Prompt           []domain.Prompt
-	UILocales        []string
-	MaxAge           *time.Duration
-	LoginHint        *string
-	HintUserID       *string
-	SessionID        string
-	UserID           string
-	AuthTime         time.Time
-	AuthMethods      []domain.UserAuthMethodType
-	AuthRequestState domain.AuthRequestState
-	NeedRefreshToken bool
+	LoginClient          string
+	ClientID             string
+	RedirectURI          string
+	State                string
+	Nonce                string
+	Scope                []string
+	Audience             []string
+	ResponseType         domain.OIDCResponseType
+	ResponseMode         domain.OIDCResponseMode
+	CodeChallenge        *domain.OIDCCodeChallenge
+	Prompt               []domain.Prompt
+	UILocales            []string
+	MaxAge               *time.Duration
+	LoginHint            *string
+	HintUserID           *string
+	SessionID            string
+	UserID               string
+	AuthTime             time.Time
+	AuthMethods          []domain.UserAuthMethodType
+	AuthRequestState     domain.AuthRequestState
+	NeedRefreshToken     bool
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 func NewAuthRequestWriteModel(ctx context.Context, id string) *AuthRequestWriteModel {
@@ -68,6 +69,7 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.HintUserID = e.HintUserID
 			m.AuthRequestState = domain.AuthRequestStateAdded
 			m.NeedRefreshToken = e.NeedRefreshToken
+			m.AuthorizationDetails = e.AuthorizationDetails
 		case *authrequest.SessionLinkedEvent:
 			m.SessionID = e.SessionID
 			m.UserID = e.UserID
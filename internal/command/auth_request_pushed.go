@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PushedAuthRequest is an authorization request, which was pushed by the client to the
+// Pushed Authorization Request endpoint (RFC 9126) and can be referenced by its request_uri.
+type PushedAuthRequest struct {
+	ID         string
+	ClientID   string
+	Parameters map[string][]string
+	Expiration time.Time
+}
+
+// AddPushedAuthRequest stores the parameters of an authorization request of an authenticated client.
+// The returned request can be used once until the expiration.
+func (c *Commands) AddPushedAuthRequest(ctx context.Context, clientID string, parameters map[string][]string, expiration time.Time) (_ *PushedAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-q8zt3nv5wd", "Errors.IDMissing")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.Pushed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-r6ht0mla3c", "Errors.AuthRequest.AlreadyExisting")
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedEvent(
+		ctx,
+		writeModel.aggregate,
+		clientID,
+		parameters,
+		expiration,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &PushedAuthRequest{
+		ID:         writeModel.AggregateID,
+		ClientID:   writeModel.ClientID,
+		Parameters: writeModel.Parameters,
+		Expiration: writeModel.Expiration,
+	}, nil
+}
+
+// UsePushedAuthRequest returns the parameters of the pushed authorization request
+// and marks it as used, so that the request_uri cannot be replayed.
+// The request can only be used by the client, which pushed it.
+func (c *Commands) UsePushedAuthRequest(ctx context.Context, id, clientID string) (_ map[string][]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Pushed || writeModel.ClientID != clientID {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-v2ke8sd0pq", "Errors.AuthRequest.NotExisting")
+	}
+	if writeModel.Used {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-m3wz7ybf1t", "Errors.AuthRequest.AlreadyHandled")
+	}
+	if writeModel.Expiration.Before(time.Now()) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-h9dl4ex2ua", "Errors.AuthRequest.Expired")
+	}
+	// the unique constraint of the event rejects concurrent uses of the request
+	err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedUsedEvent(ctx, writeModel.aggregate))
+	if zerrors.IsErrorAlreadyExists(err) {
+		return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-Pq7rk", "Errors.AuthRequest.AlreadyHandled")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return writeModel.Parameters, nil
+}
+
+func (c *Commands) getPushedAuthRequestWriteModel(ctx context.Context, id string) (writeModel *PushedAuthRequestWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewPushedAuthRequestWriteModel(ctx, id)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+)
+
+type PushedAuthRequestWriteModel struct {
+	eventstore.WriteModel
+	aggregate *eventstore.Aggregate
+
+	ClientID   string
+	Parameters map[string][]string
+	Expiration time.Time
+	Pushed     bool
+	Used       bool
+}
+
+func NewPushedAuthRequestWriteModel(ctx context.Context, id string) *PushedAuthRequestWriteModel {
+	return &PushedAuthRequestWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID: id,
+		},
+		aggregate: &authrequest.NewAggregate(id, authz.GetInstance(ctx).InstanceID()).Aggregate,
+	}
+}
+
+func (m *PushedAuthRequestWriteModel) Reduce() error {
+	for _, event := range m.Events {
+		switch e := event.(type) {
+		case *authrequest.PushedEvent:
+			m.ClientID = e.ClientID
+			m.Parameters = e.Parameters
+			m.Expiration = e.Expiration
+			m.Pushed = true
+		case *authrequest.PushedUsedEvent:
+			m.Used = true
+		}
+	}
+
+	return m.WriteModel.Reduce()
+}
+
+func (m *PushedAuthRequestWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(authrequest.AggregateType).
+		AggregateIDs(m.AggregateID).
+		EventTypes(
+			authrequest.PushedType,
+			authrequest.PushedUsedType,
+		).
+		Builder()
+}
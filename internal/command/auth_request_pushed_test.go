@@ -0,0 +1,256 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddPushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	expiration := time.Now().Add(time.Minute).UTC().Round(0)
+	parameters := map[string][]string{
+		"response_type": {"code"},
+		"redirect_uri":  {"https://example.com/callback"},
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		parameters map[string][]string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *PushedAuthRequest
+		wantErr error
+	}{
+		{
+			"missing client id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:        mockCtx,
+				parameters: parameters,
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-q8zt3nv5wd", "Errors.IDMissing"),
+		},
+		{
+			"already exists error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								parameters,
+								expiration,
+							),
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				parameters: parameters,
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-r6ht0mla3c", "Errors.AuthRequest.AlreadyExisting"),
+		},
+		{
+			"pushed",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+							"clientID",
+							parameters,
+							expiration,
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				parameters: parameters,
+			},
+			&PushedAuthRequest{
+				ID:         "id",
+				ClientID:   "clientID",
+				Parameters: parameters,
+				Expiration: expiration,
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.AddPushedAuthRequest(tt.args.ctx, tt.args.clientID, tt.args.parameters, expiration)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_UsePushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	parameters := map[string][]string{
+		"response_type": {"code"},
+		"redirect_uri":  {"https://example.com/callback"},
+	}
+	pushedEvent := func(expiration time.Time) eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+				"clientID",
+				parameters,
+				expiration,
+			),
+		)
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		clientID string
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		want       map[string][]string
+		wantErr    error
+	}{
+		{
+			"not existing",
+			expectEventstore(
+				expectFilter(),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowNotFound(nil, "COMMAND-v2ke8sd0pq", "Errors.AuthRequest.NotExisting"),
+		},
+		{
+			"other client",
+			expectEventstore(
+				expectFilter(
+					pushedEvent(time.Now().Add(time.Minute)),
+				),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "otherClientID",
+			},
+			nil,
+			zerrors.ThrowNotFound(nil, "COMMAND-v2ke8sd0pq", "Errors.AuthRequest.NotExisting"),
+		},
+		{
+			"already used",
+			expectEventstore(
+				expectFilter(
+					pushedEvent(time.Now().Add(time.Minute)),
+					eventFromEventPusher(
+						authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate),
+					),
+				),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-m3wz7ybf1t", "Errors.AuthRequest.AlreadyHandled"),
+		},
+		{
+			"expired",
+			expectEventstore(
+				expectFilter(
+					pushedEvent(time.Now().Add(-time.Minute)),
+				),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-h9dl4ex2ua", "Errors.AuthRequest.Expired"),
+		},
+		{
+			"used concurrently",
+			expectEventstore(
+				expectFilter(
+					pushedEvent(time.Now().Add(time.Minute)),
+				),
+				expectPushFailed(
+					zerrors.ThrowAlreadyExists(nil, "ID", "Errors.AuthRequest.AlreadyHandled"),
+					authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate),
+				),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-Pq7rk", "Errors.AuthRequest.AlreadyHandled"),
+		},
+		{
+			"used",
+			expectEventstore(
+				expectFilter(
+					pushedEvent(time.Now().Add(time.Minute)),
+				),
+				expectPush(
+					authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate),
+				),
+			),
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			parameters,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.UsePushedAuthRequest(tt.args.ctx, tt.args.id, tt.args.clientID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
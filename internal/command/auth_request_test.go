@@ -62,6 +62,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								false,
+								nil,
 							),
 						),
 					),
@@ -101,6 +102,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 							gu.Ptr("loginHint"),
 							gu.Ptr("hintUserID"),
 							false,
+							nil,
 						),
 					),
 				),
@@ -234,6 +236,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -276,6 +279,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -317,6 +321,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -356,6 +361,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -418,6 +424,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -469,6 +476,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -557,6 +565,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -646,6 +655,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -736,6 +746,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -827,6 +838,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -950,6 +962,7 @@ func TestCommands_FailAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								true,
+								nil,
 							),
 						),
 					),
@@ -1050,6 +1063,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
 								true,
+								nil,
 							),
 						),
 					),
@@ -1088,6 +1102,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
 								true,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -0,0 +1,241 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/cibarequest"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// CIBARequest is a Client-Initiated Backchannel Authentication request,
+// where a client asks for the authentication of a user on another device.
+type CIBARequest struct {
+	ID       string
+	ClientID string
+	// UserID and UserOrgID identify the user, who has to approve the request.
+	UserID           string
+	UserOrgID        string
+	Scopes           []string
+	Audience         []string
+	BindingMessage   string
+	Expires          time.Time
+	NeedRefreshToken bool
+	// NotificationURI is set for clients using the ping mode,
+	// which are notified with the ClientNotificationToken once the request is approved or denied.
+	NotificationURI         string
+	ClientNotificationToken string
+	// LoginURL is the URL of the login, the user is notified with to approve or deny the request.
+	LoginURL string
+}
+
+// AddCIBARequest stores a new backchannel authentication request.
+// The ID of the request is generated and returned as auth_req_id to the client.
+func (c *Commands) AddCIBARequest(ctx context.Context, request *CIBARequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if request.ClientID == "" || request.UserID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-w3ncq9v1zh", "Errors.IDMissing")
+	}
+	request.ID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	model, err := c.getCIBARequestWriteModel(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if model.State.Exists() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-u7b2kx0r4e", "Errors.CIBARequest.AlreadyHandled")
+	}
+	err = c.pushAppendAndReduce(ctx, model, cibarequest.NewAddedEvent(
+		ctx,
+		model.aggregate,
+		request.ClientID,
+		request.UserID,
+		request.UserOrgID,
+		request.Scopes,
+		request.Audience,
+		request.BindingMessage,
+		request.NotificationURI,
+		request.ClientNotificationToken,
+		request.Expires,
+		request.NeedRefreshToken,
+		request.LoginURL,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ApproveCIBARequestWithSession approves the backchannel authentication request
+// with the session of the requested user.
+func (c *Commands) ApproveCIBARequestWithSession(
+	ctx context.Context,
+	id,
+	sessionID,
+	sessionToken string,
+) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, err := c.getCIBARequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-k2vd8xq5mj", "Errors.CIBARequest.NotFound")
+	}
+	if model.State != domain.CIBARequestStateInitiated || model.Expires.Before(time.Now()) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-e0sn6p3wlc", "Errors.CIBARequest.AlreadyHandled")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionSessionLink, model.ResourceOwner, ""); err != nil {
+		return nil, err
+	}
+
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if err = sessionWriteModel.CheckIsActive(); err != nil {
+		return nil, err
+	}
+	if err := c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.UserID != model.UserID {
+		return nil, zerrors.ThrowPermissionDenied(nil, "COMMAND-y5hb1tz8gq", "Errors.CIBARequest.UserMismatch")
+	}
+
+	err = c.pushAppendAndReduce(ctx, model, cibarequest.NewApprovedEvent(
+		ctx,
+		model.aggregate,
+		sessionWriteModel.AuthMethodTypes(),
+		sessionWriteModel.AuthenticationTime(),
+		sessionWriteModel.PreferredLanguage,
+		sessionWriteModel.UserAgent,
+		sessionID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// CancelCIBARequest denies or expires the backchannel authentication request.
+func (c *Commands) CancelCIBARequest(ctx context.Context, id string, reason domain.CIBARequestCanceled) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, err := c.getCIBARequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-a9fm3rw6dt", "Errors.CIBARequest.NotFound")
+	}
+	if model.State != domain.CIBARequestStateInitiated {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-p1xj7cu4nb", "Errors.CIBARequest.AlreadyHandled")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionSessionLink, model.ResourceOwner, ""); err != nil {
+		return nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, model, cibarequest.NewCanceledEvent(ctx, model.aggregate, reason))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+func (c *Commands) getCIBARequestWriteModel(ctx context.Context, id string) (*CIBARequestWriteModel, error) {
+	model := NewCIBARequestWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	err := c.eventstore.FilterToQueryReducer(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+type CIBARequestStateError domain.CIBARequestState
+
+func (e CIBARequestStateError) Error() string {
+	return fmt.Sprintf("ciba request state not approved: %s", domain.CIBARequestState(e).String())
+}
+
+// CreateOIDCSessionFromCIBA creates a new OIDC session if the backchannel authentication request
+// of the client was approved by the user.
+// A [CIBARequestStateError] is returned if the request was not approved,
+// containing a [domain.CIBARequestState] which can be used to inform the client about the state.
+//
+// As with the device authorization, an explicit state takes precedence over expiry.
+func (c *Commands) CreateOIDCSessionFromCIBA(ctx context.Context, id, clientID, dpopJKT string) (_ *OIDCSession, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, err := c.getCIBARequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if model.ClientID != clientID {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-b6qt2wn0xs", "Errors.CIBARequest.NotFound")
+	}
+
+	switch model.State {
+	case domain.CIBARequestStateApproved:
+		break
+	case domain.CIBARequestStateUndefined:
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-f4lr9hz1cv", "Errors.CIBARequest.NotFound")
+	case domain.CIBARequestStateInitiated:
+		if model.Expires.Before(time.Now()) {
+			c.asyncPush(ctx, cibarequest.NewCanceledEvent(ctx, model.aggregate, domain.CIBARequestCanceledExpired))
+			return nil, CIBARequestStateError(domain.CIBARequestStateExpired)
+		}
+		fallthrough
+	case domain.CIBARequestStateDenied, domain.CIBARequestStateExpired, domain.CIBARequestStateDone:
+		fallthrough
+	default:
+		return nil, CIBARequestStateError(model.State)
+	}
+
+	cmd, err := c.newOIDCSessionAddEvents(ctx, model.UserID, model.UserOrgID)
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddSession(ctx,
+		model.UserID,
+		model.UserOrgID,
+		model.SessionID,
+		model.ClientID,
+		model.Audience,
+		model.Scopes,
+		model.UserAuthMethods,
+		model.AuthTime,
+		"",
+		model.PreferredLanguage,
+		model.UserAgent,
+		dpopJKT,
+		nil,
+	)
+	if err = cmd.AddAccessToken(ctx, model.Scopes, model.UserID, model.UserOrgID, domain.TokenReasonAuthRequest, nil, dpopJKT, nil); err != nil {
+		return nil, err
+	}
+	if model.NeedRefreshToken {
+		if err = cmd.AddRefreshToken(ctx, model.UserID); err != nil {
+			return nil, err
+		}
+	}
+	cmd.CIBARequestDone(ctx, model.aggregate)
+	return cmd.PushEvents(ctx)
+}
+
+func (cmd *OIDCSessionEvents) CIBARequestDone(ctx context.Context, cibaRequestAggregate *eventstore.Aggregate) {
+	cmd.events = append(cmd.events, cibarequest.NewDoneEvent(ctx, cibaRequestAggregate))
+}
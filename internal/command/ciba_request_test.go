@@ -0,0 +1,414 @@
+package command
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/cibarequest"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddCIBARequest(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute).UTC().Round(0)
+
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *CIBARequest
+		wantID      string
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "missing user, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			request: &CIBARequest{
+				ClientID: "clientID",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-w3ncq9v1zh", "Errors.IDMissing"),
+		},
+		{
+			name: "push error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPushFailed(io.ErrClosedPipe,
+						cibarequest.NewAddedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+							"clientID", "userID", "orgID",
+							[]string{"openid"}, []string{"projectID", "clientID"},
+							"message", "", "", expires, false, "",
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			request: &CIBARequest{
+				ClientID:       "clientID",
+				UserID:         "userID",
+				UserOrgID:      "orgID",
+				Scopes:         []string{"openid"},
+				Audience:       []string{"projectID", "clientID"},
+				BindingMessage: "message",
+				Expires:        expires,
+			},
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "added",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						cibarequest.NewAddedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+							"clientID", "userID", "orgID",
+							[]string{"openid", "offline_access"}, []string{"projectID", "clientID"},
+							"message", "https://example.com/ciba", "token", expires, true,
+							"https://login.example.com/backchannel-authentication?id=",
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			request: &CIBARequest{
+				ClientID:                "clientID",
+				UserID:                  "userID",
+				UserOrgID:               "orgID",
+				Scopes:                  []string{"openid", "offline_access"},
+				Audience:                []string{"projectID", "clientID"},
+				BindingMessage:          "message",
+				Expires:                 expires,
+				NeedRefreshToken:        true,
+				NotificationURI:         "https://example.com/ciba",
+				ClientNotificationToken: "token",
+				LoginURL:                "https://login.example.com/backchannel-authentication?id=",
+			},
+			wantID: "id",
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotDetails, err := c.AddCIBARequest(ctx, tt.request)
+			require.ErrorIs(t, err, tt.wantErr)
+			assertObjectDetails(t, tt.wantDetails, gotDetails)
+			if tt.wantErr == nil {
+				require.Equal(t, tt.wantID, tt.request.ID)
+			}
+		})
+	}
+}
+
+func TestCommands_ApproveCIBARequestWithSession(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+	userAgent := &domain.UserAgent{
+		FingerprintID: gu.Ptr("fp1"),
+		IP:            net.ParseIP("1.2.3.4"),
+		Description:   gu.Ptr("firefox"),
+		Header:        http.Header{"foo": []string{"bar"}},
+	}
+	cibaRequestAdded := func(userID string) eventstore.Event {
+		return eventFromEventPusherWithInstanceID(
+			"instance1",
+			cibarequest.NewAddedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+				"clientID", userID, "orgID",
+				[]string{"openid"}, []string{"projectID", "clientID"},
+				"message", "", "", expires, false, "",
+			),
+		)
+	}
+	sessionEvents := func() []eventstore.Event {
+		return []eventstore.Event{
+			eventFromEventPusher(
+				session.NewAddedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate, userAgent),
+			),
+			eventFromEventPusher(
+				session.NewUserCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+					"userID", "orgID", testNow, &language.Afrikaans),
+			),
+			eventFromEventPusher(
+				session.NewPasswordCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+					testNow),
+			),
+			eventFromEventPusherWithCreationDateNow(
+				session.NewLifetimeSetEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+					2*time.Minute),
+			),
+		}
+	}
+
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		tokenVerifier   func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+		checkPermission domain.PermissionCheck
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-k2vd8xq5mj", "Errors.CIBARequest.NotFound"),
+		},
+		{
+			name: "already denied, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						cibaRequestAdded("userID"),
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							cibarequest.NewCanceledEvent(ctx, cibarequest.NewAggregate("id", "instance1"), domain.CIBARequestCanceledDenied),
+						),
+					),
+				),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-e0sn6p3wlc", "Errors.CIBARequest.AlreadyHandled"),
+		},
+		{
+			name: "missing permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(cibaRequestAdded("userID")),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "other user, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(cibaRequestAdded("otherUserID")),
+					expectFilter(sessionEvents()...),
+				),
+				tokenVerifier:   newMockTokenVerifierValid(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "COMMAND-y5hb1tz8gq", "Errors.CIBARequest.UserMismatch"),
+		},
+		{
+			name: "approved",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(cibaRequestAdded("userID")),
+					expectFilter(sessionEvents()...),
+					expectPush(
+						cibarequest.NewApprovedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+							[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+							testNow, &language.Afrikaans, userAgent, "sessionID",
+						),
+					),
+				),
+				tokenVerifier:   newMockTokenVerifierValid(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.fields.eventstore(t),
+				sessionTokenVerifier: tt.fields.tokenVerifier,
+				checkPermission:      tt.fields.checkPermission,
+			}
+			gotDetails, err := c.ApproveCIBARequestWithSession(ctx, "id", "sessionID", "sessionToken")
+			require.ErrorIs(t, err, tt.wantErr)
+			assertObjectDetails(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_CancelCIBARequest(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	cibaRequestAdded := func() eventstore.Event {
+		return eventFromEventPusherWithInstanceID(
+			"instance1",
+			cibarequest.NewAddedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+				"clientID", "userID", "orgID",
+				[]string{"openid"}, []string{"projectID", "clientID"},
+				"message", "", "", time.Now().Add(time.Minute), false, "",
+			),
+		)
+	}
+
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-a9fm3rw6dt", "Errors.CIBARequest.NotFound"),
+		},
+		{
+			name: "already approved, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						cibaRequestAdded(),
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							cibarequest.NewApprovedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+								[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+								testNow, &language.Afrikaans, nil, "sessionID",
+							),
+						),
+					),
+				),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-p1xj7cu4nb", "Errors.CIBARequest.AlreadyHandled"),
+		},
+		{
+			name: "missing permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(cibaRequestAdded()),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "denied",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(cibaRequestAdded()),
+					expectPush(
+						cibarequest.NewCanceledEvent(ctx, cibarequest.NewAggregate("id", "instance1"), domain.CIBARequestCanceledDenied),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			gotDetails, err := c.CancelCIBARequest(ctx, "id", domain.CIBARequestCanceledDenied)
+			require.ErrorIs(t, err, tt.wantErr)
+			assertObjectDetails(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_CreateOIDCSessionFromCIBA(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	cibaRequestAdded := eventFromEventPusherWithInstanceID(
+		"instance1",
+		cibarequest.NewAddedEvent(ctx, cibarequest.NewAggregate("id", "instance1"),
+			"clientID", "userID", "orgID",
+			[]string{"openid"}, []string{"projectID", "clientID"},
+			"message", "", "", time.Now().Add(time.Minute), false, "",
+		),
+	)
+
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		clientID   string
+		wantErr    error
+	}{
+		{
+			name: "filter error",
+			eventstore: expectEventstore(
+				expectFilterError(io.ErrClosedPipe),
+			),
+			clientID: "clientID",
+			wantErr:  io.ErrClosedPipe,
+		},
+		{
+			name: "other client, not found",
+			eventstore: expectEventstore(
+				expectFilter(cibaRequestAdded),
+			),
+			clientID: "otherClientID",
+			wantErr:  zerrors.ThrowNotFound(nil, "COMMAND-b6qt2wn0xs", "Errors.CIBARequest.NotFound"),
+		},
+		{
+			name: "not yet approved",
+			eventstore: expectEventstore(
+				expectFilter(cibaRequestAdded),
+			),
+			clientID: "clientID",
+			wantErr:  CIBARequestStateError(domain.CIBARequestStateInitiated),
+		},
+		{
+			name: "denied",
+			eventstore: expectEventstore(
+				expectFilter(
+					cibaRequestAdded,
+					eventFromEventPusherWithInstanceID(
+						"instance1",
+						cibarequest.NewCanceledEvent(ctx, cibarequest.NewAggregate("id", "instance1"), domain.CIBARequestCanceledDenied),
+					),
+				),
+			),
+			clientID: "clientID",
+			wantErr:  CIBARequestStateError(domain.CIBARequestStateDenied),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.CreateOIDCSessionFromCIBA(ctx, "id", tt.clientID, "")
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Nil(t, got)
+		})
+	}
+}
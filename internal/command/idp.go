@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/saml/pkg/provider/xml"
+
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/repository/idp"
@@ -20,6 +22,7 @@ type GenericOAuthProvider struct {
 	UserEndpoint          string
 	Scopes                []string
 	IDAttribute           string
+	ClaimMapping          *domain.IDPClaimMapping
 	IDPOptions            idp.Options
 }
 
@@ -30,6 +33,7 @@ type GenericOIDCProvider struct {
 	ClientSecret     string
 	Scopes           []string
 	IsIDTokenMapping bool
+	ClaimMapping     *domain.IDPClaimMapping
 	IDPOptions       idp.Options
 }
 
@@ -109,6 +113,8 @@ type LDAPProvider struct {
 	Timeout           time.Duration
 	RootCA            []byte
 	LDAPAttributes    idp.LDAPAttributes
+	GroupMapping      *domain.LDAPGroupMapping
+	DirectorySync     bool
 	IDPOptions        idp.Options
 }
 
@@ -120,6 +126,7 @@ type SAMLProvider struct {
 	WithSignedRequest             bool
 	NameIDFormat                  *domain.SAMLNameIDFormat
 	TransientMappingAttributeName string
+	ClaimMapping                  *domain.IDPClaimMapping
 	IDPOptions                    idp.Options
 }
 
@@ -133,7 +140,28 @@ type AppleProvider struct {
 	IDPOptions idp.Options
 }
 
+type KerberosProvider struct {
+	Name             string
+	ServicePrincipal string
+	Keytab           []byte
+	IDPOptions       idp.Options
+}
+
 // ExistsIDPOnOrgOrInstance query first org level IDPs and then instance level IDPs, no check if the IDP is active
+// samlChanges computes the changes of a SAML provider based on its current state
+type samlChanges func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error)
+
+func (c *Commands) refreshSAMLMetadataChanges(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+	if writeModel.MetadataURL == "" {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Qe7fb", "Errors.IDPConfig.SAMLMetadataURLMissing")
+	}
+	metadata, err := xml.ReadMetadataFromURL(c.httpClient, writeModel.MetadataURL)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "COMMAND-Hn5ws", "Errors.Project.App.SAMLMetadataMissing")
+	}
+	return writeModel.NewRefreshMetadataChanges(metadata)
+}
+
 func ExistsIDPOnOrgOrInstance(ctx context.Context, filter preparation.FilterToQueryReducer, instanceID, orgID, id string) (exists bool, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/crewjam/saml"
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"golang.org/x/oauth2"
@@ -21,6 +22,7 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/kerberos"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
@@ -45,6 +47,7 @@ type OAuthIDPWriteModel struct {
 	UserEndpoint          string
 	Scopes                []string
 	IDAttribute           string
+	ClaimMapping          *domain.IDPClaimMapping
 	idp.Options
 
 	State domain.IDPState
@@ -73,6 +76,7 @@ func (wm *OAuthIDPWriteModel) reduceAddedEvent(e *idp.OAuthIDPAddedEvent) {
 	wm.UserEndpoint = e.UserEndpoint
 	wm.Scopes = e.Scopes
 	wm.IDAttribute = e.IDAttribute
+	wm.ClaimMapping = e.ClaimMapping
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
@@ -102,6 +106,9 @@ func (wm *OAuthIDPWriteModel) reduceChangedEvent(e *idp.OAuthIDPChangedEvent) {
 	if e.IDAttribute != nil {
 		wm.IDAttribute = *e.IDAttribute
 	}
+	if e.ClaimMapping != nil {
+		wm.ClaimMapping = e.ClaimMapping
+	}
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
@@ -115,6 +122,7 @@ func (wm *OAuthIDPWriteModel) NewChanges(
 	userEndpoint,
 	idAttribute string,
 	scopes []string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) ([]idp.OAuthIDPChanges, error) {
 	changes := make([]idp.OAuthIDPChanges, 0)
@@ -148,6 +156,9 @@ func (wm *OAuthIDPWriteModel) NewChanges(
 	if wm.IDAttribute != idAttribute {
 		changes = append(changes, idp.ChangeOAuthIDAttribute(idAttribute))
 	}
+	if !wm.ClaimMapping.Equal(claimMapping) {
+		changes = append(changes, idp.ChangeOAuthClaimMapping(claimMapping))
+	}
 	opts := wm.Options.Changes(options)
 	if !opts.IsZero() {
 		changes = append(changes, idp.ChangeOAuthOptions(opts))
@@ -170,7 +181,7 @@ func (wm *OAuthIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encry
 		RedirectURL: callbackURL,
 		Scopes:      wm.Scopes,
 	}
-	opts := make([]oauth.ProviderOpts, 0, 4)
+	opts := make([]oauth.ProviderOpts, 0, 5)
 	if wm.IsCreationAllowed {
 		opts = append(opts, oauth.WithCreationAllowed())
 	}
@@ -183,6 +194,9 @@ func (wm *OAuthIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encry
 	if wm.IsAutoUpdate {
 		opts = append(opts, oauth.WithAutoUpdate())
 	}
+	if !wm.ClaimMapping.IsZero() {
+		opts = append(opts, oauth.WithClaimMapping(wm.ClaimMapping))
+	}
 	return oauth.New(
 		config,
 		wm.Name,
@@ -208,6 +222,7 @@ type OIDCIDPWriteModel struct {
 	ClientSecret     *crypto.CryptoValue
 	Scopes           []string
 	IsIDTokenMapping bool
+	ClaimMapping     *domain.IDPClaimMapping
 	idp.Options
 
 	State domain.IDPState
@@ -248,6 +263,7 @@ func (wm *OIDCIDPWriteModel) reduceAddedEvent(e *idp.OIDCIDPAddedEvent) {
 	wm.ClientSecret = e.ClientSecret
 	wm.Scopes = e.Scopes
 	wm.IsIDTokenMapping = e.IsIDTokenMapping
+	wm.ClaimMapping = e.ClaimMapping
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
@@ -271,6 +287,9 @@ func (wm *OIDCIDPWriteModel) reduceChangedEvent(e *idp.OIDCIDPChangedEvent) {
 	if e.IsIDTokenMapping != nil {
 		wm.IsIDTokenMapping = *e.IsIDTokenMapping
 	}
+	if e.ClaimMapping != nil {
+		wm.ClaimMapping = e.ClaimMapping
+	}
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
@@ -282,6 +301,7 @@ func (wm *OIDCIDPWriteModel) NewChanges(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping bool,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) ([]idp.OIDCIDPChanges, error) {
 	changes := make([]idp.OIDCIDPChanges, 0)
@@ -309,6 +329,9 @@ func (wm *OIDCIDPWriteModel) NewChanges(
 	if wm.IsIDTokenMapping != idTokenMapping {
 		changes = append(changes, idp.ChangeOIDCIsIDTokenMapping(idTokenMapping))
 	}
+	if !wm.ClaimMapping.Equal(claimMapping) {
+		changes = append(changes, idp.ChangeOIDCClaimMapping(claimMapping))
+	}
 	opts := wm.Options.Changes(options)
 	if !opts.IsZero() {
 		changes = append(changes, idp.ChangeOIDCOptions(opts))
@@ -365,7 +388,7 @@ func (wm *OIDCIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 	if err != nil {
 		return nil, err
 	}
-	opts := make([]oidc.ProviderOpts, 1, 6)
+	opts := make([]oidc.ProviderOpts, 1, 7)
 	opts[0] = oidc.WithSelectAccount()
 	if wm.IsIDTokenMapping {
 		opts = append(opts, oidc.WithIDTokenMapping())
@@ -382,6 +405,9 @@ func (wm *OIDCIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 	if wm.IsAutoUpdate {
 		opts = append(opts, oidc.WithAutoUpdate())
 	}
+	if !wm.ClaimMapping.IsZero() {
+		opts = append(opts, oidc.WithClaimMapping(wm.ClaimMapping))
+	}
 	return oidc.New(
 		wm.Name,
 		wm.Issuer,
@@ -1368,6 +1394,8 @@ type LDAPIDPWriteModel struct {
 	UserFilters       []string
 	Timeout           time.Duration
 	RootCA            []byte
+	GroupMapping      *domain.LDAPGroupMapping
+	DirectorySync     bool
 	idp.LDAPAttributes
 	idp.Options
 
@@ -1409,6 +1437,8 @@ func (wm *LDAPIDPWriteModel) reduceAddedEvent(e *idp.LDAPIDPAddedEvent) {
 	wm.UserFilters = e.UserFilters
 	wm.Timeout = e.Timeout
 	wm.RootCA = e.RootCA
+	wm.GroupMapping = e.GroupMapping
+	wm.DirectorySync = e.DirectorySync
 	wm.LDAPAttributes = e.LDAPAttributes
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
@@ -1448,6 +1478,12 @@ func (wm *LDAPIDPWriteModel) reduceChangedEvent(e *idp.LDAPIDPChangedEvent) {
 	if e.Timeout != nil {
 		wm.Timeout = *e.Timeout
 	}
+	if e.GroupMapping != nil {
+		wm.GroupMapping = e.GroupMapping
+	}
+	if e.DirectorySync != nil {
+		wm.DirectorySync = *e.DirectorySync
+	}
 	wm.LDAPAttributes.ReduceChanges(e.LDAPAttributeChanges)
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
@@ -1466,6 +1502,8 @@ func (wm *LDAPIDPWriteModel) NewChanges(
 	rootCA []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
 	attributes idp.LDAPAttributes,
+	groupMapping *domain.LDAPGroupMapping,
+	directorySync bool,
 	options idp.Options,
 ) ([]idp.LDAPIDPChanges, error) {
 	changes := make([]idp.LDAPIDPChanges, 0)
@@ -1508,6 +1546,12 @@ func (wm *LDAPIDPWriteModel) NewChanges(
 	if !bytes.Equal(wm.RootCA, rootCA) {
 		changes = append(changes, idp.ChangeLDAPRootCA(rootCA))
 	}
+	if !wm.GroupMapping.Equal(groupMapping) {
+		changes = append(changes, idp.ChangeLDAPGroupMapping(groupMapping))
+	}
+	if wm.DirectorySync != directorySync {
+		changes = append(changes, idp.ChangeLDAPDirectorySync(directorySync))
+	}
 	attrs := wm.LDAPAttributes.Changes(attributes)
 	if !attrs.IsZero() {
 		changes = append(changes, idp.ChangeLDAPAttributes(attrs))
@@ -1567,6 +1611,9 @@ func (wm *LDAPIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 	if wm.LDAPAttributes.ProfileAttribute != "" {
 		opts = append(opts, ldap.WithProfileAttribute(wm.LDAPAttributes.ProfileAttribute))
 	}
+	if !wm.GroupMapping.IsZero() {
+		opts = append(opts, ldap.WithGroupAttribute(wm.GroupMapping.GroupAttribute))
+	}
 	if wm.IsCreationAllowed {
 		opts = append(opts, ldap.WithCreationAllowed())
 	}
@@ -1731,18 +1778,127 @@ func (wm *AppleIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+type KerberosIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID               string
+	Name             string
+	ServicePrincipal string
+	Keytab           *crypto.CryptoValue
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *KerberosIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.KerberosIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.KerberosIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *KerberosIDPWriteModel) reduceAddedEvent(e *idp.KerberosIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ServicePrincipal = e.ServicePrincipal
+	wm.Keytab = e.Keytab
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *KerberosIDPWriteModel) reduceChangedEvent(e *idp.KerberosIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ServicePrincipal != nil {
+		wm.ServicePrincipal = *e.ServicePrincipal
+	}
+	if e.Keytab != nil {
+		wm.Keytab = e.Keytab
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *KerberosIDPWriteModel) NewChanges(
+	name string,
+	servicePrincipal string,
+	keytab []byte,
+	secretCrypto crypto.EncryptionAlgorithm,
+	options idp.Options,
+) ([]idp.KerberosIDPChanges, error) {
+	changes := make([]idp.KerberosIDPChanges, 0)
+	if len(keytab) != 0 {
+		encryptedKeytab, err := crypto.Crypt(keytab, secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeKerberosKeytab(encryptedKeytab))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeKerberosName(name))
+	}
+	if wm.ServicePrincipal != servicePrincipal {
+		changes = append(changes, idp.ChangeKerberosServicePrincipal(servicePrincipal))
+	}
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeKerberosOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *KerberosIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	keytab, err := crypto.Decrypt(wm.Keytab, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]kerberos.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		opts = append(opts, kerberos.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		opts = append(opts, kerberos.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		opts = append(opts, kerberos.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		opts = append(opts, kerberos.WithAutoUpdate())
+	}
+	return kerberos.New(
+		wm.Name,
+		wm.ServicePrincipal,
+		keytab,
+		callbackURL,
+		opts...,
+	)
+}
+
+func (wm *KerberosIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
 type SAMLIDPWriteModel struct {
 	eventstore.WriteModel
 
 	Name                          string
 	ID                            string
 	Metadata                      []byte
+	MetadataURL                   string
 	Key                           *crypto.CryptoValue
 	Certificate                   []byte
+	AdditionalKeys                domain.SAMLKeys
 	Binding                       string
 	WithSignedRequest             bool
 	NameIDFormat                  *domain.SAMLNameIDFormat
 	TransientMappingAttributeName string
+	ClaimMapping                  *domain.IDPClaimMapping
 	idp.Options
 
 	State domain.IDPState
@@ -1765,12 +1921,14 @@ func (wm *SAMLIDPWriteModel) Reduce() error {
 func (wm *SAMLIDPWriteModel) reduceAddedEvent(e *idp.SAMLIDPAddedEvent) {
 	wm.Name = e.Name
 	wm.Metadata = e.Metadata
+	wm.MetadataURL = e.MetadataURL
 	wm.Key = e.Key
 	wm.Certificate = e.Certificate
 	wm.Binding = e.Binding
 	wm.WithSignedRequest = e.WithSignedRequest
 	wm.NameIDFormat = e.NameIDFormat
 	wm.TransientMappingAttributeName = e.TransientMappingAttributeName
+	wm.ClaimMapping = e.ClaimMapping
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
@@ -1782,12 +1940,18 @@ func (wm *SAMLIDPWriteModel) reduceChangedEvent(e *idp.SAMLIDPChangedEvent) {
 	if e.Certificate != nil {
 		wm.Certificate = e.Certificate
 	}
+	if e.AdditionalKeys != nil {
+		wm.AdditionalKeys = *e.AdditionalKeys
+	}
 	if e.Name != nil {
 		wm.Name = *e.Name
 	}
 	if e.Metadata != nil {
 		wm.Metadata = e.Metadata
 	}
+	if e.MetadataURL != nil {
+		wm.MetadataURL = *e.MetadataURL
+	}
 	if e.Binding != nil {
 		wm.Binding = *e.Binding
 	}
@@ -1800,12 +1964,16 @@ func (wm *SAMLIDPWriteModel) reduceChangedEvent(e *idp.SAMLIDPChangedEvent) {
 	if e.TransientMappingAttributeName != nil {
 		wm.TransientMappingAttributeName = *e.TransientMappingAttributeName
 	}
+	if e.ClaimMapping != nil {
+		wm.ClaimMapping = e.ClaimMapping
+	}
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
 func (wm *SAMLIDPWriteModel) NewChanges(
 	name string,
-	metadata,
+	metadata []byte,
+	metadataURL string,
 	key,
 	certificate []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
@@ -1813,6 +1981,7 @@ func (wm *SAMLIDPWriteModel) NewChanges(
 	withSignedRequest bool,
 	nameIDFormat *domain.SAMLNameIDFormat,
 	transientMappingAttributeName string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) ([]idp.SAMLIDPChanges, error) {
 	changes := make([]idp.SAMLIDPChanges, 0)
@@ -1832,6 +2001,9 @@ func (wm *SAMLIDPWriteModel) NewChanges(
 	if !reflect.DeepEqual(wm.Metadata, metadata) {
 		changes = append(changes, idp.ChangeSAMLMetadata(metadata))
 	}
+	if wm.MetadataURL != metadataURL {
+		changes = append(changes, idp.ChangeSAMLMetadataURL(metadataURL))
+	}
 	if wm.Binding != binding {
 		changes = append(changes, idp.ChangeSAMLBinding(binding))
 	}
@@ -1844,6 +2016,9 @@ func (wm *SAMLIDPWriteModel) NewChanges(
 	if wm.TransientMappingAttributeName != transientMappingAttributeName {
 		changes = append(changes, idp.ChangeSAMLTransientMappingAttributeName(transientMappingAttributeName))
 	}
+	if !wm.ClaimMapping.Equal(claimMapping) {
+		changes = append(changes, idp.ChangeSAMLClaimMapping(claimMapping))
+	}
 	opts := wm.Options.Changes(options)
 	if !opts.IsZero() {
 		changes = append(changes, idp.ChangeSAMLOptions(opts))
@@ -1857,7 +2032,7 @@ func (wm *SAMLIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 		return nil, err
 	}
 
-	opts := make([]saml2.ProviderOpts, 0, 7)
+	opts := make([]saml2.ProviderOpts, 0, 9)
 	if wm.IsCreationAllowed {
 		opts = append(opts, saml2.WithCreationAllowed())
 	}
@@ -1882,6 +2057,16 @@ func (wm *SAMLIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 	if wm.TransientMappingAttributeName != "" {
 		opts = append(opts, saml2.WithTransientMappingAttributeName(wm.TransientMappingAttributeName))
 	}
+	if !wm.ClaimMapping.IsZero() {
+		opts = append(opts, saml2.WithClaimMapping(wm.ClaimMapping))
+	}
+	if len(wm.AdditionalKeys) > 0 {
+		additionalKeys, err := decryptSAMLKeys(wm.AdditionalKeys, idpAlg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, saml2.WithAdditionalKeys(additionalKeys...))
+	}
 	opts = append(opts, saml2.WithCustomRequestTracker(
 		requesttracker.New(
 			addRequest,
@@ -1902,6 +2087,87 @@ func (wm *SAMLIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+// NewAddKeyChanges adds the key as additional key, so its certificate is published before it's activated.
+func (wm *SAMLIDPWriteModel) NewAddKeyChanges(keyID string, key, certificate []byte, secretCrypto crypto.EncryptionAlgorithm) ([]idp.SAMLIDPChanges, error) {
+	keyEnc, err := crypto.Encrypt(key, secretCrypto)
+	if err != nil {
+		return nil, err
+	}
+	keys := append(slices.Clone(wm.AdditionalKeys), &domain.SAMLKey{
+		ID:          keyID,
+		Key:         keyEnc,
+		Certificate: certificate,
+	})
+	return []idp.SAMLIDPChanges{idp.ChangeSAMLAdditionalKeys(keys)}, nil
+}
+
+// NewActivateKeyChanges swaps the additional key with the active one.
+// The previously active key is kept as additional key with the passed id, so assertions encrypted for it can still be decrypted.
+func (wm *SAMLIDPWriteModel) NewActivateKeyChanges(keyID, previousKeyID string) ([]idp.SAMLIDPChanges, error) {
+	key := wm.AdditionalKeys.Get(keyID)
+	if key == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Wd4qv", "Errors.IDPConfig.SAMLKeyNotExisting")
+	}
+	keys := append(wm.AdditionalKeys.Remove(keyID), &domain.SAMLKey{
+		ID:          previousKeyID,
+		Key:         wm.Key,
+		Certificate: wm.Certificate,
+	})
+	return []idp.SAMLIDPChanges{
+		idp.ChangeSAMLKey(key.Key),
+		idp.ChangeSAMLCertificate(key.Certificate),
+		idp.ChangeSAMLAdditionalKeys(keys),
+	}, nil
+}
+
+// NewRemoveKeyChanges removes the additional key, the active key can't be removed.
+func (wm *SAMLIDPWriteModel) NewRemoveKeyChanges(keyID string) ([]idp.SAMLIDPChanges, error) {
+	if wm.AdditionalKeys.Get(keyID) == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Tq8zd", "Errors.IDPConfig.SAMLKeyNotExisting")
+	}
+	return []idp.SAMLIDPChanges{idp.ChangeSAMLAdditionalKeys(wm.AdditionalKeys.Remove(keyID))}, nil
+}
+
+// NewRefreshMetadataChanges returns the change of the metadata, if the refreshed metadata differs from the current one.
+// The validity of the metadata is ignored, since some providers renew it on every request.
+func (wm *SAMLIDPWriteModel) NewRefreshMetadataChanges(metadata []byte) ([]idp.SAMLIDPChanges, error) {
+	refreshed, err := saml2.ParseMetadata(metadata)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "COMMAND-Mv2xs", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	current, err := saml2.ParseMetadata(wm.Metadata)
+	if err == nil && reflect.DeepEqual(withoutValidity(current), withoutValidity(refreshed)) {
+		return nil, nil
+	}
+	return []idp.SAMLIDPChanges{idp.ChangeSAMLMetadata(metadata)}, nil
+}
+
+func withoutValidity(metadata *saml.EntityDescriptor) *saml.EntityDescriptor {
+	metadata.ValidUntil = time.Time{}
+	metadata.CacheDuration = 0
+	metadata.Signature = nil
+	for i := range metadata.IDPSSODescriptors {
+		metadata.IDPSSODescriptors[i].ValidUntil = nil
+		metadata.IDPSSODescriptors[i].CacheDuration = 0
+	}
+	return metadata
+}
+
+func decryptSAMLKeys(keys domain.SAMLKeys, idpAlg crypto.EncryptionAlgorithm) ([]*saml2.Key, error) {
+	additionalKeys := make([]*saml2.Key, len(keys))
+	for i, key := range keys {
+		decrypted, err := crypto.Decrypt(key.Key, idpAlg)
+		if err != nil {
+			return nil, err
+		}
+		additionalKeys[i] = &saml2.Key{
+			Certificate: key.Certificate,
+			Key:         decrypted,
+		}
+	}
+	return additionalKeys, nil
+}
+
 type IDPRemoveWriteModel struct {
 	eventstore.WriteModel
 
@@ -1934,6 +2200,8 @@ func (wm *IDPRemoveWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID)
 		case *idp.AppleIDPAddedEvent:
 			wm.reduceAdded(e.ID)
+		case *idp.KerberosIDPAddedEvent:
+			wm.reduceAdded(e.ID)
 		case *idp.SAMLIDPAddedEvent:
 			wm.reduceAdded(e.ID)
 		case *idp.RemovedEvent:
@@ -2022,6 +2290,10 @@ func (wm *IDPTypeWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
 		case *org.AppleIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
+		case *instance.KerberosIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeKerberos, e.Aggregate())
+		case *org.KerberosIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeKerberos, e.Aggregate())
 		case *instance.SAMLIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
 		case *org.SAMLIDPAddedEvent:
@@ -2102,6 +2374,7 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.GoogleIDPAddedEventType,
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
+			instance.KerberosIDPAddedEventType,
 			instance.SAMLIDPAddedEventType,
 			instance.OIDCIDPMigratedAzureADEventType,
 			instance.OIDCIDPMigratedGoogleEventType,
@@ -2122,6 +2395,7 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.GoogleIDPAddedEventType,
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
+			org.KerberosIDPAddedEventType,
 			org.SAMLIDPAddedEventType,
 			org.OIDCIDPMigratedAzureADEventType,
 			org.OIDCIDPMigratedGoogleEventType,
@@ -2199,6 +2473,8 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewGoogleInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeApple:
 			writeModel.model = NewAppleInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeKerberos:
+			writeModel.model = NewKerberosInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeSAML:
 			writeModel.samlModel = NewSAMLInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeUnspecified:
@@ -2230,6 +2506,8 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewGoogleOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeApple:
 			writeModel.model = NewAppleOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeKerberos:
+			writeModel.model = NewKerberosOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeSAML:
 			writeModel.samlModel = NewSAMLOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeUnspecified:
@@ -158,6 +158,9 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -11,7 +11,10 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	providers "github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/kerberos"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -470,6 +473,80 @@ func (c *Commands) UpdateInstanceLDAPProvider(ctx context.Context, id string, pr
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
+// AddInstanceSAMLProviderKey generates an additional key for the SAML provider.
+// Its certificate is published in the metadata, so the identity provider can pick it up before the key is activated.
+func (c *Commands) AddInstanceSAMLProviderKey(ctx context.Context, id string) (keyID string, details *domain.ObjectDetails, err error) {
+	keyID, err = c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	details, err = c.changeInstanceSAMLProvider(ctx, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		key, cert, err := c.samlCertificateAndKeyGenerator(writeModel.ID)
+		if err != nil {
+			return nil, err
+		}
+		return writeModel.NewAddKeyChanges(keyID, key, cert, c.idpConfigEncryption)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, details, nil
+}
+
+// ActivateInstanceSAMLProviderKey uses the additional key for signing and publishes its certificate as the active one.
+// The previously active key is kept as additional key until it's removed.
+func (c *Commands) ActivateInstanceSAMLProviderKey(ctx context.Context, id, keyID string) (*domain.ObjectDetails, error) {
+	previousKeyID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	return c.changeInstanceSAMLProvider(ctx, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		return writeModel.NewActivateKeyChanges(keyID, previousKeyID)
+	})
+}
+
+func (c *Commands) RemoveInstanceSAMLProviderKey(ctx context.Context, id, keyID string) (*domain.ObjectDetails, error) {
+	return c.changeInstanceSAMLProvider(ctx, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		return writeModel.NewRemoveKeyChanges(keyID)
+	})
+}
+
+// RefreshInstanceSAMLProviderMetadata reads the metadata from the metadata URL of the SAML provider
+// and updates it if it changed.
+func (c *Commands) RefreshInstanceSAMLProviderMetadata(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	return c.changeInstanceSAMLProvider(ctx, id, c.refreshSAMLMetadataChanges)
+}
+
+func (c *Commands) changeInstanceSAMLProvider(ctx context.Context, id string, changes samlChanges) (*domain.ObjectDetails, error) {
+	if id = strings.TrimSpace(id); id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "INST-Xk2ma", "Errors.Invalid.Argument")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	writeModel := NewSAMLInstanceIDPWriteModel(instanceID, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "INST-Vb3kd", "Errors.IDPConfig.NotExisting")
+	}
+	samlChanges, err := changes(&writeModel.SAMLIDPWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if len(samlChanges) == 0 {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	event, err := instance.NewSAMLIDPChangedEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, samlChanges)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) AddInstanceAppleProvider(ctx context.Context, provider AppleProvider) (string, *domain.ObjectDetails, error) {
 	instanceID := authz.GetInstance(ctx).InstanceID()
 	instanceAgg := instance.NewAggregate(instanceID)
@@ -577,6 +654,48 @@ func (c *Commands) RegenerateInstanceSAMLProviderCertificate(ctx context.Context
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
+func (c *Commands) AddInstanceKerberosProvider(ctx context.Context, provider KerberosProvider) (string, *domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	instanceAgg := instance.NewAggregate(instanceID)
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel := NewKerberosInstanceIDPWriteModel(instanceID, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareAddInstanceKerberosProvider(instanceAgg, writeModel, provider))
+	if err != nil {
+		return "", nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) UpdateInstanceKerberosProvider(ctx context.Context, id string, provider KerberosProvider) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	instanceAgg := instance.NewAggregate(instanceID)
+	writeModel := NewKerberosInstanceIDPWriteModel(instanceID, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareUpdateInstanceKerberosProvider(instanceAgg, writeModel, provider))
+	if err != nil {
+		return nil, err
+	}
+	if len(cmds) == 0 {
+		// no change, so return directly
+		return &domain.ObjectDetails{
+			Sequence:      writeModel.ProcessedSequence,
+			EventDate:     writeModel.ChangeDate,
+			ResourceOwner: writeModel.ResourceOwner,
+		}, nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) DeleteInstanceProvider(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareDeleteInstanceProvider(instanceAgg, id))
@@ -630,6 +749,9 @@ func (c *Commands) prepareAddInstanceOAuthProvider(a *instance.Aggregate, writeM
 		if provider.IDAttribute = strings.TrimSpace(provider.IDAttribute); provider.IDAttribute == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-sdf3f", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -656,6 +778,7 @@ func (c *Commands) prepareAddInstanceOAuthProvider(a *instance.Aggregate, writeM
 					provider.UserEndpoint,
 					provider.IDAttribute,
 					provider.Scopes,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -686,6 +809,9 @@ func (c *Commands) prepareUpdateInstanceOAuthProvider(a *instance.Aggregate, wri
 		if provider.IDAttribute = strings.TrimSpace(provider.IDAttribute); provider.IDAttribute == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-JKD3h", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -711,6 +837,7 @@ func (c *Commands) prepareUpdateInstanceOAuthProvider(a *instance.Aggregate, wri
 				provider.UserEndpoint,
 				provider.IDAttribute,
 				provider.Scopes,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -735,6 +862,9 @@ func (c *Commands) prepareAddInstanceOIDCProvider(a *instance.Aggregate, writeMo
 		if provider.ClientSecret = strings.TrimSpace(provider.ClientSecret); provider.ClientSecret == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Sfdf4", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -759,6 +889,7 @@ func (c *Commands) prepareAddInstanceOIDCProvider(a *instance.Aggregate, writeMo
 					secret,
 					provider.Scopes,
 					provider.IsIDTokenMapping,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -780,6 +911,9 @@ func (c *Commands) prepareUpdateInstanceOIDCProvider(a *instance.Aggregate, writ
 		if provider.ClientID = strings.TrimSpace(provider.ClientID); provider.ClientID == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Db3bs", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -803,6 +937,7 @@ func (c *Commands) prepareUpdateInstanceOIDCProvider(a *instance.Aggregate, writ
 				c.idpConfigEncryption,
 				provider.Scopes,
 				provider.IsIDTokenMapping,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1528,6 +1663,9 @@ func (c *Commands) prepareAddInstanceLDAPProvider(a *instance.Aggregate, writeMo
 		if len(provider.UserFilters) == 0 {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-aAx905n", "Errors.Invalid.Argument")
 		}
+		if !provider.GroupMapping.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kf3ns", "Errors.IDPConfig.LDAPGroupMappingInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1537,6 +1675,9 @@ func (c *Commands) prepareAddInstanceLDAPProvider(a *instance.Aggregate, writeMo
 			if err = writeModel.Reduce(); err != nil {
 				return nil, err
 			}
+			if err = c.checkLDAPGroupMapping(ctx, "", provider.GroupMapping); err != nil {
+				return nil, err
+			}
 			secret, err := crypto.Encrypt([]byte(provider.BindPassword), c.idpConfigEncryption)
 			if err != nil {
 				return nil, err
@@ -1558,6 +1699,8 @@ func (c *Commands) prepareAddInstanceLDAPProvider(a *instance.Aggregate, writeMo
 					provider.Timeout,
 					provider.RootCA,
 					provider.LDAPAttributes,
+					provider.GroupMapping,
+					provider.DirectorySync,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -1591,6 +1734,9 @@ func (c *Commands) prepareUpdateInstanceLDAPProvider(a *instance.Aggregate, writ
 		if len(provider.UserFilters) == 0 {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-aAx901n", "Errors.Invalid.Argument")
 		}
+		if !provider.GroupMapping.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Tq7xw", "Errors.IDPConfig.LDAPGroupMappingInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1603,6 +1749,11 @@ func (c *Commands) prepareUpdateInstanceLDAPProvider(a *instance.Aggregate, writ
 			if !writeModel.State.Exists() {
 				return nil, zerrors.ThrowNotFound(nil, "INST-ASF3F", "Errors.IDPConfig.NotExisting")
 			}
+			if !writeModel.GroupMapping.Equal(provider.GroupMapping) {
+				if err = c.checkLDAPGroupMapping(ctx, "", provider.GroupMapping); err != nil {
+					return nil, err
+				}
+			}
 			event, err := writeModel.NewChangedEvent(
 				ctx,
 				&a.Aggregate,
@@ -1620,6 +1771,8 @@ func (c *Commands) prepareUpdateInstanceLDAPProvider(a *instance.Aggregate, writ
 				provider.RootCA,
 				c.idpConfigEncryption,
 				provider.LDAPAttributes,
+				provider.GroupMapping,
+				provider.DirectorySync,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1722,6 +1875,91 @@ func (c *Commands) prepareUpdateInstanceAppleProvider(a *instance.Aggregate, wri
 	}
 }
 
+func (c *Commands) prepareAddInstanceKerberosProvider(a *instance.Aggregate, writeModel *InstanceKerberosIDPWriteModel, provider KerberosProvider) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kb4sn", "Errors.Invalid.Argument")
+		}
+		if len(provider.Keytab) == 0 {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kb5tw", "Errors.IDPConfig.KerberosKeytabMissing")
+		}
+		if _, err := kerberos.ParseKeytab(provider.Keytab); err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "INST-Kb6ux", "Errors.IDPConfig.KerberosKeytabInvalid")
+		}
+		provider.ServicePrincipal = strings.TrimSpace(provider.ServicePrincipal)
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			keytab, err := crypto.Encrypt(provider.Keytab, c.idpConfigEncryption)
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewKerberosIDPAddedEvent(
+					ctx,
+					&a.Aggregate,
+					writeModel.ID,
+					provider.Name,
+					provider.ServicePrincipal,
+					keytab,
+					provider.IDPOptions,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareUpdateInstanceKerberosProvider(a *instance.Aggregate, writeModel *InstanceKerberosIDPWriteModel, provider KerberosProvider) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if writeModel.ID = strings.TrimSpace(writeModel.ID); writeModel.ID == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kb7vy", "Errors.IDMissing")
+		}
+		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kb8wz", "Errors.Invalid.Argument")
+		}
+		// the keytab is only changed if provided
+		if len(provider.Keytab) > 0 {
+			if _, err := kerberos.ParseKeytab(provider.Keytab); err != nil {
+				return nil, zerrors.ThrowInvalidArgument(err, "INST-Kb9xa", "Errors.IDPConfig.KerberosKeytabInvalid")
+			}
+		}
+		provider.ServicePrincipal = strings.TrimSpace(provider.ServicePrincipal)
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Kd2ye", "Errors.IDPConfig.NotExisting")
+			}
+			event, err := writeModel.NewChangedEvent(
+				ctx,
+				&a.Aggregate,
+				writeModel.ID,
+				provider.Name,
+				provider.ServicePrincipal,
+				provider.Keytab,
+				c.idpConfigEncryption,
+				provider.IDPOptions,
+			)
+			if err != nil || event == nil {
+				return nil, err
+			}
+			return []eventstore.Command{event}, nil
+		}, nil
+	}
+}
+
 func (c *Commands) prepareAddInstanceSAMLProvider(a *instance.Aggregate, writeModel *InstanceSAMLIDPWriteModel, provider *SAMLProvider) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
@@ -1740,6 +1978,9 @@ func (c *Commands) prepareAddInstanceSAMLProvider(a *instance.Aggregate, writeMo
 		if _, err := saml.ParseMetadata(provider.Metadata); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(err, "INST-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1765,12 +2006,14 @@ func (c *Commands) prepareAddInstanceSAMLProvider(a *instance.Aggregate, writeMo
 					writeModel.ID,
 					provider.Name,
 					provider.Metadata,
+					provider.MetadataURL,
 					keyEnc,
 					cert,
 					provider.Binding,
 					provider.WithSignedRequest,
 					provider.NameIDFormat,
 					provider.TransientMappingAttributeName,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -1799,6 +2042,9 @@ func (c *Commands) prepareUpdateInstanceSAMLProvider(a *instance.Aggregate, writ
 		if _, err := saml.ParseMetadata(provider.Metadata); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(err, "INST-dsfj3kl2", "Errors.Project.App.SAMLMetadataFormat")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1817,6 +2063,7 @@ func (c *Commands) prepareUpdateInstanceSAMLProvider(a *instance.Aggregate, writ
 				writeModel.ID,
 				provider.Name,
 				provider.Metadata,
+				provider.MetadataURL,
 				nil,
 				nil,
 				c.idpConfigEncryption,
@@ -1824,6 +2071,7 @@ func (c *Commands) prepareUpdateInstanceSAMLProvider(a *instance.Aggregate, writ
 				provider.WithSignedRequest,
 				provider.NameIDFormat,
 				provider.TransientMappingAttributeName,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1862,6 +2110,7 @@ func (c *Commands) prepareRegenerateInstanceSAMLProviderCertificate(a *instance.
 				writeModel.ID,
 				writeModel.Name,
 				writeModel.Metadata,
+				writeModel.MetadataURL,
 				key,
 				cert,
 				c.idpConfigEncryption,
@@ -1869,6 +2118,7 @@ func (c *Commands) prepareRegenerateInstanceSAMLProviderCertificate(a *instance.
 				writeModel.WithSignedRequest,
 				writeModel.NameIDFormat,
 				writeModel.TransientMappingAttributeName,
+				writeModel.ClaimMapping,
 				writeModel.Options,
 			)
 			if err != nil || event == nil {
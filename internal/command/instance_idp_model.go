@@ -68,6 +68,7 @@ func (wm *InstanceOAuthIDPWriteModel) NewChangedEvent(
 	userEndpoint,
 	idAttribute string,
 	scopes []string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*instance.OAuthIDPChangedEvent, error) {
 
@@ -81,6 +82,7 @@ func (wm *InstanceOAuthIDPWriteModel) NewChangedEvent(
 		userEndpoint,
 		idAttribute,
 		scopes,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -175,6 +177,7 @@ func (wm *InstanceOIDCIDPWriteModel) NewChangedEvent(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping bool,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*instance.OIDCIDPChangedEvent, error) {
 
@@ -186,6 +189,7 @@ func (wm *InstanceOIDCIDPWriteModel) NewChangedEvent(
 		secretCrypto,
 		scopes,
 		idTokenMapping,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -771,6 +775,8 @@ func (wm *InstanceLDAPIDPWriteModel) NewChangedEvent(
 	rootCA []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
 	attributes idp.LDAPAttributes,
+	groupMapping *domain.LDAPGroupMapping,
+	directorySync bool,
 	options idp.Options,
 ) (*instance.LDAPIDPChangedEvent, error) {
 
@@ -788,6 +794,8 @@ func (wm *InstanceLDAPIDPWriteModel) NewChangedEvent(
 		rootCA,
 		secretCrypto,
 		attributes,
+		groupMapping,
+		directorySync,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -863,6 +871,70 @@ func (wm *InstanceAppleIDPWriteModel) NewChangedEvent(
 	return instance.NewAppleIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type InstanceKerberosIDPWriteModel struct {
+	KerberosIDPWriteModel
+}
+
+func NewKerberosInstanceIDPWriteModel(instanceID, id string) *InstanceKerberosIDPWriteModel {
+	return &InstanceKerberosIDPWriteModel{
+		KerberosIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceKerberosIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.KerberosIDPAddedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.KerberosIDPAddedEvent)
+		case *instance.KerberosIDPChangedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.KerberosIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.KerberosIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceKerberosIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.KerberosIDPAddedEventType,
+			instance.KerberosIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceKerberosIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	servicePrincipal string,
+	keytab []byte,
+	secretCrypto crypto.EncryptionAlgorithm,
+	options idp.Options,
+) (*instance.KerberosIDPChangedEvent, error) {
+
+	changes, err := wm.KerberosIDPWriteModel.NewChanges(name, servicePrincipal, keytab, secretCrypto, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewKerberosIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type InstanceSAMLIDPWriteModel struct {
 	SAMLIDPWriteModel
 }
@@ -912,7 +984,8 @@ func (wm *InstanceSAMLIDPWriteModel) NewChangedEvent(
 	aggregate *eventstore.Aggregate,
 	id,
 	name string,
-	metadata,
+	metadata []byte,
+	metadataURL string,
 	key,
 	certificate []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
@@ -920,11 +993,13 @@ func (wm *InstanceSAMLIDPWriteModel) NewChangedEvent(
 	withSignedRequest bool,
 	nameIDFormat *domain.SAMLNameIDFormat,
 	transientMappingAttributeName string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*instance.SAMLIDPChangedEvent, error) {
 	changes, err := wm.SAMLIDPWriteModel.NewChanges(
 		name,
 		metadata,
+		metadataURL,
 		key,
 		certificate,
 		secretCrypto,
@@ -932,6 +1007,7 @@ func (wm *InstanceSAMLIDPWriteModel) NewChangedEvent(
 		withSignedRequest,
 		nameIDFormat,
 		transientMappingAttributeName,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -983,6 +1059,8 @@ func (wm *InstanceIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event)
 			wm.IDPRemoveWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
 		case *instance.AppleIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.AppleIDPAddedEvent)
+		case *instance.KerberosIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.KerberosIDPAddedEvent)
 		case *instance.IDPRemovedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.RemovedEvent)
 		case *instance.IDPConfigAddedEvent:
@@ -1013,6 +1091,7 @@ func (wm *InstanceIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.GoogleIDPAddedEventType,
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
+			instance.KerberosIDPAddedEventType,
 			instance.SAMLIDPAddedEventType,
 			instance.IDPRemovedEventType,
 		).
@@ -1,11 +1,16 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
 	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
 	openid "github.com/zitadel/oidc/v3/pkg/oidc"
@@ -19,6 +24,7 @@ import (
 	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -249,6 +255,33 @@ func TestCommandSide_AddInstanceGenericOAuthIDP(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid claim mapping",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: GenericOAuthProvider{
+					Name:                  "name",
+					ClientID:              "clientID",
+					ClientSecret:          "clientSecret",
+					AuthorizationEndpoint: "auth",
+					TokenEndpoint:         "token",
+					UserEndpoint:          "user",
+					IDAttribute:           "idAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "email",
+					},
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", ""))
+				},
+			},
+		},
 		{
 			name: "ok",
 			fields: fields{
@@ -270,6 +303,7 @@ func TestCommandSide_AddInstanceGenericOAuthIDP(t *testing.T) {
 							"user",
 							"idAttribute",
 							nil,
+							nil,
 							idp.Options{},
 						),
 					),
@@ -315,6 +349,9 @@ func TestCommandSide_AddInstanceGenericOAuthIDP(t *testing.T) {
 							"user",
 							"idAttribute",
 							[]string{"user"},
+							&domain.IDPClaimMapping{
+								Email: "$.mail",
+							},
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -338,6 +375,9 @@ func TestCommandSide_AddInstanceGenericOAuthIDP(t *testing.T) {
 					UserEndpoint:          "user",
 					Scopes:                []string{"user"},
 					IDAttribute:           "idAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "$.mail",
+					},
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -569,6 +609,7 @@ func TestCommandSide_UpdateInstanceGenericOAuthIDP(t *testing.T) {
 								"user",
 								"idAttribute",
 								nil,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -611,6 +652,7 @@ func TestCommandSide_UpdateInstanceGenericOAuthIDP(t *testing.T) {
 								"user",
 								"idAttribute",
 								nil,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -633,6 +675,9 @@ func TestCommandSide_UpdateInstanceGenericOAuthIDP(t *testing.T) {
 									idp.ChangeOAuthUserEndpoint("new user"),
 									idp.ChangeOAuthScopes([]string{"openid", "profile"}),
 									idp.ChangeOAuthIDAttribute("newAttribute"),
+									idp.ChangeOAuthClaimMapping(&domain.IDPClaimMapping{
+										Email: "$.mail",
+									}),
 									idp.ChangeOAuthOptions(idp.OptionChanges{
 										IsCreationAllowed: &t,
 										IsLinkingAllowed:  &t,
@@ -659,6 +704,9 @@ func TestCommandSide_UpdateInstanceGenericOAuthIDP(t *testing.T) {
 					UserEndpoint:          "new user",
 					Scopes:                []string{"openid", "profile"},
 					IDAttribute:           "newAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "$.mail",
+					},
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -805,6 +853,7 @@ func TestCommandSide_AddInstanceGenericOIDCIDP(t *testing.T) {
 							},
 							nil,
 							false,
+							nil,
 							idp.Options{},
 						),
 					),
@@ -845,6 +894,7 @@ func TestCommandSide_AddInstanceGenericOIDCIDP(t *testing.T) {
 							},
 							[]string{openid.ScopeOpenID},
 							true,
+							nil,
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -1029,6 +1079,7 @@ func TestCommandSide_UpdateInstanceGenericOIDCIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1066,6 +1117,7 @@ func TestCommandSide_UpdateInstanceGenericOIDCIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1253,6 +1305,7 @@ func TestCommandSide_MigrateInstanceGenericOIDCToAzureADProvider(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1311,6 +1364,7 @@ func TestCommandSide_MigrateInstanceGenericOIDCToAzureADProvider(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1475,6 +1529,7 @@ func TestCommandSide_MigrateInstanceOIDCToGoogleIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1527,6 +1582,7 @@ func TestCommandSide_MigrateInstanceOIDCToGoogleIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -4055,9 +4111,10 @@ func TestCommandSide_UpdateInstanceGoogleIDP(t *testing.T) {
 
 func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 	type fields struct {
-		eventstore   func(*testing.T) *eventstore.Eventstore
-		idGenerator  id.Generator
-		secretCrypto crypto.EncryptionAlgorithm
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		secretCrypto    crypto.EncryptionAlgorithm
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx      context.Context
@@ -4237,6 +4294,34 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid group mapping",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BindDN:            "binddn",
+					BaseDN:            "baseDN",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					GroupMapping: &domain.LDAPGroupMapping{
+						Groups: []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kf3ns", ""))
+				},
+			},
+		},
 		{
 			name: "ok",
 			fields: fields{
@@ -4262,6 +4347,8 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 							time.Second*30,
 							[]byte("certificate"),
 							idp.LDAPAttributes{},
+							nil,
+							false,
 							idp.Options{},
 						),
 					),
@@ -4295,6 +4382,16 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 			fields: fields{
 				eventstore: expectEventstore(
 					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
 					expectPush(
 						instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 							"id1",
@@ -4329,6 +4426,11 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 								AvatarURLAttribute:         "avatarURL",
 								ProfileAttribute:           "profile",
 							},
+							&domain.LDAPGroupMapping{
+								GroupAttribute: "memberOf",
+								Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+							},
+							true,
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -4338,8 +4440,9 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 						),
 					),
 				),
-				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto:    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				checkPermission: newMockPermissionCheckAllowed(),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
@@ -4370,6 +4473,11 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 						AvatarURLAttribute:         "avatarURL",
 						ProfileAttribute:           "profile",
 					},
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+					DirectorySync: true,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -4383,6 +4491,79 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
 			},
 		},
+		{
+			name: "group mapping of unknown project, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BaseDN:            "baseDN",
+					BindDN:            "dn",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					Timeout:           time.Second * 30,
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "group mapping without permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BaseDN:            "baseDN",
+					BindDN:            "dn",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					Timeout:           time.Second * 30,
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4390,6 +4571,7 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 				eventstore:          tt.fields.eventstore(t),
 				idGenerator:         tt.fields.idGenerator,
 				idpConfigEncryption: tt.fields.secretCrypto,
+				checkPermission:     tt.fields.checkPermission,
 			}
 			id, got, err := c.AddInstanceLDAPProvider(tt.args.ctx, tt.args.provider)
 			if tt.res.err == nil {
@@ -4408,8 +4590,9 @@ func TestCommandSide_AddInstanceLDAPIDP(t *testing.T) {
 
 func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 	type fields struct {
-		eventstore   func(*testing.T) *eventstore.Eventstore
-		secretCrypto crypto.EncryptionAlgorithm
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		secretCrypto    crypto.EncryptionAlgorithm
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx      context.Context
@@ -4632,6 +4815,8 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 								time.Second*30,
 								[]byte("certificate"),
 								idp.LDAPAttributes{},
+								nil,
+								false,
 								idp.Options{},
 							)),
 					),
@@ -4682,9 +4867,21 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 								time.Second*30,
 								[]byte("certificate"),
 								idp.LDAPAttributes{},
+								nil,
+								false,
 								idp.Options{},
 							)),
 					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
 					expectPush(
 						func() eventstore.Command {
 							t := true
@@ -4706,6 +4903,11 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 									idp.ChangeLDAPUserObjectClasses([]string{"new object"}),
 									idp.ChangeLDAPUserFilters([]string{"new filter"}),
 									idp.ChangeLDAPTimeout(time.Second * 20),
+									idp.ChangeLDAPGroupMapping(&domain.LDAPGroupMapping{
+										GroupAttribute: "memberOf",
+										Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+									}),
+									idp.ChangeLDAPDirectorySync(true),
 									idp.ChangeLDAPAttributes(idp.LDAPAttributeChanges{
 										IDAttribute:                stringPointer("new id"),
 										FirstNameAttribute:         stringPointer("new firstName"),
@@ -4733,7 +4935,8 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 						}(),
 					),
 				),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				secretCrypto:    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				checkPermission: newMockPermissionCheckAllowed(),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
@@ -4765,6 +4968,11 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 						AvatarURLAttribute:         "new avatarURL",
 						ProfileAttribute:           "new profile",
 					},
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+					DirectorySync: true,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -4783,6 +4991,7 @@ func TestCommandSide_UpdateInstanceLDAPIDP(t *testing.T) {
 			c := &Commands{
 				eventstore:          tt.fields.eventstore(t),
 				idpConfigEncryption: tt.fields.secretCrypto,
+				checkPermission:     tt.fields.checkPermission,
 			}
 			got, err := c.UpdateInstanceLDAPProvider(tt.args.ctx, tt.args.id, tt.args.provider)
 			if tt.res.err == nil {
@@ -5246,16 +5455,16 @@ func TestCommandSide_UpdateInstanceAppleIDP(t *testing.T) {
 	}
 }
 
-func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
+func TestCommandSide_AddInstanceKerberosIDP(t *testing.T) {
+	keytab := kerberosTestKeytab(t)
 	type fields struct {
-		eventstore                 func(*testing.T) *eventstore.Eventstore
-		idGenerator                id.Generator
-		secretCrypto               crypto.EncryptionAlgorithm
-		certificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+		eventstore   func(*testing.T) *eventstore.Eventstore
+		idGenerator  id.Generator
+		secretCrypto crypto.EncryptionAlgorithm
 	}
 	type args struct {
 		ctx      context.Context
-		provider *SAMLProvider
+		provider KerberosProvider
 	}
 	type res struct {
 		id   string
@@ -5276,48 +5485,48 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 			},
 			args{
 				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{},
+				provider: KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-o07zjotgnd", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb4sn", "Errors.Invalid.Argument"))
 				},
 			},
 		},
 		{
-			"no metadata",
+			"missing keytab",
 			fields{
 				eventstore:  expectEventstore(),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
 			},
 			args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{
+				provider: KerberosProvider{
 					Name: "name",
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-3bi3esi16t", "Errors.Invalid.Argument"))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb5tw", "Errors.IDPConfig.KerberosKeytabMissing"))
 				},
 			},
 		},
 		{
-			"invalid metadata, error",
+			"invalid keytab",
 			fields{
 				eventstore:  expectEventstore(),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
 			},
 			args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: []byte("metadata"),
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: []byte("keytab"),
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat"))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb6ux", "Errors.IDPConfig.KerberosKeytabInvalid"))
 				},
 			},
 		},
@@ -5327,34 +5536,28 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(),
 					expectPush(
-						instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+						instance.NewKerberosIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 							"id1",
 							"name",
-							validSAMLMetadata,
+							"",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
 								KeyID:      "id",
-								Crypted:    []byte("key"),
+								Crypted:    keytab,
 							},
-							[]byte("certificate"),
-							"",
-							false,
-							nil,
-							"",
 							idp.Options{},
 						),
 					),
 				),
-				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
-				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: validSAMLMetadata,
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: keytab,
 				},
 			},
 			res: res{
@@ -5368,21 +5571,16 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(),
 					expectPush(
-						instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+						instance.NewKerberosIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 							"id1",
 							"name",
-							validSAMLMetadata,
+							"HTTP/login.example.com",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
 								KeyID:      "id",
-								Crypted:    []byte("key"),
+								Crypted:    keytab,
 							},
-							[]byte("certificate"),
-							"binding",
-							true,
-							gu.Ptr(domain.SAMLNameIDFormatTransient),
-							"customAttribute",
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -5392,19 +5590,15 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 						),
 					),
 				),
-				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
-				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{
-					Name:                          "name",
-					Metadata:                      validSAMLMetadata,
-					Binding:                       "binding",
-					WithSignedRequest:             true,
-					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
-					TransientMappingAttributeName: "customAttribute",
+				provider: KerberosProvider{
+					Name:             "name",
+					ServicePrincipal: " HTTP/login.example.com ",
+					Keytab:           keytab,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -5422,12 +5616,11 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore:                     tt.fields.eventstore(t),
-				idGenerator:                    tt.fields.idGenerator,
-				idpConfigEncryption:            tt.fields.secretCrypto,
-				samlCertificateAndKeyGenerator: tt.fields.certificateAndKeyGenerator,
+				eventstore:          tt.fields.eventstore(t),
+				idGenerator:         tt.fields.idGenerator,
+				idpConfigEncryption: tt.fields.secretCrypto,
 			}
-			id, got, err := c.AddInstanceSAMLProvider(tt.args.ctx, tt.args.provider)
+			id, got, err := c.AddInstanceKerberosProvider(tt.args.ctx, tt.args.provider)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -5442,7 +5635,8 @@ func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 	}
 }
 
-func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
+func TestCommandSide_UpdateInstanceKerberosIDP(t *testing.T) {
+	keytab := kerberosTestKeytab(t)
 	type fields struct {
 		eventstore   func(*testing.T) *eventstore.Eventstore
 		secretCrypto crypto.EncryptionAlgorithm
@@ -5450,7 +5644,7 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 	type args struct {
 		ctx      context.Context
 		id       string
-		provider *SAMLProvider
+		provider KerberosProvider
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -5469,11 +5663,11 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 			},
 			args{
 				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
-				provider: &SAMLProvider{},
+				provider: KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-7o3rq1owpm", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb7vy", "Errors.IDMissing"))
 				},
 			},
 		},
@@ -5485,48 +5679,30 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 			args{
 				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
 				id:       "id1",
-				provider: &SAMLProvider{},
-			},
-			res{
-				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-q2s9rak7o9", ""))
-				},
-			},
-		},
-		{
-			"no metadata",
-			fields{
-				eventstore: expectEventstore(),
-			},
-			args{
-				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				id:  "id1",
-				provider: &SAMLProvider{
-					Name: "name",
-				},
+				provider: KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-iw1rxnf4sf", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb8wz", "Errors.Invalid.Argument"))
 				},
 			},
 		},
 		{
-			"invalid metadata, error",
+			"invalid keytab",
 			fields{
 				eventstore: expectEventstore(),
 			},
 			args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
 				id:  "id1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: []byte("metadata"),
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: []byte("keytab"),
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-dsfj3kl2", "Errors.Project.App.SAMLMetadataFormat"))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Kb9xa", "Errors.IDPConfig.KerberosKeytabInvalid"))
 				},
 			},
 		},
@@ -5540,9 +5716,8 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
 				id:  "id1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: validSAMLMetadata,
+				provider: KerberosProvider{
+					Name: "name",
 				},
 			},
 			res: res{
@@ -5555,21 +5730,16 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(
 						eventFromEventPusher(
-							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							instance.NewKerberosIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 								"id1",
 								"name",
-								validSAMLMetadata,
+								"",
 								&crypto.CryptoValue{
 									CryptoType: crypto.TypeEncryption,
 									Algorithm:  "enc",
 									KeyID:      "id",
-									Crypted:    []byte("key"),
+									Crypted:    keytab,
 								},
-								[]byte("certificate"),
-								"",
-								false,
-								nil,
-								"",
 								idp.Options{},
 							)),
 					),
@@ -5578,9 +5748,8 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
 				id:  "id1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: validSAMLMetadata,
+				provider: KerberosProvider{
+					Name: "name",
 				},
 			},
 			res: res{
@@ -5593,37 +5762,34 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(
 						eventFromEventPusher(
-							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							instance.NewKerberosIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 								"id1",
 								"name",
-								[]byte("metadata"),
+								"",
 								&crypto.CryptoValue{
 									CryptoType: crypto.TypeEncryption,
 									Algorithm:  "enc",
 									KeyID:      "id",
-									Crypted:    []byte("key"),
+									Crypted:    []byte("keytab"),
 								},
-								[]byte("certificate"),
-								"binding",
-								false,
-								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
-								"",
 								idp.Options{},
 							)),
 					),
 					expectPush(
 						func() eventstore.Command {
 							t := true
-							event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							event, _ := instance.NewKerberosIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
 								"id1",
-								[]idp.SAMLIDPChanges{
-									idp.ChangeSAMLName("new name"),
-									idp.ChangeSAMLMetadata(validSAMLMetadata),
-									idp.ChangeSAMLBinding("new binding"),
-									idp.ChangeSAMLWithSignedRequest(true),
-									idp.ChangeSAMLNameIDFormat(gu.Ptr(domain.SAMLNameIDFormatTransient)),
-									idp.ChangeSAMLTransientMappingAttributeName("customAttribute"),
-									idp.ChangeSAMLOptions(idp.OptionChanges{
+								[]idp.KerberosIDPChanges{
+									idp.ChangeKerberosKeytab(&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    keytab,
+									}),
+									idp.ChangeKerberosName("new name"),
+									idp.ChangeKerberosServicePrincipal("HTTP/login.example.com"),
+									idp.ChangeKerberosOptions(idp.OptionChanges{
 										IsCreationAllowed: &t,
 										IsLinkingAllowed:  &t,
 										IsAutoCreation:    &t,
@@ -5640,13 +5806,10 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
 				id:  "id1",
-				provider: &SAMLProvider{
-					Name:                          "new name",
-					Metadata:                      validSAMLMetadata,
-					Binding:                       "new binding",
-					WithSignedRequest:             true,
-					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
-					TransientMappingAttributeName: "customAttribute",
+				provider: KerberosProvider{
+					Name:             "new name",
+					ServicePrincipal: "HTTP/login.example.com",
+					Keytab:           keytab,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -5666,7 +5829,7 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 				eventstore:          tt.fields.eventstore(t),
 				idpConfigEncryption: tt.fields.secretCrypto,
 			}
-			got, err := c.UpdateInstanceSAMLProvider(tt.args.ctx, tt.args.id, tt.args.provider)
+			got, err := c.UpdateInstanceKerberosProvider(tt.args.ctx, tt.args.id, tt.args.provider)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -5680,17 +5843,33 @@ func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
 	}
 }
 
-func TestCommandSide_RegenerateInstanceSAMLProviderCertificate(t *testing.T) {
+// kerberosTestKeytab returns a valid keytab containing a single key for HTTP/login.example.com
+func kerberosTestKeytab(t *testing.T) []byte {
+	kt := keytab.New()
+	err := kt.AddEntry("HTTP/login.example.com", "EXAMPLE.COM", "password", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := kt.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 	type fields struct {
 		eventstore                 func(*testing.T) *eventstore.Eventstore
+		idGenerator                id.Generator
 		secretCrypto               crypto.EncryptionAlgorithm
 		certificateAndKeyGenerator func(id string) ([]byte, []byte, error)
 	}
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx      context.Context
+		provider *SAMLProvider
 	}
 	type res struct {
+		id   string
 		want *domain.ObjectDetails
 		err  func(error) bool
 	}
@@ -5701,86 +5880,156 @@ func TestCommandSide_RegenerateInstanceSAMLProviderCertificate(t *testing.T) {
 		res    res
 	}{
 		{
-			"invalid id",
+			"invalid name",
 			fields{
-				eventstore: expectEventstore(),
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				provider: &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-o07zjotgnd", ""))
+				},
+			},
+		},
+		{
+			"no metadata",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
 			},
 			args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: &SAMLProvider{
+					Name: "name",
+				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-7de108gqya", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-3bi3esi16t", "Errors.Invalid.Argument"))
 				},
 			},
 		},
 		{
-			name: "not found",
+			"invalid metadata, error",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: []byte("metadata"),
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat"))
+				},
+			},
+		},
+		{
+			name: "ok",
 			fields: fields{
 				eventstore: expectEventstore(
 					expectFilter(),
+					expectPush(
+						instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							"name",
+							validSAMLMetadata,
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+							[]byte("certificate"),
+							"",
+							false,
+							nil,
+							"",
+							nil,
+							idp.Options{},
+						),
+					),
 				),
+				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				id:  "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: validSAMLMetadata,
+				},
 			},
 			res: res{
-				err: zerrors.IsNotFound,
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
 			},
 		},
 		{
-			name: "change ok",
+			name: "ok all set",
 			fields: fields{
 				eventstore: expectEventstore(
-					expectFilter(
-						eventFromEventPusher(
-							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
-								"id1",
-								"name",
-								[]byte("metadata"),
-								&crypto.CryptoValue{
-									CryptoType: crypto.TypeEncryption,
-									Algorithm:  "enc",
-									KeyID:      "id",
-									Crypted:    []byte("key"),
-								},
-								[]byte("certificate"),
-								"binding",
-								false,
-								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
-								"",
-								idp.Options{},
-							)),
-					),
+					expectFilter(),
 					expectPush(
-						func() eventstore.Command {
-							event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
-								"id1",
-								[]idp.SAMLIDPChanges{
-									idp.ChangeSAMLKey(&crypto.CryptoValue{
-										CryptoType: crypto.TypeEncryption,
-										Algorithm:  "enc",
-										KeyID:      "id",
-										Crypted:    []byte("new key"),
-									}),
-									idp.ChangeSAMLCertificate([]byte("new certificate")),
-								},
-							)
-							return event
-						}(),
+						instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							"name",
+							validSAMLMetadata,
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+							[]byte("certificate"),
+							"binding",
+							true,
+							gu.Ptr(domain.SAMLNameIDFormatTransient),
+							"customAttribute",
+							nil,
+							idp.Options{
+								IsCreationAllowed: true,
+								IsLinkingAllowed:  true,
+								IsAutoCreation:    true,
+								IsAutoUpdate:      true,
+							},
+						),
 					),
 				),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
-				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) {
-					return []byte("new key"), []byte("new certificate"), nil
-				},
+				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "instance1"),
-				id:  "id1",
+				provider: &SAMLProvider{
+					Name:                          "name",
+					Metadata:                      validSAMLMetadata,
+					Binding:                       "binding",
+					WithSignedRequest:             true,
+					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
+					TransientMappingAttributeName: "customAttribute",
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
 			},
 			res: res{
+				id:   "id1",
 				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
 			},
 		},
@@ -5789,10 +6038,844 @@ func TestCommandSide_RegenerateInstanceSAMLProviderCertificate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
 				eventstore:                     tt.fields.eventstore(t),
+				idGenerator:                    tt.fields.idGenerator,
 				idpConfigEncryption:            tt.fields.secretCrypto,
 				samlCertificateAndKeyGenerator: tt.fields.certificateAndKeyGenerator,
 			}
-			got, err := c.RegenerateInstanceSAMLProviderCertificate(tt.args.ctx, tt.args.id)
+			id, got, err := c.AddInstanceSAMLProvider(tt.args.ctx, tt.args.provider)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_UpdateInstanceGenericSAMLIDP(t *testing.T) {
+	type fields struct {
+		eventstore   func(*testing.T) *eventstore.Eventstore
+		secretCrypto crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		provider *SAMLProvider
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				provider: &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-7o3rq1owpm", ""))
+				},
+			},
+		},
+		{
+			"invalid name",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				id:       "id1",
+				provider: &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-q2s9rak7o9", ""))
+				},
+			},
+		},
+		{
+			"no metadata",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: &SAMLProvider{
+					Name: "name",
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-iw1rxnf4sf", ""))
+				},
+			},
+		},
+		{
+			"invalid metadata, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: []byte("metadata"),
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-dsfj3kl2", "Errors.Project.App.SAMLMetadataFormat"))
+				},
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: validSAMLMetadata,
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								validSAMLMetadata,
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("key"),
+								},
+								[]byte("certificate"),
+								"",
+								false,
+								nil,
+								"",
+								nil,
+								idp.Options{},
+							)),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: validSAMLMetadata,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "change ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								[]byte("metadata"),
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("key"),
+								},
+								[]byte("certificate"),
+								"binding",
+								false,
+								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
+								"",
+								nil,
+								idp.Options{},
+							)),
+					),
+					expectPush(
+						func() eventstore.Command {
+							t := true
+							event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								[]idp.SAMLIDPChanges{
+									idp.ChangeSAMLName("new name"),
+									idp.ChangeSAMLMetadata(validSAMLMetadata),
+									idp.ChangeSAMLBinding("new binding"),
+									idp.ChangeSAMLWithSignedRequest(true),
+									idp.ChangeSAMLNameIDFormat(gu.Ptr(domain.SAMLNameIDFormatTransient)),
+									idp.ChangeSAMLTransientMappingAttributeName("customAttribute"),
+									idp.ChangeSAMLOptions(idp.OptionChanges{
+										IsCreationAllowed: &t,
+										IsLinkingAllowed:  &t,
+										IsAutoCreation:    &t,
+										IsAutoUpdate:      &t,
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: &SAMLProvider{
+					Name:                          "new name",
+					Metadata:                      validSAMLMetadata,
+					Binding:                       "new binding",
+					WithSignedRequest:             true,
+					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
+					TransientMappingAttributeName: "customAttribute",
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: tt.fields.secretCrypto,
+			}
+			got, err := c.UpdateInstanceSAMLProvider(tt.args.ctx, tt.args.id, tt.args.provider)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RegenerateInstanceSAMLProviderCertificate(t *testing.T) {
+	type fields struct {
+		eventstore                 func(*testing.T) *eventstore.Eventstore
+		secretCrypto               crypto.EncryptionAlgorithm
+		certificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-7de108gqya", ""))
+				},
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "change ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								[]byte("metadata"),
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("key"),
+								},
+								[]byte("certificate"),
+								"binding",
+								false,
+								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
+								"",
+								nil,
+								idp.Options{},
+							)),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								[]idp.SAMLIDPChanges{
+									idp.ChangeSAMLKey(&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("new key"),
+									}),
+									idp.ChangeSAMLCertificate([]byte("new certificate")),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) {
+					return []byte("new key"), []byte("new certificate"), nil
+				},
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                     tt.fields.eventstore(t),
+				idpConfigEncryption:            tt.fields.secretCrypto,
+				samlCertificateAndKeyGenerator: tt.fields.certificateAndKeyGenerator,
+			}
+			got, err := c.RegenerateInstanceSAMLProviderCertificate(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func instanceSAMLIDPAddedEvent(metadata []byte, metadataURL string) *instance.SAMLIDPAddedEvent {
+	return instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		metadata,
+		metadataURL,
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("key"),
+		},
+		[]byte("certificate"),
+		"",
+		false,
+		nil,
+		"",
+		nil,
+		idp.Options{},
+	)
+}
+
+func instanceSAMLIDPChangedEvent(changes ...idp.SAMLIDPChanges) *instance.SAMLIDPChangedEvent {
+	event, _ := instance.NewSAMLIDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", changes)
+	return event
+}
+
+func TestCommandSide_AddInstanceSAMLProviderKey(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		keyID string
+		want  *domain.ObjectDetails
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid id",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-Xk2ma", ""))
+				},
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "add ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+						eventFromEventPusher(instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+								{ID: "key0", Key: &crypto.CryptoValue{Crypted: []byte("key0")}, Certificate: []byte("certificate0")},
+							}),
+						)),
+					),
+					expectPush(
+						instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+								{ID: "key0", Key: &crypto.CryptoValue{Crypted: []byte("key0")}, Certificate: []byte("certificate0")},
+								{
+									ID: "key1",
+									Key: &crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("new key"),
+									},
+									Certificate: []byte("new certificate"),
+								},
+							}),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				keyID: "key1",
+				want:  &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idGenerator:         tt.fields.idGenerator,
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				samlCertificateAndKeyGenerator: func(id string) ([]byte, []byte, error) {
+					return []byte("new key"), []byte("new certificate"), nil
+				},
+			}
+			keyID, got, err := c.AddInstanceSAMLProviderKey(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.keyID, keyID)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ActivateInstanceSAMLProviderKey(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx   context.Context
+		id    string
+		keyID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "key not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key0"),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				keyID: "key1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Wd4qv", "Errors.IDPConfig.SAMLKeyNotExisting"))
+				},
+			},
+		},
+		{
+			name: "activate ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+						eventFromEventPusher(instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+								{ID: "key1", Key: &crypto.CryptoValue{Crypted: []byte("key1")}, Certificate: []byte("certificate1")},
+							}),
+						)),
+					),
+					expectPush(
+						instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLKey(&crypto.CryptoValue{Crypted: []byte("key1")}),
+							idp.ChangeSAMLCertificate([]byte("certificate1")),
+							idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+								{
+									ID: "key0",
+									Key: &crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("key"),
+									},
+									Certificate: []byte("certificate"),
+								},
+							}),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key0"),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				keyID: "key1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.ActivateInstanceSAMLProviderKey(tt.args.ctx, tt.args.id, tt.args.keyID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveInstanceSAMLProviderKey(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		id    string
+		keyID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "key not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				keyID: "key1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Tq8zd", "Errors.IDPConfig.SAMLKeyNotExisting"))
+				},
+			},
+		},
+		{
+			name: "remove ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+						eventFromEventPusher(instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+								{ID: "key1", Key: &crypto.CryptoValue{Crypted: []byte("key1")}, Certificate: []byte("certificate1")},
+							}),
+						)),
+					),
+					expectPush(
+						instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLAdditionalKeys(nil),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				keyID: "key1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.RemoveInstanceSAMLProviderKey(tt.args.ctx, tt.args.id, tt.args.keyID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RefreshInstanceSAMLProviderMetadata(t *testing.T) {
+	renewedMetadata := bytes.Replace(validSAMLMetadata, []byte(`<EntityDescriptor `), []byte(`<EntityDescriptor validUntil="2030-01-01T00:00:00Z" `), 1)
+	changedMetadata := bytes.Replace(validSAMLMetadata, []byte(`http://localhost:8080/saml/v2/SSO`), []byte(`http://localhost:8080/saml/v3/SSO`), -1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/renewed":
+			_, _ = w.Write(renewedMetadata)
+		case "/changed":
+			_, _ = w.Write(changedMetadata)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no metadata url",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, "")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "metadata unavailable",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, server.URL+"/missing")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: zerrors.IsUnavailable,
+			},
+		},
+		{
+			name: "only validity renewed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, server.URL+"/renewed")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "metadata changed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent(validSAMLMetadata, server.URL+"/changed")),
+					),
+					expectPush(
+						instanceSAMLIDPChangedEvent(
+							idp.ChangeSAMLMetadata(changedMetadata),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+				httpClient: server.Client(),
+			}
+			got, err := c.RefreshInstanceSAMLProviderMetadata(tt.args.ctx, tt.args.id)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
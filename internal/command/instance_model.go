@@ -81,6 +81,16 @@ func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotName implements [eventstore.SnapshotReducer]
+func (wm *InstanceWriteModel) SnapshotName() string {
+	return "instance"
+}
+
+// SnapshotRevision implements [eventstore.SnapshotReducer]
+func (wm *InstanceWriteModel) SnapshotRevision() uint16 {
+	return 1
+}
+
 func InstanceAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return &eventstore.Aggregate{
 		ID:            wm.AggregateID,
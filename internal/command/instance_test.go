@@ -49,6 +49,7 @@ func projectAddedEvents(ctx context.Context, instanceID, orgID, id, owner string
 			false,
 			false,
 			domain.PrivateLabelingSettingUnspecified,
+			nil,
 		),
 		instance.NewIAMProjectSetEvent(ctx,
 			&instance.NewAggregate(instanceID).Aggregate,
@@ -125,6 +126,9 @@ func oidcAppEvents(ctx context.Context, orgID, projectID, id, name, clientID str
 			"",
 			domain.LoginVersionUnspecified,
 			"",
+			false,
+			false,
+			"",
 		),
 	}
 }
@@ -440,6 +444,9 @@ func generatedDomainFilters(instanceID, orgID, projectID, appID, generatedDomain
 				"",
 				domain.LoginVersionUnspecified,
 				"",
+				false,
+				false,
+				"",
 			),
 		),
 		expectFilter(
@@ -2,9 +2,15 @@ package command
 
 import (
 	"context"
+	"maps"
+	"slices"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 func (c *Commands) BackChannelLogoutSent(ctx context.Context, id, oidcSessionID, instanceID string) (err error) {
@@ -22,3 +28,178 @@ func (c *Commands) BackChannelLogoutSent(ctx context.Context, id, oidcSessionID,
 		sessionlogout.NewBackChannelLogoutSentEvent(ctx, sessionWriteModel.aggregate, oidcSessionID),
 	)
 }
+
+func (c *Commands) SAMLLogoutSent(ctx context.Context, id, samlSessionID, instanceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel := NewSAMLSessionLogoutWriteModel(id, instanceID, samlSessionID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return err
+	}
+
+	return c.pushAppendAndReduce(
+		ctx,
+		sessionWriteModel,
+		sessionlogout.NewSAMLLogoutSentEvent(ctx, sessionWriteModel.aggregate, samlSessionID),
+	)
+}
+
+// SAMLLogoutFailed marks the single logout of the saml session as failed,
+// so no further LogoutRequest will be sent to the service provider.
+func (c *Commands) SAMLLogoutFailed(ctx context.Context, id, samlSessionID, instanceID, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel := NewSAMLSessionLogoutWriteModel(id, instanceID, samlSessionID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return err
+	}
+
+	return c.pushAppendAndReduce(
+		ctx,
+		sessionWriteModel,
+		sessionlogout.NewSAMLLogoutFailedEvent(ctx, sessionWriteModel.aggregate, samlSessionID, reason),
+	)
+}
+
+// SAMLFrontChannelLogout contains the LogoutRequest of a service provider sent through the user agent,
+// so the logout can be propagated through the user agent to the other service providers of the session.
+type SAMLFrontChannelLogout struct {
+	UserAgentID  string
+	InResponseTo string
+	ResponseURL  string
+	Binding      string
+	RelayState   string
+	// Supported returns if a LogoutRequest can be sent to the service provider (entityID) through the user agent.
+	Supported func(entityID string) bool
+}
+
+// SAMLLogoutRequested terminates the sessions of the user (nameID), which are registered for single logout at the service provider (entityID).
+// If sessionIndexes are provided, only the sessions with the corresponding session index are terminated.
+// The saml sessions of the requesting service provider are marked as requested, so no LogoutRequest will be sent back to it.
+// False is returned if no session is registered, e.g. if the user did not authenticate through a session (login V1).
+//
+// If frontChannel is provided, the logout of the other service providers of the first session, which support it,
+// is started to be requested through the user agent and returned. They will not be notified through the back-channel.
+func (c *Commands) SAMLLogoutRequested(ctx context.Context, entityID, nameID string, sessionIndexes []string, frontChannel *SAMLFrontChannelLogout) (found bool, _ *SAMLFrontChannelLogoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if entityID == "" || nameID == "" {
+		return false, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-p4lq8c0n2x", "Errors.SAMLSession.LogoutInvalid")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	registrations := NewSAMLLogoutRegistrationsWriteModel(instanceID, entityID, nameID, sessionIndexes)
+	if err = c.eventstore.FilterToQueryReducer(ctx, registrations); err != nil {
+		return false, nil, err
+	}
+	if len(registrations.Sessions) == 0 {
+		return false, nil, nil
+	}
+	sessionIDs := slices.Sorted(maps.Keys(registrations.Sessions))
+	cmds := make([]eventstore.Command, 0, len(registrations.Sessions)*2)
+	var chain *SAMLFrontChannelLogoutWriteModel
+	for _, sessionID := range sessionIDs {
+		samlSessionIDs := registrations.Sessions[sessionID]
+		sessionWriteModel := NewSessionWriteModel(sessionID, instanceID)
+		if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+			return false, nil, err
+		}
+		if sessionWriteModel.CheckIsActive() != nil {
+			continue
+		}
+		logoutAggregate := &sessionlogout.NewAggregate(sessionID, instanceID).Aggregate
+		for _, samlSessionID := range samlSessionIDs {
+			cmds = append(cmds, sessionlogout.NewSAMLLogoutRequestedEvent(ctx, logoutAggregate, samlSessionID))
+		}
+		if frontChannel != nil && chain == nil {
+			chain, err = c.startSAMLFrontChannelLogout(ctx, logoutAggregate, entityID, samlSessionIDs, frontChannel)
+			if err != nil {
+				return false, nil, err
+			}
+			if chain != nil {
+				cmds = append(cmds, chain.startedEvent(ctx, logoutAggregate))
+			}
+		}
+		cmds = append(cmds, session.NewTerminateEvent(ctx, &session.NewAggregate(sessionWriteModel.AggregateID, sessionWriteModel.ResourceOwner).Aggregate))
+	}
+	if len(cmds) == 0 {
+		return true, nil, nil
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return true, nil, err
+	}
+	return true, chain, nil
+}
+
+// startSAMLFrontChannelLogout returns the LogoutRequests to send through the user agent
+// to the other service providers of the session or nil if there are none.
+func (c *Commands) startSAMLFrontChannelLogout(ctx context.Context, aggregate *eventstore.Aggregate, entityID string, requestedSAMLSessionIDs []string, frontChannel *SAMLFrontChannelLogout) (*SAMLFrontChannelLogoutWriteModel, error) {
+	chain := NewSAMLFrontChannelLogoutWriteModel(aggregate.ID, aggregate.InstanceID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, chain); err != nil {
+		return nil, err
+	}
+	for _, request := range chain.pending() {
+		if slices.Contains(requestedSAMLSessionIDs, request.SAMLSessionID) || !frontChannel.Supported(request.EntityID) {
+			continue
+		}
+		id, err := c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+		// saml IDs must not start with a digit
+		request.RequestID = "_" + id
+		chain.Requests = append(chain.Requests, request)
+	}
+	if len(chain.Requests) == 0 {
+		return nil, nil
+	}
+	chain.UserAgentID = frontChannel.UserAgentID
+	chain.EntityID = entityID
+	chain.InResponseTo = frontChannel.InResponseTo
+	chain.ResponseURL = frontChannel.ResponseURL
+	chain.Binding = frontChannel.Binding
+	chain.RelayState = frontChannel.RelayState
+	return chain, nil
+}
+
+func (wm *SAMLFrontChannelLogoutWriteModel) startedEvent(ctx context.Context, aggregate *eventstore.Aggregate) eventstore.Command {
+	requests := make([]*sessionlogout.SAMLFrontChannelLogoutRequest, len(wm.Requests))
+	for i, request := range wm.Requests {
+		requests[i] = &sessionlogout.SAMLFrontChannelLogoutRequest{
+			SAMLSessionID: request.SAMLSessionID,
+			RequestID:     request.RequestID,
+		}
+	}
+	return sessionlogout.NewSAMLFrontChannelLogoutStartedEvent(ctx, aggregate, wm.UserAgentID, wm.EntityID, wm.InResponseTo, wm.ResponseURL, wm.Binding, wm.RelayState, requests)
+}
+
+// SAMLFrontChannelLogoutResponded records the response of the service provider (entityID) to the LogoutRequest (requestID)
+// sent through the user agent for the session (id). A non-empty failure marks the logout as failed.
+// The updated state is returned to continue with the next LogoutRequest or the LogoutResponse to the requesting service provider.
+func (c *Commands) SAMLFrontChannelLogoutResponded(ctx context.Context, id, userAgentID, entityID, requestID, failure string) (_ *SAMLFrontChannelLogoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	chain := NewSAMLFrontChannelLogoutWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, chain); err != nil {
+		return nil, err
+	}
+	if chain.UserAgentID == "" || chain.UserAgentID != userAgentID {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xo4bQ", "Errors.SAMLSession.LogoutInvalid")
+	}
+	request := chain.Next()
+	if request == nil || request.RequestID != requestID || request.EntityID != entityID {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-m2RfE", "Errors.SAMLSession.LogoutInvalid")
+	}
+	aggregate := &sessionlogout.NewAggregate(chain.AggregateID, chain.InstanceID).Aggregate
+	var cmd eventstore.Command = sessionlogout.NewSAMLLogoutSentEvent(ctx, aggregate, request.SAMLSessionID)
+	if failure != "" {
+		cmd = sessionlogout.NewSAMLLogoutFailedEvent(ctx, aggregate, request.SAMLSessionID, failure)
+	}
+	if err = c.pushAppendAndReduce(ctx, chain, cmd); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"slices"
+
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
 )
@@ -72,3 +74,236 @@ func (wm *SessionLogoutWriteModel) reduceSent(e *sessionlogout.BackChannelLogout
 	}
 	wm.BackChannelLogoutSent = true
 }
+
+type SAMLSessionLogoutWriteModel struct {
+	eventstore.WriteModel
+
+	UserID        string
+	SAMLSessionID string
+	EntityID      string
+	LogoutSent    bool
+	LogoutFailed  bool
+
+	aggregate *eventstore.Aggregate
+}
+
+func NewSAMLSessionLogoutWriteModel(id string, instanceID string, samlSessionID string) *SAMLSessionLogoutWriteModel {
+	return &SAMLSessionLogoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+		aggregate:     &sessionlogout.NewAggregate(id, instanceID).Aggregate,
+		SAMLSessionID: samlSessionID,
+	}
+}
+
+func (wm *SAMLSessionLogoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *sessionlogout.SAMLLogoutRegisteredEvent:
+			wm.UserID = e.UserID
+			wm.EntityID = e.EntityID
+		case *sessionlogout.SAMLLogoutSentEvent:
+			wm.LogoutSent = true
+		case *sessionlogout.SAMLLogoutFailedEvent:
+			wm.LogoutFailed = true
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLSessionLogoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(sessionlogout.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			sessionlogout.SAMLLogoutRegisteredType,
+			sessionlogout.SAMLLogoutSentType,
+			sessionlogout.SAMLLogoutFailedType,
+		).
+		EventData(map[string]interface{}{
+			"saml_session_id": wm.SAMLSessionID,
+		}).
+		Builder()
+}
+
+// SAMLLogoutRegistrationsWriteModel collects the saml sessions of a user (nameID) at a service provider (entityID),
+// which are registered for single logout, grouped by their session.
+type SAMLLogoutRegistrationsWriteModel struct {
+	eventstore.WriteModel
+
+	entityID       string
+	nameID         string
+	sessionIndexes []string
+
+	// Sessions maps the session IDs to the IDs of their saml sessions
+	Sessions map[string][]string
+}
+
+func NewSAMLLogoutRegistrationsWriteModel(instanceID, entityID, nameID string, sessionIndexes []string) *SAMLLogoutRegistrationsWriteModel {
+	return &SAMLLogoutRegistrationsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+		entityID:       entityID,
+		nameID:         nameID,
+		sessionIndexes: sessionIndexes,
+		Sessions:       make(map[string][]string),
+	}
+}
+
+func (wm *SAMLLogoutRegistrationsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		e, ok := event.(*sessionlogout.SAMLLogoutRegisteredEvent)
+		if !ok || e.EntityID != wm.entityID || e.NameID != wm.nameID {
+			continue
+		}
+		if len(wm.sessionIndexes) > 0 && !slices.Contains(wm.sessionIndexes, e.SessionIndex) {
+			continue
+		}
+		wm.Sessions[e.Aggregate().ID] = append(wm.Sessions[e.Aggregate().ID], e.SAMLSessionID)
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLLogoutRegistrationsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(sessionlogout.AggregateType).
+		EventTypes(sessionlogout.SAMLLogoutRegisteredType).
+		EventData(map[string]interface{}{
+			"entity_id": wm.entityID,
+			"name_id":   wm.nameID,
+		}).
+		Builder()
+}
+
+// SAMLFrontChannelLogoutWriteModel is the state of the saml sessions of a session,
+// which are logged out through the user agent.
+type SAMLFrontChannelLogoutWriteModel struct {
+	eventstore.WriteModel
+
+	// the fields of the LogoutRequest of the service provider, which started the logout
+	UserAgentID  string
+	EntityID     string
+	InResponseTo string
+	ResponseURL  string
+	Binding      string
+	RelayState   string
+
+	// Requests are the LogoutRequests sent through the user agent in order
+	Requests []*SAMLFrontChannelLogoutRequest
+
+	sessions map[string]*SAMLFrontChannelLogoutRequest
+	order    []string
+}
+
+// SAMLFrontChannelLogoutRequest is the LogoutRequest for a saml session.
+// The RequestID is only set if the request is sent through the user agent.
+type SAMLFrontChannelLogoutRequest struct {
+	SAMLSessionID string
+	RequestID     string
+	EntityID      string
+	Issuer        string
+	NameID        string
+	NameIDFormat  string
+	SessionIndex  string
+	Done          bool
+}
+
+func NewSAMLFrontChannelLogoutWriteModel(id, instanceID string) *SAMLFrontChannelLogoutWriteModel {
+	return &SAMLFrontChannelLogoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+		sessions: make(map[string]*SAMLFrontChannelLogoutRequest),
+	}
+}
+
+func (wm *SAMLFrontChannelLogoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *sessionlogout.SAMLLogoutRegisteredEvent:
+			wm.sessions[e.SAMLSessionID] = &SAMLFrontChannelLogoutRequest{
+				SAMLSessionID: e.SAMLSessionID,
+				EntityID:      e.EntityID,
+				Issuer:        e.Issuer,
+				NameID:        e.NameID,
+				NameIDFormat:  e.NameIDFormat,
+				SessionIndex:  e.SessionIndex,
+			}
+			wm.order = append(wm.order, e.SAMLSessionID)
+		case *sessionlogout.SAMLLogoutRequestedEvent:
+			wm.done(e.SAMLSessionID)
+		case *sessionlogout.SAMLLogoutSentEvent:
+			wm.done(e.SAMLSessionID)
+		case *sessionlogout.SAMLLogoutFailedEvent:
+			wm.done(e.SAMLSessionID)
+		case *sessionlogout.SAMLFrontChannelLogoutStartedEvent:
+			wm.UserAgentID = e.UserAgentID
+			wm.EntityID = e.EntityID
+			wm.InResponseTo = e.InResponseTo
+			wm.ResponseURL = e.ResponseURL
+			wm.Binding = e.Binding
+			wm.RelayState = e.RelayState
+			wm.Requests = make([]*SAMLFrontChannelLogoutRequest, 0, len(e.Requests))
+			for _, request := range e.Requests {
+				if session, ok := wm.sessions[request.SAMLSessionID]; ok {
+					session.RequestID = request.RequestID
+					wm.Requests = append(wm.Requests, session)
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLFrontChannelLogoutWriteModel) done(samlSessionID string) {
+	if session, ok := wm.sessions[samlSessionID]; ok {
+		session.Done = true
+	}
+}
+
+func (wm *SAMLFrontChannelLogoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(sessionlogout.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			sessionlogout.SAMLLogoutRegisteredType,
+			sessionlogout.SAMLLogoutRequestedType,
+			sessionlogout.SAMLLogoutSentType,
+			sessionlogout.SAMLLogoutFailedType,
+			sessionlogout.SAMLFrontChannelLogoutStartedType,
+		).
+		Builder()
+}
+
+// pending returns the saml sessions, which were neither logged out nor requested through the user agent.
+func (wm *SAMLFrontChannelLogoutWriteModel) pending() []*SAMLFrontChannelLogoutRequest {
+	pending := make([]*SAMLFrontChannelLogoutRequest, 0, len(wm.order))
+	for _, samlSessionID := range wm.order {
+		if session := wm.sessions[samlSessionID]; !session.Done && session.RequestID == "" {
+			pending = append(pending, session)
+		}
+	}
+	return pending
+}
+
+// Next returns the next LogoutRequest to send through the user agent
+// or nil if all service providers responded.
+func (wm *SAMLFrontChannelLogoutWriteModel) Next() *SAMLFrontChannelLogoutRequest {
+	for _, request := range wm.Requests {
+		if !request.Done {
+			return request
+		}
+	}
+	return nil
+}
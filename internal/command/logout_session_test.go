@@ -0,0 +1,338 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SAMLLogoutRequested(t *testing.T) {
+	registered := func(sessionID, samlSessionID, sessionIndex string) eventstore.Event {
+		return eventFromEventPusher(
+			sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), &sessionlogout.NewAggregate(sessionID, "instanceID").Aggregate,
+				samlSessionID, "userID", "entityID", "issuer", "user@example.com", "", sessionIndex),
+		)
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		entityID       string
+		nameID         string
+		sessionIndexes []string
+		frontChannel   *SAMLFrontChannelLogout
+	}
+	type res struct {
+		found    bool
+		requests []string
+		err      error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing nameID, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				entityID: "entityID",
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-p4lq8c0n2x", "Errors.SAMLSession.LogoutInvalid"),
+			},
+		},
+		{
+			"no registration, not found",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				entityID: "entityID",
+				nameID:   "user@example.com",
+			},
+			res{},
+		},
+		{
+			"session already terminated, found",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						registered("sessionID", "V2_samlSessionID", "sessionIndex"),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate, &domain.UserAgent{}),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate),
+						),
+					),
+				),
+			},
+			args{
+				entityID: "entityID",
+				nameID:   "user@example.com",
+			},
+			res{
+				found: true,
+			},
+		},
+		{
+			"other session index, not found",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						registered("sessionID", "V2_samlSessionID", "sessionIndex"),
+					),
+				),
+			},
+			args{
+				entityID:       "entityID",
+				nameID:         "user@example.com",
+				sessionIndexes: []string{"otherSessionIndex"},
+			},
+			res{},
+		},
+		{
+			"active session, terminated",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						registered("sessionID", "V2_samlSessionID", "sessionIndex"),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate, &domain.UserAgent{}),
+						),
+					),
+					expectPush(
+						sessionlogout.NewSAMLLogoutRequestedEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate, "V2_samlSessionID"),
+						session.NewTerminateEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				entityID:       "entityID",
+				nameID:         "user@example.com",
+				sessionIndexes: []string{"sessionIndex"},
+			},
+			res{
+				found: true,
+			},
+		},
+		{
+			"active session, front-channel started",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						registered("sessionID", "V2_samlSessionID", "sessionIndex"),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate, &domain.UserAgent{}),
+						),
+					),
+					expectFilter(
+						registered("sessionID", "V2_samlSessionID", "sessionIndex"),
+						eventFromEventPusher(
+							sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate,
+								"V2_samlSessionID2", "userID", "entityID2", "issuer", "user@example.com", "", "sessionIndex2"),
+						),
+						eventFromEventPusher(
+							sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate,
+								"V2_samlSessionID3", "userID", "soapOnly", "issuer", "user@example.com", "", "sessionIndex3"),
+						),
+					),
+					expectPush(
+						sessionlogout.NewSAMLLogoutRequestedEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate, "V2_samlSessionID"),
+						sessionlogout.NewSAMLFrontChannelLogoutStartedEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate,
+							"userAgentID", "entityID", "requestID", "https://sp.example.com/slo", "binding", "relayState",
+							[]*sessionlogout.SAMLFrontChannelLogoutRequest{{SAMLSessionID: "V2_samlSessionID2", RequestID: "_id"}},
+						),
+						session.NewTerminateEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				entityID:       "entityID",
+				nameID:         "user@example.com",
+				sessionIndexes: []string{"sessionIndex"},
+				frontChannel: &SAMLFrontChannelLogout{
+					UserAgentID:  "userAgentID",
+					InResponseTo: "requestID",
+					ResponseURL:  "https://sp.example.com/slo",
+					Binding:      "binding",
+					RelayState:   "relayState",
+					Supported: func(entityID string) bool {
+						return entityID != "soapOnly"
+					},
+				},
+			},
+			res{
+				found:    true,
+				requests: []string{"V2_samlSessionID2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			found, chain, err := c.SAMLLogoutRequested(authz.WithInstanceID(context.Background(), "instanceID"), tt.args.entityID, tt.args.nameID, tt.args.sessionIndexes, tt.args.frontChannel)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.found, found)
+			if len(tt.res.requests) == 0 {
+				assert.Nil(t, chain)
+				return
+			}
+			require.NotNil(t, chain)
+			samlSessionIDs := make([]string, len(chain.Requests))
+			for i, request := range chain.Requests {
+				samlSessionIDs[i] = request.SAMLSessionID
+			}
+			assert.Equal(t, tt.res.requests, samlSessionIDs)
+		})
+	}
+}
+
+func TestCommands_SAMLFrontChannelLogoutResponded(t *testing.T) {
+	aggregate := &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate
+	chainEvents := func() []eventstore.Event {
+		return []eventstore.Event{
+			eventFromEventPusher(
+				sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), aggregate,
+					"V2_samlSessionID", "userID", "entityID", "issuer", "user@example.com", "", "sessionIndex"),
+			),
+			eventFromEventPusher(
+				sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), aggregate,
+					"V2_samlSessionID2", "userID", "entityID2", "issuer", "user@example.com", "", "sessionIndex2"),
+			),
+			eventFromEventPusher(
+				sessionlogout.NewSAMLLogoutRequestedEvent(context.Background(), aggregate, "V2_samlSessionID"),
+			),
+			eventFromEventPusher(
+				sessionlogout.NewSAMLFrontChannelLogoutStartedEvent(context.Background(), aggregate,
+					"userAgentID", "entityID", "requestID", "https://sp.example.com/slo", "binding", "relayState",
+					[]*sessionlogout.SAMLFrontChannelLogoutRequest{{SAMLSessionID: "V2_samlSessionID2", RequestID: "_id"}},
+				),
+			),
+		}
+	}
+	type args struct {
+		userAgentID string
+		entityID    string
+		requestID   string
+		failure     string
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		wantErr    error
+	}{
+		{
+			"other user agent, error",
+			expectEventstore(
+				expectFilter(chainEvents()...),
+			),
+			args{
+				userAgentID: "otherUserAgentID",
+				entityID:    "entityID2",
+				requestID:   "_id",
+			},
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xo4bQ", "Errors.SAMLSession.LogoutInvalid"),
+		},
+		{
+			"other request, error",
+			expectEventstore(
+				expectFilter(chainEvents()...),
+			),
+			args{
+				userAgentID: "userAgentID",
+				entityID:    "entityID2",
+				requestID:   "_otherID",
+			},
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-m2RfE", "Errors.SAMLSession.LogoutInvalid"),
+		},
+		{
+			"already responded, error",
+			expectEventstore(
+				expectFilter(append(chainEvents(),
+					eventFromEventPusher(sessionlogout.NewSAMLLogoutSentEvent(context.Background(), aggregate, "V2_samlSessionID2")),
+				)...),
+			),
+			args{
+				userAgentID: "userAgentID",
+				entityID:    "entityID2",
+				requestID:   "_id",
+			},
+			zerrors.ThrowPreconditionFailed(nil, "COMMAND-m2RfE", "Errors.SAMLSession.LogoutInvalid"),
+		},
+		{
+			"success, sent",
+			expectEventstore(
+				expectFilter(chainEvents()...),
+				expectPush(
+					sessionlogout.NewSAMLLogoutSentEvent(context.Background(), aggregate, "V2_samlSessionID2"),
+				),
+			),
+			args{
+				userAgentID: "userAgentID",
+				entityID:    "entityID2",
+				requestID:   "_id",
+			},
+			nil,
+		},
+		{
+			"failure, failed",
+			expectEventstore(
+				expectFilter(chainEvents()...),
+				expectPush(
+					sessionlogout.NewSAMLLogoutFailedEvent(context.Background(), aggregate, "V2_samlSessionID2", "failure"),
+				),
+			),
+			args{
+				userAgentID: "userAgentID",
+				entityID:    "entityID2",
+				requestID:   "_id",
+				failure:     "failure",
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			chain, err := c.SAMLFrontChannelLogoutResponded(authz.WithInstanceID(context.Background(), "instanceID"), "sessionID", tt.args.userAgentID, tt.args.entityID, tt.args.requestID, tt.args.failure)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Nil(t, chain.Next())
+			assert.Equal(t, "requestID", chain.InResponseTo)
+		})
+	}
+}
@@ -48,6 +48,9 @@ type OIDCSession struct {
 	Reason            domain.TokenReason
 	Actor             *domain.TokenActor
 	RefreshToken      string
+	DPoPJKT           string
+	// AuthorizationDetails are the authorization_details (RFC 9396) the access token was issued for.
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel) error
@@ -55,7 +58,10 @@ type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel)
 // CreateOIDCSessionFromAuthRequest creates a new OIDC Session, creates an access token and refresh token.
 // It returns the access token id, expiration and the refresh token.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReqId string, complianceCheck AuthRequestComplianceChecker, needRefreshToken bool) (session *OIDCSession, state string, err error) {
+// If a dpopJKT is provided, the tokens are bound to the DPoP key with this thumbprint.
+// The authorization_details granted by the auth request are stored on the session.
+// If authorizationDetails are provided, the access token is restricted to them, they must be a subset of the granted ones.
+func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReqId string, complianceCheck AuthRequestComplianceChecker, needRefreshToken bool, dpopJKT string, authorizationDetails domain.AuthorizationDetails) (session *OIDCSession, state string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -94,6 +100,10 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReq
 	if err = complianceCheck(ctx, authReqModel); err != nil {
 		return nil, "", err
 	}
+	tokenAuthorizationDetails, err := accessTokenAuthorizationDetails(authReqModel.AuthorizationDetails, authorizationDetails)
+	if err != nil {
+		return nil, "", err
+	}
 
 	cmd.AddSession(ctx,
 		sessionModel.UserID,
@@ -107,10 +117,12 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(ctx context.Context, authReq
 		authReqModel.Nonce,
 		sessionModel.PreferredLanguage,
 		sessionModel.UserAgent,
+		dpopJKT,
+		authReqModel.AuthorizationDetails,
 	)
 
 	if authReqModel.ResponseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil); err != nil {
+		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil, dpopJKT, tokenAuthorizationDetails); err != nil {
 			return nil, "", err
 		}
 	}
@@ -148,6 +160,7 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 	needRefreshToken bool,
 	sessionID string,
 	responseType domain.OIDCResponseType,
+	dpopJKT string,
 ) (session *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -163,10 +176,10 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 		cmd.UserImpersonated(ctx, userID, resourceOwner, clientID, actor)
 	}
 
-	cmd.AddSession(ctx, userID, resourceOwner, sessionID, clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent)
+	cmd.AddSession(ctx, userID, resourceOwner, sessionID, clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent, dpopJKT, nil)
 	cmd.RegisterLogout(ctx, sessionID, userID, clientID, backChannelLogoutURI)
 	if responseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor); err != nil {
+		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor, dpopJKT, nil); err != nil {
 			return nil, err
 		}
 	}
@@ -190,7 +203,9 @@ type RefreshTokenComplianceChecker func(ctx context.Context, wm *OIDCSessionWrit
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker) (_ *OIDCSession, err error) {
+// If a dpopJKT is provided, the new access token is bound to the DPoP key with this thumbprint.
+// If authorizationDetails are provided, the new access token is restricted to them, they must be a subset of the ones granted for the session.
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker, dpopJKT string, authorizationDetails domain.AuthorizationDetails) (_ *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -202,11 +217,17 @@ func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	authorizationDetails, err = accessTokenAuthorizationDetails(cmd.oidcSessionWriteModel.AuthorizationDetails, authorizationDetails)
+	if err != nil {
+		return nil, err
+	}
 	err = cmd.AddAccessToken(ctx, scope,
 		cmd.oidcSessionWriteModel.UserID,
 		cmd.oidcSessionWriteModel.UserResourceOwner,
 		domain.TokenReasonRefresh,
 		cmd.oidcSessionWriteModel.AccessTokenActor,
+		dpopJKT,
+		authorizationDetails,
 	)
 	if err != nil {
 		return nil, err
@@ -406,6 +427,8 @@ func (c *OIDCSessionEvents) AddSession(
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	dpopJKT string,
+	authorizationDetails domain.AuthorizationDetails,
 ) {
 	c.events = append(c.events, oidcsession.NewAddedEvent(
 		ctx,
@@ -421,6 +444,8 @@ func (c *OIDCSessionEvents) AddSession(
 		nonce,
 		preferredLanguage,
 		userAgent,
+		dpopJKT,
+		authorizationDetails,
 	))
 }
 
@@ -459,13 +484,13 @@ func (c *OIDCSessionEvents) RegisterLogout(ctx context.Context, sessionID, userI
 	))
 }
 
-func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor) error {
+func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor, dpopJKT string, authorizationDetails domain.AuthorizationDetails) error {
 	accessTokenID, err := c.idGenerator.Next()
 	if err != nil {
 		return err
 	}
 	c.accessTokenID = AccessTokenPrefix + accessTokenID
-	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor))
+	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor, dpopJKT, authorizationDetails))
 	if !authz.GetFeatures(ctx).DisableUserTokenEvent {
 		c.events = append(c.events, user.NewUserTokenV2AddedEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, c.accessTokenID))
 	}
@@ -518,20 +543,22 @@ func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (*OIDCSession, error
 		return nil, err
 	}
 	session := &OIDCSession{
-		SessionID:         c.oidcSessionWriteModel.SessionID,
-		ClientID:          c.oidcSessionWriteModel.ClientID,
-		UserID:            c.oidcSessionWriteModel.UserID,
-		Audience:          c.oidcSessionWriteModel.Audience,
-		Expiration:        c.oidcSessionWriteModel.AccessTokenExpiration,
-		Scope:             c.oidcSessionWriteModel.Scope,
-		AuthMethods:       c.oidcSessionWriteModel.AuthMethods,
-		AuthTime:          c.oidcSessionWriteModel.AuthTime,
-		Nonce:             c.oidcSessionWriteModel.Nonce,
-		PreferredLanguage: c.oidcSessionWriteModel.PreferredLanguage,
-		UserAgent:         c.oidcSessionWriteModel.UserAgent,
-		Reason:            c.oidcSessionWriteModel.AccessTokenReason,
-		Actor:             c.oidcSessionWriteModel.AccessTokenActor,
-		RefreshToken:      c.refreshToken,
+		SessionID:            c.oidcSessionWriteModel.SessionID,
+		ClientID:             c.oidcSessionWriteModel.ClientID,
+		UserID:               c.oidcSessionWriteModel.UserID,
+		Audience:             c.oidcSessionWriteModel.Audience,
+		Expiration:           c.oidcSessionWriteModel.AccessTokenExpiration,
+		Scope:                c.oidcSessionWriteModel.Scope,
+		AuthMethods:          c.oidcSessionWriteModel.AuthMethods,
+		AuthTime:             c.oidcSessionWriteModel.AuthTime,
+		Nonce:                c.oidcSessionWriteModel.Nonce,
+		PreferredLanguage:    c.oidcSessionWriteModel.PreferredLanguage,
+		UserAgent:            c.oidcSessionWriteModel.UserAgent,
+		Reason:               c.oidcSessionWriteModel.AccessTokenReason,
+		Actor:                c.oidcSessionWriteModel.AccessTokenActor,
+		RefreshToken:         c.refreshToken,
+		DPoPJKT:              c.oidcSessionWriteModel.AccessTokenDPoPJKT,
+		AuthorizationDetails: c.oidcSessionWriteModel.AccessTokenAuthorizationDetails,
 	}
 	if c.accessTokenID != "" {
 		// prefix the returned id with the oidcSessionID so that we can retrieve it later on
@@ -542,6 +569,19 @@ func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (*OIDCSession, error
 	return session, nil
 }
 
+// accessTokenAuthorizationDetails returns the authorization_details an access token is issued for.
+// Without requested details, the token gets all granted details.
+// Requested details must be a subset of the granted ones.
+func accessTokenAuthorizationDetails(granted, requested domain.AuthorizationDetails) (domain.AuthorizationDetails, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	if !granted.Contains(requested) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-k2v9dq7mfx", "Errors.AuthorizationDetails.NotGranted")
+	}
+	return requested, nil
+}
+
 func (c *Commands) tokenTokenLifetimes(ctx context.Context) (accessTokenLifetime time.Duration, refreshTokenLifetime time.Duration, refreshTokenIdleLifetime time.Duration, err error) {
 	oidcSettings := NewInstanceOIDCSettingsWriteModel(ctx)
 	err = c.eventstore.FilterToQueryReducer(ctx, oidcSettings)
@@ -14,27 +14,31 @@ import (
 type OIDCSessionWriteModel struct {
 	eventstore.WriteModel
 
-	UserID                     string
-	UserResourceOwner          string
-	PreferredLanguage          *language.Tag
-	SessionID                  string
-	ClientID                   string
-	Audience                   []string
-	Scope                      []string
-	AuthMethods                []domain.UserAuthMethodType
-	AuthTime                   time.Time
-	Nonce                      string
-	UserAgent                  *domain.UserAgent
-	State                      domain.OIDCSessionState
-	AccessTokenID              string
-	AccessTokenCreation        time.Time
-	AccessTokenExpiration      time.Time
-	AccessTokenReason          domain.TokenReason
-	AccessTokenActor           *domain.TokenActor
-	RefreshTokenID             string
-	RefreshToken               string
-	RefreshTokenExpiration     time.Time
-	RefreshTokenIdleExpiration time.Time
+	UserID                          string
+	UserResourceOwner               string
+	PreferredLanguage               *language.Tag
+	SessionID                       string
+	ClientID                        string
+	Audience                        []string
+	Scope                           []string
+	AuthMethods                     []domain.UserAuthMethodType
+	AuthTime                        time.Time
+	Nonce                           string
+	UserAgent                       *domain.UserAgent
+	DPoPJKT                         string
+	AuthorizationDetails            domain.AuthorizationDetails
+	State                           domain.OIDCSessionState
+	AccessTokenID                   string
+	AccessTokenCreation             time.Time
+	AccessTokenExpiration           time.Time
+	AccessTokenReason               domain.TokenReason
+	AccessTokenActor                *domain.TokenActor
+	AccessTokenDPoPJKT              string
+	AccessTokenAuthorizationDetails domain.AuthorizationDetails
+	RefreshTokenID                  string
+	RefreshToken                    string
+	RefreshTokenExpiration          time.Time
+	RefreshTokenIdleExpiration      time.Time
 
 	aggregate *eventstore.Aggregate
 }
@@ -101,6 +105,8 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Nonce = e.Nonce
 	wm.PreferredLanguage = e.PreferredLanguage
 	wm.UserAgent = e.UserAgent
+	wm.DPoPJKT = e.DPoPJKT
+	wm.AuthorizationDetails = e.AuthorizationDetails
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -114,6 +120,8 @@ func (wm *OIDCSessionWriteModel) reduceAccessTokenAdded(e *oidcsession.AccessTok
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.AccessTokenReason = e.Reason
 	wm.AccessTokenActor = e.Actor
+	wm.AccessTokenDPoPJKT = e.DPoPJKT
+	wm.AccessTokenAuthorizationDetails = e.AuthorizationDetails
 }
 
 func (wm *OIDCSessionWriteModel) reduceAccessTokenRevoked(e *oidcsession.AccessTokenRevokedEvent) {
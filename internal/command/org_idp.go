@@ -10,7 +10,10 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	providers "github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/kerberos"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -509,6 +512,79 @@ func (c *Commands) RegenerateOrgSAMLProviderCertificate(ctx context.Context, res
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
+// AddOrgSAMLProviderKey generates an additional key for the SAML provider.
+// Its certificate is published in the metadata, so the identity provider can pick it up before the key is activated.
+func (c *Commands) AddOrgSAMLProviderKey(ctx context.Context, resourceOwner, id string) (keyID string, details *domain.ObjectDetails, err error) {
+	keyID, err = c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	details, err = c.changeOrgSAMLProvider(ctx, resourceOwner, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		key, cert, err := c.samlCertificateAndKeyGenerator(writeModel.ID)
+		if err != nil {
+			return nil, err
+		}
+		return writeModel.NewAddKeyChanges(keyID, key, cert, c.idpConfigEncryption)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, details, nil
+}
+
+// ActivateOrgSAMLProviderKey uses the additional key for signing and publishes its certificate as the active one.
+// The previously active key is kept as additional key until it's removed.
+func (c *Commands) ActivateOrgSAMLProviderKey(ctx context.Context, resourceOwner, id, keyID string) (*domain.ObjectDetails, error) {
+	previousKeyID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	return c.changeOrgSAMLProvider(ctx, resourceOwner, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		return writeModel.NewActivateKeyChanges(keyID, previousKeyID)
+	})
+}
+
+func (c *Commands) RemoveOrgSAMLProviderKey(ctx context.Context, resourceOwner, id, keyID string) (*domain.ObjectDetails, error) {
+	return c.changeOrgSAMLProvider(ctx, resourceOwner, id, func(writeModel *SAMLIDPWriteModel) ([]idp.SAMLIDPChanges, error) {
+		return writeModel.NewRemoveKeyChanges(keyID)
+	})
+}
+
+// RefreshOrgSAMLProviderMetadata reads the metadata from the metadata URL of the SAML provider
+// and updates it if it changed.
+func (c *Commands) RefreshOrgSAMLProviderMetadata(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	return c.changeOrgSAMLProvider(ctx, resourceOwner, id, c.refreshSAMLMetadataChanges)
+}
+
+func (c *Commands) changeOrgSAMLProvider(ctx context.Context, resourceOwner, id string, changes samlChanges) (*domain.ObjectDetails, error) {
+	if id = strings.TrimSpace(id); id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Xk2ma", "Errors.Invalid.Argument")
+	}
+	writeModel := NewSAMLOrgIDPWriteModel(resourceOwner, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Vb3kd", "Errors.Org.IDPConfig.NotExisting")
+	}
+	samlChanges, err := changes(&writeModel.SAMLIDPWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if len(samlChanges) == 0 {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	event, err := org.NewSAMLIDPChangedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, samlChanges)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) AddOrgAppleProvider(ctx context.Context, resourceOwner string, provider AppleProvider) (string, *domain.ObjectDetails, error) {
 	orgAgg := org.NewAggregate(resourceOwner)
 	id, err := c.idGenerator.Next()
@@ -549,6 +625,46 @@ func (c *Commands) UpdateOrgAppleProvider(ctx context.Context, resourceOwner, id
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
+func (c *Commands) AddOrgKerberosProvider(ctx context.Context, resourceOwner string, provider KerberosProvider) (string, *domain.ObjectDetails, error) {
+	orgAgg := org.NewAggregate(resourceOwner)
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel := NewKerberosOrgIDPWriteModel(resourceOwner, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareAddOrgKerberosProvider(orgAgg, writeModel, provider))
+	if err != nil {
+		return "", nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) UpdateOrgKerberosProvider(ctx context.Context, resourceOwner, id string, provider KerberosProvider) (*domain.ObjectDetails, error) {
+	orgAgg := org.NewAggregate(resourceOwner)
+	writeModel := NewKerberosOrgIDPWriteModel(resourceOwner, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareUpdateOrgKerberosProvider(orgAgg, writeModel, provider))
+	if err != nil {
+		return nil, err
+	}
+	if len(cmds) == 0 {
+		// no change, so return directly
+		return &domain.ObjectDetails{
+			Sequence:      writeModel.ProcessedSequence,
+			EventDate:     writeModel.ChangeDate,
+			ResourceOwner: writeModel.ResourceOwner,
+		}, nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) DeleteOrgProvider(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
 	orgAgg := org.NewAggregate(resourceOwner)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareDeleteOrgProvider(orgAgg, resourceOwner, id))
@@ -602,6 +718,9 @@ func (c *Commands) prepareAddOrgOAuthProvider(a *org.Aggregate, writeModel *OrgO
 		if provider.IDAttribute = strings.TrimSpace(provider.IDAttribute); provider.IDAttribute == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-sadf3d", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -628,6 +747,7 @@ func (c *Commands) prepareAddOrgOAuthProvider(a *org.Aggregate, writeModel *OrgO
 					provider.UserEndpoint,
 					provider.IDAttribute,
 					provider.Scopes,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -658,6 +778,9 @@ func (c *Commands) prepareUpdateOrgOAuthProvider(a *org.Aggregate, writeModel *O
 		if provider.IDAttribute = strings.TrimSpace(provider.IDAttribute); provider.IDAttribute == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-SAe4gh", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -683,6 +806,7 @@ func (c *Commands) prepareUpdateOrgOAuthProvider(a *org.Aggregate, writeModel *O
 				provider.UserEndpoint,
 				provider.IDAttribute,
 				provider.Scopes,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -707,6 +831,9 @@ func (c *Commands) prepareAddOrgOIDCProvider(a *org.Aggregate, writeModel *OrgOI
 		if provider.ClientSecret = strings.TrimSpace(provider.ClientSecret); provider.ClientSecret == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Sfdf4", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -731,6 +858,7 @@ func (c *Commands) prepareAddOrgOIDCProvider(a *org.Aggregate, writeModel *OrgOI
 					secret,
 					provider.Scopes,
 					provider.IsIDTokenMapping,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -752,6 +880,9 @@ func (c *Commands) prepareUpdateOrgOIDCProvider(a *org.Aggregate, writeModel *Or
 		if provider.ClientID = strings.TrimSpace(provider.ClientID); provider.ClientID == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Db3bs", "Errors.Invalid.Argument")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -775,6 +906,7 @@ func (c *Commands) prepareUpdateOrgOIDCProvider(a *org.Aggregate, writeModel *Or
 				c.idpConfigEncryption,
 				provider.Scopes,
 				provider.IsIDTokenMapping,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1512,6 +1644,9 @@ func (c *Commands) prepareAddOrgLDAPProvider(a *org.Aggregate, writeModel *OrgLD
 		if len(provider.UserFilters) == 0 {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-aAx9x1n", "Errors.Invalid.Argument")
 		}
+		if !provider.GroupMapping.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Kf3ns", "Errors.IDPConfig.LDAPGroupMappingInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1521,6 +1656,9 @@ func (c *Commands) prepareAddOrgLDAPProvider(a *org.Aggregate, writeModel *OrgLD
 			if err = writeModel.Reduce(); err != nil {
 				return nil, err
 			}
+			if err = c.checkLDAPGroupMapping(ctx, a.ID, provider.GroupMapping); err != nil {
+				return nil, err
+			}
 			secret, err := crypto.Encrypt([]byte(provider.BindPassword), c.idpConfigEncryption)
 			if err != nil {
 				return nil, err
@@ -1542,6 +1680,8 @@ func (c *Commands) prepareAddOrgLDAPProvider(a *org.Aggregate, writeModel *OrgLD
 					provider.Timeout,
 					provider.RootCA,
 					provider.LDAPAttributes,
+					provider.GroupMapping,
+					provider.DirectorySync,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -1575,6 +1715,9 @@ func (c *Commands) prepareUpdateOrgLDAPProvider(a *org.Aggregate, writeModel *Or
 		if len(provider.UserFilters) == 0 {
 			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-aBx901n", "Errors.Invalid.Argument")
 		}
+		if !provider.GroupMapping.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Tq7xw", "Errors.IDPConfig.LDAPGroupMappingInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1587,6 +1730,11 @@ func (c *Commands) prepareUpdateOrgLDAPProvider(a *org.Aggregate, writeModel *Or
 			if !writeModel.State.Exists() {
 				return nil, zerrors.ThrowNotFound(nil, "ORG-ASF3F", "Errors.Org.IDPConfig.NotExisting")
 			}
+			if !writeModel.GroupMapping.Equal(provider.GroupMapping) {
+				if err = c.checkLDAPGroupMapping(ctx, a.ID, provider.GroupMapping); err != nil {
+					return nil, err
+				}
+			}
 			event, err := writeModel.NewChangedEvent(
 				ctx,
 				&a.Aggregate,
@@ -1604,6 +1752,8 @@ func (c *Commands) prepareUpdateOrgLDAPProvider(a *org.Aggregate, writeModel *Or
 				provider.RootCA,
 				c.idpConfigEncryption,
 				provider.LDAPAttributes,
+				provider.GroupMapping,
+				provider.DirectorySync,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1706,6 +1856,91 @@ func (c *Commands) prepareUpdateOrgAppleProvider(a *org.Aggregate, writeModel *O
 	}
 }
 
+func (c *Commands) prepareAddOrgKerberosProvider(a *org.Aggregate, writeModel *OrgKerberosIDPWriteModel, provider KerberosProvider) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Kc4sn", "Errors.Invalid.Argument")
+		}
+		if len(provider.Keytab) == 0 {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Kc5tw", "Errors.IDPConfig.KerberosKeytabMissing")
+		}
+		if _, err := kerberos.ParseKeytab(provider.Keytab); err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "ORG-Kc6ux", "Errors.IDPConfig.KerberosKeytabInvalid")
+		}
+		provider.ServicePrincipal = strings.TrimSpace(provider.ServicePrincipal)
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			keytab, err := crypto.Encrypt(provider.Keytab, c.idpConfigEncryption)
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewKerberosIDPAddedEvent(
+					ctx,
+					&a.Aggregate,
+					writeModel.ID,
+					provider.Name,
+					provider.ServicePrincipal,
+					keytab,
+					provider.IDPOptions,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareUpdateOrgKerberosProvider(a *org.Aggregate, writeModel *OrgKerberosIDPWriteModel, provider KerberosProvider) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if writeModel.ID = strings.TrimSpace(writeModel.ID); writeModel.ID == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Kc7vy", "Errors.IDMissing")
+		}
+		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Kc8wz", "Errors.Invalid.Argument")
+		}
+		// the keytab is only changed if provided
+		if len(provider.Keytab) > 0 {
+			if _, err := kerberos.ParseKeytab(provider.Keytab); err != nil {
+				return nil, zerrors.ThrowInvalidArgument(err, "ORG-Kc9xa", "Errors.IDPConfig.KerberosKeytabInvalid")
+			}
+		}
+		provider.ServicePrincipal = strings.TrimSpace(provider.ServicePrincipal)
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "ORG-Kd2ye", "Errors.IDPConfig.NotExisting")
+			}
+			event, err := writeModel.NewChangedEvent(
+				ctx,
+				&a.Aggregate,
+				writeModel.ID,
+				provider.Name,
+				provider.ServicePrincipal,
+				provider.Keytab,
+				c.idpConfigEncryption,
+				provider.IDPOptions,
+			)
+			if err != nil || event == nil {
+				return nil, err
+			}
+			return []eventstore.Command{event}, nil
+		}, nil
+	}
+}
+
 func (c *Commands) prepareAddOrgSAMLProvider(a *org.Aggregate, writeModel *OrgSAMLIDPWriteModel, provider *SAMLProvider) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
@@ -1724,6 +1959,9 @@ func (c *Commands) prepareAddOrgSAMLProvider(a *org.Aggregate, writeModel *OrgSA
 		if _, err := saml.ParseMetadata(provider.Metadata); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(err, "ORG-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1748,12 +1986,14 @@ func (c *Commands) prepareAddOrgSAMLProvider(a *org.Aggregate, writeModel *OrgSA
 					writeModel.ID,
 					provider.Name,
 					provider.Metadata,
+					provider.MetadataURL,
 					keyEnc,
 					cert,
 					provider.Binding,
 					provider.WithSignedRequest,
 					provider.NameIDFormat,
 					provider.TransientMappingAttributeName,
+					provider.ClaimMapping,
 					provider.IDPOptions,
 				),
 			}, nil
@@ -1782,6 +2022,9 @@ func (c *Commands) prepareUpdateOrgSAMLProvider(a *org.Aggregate, writeModel *Or
 		if _, err := saml.ParseMetadata(provider.Metadata); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(err, "ORG-SFqqh42", "Errors.Project.App.SAMLMetadataFormat")
 		}
+		if err := providers.ValidateClaimMapping(provider.ClaimMapping); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			events, err := filter(ctx, writeModel.Query())
 			if err != nil {
@@ -1800,6 +2043,7 @@ func (c *Commands) prepareUpdateOrgSAMLProvider(a *org.Aggregate, writeModel *Or
 				writeModel.ID,
 				provider.Name,
 				provider.Metadata,
+				provider.MetadataURL,
 				nil,
 				nil,
 				c.idpConfigEncryption,
@@ -1807,6 +2051,7 @@ func (c *Commands) prepareUpdateOrgSAMLProvider(a *org.Aggregate, writeModel *Or
 				provider.WithSignedRequest,
 				provider.NameIDFormat,
 				provider.TransientMappingAttributeName,
+				provider.ClaimMapping,
 				provider.IDPOptions,
 			)
 			if err != nil || event == nil {
@@ -1845,6 +2090,7 @@ func (c *Commands) prepareRegenerateOrgSAMLProviderCertificate(a *org.Aggregate,
 				writeModel.ID,
 				writeModel.Name,
 				writeModel.Metadata,
+				writeModel.MetadataURL,
 				key,
 				cert,
 				c.idpConfigEncryption,
@@ -1852,6 +2098,7 @@ func (c *Commands) prepareRegenerateOrgSAMLProviderCertificate(a *org.Aggregate,
 				writeModel.WithSignedRequest,
 				writeModel.NameIDFormat,
 				writeModel.TransientMappingAttributeName,
+				writeModel.ClaimMapping,
 				writeModel.Options,
 			)
 			if err != nil || event == nil {
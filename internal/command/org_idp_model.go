@@ -70,6 +70,7 @@ func (wm *OrgOAuthIDPWriteModel) NewChangedEvent(
 	userEndpoint,
 	idAttribute string,
 	scopes []string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*org.OAuthIDPChangedEvent, error) {
 
@@ -83,6 +84,7 @@ func (wm *OrgOAuthIDPWriteModel) NewChangedEvent(
 		userEndpoint,
 		idAttribute,
 		scopes,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -177,6 +179,7 @@ func (wm *OrgOIDCIDPWriteModel) NewChangedEvent(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping bool,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*org.OIDCIDPChangedEvent, error) {
 
@@ -188,6 +191,7 @@ func (wm *OrgOIDCIDPWriteModel) NewChangedEvent(
 		secretCrypto,
 		scopes,
 		idTokenMapping,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -781,6 +785,8 @@ func (wm *OrgLDAPIDPWriteModel) NewChangedEvent(
 	rootCA []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
 	attributes idp.LDAPAttributes,
+	groupMapping *domain.LDAPGroupMapping,
+	directorySync bool,
 	options idp.Options,
 ) (*org.LDAPIDPChangedEvent, error) {
 
@@ -798,6 +804,8 @@ func (wm *OrgLDAPIDPWriteModel) NewChangedEvent(
 		rootCA,
 		secretCrypto,
 		attributes,
+		groupMapping,
+		directorySync,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -873,6 +881,70 @@ func (wm *OrgAppleIDPWriteModel) NewChangedEvent(
 	return org.NewAppleIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type OrgKerberosIDPWriteModel struct {
+	KerberosIDPWriteModel
+}
+
+func NewKerberosOrgIDPWriteModel(orgID, id string) *OrgKerberosIDPWriteModel {
+	return &OrgKerberosIDPWriteModel{
+		KerberosIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgKerberosIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.KerberosIDPAddedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.KerberosIDPAddedEvent)
+		case *org.KerberosIDPChangedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.KerberosIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.KerberosIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.KerberosIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgKerberosIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.KerberosIDPAddedEventType,
+			org.KerberosIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgKerberosIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	servicePrincipal string,
+	keytab []byte,
+	secretCrypto crypto.EncryptionAlgorithm,
+	options idp.Options,
+) (*org.KerberosIDPChangedEvent, error) {
+
+	changes, err := wm.KerberosIDPWriteModel.NewChanges(name, servicePrincipal, keytab, secretCrypto, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewKerberosIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type OrgSAMLIDPWriteModel struct {
 	SAMLIDPWriteModel
 }
@@ -924,7 +996,8 @@ func (wm *OrgSAMLIDPWriteModel) NewChangedEvent(
 	aggregate *eventstore.Aggregate,
 	id,
 	name string,
-	metadata,
+	metadata []byte,
+	metadataURL string,
 	key,
 	certificate []byte,
 	secretCrypto crypto.EncryptionAlgorithm,
@@ -932,11 +1005,13 @@ func (wm *OrgSAMLIDPWriteModel) NewChangedEvent(
 	withSignedRequest bool,
 	nameIDFormat *domain.SAMLNameIDFormat,
 	transientMappingAttributeName string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) (*org.SAMLIDPChangedEvent, error) {
 	changes, err := wm.SAMLIDPWriteModel.NewChanges(
 		name,
 		metadata,
+		metadataURL,
 		key,
 		certificate,
 		secretCrypto,
@@ -944,6 +1019,7 @@ func (wm *OrgSAMLIDPWriteModel) NewChangedEvent(
 		withSignedRequest,
 		nameIDFormat,
 		transientMappingAttributeName,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -993,6 +1069,8 @@ func (wm *OrgIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.IDPRemoveWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
 		case *org.AppleIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.AppleIDPAddedEvent)
+		case *org.KerberosIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.KerberosIDPAddedEvent)
 		case *org.SAMLIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.SAMLIDPAddedEvent)
 		case *org.IDPRemovedEvent:
@@ -1025,6 +1103,7 @@ func (wm *OrgIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.GoogleIDPAddedEventType,
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
+			org.KerberosIDPAddedEventType,
 			org.SAMLIDPAddedEventType,
 			org.IDPRemovedEventType,
 		).
@@ -18,6 +18,7 @@ import (
 	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -189,6 +190,34 @@ func TestCommandSide_AddOrgGenericOAuthProvider(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid claim mapping",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: GenericOAuthProvider{
+					Name:                  "name",
+					ClientID:              "clientID",
+					ClientSecret:          "clientSecret",
+					AuthorizationEndpoint: "auth",
+					TokenEndpoint:         "token",
+					UserEndpoint:          "user",
+					IDAttribute:           "idAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "email",
+					},
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", ""))
+				},
+			},
+		},
 		{
 			name: "ok",
 			fields: fields{
@@ -210,6 +239,7 @@ func TestCommandSide_AddOrgGenericOAuthProvider(t *testing.T) {
 							"user",
 							"idAttribute",
 							nil,
+							nil,
 							idp.Options{},
 						),
 					),
@@ -256,6 +286,9 @@ func TestCommandSide_AddOrgGenericOAuthProvider(t *testing.T) {
 							"user",
 							"idAttribute",
 							[]string{"user"},
+							&domain.IDPClaimMapping{
+								Email: "$.mail",
+							},
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -280,6 +313,9 @@ func TestCommandSide_AddOrgGenericOAuthProvider(t *testing.T) {
 					UserEndpoint:          "user",
 					Scopes:                []string{"user"},
 					IDAttribute:           "idAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "$.mail",
+					},
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -520,6 +556,7 @@ func TestCommandSide_UpdateOrgGenericOAuthIDP(t *testing.T) {
 								"user",
 								"idAttribute",
 								nil,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -563,6 +600,7 @@ func TestCommandSide_UpdateOrgGenericOAuthIDP(t *testing.T) {
 								"user",
 								"idAttribute",
 								nil,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -585,6 +623,9 @@ func TestCommandSide_UpdateOrgGenericOAuthIDP(t *testing.T) {
 									idp.ChangeOAuthUserEndpoint("new user"),
 									idp.ChangeOAuthScopes([]string{"openid", "profile"}),
 									idp.ChangeOAuthIDAttribute("newAttribute"),
+									idp.ChangeOAuthClaimMapping(&domain.IDPClaimMapping{
+										Email: "$.mail",
+									}),
 									idp.ChangeOAuthOptions(idp.OptionChanges{
 										IsCreationAllowed: &t,
 										IsLinkingAllowed:  &t,
@@ -612,6 +653,9 @@ func TestCommandSide_UpdateOrgGenericOAuthIDP(t *testing.T) {
 					UserEndpoint:          "new user",
 					Scopes:                []string{"openid", "profile"},
 					IDAttribute:           "newAttribute",
+					ClaimMapping: &domain.IDPClaimMapping{
+						Email: "$.mail",
+					},
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -763,6 +807,7 @@ func TestCommandSide_AddOrgGenericOIDCIDP(t *testing.T) {
 							},
 							nil,
 							false,
+							nil,
 							idp.Options{},
 						),
 					),
@@ -804,6 +849,7 @@ func TestCommandSide_AddOrgGenericOIDCIDP(t *testing.T) {
 							},
 							[]string{openid.ScopeOpenID},
 							true,
+							nil,
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -995,6 +1041,7 @@ func TestCommandSide_UpdateOrgGenericOIDCIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1033,6 +1080,7 @@ func TestCommandSide_UpdateOrgGenericOIDCIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1225,6 +1273,7 @@ func TestCommandSide_MigrateOrgGenericOIDCToAzureADProvider(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1284,6 +1333,7 @@ func TestCommandSide_MigrateOrgGenericOIDCToAzureADProvider(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1452,6 +1502,7 @@ func TestCommandSide_MigrateOrgOIDCToGoogleIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -1505,6 +1556,7 @@ func TestCommandSide_MigrateOrgOIDCToGoogleIDP(t *testing.T) {
 								},
 								nil,
 								false,
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -4114,9 +4166,10 @@ func TestCommandSide_UpdateOrgGoogleIDP(t *testing.T) {
 
 func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 	type fields struct {
-		eventstore   func(*testing.T) *eventstore.Eventstore
-		idGenerator  id.Generator
-		secretCrypto crypto.EncryptionAlgorithm
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		secretCrypto    crypto.EncryptionAlgorithm
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx           context.Context
@@ -4305,6 +4358,35 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid group mapping",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BindDN:            "binddn",
+					BaseDN:            "baseDN",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					GroupMapping: &domain.LDAPGroupMapping{
+						Groups: []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kf3ns", ""))
+				},
+			},
+		},
 		{
 			name: "ok",
 			fields: fields{
@@ -4330,6 +4412,8 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 							time.Second*30,
 							nil,
 							idp.LDAPAttributes{},
+							nil,
+							false,
 							idp.Options{},
 						),
 					),
@@ -4363,6 +4447,16 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 			fields: fields{
 				eventstore: expectEventstore(
 					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
 					expectPush(
 						org.NewLDAPIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
 							"id1",
@@ -4397,6 +4491,11 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 								AvatarURLAttribute:         "avatarURL",
 								ProfileAttribute:           "profile",
 							},
+							&domain.LDAPGroupMapping{
+								GroupAttribute: "memberOf",
+								Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+							},
+							true,
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -4406,8 +4505,9 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 						),
 					),
 				),
-				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto:    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				checkPermission: newMockPermissionCheckAllowed(),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -4439,6 +4539,11 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 						AvatarURLAttribute:         "avatarURL",
 						ProfileAttribute:           "profile",
 					},
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+					DirectorySync: true,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -4452,6 +4557,91 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 				want: &domain.ObjectDetails{ResourceOwner: "org1"},
 			},
 		},
+		{
+			name: "group mapping of project of other org, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BaseDN:            "baseDN",
+					BindDN:            "dn",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					Timeout:           time.Second * 30,
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "group mapping without permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: LDAPProvider{
+					Name:              "name",
+					Servers:           []string{"server"},
+					BaseDN:            "baseDN",
+					BindDN:            "dn",
+					BindPassword:      "password",
+					UserBase:          "user",
+					UserObjectClasses: []string{"object"},
+					UserFilters:       []string{"filter"},
+					Timeout:           time.Second * 30,
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4459,6 +4649,7 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 				eventstore:          tt.fields.eventstore(t),
 				idGenerator:         tt.fields.idGenerator,
 				idpConfigEncryption: tt.fields.secretCrypto,
+				checkPermission:     tt.fields.checkPermission,
 			}
 			id, got, err := c.AddOrgLDAPProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.provider)
 			if tt.res.err == nil {
@@ -4477,8 +4668,9 @@ func TestCommandSide_AddOrgLDAPIDP(t *testing.T) {
 
 func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 	type fields struct {
-		eventstore   func(*testing.T) *eventstore.Eventstore
-		secretCrypto crypto.EncryptionAlgorithm
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		secretCrypto    crypto.EncryptionAlgorithm
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx           context.Context
@@ -4711,6 +4903,8 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 								time.Second*30,
 								[]byte("certificate"),
 								idp.LDAPAttributes{},
+								nil,
+								false,
 								idp.Options{},
 							)),
 					),
@@ -4761,9 +4955,21 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 								time.Second*30,
 								[]byte("certificate"),
 								idp.LDAPAttributes{},
+								nil,
+								false,
 								idp.Options{},
 							)),
 					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+					),
 					expectPush(
 						func() eventstore.Command {
 							t := true
@@ -4785,6 +4991,11 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 									idp.ChangeLDAPUserObjectClasses([]string{"new object"}),
 									idp.ChangeLDAPUserFilters([]string{"new filter"}),
 									idp.ChangeLDAPTimeout(time.Second * 20),
+									idp.ChangeLDAPGroupMapping(&domain.LDAPGroupMapping{
+										GroupAttribute: "memberOf",
+										Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+									}),
+									idp.ChangeLDAPDirectorySync(true),
 									idp.ChangeLDAPAttributes(idp.LDAPAttributeChanges{
 										IDAttribute:                stringPointer("new id"),
 										FirstNameAttribute:         stringPointer("new firstName"),
@@ -4812,7 +5023,8 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 						}(),
 					),
 				),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				secretCrypto:    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				checkPermission: newMockPermissionCheckAllowed(),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -4845,6 +5057,11 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 						AvatarURLAttribute:         "new avatarURL",
 						ProfileAttribute:           "new profile",
 					},
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+					},
+					DirectorySync: true,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -4863,6 +5080,7 @@ func TestCommandSide_UpdateOrgLDAPIDP(t *testing.T) {
 			c := &Commands{
 				eventstore:          tt.fields.eventstore(t),
 				idpConfigEncryption: tt.fields.secretCrypto,
+				checkPermission:     tt.fields.checkPermission,
 			}
 			got, err := c.UpdateOrgLDAPProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.provider)
 			if tt.res.err == nil {
@@ -5345,17 +5563,17 @@ func stringPointer(s string) *string {
 	return &s
 }
 
-func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
+func TestCommandSide_AddOrgKerberosIDP(t *testing.T) {
+	keytab := kerberosTestKeytab(t)
 	type fields struct {
-		eventstore                 func(*testing.T) *eventstore.Eventstore
-		idGenerator                id.Generator
-		secretCrypto               crypto.EncryptionAlgorithm
-		certificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+		eventstore   func(*testing.T) *eventstore.Eventstore
+		idGenerator  id.Generator
+		secretCrypto crypto.EncryptionAlgorithm
 	}
 	type args struct {
 		ctx           context.Context
 		resourceOwner string
-		provider      *SAMLProvider
+		provider      KerberosProvider
 	}
 	type res struct {
 		id   string
@@ -5377,16 +5595,16 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 			args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider:      &SAMLProvider{},
+				provider:      KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-957lr0f8u3", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc4sn", "Errors.Invalid.Argument"))
 				},
 			},
 		},
 		{
-			"no metadata",
+			"missing keytab",
 			fields{
 				eventstore:  expectEventstore(),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
@@ -5394,18 +5612,18 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 			args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider: &SAMLProvider{
+				provider: KerberosProvider{
 					Name: "name",
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-78isv6m53a", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc5tw", "Errors.IDPConfig.KerberosKeytabMissing"))
 				},
 			},
 		},
 		{
-			"invalid metadata, fail on error",
+			"invalid keytab",
 			fields{
 				eventstore:  expectEventstore(),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
@@ -5413,14 +5631,14 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 			args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: []byte("metadata"),
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: []byte("keytab"),
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat"))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc6ux", "Errors.IDPConfig.KerberosKeytabInvalid"))
 				},
 			},
 		},
@@ -5430,34 +5648,29 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(),
 					expectPush(
-						org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+						org.NewKerberosIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
 							"id1",
 							"name",
-							validSAMLMetadata,
+							"",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
 								KeyID:      "id",
-								Crypted:    []byte("key"),
+								Crypted:    keytab,
 							},
-							[]byte("certificate"),
-							"",
-							false,
-							nil,
-							"",
 							idp.Options{},
 						),
 					),
 				),
 				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)), certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: validSAMLMetadata,
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: keytab,
 				},
 			},
 			res: res{
@@ -5471,21 +5684,16 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(),
 					expectPush(
-						org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+						org.NewKerberosIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
 							"id1",
 							"name",
-							validSAMLMetadata,
+							"HTTP/login.example.com",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
 								KeyID:      "id",
-								Crypted:    []byte("key"),
+								Crypted:    keytab,
 							},
-							[]byte("certificate"),
-							"binding",
-							true,
-							gu.Ptr(domain.SAMLNameIDFormatTransient),
-							"customAttribute",
 							idp.Options{
 								IsCreationAllowed: true,
 								IsLinkingAllowed:  true,
@@ -5495,20 +5703,16 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 						),
 					),
 				),
-				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
-				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
-				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider: &SAMLProvider{
-					Name:                          "name",
-					Metadata:                      validSAMLMetadata,
-					Binding:                       "binding",
-					WithSignedRequest:             true,
-					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
-					TransientMappingAttributeName: "customAttribute",
+				provider: KerberosProvider{
+					Name:             "name",
+					ServicePrincipal: " HTTP/login.example.com ",
+					Keytab:           keytab,
 					IDPOptions: idp.Options{
 						IsCreationAllowed: true,
 						IsLinkingAllowed:  true,
@@ -5526,12 +5730,11 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore:                     tt.fields.eventstore(t),
-				idGenerator:                    tt.fields.idGenerator,
-				idpConfigEncryption:            tt.fields.secretCrypto,
-				samlCertificateAndKeyGenerator: tt.fields.certificateAndKeyGenerator,
+				eventstore:          tt.fields.eventstore(t),
+				idGenerator:         tt.fields.idGenerator,
+				idpConfigEncryption: tt.fields.secretCrypto,
 			}
-			id, got, err := c.AddOrgSAMLProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.provider)
+			id, got, err := c.AddOrgKerberosProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.provider)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -5546,7 +5749,8 @@ func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 	}
 }
 
-func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
+func TestCommandSide_UpdateOrgKerberosIDP(t *testing.T) {
+	keytab := kerberosTestKeytab(t)
 	type fields struct {
 		eventstore   func(*testing.T) *eventstore.Eventstore
 		secretCrypto crypto.EncryptionAlgorithm
@@ -5555,7 +5759,7 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 		ctx           context.Context
 		resourceOwner string
 		id            string
-		provider      *SAMLProvider
+		provider      KerberosProvider
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -5575,11 +5779,11 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 			args{
 				ctx:           context.Background(),
 				resourceOwner: "org1",
-				provider:      &SAMLProvider{},
+				provider:      KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-wwdwdlaya0", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc7vy", "Errors.IDMissing"))
 				},
 			},
 		},
@@ -5592,35 +5796,16 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 				ctx:           context.Background(),
 				resourceOwner: "org1",
 				id:            "id1",
-				provider:      &SAMLProvider{},
-			},
-			res{
-				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-egixaofgyl", ""))
-				},
-			},
-		},
-		{
-			"no metadata",
-			fields{
-				eventstore: expectEventstore(),
-			},
-			args{
-				ctx:           context.Background(),
-				resourceOwner: "org1",
-				id:            "id1",
-				provider: &SAMLProvider{
-					Name: "name",
-				},
+				provider:      KerberosProvider{},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-j6spncd74m", ""))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc8wz", "Errors.Invalid.Argument"))
 				},
 			},
 		},
 		{
-			"invalid metadata, error",
+			"invalid keytab",
 			fields{
 				eventstore: expectEventstore(),
 			},
@@ -5628,14 +5813,14 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 				ctx:           context.Background(),
 				resourceOwner: "org1",
 				id:            "id1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: []byte("metadata"),
+				provider: KerberosProvider{
+					Name:   "name",
+					Keytab: []byte("keytab"),
 				},
 			},
 			res{
 				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-SFqqh42", "Errors.Project.App.SAMLMetadataFormat"))
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-Kc9xa", "Errors.IDPConfig.KerberosKeytabInvalid"))
 				},
 			},
 		},
@@ -5650,15 +5835,453 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 				ctx:           context.Background(),
 				resourceOwner: "org1",
 				id:            "id1",
-				provider: &SAMLProvider{
-					Name:     "name",
-					Metadata: validSAMLMetadata,
+				provider: KerberosProvider{
+					Name: "name",
 				},
 			},
 			res: res{
-				err: func(err error) bool {
-					return errors.Is(err, zerrors.ThrowNotFound(nil, "ORG-z82dddndql", ""))
-				},
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewKerberosIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    keytab,
+								},
+								idp.Options{},
+							)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: KerberosProvider{
+					Name: "name",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "change ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewKerberosIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("keytab"),
+								},
+								idp.Options{},
+							)),
+					),
+					expectPush(
+						func() eventstore.Command {
+							t := true
+							event, _ := org.NewKerberosIDPChangedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								[]idp.KerberosIDPChanges{
+									idp.ChangeKerberosKeytab(&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    keytab,
+									}),
+									idp.ChangeKerberosName("new name"),
+									idp.ChangeKerberosServicePrincipal("HTTP/login.example.com"),
+									idp.ChangeKerberosOptions(idp.OptionChanges{
+										IsCreationAllowed: &t,
+										IsLinkingAllowed:  &t,
+										IsAutoCreation:    &t,
+										IsAutoUpdate:      &t,
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: KerberosProvider{
+					Name:             "new name",
+					ServicePrincipal: "HTTP/login.example.com",
+					Keytab:           keytab,
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: tt.fields.secretCrypto,
+			}
+			got, err := c.UpdateOrgKerberosProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.provider)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
+	type fields struct {
+		eventstore                 func(*testing.T) *eventstore.Eventstore
+		idGenerator                id.Generator
+		secretCrypto               crypto.EncryptionAlgorithm
+		certificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		provider      *SAMLProvider
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid name",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider:      &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-957lr0f8u3", ""))
+				},
+			},
+		},
+		{
+			"no metadata",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: &SAMLProvider{
+					Name: "name",
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-78isv6m53a", ""))
+				},
+			},
+		},
+		{
+			"invalid metadata, fail on error",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: []byte("metadata"),
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-SF3rwhgh", "Errors.Project.App.SAMLMetadataFormat"))
+				},
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							"name",
+							validSAMLMetadata,
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+							[]byte("certificate"),
+							"",
+							false,
+							nil,
+							"",
+							nil,
+							idp.Options{},
+						),
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)), certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: validSAMLMetadata,
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "ok all set",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							"name",
+							validSAMLMetadata,
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+							[]byte("certificate"),
+							"binding",
+							true,
+							gu.Ptr(domain.SAMLNameIDFormatTransient),
+							"customAttribute",
+							nil,
+							idp.Options{
+								IsCreationAllowed: true,
+								IsLinkingAllowed:  true,
+								IsAutoCreation:    true,
+								IsAutoUpdate:      true,
+							},
+						),
+					),
+				),
+				idGenerator:                id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				secretCrypto:               crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				certificateAndKeyGenerator: func(id string) ([]byte, []byte, error) { return []byte("key"), []byte("certificate"), nil },
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: &SAMLProvider{
+					Name:                          "name",
+					Metadata:                      validSAMLMetadata,
+					Binding:                       "binding",
+					WithSignedRequest:             true,
+					NameIDFormat:                  gu.Ptr(domain.SAMLNameIDFormatTransient),
+					TransientMappingAttributeName: "customAttribute",
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                     tt.fields.eventstore(t),
+				idGenerator:                    tt.fields.idGenerator,
+				idpConfigEncryption:            tt.fields.secretCrypto,
+				samlCertificateAndKeyGenerator: tt.fields.certificateAndKeyGenerator,
+			}
+			id, got, err := c.AddOrgSAMLProvider(tt.args.ctx, tt.args.resourceOwner, tt.args.provider)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
+	type fields struct {
+		eventstore   func(*testing.T) *eventstore.Eventstore
+		secretCrypto crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		provider      *SAMLProvider
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider:      &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-wwdwdlaya0", ""))
+				},
+			},
+		},
+		{
+			"invalid name",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider:      &SAMLProvider{},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-egixaofgyl", ""))
+				},
+			},
+		},
+		{
+			"no metadata",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: &SAMLProvider{
+					Name: "name",
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-j6spncd74m", ""))
+				},
+			},
+		},
+		{
+			"invalid metadata, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: []byte("metadata"),
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "ORG-SFqqh42", "Errors.Project.App.SAMLMetadataFormat"))
+				},
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: &SAMLProvider{
+					Name:     "name",
+					Metadata: validSAMLMetadata,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "ORG-z82dddndql", ""))
+				},
 			},
 		},
 		{
@@ -5671,6 +6294,7 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 								"id1",
 								"name",
 								validSAMLMetadata,
+								"",
 								&crypto.CryptoValue{
 									CryptoType: crypto.TypeEncryption,
 									Algorithm:  "enc",
@@ -5682,6 +6306,7 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 								false,
 								nil,
 								"",
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -5710,6 +6335,7 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 								"id1",
 								"name",
 								[]byte("metadata"),
+								"",
 								&crypto.CryptoValue{
 									CryptoType: crypto.TypeEncryption,
 									Algorithm:  "enc",
@@ -5721,6 +6347,7 @@ func TestCommandSide_UpdateOrgSAMLIDP(t *testing.T) {
 								false,
 								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
 								"",
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -5858,6 +6485,7 @@ func TestCommandSide_RegenerateOrgSAMLProviderCertificate(t *testing.T) {
 								"id1",
 								"name",
 								[]byte("metadata"),
+								"",
 								&crypto.CryptoValue{
 									CryptoType: crypto.TypeEncryption,
 									Algorithm:  "enc",
@@ -5869,6 +6497,7 @@ func TestCommandSide_RegenerateOrgSAMLProviderCertificate(t *testing.T) {
 								false,
 								gu.Ptr(domain.SAMLNameIDFormatUnspecified),
 								"",
+								nil,
 								idp.Options{},
 							)),
 					),
@@ -5925,3 +6554,125 @@ func TestCommandSide_RegenerateOrgSAMLProviderCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandSide_ActivateOrgSAMLProviderKey(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		keyID         string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key0"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				keyID:         "key1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "activate ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								validSAMLMetadata,
+								"",
+								&crypto.CryptoValue{Crypted: []byte("key")},
+								[]byte("certificate"),
+								"",
+								false,
+								nil,
+								"",
+								nil,
+								idp.Options{},
+							)),
+						eventFromEventPusher(
+							func() eventstore.Command {
+								event, _ := org.NewSAMLIDPChangedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+									"id1",
+									[]idp.SAMLIDPChanges{
+										idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+											{ID: "key1", Key: &crypto.CryptoValue{Crypted: []byte("key1")}, Certificate: []byte("certificate1")},
+										}),
+									},
+								)
+								return event
+							}(),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := org.NewSAMLIDPChangedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								[]idp.SAMLIDPChanges{
+									idp.ChangeSAMLKey(&crypto.CryptoValue{Crypted: []byte("key1")}),
+									idp.ChangeSAMLCertificate([]byte("certificate1")),
+									idp.ChangeSAMLAdditionalKeys(domain.SAMLKeys{
+										{ID: "key0", Key: &crypto.CryptoValue{Crypted: []byte("key")}, Certificate: []byte("certificate")},
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "key0"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				keyID:         "key1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.ActivateOrgSAMLProviderKey(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.keyID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
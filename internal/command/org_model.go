@@ -60,6 +60,16 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// SnapshotName implements [eventstore.SnapshotReducer]
+func (wm *OrgWriteModel) SnapshotName() string {
+	return "org"
+}
+
+// SnapshotRevision implements [eventstore.SnapshotReducer]
+func (wm *OrgWriteModel) SnapshotRevision() uint16 {
+	return 1
+}
+
 func OrgAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, org.AggregateType, org.AggregateVersion)
 }
@@ -1085,6 +1085,7 @@ func TestCommandSide_RemoveOrg(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						)),
 				),
@@ -77,7 +77,9 @@ func (c *Commands) addProjectWithID(ctx context.Context, projectAdd *domain.Proj
 			projectAdd.ProjectRoleAssertion,
 			projectAdd.ProjectRoleCheck,
 			projectAdd.HasProjectCheck,
-			projectAdd.PrivateLabelingSetting),
+			projectAdd.PrivateLabelingSetting,
+			projectAdd.AuthorizationDetailsTypes,
+		),
 	}
 	postCommit, err := c.projectCreatedMilestone(ctx, &events)
 	if err != nil {
@@ -122,6 +124,7 @@ func AddProjectCommand(
 					projectRoleCheck,
 					hasProjectCheck,
 					privateLabelingSetting,
+					nil,
 				),
 			}, nil
 		}, nil
@@ -213,7 +216,9 @@ func (c *Commands) ChangeProject(ctx context.Context, projectChange *domain.Proj
 		projectChange.ProjectRoleAssertion,
 		projectChange.ProjectRoleCheck,
 		projectChange.HasProjectCheck,
-		projectChange.PrivateLabelingSetting)
+		projectChange.PrivateLabelingSetting,
+		projectChange.AuthorizationDetailsTypes,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +102,7 @@ func TestAddAPIConfig(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						}, nil
 					}).
@@ -211,7 +212,7 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 				),
@@ -240,7 +241,7 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -294,7 +295,7 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -348,7 +349,7 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
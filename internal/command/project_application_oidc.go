@@ -34,6 +34,9 @@ type addOIDCApp struct {
 	BackChannelLogoutURI        string
 	LoginVersion                domain.LoginVersion
 	LoginBaseURI                string
+	RequirePAR                  bool
+	RequireDPoP                 bool
+	CIBANotificationURI         string
 
 	ClientID          string
 	ClientSecret      string
@@ -114,6 +117,9 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 					app.BackChannelLogoutURI,
 					app.LoginVersion,
 					app.LoginBaseURI,
+					app.RequirePAR,
+					app.RequireDPoP,
+					app.CIBANotificationURI,
 				),
 			}, nil
 		}, nil
@@ -204,6 +210,9 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		strings.TrimSpace(oidcApp.BackChannelLogoutURI),
 		oidcApp.LoginVersion,
 		strings.TrimSpace(oidcApp.LoginBaseURI),
+		oidcApp.RequirePAR,
+		oidcApp.RequireDPoP,
+		strings.TrimSpace(oidcApp.CIBANotificationURI),
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -264,6 +273,9 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		strings.TrimSpace(oidc.BackChannelLogoutURI),
 		oidc.LoginVersion,
 		strings.TrimSpace(oidc.LoginBaseURI),
+		oidc.RequirePAR,
+		oidc.RequireDPoP,
+		strings.TrimSpace(oidc.CIBANotificationURI),
 	)
 	if err != nil {
 		return nil, err
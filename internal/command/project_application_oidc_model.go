@@ -39,6 +39,9 @@ type OIDCApplicationWriteModel struct {
 	BackChannelLogoutURI     string
 	LoginVersion             domain.LoginVersion
 	LoginBaseURI             string
+	RequirePAR               bool
+	RequireDPoP              bool
+	CIBANotificationURI      string
 	oidc                     bool
 }
 
@@ -171,6 +174,9 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
 	wm.LoginVersion = e.LoginVersion
 	wm.LoginBaseURI = e.LoginBaseURI
+	wm.RequirePAR = e.RequirePAR
+	wm.RequireDPoP = e.RequireDPoP
+	wm.CIBANotificationURI = e.CIBANotificationURI
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -228,6 +234,15 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.LoginBaseURI != nil {
 		wm.LoginBaseURI = *e.LoginBaseURI
 	}
+	if e.RequirePAR != nil {
+		wm.RequirePAR = *e.RequirePAR
+	}
+	if e.RequireDPoP != nil {
+		wm.RequireDPoP = *e.RequireDPoP
+	}
+	if e.CIBANotificationURI != nil {
+		wm.CIBANotificationURI = *e.CIBANotificationURI
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -272,6 +287,9 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	backChannelLogoutURI string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	requirePAR bool,
+	requireDPoP bool,
+	cibaNotificationURI string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -330,6 +348,15 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.LoginBaseURI != loginBaseURI {
 		changes = append(changes, project.ChangeOIDCLoginBaseURI(loginBaseURI))
 	}
+	if wm.RequirePAR != requirePAR {
+		changes = append(changes, project.ChangeRequirePAR(requirePAR))
+	}
+	if wm.RequireDPoP != requireDPoP {
+		changes = append(changes, project.ChangeRequireDPoP(requireDPoP))
+	}
+	if wm.CIBANotificationURI != cibaNotificationURI {
+		changes = append(changes, project.ChangeCIBANotificationURI(cibaNotificationURI))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
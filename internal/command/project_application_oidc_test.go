@@ -145,6 +145,7 @@ func TestAddOIDCApp(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						}, nil
 					}).
@@ -178,6 +179,9 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -213,6 +217,7 @@ func TestAddOIDCApp(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						}, nil
 					}).
@@ -246,6 +251,9 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -281,6 +289,7 @@ func TestAddOIDCApp(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						}, nil
 					}).
@@ -314,6 +323,9 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -349,6 +361,7 @@ func TestAddOIDCApp(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						}, nil
 					}).
@@ -382,6 +395,9 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -470,7 +486,7 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 				),
@@ -499,7 +515,7 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -531,6 +547,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -606,7 +625,7 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -638,6 +657,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -872,6 +894,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -946,6 +971,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -1020,6 +1048,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion1,
 								"",
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -1214,6 +1245,9 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -1378,6 +1412,9 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -1416,6 +1453,9 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -1453,6 +1493,9 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -114,7 +114,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 				),
@@ -143,7 +143,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 				),
@@ -175,7 +175,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -234,7 +234,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -297,7 +297,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 					expectPush(
@@ -357,7 +357,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingUnspecified),
+								domain.PrivateLabelingSettingUnspecified, nil),
 						),
 					),
 				),
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SCIMProvisioning is the scim service provider the users granted on the project of the application are provisioned to.
+type SCIMProvisioning struct {
+	// Endpoint is the base url of the scim service provider, e.g. https://app.example.com/scim/v2
+	Endpoint string
+	// Token is sent as bearer token to the scim service provider,
+	// if empty the token of the existing configuration is kept.
+	Token string
+}
+
+func (p *SCIMProvisioning) validate() error {
+	u, err := url.Parse(p.Endpoint)
+	if err != nil || p.Endpoint == "" {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-n7iv0wq5zs", "Errors.Project.App.SCIMProvisioning.InvalidEndpoint")
+	}
+	if !u.IsAbs() || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-8xj8mu7flw", "Errors.Project.App.SCIMProvisioning.InvalidEndpoint")
+	}
+	return nil
+}
+
+// SetApplicationSCIMProvisioning sets the scim service provider of the application,
+// the users granted on the project are provisioned to it.
+func (c *Commands) SetApplicationSCIMProvisioning(ctx context.Context, projectID, appID, resourceOwner string, provisioning *SCIMProvisioning) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-c9wpp0tq4j", "Errors.IDMissing")
+	}
+	if err := provisioning.validate(); err != nil {
+		return nil, err
+	}
+
+	wm, err := c.getSCIMProvisioningWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.AppState.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-y2qm1nzb3k", "Errors.Project.App.NotExisting")
+	}
+
+	token := wm.Token
+	if provisioning.Token != "" {
+		token, err = crypto.Encrypt([]byte(provisioning.Token), c.targetEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if token == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-5o8zmz4wq3", "Errors.Project.App.SCIMProvisioning.TokenMissing")
+	}
+	if provisioning.Token == "" && wm.Endpoint == provisioning.Endpoint {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-0abdn8x1qf", "Errors.NoChangesFound")
+	}
+
+	if err := c.pushAppendAndReduce(ctx, wm, project.NewSCIMProvisioningSetEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		appID,
+		provisioning.Endpoint,
+		token,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// RemoveApplicationSCIMProvisioning stops the provisioning of the users to the scim service provider of the application.
+func (c *Commands) RemoveApplicationSCIMProvisioning(ctx context.Context, projectID, appID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-3vb5m4ld0e", "Errors.IDMissing")
+	}
+
+	wm, err := c.getSCIMProvisioningWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.AppState.Exists() || !wm.configured() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-o1z4gg8k2d", "Errors.Project.App.SCIMProvisioning.NotExisting")
+	}
+
+	if err := c.pushAppendAndReduce(ctx, wm, project.NewSCIMProvisioningRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		appID,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// SCIMProvisioningUserSynced records the successful provisioning of the user to the scim service provider of the application.
+func (c *Commands) SCIMProvisioningUserSynced(ctx context.Context, projectID, appID, resourceOwner, userID, remoteID string, state domain.SCIMProvisioningState) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.getConfiguredSCIMProvisioningWriteModel(ctx, projectID, appID, resourceOwner, userID)
+	if err != nil {
+		return err
+	}
+	return c.pushAppendAndReduce(ctx, wm, project.NewSCIMProvisioningUserSyncedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		appID,
+		userID,
+		remoteID,
+		state,
+	))
+}
+
+// SCIMProvisioningUserFailed records the failed provisioning of the user to the scim service provider of the application.
+func (c *Commands) SCIMProvisioningUserFailed(ctx context.Context, projectID, appID, resourceOwner, userID, message string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.getConfiguredSCIMProvisioningWriteModel(ctx, projectID, appID, resourceOwner, userID)
+	if err != nil {
+		return err
+	}
+	return c.pushAppendAndReduce(ctx, wm, project.NewSCIMProvisioningUserFailedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		appID,
+		userID,
+		message,
+	))
+}
+
+func (c *Commands) getConfiguredSCIMProvisioningWriteModel(ctx context.Context, projectID, appID, resourceOwner, userID string) (*SCIMProvisioningWriteModel, error) {
+	if projectID == "" || appID == "" || userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-k3u9r6w2pe", "Errors.IDMissing")
+	}
+	wm, err := c.getSCIMProvisioningWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.AppState.Exists() || !wm.configured() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-q8h2dw5c1v", "Errors.Project.App.SCIMProvisioning.NotExisting")
+	}
+	return wm, nil
+}
+
+func (c *Commands) getSCIMProvisioningWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*SCIMProvisioningWriteModel, error) {
+	wm := NewSCIMProvisioningWriteModel(projectID, appID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type SCIMProvisioningWriteModel struct {
+	eventstore.WriteModel
+
+	AppID    string
+	AppState domain.AppState
+	Endpoint string
+	Token    *crypto.CryptoValue
+}
+
+func NewSCIMProvisioningWriteModel(projectID, appID, resourceOwner string) *SCIMProvisioningWriteModel {
+	return &SCIMProvisioningWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *SCIMProvisioningWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.SCIMProvisioningSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.SCIMProvisioningRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *SCIMProvisioningWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.AppState = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.Endpoint = ""
+			wm.Token = nil
+		case *project.SCIMProvisioningSetEvent:
+			wm.Endpoint = e.Endpoint
+			wm.Token = e.Token
+		case *project.SCIMProvisioningRemovedEvent:
+			wm.Endpoint = ""
+			wm.Token = nil
+		case *project.ProjectRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.Endpoint = ""
+			wm.Token = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SCIMProvisioningWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.SCIMProvisioningSetType,
+			project.SCIMProvisioningRemovedType,
+			project.ProjectRemovedType,
+		).Builder()
+}
+
+func (wm *SCIMProvisioningWriteModel) configured() bool {
+	return wm.Endpoint != ""
+}
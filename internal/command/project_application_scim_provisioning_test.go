@@ -0,0 +1,453 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetApplicationSCIMProvisioning(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		projectID     string
+		appID         string
+		resourceOwner string
+		provisioning  *SCIMProvisioning
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	token := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("token"),
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing app id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				projectID:     "project1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v2", Token: "token"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid endpoint, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "example.com/scim/v2", Token: "token"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "app not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v2", Token: "token"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "token missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v2"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSCIMProvisioningSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://example.com/scim/v2",
+								token,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v2"},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set provisioning, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+					),
+					expectPush(
+						project.NewSCIMProvisioningSetEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"https://example.com/scim/v2",
+							token,
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v2", Token: "token"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change endpoint and keep token, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSCIMProvisioningSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://example.com/scim/v2",
+								token,
+							),
+						),
+					),
+					expectPush(
+						project.NewSCIMProvisioningSetEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"https://example.com/scim/v3",
+							token,
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+				provisioning:  &SCIMProvisioning{Endpoint: "https://example.com/scim/v3"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:       tt.fields.eventstore(t),
+				targetEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.SetApplicationSCIMProvisioning(context.Background(), tt.args.projectID, tt.args.appID, tt.args.resourceOwner, tt.args.provisioning)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveApplicationSCIMProvisioning(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		projectID     string
+		appID         string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing project id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				appID:         "app1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "provisioning not configured, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove provisioning, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSCIMProvisioningSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://example.com/scim/v2",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("token"),
+								},
+							),
+						),
+					),
+					expectPush(
+						project.NewSCIMProvisioningRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RemoveApplicationSCIMProvisioning(context.Background(), tt.args.projectID, tt.args.appID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SCIMProvisioningUserSynced(t *testing.T) {
+	type args struct {
+		userID string
+	}
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		args       args
+		wantErr    func(error) bool
+	}{
+		{
+			name:       "missing user id, invalid argument error",
+			eventstore: expectEventstore(),
+			wantErr:    zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "provisioning not configured, not found error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+					),
+				),
+			),
+			args: args{
+				userID: "user1",
+			},
+			wantErr: zerrors.IsNotFound,
+		},
+		{
+			name: "user synced, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+					),
+					eventFromEventPusher(
+						project.NewSCIMProvisioningSetEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"https://example.com/scim/v2",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("token"),
+							},
+						),
+					),
+				),
+				expectPush(
+					project.NewSCIMProvisioningUserSyncedEvent(context.Background(),
+						&project.NewAggregate("project1", "org1").Aggregate,
+						"app1",
+						"user1",
+						"remote1",
+						domain.SCIMProvisioningStateProvisioned,
+					),
+				),
+			),
+			args: args{
+				userID: "user1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			err := r.SCIMProvisioningUserSynced(context.Background(), "project1", "app1", "org1", tt.args.userID, "remote1", domain.SCIMProvisioningStateProvisioned)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+		})
+	}
+}
@@ -6,12 +6,13 @@ import (
 
 func projectWriteModelToProject(writeModel *ProjectWriteModel) *domain.Project {
 	return &domain.Project{
-		ObjectRoot:             writeModelToObjectRoot(writeModel.WriteModel),
-		Name:                   writeModel.Name,
-		ProjectRoleAssertion:   writeModel.ProjectRoleAssertion,
-		ProjectRoleCheck:       writeModel.ProjectRoleCheck,
-		HasProjectCheck:        writeModel.HasProjectCheck,
-		PrivateLabelingSetting: writeModel.PrivateLabelingSetting,
+		ObjectRoot:                writeModelToObjectRoot(writeModel.WriteModel),
+		Name:                      writeModel.Name,
+		ProjectRoleAssertion:      writeModel.ProjectRoleAssertion,
+		ProjectRoleCheck:          writeModel.ProjectRoleCheck,
+		HasProjectCheck:           writeModel.HasProjectCheck,
+		PrivateLabelingSetting:    writeModel.PrivateLabelingSetting,
+		AuthorizationDetailsTypes: writeModel.AuthorizationDetailsTypes,
 	}
 }
 
@@ -50,6 +51,9 @@ func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.O
 		BackChannelLogoutURI:     writeModel.BackChannelLogoutURI,
 		LoginVersion:             writeModel.LoginVersion,
 		LoginBaseURI:             writeModel.LoginBaseURI,
+		RequirePAR:               writeModel.RequirePAR,
+		RequireDPoP:              writeModel.RequireDPoP,
+		CIBANotificationURI:      writeModel.CIBANotificationURI,
 	}
 }
 
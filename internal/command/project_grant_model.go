@@ -188,3 +188,75 @@ func (wm *ProjectGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuil
 
 	return query
 }
+
+// projectGrantOfOrgWriteModel is the grant of a project to a specific organization
+type projectGrantOfOrgWriteModel struct {
+	eventstore.WriteModel
+
+	GrantedOrgID string
+	GrantID      string
+	RoleKeys     []string
+	State        domain.ProjectGrantState
+}
+
+func newProjectGrantOfOrgWriteModel(projectID, grantedOrgID string) *projectGrantOfOrgWriteModel {
+	return &projectGrantOfOrgWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID: projectID,
+		},
+		GrantedOrgID: grantedOrgID,
+	}
+}
+
+func (wm *projectGrantOfOrgWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.GrantAddedEvent:
+			if e.GrantedOrgID != wm.GrantedOrgID {
+				continue
+			}
+			wm.GrantID = e.GrantID
+			wm.RoleKeys = e.RoleKeys
+			wm.State = domain.ProjectGrantStateActive
+		case *project.GrantChangedEvent:
+			if e.GrantID == wm.GrantID {
+				wm.RoleKeys = e.RoleKeys
+			}
+		case *project.GrantCascadeChangedEvent:
+			if e.GrantID == wm.GrantID {
+				wm.RoleKeys = e.RoleKeys
+			}
+		case *project.GrantDeactivateEvent:
+			if e.GrantID == wm.GrantID && wm.State != domain.ProjectGrantStateRemoved {
+				wm.State = domain.ProjectGrantStateInactive
+			}
+		case *project.GrantReactivatedEvent:
+			if e.GrantID == wm.GrantID && wm.State != domain.ProjectGrantStateRemoved {
+				wm.State = domain.ProjectGrantStateActive
+			}
+		case *project.GrantRemovedEvent:
+			if e.GrantID == wm.GrantID {
+				wm.State = domain.ProjectGrantStateRemoved
+			}
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.ProjectGrantStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *projectGrantOfOrgWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.GrantAddedType,
+			project.GrantChangedType,
+			project.GrantCascadeChangedType,
+			project.GrantDeactivatedType,
+			project.GrantReactivatedType,
+			project.GrantRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
@@ -90,6 +90,7 @@ func TestCommandSide_AddProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "otherorg").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -134,6 +135,7 @@ func TestCommandSide_AddProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -164,6 +166,7 @@ func TestCommandSide_AddProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -201,6 +204,7 @@ func TestCommandSide_AddProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -388,6 +392,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "otherorg").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -434,6 +439,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -473,6 +479,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -519,6 +526,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -573,6 +581,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -651,6 +660,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -731,6 +741,7 @@ func TestCommandSide_ChangeProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -906,6 +917,7 @@ func TestCommandSide_DeactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -933,6 +945,7 @@ func TestCommandSide_DeactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -971,6 +984,7 @@ func TestCommandSide_DeactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1103,6 +1117,7 @@ func TestCommandSide_ReactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1130,6 +1145,7 @@ func TestCommandSide_ReactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1164,6 +1180,7 @@ func TestCommandSide_ReactivateProjectGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -11,12 +12,13 @@ import (
 type ProjectWriteModel struct {
 	eventstore.WriteModel
 
-	Name                   string
-	ProjectRoleAssertion   bool
-	ProjectRoleCheck       bool
-	HasProjectCheck        bool
-	PrivateLabelingSetting domain.PrivateLabelingSetting
-	State                  domain.ProjectState
+	Name                      string
+	ProjectRoleAssertion      bool
+	ProjectRoleCheck          bool
+	HasProjectCheck           bool
+	PrivateLabelingSetting    domain.PrivateLabelingSetting
+	AuthorizationDetailsTypes []string
+	State                     domain.ProjectState
 }
 
 func NewProjectWriteModel(projectID string, resourceOwner string) *ProjectWriteModel {
@@ -37,6 +39,7 @@ func (wm *ProjectWriteModel) Reduce() error {
 			wm.ProjectRoleCheck = e.ProjectRoleCheck
 			wm.HasProjectCheck = e.HasProjectCheck
 			wm.PrivateLabelingSetting = e.PrivateLabelingSetting
+			wm.AuthorizationDetailsTypes = e.AuthorizationDetailsTypes
 			wm.State = domain.ProjectStateActive
 		case *project.ProjectChangeEvent:
 			if e.Name != nil {
@@ -54,6 +57,9 @@ func (wm *ProjectWriteModel) Reduce() error {
 			if e.PrivateLabelingSetting != nil {
 				wm.PrivateLabelingSetting = *e.PrivateLabelingSetting
 			}
+			if e.AuthorizationDetailsTypes != nil {
+				wm.AuthorizationDetailsTypes = *e.AuthorizationDetailsTypes
+			}
 		case *project.ProjectDeactivatedEvent:
 			if wm.State == domain.ProjectStateRemoved {
 				continue
@@ -93,6 +99,7 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	projectRoleCheck,
 	hasProjectCheck bool,
 	privateLabelingSetting domain.PrivateLabelingSetting,
+	authorizationDetailsTypes []string,
 ) (*project.ProjectChangeEvent, bool, error) {
 	changes := make([]project.ProjectChanges, 0)
 	var err error
@@ -114,6 +121,9 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	if wm.PrivateLabelingSetting != privateLabelingSetting {
 		changes = append(changes, project.ChangePrivateLabelingSetting(privateLabelingSetting))
 	}
+	if !slices.Equal(wm.AuthorizationDetailsTypes, authorizationDetailsTypes) {
+		changes = append(changes, project.ChangeAuthorizationDetailsTypes(authorizationDetailsTypes))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
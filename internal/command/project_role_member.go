@@ -0,0 +1,161 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ProjectRoleMembers describes the changes of a project role and the users granted with it.
+type ProjectRoleMembers struct {
+	// Role identifies the role (project and key) and contains the desired display name and group.
+	Role *domain.ProjectRole
+	// Added are the users to grant the role to.
+	Added []*ProjectRoleMember
+	// RemovedGrantIDs are the ids of the user grants to revoke the role from.
+	// User grants without any remaining role are removed.
+	RemovedGrantIDs []string
+}
+
+// ProjectRoleMember is a user to grant the role to.
+// If the user already has a grant on the project, GrantID is set and the role is added to it.
+type ProjectRoleMember struct {
+	UserID  string
+	GrantID string
+}
+
+// ChangeProjectRoleMembers changes the role and the user grants of its members.
+// All changes are validated before any is pushed, so either all or none of the changes are applied.
+// Changing the members requires the permission to write user grants of the project.
+func (c *Commands) ChangeProjectRoleMembers(ctx context.Context, members *ProjectRoleMembers, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if members.Role == nil || !members.Role.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rm3bQ", "Errors.Project.Role.Invalid")
+	}
+	projectID, key := members.Role.AggregateID, members.Role.Key
+	existingRole, err := c.getProjectRoleWriteModelByID(ctx, key, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingRole.State == domain.ProjectRoleStateUnspecified || existingRole.State == domain.ProjectRoleStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rm4pW", "Errors.Project.Role.NotExisting")
+	}
+	if len(members.Added) > 0 || len(members.RemovedGrantIDs) > 0 {
+		if err = c.checkPermission(ctx, domain.PermissionUserGrantWrite, resourceOwner, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	cmds := make([]eventstore.Command, 0, len(members.Added)+len(members.RemovedGrantIDs)+1)
+	changeEvent, changed, err := existingRole.NewProjectRoleChangedEvent(ctx, ProjectAggregateFromWriteModel(&existingRole.WriteModel), key, members.Role.DisplayName, members.Role.Group)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		cmds = append(cmds, changeEvent)
+	}
+	for _, grantID := range members.RemovedGrantIDs {
+		cmd, err := c.revokeProjectRoleFromUserGrant(ctx, projectID, key, grantID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	for _, member := range members.Added {
+		cmd, err := c.grantProjectRoleToMember(ctx, projectID, key, member, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	if err = c.pushAppendAndReduce(ctx, existingRole, cmds...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingRole.WriteModel), nil
+}
+
+// RemoveProjectRoleWithMembers removes the role and revokes it from the user grants of its members.
+// Revoking the role requires the permission to write user grants of the project.
+func (c *Commands) RemoveProjectRoleWithMembers(ctx context.Context, projectID, key, resourceOwner string, userGrantIDs ...string) (_ *domain.ObjectDetails, err error) {
+	if len(userGrantIDs) > 0 {
+		if err = c.checkPermission(ctx, domain.PermissionUserGrantWrite, resourceOwner, projectID); err != nil {
+			return nil, err
+		}
+	}
+	return c.RemoveProjectRole(ctx, projectID, key, resourceOwner, nil, userGrantIDs...)
+}
+
+func (c *Commands) grantProjectRoleToMember(ctx context.Context, projectID, key string, member *ProjectRoleMember, resourceOwner string) (eventstore.Command, error) {
+	if member.GrantID == "" {
+		cmd, _, err := c.addUserGrant(ctx, &domain.UserGrant{
+			UserID:    member.UserID,
+			ProjectID: projectID,
+			RoleKeys:  []string{key},
+		}, resourceOwner)
+		return cmd, err
+	}
+	existing, err := c.projectRoleMemberGrant(ctx, projectID, member.GrantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserID != member.UserID {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rm6uT", "Errors.UserGrant.Invalid")
+	}
+	if slices.Contains(existing.RoleKeys, key) {
+		return nil, nil
+	}
+	cmd, _, err := c.changeUserGrant(ctx, &domain.UserGrant{
+		ObjectRoot: models.ObjectRoot{AggregateID: member.GrantID, ResourceOwner: resourceOwner},
+		UserID:     member.UserID,
+		RoleKeys:   append(slices.Clone(existing.RoleKeys), key),
+	}, resourceOwner, false)
+	return cmd, err
+}
+
+func (c *Commands) revokeProjectRoleFromUserGrant(ctx context.Context, projectID, key, grantID, resourceOwner string) (eventstore.Command, error) {
+	existing, err := c.projectRoleMemberGrant(ctx, projectID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(existing.RoleKeys, key) {
+		return nil, nil
+	}
+	roleKeys := slices.DeleteFunc(slices.Clone(existing.RoleKeys), func(roleKey string) bool {
+		return roleKey == key
+	})
+	// a grant without roles has no meaning for the members of a role
+	if len(roleKeys) == 0 {
+		cmd, _, err := c.removeUserGrant(ctx, grantID, resourceOwner, false)
+		return cmd, err
+	}
+	cmd, _, err := c.changeUserGrant(ctx, &domain.UserGrant{
+		ObjectRoot: models.ObjectRoot{AggregateID: grantID, ResourceOwner: resourceOwner},
+		UserID:     existing.UserID,
+		RoleKeys:   roleKeys,
+	}, resourceOwner, false)
+	return cmd, err
+}
+
+// projectRoleMemberGrant returns the active or inactive user grant of the project.
+func (c *Commands) projectRoleMemberGrant(ctx context.Context, projectID, grantID, resourceOwner string) (*UserGrantWriteModel, error) {
+	existing, err := c.userGrantWriteModelByID(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.UserGrantStateUnspecified || existing.State == domain.UserGrantStateRemoved || existing.ProjectID != projectID {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rm5kE", "Errors.UserGrant.NotFound")
+	}
+	return existing, nil
+}
@@ -0,0 +1,242 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_ChangeProjectRoleMembers(t *testing.T) {
+	roleAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			project.NewRoleAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"key1",
+				"key",
+				"",
+			),
+		)
+	}
+	grantAdded := func(grantID string, roleKeys ...string) eventstore.Event {
+		return eventFromEventPusher(
+			usergrant.NewUserGrantAddedEvent(context.Background(),
+				&usergrant.NewAggregate(grantID, "org1").Aggregate,
+				"user1",
+				"project1",
+				"", roleKeys),
+		)
+	}
+	roleChanged := func(displayName string) *project.RoleChangedEvent {
+		event, _ := project.NewRoleChangedEvent(context.Background(),
+			&project.NewAggregate("project1", "org1").Aggregate,
+			"key1",
+			[]project.RoleChanges{project.ChangeDisplayName(displayName)},
+		)
+		return event
+	}
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx     context.Context
+		members *ProjectRoleMembers
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid role, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				members: &ProjectRoleMembers{
+					Role: &domain.ProjectRole{ObjectRoot: models.ObjectRoot{AggregateID: "project1"}},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "role not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				members: &ProjectRoleMembers{
+					Role: &domain.ProjectRole{ObjectRoot: models.ObjectRoot{AggregateID: "project1"}, Key: "key1"},
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no permission to change members, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(roleAdded()),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				members: &ProjectRoleMembers{
+					Role:  &domain.ProjectRole{ObjectRoot: models.ObjectRoot{AggregateID: "project1"}, Key: "key1", DisplayName: "key"},
+					Added: []*ProjectRoleMember{{UserID: "user1"}},
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "member grant of other project, nothing pushed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(roleAdded()),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project2",
+								"", []string{"key1"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				members: &ProjectRoleMembers{
+					Role:            &domain.ProjectRole{ObjectRoot: models.ObjectRoot{AggregateID: "project1"}, Key: "key1", DisplayName: "changed"},
+					RemovedGrantIDs: []string{"usergrant1"},
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "role and members changed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(roleAdded()),
+					// revoke the role from usergrant2, which has no other role
+					expectFilter(grantAdded("usergrant2", "key1")),
+					expectFilter(grantAdded("usergrant2", "key1")),
+					// grant the role to the existing usergrant1
+					expectFilter(grantAdded("usergrant1", "key2")),
+					expectFilter(grantAdded("usergrant1", "key2")),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+								nil,
+							),
+						),
+						roleAdded(),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"key2",
+								"key 2",
+								"",
+							),
+						),
+					),
+					expectPush(
+						roleChanged("changed"),
+						usergrant.NewUserGrantRemovedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant2", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+						),
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							[]string{"key2", "key1"},
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				members: &ProjectRoleMembers{
+					Role:            &domain.ProjectRole{ObjectRoot: models.ObjectRoot{AggregateID: "project1"}, Key: "key1", DisplayName: "changed"},
+					Added:           []*ProjectRoleMember{{UserID: "user1", GrantID: "usergrant1"}},
+					RemovedGrantIDs: []string{"usergrant2"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "project1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := r.ChangeProjectRoleMembers(tt.args.ctx, tt.args.members, "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ func TestCommandSide_AddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -81,6 +82,7 @@ func TestCommandSide_AddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -110,6 +112,7 @@ func TestCommandSide_AddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -151,6 +154,7 @@ func TestCommandSide_AddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -240,6 +244,7 @@ func TestCommandSide_BulkAddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -279,6 +284,7 @@ func TestCommandSide_BulkAddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -312,6 +318,7 @@ func TestCommandSide_BulkAddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -365,6 +372,7 @@ func TestCommandSide_BulkAddProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -479,6 +487,7 @@ func TestCommandSide_ChangeProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -516,6 +525,7 @@ func TestCommandSide_ChangeProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -564,6 +574,7 @@ func TestCommandSide_ChangeProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -606,6 +617,7 @@ func TestCommandSide_ChangeProjectRole(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
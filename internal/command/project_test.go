@@ -86,6 +86,7 @@ func TestCommandSide_AddProject(t *testing.T) {
 							&project.NewAggregate("project1", "org1").Aggregate,
 							"project", true, true, true,
 							domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+							nil,
 						),
 					),
 				),
@@ -116,7 +117,7 @@ func TestCommandSide_AddProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 				),
@@ -149,6 +150,7 @@ func TestCommandSide_AddProject(t *testing.T) {
 							&project.NewAggregate("project1", "org1").Aggregate,
 							"project", true, true, true,
 							domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+							nil,
 						),
 					),
 				),
@@ -290,7 +292,7 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectRemovedEvent(context.Background(),
@@ -325,7 +327,7 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 				),
@@ -358,7 +360,7 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					expectPush(
@@ -413,7 +415,7 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					expectPush(
@@ -556,7 +558,7 @@ func TestCommandSide_DeactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectRemovedEvent(context.Background(),
@@ -586,7 +588,7 @@ func TestCommandSide_DeactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectDeactivatedEvent(context.Background(),
@@ -614,7 +616,7 @@ func TestCommandSide_DeactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					expectPush(
@@ -732,7 +734,7 @@ func TestCommandSide_ReactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectRemovedEvent(context.Background(),
@@ -762,7 +764,7 @@ func TestCommandSide_ReactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 				),
@@ -786,7 +788,7 @@ func TestCommandSide_ReactivateProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectDeactivatedEvent(context.Background(),
@@ -908,7 +910,7 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 						eventFromEventPusher(
 							project.NewProjectRemovedEvent(context.Background(),
@@ -938,7 +940,7 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					// no saml application events
@@ -972,7 +974,7 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					expectFilter(
@@ -1025,7 +1027,7 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 							project.NewProjectAddedEvent(context.Background(),
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"project", true, true, true,
-								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy, nil),
 						),
 					),
 					expectFilter(
@@ -1208,6 +1210,7 @@ func TestAddProject(t *testing.T) {
 						false,
 						false,
 						domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						nil,
 					),
 				},
 			},
@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/samlrequest"
 	"github.com/zitadel/zitadel/internal/repository/samlsession"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -31,9 +32,18 @@ type SAMLSession struct {
 	UserAgent         *domain.UserAgent
 }
 
+// SAMLSessionLogout contains the information sent to the service provider in the assertion,
+// which is needed to terminate the session at the service provider on logout.
+type SAMLSessionLogout struct {
+	Issuer       string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+}
+
 type SAMLRequestComplianceChecker func(context.Context, *SAMLRequestWriteModel) error
 
-func (c *Commands) CreateSAMLSessionFromSAMLRequest(ctx context.Context, samlReqId string, complianceCheck SAMLRequestComplianceChecker, samlResponseID string, samlResponseLifetime time.Duration) (err error) {
+func (c *Commands) CreateSAMLSessionFromSAMLRequest(ctx context.Context, samlReqId string, complianceCheck SAMLRequestComplianceChecker, samlResponseID string, samlResponseLifetime time.Duration, logout *SAMLSessionLogout) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -79,6 +89,7 @@ func (c *Commands) CreateSAMLSessionFromSAMLRequest(ctx context.Context, samlReq
 	if err = cmd.AddSAMLResponse(ctx, samlResponseID, samlResponseLifetime); err != nil {
 		return err
 	}
+	cmd.RegisterLogout(ctx, sessionModel.AggregateID, sessionModel.UserID, samlReqModel.Issuer, logout)
 	cmd.SetSAMLRequestSuccessful(ctx, samlReqModel.aggregate)
 	_, err = cmd.PushEvents(ctx)
 	return err
@@ -154,6 +165,25 @@ func (c *SAMLSessionEvents) SetSAMLRequestFailed(ctx context.Context, samlReques
 	c.events = append(c.events, samlrequest.NewFailedEvent(ctx, samlRequestAggregate, err))
 }
 
+func (c *SAMLSessionEvents) RegisterLogout(ctx context.Context, sessionID, userID, entityID string, logout *SAMLSessionLogout) {
+	// If there's no SSO session or the service provider does not support single logout,
+	// we do not need to register a logout.
+	if sessionID == "" || logout == nil {
+		return
+	}
+	c.events = append(c.events, sessionlogout.NewSAMLLogoutRegisteredEvent(
+		ctx,
+		&sessionlogout.NewAggregate(sessionID, authz.GetInstance(ctx).InstanceID()).Aggregate,
+		c.samlSessionWriteModel.AggregateID,
+		userID,
+		entityID,
+		logout.Issuer,
+		logout.NameID,
+		logout.NameIDFormat,
+		logout.SessionIndex,
+	))
+}
+
 func (c *SAMLSessionEvents) AddSAMLResponse(ctx context.Context, id string, lifetime time.Duration) error {
 	c.samlResponseID = id
 	c.events = append(c.events, samlsession.NewSAMLResponseAddedEvent(ctx, c.samlSessionWriteModel.aggregate, id, lifetime))
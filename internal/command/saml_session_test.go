@@ -22,6 +22,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/samlrequest"
 	"github.com/zitadel/zitadel/internal/repository/samlsession"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -44,6 +45,7 @@ func TestCommands_CreateSAMLSessionFromSAMLRequest(t *testing.T) {
 		samlResponseID       string
 		complianceCheck      SAMLRequestComplianceChecker
 		samlResponseLifetime time.Duration
+		logout               *SAMLSessionLogout
 	}
 	type res struct {
 		err error
@@ -322,6 +324,103 @@ func TestCommands_CreateSAMLSessionFromSAMLRequest(t *testing.T) {
 			},
 			res{},
 		},
+		{
+			"add successful, logout registered",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							samlrequest.NewAddedEvent(context.Background(), &samlrequest.NewAggregate("V2_samlRequestID", "instanceID").Aggregate,
+								"loginClient",
+								"applicationId",
+								"acs",
+								"relaystate",
+								"request",
+								"binding",
+								"issuer",
+								"destination",
+							),
+						),
+						eventFromEventPusher(
+							samlrequest.NewSessionLinkedEvent(context.Background(), &samlrequest.NewAggregate("V2_samlRequestID", "instanceID").Aggregate,
+								"sessionID",
+								"userID",
+								testNow,
+								[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								&domain.UserAgent{
+									FingerprintID: gu.Ptr("fp1"),
+									IP:            net.ParseIP("1.2.3.4"),
+									Description:   gu.Ptr("firefox"),
+									Header:        http.Header{"foo": []string{"bar"}},
+								},
+							),
+						),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+								"userID", "org1", testNow, &language.Afrikaans),
+						),
+						eventFromEventPusher(
+							session.NewPasswordCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+								testNow),
+						),
+					),
+					expectFilter(
+						user.NewHumanAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"username",
+							"firstname",
+							"lastname",
+							"nickname",
+							"displayname",
+							language.Afrikaans,
+							domain.GenderUnspecified,
+							"email",
+							false,
+						),
+					),
+					expectPush(
+						samlsession.NewAddedEvent(context.Background(), &samlsession.NewAggregate("V2_samlSessionID", "org1").Aggregate,
+							"userID", "org1", "sessionID", "issuer", []string{"issuer"},
+							[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword}, testNow, &language.Afrikaans,
+							&domain.UserAgent{
+								FingerprintID: gu.Ptr("fp1"),
+								IP:            net.ParseIP("1.2.3.4"),
+								Description:   gu.Ptr("firefox"),
+								Header:        http.Header{"foo": []string{"bar"}},
+							},
+						),
+						samlsession.NewSAMLResponseAddedEvent(context.Background(), &samlsession.NewAggregate("V2_samlSessionID", "org1").Aggregate, "samlResponseID", time.Minute*5),
+						sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate,
+							"V2_samlSessionID", "userID", "issuer", "destination", "user@example.com", "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress", "sessionIndex"),
+						samlrequest.NewSucceededEvent(context.Background(), &samlrequest.NewAggregate("V2_samlRequestID", "instanceID").Aggregate),
+					),
+				),
+				idGenerator:  mock.NewIDGeneratorExpectIDs(t, "samlSessionID"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args{
+				ctx:                  authz.WithInstanceID(context.Background(), "instanceID"),
+				samlRequestID:        "V2_samlRequestID",
+				samlResponseID:       "samlResponseID",
+				samlResponseLifetime: time.Minute * 5,
+				complianceCheck:      mockSAMLRequestComplianceChecker(nil),
+				logout: &SAMLSessionLogout{
+					Issuer:       "destination",
+					NameID:       "user@example.com",
+					NameIDFormat: "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+					SessionIndex: "sessionIndex",
+				},
+			},
+			res{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -330,7 +429,7 @@ func TestCommands_CreateSAMLSessionFromSAMLRequest(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			err := c.CreateSAMLSessionFromSAMLRequest(tt.args.ctx, tt.args.samlRequestID, tt.args.complianceCheck, tt.args.samlResponseID, tt.args.samlResponseLifetime)
+			err := c.CreateSAMLSessionFromSAMLRequest(tt.args.ctx, tt.args.samlRequestID, tt.args.complianceCheck, tt.args.samlResponseID, tt.args.samlResponseLifetime, tt.args.logout)
 			require.ErrorIs(t, err, tt.res.err)
 		})
 	}
@@ -0,0 +1,188 @@
+package command
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SyncLDAPUserGrants grants the user the project roles mapped to its LDAP groups.
+// Roles of the mapping the user is no longer entitled to are removed from its grants, other roles are kept.
+// A grant is removed if no role is left.
+// The orgID is the organization of the identity provider or empty for identity providers of the instance.
+// Projects of the mapping, which do not belong to and are not granted to the organization, are skipped.
+func (c *Commands) SyncLDAPUserGrants(ctx context.Context, userID, orgID string, mapping *domain.LDAPGroupMapping, groups []string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gq5vx", "Errors.IDMissing")
+	}
+	if mapping.IsZero() {
+		return nil
+	}
+	granted, managed := mapping.ProjectRoles(groups)
+	targets := make(map[string]*ldapGrantTarget, len(managed))
+	for _, projectID := range mapping.ProjectIDs() {
+		target, err := c.ldapGrantTarget(ctx, orgID, projectID)
+		if err != nil {
+			logging.WithFields("org", orgID, "project", projectID).WithError(err).Warn("ldap group mapping skipped for project")
+			continue
+		}
+		targets[projectID] = target
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	grants, err := c.userProjectGrants(ctx, userID, targets)
+	if err != nil {
+		return err
+	}
+	cmds := make([]eventstore.Command, 0, len(targets))
+	for _, projectID := range slices.Sorted(maps.Keys(targets)) {
+		cmd, err := c.syncLDAPUserGrant(ctx, userID, targets[projectID], grants[projectID], granted[projectID], managed[projectID])
+		if err != nil {
+			return err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+// ldapGrantTarget is the project or project grant the roles of an LDAP group mapping are granted on
+type ldapGrantTarget struct {
+	projectID      string
+	projectGrantID string
+	// resourceOwner of the user grants
+	resourceOwner string
+}
+
+// ldapGrantTarget resolves the project of a group mapping for the organization of the identity provider.
+// The project must belong to the organization or be granted to it.
+// Identity providers of the instance (empty orgID) can map any project of the instance.
+func (c *Commands) ldapGrantTarget(ctx context.Context, orgID, projectID string) (*ldapGrantTarget, error) {
+	project, err := c.getProjectWriteModelByID(ctx, projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !isProjectStateExists(project.State) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Nf2ke", "Errors.Project.NotFound")
+	}
+	if orgID == "" || project.ResourceOwner == orgID {
+		return &ldapGrantTarget{projectID: projectID, resourceOwner: project.ResourceOwner}, nil
+	}
+	grant := newProjectGrantOfOrgWriteModel(projectID, orgID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, grant); err != nil {
+		return nil, err
+	}
+	if grant.State != domain.ProjectGrantStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hq4ob", "Errors.Project.Grant.NotFound")
+	}
+	return &ldapGrantTarget{projectID: projectID, projectGrantID: grant.GrantID, resourceOwner: orgID}, nil
+}
+
+// checkLDAPGroupMapping ensures the projects of the mapping belong to or are granted to the organization of the identity provider
+// and that the caller is allowed to grant their roles, as the grants are created without further permission checks on login.
+func (c *Commands) checkLDAPGroupMapping(ctx context.Context, orgID string, mapping *domain.LDAPGroupMapping) error {
+	for _, projectID := range mapping.ProjectIDs() {
+		target, err := c.ldapGrantTarget(ctx, orgID, projectID)
+		if err != nil {
+			return err
+		}
+		resourceID := target.projectID
+		if target.projectGrantID != "" {
+			resourceID = target.projectGrantID
+		}
+		if err = c.checkPermission(ctx, domain.PermissionUserGrantWrite, target.resourceOwner, resourceID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Commands) syncLDAPUserGrant(ctx context.Context, userID string, target *ldapGrantTarget, existing *UserGrantWriteModel, granted, managed []string) (eventstore.Command, error) {
+	if existing == nil {
+		if len(granted) == 0 {
+			return nil, nil
+		}
+		cmd, _, err := c.addUserGrant(ctx, &domain.UserGrant{
+			UserID:         userID,
+			ProjectID:      target.projectID,
+			ProjectGrantID: target.projectGrantID,
+			RoleKeys:       granted,
+		}, target.resourceOwner)
+		return cmd, err
+	}
+
+	roleKeys := make([]string, 0, len(existing.RoleKeys)+len(granted))
+	for _, key := range existing.RoleKeys {
+		if !slices.Contains(managed, key) || slices.Contains(granted, key) {
+			roleKeys = append(roleKeys, key)
+		}
+	}
+	for _, key := range granted {
+		if !slices.Contains(roleKeys, key) {
+			roleKeys = append(roleKeys, key)
+		}
+	}
+	userGrantAgg := UserGrantAggregateFromWriteModel(&existing.WriteModel)
+	if len(roleKeys) == 0 {
+		return usergrant.NewUserGrantRemovedEvent(ctx, userGrantAgg, userID, target.projectID, target.projectGrantID), nil
+	}
+	if len(roleKeys) == len(existing.RoleKeys) && !slices.ContainsFunc(roleKeys, func(key string) bool {
+		return !slices.Contains(existing.RoleKeys, key)
+	}) {
+		return nil, nil
+	}
+	err := c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+		UserID:         userID,
+		ProjectID:      target.projectID,
+		ProjectGrantID: target.projectGrantID,
+		RoleKeys:       roleKeys,
+	}, existing.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return usergrant.NewUserGrantChangedEvent(ctx, userGrantAgg, userID, roleKeys), nil
+}
+
+// userProjectGrants returns the existing grants of the user on the targets by project ID
+func (c *Commands) userProjectGrants(ctx context.Context, userID string, targets map[string]*ldapGrantTarget) (_ map[string]*UserGrantWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userGrants := newUserGrantsOfUserReadModel(userID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, userGrants); err != nil {
+		return nil, err
+	}
+	grants := make(map[string]*UserGrantWriteModel, len(targets))
+	for _, grant := range userGrants.Grants {
+		target, ok := targets[grant.ProjectID]
+		if !ok || grant.ProjectGrantID != target.projectGrantID {
+			continue
+		}
+		writeModel, err := c.userGrantWriteModelByID(ctx, grant.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		if writeModel.State == domain.UserGrantStateUnspecified || writeModel.State == domain.UserGrantStateRemoved {
+			continue
+		}
+		grants[grant.ProjectID] = writeModel
+	}
+	return grants, nil
+}
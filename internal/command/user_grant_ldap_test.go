@@ -0,0 +1,418 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SyncLDAPUserGrants(t *testing.T) {
+	mapping := &domain.LDAPGroupMapping{
+		GroupAttribute: "memberOf",
+		Groups: []*domain.LDAPGroupRole{
+			{Group: "cn=admins,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin"}},
+			{Group: "cn=users,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"user"}},
+		},
+	}
+	userAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username1",
+				"firstname1",
+				"lastname1",
+				"nickname1",
+				"displayname1",
+				language.German,
+				domain.GenderMale,
+				"email1",
+				true,
+			),
+		)
+	}
+	projectAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			project.NewProjectAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"projectname1", true, true, true,
+				domain.PrivateLabelingSettingUnspecified,
+				nil,
+			),
+		)
+	}
+	roleAdded := func(key string) eventstore.Event {
+		return eventFromEventPusher(
+			project.NewRoleAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				key,
+				key,
+				"",
+			),
+		)
+	}
+	projectGrantAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			project.NewGrantAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"projectgrant1",
+				"org2",
+				[]string{"admin"},
+			),
+		)
+	}
+	grantAdded := func(roleKeys ...string) eventstore.Event {
+		return eventFromEventPusher(
+			usergrant.NewUserGrantAddedEvent(context.Background(),
+				&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+				"user1",
+				"project1",
+				"",
+				roleKeys,
+			),
+		)
+	}
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		userID  string
+		orgID   string
+		mapping *domain.LDAPGroupMapping
+		groups  []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			name: "missing user id, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID:   "org1",
+				mapping: mapping,
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "no mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+				groups: []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "project not found, skipped",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "project of other org, skipped",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org2",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "no grant and no group, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+			},
+		},
+		{
+			name: "grant added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(),
+					expectFilter(
+						userAdded(),
+						projectAdded(),
+						roleAdded("admin"),
+						roleAdded("user"),
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin", "user"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+				groups:  []string{"CN=Admins,DC=example,DC=com", "cn=users,dc=example,dc=com", "cn=others,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "instance idp, grant added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(),
+					expectFilter(
+						userAdded(),
+						projectAdded(),
+						roleAdded("admin"),
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				userID:  "user1",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "granted project, grant added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(
+						projectGrantAdded(),
+					),
+					expectFilter(),
+					expectFilter(
+						userAdded(),
+						projectAdded(),
+						roleAdded("admin"),
+						roleAdded("user"),
+						projectGrantAdded(),
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"projectgrant1",
+							[]string{"admin"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org2",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "grant changed, unmanaged role kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(
+						grantAdded("admin", "other"),
+					),
+					expectFilter(
+						grantAdded("admin", "other"),
+					),
+					expectFilter(
+						userAdded(),
+						projectAdded(),
+						roleAdded("admin"),
+						roleAdded("user"),
+						roleAdded("other"),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							[]string{"other", "user"},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+				groups:  []string{"cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "grant unchanged, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(
+						grantAdded("user", "admin"),
+					),
+					expectFilter(
+						grantAdded("user", "admin"),
+					),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "grant removed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(
+						grantAdded("admin"),
+					),
+					expectFilter(
+						grantAdded("admin"),
+					),
+					expectPush(
+						usergrant.NewUserGrantRemovedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+			},
+		},
+		{
+			name: "removed grant added again, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						projectAdded(),
+					),
+					expectFilter(
+						grantAdded("admin"),
+					),
+					expectFilter(
+						grantAdded("admin"),
+						eventFromEventPusher(
+							usergrant.NewUserGrantRemovedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"",
+							),
+						),
+					),
+					expectFilter(
+						userAdded(),
+						projectAdded(),
+						roleAdded("admin"),
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant2", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant2"),
+			},
+			args: args{
+				userID:  "user1",
+				orgID:   "org1",
+				mapping: mapping,
+				groups:  []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			err := c.SyncLDAPUserGrants(context.Background(), tt.args.userID, tt.args.orgID, tt.args.mapping, tt.args.groups)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
@@ -184,3 +184,46 @@ func (wm *UserGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder
 		Builder()
 	return query
 }
+
+// userGrantsOfUserReadModel collects the grants ever added to the user,
+// their current state must be loaded from the [UserGrantWriteModel].
+type userGrantsOfUserReadModel struct {
+	eventstore.WriteModel
+
+	UserID string
+	Grants []*userGrantOfUser
+}
+
+type userGrantOfUser struct {
+	ID             string
+	ProjectID      string
+	ProjectGrantID string
+}
+
+func newUserGrantsOfUserReadModel(userID string) *userGrantsOfUserReadModel {
+	return &userGrantsOfUserReadModel{
+		UserID: userID,
+	}
+}
+
+func (rm *userGrantsOfUserReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if e, ok := event.(*usergrant.UserGrantAddedEvent); ok && e.UserID == rm.UserID {
+			rm.Grants = append(rm.Grants, &userGrantOfUser{
+				ID:             e.Aggregate().ID,
+				ProjectID:      e.ProjectID,
+				ProjectGrantID: e.ProjectGrantID,
+			})
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *userGrantsOfUserReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{"userId": rm.UserID}).
+		Builder()
+}
@@ -126,6 +126,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -175,6 +176,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -217,6 +219,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -260,6 +263,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -304,6 +308,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -364,6 +369,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -424,6 +430,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -494,6 +501,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -807,6 +815,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -868,6 +877,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -923,6 +933,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -979,6 +990,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -1051,6 +1063,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -1138,6 +1151,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&project.NewAggregate("project1", "org1").Aggregate,
 								"projectname1", true, true, true,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -82,7 +83,7 @@ func (c *Commands) HumanAddU2FSetup(ctx context.Context, userID, resourceowner s
 	if err != nil {
 		return nil, err
 	}
-	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, "", u2fTokens, domain.AuthenticatorAttachmentUnspecified, domain.UserVerificationRequirementDiscouraged)
+	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, "", u2fTokens, domain.AuthenticatorAttachmentUnspecified, domain.UserVerificationRequirementDiscouraged, false)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +109,7 @@ func (c *Commands) HumanAddPasswordlessSetup(ctx context.Context, userID, resour
 	if err != nil {
 		return nil, err
 	}
-	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, "", passwordlessTokens, authenticatorPlatform, domain.UserVerificationRequirementRequired)
+	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, "", passwordlessTokens, authenticatorPlatform, domain.UserVerificationRequirementRequired, true)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +138,7 @@ func (c *Commands) HumanAddPasswordlessSetupInitCode(ctx context.Context, userID
 	return c.HumanAddPasswordlessSetup(ctx, userID, resourceowner, preferredPlatformType)
 }
 
-func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner, rpID string, tokens []*domain.WebAuthNToken, authenticatorPlatform domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement) (*HumanWebAuthNWriteModel, *eventstore.Aggregate, *domain.WebAuthNToken, error) {
+func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner, rpID string, tokens []*domain.WebAuthNToken, authenticatorPlatform domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, residentKey bool) (*HumanWebAuthNWriteModel, *eventstore.Aggregate, *domain.WebAuthNToken, error) {
 	if userID == "" {
 		return nil, nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-3M0od", "Errors.IDMissing")
 	}
@@ -160,7 +161,7 @@ func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner,
 	if accountName == "" {
 		accountName = string(user.EmailAddress)
 	}
-	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, rpID, tokens...)
+	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, residentKey, rpID, tokens...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -416,6 +417,31 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 	}
 
 	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, passwordlessTokens)
+	return c.pushPasswordlessLoginChecked(ctx, userID, resourceOwner, userAgg, token, signCount, authRequest, err)
+}
+
+// HumanBeginDiscoverablePasswordlessLogin creates a passwordless challenge, which is not bound to a user.
+// The user will be resolved from the discoverable credential (passkey) used to sign it.
+func (c *Commands) HumanBeginDiscoverablePasswordlessLogin(ctx context.Context) (*domain.WebAuthNLogin, error) {
+	return c.webauthnConfig.BeginDiscoverableLogin(ctx, domain.UserVerificationRequirementRequired, "")
+}
+
+// HumanFinishDiscoverablePasswordlessLogin verifies the assertion for a challenge created by [Commands.HumanBeginDiscoverablePasswordlessLogin].
+// The user must have been resolved from the user handle of the assertion.
+func (c *Commands) HumanFinishDiscoverablePasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthNLogin *domain.WebAuthNLogin, authRequest *domain.AuthRequest) error {
+	if webAuthNLogin == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wd8qr", "Errors.User.WebAuthN.NoChallenge")
+	}
+	passwordlessTokens, err := c.getHumanPasswordlessTokens(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+
+	userAgg, token, signCount, err := c.finishDiscoverableWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, passwordlessTokens)
+	return c.pushPasswordlessLoginChecked(ctx, userID, resourceOwner, userAgg, token, signCount, authRequest, err)
+}
+
+func (c *Commands) pushPasswordlessLoginChecked(ctx context.Context, userID, resourceOwner string, userAgg *eventstore.Aggregate, token *domain.WebAuthNToken, signCount uint32, authRequest *domain.AuthRequest, err error) error {
 	if err != nil {
 		if userAgg == nil {
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed passwordless check event")
@@ -449,6 +475,18 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 }
 
 func (c *Commands) finishWebAuthNLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthN *domain.WebAuthNLogin, tokens []*domain.WebAuthNToken) (*eventstore.Aggregate, *domain.WebAuthNToken, uint32, error) {
+	return c.validateWebAuthNLogin(ctx, userID, resourceOwner, tokens, func(human *domain.Human) (*webauthn.Credential, error) {
+		return c.webauthnConfig.FinishLogin(ctx, human, webAuthN, credentialData, tokens...)
+	})
+}
+
+func (c *Commands) finishDiscoverableWebAuthNLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthN *domain.WebAuthNLogin, tokens []*domain.WebAuthNToken) (*eventstore.Aggregate, *domain.WebAuthNToken, uint32, error) {
+	return c.validateWebAuthNLogin(ctx, userID, resourceOwner, tokens, func(human *domain.Human) (*webauthn.Credential, error) {
+		return c.webauthnConfig.FinishDiscoverableLogin(ctx, human, webAuthN, credentialData, tokens...)
+	})
+}
+
+func (c *Commands) validateWebAuthNLogin(ctx context.Context, userID, resourceOwner string, tokens []*domain.WebAuthNToken, validate func(human *domain.Human) (*webauthn.Credential, error)) (*eventstore.Aggregate, *domain.WebAuthNToken, uint32, error) {
 	if userID == "" {
 		return nil, nil, 0, zerrors.ThrowPreconditionFailed(nil, "COMMAND-hh8K9", "Errors.IDMissing")
 	}
@@ -457,7 +495,7 @@ func (c *Commands) finishWebAuthNLogin(ctx context.Context, userID, resourceOwne
 	if err != nil {
 		return nil, nil, 0, err
 	}
-	credential, err := c.webauthnConfig.FinishLogin(ctx, human, webAuthN, credentialData, tokens...)
+	credential, err := validate(human)
 	if err != nil && (credential == nil || credential.ID == nil) {
 		return nil, nil, 0, err
 	}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_HumanFinishDiscoverablePasswordlessLogin(t *testing.T) {
+	ctx := authz.NewMockContext("instance1", "org1", "user1")
+	ctx = http_util.WithRequestedHost(ctx, "example.com")
+
+	webauthnConfig := &webauthn_helper.Config{
+		DisplayName:    "test",
+		ExternalSecure: true,
+	}
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	webAuthNLogin := &domain.WebAuthNLogin{
+		Challenge:        "challenge",
+		UserVerification: domain.UserVerificationRequirementRequired,
+		RPID:             "example.com",
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID         string
+		resourceOwner  string
+		credentialData []byte
+		webAuthNLogin  *domain.WebAuthNLogin
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "challenge already used",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:         "user1",
+				resourceOwner:  "org1",
+				credentialData: testDiscoverableAssertion(t, "user1"),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wd8qr", "Errors.User.WebAuthN.NoChallenge"),
+		},
+		{
+			name: "user removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(ctx,
+								userAgg,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserRemovedEvent(ctx,
+								userAgg,
+								"username",
+								nil,
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:         "user1",
+				resourceOwner:  "org1",
+				credentialData: testDiscoverableAssertion(t, "user1"),
+				webAuthNLogin:  webAuthNLogin,
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Mv9sd", "Errors.User.NotFound"),
+		},
+		{
+			name: "user of another organization",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(), // getHumanPasswordlessTokens
+					expectFilter(), // getHuman
+				),
+			},
+			args: args{
+				userID:         "user1",
+				resourceOwner:  "org2",
+				credentialData: testDiscoverableAssertion(t, "user1"),
+				webAuthNLogin:  webAuthNLogin,
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-M9dsd", "Errors.User.NotFound"),
+		},
+		{
+			name: "user handle of another user",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(), // getHumanPasswordlessTokens
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(ctx,
+								userAgg,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:         "user1",
+				resourceOwner:  "org1",
+				credentialData: testDiscoverableAssertion(t, "user2"),
+				webAuthNLogin:  webAuthNLogin,
+			},
+			wantErr: zerrors.ThrowInternal(nil, "WEBAU-Tp6jd", "Errors.User.WebAuthN.ValidateLoginFailed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				webauthnConfig: webauthnConfig,
+			}
+			err := c.HumanFinishDiscoverablePasswordlessLogin(ctx, tt.args.userID, tt.args.resourceOwner, tt.args.credentialData, tt.args.webAuthNLogin, &domain.AuthRequest{})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// testDiscoverableAssertion returns an unsigned assertion with the user handle of the user,
+// which is enough for the checks before the signature is verified.
+func testDiscoverableAssertion(t *testing.T, userHandle string) []byte {
+	clientData, err := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": "challenge",
+		"origin":    "https://example.com",
+	})
+	require.NoError(t, err)
+	// rp id hash (32 bytes), flags (user present and verified) and sign count (4 bytes)
+	authData := append(make([]byte, 32), 0x05, 0, 0, 0, 1)
+	rawID := base64.RawURLEncoding.EncodeToString([]byte("credentialID"))
+	data, err := json.Marshal(map[string]any{
+		"id":    rawID,
+		"rawId": rawID,
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString([]byte("signature")),
+			"userHandle":        base64.RawURLEncoding.EncodeToString([]byte(userHandle)),
+		},
+	})
+	require.NoError(t, err)
+	return data
+}
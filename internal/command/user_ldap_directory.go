@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// DeactivateLDAPDirectoryUser deactivates a user removed from the directory of the LDAP identity provider.
+// The identity provider is stored on the event, so only the directory sync of the same identity provider reactivates the user.
+func (c *Commands) DeactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vd3kq", "Errors.User.UserIDMissing")
+	}
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xr8wn", "Errors.IDMissing")
+	}
+
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Lw2rf", "Errors.User.NotFound")
+	}
+	if isUserStateInitial(existingUser.UserState) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Hj6ts", "Errors.User.CantDeactivateInitial")
+	}
+	if isUserStateInactive(existingUser.UserState) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Qm5bz", "Errors.User.AlreadyInactive")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserDeactivatedByDirectoryEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), idpID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
+// ReactivateLDAPDirectoryUser reactivates a user returning to the directory of the LDAP identity provider.
+// Only users deactivated by the directory sync of the same identity provider are reactivated,
+// users deactivated otherwise (e.g. by an administrator) result in a precondition failed error.
+func (c *Commands) ReactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Tz7ce", "Errors.User.UserIDMissing")
+	}
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ka1pm", "Errors.IDMissing")
+	}
+
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Fn9gh", "Errors.User.NotFound")
+	}
+	if !isUserStateInactive(existingUser.UserState) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Bs4yd", "Errors.User.NotInactive")
+	}
+	if existingUser.DeactivatedByDirectory != idpID {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wc8uj", "Errors.User.NotDeactivatedByDirectory")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserReactivatedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
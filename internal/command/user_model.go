@@ -15,6 +15,8 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+	// DeactivatedByDirectory is the id of the identity provider, whose directory sync deactivated the user
+	DeactivatedByDirectory string
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -77,10 +79,12 @@ func (wm *UserWriteModel) Reduce() error {
 		case *user.UserDeactivatedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateInactive
+				wm.DeactivatedByDirectory = e.DirectoryIDPID
 			}
 		case *user.UserReactivatedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateActive
+				wm.DeactivatedByDirectory = ""
 			}
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
@@ -210,6 +210,49 @@ func (c *Commands) RemoveUserV2(ctx context.Context, userID, resourceOwner strin
 	return writeModelToObjectDetails(&existingUser.WriteModel), nil
 }
 
+// ForgetUser removes the user if it still exists and destroys the key of its personal data.
+// The personal data of all events of the user cannot be read anymore afterwards.
+// Already removed users can be forgotten as well.
+// The destruction is recorded with a [user.UserForgottenEvent] after the key was destroyed,
+// so a failed push is recorded by calling ForgetUser again.
+func (c *Commands) ForgetUser(ctx context.Context, userID, resourceOwner string, cascadingUserMemberships []*CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Fq3ls", "Errors.User.UserIDMissing")
+	}
+	if !c.eventstore.PIIEncryptionEnabled() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xk7tb", "Errors.User.PIIEncryptionDisabled")
+	}
+
+	existingUser, err := c.userRemoveWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser.UserState == domain.UserStateUnspecified {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Wn8gh", "Errors.User.NotFound")
+	}
+
+	if isUserStateExists(existingUser.UserState) {
+		if _, err = c.RemoveUserV2(ctx, userID, resourceOwner, cascadingUserMemberships, cascadingGrantIDs...); err != nil {
+			return nil, err
+		}
+	} else if err := c.checkPermissionDeleteUser(ctx, existingUser.ResourceOwner, existingUser.AggregateID); err != nil {
+		return nil, err
+	}
+
+	if err = c.eventstore.DestroyPII(ctx, authz.GetInstance(ctx).InstanceID(), existingUser.AggregateID); err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewUserForgottenEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
 func (c *Commands) userRemoveWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *UserV2WriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
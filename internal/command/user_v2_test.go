@@ -9,8 +9,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -1371,3 +1373,352 @@ func TestCommandSide_RemoveUserV2(t *testing.T) {
 		})
 	}
 }
+
+type mockPIIEncryption struct {
+	destroyed []string
+	err       error
+}
+
+func (m *mockPIIEncryption) EncryptPayload(_ context.Context, _ *eventstore.Aggregate, _ []string, payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+func (m *mockPIIEncryption) DecryptPayload(_ *eventstore.Aggregate, _ []string, payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+func (m *mockPIIEncryption) DestroyKey(_ context.Context, instanceID, aggregateID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.destroyed = append(m.destroyed, instanceID+"/"+aggregateID)
+	return nil
+}
+
+func expectEventstoreWithPII(pii eventstore.PIIEncryption, expects ...expect) func(*testing.T) *eventstore.Eventstore {
+	return func(t *testing.T) *eventstore.Eventstore {
+		m := mock.NewRepo(t)
+		for _, e := range expects {
+			e(m)
+		}
+		return eventstore.NewEventstore(
+			&eventstore.Config{
+				Querier:       m.MockQuerier,
+				Pusher:        m.MockPusher,
+				PII:           eventstore.PIIConfig{Enabled: true},
+				PIIEncryption: pii,
+			},
+		)
+	}
+}
+
+func TestCommandSide_ForgetUser(t *testing.T) {
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	type res struct {
+		want      *domain.ObjectDetails
+		destroyed []string
+		err       func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields func(*mockPIIEncryption) fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: func(pii *mockPIIEncryption) fields {
+				return fields{
+					eventstore:      expectEventstoreWithPII(pii),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Fq3ls", "Errors.User.UserIDMissing"))
+				},
+			},
+		},
+		{
+			name: "pii encryption disabled, precondition failed error",
+			fields: func(*mockPIIEncryption) fields {
+				return fields{
+					eventstore:      expectEventstore(),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xk7tb", "Errors.User.PIIEncryptionDisabled"))
+				},
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: func(pii *mockPIIEncryption) fields {
+				return fields{
+					eventstore: expectEventstoreWithPII(pii,
+						expectFilter(),
+					),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Wn8gh", "Errors.User.NotFound"))
+				},
+			},
+		},
+		{
+			name: "user removed, no permission",
+			fields: func(pii *mockPIIEncryption) fields {
+				return fields{
+					eventstore: expectEventstoreWithPII(pii,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									"firstname",
+									"lastname",
+									"nickname",
+									"displayname",
+									language.German,
+									domain.GenderUnspecified,
+									"email@test.ch",
+									true,
+								),
+							),
+							eventFromEventPusher(
+								user.NewUserRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									nil,
+									true,
+								),
+							),
+						),
+					),
+					checkPermission: newMockPermissionCheckNotAllowed(),
+				}
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"))
+				},
+			},
+		},
+		{
+			name: "user removed, key destroyed",
+			fields: func(pii *mockPIIEncryption) fields {
+				return fields{
+					eventstore: expectEventstoreWithPII(pii,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									"firstname",
+									"lastname",
+									"nickname",
+									"displayname",
+									language.German,
+									domain.GenderUnspecified,
+									"email@test.ch",
+									true,
+								),
+							),
+							eventFromEventPusher(
+								user.NewUserRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									nil,
+									true,
+								),
+							),
+						),
+						expectPush(
+							user.NewUserForgottenEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				destroyed: []string{"instance1/user1"},
+			},
+		},
+		{
+			name: "destroy key failed, not forgotten",
+			fields: func(pii *mockPIIEncryption) fields {
+				pii.err = zerrors.ThrowInternal(nil, "ID", "destroy failed")
+				return fields{
+					eventstore: expectEventstoreWithPII(pii,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									"firstname",
+									"lastname",
+									"nickname",
+									"displayname",
+									language.German,
+									domain.GenderUnspecified,
+									"email@test.ch",
+									true,
+								),
+							),
+							eventFromEventPusher(
+								user.NewUserRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									nil,
+									true,
+								),
+							),
+						),
+					),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				userID: "user1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInternal(nil, "ID", "destroy failed"))
+				},
+			},
+		},
+		{
+			name: "remove user, key destroyed",
+			fields: func(pii *mockPIIEncryption) fields {
+				return fields{
+					eventstore: expectEventstoreWithPII(pii,
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									"firstname",
+									"lastname",
+									"nickname",
+									"displayname",
+									language.German,
+									domain.GenderUnspecified,
+									"email@test.ch",
+									true,
+								),
+							),
+						),
+						expectFilter(
+							eventFromEventPusher(
+								user.NewHumanAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"username",
+									"firstname",
+									"lastname",
+									"nickname",
+									"displayname",
+									language.German,
+									domain.GenderUnspecified,
+									"email@test.ch",
+									true,
+								),
+							),
+						),
+						expectFilter(
+							eventFromEventPusher(
+								org.NewDomainPolicyAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									true,
+									true,
+									true,
+								),
+							),
+						),
+						expectPush(
+							user.NewUserRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								nil,
+								true,
+							),
+						),
+						expectPush(
+							user.NewUserForgottenEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+					checkPermission: newMockPermissionCheckAllowed(),
+				}
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				destroyed: []string{"instance1/user1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pii := new(mockPIIEncryption)
+			f := tt.fields(pii)
+			r := &Commands{
+				eventstore:      f.eventstore(t),
+				checkPermission: f.checkPermission,
+			}
+			got, err := r.ForgetUser(tt.args.ctx, tt.args.userID, "", nil)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+			assert.Equal(t, tt.res.destroyed, pii.destroyed)
+		})
+	}
+}
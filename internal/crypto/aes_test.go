@@ -17,13 +17,17 @@ type mockKeyStorage struct {
 }
 
 func (s *mockKeyStorage) ReadKeys() (Keys, error) {
-	return s.keys, nil
+	return nil, errors.New("mockKeyStorage.ReadKeys not implemented")
 }
 
 func (s *mockKeyStorage) ReadKey(id string) (*Key, error) {
+	value, ok := s.keys[id]
+	if !ok {
+		return nil, errors.New("mockKeyStorage.ReadKey key not found")
+	}
 	return &Key{
 		ID:    id,
-		Value: s.keys[id],
+		Value: value,
 	}, nil
 }
 
@@ -31,6 +35,10 @@ func (*mockKeyStorage) CreateKeys(context.Context, ...*Key) error {
 	return errors.New("mockKeyStorage.CreateKeys not implemented")
 }
 
+func (*mockKeyStorage) DeleteKeys(context.Context, ...string) error {
+	return errors.New("mockKeyStorage.DeleteKeys not implemented")
+}
+
 func newTestAESCrypto(t testing.TB) *AESCrypto {
 	keyConfig := &KeyConfig{
 		EncryptionKeyID:  "keyID",
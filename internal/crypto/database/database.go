@@ -7,6 +7,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	z_db "github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -14,6 +15,7 @@ import (
 type Database struct {
 	client    *z_db.DB
 	masterKey string
+	kms       kms.KMS
 	encrypt   func(key, masterKey string) (encryptedKey string, err error)
 	decrypt   func(encryptedKey, masterKey string) (key string, err error)
 }
@@ -24,13 +26,21 @@ const (
 	encryptionKeysKeyCol = "key"
 )
 
-func NewKeyStorage(client *z_db.DB, masterKey string) (*Database, error) {
+// NewKeyStorage returns the storage of the encryption keys.
+// If a kms is passed, the master key might be wrapped by it and new keys are wrapped by the kms instead of the master key.
+// Keys encrypted by the master key can still be read, until they are rewrapped.
+func NewKeyStorage(ctx context.Context, client *z_db.DB, masterKey string, keyManagement kms.KMS) (*Database, error) {
+	masterKey, err := kms.UnwrapMasterKey(ctx, keyManagement, masterKey)
+	if err != nil {
+		return nil, err
+	}
 	if err := checkMasterKeyLength(masterKey); err != nil {
 		return nil, err
 	}
 	return &Database{
 		client:    client,
 		masterKey: masterKey,
+		kms:       keyManagement,
 		encrypt:   crypto.EncryptAESString,
 		decrypt:   crypto.DecryptAESString,
 	}, nil
@@ -51,7 +61,7 @@ func (d *Database) ReadKeys() (crypto.Keys, error) {
 			if err != nil {
 				return zerrors.ThrowInternal(err, "", "unable to read keys")
 			}
-			key, err := d.decrypt(encryptionKey, d.masterKey)
+			key, err := d.decryptKey(context.Background(), encryptionKey)
 			if err != nil {
 				return zerrors.ThrowInternal(err, "", "unable to decrypt key")
 			}
@@ -83,7 +93,7 @@ func (d *Database) ReadKey(id string) (_ *crypto.Key, err error) {
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to read key")
 		}
-		key, err = d.decrypt(encryptionKey, d.masterKey)
+		key, err = d.decryptKey(context.Background(), encryptionKey)
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to decrypt key")
 		}
@@ -103,7 +113,7 @@ func (d *Database) CreateKeys(ctx context.Context, keys ...*crypto.Key) error {
 	insert := sq.Insert(EncryptionKeysTable).
 		Columns(encryptionKeysIDCol, encryptionKeysKeyCol).PlaceholderFormat(sq.Dollar)
 	for _, key := range keys {
-		encryptionKey, err := d.encrypt(key.Value, d.masterKey)
+		encryptionKey, err := d.encryptKey(ctx, key.Value)
 		if err != nil {
 			return zerrors.ThrowInternal(err, "", "unable to encrypt key")
 		}
@@ -129,6 +139,108 @@ func (d *Database) CreateKeys(ctx context.Context, keys ...*crypto.Key) error {
 	return nil
 }
 
+func (d *Database) DeleteKeys(ctx context.Context, ids ...string) error {
+	stmt, args, err := sq.Delete(EncryptionKeysTable).
+		Where(sq.Eq{encryptionKeysIDCol: ids}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "", "unable to delete keys")
+	}
+	_, err = d.client.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "", "unable to delete keys")
+	}
+	return nil
+}
+
+// RewrapKeys reads the keys with the previous storage, e.g. with the previous master key,
+// and encrypts them with the kms if configured or the master key of the storage.
+// The keys are read and updated in a single transaction locking them (SELECT ... FOR UPDATE),
+// so concurrent rotations can't overwrite each other.
+// Keys created concurrently by a running ZITADEL with the previous master key can't be prevented, see the rotate-master command.
+func (d *Database) RewrapKeys(ctx context.Context, previous *Database) (count int, err error) {
+	tx, err := d.client.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "", "unable to rewrap keys")
+	}
+	defer func() {
+		err = z_db.CloseTransaction(tx, err)
+	}()
+	keys, err := lockKeys(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		decrypted, err := previous.decryptKey(ctx, key.Value)
+		if err != nil {
+			return 0, zerrors.ThrowInternal(err, "", "unable to decrypt key")
+		}
+		encryptionKey, err := d.encryptKey(ctx, decrypted)
+		if err != nil {
+			return 0, zerrors.ThrowInternal(err, "", "unable to encrypt key")
+		}
+		stmt, args, err := sq.Update(EncryptionKeysTable).
+			Set(encryptionKeysKeyCol, encryptionKey).
+			Where(sq.Eq{encryptionKeysIDCol: key.ID}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			return 0, zerrors.ThrowInternal(err, "", "unable to rewrap keys")
+		}
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			return 0, zerrors.ThrowInternal(err, "", "unable to rewrap keys")
+		}
+	}
+	return len(keys), nil
+}
+
+// lockKeys returns the encrypted keys locked for update until the end of the transaction.
+func lockKeys(ctx context.Context, tx *sql.Tx) ([]*crypto.Key, error) {
+	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
+		From(EncryptionKeysTable).
+		OrderBy(encryptionKeysIDCol).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "", "unable to read keys")
+	}
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "", "unable to read keys")
+	}
+	defer rows.Close()
+	keys := make([]*crypto.Key, 0)
+	for rows.Next() {
+		key := new(crypto.Key)
+		if err = rows.Scan(&key.ID, &key.Value); err != nil {
+			return nil, zerrors.ThrowInternal(err, "", "unable to read keys")
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "", "unable to read keys")
+	}
+	return keys, nil
+}
+
+func (d *Database) encryptKey(ctx context.Context, key string) (string, error) {
+	if d.kms != nil {
+		return kms.WrapString(ctx, d.kms, key)
+	}
+	return d.encrypt(key, d.masterKey)
+}
+
+func (d *Database) decryptKey(ctx context.Context, encryptionKey string) (string, error) {
+	if !kms.IsWrapped(encryptionKey) {
+		return d.decrypt(encryptionKey, d.masterKey)
+	}
+	if d.kms == nil {
+		return "", zerrors.ThrowPreconditionFailed(nil, "", "key is wrapped by a kms, but no kms is configured")
+	}
+	return kms.UnwrapString(ctx, d.kms, encryptionKey)
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return zerrors.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
@@ -7,12 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	z_db "github.com/zitadel/zitadel/internal/database"
 	db_mock "github.com/zitadel/zitadel/internal/database/mock"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -541,3 +544,296 @@ func expectRollback(err error) func(m sqlmock.Sqlmock) {
 		}
 	}
 }
+
+func Test_database_DeleteKeys(t *testing.T) {
+	type fields struct {
+		client db
+	}
+	type args struct {
+		ids []string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"delete fails, error",
+			fields{
+				client: dbMock(t,
+					expectExec("DELETE FROM system.encryption_keys WHERE id IN ($1)", sql.ErrConnDone, "id1"),
+				),
+			},
+			args{
+				ids: []string{"id1"},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			"delete ok",
+			fields{
+				client: dbMock(t,
+					expectExec("DELETE FROM system.encryption_keys WHERE id IN ($1,$2)", nil, "id1", "id2"),
+				),
+			},
+			args{
+				ids: []string{"id1", "id2"},
+			},
+			res{
+				err: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Database{
+				client: tt.fields.client.db,
+			}
+			err := d.DeleteKeys(context.Background(), tt.args.ids...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			if err := tt.fields.client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// reverseKMS "wraps" the keys by reversing them
+type reverseKMS struct{}
+
+func (reverseKMS) Wrap(_ context.Context, plaintext []byte) ([]byte, error) {
+	return reverse(plaintext), nil
+}
+
+func (reverseKMS) Unwrap(_ context.Context, ciphertext []byte) ([]byte, error) {
+	return reverse(ciphertext), nil
+}
+
+func reverse(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+	return reversed
+}
+
+func Test_database_ReadKey_kms(t *testing.T) {
+	wrapped, err := kms.WrapString(context.Background(), reverseKMS{}, "key1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		kms  kms.KMS
+		res  func(*testing.T, *crypto.Key, error)
+	}{
+		{
+			"no kms, error",
+			nil,
+			func(t *testing.T, _ *crypto.Key, err error) {
+				assert.True(t, zerrors.IsInternal(err))
+			},
+		},
+		{
+			"unwrapped by kms",
+			reverseKMS{},
+			func(t *testing.T, key *crypto.Key, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &crypto.Key{ID: "id1", Value: "key1"}, key)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dbMock(t, expectQuery(
+				"SELECT key FROM system.encryption_keys WHERE id = $1",
+				[]string{"key"},
+				[][]driver.Value{{wrapped}},
+				"id1",
+			))
+			d := &Database{
+				client:    client.db,
+				masterKey: "masterKey",
+				kms:       tt.kms,
+				decrypt: func(encryptedKey, masterKey string) (key string, err error) {
+					return "", fmt.Errorf("not encrypted by the masterkey")
+				},
+			}
+			key, err := d.ReadKey("id1")
+			tt.res(t, key, err)
+			if err := client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_database_RewrapKeys(t *testing.T) {
+	wrapped, err := kms.WrapString(context.Background(), reverseKMS{}, "key1")
+	require.NoError(t, err)
+
+	type fields struct {
+		client  db
+		kms     kms.KMS
+		encrypt func(key, masterKey string) (encryptedKey string, err error)
+	}
+	type res struct {
+		count int
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			"lock fails, rollback",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectQueryErr("SELECT id, key FROM system.encryption_keys ORDER BY id FOR UPDATE", sql.ErrConnDone),
+					expectRollback(nil),
+				),
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			"decryption fails, rollback",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectQuery("SELECT id, key FROM system.encryption_keys ORDER BY id FOR UPDATE",
+						[]string{"id", "key"},
+						[][]driver.Value{{"id1", "otherKey1"}},
+					),
+					expectRollback(nil),
+				),
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInternal(nil, "", "unable to decrypt key"))
+				},
+			},
+		},
+		{
+			"update fails, rollback",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectQuery("SELECT id, key FROM system.encryption_keys ORDER BY id FOR UPDATE",
+						[]string{"id", "key"},
+						[][]driver.Value{{"id1", "encryptedKey1"}},
+					),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", sql.ErrConnDone, "newKey1", "id1"),
+					expectRollback(nil),
+				),
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return "new" + strings.ToUpper(key[:1]) + key[1:], nil
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			"rewrapped by master key",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectQuery("SELECT id, key FROM system.encryption_keys ORDER BY id FOR UPDATE",
+						[]string{"id", "key"},
+						[][]driver.Value{{"id1", "encryptedKey1"}},
+					),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, "newKey1", "id1"),
+					expectCommit(nil),
+				),
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return "new" + strings.ToUpper(key[:1]) + key[1:], nil
+				},
+			},
+			res{
+				count: 1,
+			},
+		},
+		{
+			"rewrapped by kms",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectQuery("SELECT id, key FROM system.encryption_keys ORDER BY id FOR UPDATE",
+						[]string{"id", "key"},
+						[][]driver.Value{{"id1", "encryptedKey1"}},
+					),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, wrapped, "id1"),
+					expectCommit(nil),
+				),
+				kms: reverseKMS{},
+			},
+			res{
+				count: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := &Database{
+				masterKey: "previousMasterKey",
+				decrypt: func(encryptedKey, masterKey string) (key string, err error) {
+					if encryptedKey != "encryptedKey1" {
+						return "", zerrors.ThrowInternal(nil, "", "invalid key")
+					}
+					return "key1", nil
+				},
+			}
+			d := &Database{
+				client:    tt.fields.client.db,
+				masterKey: "masterKey",
+				kms:       tt.fields.kms,
+				encrypt:   tt.fields.encrypt,
+			}
+			count, err := d.RewrapKeys(context.Background(), previous)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			assert.Equal(t, tt.res.count, count)
+			if err := tt.fields.client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewKeyStorage(t *testing.T) {
+	ctx := context.Background()
+	wrapped, err := kms.WrapString(ctx, reverseKMS{}, "0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	_, err = NewKeyStorage(ctx, nil, "short", nil)
+	assert.Error(t, err)
+
+	_, err = NewKeyStorage(ctx, nil, wrapped, nil)
+	assert.Error(t, err)
+
+	storage, err := NewKeyStorage(ctx, nil, wrapped, reverseKMS{})
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", storage.masterKey)
+}
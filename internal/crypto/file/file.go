@@ -42,3 +42,7 @@ func (d *Storage) ReadKey(id string) (*crypto.Key, error) {
 func (d *Storage) CreateKeys(keys ...*crypto.Key) error {
 	return fmt.Errorf("this provider is not able to store new keys")
 }
+
+func (d *Storage) DeleteKeys(ids ...string) error {
+	return fmt.Errorf("this provider is not able to delete keys")
+}
@@ -39,31 +39,33 @@ func LoadKey(id string, keyStorage KeyStorage) (string, error) {
 	return key.Value, nil
 }
 
+// LoadKeys reads the keys of the config by their id.
+// The key storage might contain many more keys (e.g. the data keys of personal data),
+// so they are not read all at once.
 func LoadKeys(config *KeyConfig, keyStorage KeyStorage) (Keys, []string, error) {
 	if config == nil {
 		return nil, nil, zerrors.ThrowInvalidArgument(nil, "CRYPT-dJK8s", "config must not be nil")
 	}
-	readKeys, err := keyStorage.ReadKeys()
-	if err != nil {
-		return nil, nil, err
-	}
 	keys := make(Keys)
 	ids := make([]string, 0, len(config.DecryptionKeyIDs)+1)
 	if config.EncryptionKeyID != "" {
-		key, ok := readKeys[config.EncryptionKeyID]
-		if !ok {
-			return nil, nil, zerrors.ThrowInternalf(nil, "CRYPT-v2Kas", "encryption key %s not found", config.EncryptionKeyID)
+		key, err := keyStorage.ReadKey(config.EncryptionKeyID)
+		if err != nil {
+			return nil, nil, zerrors.ThrowInternalf(err, "CRYPT-v2Kas", "encryption key %s not found", config.EncryptionKeyID)
 		}
-		keys[config.EncryptionKeyID] = key
+		keys[config.EncryptionKeyID] = key.Value
 		ids = append(ids, config.EncryptionKeyID)
 	}
 	for _, id := range config.DecryptionKeyIDs {
-		key, ok := readKeys[id]
-		if !ok {
-			logging.Errorf("description key %s not found", id)
+		if _, ok := keys[id]; ok {
+			continue
+		}
+		key, err := keyStorage.ReadKey(id)
+		if err != nil {
+			logging.WithError(err).Errorf("description key %s not found", id)
 			continue
 		}
-		keys[id] = key
+		keys[id] = key.Value
 		ids = append(ids, id)
 	}
 	return keys, ids, nil
@@ -6,4 +6,5 @@ type KeyStorage interface {
 	ReadKeys() (Keys, error)
 	ReadKey(id string) (*Key, error)
 	CreateKeys(context.Context, ...*Key) error
+	DeleteKeys(context.Context, ...string) error
 }
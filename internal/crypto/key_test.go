@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeys(t *testing.T) {
+	// the storage also contains keys which are not part of the config,
+	// [mockKeyStorage.ReadKeys] fails to ensure only the configured keys are read.
+	storage := &mockKeyStorage{keys: Keys{
+		"encryption": "encryptionKey",
+		"decryption": "decryptionKey",
+		"pii_other":  "otherKey",
+	}}
+
+	keys, ids, err := LoadKeys(&KeyConfig{
+		EncryptionKeyID:  "encryption",
+		DecryptionKeyIDs: []string{"encryption", "decryption", "missing"},
+	}, storage)
+	require.NoError(t, err)
+	assert.Equal(t, Keys{"encryption": "encryptionKey", "decryption": "decryptionKey"}, keys)
+	assert.Equal(t, []string{"encryption", "decryption"}, ids)
+
+	_, _, err = LoadKeys(&KeyConfig{EncryptionKeyID: "missing"}, storage)
+	assert.Error(t, err)
+}
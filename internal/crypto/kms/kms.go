@@ -0,0 +1,110 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// KMS wraps and unwraps keys with a key of an external key management system.
+// The wrapping key never leaves the key management system.
+type KMS interface {
+	Wrap(ctx context.Context, plaintext []byte) ([]byte, error)
+	Unwrap(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+type Type string
+
+const (
+	TypeNone   Type = ""
+	TypePKCS11 Type = "pkcs11"
+	TypeVault  Type = "vault"
+)
+
+type Config struct {
+	// Type of the key management system, "pkcs11" or "vault".
+	// No key management system is used if empty.
+	Type   Type
+	PKCS11 PKCS11Config
+	Vault  VaultConfig
+}
+
+type PKCS11Config struct {
+	// Path to the PKCS#11 module of the hardware security module, e.g. /usr/lib/softhsm/libsofthsm2.so
+	ModulePath string
+	// Label of the token containing the wrapping key
+	TokenLabel string
+	// PIN of the user of the token
+	PIN string
+	// Label of the AES key wrapping the keys
+	KeyLabel string
+}
+
+// NewKMS returns the configured key management system
+// or nil if none is configured.
+func (c *Config) NewKMS() (KMS, error) {
+	switch c.Type {
+	case TypeNone:
+		return nil, nil
+	case TypePKCS11:
+		kms, err := NewPKCS11(&c.PKCS11)
+		if err != nil {
+			return nil, err
+		}
+		return kms, nil
+	case TypeVault:
+		return NewVault(&c.Vault)
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "KMS-Tq7vd", "unknown kms type %q", c.Type)
+	}
+}
+
+// wrappedPrefix marks values wrapped by a [KMS]
+const wrappedPrefix = "kms:"
+
+// IsWrapped returns true if the value was wrapped by [WrapString]
+func IsWrapped(value string) bool {
+	return strings.HasPrefix(value, wrappedPrefix)
+}
+
+// WrapString wraps the value with the kms and encodes it,
+// so it can be stored as text and recognized by [IsWrapped].
+func WrapString(ctx context.Context, kms KMS, value string) (string, error) {
+	wrapped, err := kms.Wrap(ctx, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	return wrappedPrefix + base64.RawURLEncoding.EncodeToString(wrapped), nil
+}
+
+// UnwrapString decodes and unwraps a value created by [WrapString]
+func UnwrapString(ctx context.Context, kms KMS, value string) (string, error) {
+	if !IsWrapped(value) {
+		return "", zerrors.ThrowInvalidArgument(nil, "KMS-Ha3ol", "value is not wrapped by a kms")
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, wrappedPrefix))
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "KMS-Pv6xe", "unable to decode wrapped value")
+	}
+	unwrapped, err := kms.Unwrap(ctx, wrapped)
+	if err != nil {
+		return "", err
+	}
+	return string(unwrapped), nil
+}
+
+// UnwrapMasterKey returns the master key unwrapped by the kms if it was wrapped,
+// otherwise the master key is returned unchanged.
+func UnwrapMasterKey(ctx context.Context, kms KMS, masterKey string) (string, error) {
+	// wrapped master keys are read from files which might end with a new line
+	wrapped := strings.TrimSpace(masterKey)
+	if !IsWrapped(wrapped) {
+		return masterKey, nil
+	}
+	if kms == nil {
+		return "", zerrors.ThrowPreconditionFailed(nil, "KMS-Ux9bn", "master key is wrapped, but no kms is configured")
+	}
+	return UnwrapString(ctx, kms, wrapped)
+}
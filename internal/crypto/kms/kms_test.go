@@ -0,0 +1,107 @@
+package kms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// reverseKMS "wraps" the keys by reversing them
+type reverseKMS struct{}
+
+func (reverseKMS) Wrap(_ context.Context, plaintext []byte) ([]byte, error) {
+	return reverse(plaintext), nil
+}
+
+func (reverseKMS) Unwrap(_ context.Context, ciphertext []byte) ([]byte, error) {
+	return reverse(ciphertext), nil
+}
+
+func reverse(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+	return reversed
+}
+
+func TestWrapString(t *testing.T) {
+	ctx := context.Background()
+
+	wrapped, err := WrapString(ctx, reverseKMS{}, "0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+	assert.True(t, IsWrapped(wrapped))
+	assert.NotContains(t, wrapped, "0123456789abcdef")
+
+	unwrapped, err := UnwrapString(ctx, reverseKMS{}, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", unwrapped)
+
+	_, err = UnwrapString(ctx, reverseKMS{}, "0123456789abcdef0123456789abcdef")
+	assert.ErrorIs(t, err, zerrors.ThrowInvalidArgument(nil, "KMS-Ha3ol", ""))
+}
+
+func TestUnwrapMasterKey(t *testing.T) {
+	ctx := context.Background()
+	wrapped, err := WrapString(ctx, reverseKMS{}, "0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		kms       KMS
+		masterKey string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "plain master key",
+			kms:       reverseKMS{},
+			masterKey: "0123456789abcdef0123456789abcdef",
+			want:      "0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:      "plain master key without kms",
+			masterKey: "0123456789abcdef0123456789abcdef",
+			want:      "0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:      "wrapped master key",
+			kms:       reverseKMS{},
+			masterKey: wrapped + "\n",
+			want:      "0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:      "wrapped master key without kms",
+			masterKey: wrapped,
+			wantErr:   zerrors.ThrowPreconditionFailed(nil, "KMS-Ux9bn", ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapMasterKey(ctx, tt.kms, tt.masterKey)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_NewKMS(t *testing.T) {
+	kms, err := (&Config{}).NewKMS()
+	require.NoError(t, err)
+	assert.Nil(t, kms)
+
+	kms, err = (&Config{Type: TypeVault, Vault: VaultConfig{Address: "http://localhost:8200", KeyName: "zitadel"}}).NewKMS()
+	require.NoError(t, err)
+	assert.IsType(t, new(Vault), kms)
+
+	_, err = (&Config{Type: "unknown"}).NewKMS()
+	assert.ErrorIs(t, err, zerrors.ThrowInvalidArgument(nil, "KMS-Tq7vd", ""))
+}
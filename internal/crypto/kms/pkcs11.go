@@ -0,0 +1,154 @@
+//go:build cgo
+
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	gcmIVSize  = 12
+	gcmTagBits = 128
+)
+
+// PKCS11 wraps the keys with an AES key stored in a hardware security module.
+// The keys are encrypted using AES-GCM inside the module,
+// the wrapped value consists of the initialization vector followed by the cipher text.
+type PKCS11 struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+
+	// a session must not be used concurrently
+	mu sync.Mutex
+}
+
+var _ KMS = (*PKCS11)(nil)
+
+// NewPKCS11 loads the module, logs into the token and looks up the wrapping key
+func NewPKCS11(config *PKCS11Config) (_ *PKCS11, err error) {
+	ctx := pkcs11.New(config.ModulePath)
+	if ctx == nil {
+		return nil, zerrors.ThrowInternalf(nil, "KMS-Mf4ay", "unable to load pkcs11 module %s", config.ModulePath)
+	}
+	defer func() {
+		if err != nil {
+			ctx.Destroy()
+		}
+	}()
+	// the module is initialized once per process
+	if err = ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		return nil, zerrors.ThrowInternal(err, "KMS-Ql2ci", "unable to initialize pkcs11 module")
+	}
+	slot, err := findSlot(ctx, config.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Ow0sf", "unable to open pkcs11 session")
+	}
+	// the login state is shared by all sessions of the process
+	if err = ctx.Login(session, pkcs11.CKU_USER, config.PIN); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return nil, zerrors.ThrowInternal(err, "KMS-Vd5gt", "unable to log into pkcs11 token")
+	}
+	key, err := findKey(ctx, session, config.KeyLabel)
+	if err != nil {
+		return nil, err
+	}
+	return &PKCS11{
+		ctx:     ctx,
+		session: session,
+		key:     key,
+	}, nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "KMS-Ep3rk", "unable to list pkcs11 slots")
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, zerrors.ThrowInternal(err, "KMS-Bj8cw", "unable to read pkcs11 token")
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, zerrors.ThrowNotFoundf(nil, "KMS-Ya1hm", "pkcs11 token %q not found", tokenLabel)
+}
+
+func findKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, keyLabel string) (pkcs11.ObjectHandle, error) {
+	err := ctx.FindObjectsInit(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	})
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "KMS-Gi6nu", "unable to search pkcs11 key")
+	}
+	keys, _, err := ctx.FindObjects(session, 1)
+	if finalErr := ctx.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "KMS-Sn9zq", "unable to search pkcs11 key")
+	}
+	if len(keys) == 0 {
+		return 0, zerrors.ThrowNotFoundf(nil, "KMS-Iw2pd", "pkcs11 key %q not found", keyLabel)
+	}
+	return keys[0], nil
+}
+
+// Wrap implements [KMS]
+func (p *PKCS11) Wrap(_ context.Context, plaintext []byte) ([]byte, error) {
+	iv := make([]byte, gcmIVSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Rk4wb", "unable to generate iv")
+	}
+	params := pkcs11.NewGCMParams(iv, nil, gcmTagBits)
+	defer params.Free()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.ctx.EncryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, p.key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Cy7lf", "unable to wrap key")
+	}
+	ciphertext, err := p.ctx.Encrypt(p.session, plaintext)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Zh0ua", "unable to wrap key")
+	}
+	// some modules ignore the passed iv and generate their own
+	return append(params.IV(), ciphertext...), nil
+}
+
+// Unwrap implements [KMS]
+func (p *PKCS11) Unwrap(_ context.Context, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < gcmIVSize {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Df1xo", "wrapped key too short")
+	}
+	params := pkcs11.NewGCMParams(ciphertext[:gcmIVSize], nil, gcmTagBits)
+	defer params.Free()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.ctx.DecryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, p.key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Hu3vi", "unable to unwrap key")
+	}
+	plaintext, err := p.ctx.Decrypt(p.session, ciphertext[gcmIVSize:])
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Ne8qt", "unable to unwrap key")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,17 @@
+//go:build !cgo
+
+package kms
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PKCS11 requires cgo to load the module of the hardware security module,
+// binaries built without cgo refuse to start if it is configured.
+type PKCS11 struct {
+	KMS
+}
+
+func NewPKCS11(*PKCS11Config) (*PKCS11, error) {
+	return nil, zerrors.ThrowUnimplemented(nil, "KMS-Wc5js", "the pkcs11 kms is not supported by this binary, it must be built with cgo (CGO_ENABLED=1)")
+}
@@ -0,0 +1,23 @@
+//go:build !cgo
+
+package kms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestConfig_NewKMS_pkcs11WithoutCgo(t *testing.T) {
+	config := &Config{
+		Type: TypePKCS11,
+		PKCS11: PKCS11Config{
+			ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+		},
+	}
+	kms, err := config.NewKMS()
+	assert.Nil(t, kms)
+	assert.ErrorIs(t, err, zerrors.ThrowUnimplemented(nil, "KMS-Wc5js", ""))
+}
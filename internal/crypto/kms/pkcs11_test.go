@@ -0,0 +1,42 @@
+//go:build cgo
+
+package kms
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPKCS11_softHSM runs against SoftHSM if SOFTHSM_LIB is set:
+//
+//	softhsm2-util --init-token --free --label zitadel --pin 1234 --so-pin 1234
+//	pkcs11-tool --module $SOFTHSM_LIB --login --pin 1234 --token-label zitadel --keygen --key-type AES:32 --label zitadel
+func TestPKCS11_softHSM(t *testing.T) {
+	module := os.Getenv("SOFTHSM_LIB")
+	if module == "" {
+		t.Skip("SOFTHSM_LIB not set")
+	}
+	ctx := context.Background()
+	hsm, err := NewPKCS11(&PKCS11Config{
+		ModulePath: module,
+		TokenLabel: "zitadel",
+		PIN:        "1234",
+		KeyLabel:   "zitadel",
+	})
+	require.NoError(t, err)
+
+	wrapped, err := hsm.Wrap(ctx, []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), "0123456789abcdef")
+
+	unwrapped, err := hsm.Unwrap(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0123456789abcdef0123456789abcdef"), unwrapped)
+
+	_, err = hsm.Unwrap(ctx, append(wrapped[:len(wrapped)-1], wrapped[len(wrapped)-1]^1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const envVaultToken = "VAULT_TOKEN"
+
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token used to authenticate at Vault, the VAULT_TOKEN environment variable is used if empty
+	Token string
+	// Path the transit secrets engine is mounted at
+	MountPath string
+	// Name of the transit key wrapping the keys
+	KeyName string
+	// Timeout of the requests to Vault
+	Timeout time.Duration
+}
+
+// Vault wraps the keys using the transit secrets engine of HashiCorp Vault.
+// The wrapped value is the cipher text returned by Vault (e.g. vault:v1:...),
+// so keys wrapped by older versions of the transit key can still be unwrapped after a rotation.
+type Vault struct {
+	client  *http.Client
+	encrypt string
+	decrypt string
+	token   string
+}
+
+var _ KMS = (*Vault)(nil)
+
+func NewVault(config *VaultConfig) (*Vault, error) {
+	address, err := url.Parse(config.Address)
+	if err != nil || address.Scheme == "" || address.Host == "" {
+		return nil, zerrors.ThrowInvalidArgumentf(err, "KMS-Jb2kr", "invalid vault address %q", config.Address)
+	}
+	if config.KeyName == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "KMS-Oq5em", "vault key name missing")
+	}
+	token := config.Token
+	if token == "" {
+		token = os.Getenv(envVaultToken)
+	}
+	mountPath := strings.Trim(config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = "transit"
+	}
+	return &Vault{
+		client:  &http.Client{Timeout: config.Timeout},
+		encrypt: address.JoinPath("v1", mountPath, "encrypt", config.KeyName).String(),
+		decrypt: address.JoinPath("v1", mountPath, "decrypt", config.KeyName).String(),
+		token:   token,
+	}, nil
+}
+
+type vaultEncryptRequest struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type vaultEncryptResponse struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type vaultDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type vaultDecryptResponse struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type vaultResponse[T any] struct {
+	Data   T        `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Wrap implements [KMS]
+func (v *Vault) Wrap(ctx context.Context, plaintext []byte) ([]byte, error) {
+	response, err := post[vaultEncryptResponse](ctx, v, v.encrypt, &vaultEncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(response.Ciphertext), nil
+}
+
+// Unwrap implements [KMS]
+func (v *Vault) Unwrap(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	response, err := post[vaultDecryptResponse](ctx, v, v.decrypt, &vaultDecryptRequest{
+		Ciphertext: string(ciphertext),
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(response.Plaintext)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Xs3fa", "unable to decode vault response")
+	}
+	return plaintext, nil
+}
+
+func post[R any](ctx context.Context, v *Vault, endpoint string, request any) (*R, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Ki7ub", "unable to create vault request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "KMS-Ad4sy", "unable to create vault request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", v.token)
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "KMS-Lr9de", "unable to reach vault")
+	}
+	defer resp.Body.Close()
+
+	response := new(vaultResponse[R])
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, zerrors.ThrowInternal(err, "KMS-Fz1ng", "unable to decode vault response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, zerrors.ThrowInternalf(nil, "KMS-Uv6pw", "vault responded with status %d: %s", resp.StatusCode, strings.Join(response.Errors, ", "))
+	}
+	return &response.Data, nil
+}
@@ -0,0 +1,99 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transitServer mimics the encrypt and decrypt endpoints of the transit secrets engine
+func transitServer(t *testing.T, token string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		request := make(map[string]string)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/zitadel":
+			data = map[string]string{"ciphertext": "vault:v1:" + request["plaintext"]}
+		case "/v1/transit/decrypt/zitadel":
+			data = map[string]string{"plaintext": strings.TrimPrefix(request["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+}
+
+func TestVault(t *testing.T) {
+	ctx := context.Background()
+	server := transitServer(t, "token")
+	defer server.Close()
+
+	vault, err := NewVault(&VaultConfig{Address: server.URL, Token: "token", KeyName: "zitadel"})
+	require.NoError(t, err)
+
+	wrapped, err := vault.Wrap(ctx, []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, "vault:v1:"+base64.StdEncoding.EncodeToString([]byte("key")), string(wrapped))
+
+	unwrapped, err := vault.Unwrap(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), unwrapped)
+
+	t.Run("permission denied", func(t *testing.T) {
+		vault, err := NewVault(&VaultConfig{Address: server.URL, Token: "wrong", KeyName: "zitadel"})
+		require.NoError(t, err)
+		_, err = vault.Wrap(ctx, []byte("key"))
+		assert.ErrorContains(t, err, "permission denied")
+	})
+}
+
+func TestNewVault(t *testing.T) {
+	_, err := NewVault(&VaultConfig{Address: "localhost", KeyName: "zitadel"})
+	assert.Error(t, err)
+	_, err = NewVault(&VaultConfig{Address: "http://localhost:8200"})
+	assert.Error(t, err)
+
+	t.Setenv(envVaultToken, "token")
+	vault, err := NewVault(&VaultConfig{Address: "http://localhost:8200/", MountPath: "/secrets/transit/", KeyName: "zitadel"})
+	require.NoError(t, err)
+	assert.Equal(t, "token", vault.token)
+	assert.Equal(t, "http://localhost:8200/v1/secrets/transit/encrypt/zitadel", vault.encrypt)
+	assert.Equal(t, "http://localhost:8200/v1/secrets/transit/decrypt/zitadel", vault.decrypt)
+}
+
+// TestVault_devServer runs against a Vault dev server if VAULT_ADDR is set:
+//
+//	vault server -dev -dev-root-token-id=root
+//	VAULT_ADDR=http://127.0.0.1:8200 VAULT_TOKEN=root vault secrets enable transit
+//	VAULT_ADDR=http://127.0.0.1:8200 VAULT_TOKEN=root vault write -f transit/keys/zitadel
+func TestVault_devServer(t *testing.T) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		t.Skip("VAULT_ADDR not set")
+	}
+	ctx := context.Background()
+	vault, err := NewVault(&VaultConfig{Address: address, KeyName: "zitadel"})
+	require.NoError(t, err)
+
+	wrapped, err := WrapString(ctx, vault, "0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+	unwrapped, err := UnwrapString(ctx, vault, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", unwrapped)
+}
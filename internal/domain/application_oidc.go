@@ -48,6 +48,9 @@ type OIDCApp struct {
 	BackChannelLogoutURI     string
 	LoginVersion             LoginVersion
 	LoginBaseURI             string
+	RequirePAR               bool
+	RequireDPoP              bool
+	CIBANotificationURI      string
 
 	State AppState
 }
@@ -105,6 +108,7 @@ const (
 	OIDCGrantTypeRefreshToken
 	OIDCGrantTypeDeviceCode
 	OIDCGrantTypeTokenExchange
+	OIDCGrantTypeCIBA
 )
 
 type OIDCApplicationType int32
@@ -174,10 +178,14 @@ func RequiredOIDCGrantTypes(responseTypes []OIDCResponseType, grantTypesSet []OI
 		switch r {
 		case OIDCResponseTypeCode:
 			// #5684 when "Device Code" is selected, "Authorization Code" is no longer a hard requirement
-			if !containsOIDCGrantType(grantTypesSet, OIDCGrantTypeDeviceCode) {
-				grantTypes = append(grantTypes, OIDCGrantTypeAuthorizationCode)
-			} else {
+			// the same applies to the decoupled "CIBA" flow
+			switch {
+			case containsOIDCGrantType(grantTypesSet, OIDCGrantTypeDeviceCode):
 				grantTypes = append(grantTypes, OIDCGrantTypeDeviceCode)
+			case containsOIDCGrantType(grantTypesSet, OIDCGrantTypeCIBA):
+				grantTypes = append(grantTypes, OIDCGrantTypeCIBA)
+			default:
+				grantTypes = append(grantTypes, OIDCGrantTypeAuthorizationCode)
 			}
 		case OIDCResponseTypeIDToken, OIDCResponseTypeIDTokenToken:
 			if !implicit {
@@ -212,6 +220,12 @@ func containsOIDCGrantType(grantTypes []OIDCGrantType, grantType OIDCGrantType)
 	return false
 }
 
+// containsDecoupledOIDCGrantType checks for grant types where the user authenticates on another device
+// and the client therefore does not need a redirect uri.
+func containsDecoupledOIDCGrantType(grantTypes []OIDCGrantType) bool {
+	return containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) || containsOIDCGrantType(grantTypes, OIDCGrantTypeCIBA)
+}
+
 func (a *OIDCApp) FillCompliance() {
 	a.Compliance = GetOIDCCompliance(a.OIDCVersion, a.ApplicationType, a.GrantTypes, a.ResponseTypes, a.AuthMethodType, a.RedirectUris)
 }
@@ -241,7 +255,7 @@ func GetOIDCV1Compliance(appType OIDCApplicationType, grantTypes []OIDCGrantType
 }
 
 func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantType) {
-	if !containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) && containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
+	if !containsDecoupledOIDCGrantType(grantTypes) && containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append(compliance.Problems, "Application.OIDC.V1.GrantType.Refresh.NoAuthCode")
 	}
@@ -249,7 +263,7 @@ func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantTy
 
 func checkRedirectURIs(compliance *Compliance, grantTypes []OIDCGrantType, appType OIDCApplicationType, redirectUris []string) {
 	// See #5684 for OIDCGrantTypeDeviceCode and redirectUris further explanation
-	if len(redirectUris) == 0 && (!containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) || (containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) && containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode))) {
+	if len(redirectUris) == 0 && (!containsDecoupledOIDCGrantType(grantTypes) || containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode)) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append([]string{"Application.OIDC.V1.NoRedirectUris"}, compliance.Problems...)
 	}
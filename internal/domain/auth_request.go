@@ -61,6 +61,8 @@ type AuthRequest struct {
 	OrgTranslations          []*CustomText
 	SAMLRequestID            string
 	RequestLocalAuth         bool
+	// PasswordlessChallenge is set when a passwordless login was started without a user (discoverable credential)
+	PasswordlessChallenge *WebAuthNLogin
 	// orgID the policies were last loaded with
 	policyOrgID string
 	// SessionID is set to the computed sessionID of the login session table
@@ -103,6 +105,7 @@ type ExternalUser struct {
 	Phone             PhoneNumber
 	IsPhoneVerified   bool
 	Metadatas         []*Metadata
+	Groups            []string
 }
 
 type Prompt int32
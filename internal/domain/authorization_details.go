@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AuthorizationDetail is a single object of the authorization_details parameter
+// of Rich Authorization Requests (RFC 9396).
+// Besides the required type, the fields of the object are defined by its type.
+type AuthorizationDetail map[string]any
+
+// Type returns the type of the authorization detail, which is empty if it's missing or not a string.
+func (d AuthorizationDetail) Type() string {
+	typ, _ := d["type"].(string)
+	return typ
+}
+
+type AuthorizationDetails []AuthorizationDetail
+
+// ParseAuthorizationDetails parses the JSON encoded authorization_details parameter.
+// Each object of the array must contain a type.
+func ParseAuthorizationDetails(details string) (AuthorizationDetails, error) {
+	if details == "" {
+		return nil, nil
+	}
+	var parsed AuthorizationDetails
+	if err := json.Unmarshal([]byte(details), &parsed); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-r4wq8n2xkd", "Errors.AuthorizationDetails.Invalid")
+	}
+	if err := parsed.Validate(); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// Validate checks that every authorization detail has a type.
+func (d AuthorizationDetails) Validate() error {
+	for _, detail := range d {
+		if detail.Type() == "" {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-h8s2vb0lqe", "Errors.AuthorizationDetails.Invalid")
+		}
+	}
+	return nil
+}
+
+// Types returns the distinct types of the authorization details.
+func (d AuthorizationDetails) Types() []string {
+	types := make([]string, 0, len(d))
+	for _, detail := range d {
+		if !slices.Contains(types, detail.Type()) {
+			types = append(types, detail.Type())
+		}
+	}
+	return types
+}
+
+// CheckAllowedTypes returns an error if any of the authorization details has a type,
+// which is not allowed.
+func (d AuthorizationDetails) CheckAllowedTypes(allowed []string) error {
+	for _, typ := range d.Types() {
+		if !slices.Contains(allowed, typ) {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-m1ct5z7ypa", "Errors.AuthorizationDetails.TypeNotAllowed")
+		}
+	}
+	return nil
+}
+
+// Contains checks if every authorization detail of the subset
+// is part of the authorization details.
+func (d AuthorizationDetails) Contains(subset AuthorizationDetails) bool {
+	for _, detail := range subset {
+		if !slices.ContainsFunc(d, func(granted AuthorizationDetail) bool {
+			return reflect.DeepEqual(normalizeAuthorizationDetail(granted), normalizeAuthorizationDetail(detail))
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeAuthorizationDetail marshals and unmarshals the detail,
+// so details parsed from a request can be compared with details read from an event.
+func normalizeAuthorizationDetail(detail AuthorizationDetail) any {
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return detail
+	}
+	var normalized any
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return detail
+	}
+	return normalized
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuthorizationDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		details string
+		want    AuthorizationDetails
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "invalid json",
+			details: `{"type":`,
+			wantErr: true,
+		},
+		{
+			name:    "object instead of array",
+			details: `{"type":"payment_initiation"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing type",
+			details: `[{"actions":["initiate"]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "parsed",
+			details: `[{"type":"payment_initiation","instructedAmount":{"currency":"EUR","amount":"50.00"}}]`,
+			want: AuthorizationDetails{
+				{
+					"type": "payment_initiation",
+					"instructedAmount": map[string]any{
+						"currency": "EUR",
+						"amount":   "50.00",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuthorizationDetails(tt.details)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthorizationDetails_CheckAllowedTypes(t *testing.T) {
+	details := AuthorizationDetails{
+		{"type": "payment_initiation"},
+		{"type": "account_information"},
+	}
+	assert.NoError(t, details.CheckAllowedTypes([]string{"account_information", "payment_initiation"}))
+	assert.Error(t, details.CheckAllowedTypes([]string{"payment_initiation"}))
+	assert.Error(t, details.CheckAllowedTypes(nil))
+	assert.NoError(t, AuthorizationDetails(nil).CheckAllowedTypes(nil))
+}
+
+func TestAuthorizationDetails_Contains(t *testing.T) {
+	granted := AuthorizationDetails{
+		{"type": "payment_initiation", "amount": 50},
+		{"type": "account_information", "locations": []any{"https://example.com/accounts"}},
+	}
+	tests := []struct {
+		name   string
+		subset AuthorizationDetails
+		want   bool
+	}{
+		{
+			name: "empty subset",
+			want: true,
+		},
+		{
+			name:   "contained",
+			subset: AuthorizationDetails{{"type": "account_information", "locations": []string{"https://example.com/accounts"}}},
+			want:   true,
+		},
+		{
+			name:   "number types differ",
+			subset: AuthorizationDetails{{"type": "payment_initiation", "amount": 50.0}},
+			want:   true,
+		},
+		{
+			name:   "other value",
+			subset: AuthorizationDetails{{"type": "payment_initiation", "amount": 5000}},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, granted.Contains(tt.subset))
+		})
+	}
+}
@@ -8,23 +8,24 @@ import (
 )
 
 const (
-	InitCodeMessageType                 = "InitCode"
-	PasswordResetMessageType            = "PasswordReset"
-	VerifyEmailMessageType              = "VerifyEmail"
-	VerifyPhoneMessageType              = "VerifyPhone"
-	VerifySMSOTPMessageType             = "VerifySMSOTP"
-	VerifyEmailOTPMessageType           = "VerifyEmailOTP"
-	DomainClaimedMessageType            = "DomainClaimed"
-	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
-	PasswordChangeMessageType           = "PasswordChange"
-	InviteUserMessageType               = "InviteUser"
-	MessageTitle                        = "Title"
-	MessagePreHeader                    = "PreHeader"
-	MessageSubject                      = "Subject"
-	MessageGreeting                     = "Greeting"
-	MessageText                         = "Text"
-	MessageButtonText                   = "ButtonText"
-	MessageFooterText                   = "Footer"
+	InitCodeMessageType                  = "InitCode"
+	PasswordResetMessageType             = "PasswordReset"
+	VerifyEmailMessageType               = "VerifyEmail"
+	VerifyPhoneMessageType               = "VerifyPhone"
+	VerifySMSOTPMessageType              = "VerifySMSOTP"
+	VerifyEmailOTPMessageType            = "VerifyEmailOTP"
+	DomainClaimedMessageType             = "DomainClaimed"
+	PasswordlessRegistrationMessageType  = "PasswordlessRegistration"
+	PasswordChangeMessageType            = "PasswordChange"
+	InviteUserMessageType                = "InviteUser"
+	BackchannelAuthenticationMessageType = "BackchannelAuthentication"
+	MessageTitle                         = "Title"
+	MessagePreHeader                     = "PreHeader"
+	MessageSubject                       = "Subject"
+	MessageGreeting                      = "Greeting"
+	MessageText                          = "Text"
+	MessageButtonText                    = "ButtonText"
+	MessageFooterText                    = "Footer"
 )
 
 type CustomMessageText struct {
@@ -63,5 +64,6 @@ func IsMessageTextType(textType string) bool {
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
 		textType == PasswordChangeMessageType ||
-		textType == InviteUserMessageType
+		textType == InviteUserMessageType ||
+		textType == BackchannelAuthenticationMessageType
 }
@@ -151,6 +151,11 @@ func (s TriggerType) LocalizationKey() string {
 	}
 }
 
+// FunctionAuthorizationDetailsApproval is called for authorization requests with authorization_details (RFC 9396).
+// The targets can approve, restrict or deny the requested details.
+// The function is only available for Actions v2 executions.
+const FunctionAuthorizationDetailsApproval = "Action.Flow.Type.CustomiseToken.Action.TriggerType.AuthorizationDetailsApproval"
+
 func AllFunctions() []string {
 	functions := make([]string, 0)
 	for _, flowType := range AllFlowTypes() {
@@ -158,7 +163,7 @@ func AllFunctions() []string {
 			functions = append(functions, flowType.LocalizationKey()+"."+triggerType.LocalizationKey())
 		}
 	}
-	return functions
+	return append(functions, FunctionAuthorizationDetailsApproval)
 }
 
 func FunctionExists() func(string) bool {
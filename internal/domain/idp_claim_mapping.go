@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"maps"
+)
+
+// IDPClaimMapping maps the claims received from an identity provider to the attributes of the federated user.
+// Every attribute is an expression of a subset of JSONPath (e.g. `$.name.given` or `$.emails[0].value`), which is evaluated
+// against the claims of the provider. Only member names and array indexes are supported. Empty expressions keep the attribute of the provider's default mapping.
+type IDPClaimMapping struct {
+	PreferredUsername string `json:"preferredUsername,omitempty"`
+	FirstName         string `json:"firstName,omitempty"`
+	LastName          string `json:"lastName,omitempty"`
+	DisplayName       string `json:"displayName,omitempty"`
+	NickName          string `json:"nickName,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     string `json:"emailVerified,omitempty"`
+	Phone             string `json:"phone,omitempty"`
+	PhoneVerified     string `json:"phoneVerified,omitempty"`
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+	AvatarURL         string `json:"avatarURL,omitempty"`
+	Profile           string `json:"profile,omitempty"`
+	// Metadata maps the metadata keys of the user to the expressions of their values
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func (m *IDPClaimMapping) IsZero() bool {
+	return m == nil ||
+		m.PreferredUsername == "" &&
+			m.FirstName == "" &&
+			m.LastName == "" &&
+			m.DisplayName == "" &&
+			m.NickName == "" &&
+			m.Email == "" &&
+			m.EmailVerified == "" &&
+			m.Phone == "" &&
+			m.PhoneVerified == "" &&
+			m.PreferredLanguage == "" &&
+			m.AvatarURL == "" &&
+			m.Profile == "" &&
+			len(m.Metadata) == 0
+}
+
+// Equal reports whether both mappings define the same expressions,
+// a nil mapping equals an empty one.
+func (m *IDPClaimMapping) Equal(mapping *IDPClaimMapping) bool {
+	if m.IsZero() || mapping.IsZero() {
+		return m.IsZero() && mapping.IsZero()
+	}
+	return m.PreferredUsername == mapping.PreferredUsername &&
+		m.FirstName == mapping.FirstName &&
+		m.LastName == mapping.LastName &&
+		m.DisplayName == mapping.DisplayName &&
+		m.NickName == mapping.NickName &&
+		m.Email == mapping.Email &&
+		m.EmailVerified == mapping.EmailVerified &&
+		m.Phone == mapping.Phone &&
+		m.PhoneVerified == mapping.PhoneVerified &&
+		m.PreferredLanguage == mapping.PreferredLanguage &&
+		m.AvatarURL == mapping.AvatarURL &&
+		m.Profile == mapping.Profile &&
+		maps.Equal(m.Metadata, mapping.Metadata)
+}
+
+func (m *IDPClaimMapping) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *IDPClaimMapping) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, m)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), m)
+	}
+	return nil
+}
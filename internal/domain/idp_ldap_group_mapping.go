@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// LDAPGroupMapping grants project roles to the users of an LDAP identity provider based on their group memberships.
+// Only the roles of the mapping are managed, roles granted otherwise are kept.
+type LDAPGroupMapping struct {
+	// GroupAttribute is the attribute of the user listing the DNs of its groups, e.g. `memberOf`
+	GroupAttribute string           `json:"groupAttribute,omitempty"`
+	Groups         []*LDAPGroupRole `json:"groups,omitempty"`
+}
+
+// LDAPGroupRole grants the roles of the project to the members of the group.
+type LDAPGroupRole struct {
+	// Group is the DN of the group, it is compared case-insensitive
+	Group     string   `json:"group"`
+	ProjectID string   `json:"projectId"`
+	RoleKeys  []string `json:"roleKeys"`
+}
+
+func (m *LDAPGroupMapping) IsZero() bool {
+	return m == nil || m.GroupAttribute == "" && len(m.Groups) == 0
+}
+
+func (m *LDAPGroupMapping) IsValid() bool {
+	if m.IsZero() {
+		return true
+	}
+	if m.GroupAttribute == "" {
+		return false
+	}
+	for _, group := range m.Groups {
+		if group == nil || group.Group == "" || group.ProjectID == "" || len(group.RoleKeys) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both mappings grant the same roles,
+// a nil mapping equals an empty one.
+func (m *LDAPGroupMapping) Equal(mapping *LDAPGroupMapping) bool {
+	if m.IsZero() || mapping.IsZero() {
+		return m.IsZero() && mapping.IsZero()
+	}
+	return m.GroupAttribute == mapping.GroupAttribute &&
+		slices.EqualFunc(m.Groups, mapping.Groups, func(a, b *LDAPGroupRole) bool {
+			return a.Group == b.Group && a.ProjectID == b.ProjectID && slices.Equal(a.RoleKeys, b.RoleKeys)
+		})
+}
+
+// ProjectIDs returns the sorted IDs of the projects the mapping grants roles on.
+func (m *LDAPGroupMapping) ProjectIDs() []string {
+	if m.IsZero() {
+		return nil
+	}
+	projectIDs := make([]string, 0, len(m.Groups))
+	for _, group := range m.Groups {
+		projectIDs = appendMissing(projectIDs, group.ProjectID)
+	}
+	slices.Sort(projectIDs)
+	return projectIDs
+}
+
+// ProjectRoles returns the role keys per project granted by the groups
+// and all role keys per project managed by the mapping.
+func (m *LDAPGroupMapping) ProjectRoles(groups []string) (granted, managed map[string][]string) {
+	granted = make(map[string][]string)
+	managed = make(map[string][]string)
+	if m.IsZero() {
+		return granted, managed
+	}
+	for _, group := range m.Groups {
+		managed[group.ProjectID] = appendMissing(managed[group.ProjectID], group.RoleKeys...)
+		isMember := slices.ContainsFunc(groups, func(dn string) bool {
+			return strings.EqualFold(dn, group.Group)
+		})
+		if isMember {
+			granted[group.ProjectID] = appendMissing(granted[group.ProjectID], group.RoleKeys...)
+		}
+	}
+	return granted, managed
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func (m *LDAPGroupMapping) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *LDAPGroupMapping) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, m)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), m)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPGroupMapping_ProjectRoles(t *testing.T) {
+	mapping := &LDAPGroupMapping{
+		GroupAttribute: "memberOf",
+		Groups: []*LDAPGroupRole{
+			{Group: "cn=admins,ou=groups,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+			{Group: "cn=users,ou=groups,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"user"}},
+			{Group: "cn=users,ou=groups,dc=example,dc=com", ProjectID: "project2", RoleKeys: []string{"viewer"}},
+		},
+	}
+	tests := []struct {
+		name        string
+		mapping     *LDAPGroupMapping
+		groups      []string
+		wantGranted map[string][]string
+		wantManaged map[string][]string
+	}{
+		{
+			name:        "no mapping",
+			mapping:     nil,
+			groups:      []string{"cn=admins,ou=groups,dc=example,dc=com"},
+			wantGranted: map[string][]string{},
+			wantManaged: map[string][]string{},
+		},
+		{
+			name:        "no groups",
+			mapping:     mapping,
+			wantGranted: map[string][]string{},
+			wantManaged: map[string][]string{"project1": {"admin", "user"}, "project2": {"viewer"}},
+		},
+		{
+			name:        "case-insensitive group",
+			mapping:     mapping,
+			groups:      []string{"CN=Users,OU=Groups,DC=example,DC=com", "cn=other,dc=example,dc=com"},
+			wantGranted: map[string][]string{"project1": {"user"}, "project2": {"viewer"}},
+			wantManaged: map[string][]string{"project1": {"admin", "user"}, "project2": {"viewer"}},
+		},
+		{
+			name:        "roles of multiple groups",
+			mapping:     mapping,
+			groups:      []string{"cn=users,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+			wantGranted: map[string][]string{"project1": {"admin", "user"}, "project2": {"viewer"}},
+			wantManaged: map[string][]string{"project1": {"admin", "user"}, "project2": {"viewer"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted, managed := tt.mapping.ProjectRoles(tt.groups)
+			assert.Equal(t, tt.wantGranted, granted)
+			assert.Equal(t, tt.wantManaged, managed)
+		})
+	}
+}
+
+func TestLDAPGroupMapping_ProjectIDs(t *testing.T) {
+	assert.Nil(t, (*LDAPGroupMapping)(nil).ProjectIDs())
+	mapping := &LDAPGroupMapping{
+		GroupAttribute: "memberOf",
+		Groups: []*LDAPGroupRole{
+			{Group: "cn=users,ou=groups,dc=example,dc=com", ProjectID: "project2", RoleKeys: []string{"viewer"}},
+			{Group: "cn=admins,ou=groups,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"admin"}},
+			{Group: "cn=users,ou=groups,dc=example,dc=com", ProjectID: "project1", RoleKeys: []string{"user"}},
+		},
+	}
+	assert.Equal(t, []string{"project1", "project2"}, mapping.ProjectIDs())
+}
+
+func TestLDAPGroupMapping_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *LDAPGroupMapping
+		want    bool
+	}{
+		{
+			name:    "nil",
+			mapping: nil,
+			want:    true,
+		},
+		{
+			name:    "missing group attribute",
+			mapping: &LDAPGroupMapping{Groups: []*LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}}},
+			want:    false,
+		},
+		{
+			name:    "missing roles",
+			mapping: &LDAPGroupMapping{GroupAttribute: "memberOf", Groups: []*LDAPGroupRole{{Group: "cn=admins", ProjectID: "project"}}},
+			want:    false,
+		},
+		{
+			name:    "valid",
+			mapping: &LDAPGroupMapping{GroupAttribute: "memberOf", Groups: []*LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}}},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mapping.IsValid())
+		})
+	}
+}
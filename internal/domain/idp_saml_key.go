@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+// SAMLKey is an additional key pair of a SAML service provider.
+// Its certificate is published in the metadata next to the active one and the key is used to decrypt assertions,
+// so the identity provider can pick up a new certificate before it is activated and the previous one stays usable afterwards.
+type SAMLKey struct {
+	ID          string              `json:"id"`
+	Key         *crypto.CryptoValue `json:"key"`
+	Certificate []byte              `json:"certificate"`
+}
+
+type SAMLKeys []*SAMLKey
+
+// Get returns the key with the id or nil if it does not exist.
+func (k SAMLKeys) Get(id string) *SAMLKey {
+	i := slices.IndexFunc(k, func(key *SAMLKey) bool {
+		return key.ID == id
+	})
+	if i < 0 {
+		return nil
+	}
+	return k[i]
+}
+
+// Remove returns a copy of the keys without the key with the id.
+func (k SAMLKeys) Remove(id string) SAMLKeys {
+	return slices.DeleteFunc(slices.Clone(k), func(key *SAMLKey) bool {
+		return key.ID == id
+	})
+}
+
+func (k SAMLKeys) Value() (driver.Value, error) {
+	if len(k) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(k)
+}
+
+func (k *SAMLKeys) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, k)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), k)
+	}
+	return nil
+}
@@ -38,6 +38,10 @@ const (
 	PermissionOrgRead             = "org.read"
 	PermissionIDPRead             = "iam.idp.read"
 	PermissionOrgIDPRead          = "org.idp.read"
+	PermissionProjectRoleRead     = "project.role.read"
+	PermissionProjectRoleWrite    = "project.role.write"
+	PermissionProjectRoleDelete   = "project.role.delete"
+	PermissionUserGrantWrite      = "user.grant.write"
 )
 
 // ProjectPermissionCheck is used as a check for preconditions dependent on application, project, user resourceowner and usergrants.
@@ -13,6 +13,9 @@ type Project struct {
 	ProjectRoleCheck       bool
 	HasProjectCheck        bool
 	PrivateLabelingSetting PrivateLabelingSetting
+	// AuthorizationDetailsTypes are the types of authorization_details (RFC 9396),
+	// which the applications of the project are allowed to request.
+	AuthorizationDetailsTypes []string
 }
 
 type ProjectState int32
@@ -32,6 +32,10 @@ func (*mockKeyStorage) CreateKeys(context.Context, ...*crypto.Key) error {
 	return errors.New("mockKeyStorage.CreateKeys not implemented")
 }
 
+func (*mockKeyStorage) DeleteKeys(context.Context, ...string) error {
+	return errors.New("mockKeyStorage.DeleteKeys not implemented")
+}
+
 func TestFromRefreshToken(t *testing.T) {
 	const (
 		userID  = "userID"
@@ -0,0 +1,15 @@
+package domain
+
+// SCIMProvisioningState is the state of the provisioning of a user to the scim service provider of an application.
+type SCIMProvisioningState int32
+
+const (
+	SCIMProvisioningStateUnspecified SCIMProvisioningState = iota
+	// SCIMProvisioningStateProvisioned the user is created or updated at the scim service provider
+	SCIMProvisioningStateProvisioned
+	// SCIMProvisioningStateDeactivated the user is deactivated at the scim service provider,
+	// because it is no longer granted on the project or removed.
+	SCIMProvisioningStateDeactivated
+	// SCIMProvisioningStateFailed the last call to the scim service provider failed
+	SCIMProvisioningStateFailed
+)
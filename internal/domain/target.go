@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type TargetType uint
 
 const (
@@ -24,3 +26,11 @@ func (s TargetState) Valid() bool {
 func (s TargetState) Exists() bool {
 	return s != TargetUnspecified && s != TargetRemoved
 }
+
+// TargetRetryPolicy defines how the delivery to an async or webhook target is retried.
+// Zero values fall back to the defaults of the execution worker.
+type TargetRetryPolicy struct {
+	MaxAttempts    uint8         `json:"maxAttempts,omitempty"`
+	InitialBackoff time.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     time.Duration `json:"maxBackoff,omitempty"`
+}
@@ -0,0 +1,243 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/samlrequest"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ClosingEventTypes are the event types after which no further events are pushed to an aggregate.
+// Aggregates whose latest event is of one of these types are archived.
+var ClosingEventTypes = []eventstore.EventType{
+	user.UserRemovedType,
+	user.UserForgottenType,
+	org.OrgRemovedEventType,
+	project.ProjectRemovedType,
+	usergrant.UserGrantRemovedType,
+	session.TerminateType,
+	authrequest.SucceededType,
+	authrequest.FailedType,
+	samlrequest.SucceededType,
+	samlrequest.FailedType,
+}
+
+// Archiver moves the events of closed aggregates from the eventstore to the [Storage].
+//
+// The closing event stays in the eventstore, so the sequence of the aggregate is preserved
+// and projections relying on the latest event of an aggregate are not affected.
+type Archiver struct {
+	client       *database.DB
+	storage      Storage
+	config       *Config
+	closingTypes []string
+	now          func() time.Time
+}
+
+func NewArchiver(client *database.DB, storage Storage, config *Config) *Archiver {
+	closingTypes := make([]string, len(ClosingEventTypes))
+	for i, typ := range ClosingEventTypes {
+		closingTypes[i] = string(typ)
+	}
+	return &Archiver{
+		client:       client,
+		storage:      storage,
+		config:       config,
+		closingTypes: closingTypes,
+		now:          time.Now,
+	}
+}
+
+type aggregate struct {
+	instanceID    string
+	aggregateType string
+	aggregateID   string
+}
+
+const candidatesQuery = `SELECT e.instance_id, e.aggregate_type, e.aggregate_id FROM eventstore.events2 e` +
+	` WHERE e.event_type = ANY($1) AND e.created_at < $2` +
+	` AND NOT EXISTS (SELECT 1 FROM eventstore.events2 n WHERE n.instance_id = e.instance_id AND n.aggregate_type = e.aggregate_type AND n.aggregate_id = e.aggregate_id AND n."sequence" > e."sequence")` +
+	` AND EXISTS (SELECT 1 FROM eventstore.events2 p WHERE p.instance_id = e.instance_id AND p.aggregate_type = e.aggregate_type AND p.aggregate_id = e.aggregate_id AND p."sequence" < e."sequence")` +
+	` LIMIT $3`
+
+// Archive archives the events of up to [Config.BulkLimit] closed aggregates
+// and returns the amount of archived aggregates.
+func (a *Archiver) Archive(ctx context.Context) (archived int, err error) {
+	cutoff := a.now().Add(-a.config.MinAge)
+	aggregates := make([]*aggregate, 0, a.config.BulkLimit)
+	err = a.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			agg := new(aggregate)
+			if err := rows.Scan(&agg.instanceID, &agg.aggregateType, &agg.aggregateID); err != nil {
+				return err
+			}
+			aggregates = append(aggregates, agg)
+		}
+		return nil
+	}, candidatesQuery, database.TextArray[string](a.closingTypes), cutoff, a.config.BulkLimit)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "ARCHI-e1l6tz9vcq", "unable to query closed aggregates")
+	}
+	for _, agg := range aggregates {
+		ok, err := a.archiveAggregate(ctx, agg, cutoff)
+		if err != nil {
+			return archived, err
+		}
+		if ok {
+			archived++
+		}
+	}
+	return archived, nil
+}
+
+const (
+	aggregateEventsQuery = `SELECT created_at, event_type, "sequence", "position", payload, creator, owner, revision FROM eventstore.events2` +
+		` WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 ORDER BY "sequence" FOR UPDATE`
+	archivedObjectQuery = `SELECT object_name FROM eventstore.archived_aggregates` +
+		` WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 FOR UPDATE`
+	upsertArchivedStmt = `INSERT INTO eventstore.archived_aggregates (instance_id, aggregate_type, aggregate_id, object_name, event_count, "position") VALUES ($1, $2, $3, $4, $5, $6)` +
+		` ON CONFLICT (instance_id, aggregate_type, aggregate_id) DO UPDATE SET object_name = EXCLUDED.object_name, event_count = EXCLUDED.event_count, "position" = EXCLUDED."position", archived_at = now()`
+	deleteArchivedEventsStmt = `DELETE FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND "sequence" < $4`
+)
+
+// archiveAggregate writes all events except the closing event to the storage and removes them from the eventstore.
+// The events are locked during the archival, so no event can be pushed in the meantime.
+// False is returned if the aggregate isn't closed anymore.
+//
+// Each archival is written to a new object, which is only referenced after the transaction is committed.
+// Objects of failed archivals and of previous archivals of the aggregate are removed afterwards.
+func (a *Archiver) archiveAggregate(ctx context.Context, agg *aggregate, cutoff time.Time) (_ bool, err error) {
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-k9r2fj4xnw", "unable to begin transaction")
+	}
+	var name, previousName string
+	defer func() {
+		err = database.CloseTransaction(tx, err)
+		a.cleanup(ctx, name, previousName, err)
+	}()
+
+	events, err := a.aggregateEvents(ctx, tx, agg)
+	if err != nil {
+		return false, err
+	}
+	// the aggregate could have been changed since the candidates were queried
+	if len(events) < 2 || !a.isClosing(events[len(events)-1], cutoff) {
+		return false, nil
+	}
+	closing := events[len(events)-1]
+	events = events[:len(events)-1]
+
+	previousName, previous, err := a.previouslyArchived(ctx, tx, agg)
+	if err != nil {
+		return false, err
+	}
+	events = append(previous, events...)
+
+	data, err := encodeEvents(events)
+	if err != nil {
+		return false, err
+	}
+	name = objectName(agg.instanceID, agg.aggregateType, agg.aggregateID, closing.Sequence)
+	if err = a.storage.Put(ctx, name, data); err != nil {
+		return false, err
+	}
+	if _, err = tx.ExecContext(ctx, upsertArchivedStmt, agg.instanceID, agg.aggregateType, agg.aggregateID, name, len(events), events[len(events)-1].Position); err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-a3q8mv1ecy", "unable to store archived aggregate")
+	}
+	if _, err = tx.ExecContext(ctx, deleteArchivedEventsStmt, agg.instanceID, agg.aggregateType, agg.aggregateID, closing.Sequence); err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-y5w0gs7hun", "unable to delete archived events")
+	}
+	return true, nil
+}
+
+// cleanup removes the object written by a failed archival
+// or the object of the previous archival, which is replaced after a successful archival.
+// Objects which cannot be removed are only unreferenced, so failures are logged.
+func (a *Archiver) cleanup(ctx context.Context, name, previousName string, err error) {
+	unreferenced := previousName
+	if err != nil {
+		unreferenced = name
+	}
+	if unreferenced == "" {
+		return
+	}
+	logging.WithFields("object", unreferenced).OnError(a.storage.Delete(ctx, unreferenced)).Warn("unable to delete unreferenced archive")
+}
+
+func (a *Archiver) aggregateEvents(ctx context.Context, tx *sql.Tx, agg *aggregate) ([]*archivedEvent, error) {
+	rows, err := tx.QueryContext(ctx, aggregateEventsQuery, agg.instanceID, agg.aggregateType, agg.aggregateID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-n7c4bd2ipz", "unable to query aggregate events")
+	}
+	defer rows.Close()
+	events := make([]*archivedEvent, 0)
+	for rows.Next() {
+		event := &archivedEvent{
+			InstanceID:    agg.instanceID,
+			AggregateType: agg.aggregateType,
+			AggregateID:   agg.aggregateID,
+		}
+		var (
+			payload []byte
+			owner   sql.NullString
+		)
+		if err = rows.Scan(&event.CreatedAt, &event.EventType, &event.Sequence, &event.Position, &payload, &event.Creator, &owner, &event.Revision); err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHI-n7c4bd2ipz", "unable to query aggregate events")
+		}
+		event.Payload = payload
+		event.Owner = owner.String
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-n7c4bd2ipz", "unable to query aggregate events")
+	}
+	return events, nil
+}
+
+// previouslyArchived returns the object name and the events of an earlier archival of the same aggregate,
+// which happens if events were pushed after the aggregate was archived and it was closed again.
+func (a *Archiver) previouslyArchived(ctx context.Context, tx *sql.Tx, agg *aggregate) (string, []*archivedEvent, error) {
+	var name string
+	err := tx.QueryRowContext(ctx, archivedObjectQuery, agg.instanceID, agg.aggregateType, agg.aggregateID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, zerrors.ThrowInternal(err, "ARCHI-h2p6xk0sud", "unable to query archived aggregate")
+	}
+	data, err := a.storage.Get(ctx, name)
+	if err != nil {
+		return "", nil, err
+	}
+	events, err := decodeEvents(data)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, events, nil
+}
+
+func (a *Archiver) isClosing(event *archivedEvent, cutoff time.Time) bool {
+	if !event.CreatedAt.Before(cutoff) {
+		return false
+	}
+	for _, typ := range a.closingTypes {
+		if event.EventType == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package archive
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestArchiver_Archive(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	name := objectName("instance", "user", "user1", 3)
+	previousName := objectName("instance", "user", "user1", 1)
+	eventColumns := []string{"created_at", "event_type", "sequence", "position", "payload", "creator", "owner", "revision"}
+
+	tests := []struct {
+		name         string
+		previous     []*archivedEvent
+		mock         func(t *testing.T) *mock.SQLMock
+		wantArchived int
+		wantErr      bool
+		wantEvents   []uint64
+	}{
+		{
+			name: "archive closed aggregate",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(candidatesQuery,
+						mock.WithQueryArgs(sqlmock.AnyArg(), now.Add(-24*time.Hour), sqlmock.AnyArg()),
+						mock.WithQueryResult([]string{"instance_id", "aggregate_type", "aggregate_id"}, [][]driver.Value{{"instance", "user", "user1"}}),
+					),
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(aggregateEventsQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult(eventColumns, [][]driver.Value{
+							{old, "user.human.added", 1, 1.0, []byte(`{"userName":"hodor"}`), "creator", "org", 2},
+							{old, "user.human.changed", 2, 2.0, nil, "creator", "org", 2},
+							{old, "user.removed", 3, 3.0, nil, "creator", "org", 2},
+						}),
+					),
+					mock.ExpectQuery(archivedObjectQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{}),
+					),
+					mock.ExcpectExec(upsertArchivedStmt,
+						mock.WithExecArgs("instance", "user", "user1", name, 2, 2.0),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deleteArchivedEventsStmt,
+						mock.WithExecArgs("instance", "user", "user1", uint64(3)),
+						mock.WithExecRowsAffected(2),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+			wantArchived: 1,
+			wantEvents:   []uint64{1, 2},
+		},
+		{
+			name: "archive reclosed aggregate replaces previous archive",
+			previous: []*archivedEvent{
+				{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", EventType: "user.human.added", Sequence: 1, Position: 1},
+			},
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(candidatesQuery,
+						mock.WithQueryArgs(sqlmock.AnyArg(), now.Add(-24*time.Hour), sqlmock.AnyArg()),
+						mock.WithQueryResult([]string{"instance_id", "aggregate_type", "aggregate_id"}, [][]driver.Value{{"instance", "user", "user1"}}),
+					),
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(aggregateEventsQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult(eventColumns, [][]driver.Value{
+							{old, "user.human.changed", 2, 2.0, nil, "creator", "org", 2},
+							{old, "user.removed", 3, 3.0, nil, "creator", "org", 2},
+						}),
+					),
+					mock.ExpectQuery(archivedObjectQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{{previousName}}),
+					),
+					mock.ExcpectExec(upsertArchivedStmt,
+						mock.WithExecArgs("instance", "user", "user1", name, 2, 2.0),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deleteArchivedEventsStmt,
+						mock.WithExecArgs("instance", "user", "user1", uint64(3)),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+			wantArchived: 1,
+			wantEvents:   []uint64{1, 2},
+		},
+		{
+			name: "failed commit removes written archive",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(candidatesQuery,
+						mock.WithQueryArgs(sqlmock.AnyArg(), now.Add(-24*time.Hour), sqlmock.AnyArg()),
+						mock.WithQueryResult([]string{"instance_id", "aggregate_type", "aggregate_id"}, [][]driver.Value{{"instance", "user", "user1"}}),
+					),
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(aggregateEventsQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult(eventColumns, [][]driver.Value{
+							{old, "user.human.added", 1, 1.0, nil, "creator", "org", 2},
+							{old, "user.human.changed", 2, 2.0, nil, "creator", "org", 2},
+							{old, "user.removed", 3, 3.0, nil, "creator", "org", 2},
+						}),
+					),
+					mock.ExpectQuery(archivedObjectQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{}),
+					),
+					mock.ExcpectExec(upsertArchivedStmt,
+						mock.WithExecArgs("instance", "user", "user1", name, 2, 2.0),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deleteArchivedEventsStmt,
+						mock.WithExecArgs("instance", "user", "user1", uint64(3)),
+						mock.WithExecRowsAffected(2),
+					),
+					mock.ExpectCommit(errors.New("commit failed")),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregate reopened",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(candidatesQuery,
+						mock.WithQueryArgs(sqlmock.AnyArg(), now.Add(-24*time.Hour), sqlmock.AnyArg()),
+						mock.WithQueryResult([]string{"instance_id", "aggregate_type", "aggregate_id"}, [][]driver.Value{{"instance", "user", "user1"}}),
+					),
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(aggregateEventsQuery,
+						mock.WithQueryArgs("instance", "user", "user1"),
+						mock.WithQueryResult(eventColumns, [][]driver.Value{
+							{old, "user.human.added", 1, 1.0, nil, "creator", "org", 2},
+							{old, "user.removed", 2, 2.0, nil, "creator", "org", 2},
+							{now, "user.human.changed", 3, 3.0, nil, "creator", "org", 2},
+						}),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+			wantArchived: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage, err := NewFilesystemStorage(t.TempDir())
+			require.NoError(t, err)
+			if tt.previous != nil {
+				data, err := encodeEvents(tt.previous)
+				require.NoError(t, err)
+				require.NoError(t, storage.Put(ctx, previousName, data))
+			}
+			dbMock := tt.mock(t)
+			defer dbMock.Assert(t)
+
+			archiver := NewArchiver(&database.DB{DB: dbMock.DB}, storage, &Config{MinAge: 24 * time.Hour, BulkLimit: 10})
+			archiver.now = func() time.Time { return now }
+			archived, err := archiver.Archive(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantArchived, archived)
+			if tt.previous != nil {
+				_, err = storage.Get(ctx, previousName)
+				assert.True(t, zerrors.IsNotFound(err), "previous archive must be removed")
+			}
+
+			data, err := storage.Get(ctx, name)
+			if tt.wantEvents == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			events, err := decodeEvents(data)
+			require.NoError(t, err)
+			sequences := make([]uint64, len(events))
+			for i, event := range events {
+				sequences[i] = event.Sequence
+			}
+			assert.Equal(t, tt.wantEvents, sequences)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	// Enabled schedules the archive job and replays archived events on queries for specific aggregates.
+	Enabled bool
+	// MinAge is the minimum age of the event which closed an aggregate, before the aggregate is archived.
+	MinAge time.Duration
+	// Interval in which the archive job is scheduled by the leader of the queue.
+	Interval time.Duration
+	// BulkLimit is the maximum amount of aggregates archived per run.
+	BulkLimit uint16
+	Storage   StorageConfig
+}
+
+type StorageType string
+
+const (
+	StorageTypeFilesystem StorageType = "filesystem"
+	StorageTypeS3         StorageType = "s3"
+)
+
+type StorageConfig struct {
+	// Type of the storage, "filesystem" or "s3".
+	Type       StorageType
+	Filesystem FilesystemConfig
+	S3         S3Config
+}
+
+// NewStorage returns the configured storage for archived events.
+func (c *StorageConfig) NewStorage() (Storage, error) {
+	switch c.Type {
+	case StorageTypeFilesystem:
+		return NewFilesystemStorage(c.Filesystem.Path)
+	case StorageTypeS3:
+		return c.S3.NewStorage()
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "ARCHI-t2w0hq8xzu", "unknown archive storage type %q", c.Type)
+	}
+}
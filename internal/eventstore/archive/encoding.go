@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// archivedEvent is the representation of an event in the archive.
+// Archives are gzip compressed files containing one json encoded event per line.
+type archivedEvent struct {
+	InstanceID    string          `json:"instanceId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	Position      float64         `json:"position"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Creator       string          `json:"creator"`
+	Owner         string          `json:"owner"`
+	Revision      uint16          `json:"revision"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+// objectName returns the name under which the events of the aggregate are stored.
+// The aggregate id is encoded as it's chosen by the client and could contain path separators.
+// The sequence of the closing event makes the name unique per archival, so referenced objects are never overwritten.
+func objectName(instanceID, aggregateType, aggregateID string, closingSequence uint64) string {
+	return strings.Join([]string{
+		instanceID,
+		aggregateType,
+		base64.RawURLEncoding.EncodeToString([]byte(aggregateID)) + "." + strconv.FormatUint(closingSequence, 10) + ".jsonl.gz",
+	}, "/")
+}
+
+func encodeEvents(events []*archivedEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHI-o4y9sd2pke", "unable to encode archive")
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-o4y9sd2pke", "unable to encode archive")
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeEvents(data []byte) ([]*archivedEvent, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-b8h3wn5zrt", "unable to decode archive")
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	// payloads can be larger than the default token size
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	events := make([]*archivedEvent, 0)
+	for scanner.Scan() {
+		event := new(archivedEvent)
+		if err = json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, zerrors.ThrowInternal(err, "ARCHI-b8h3wn5zrt", "unable to decode archive")
+		}
+		events = append(events, event)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-b8h3wn5zrt", "unable to decode archive")
+	}
+	return events, nil
+}
+
+func (e *archivedEvent) toEvent() *repository.Event {
+	var data []byte
+	// an event without payload is stored as null
+	if len(e.Payload) > 0 && string(e.Payload) != "null" {
+		data = e.Payload
+	}
+	return &repository.Event{
+		Seq:           e.Sequence,
+		Pos:           e.Position,
+		CreationDate:  e.CreatedAt,
+		Typ:           eventstore.EventType(e.EventType),
+		Data:          data,
+		EditorUser:    e.Creator,
+		Version:       eventstore.Version("v" + strconv.Itoa(int(e.Revision))),
+		AggregateID:   e.AggregateID,
+		AggregateType: eventstore.AggregateType(e.AggregateType),
+		ResourceOwner: sql.NullString{String: e.Owner, Valid: e.Owner != ""},
+		InstanceID:    e.InstanceID,
+	}
+}
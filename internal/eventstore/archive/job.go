@@ -0,0 +1,64 @@
+package archive
+
+import (
+	"context"
+
+	"github.com/riverqueue/river"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/queue"
+)
+
+const QueueName = "event_archive"
+
+// ArchiveEvents is the periodic job to archive the events of closed aggregates.
+// It's scheduled by the leader of the queue only, so the search for closed aggregates runs once per interval.
+type ArchiveEvents struct{}
+
+func (*ArchiveEvents) Kind() string {
+	return "event_archive"
+}
+
+// InsertOpts implements [river.JobArgsWithInsertOpts]
+func (*ArchiveEvents) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: QueueName,
+		// the next run archives the remaining aggregates
+		MaxAttempts: 1,
+	}
+}
+
+var (
+	_ river.Worker[*ArchiveEvents] = (*Worker)(nil)
+	_ queue.Worker                 = (*Worker)(nil)
+)
+
+// Worker archives the events of closed aggregates.
+type Worker struct {
+	river.WorkerDefaults[*ArchiveEvents]
+
+	archiver *Archiver
+}
+
+func NewWorker(archiver *Archiver) *Worker {
+	return &Worker{
+		archiver: archiver,
+	}
+}
+
+// Register implements [queue.Worker]
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: 1,
+	}
+}
+
+// Work implements [river.Worker]
+func (w *Worker) Work(ctx context.Context, _ *river.Job[*ArchiveEvents]) error {
+	archived, err := w.archiver.Archive(ctx)
+	if archived > 0 {
+		logging.WithFields("aggregates", archived).Info("events archived")
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ eventstore.Querier = (*Querier)(nil)
+
+// Querier replays the archived events of aggregates
+// in addition to the events returned by the wrapped [eventstore.Querier].
+//
+// Archived events are only replayed for queries which are restricted to specific aggregate ids,
+// which is the case for write models requiring the full history of an aggregate.
+// Queries without aggregate ids, for example of projections, only return the events of the eventstore.
+// Reads which don't use the [eventstore.Querier], like the [eventstore.Searcher], the v4 eventstore
+// and projection rebuilds, don't replay archived events either.
+type Querier struct {
+	eventstore.Querier
+	client  *database.DB
+	storage Storage
+}
+
+func NewQuerier(querier eventstore.Querier, client *database.DB, storage Storage) *Querier {
+	return &Querier{
+		Querier: querier,
+		client:  client,
+		storage: storage,
+	}
+}
+
+// FilterToReducer implements [eventstore.Querier]
+func (q *Querier) FilterToReducer(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder, reduce eventstore.Reducer) error {
+	aggregateIDs := archivableAggregateIDs(searchQuery)
+	if len(aggregateIDs) == 0 {
+		return q.Querier.FilterToReducer(ctx, searchQuery, reduce)
+	}
+	archived, err := q.archivedEvents(ctx, searchQuery, aggregateIDs)
+	if err != nil {
+		return err
+	}
+	if len(archived) == 0 {
+		return q.Querier.FilterToReducer(ctx, searchQuery, reduce)
+	}
+
+	// the archived events are merged with the stored events by their position.
+	// Because the limit is applied to both sets, the merged set contains at least the limited events.
+	stored := make([]eventstore.Event, 0, len(archived))
+	err = q.Querier.FilterToReducer(ctx, searchQuery, func(event eventstore.Event) error {
+		stored = append(stored, event)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	events := mergeEvents(archived, stored, searchQuery.GetDesc())
+	if limit := searchQuery.GetLimit(); limit > 0 && uint64(len(events)) > limit {
+		events = events[:limit]
+	}
+	for _, event := range events {
+		if err = reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archivableAggregateIDs returns the aggregate ids of the query
+// if archived events can be replayed for it.
+func archivableAggregateIDs(searchQuery *eventstore.SearchQueryBuilder) []string {
+	if searchQuery.GetColumns() != eventstore.ColumnsEvent || searchQuery.GetOffset() > 0 || len(searchQuery.GetQueries()) == 0 {
+		return nil
+	}
+	aggregateIDs := make([]string, 0, len(searchQuery.GetQueries()))
+	for _, query := range searchQuery.GetQueries() {
+		if len(query.GetAggregateIDs()) == 0 {
+			return nil
+		}
+		aggregateIDs = append(aggregateIDs, query.GetAggregateIDs()...)
+	}
+	slices.Sort(aggregateIDs)
+	return slices.Compact(aggregateIDs)
+}
+
+const archivedObjectsQuery = `SELECT object_name FROM eventstore.archived_aggregates WHERE aggregate_id = ANY($1) AND (cardinality($2::TEXT[]) = 0 OR instance_id = ANY($2))`
+
+func (q *Querier) archivedEvents(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder, aggregateIDs []string) ([]eventstore.Event, error) {
+	instanceIDs := searchQuery.GetInstanceIDs()
+	if instanceID := searchQuery.GetInstanceID(); instanceID != nil {
+		instanceIDs = []string{*instanceID}
+	}
+	names := make([]string, 0)
+	err := q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			names = append(names, name)
+		}
+		return nil
+	}, archivedObjectsQuery, database.TextArray[string](aggregateIDs), database.TextArray[string](instanceIDs))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-f6t1uw8zyl", "unable to query archived aggregates")
+	}
+
+	events := make([]eventstore.Event, 0)
+	for _, name := range names {
+		data, err := q.storage.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := decodeEvents(data)
+		if err != nil {
+			return nil, err
+		}
+		if isExcluded(searchQuery.GetExcludeAggregateIDs(), archived) {
+			continue
+		}
+		for _, event := range archived {
+			if matches(searchQuery, event) {
+				events = append(events, event.toEvent())
+			}
+		}
+	}
+	return events, nil
+}
+
+// mergeEvents combines both sets of events ordered by position.
+func mergeEvents(archived, stored []eventstore.Event, desc bool) []eventstore.Event {
+	events := append(archived, stored...)
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Position() == events[j].Position() {
+			return events[i].Sequence() < events[j].Sequence() != desc
+		}
+		return events[i].Position() < events[j].Position() != desc
+	})
+	return events
+}
+
+// matches reproduces the conditions of the eventstore query for an archived event.
+func matches(searchQuery *eventstore.SearchQueryBuilder, event *archivedEvent) bool {
+	if instanceID := searchQuery.GetInstanceID(); instanceID != nil && *instanceID != event.InstanceID {
+		return false
+	}
+	if instanceIDs := searchQuery.GetInstanceIDs(); len(instanceIDs) > 0 && !slices.Contains(instanceIDs, event.InstanceID) {
+		return false
+	}
+	if owner := searchQuery.GetResourceOwner(); owner != "" && owner != event.Owner {
+		return false
+	}
+	if editor := searchQuery.GetEditorUser(); editor != "" && editor != event.Creator {
+		return false
+	}
+	if position := searchQuery.GetPositionAfter(); position > 0 && event.Position <= position {
+		return false
+	}
+	if position := searchQuery.GetPositionAtLeast(); position > 0 && event.Position < position {
+		return false
+	}
+	if sequence := searchQuery.GetEventSequenceGreater(); sequence > 0 && event.Sequence <= sequence {
+		return false
+	}
+	if after := searchQuery.GetCreationDateAfter(); !after.IsZero() && !event.CreatedAt.After(after) {
+		return false
+	}
+	if before := searchQuery.GetCreationDateBefore(); !before.IsZero() && !event.CreatedAt.Before(before) {
+		return false
+	}
+	for _, query := range searchQuery.GetQueries() {
+		if queryMatches(query, event) {
+			return true
+		}
+	}
+	return false
+}
+
+func queryMatches(query *eventstore.SearchQuery, event *archivedEvent) bool {
+	if types := query.GetAggregateTypes(); len(types) > 0 && !slices.Contains(types, eventstore.AggregateType(event.AggregateType)) {
+		return false
+	}
+	if ids := query.GetAggregateIDs(); len(ids) > 0 && !slices.Contains(ids, event.AggregateID) {
+		return false
+	}
+	if types := query.GetEventTypes(); len(types) > 0 && !slices.Contains(types, eventstore.EventType(event.EventType)) {
+		return false
+	}
+	if position := query.GetPositionAfter(); position > 0 && event.Position <= position {
+		return false
+	}
+	return payloadContains(event.Payload, query.GetEventData())
+}
+
+// payloadContains checks if the top level fields of data are equal in the payload.
+func payloadContains(payload json.RawMessage, data map[string]interface{}) bool {
+	if len(data) == 0 {
+		return true
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return false
+	}
+	// normalize the expected values to their json representation
+	expected, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	wanted := make(map[string]interface{}, len(data))
+	if err = json.Unmarshal(expected, &wanted); err != nil {
+		return false
+	}
+	for key, value := range wanted {
+		if !reflect.DeepEqual(fields[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isExcluded checks if the archived aggregate contains an event matching the exclusion query.
+func isExcluded(exclusion *eventstore.ExclusionQuery, events []*archivedEvent) bool {
+	if exclusion == nil {
+		return false
+	}
+	for _, event := range events {
+		if types := exclusion.GetAggregateTypes(); len(types) > 0 && !slices.Contains(types, eventstore.AggregateType(event.AggregateType)) {
+			continue
+		}
+		if types := exclusion.GetEventTypes(); len(types) > 0 && !slices.Contains(types, eventstore.EventType(event.EventType)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
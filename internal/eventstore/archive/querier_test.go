@@ -0,0 +1,291 @@
+package archive
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func Test_encoding(t *testing.T) {
+	events := []*archivedEvent{
+		{
+			InstanceID:    "instance",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			EventType:     "user.human.added",
+			Sequence:      1,
+			Position:      1.5,
+			CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Creator:       "creator",
+			Owner:         "org",
+			Revision:      2,
+			Payload:       json.RawMessage(`{"userName":"hodor"}`),
+		},
+		{
+			InstanceID:    "instance",
+			AggregateType: "user",
+			AggregateID:   "user1",
+			EventType:     "user.human.password.changed",
+			Sequence:      2,
+			Position:      2,
+		},
+	}
+	data, err := encodeEvents(events)
+	require.NoError(t, err)
+	decoded, err := decodeEvents(data)
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+
+	event := decoded[0].toEvent()
+	assert.Equal(t, eventstore.Version("v2"), event.Version)
+	assert.Equal(t, "org", event.ResourceOwner.String)
+	assert.Nil(t, decoded[1].toEvent().Data)
+}
+
+func Test_matches(t *testing.T) {
+	event := &archivedEvent{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		EventType:     "user.human.added",
+		Sequence:      3,
+		Position:      10,
+		CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Creator:       "creator",
+		Owner:         "org",
+		Payload:       json.RawMessage(`{"userName":"hodor","age":3}`),
+	}
+	tests := []struct {
+		name  string
+		query *eventstore.SearchQueryBuilder
+		want  bool
+	}{
+		{
+			name: "aggregate",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			want: true,
+		},
+		{
+			name: "other instance",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("other").
+				AddQuery().AggregateIDs("user1").Builder(),
+			want: false,
+		},
+		{
+			name: "other resource owner",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				ResourceOwner("other").
+				AddQuery().AggregateIDs("user1").Builder(),
+			want: false,
+		},
+		{
+			name: "position after",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				PositionAfter(10).
+				AddQuery().AggregateIDs("user1").Builder(),
+			want: false,
+		},
+		{
+			name: "sequence greater",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				SequenceGreater(2).
+				AddQuery().AggregateIDs("user1").Builder(),
+			want: true,
+		},
+		{
+			name: "created before",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				CreationDateBefore(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).
+				AddQuery().AggregateIDs("user1").Builder(),
+			want: false,
+		},
+		{
+			name: "other event type",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("user1").EventTypes("user.removed").Builder(),
+			want: false,
+		},
+		{
+			name: "second query",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("user2").
+				Or().AggregateIDs("user1").EventTypes("user.human.added").Builder(),
+			want: true,
+		},
+		{
+			name: "event data",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("user1").EventData(map[string]interface{}{"userName": "hodor", "age": 3}).Builder(),
+			want: true,
+		},
+		{
+			name: "other event data",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("user1").EventData(map[string]interface{}{"userName": "other"}).Builder(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matches(tt.query, event))
+		})
+	}
+}
+
+func Test_archivableAggregateIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *eventstore.SearchQueryBuilder
+		want  []string
+	}{
+		{
+			name: "aggregate ids",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("b", "a").
+				Or().AggregateIDs("a").Builder(),
+			want: []string{"a", "b"},
+		},
+		{
+			name: "query without aggregate id",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				AddQuery().AggregateIDs("a").
+				Or().AggregateTypes("user").Builder(),
+			want: nil,
+		},
+		{
+			name: "max position",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+				AddQuery().AggregateIDs("a").Builder(),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, archivableAggregateIDs(tt.query))
+		})
+	}
+}
+
+type testQuerier struct {
+	eventstore.Querier
+	events []eventstore.Event
+}
+
+func (q *testQuerier) FilterToReducer(_ context.Context, _ *eventstore.SearchQueryBuilder, reduce eventstore.Reducer) error {
+	for _, event := range q.events {
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestQuerier_FilterToReducer(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewFilesystemStorage(t.TempDir())
+	require.NoError(t, err)
+	name := objectName("instance", "user", "user1", 2)
+	data, err := encodeEvents([]*archivedEvent{
+		{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", EventType: "user.human.added", Sequence: 1, Position: 1},
+		{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", EventType: "user.human.changed", Sequence: 2, Position: 2},
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(ctx, name, data))
+
+	inner := &testQuerier{events: []eventstore.Event{
+		&repository.Event{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Typ: "user.removed", Seq: 3, Pos: 3},
+	}}
+
+	tests := []struct {
+		name    string
+		query   *eventstore.SearchQueryBuilder
+		mock    func(t *testing.T) *mock.SQLMock
+		wantSeq []uint64
+	}{
+		{
+			name: "archived and stored events",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(archivedObjectsQuery,
+						mock.WithQueryArgs("{user1}", "{instance}"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{{name}}),
+					),
+				)
+			},
+			wantSeq: []uint64{1, 2, 3},
+		},
+		{
+			name: "desc with limit",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				OrderDesc().
+				Limit(2).
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(archivedObjectsQuery,
+						mock.WithQueryArgs("{user1}", "{instance}"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{{name}}),
+					),
+				)
+			},
+			wantSeq: []uint64{3, 2},
+		},
+		{
+			name: "not archived",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(archivedObjectsQuery,
+						mock.WithQueryArgs("{user1}", "{instance}"),
+						mock.WithQueryResult([]string{"object_name"}, [][]driver.Value{}),
+					),
+				)
+			},
+			wantSeq: []uint64{3},
+		},
+		{
+			name: "without aggregate ids",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").Builder(),
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantSeq: []uint64{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.mock(t)
+			defer dbMock.Assert(t)
+			querier := NewQuerier(inner, &database.DB{DB: dbMock.DB}, storage)
+
+			var sequences []uint64
+			err := querier.FilterToReducer(ctx, tt.query, func(event eventstore.Event) error {
+				sequences = append(sequences, event.Sequence())
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSeq, sequences)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type S3Config struct {
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SSL             bool
+	Location        string
+	Bucket          string
+	// Prefix is prepended to the object names, e.g. "events/".
+	Prefix string
+}
+
+// s3Storage stores the archived events in an S3 compatible object storage.
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+func (c *S3Config) NewStorage() (Storage, error) {
+	client, err := minio.New(c.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(c.AccessKeyID, c.SecretAccessKey, ""),
+		Secure: c.SSL,
+		Region: c.Location,
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-m4rz8c2qva", "unable to initialize archive storage")
+	}
+	return &s3Storage{
+		client: client,
+		bucket: c.Bucket,
+		prefix: c.Prefix,
+	}, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, name string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+name, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/gzip",
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-g7k1yt9wsb", "unable to write archive")
+	}
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, name string) ([]byte, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.prefix+name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-z0u5hb3mxe", "unable to read archive")
+	}
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return nil, zerrors.ThrowNotFound(err, "ARCHI-c2n6qp8ofl", "archive not found")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-z0u5hb3mxe", "unable to read archive")
+	}
+	return data, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, name string) error {
+	// removing a missing object succeeds
+	if err := s.client.RemoveObject(ctx, s.bucket, s.prefix+name, minio.RemoveObjectOptions{}); err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-b8f3wn6jqa", "unable to delete archive")
+	}
+	return nil
+}
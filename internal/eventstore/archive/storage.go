@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Storage stores the compressed events of archived aggregates as objects.
+type Storage interface {
+	// Put stores the data under the name, existing objects are overwritten.
+	Put(ctx context.Context, name string, data []byte) error
+	// Get returns the data stored under the name.
+	// A not found error is returned if the object does not exist.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Delete removes the object stored under the name.
+	// Deleting an object which does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+}
+
+type FilesystemConfig struct {
+	// Path of the directory the archived events are stored in.
+	Path string
+}
+
+// filesystemStorage stores the archived events in a local directory.
+// It's suitable for single node deployments, shared volumes and tests.
+type filesystemStorage struct {
+	root string
+}
+
+func NewFilesystemStorage(path string) (Storage, error) {
+	if path == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ARCHI-v6c1kq3mzn", "archive path is empty")
+	}
+	if err := os.MkdirAll(path, 0o750); err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-d9g4xw7lbe", "unable to create archive directory")
+	}
+	return &filesystemStorage{root: path}, nil
+}
+
+func (s *filesystemStorage) Put(_ context.Context, name string, data []byte) error {
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-q3b8nm1ryc", "unable to create archive directory")
+	}
+	// write to a temporary file first, so readers never see partially written objects
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-j5s0fu4twa", "unable to write archive")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-j5s0fu4twa", "unable to write archive")
+	}
+	return nil
+}
+
+func (s *filesystemStorage) Get(_ context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, zerrors.ThrowNotFound(err, "ARCHI-x1p7ve5hgk", "archive not found")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-w8e2lr6nqd", "unable to read archive")
+	}
+	return data, nil
+}
+
+func (s *filesystemStorage) Delete(_ context.Context, name string) error {
+	err := os.Remove(s.path(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return zerrors.ThrowInternal(err, "ARCHI-r4m9zc1hxo", "unable to delete archive")
+	}
+	return nil
+}
+
+func (s *filesystemStorage) path(name string) string {
+	return filepath.Join(s.root, filepath.FromSlash(name))
+}
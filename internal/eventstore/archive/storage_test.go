@@ -0,0 +1,37 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestFilesystemStorage(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewFilesystemStorage(t.TempDir())
+	require.NoError(t, err)
+
+	name := objectName("instance", "user", "../user1", 3)
+	_, err = storage.Get(ctx, name)
+	assert.True(t, zerrors.IsNotFound(err))
+
+	require.NoError(t, storage.Put(ctx, name, []byte("first")))
+	require.NoError(t, storage.Put(ctx, name, []byte("second")))
+	got, err := storage.Get(ctx, name)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), got)
+
+	require.NoError(t, storage.Delete(ctx, name))
+	_, err = storage.Get(ctx, name)
+	assert.True(t, zerrors.IsNotFound(err))
+	require.NoError(t, storage.Delete(ctx, name))
+}
+
+func TestNewFilesystemStorage_emptyPath(t *testing.T) {
+	_, err := NewFilesystemStorage("")
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+}
@@ -8,7 +8,12 @@ type Config struct {
 	PushTimeout time.Duration
 	MaxRetries  uint32
 
-	Pusher   Pusher
-	Querier  Querier
-	Searcher Searcher
+	Snapshots SnapshotConfig
+	PII       PIIConfig
+
+	Pusher        Pusher
+	Querier       Querier
+	Searcher      Searcher
+	SnapshotStore SnapshotStore
+	PIIEncryption PIIEncryption
 }
@@ -23,6 +23,12 @@ type Eventstore struct {
 	pusher   Pusher
 	querier  Querier
 	searcher Searcher
+
+	snapshots         SnapshotStore
+	snapshotThreshold uint32
+
+	pii              PIIEncryption
+	encryptPIIFields bool
 }
 
 var (
@@ -56,7 +62,7 @@ type eventTypeInterceptors struct {
 }
 
 func NewEventstore(config *Config) *Eventstore {
-	return &Eventstore{
+	es := &Eventstore{
 		PushTimeout: config.PushTimeout,
 		maxRetries:  int(config.MaxRetries),
 
@@ -64,6 +70,15 @@ func NewEventstore(config *Config) *Eventstore {
 		querier:  config.Querier,
 		searcher: config.Searcher,
 	}
+	if config.Snapshots.Enabled && config.SnapshotStore != nil {
+		es.snapshots = config.SnapshotStore
+		es.snapshotThreshold = config.Snapshots.Threshold
+	}
+	if config.PIIEncryption != nil {
+		es.pii = config.PIIEncryption
+		es.encryptPIIFields = config.PII.Enabled
+	}
+	return es
 }
 
 // Health checks if the eventstore can properly work
@@ -89,10 +104,11 @@ func (es *Eventstore) PushWithClient(ctx context.Context, client database.Contex
 		ctx, cancel = context.WithTimeout(ctx, es.PushTimeout)
 		defer cancel()
 	}
-	var (
-		events []Event
-		err    error
-	)
+	cmds, err := es.encryptPII(ctx, cmds)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
 
 	// Retry when there is a collision of the sequence as part of the primary key.
 	// "duplicate key value violates unique constraint \"events2_pkey\" (SQLSTATE 23505)"
@@ -193,6 +209,10 @@ func (es *Eventstore) mapEvent(event Event) (Event, error) {
 }
 
 func (es *Eventstore) mapEventLocked(event Event) (Event, error) {
+	event, err := es.decryptPII(event)
+	if err != nil {
+		return nil, err
+	}
 	interceptors, ok := eventInterceptors[event.Type()]
 	if !ok || interceptors.eventMapper == nil {
 		return BaseEventFromRepo(event), nil
@@ -253,8 +273,13 @@ type QueryReducer interface {
 }
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
-// appends all events to the reducer and calls it's reduce function
+// appends all events to the reducer and calls it's reduce function.
+// If the reducer implements [SnapshotReducer] and snapshots are enabled,
+// only the events after the latest snapshot are reduced.
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if reducer, ok := r.(SnapshotReducer); ok && es.snapshots != nil {
+		return es.filterWithSnapshot(ctx, reducer)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
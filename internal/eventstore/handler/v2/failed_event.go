@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	_ "embed"
+	"math"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -47,16 +48,29 @@ func failureFromStatement(statement *Statement, err error) *failure {
 	}
 }
 
+// FailedEventBlocker is implemented by projections which must reduce the events in order without gaps.
+// If a statement of the projection fails, the handler does not skip the event after MaxFailureCount
+// but retries it until it succeeds, the following events are not reduced in the meantime.
+type FailedEventBlocker interface {
+	BlockOnFailedEvent() bool
+}
+
 func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool) {
 	failureCount, err := h.failureCount(tx, f)
 	if err != nil {
 		h.logFailure(f).WithError(err).Warn("unable to get failure count")
 		return false
 	}
-	failureCount += 1
+	// the count is stored as uint8, it stops at the max value if the event is retried forever
+	if failureCount < math.MaxUint8 {
+		failureCount += 1
+	}
 	err = h.setFailureCount(tx, failureCount, f)
 	h.logFailure(f).OnError(err).Warn("unable to update failure count")
 
+	if blocker, ok := h.projection.(FailedEventBlocker); ok && blocker.BlockOnFailedEvent() {
+		return false
+	}
 	return failureCount >= h.maxFailureCount
 }
 
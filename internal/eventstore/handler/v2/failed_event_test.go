@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestHandler_handleFailedStmt(t *testing.T) {
+	failedAt := time.Now()
+	f := &failure{
+		sequence:      3,
+		instance:      "instance",
+		aggregateID:   "aggregate id",
+		aggregateType: "aggregate type",
+		eventDate:     failedAt,
+		err:           errors.New("failed"),
+	}
+	expectFailure := func(count, newCount uint8) *mock.SQLMock {
+		return mock.NewSQLMock(t,
+			mock.ExpectBegin(nil),
+			mock.ExpectQuery(failureCountStmt,
+				mock.WithQueryArgs("projection", "instance", eventstore.AggregateType("aggregate type"), "aggregate id", uint64(3)),
+				mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{count}}),
+			),
+			mock.ExcpectExec(setFailedEventStmt,
+				mock.WithExecArgs("projection", "instance", eventstore.AggregateType("aggregate type"), "aggregate id", failedAt, uint64(3), newCount, "failed"),
+				mock.WithExecRowsAffected(1),
+			),
+		)
+	}
+	tests := []struct {
+		name               string
+		projection         Projection
+		mock               *mock.SQLMock
+		wantShouldContinue bool
+	}{
+		{
+			name:       "below max failure count",
+			projection: &projection{name: "projection"},
+			mock:       expectFailure(0, 1),
+		},
+		{
+			name:               "max failure count reached, skipped",
+			projection:         &projection{name: "projection"},
+			mock:               expectFailure(1, 2),
+			wantShouldContinue: true,
+		},
+		{
+			name:       "max failure count reached, blocked",
+			projection: &blockingProjection{projection: projection{name: "projection"}},
+			mock:       expectFailure(1, 2),
+		},
+		{
+			name:       "failure count at max value, blocked",
+			projection: &blockingProjection{projection: projection{name: "projection"}},
+			mock:       expectFailure(255, 255),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection:      tt.projection,
+				maxFailureCount: 2,
+			}
+			tx, err := tt.mock.DB.BeginTx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+			if got := h.handleFailedStmt(tx, f); got != tt.wantShouldContinue {
+				t.Errorf("handleFailedStmt() = %v, want %v", got, tt.wantShouldContinue)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
@@ -21,3 +21,23 @@ func (p *projection) Name() string {
 func (p *projection) Reducers() []AggregateReducer {
 	return p.reducers
 }
+
+// latestPositionProjection starts at the latest position of the instance
+type latestPositionProjection struct {
+	projection
+}
+
+// StartAtLatestPosition implements [LatestPositionStarter]
+func (p *latestPositionProjection) StartAtLatestPosition() bool {
+	return true
+}
+
+// blockingProjection never skips failed events
+type blockingProjection struct {
+	projection
+}
+
+// BlockOnFailedEvent implements [FailedEventBlocker]
+func (p *blockingProjection) BlockOnFailedEvent() bool {
+	return true
+}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	shadowSuffix = "_shadow"
+
+	// dependentObjectsStillExistCode is returned if a dropped table is referenced by other objects
+	dependentObjectsStillExistCode = "2BP01"
+)
+
+var (
+	//go:embed rebuild_tables.sql
+	shadowTablesStmt string
+	//go:embed rebuild_lock.sql
+	lockRebuildStatesStmt string
+)
+
+const (
+	shadowIndexesStmt     = "SELECT indexname FROM pg_indexes WHERE schemaname = $1 AND tablename = $2"
+	shadowForeignKeysStmt = "SELECT conname FROM pg_constraint WHERE contype = 'f' AND conrelid = $1::REGCLASS"
+
+	deleteStatesStmt       = "DELETE FROM projections.current_states WHERE projection_name = $1"
+	moveStatesStmt         = "UPDATE projections.current_states SET projection_name = $1 WHERE projection_name = $2"
+	deleteFailedEventsStmt = "DELETE FROM projections.failed_events2 WHERE projection_name = $1"
+	moveFailedEventsStmt   = "UPDATE projections.failed_events2 SET projection_name = $1 WHERE projection_name = $2"
+)
+
+// shadowProjection reduces the events of the wrapped projection into separate tables.
+// Table names, current states and failed events are derived from the projection name,
+// so the shadow is projected independently of the live projection.
+type shadowProjection struct {
+	Projection
+}
+
+var _ initializer = (*shadowProjection)(nil)
+
+// Name implements [Projection]
+func (p *shadowProjection) Name() string {
+	return p.Projection.Name() + shadowSuffix
+}
+
+// Init implements [initializer]
+func (p *shadowProjection) Init() *handler.Check {
+	if check, ok := p.Projection.(initializer); ok {
+		return check.Init()
+	}
+	return new(handler.Check)
+}
+
+type projectionTable struct {
+	name   string
+	isView bool
+}
+
+// Rebuild projects all events into shadow tables while the live tables keep serving reads.
+// As soon as the shadow caught up for all instances, its tables replace the live tables
+// in a single transaction and the live handler continues from the position of the shadow.
+//
+// Projections created by [NewViewCheck] are not supported because the view references the live tables.
+func (h *Handler) Rebuild(ctx context.Context) (err error) {
+	shadow := h.shadow()
+	// remove leftovers of a previously cancelled rebuild
+	if err = shadow.dropShadow(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		dropErr := shadow.dropShadow(ctx)
+		shadow.log().OnError(dropErr).Warn("unable to drop shadow tables")
+	}()
+
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+	tables, err := projectionTables(ctx, h.client.DB, shadow.ProjectionName())
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table.isView {
+			return zerrors.ThrowPreconditionFailed(nil, "V2-Rb7kq", "projections based on views cannot be rebuilt")
+		}
+	}
+
+	instances, err := h.existingInstances(ctx)
+	if err != nil {
+		return err
+	}
+	for i, instance := range instances {
+		shadow.log().WithField("instance", instance).WithField("progress", fmt.Sprintf("%d/%d", i+1, len(instances))).Info("rebuild instance")
+		if _, err = shadow.Trigger(authz.WithInstanceID(ctx, instance), WithAwaitRunning()); err != nil {
+			return err
+		}
+	}
+
+	return h.swapShadow(ctx, shadow)
+}
+
+// shadow returns a handler with the same configuration which projects into the shadow tables.
+// Caches are not invalidated because the shadow tables are not queried.
+func (h *Handler) shadow() *Handler {
+	return &Handler{
+		projection:           &shadowProjection{Projection: h.projection},
+		client:               h.client,
+		es:                   h.es,
+		bulkLimit:            h.bulkLimit,
+		eventTypes:           h.eventTypes,
+		maxFailureCount:      h.maxFailureCount,
+		retryFailedAfter:     h.retryFailedAfter,
+		requeueEvery:         h.requeueEvery,
+		txDuration:           h.txDuration,
+		now:                  h.now,
+		triggerWithoutEvents: h.triggerWithoutEvents,
+		queryInstances:       h.queryInstances,
+	}
+}
+
+// dropShadow removes the tables, current states and failed events of the shadow projection.
+func (h *Handler) dropShadow(ctx context.Context) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Dq3mf", "begin failed")
+	}
+	defer func() {
+		err = database.CloseTransaction(tx, err)
+	}()
+
+	tables, err := projectionTables(ctx, tx, h.ProjectionName())
+	if err != nil {
+		return err
+	}
+	schema, _ := splitProjectionName(h.ProjectionName())
+	for _, table := range tables {
+		kind := "TABLE"
+		if table.isView {
+			kind = "VIEW"
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("DROP %s IF EXISTS %s.%s CASCADE", kind, schema, table.name)); err != nil {
+			return zerrors.ThrowInternal(err, "V2-W2xbe", "unable to drop shadow table")
+		}
+	}
+	if _, err = tx.ExecContext(ctx, deleteStatesStmt, h.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-g9Lfe", "unable to delete shadow states")
+	}
+	if _, err = tx.ExecContext(ctx, deleteFailedEventsStmt, h.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-P0oxt", "unable to delete shadow failed events")
+	}
+	return nil
+}
+
+// swapShadow replaces the live tables of the projection with the tables of the shadow.
+// The current states of the live projection are locked during the swap,
+// so the live handler cannot reduce events into the tables being replaced.
+func (h *Handler) swapShadow(ctx context.Context, shadow *Handler) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-u8Gxn", "begin failed")
+	}
+	defer func() {
+		err = database.CloseTransaction(tx, err)
+	}()
+
+	if _, err = tx.ExecContext(ctx, lockRebuildStatesStmt, h.ProjectionName(), shadow.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Kd0wa", "unable to lock current states")
+	}
+	tables, err := projectionTables(ctx, tx, shadow.ProjectionName())
+	if err != nil {
+		return err
+	}
+
+	schema, liveName := splitProjectionName(h.ProjectionName())
+	_, shadowName := splitProjectionName(shadow.ProjectionName())
+	liveTable := func(name string) string {
+		return liveName + strings.TrimPrefix(name, shadowName)
+	}
+
+	// the tables are dropped in one statement, so the foreign keys between the tables of the projection do not prevent the drop.
+	// Other objects depending on the tables (e.g. views) are not dropped, the swap fails instead.
+	liveTables := make([]string, len(tables))
+	for i, table := range tables {
+		liveTables[i] = schema + "." + liveTable(table.name)
+	}
+	if _, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+strings.Join(liveTables, ", ")); err != nil {
+		pgErr := new(pgconn.PgError)
+		if errors.As(err, &pgErr) && pgErr.Code == dependentObjectsStillExistCode {
+			return zerrors.ThrowPreconditionFailed(err, "V2-Wq3Lr", "other objects depend on the live tables, remove them before the rebuild")
+		}
+		return zerrors.ThrowInternal(err, "V2-o1Ubr", "unable to drop live tables")
+	}
+	for _, table := range tables {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s.%s RENAME TO %s", schema, table.name, liveTable(table.name))); err != nil {
+			return zerrors.ThrowInternal(err, "V2-aZ4ch", "unable to rename shadow table")
+		}
+		if err = renameShadowRelations(ctx, tx, schema, liveTable(table.name), shadowName, liveName); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteStatesStmt, h.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Yw2zm", "unable to delete current states")
+	}
+	if _, err = tx.ExecContext(ctx, moveStatesStmt, h.ProjectionName(), shadow.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-R8pdv", "unable to move current states")
+	}
+	if _, err = tx.ExecContext(ctx, deleteFailedEventsStmt, h.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-cL5wi", "unable to delete failed events")
+	}
+	if _, err = tx.ExecContext(ctx, moveFailedEventsStmt, h.ProjectionName(), shadow.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Ej9tq", "unable to move failed events")
+	}
+	return nil
+}
+
+// renameShadowRelations renames the indexes and foreign keys of a renamed shadow table,
+// so they match the names created by the checks of the live projection.
+// Unique constraints and primary keys are renamed together with their indexes.
+func renameShadowRelations(ctx context.Context, tx *sql.Tx, schema, table, shadowName, liveName string) error {
+	indexes, err := queryNames(ctx, tx, shadowIndexesStmt, schema, table)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Xe0ib", "unable to query indexes")
+	}
+	for _, index := range indexes {
+		if !strings.Contains(index, shadowName) {
+			continue
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("ALTER INDEX %s.%s RENAME TO %s", schema, index, strings.Replace(index, shadowName, liveName, 1))); err != nil {
+			return zerrors.ThrowInternal(err, "V2-nM6vs", "unable to rename index")
+		}
+	}
+
+	foreignKeys, err := queryNames(ctx, tx, shadowForeignKeysStmt, schema+"."+table)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Ho4ke", "unable to query foreign keys")
+	}
+	for _, foreignKey := range foreignKeys {
+		if !strings.Contains(foreignKey, shadowName) {
+			continue
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s.%s RENAME CONSTRAINT %s TO %s", schema, table, foreignKey, strings.Replace(foreignKey, shadowName, liveName, 1))); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Ug1ap", "unable to rename foreign key")
+		}
+	}
+	return nil
+}
+
+// projectionTables returns the primary and suffixed tables of the projection
+func projectionTables(ctx context.Context, querier database.ContextQuerier, projectionName string) ([]*projectionTable, error) {
+	schema, name := splitProjectionName(projectionName)
+	rows, err := querier.QueryContext(ctx, shadowTablesStmt, schema, name, database.EscapeLikeWildcards(name+"_")+"%")
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Xo8ad", "unable to query tables")
+	}
+	defer rows.Close()
+
+	var tables []*projectionTable
+	for rows.Next() {
+		table := new(projectionTable)
+		if err = rows.Scan(&table.name, &table.isView); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Mf3ka", "unable to scan table")
+		}
+		tables = append(tables, table)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Vu6jd", "unable to query tables")
+	}
+	return tables, nil
+}
+
+func queryNames(ctx context.Context, tx *sql.Tx, stmt string, args ...any) (names []string, err error) {
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func splitProjectionName(projectionName string) (schema, name string) {
+	schema, name, ok := strings.Cut(projectionName, ".")
+	if !ok {
+		return "public", projectionName
+	}
+	return schema, name
+}
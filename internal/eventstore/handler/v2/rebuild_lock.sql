@@ -0,0 +1,7 @@
+SELECT
+    projection_name
+FROM
+    projections.current_states
+WHERE
+    projection_name IN ($1, $2)
+FOR UPDATE;
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestShadowProjection_Name(t *testing.T) {
+	p := &shadowProjection{Projection: &projection{name: "projections.users"}}
+	if got := p.Name(); got != "projections.users_shadow" {
+		t.Errorf("unexpected name: want projections.users_shadow, got %s", got)
+	}
+	if check := p.Init(); !check.IsNoop() {
+		t.Error("expected noop check for projection without initializer")
+	}
+}
+
+func TestHandler_swapShadow(t *testing.T) {
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		isErr func(t *testing.T, err error)
+	}{
+		{
+			name: "lock fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(lockRebuildStatesStmt,
+					mock.WithExecArgs("projections.users", "projections.users_shadow"),
+					mock.WithExecErr(sql.ErrConnDone),
+				),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, zerrors.ThrowInternal(nil, "V2-Kd0wa", "")) {
+					t.Errorf("unexpected error, want: internal (V2-Kd0wa), got: %v", err)
+				}
+			},
+		},
+		{
+			name: "dependent objects",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(lockRebuildStatesStmt,
+					mock.WithExecArgs("projections.users", "projections.users_shadow"),
+					mock.WithExecRowsAffected(2),
+				),
+				mock.ExpectQuery(shadowTablesStmt,
+					mock.WithQueryArgs("projections", "users_shadow", `users\_shadow\_%`),
+					mock.WithQueryResult(
+						[]string{"table_name", "is_view"},
+						[][]driver.Value{
+							{"users_shadow", false},
+						},
+					),
+				),
+				mock.ExcpectExec("DROP TABLE IF EXISTS projections.users",
+					mock.WithExecErr(&pgconn.PgError{Code: "2BP01"}),
+				),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "V2-Wq3Lr", "")) {
+					t.Errorf("unexpected error, want: precondition failed (V2-Wq3Lr), got: %v", err)
+				}
+			},
+		},
+		{
+			name: "swap tables",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExcpectExec(lockRebuildStatesStmt,
+					mock.WithExecArgs("projections.users", "projections.users_shadow"),
+					mock.WithExecRowsAffected(2),
+				),
+				mock.ExpectQuery(shadowTablesStmt,
+					mock.WithQueryArgs("projections", "users_shadow", `users\_shadow\_%`),
+					mock.WithQueryResult(
+						[]string{"table_name", "is_view"},
+						[][]driver.Value{
+							{"users_shadow", false},
+							{"users_shadow_humans", false},
+						},
+					),
+				),
+				mock.ExcpectExec("DROP TABLE IF EXISTS projections.users, projections.users_humans", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER TABLE projections.users_shadow RENAME TO users", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(shadowIndexesStmt,
+					mock.WithQueryArgs("projections", "users"),
+					mock.WithQueryResult(
+						[]string{"indexname"},
+						[][]driver.Value{
+							{"users_shadow_pkey"},
+							{"users_shadow_username_idx"},
+						},
+					),
+				),
+				mock.ExcpectExec("ALTER INDEX projections.users_shadow_pkey RENAME TO users_pkey", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER INDEX projections.users_shadow_username_idx RENAME TO users_username_idx", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(shadowForeignKeysStmt,
+					mock.WithQueryArgs("projections.users"),
+					mock.WithQueryResult([]string{"conname"}, nil),
+				),
+				mock.ExcpectExec("ALTER TABLE projections.users_shadow_humans RENAME TO users_humans", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(shadowIndexesStmt,
+					mock.WithQueryArgs("projections", "users_humans"),
+					mock.WithQueryResult(
+						[]string{"indexname"},
+						[][]driver.Value{
+							{"users_shadow_humans_pkey"},
+						},
+					),
+				),
+				mock.ExcpectExec("ALTER INDEX projections.users_shadow_humans_pkey RENAME TO users_humans_pkey", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(shadowForeignKeysStmt,
+					mock.WithQueryArgs("projections.users_humans"),
+					mock.WithQueryResult(
+						[]string{"conname"},
+						[][]driver.Value{
+							{"fk_humans_ref_users_shadow"},
+						},
+					),
+				),
+				mock.ExcpectExec("ALTER TABLE projections.users_humans RENAME CONSTRAINT fk_humans_ref_users_shadow TO fk_humans_ref_users", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec(deleteStatesStmt,
+					mock.WithExecArgs("projections.users"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(moveStatesStmt,
+					mock.WithExecArgs("projections.users", "projections.users_shadow"),
+					mock.WithExecRowsAffected(1),
+				),
+				mock.ExcpectExec(deleteFailedEventsStmt,
+					mock.WithExecArgs("projections.users"),
+					mock.WithExecNoRowsAffected(),
+				),
+				mock.ExcpectExec(moveFailedEventsStmt,
+					mock.WithExecArgs("projections.users", "projections.users_shadow"),
+					mock.WithExecNoRowsAffected(),
+				),
+				mock.ExpectCommit(nil),
+			),
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{name: "projections.users"},
+				client:     &database.DB{DB: tt.mock.DB},
+			}
+
+			err := h.swapShadow(context.Background(), h.shadow())
+			tt.isErr(t, err)
+
+			tt.mock.Assert(t)
+		})
+	}
+}
@@ -31,10 +31,19 @@ var (
 	updateStateStmt string
 	//go:embed state_lock.sql
 	lockStateStmt string
+	//go:embed state_latest_position.sql
+	latestPositionStmt string
 
 	errJustUpdated = errors.New("projection was just updated")
 )
 
+// LatestPositionStarter is implemented by projections which only reduce the events created after they were started.
+// If the projection has no state for an instance yet, it starts at the latest position of the instance
+// instead of reducing all previous events of the instance.
+type LatestPositionStarter interface {
+	StartAtLatestPosition() bool
+}
+
 func (h *Handler) currentState(ctx context.Context, tx *sql.Tx, config *triggerConfig) (currentState *state, err error) {
 	currentState = &state{
 		instanceID: authz.GetInstance(ctx).InstanceID(),
@@ -63,13 +72,20 @@ func (h *Handler) currentState(ctx context.Context, tx *sql.Tx, config *triggerC
 		position,
 		offset,
 	)
-	if errors.Is(err, sql.ErrNoRows) {
+	isNew := errors.Is(err, sql.ErrNoRows)
+	if isNew {
 		err = h.lockState(tx, currentState.instanceID)
 	}
 	if err != nil {
 		h.log().WithError(err).Debug("unable to query current state")
 		return nil, err
 	}
+	if starter, ok := h.projection.(LatestPositionStarter); isNew && ok && starter.StartAtLatestPosition() {
+		if err = tx.QueryRow(latestPositionStmt, currentState.instanceID).Scan(position); err != nil {
+			h.log().WithError(err).Debug("unable to query latest position")
+			return nil, err
+		}
+	}
 
 	currentState.aggregateID = aggregateID.String
 	currentState.aggregateType = eventstore.AggregateType(aggregateType.String)
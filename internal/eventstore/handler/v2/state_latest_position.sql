@@ -0,0 +1,6 @@
+SELECT
+    COALESCE(MAX("position"), 0)
+FROM
+    eventstore.events2
+WHERE
+    instance_id = $1;
\ No newline at end of file
@@ -343,6 +343,55 @@ func TestHandler_currentState(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no row, start at latest position",
+			fields: fields{
+				projection: &latestPositionProjection{
+					projection: projection{
+						name: "projection",
+					},
+				},
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(currentStateStmt,
+						mock.WithQueryArgs(
+							"instance",
+							"projection",
+						),
+						mock.WithQueryErr(sql.ErrNoRows),
+					),
+					mock.ExcpectExec(lockStateStmt,
+						mock.WithExecArgs(
+							"projection",
+							"instance",
+						),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExpectQuery(latestPositionStmt,
+						mock.WithQueryArgs(
+							"instance",
+						),
+						mock.WithQueryResult(
+							[]string{"max"},
+							[][]driver.Value{
+								{
+									float64(42),
+								},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance"),
+			},
+			want: want{
+				currentState: &state{
+					instanceID: "instance",
+					position:   42,
+				},
+			},
+		},
 		{
 			name: "state locked",
 			fields: fields{
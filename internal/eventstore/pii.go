@@ -0,0 +1,119 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PIIConfig configures the encryption of personal data in event payloads
+type PIIConfig struct {
+	// Enabled encrypts the registered fields of new events.
+	// Encrypted fields of stored events are decrypted regardless of this setting.
+	Enabled bool
+	// KeyCacheDuration defines how long the data keys are cached in memory.
+	KeyCacheDuration time.Duration
+	// KeyCacheSize defines the maximum amount of data keys cached in memory.
+	KeyCacheSize int
+}
+
+// PIIEncryption encrypts and decrypts the personal data fields of event payloads.
+// The fields are encrypted with a key per aggregate,
+// destroying the key makes the fields of all events of the aggregate unreadable.
+type PIIEncryption interface {
+	EncryptPayload(ctx context.Context, aggregate *Aggregate, fields []string, payload []byte) ([]byte, error)
+	DecryptPayload(aggregate *Aggregate, fields []string, payload []byte) ([]byte, error)
+	DestroyKey(ctx context.Context, instanceID, aggregateID string) error
+}
+
+var piiFields = map[EventType][]string{}
+
+// RegisterPIIFields registers the json fields of the payload of the event type containing personal data
+func RegisterPIIFields(eventType EventType, fields ...string) {
+	piiFields[eventType] = append(piiFields[eventType], fields...)
+}
+
+// PIIEncryptionEnabled returns true if personal data of new events is encrypted
+func (es *Eventstore) PIIEncryptionEnabled() bool {
+	return es.pii != nil && es.encryptPIIFields
+}
+
+// DestroyPII destroys the key of the aggregate,
+// the personal data of its events cannot be decrypted afterwards
+func (es *Eventstore) DestroyPII(ctx context.Context, instanceID, aggregateID string) error {
+	if es.pii == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "V2-Nc4ke", "Errors.User.PIIEncryptionDisabled")
+	}
+	return es.pii.DestroyKey(ctx, instanceID, aggregateID)
+}
+
+// encryptPII replaces the payload of commands containing personal data with the encrypted payload
+func (es *Eventstore) encryptPII(ctx context.Context, cmds []Command) ([]Command, error) {
+	if es.pii == nil || !es.encryptPIIFields {
+		return cmds, nil
+	}
+	encrypted := make([]Command, len(cmds))
+	for i, cmd := range cmds {
+		fields, ok := piiFields[cmd.Type()]
+		if !ok || cmd.Payload() == nil {
+			encrypted[i] = cmd
+			continue
+		}
+		payload, err := json.Marshal(cmd.Payload())
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-Pq8vm", "Errors.Internal")
+		}
+		payload, err = es.pii.EncryptPayload(ctx, cmd.Aggregate(), fields, payload)
+		if err != nil {
+			return nil, err
+		}
+		encrypted[i] = &piiCommand{Command: cmd, payload: payload}
+	}
+	return encrypted, nil
+}
+
+// decryptPII returns the event with the decrypted payload if the event contains personal data
+func (es *Eventstore) decryptPII(event Event) (Event, error) {
+	if es.pii == nil {
+		return event, nil
+	}
+	fields, ok := piiFields[event.Type()]
+	if !ok || len(event.DataAsBytes()) == 0 {
+		return event, nil
+	}
+	payload, err := es.pii.DecryptPayload(event.Aggregate(), fields, event.DataAsBytes())
+	if err != nil {
+		return nil, err
+	}
+	return &piiEvent{Event: event, payload: payload}, nil
+}
+
+type piiCommand struct {
+	Command
+	payload json.RawMessage
+}
+
+// Payload implements [Command]
+func (c *piiCommand) Payload() any {
+	return c.payload
+}
+
+type piiEvent struct {
+	Event
+	payload []byte
+}
+
+// DataAsBytes implements [Event]
+func (e *piiEvent) DataAsBytes() []byte {
+	return e.payload
+}
+
+// Unmarshal implements [Event]
+func (e *piiEvent) Unmarshal(ptr any) error {
+	if len(e.payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.payload, ptr)
+}
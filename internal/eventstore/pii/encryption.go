@@ -0,0 +1,216 @@
+package pii
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const keyPrefix = "pii_"
+
+// KeyID returns the id of the data key of the aggregate in the key storage
+func KeyID(instanceID, aggregateID string) string {
+	return keyPrefix + instanceID + "_" + aggregateID
+}
+
+// encryptedValue replaces the value of an encrypted field in the payload
+type encryptedValue struct {
+	Value []byte `json:"pii"`
+}
+
+// Encryption encrypts the personal data fields of event payloads with a data key per aggregate.
+// The data keys are stored in the [crypto.KeyStorage].
+// After the key of an aggregate was destroyed, the encrypted fields are returned as null.
+type Encryption struct {
+	keyStorage crypto.KeyStorage
+	// keys caches the values of the data keys by id, nil if caching is disabled
+	keys *expirable.LRU[string, string]
+}
+
+var _ eventstore.PIIEncryption = (*Encryption)(nil)
+
+// NewEncryption returns the encryption using the keys of the key storage.
+// The keys are cached in memory for the cacheDuration, at most cacheSize keys are cached.
+// The least recently used keys are evicted first.
+// Caching is disabled if the duration or the size are not positive.
+func NewEncryption(keyStorage crypto.KeyStorage, cacheDuration time.Duration, cacheSize int) *Encryption {
+	e := &Encryption{
+		keyStorage: keyStorage,
+	}
+	if cacheDuration > 0 && cacheSize > 0 {
+		e.keys = expirable.NewLRU[string, string](cacheSize, nil, cacheDuration)
+	}
+	return e
+}
+
+// EncryptPayload implements [eventstore.PIIEncryption]
+// The data key of the aggregate is created if it does not exist yet.
+func (e *Encryption) EncryptPayload(ctx context.Context, aggregate *eventstore.Aggregate, fields []string, payload []byte) ([]byte, error) {
+	values, err := unmarshalFields(payload)
+	if err != nil || values == nil {
+		return payload, err
+	}
+	var key string
+	for _, field := range fields {
+		value, ok := values[field]
+		if !ok || isNull(value) || isEncrypted(value) {
+			continue
+		}
+		if key == "" {
+			if key, err = e.ensureKey(ctx, KeyID(aggregate.InstanceID, aggregate.ID)); err != nil {
+				return nil, err
+			}
+		}
+		encrypted, err := crypto.EncryptAES(value, key)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "PII-Ew3ja", "Errors.Internal")
+		}
+		if values[field], err = json.Marshal(&encryptedValue{Value: encrypted}); err != nil {
+			return nil, zerrors.ThrowInternal(err, "PII-Ut5cm", "Errors.Internal")
+		}
+	}
+	return marshalFields(values)
+}
+
+// DecryptPayload implements [eventstore.PIIEncryption]
+// Fields which were stored before the encryption was enabled are returned unchanged.
+func (e *Encryption) DecryptPayload(aggregate *eventstore.Aggregate, fields []string, payload []byte) ([]byte, error) {
+	values, err := unmarshalFields(payload)
+	if err != nil || values == nil {
+		return payload, err
+	}
+	var (
+		key               string
+		keyRead, keyFound bool
+	)
+	for _, field := range fields {
+		value, ok := values[field]
+		if !ok || !isEncrypted(value) {
+			continue
+		}
+		if !keyRead {
+			key, keyFound, err = e.key(KeyID(aggregate.InstanceID, aggregate.ID))
+			if err != nil {
+				return nil, err
+			}
+			keyRead = true
+		}
+		// the key was destroyed, the personal data is not readable anymore
+		if !keyFound {
+			values[field] = json.RawMessage("null")
+			continue
+		}
+		encrypted := new(encryptedValue)
+		if err = json.Unmarshal(value, encrypted); err != nil {
+			return nil, zerrors.ThrowInternal(err, "PII-Lb9sq", "Errors.Internal")
+		}
+		if values[field], err = crypto.DecryptAES(encrypted.Value, key); err != nil {
+			return nil, zerrors.ThrowInternal(err, "PII-Ox2rd", "Errors.Internal")
+		}
+	}
+	return marshalFields(values)
+}
+
+// DestroyKey deletes the data key of the aggregate.
+// The encrypted fields of all events of the aggregate cannot be decrypted afterwards.
+// Other processes might use their cached key until the cache expires.
+func (e *Encryption) DestroyKey(ctx context.Context, instanceID, aggregateID string) error {
+	id := KeyID(instanceID, aggregateID)
+	if err := e.keyStorage.DeleteKeys(ctx, id); err != nil {
+		return err
+	}
+	if e.keys != nil {
+		e.keys.Remove(id)
+	}
+	return nil
+}
+
+func (e *Encryption) ensureKey(ctx context.Context, id string) (string, error) {
+	value, found, err := e.key(id)
+	if err != nil || found {
+		return value, err
+	}
+	key, err := crypto.NewKey(id)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "PII-Kq0va", "Errors.Internal")
+	}
+	if err = e.keyStorage.CreateKeys(ctx, key); err != nil {
+		// the key might have been created concurrently
+		logging.WithError(err).Debug("unable to create pii key")
+		value, found, err = e.key(id)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			return "", zerrors.ThrowInternal(nil, "PII-Ja7zn", "Errors.Internal")
+		}
+		return value, nil
+	}
+	e.cache(id, key.Value)
+	return key.Value, nil
+}
+
+// key returns the key with the given id, found is false if the key does not exist
+func (e *Encryption) key(id string) (value string, found bool, err error) {
+	if e.keys != nil {
+		if value, ok := e.keys.Get(id); ok {
+			return value, true, nil
+		}
+	}
+
+	key, err := e.keyStorage.ReadKey(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	e.cache(id, key.Value)
+	return key.Value, true, nil
+}
+
+func (e *Encryption) cache(id, value string) {
+	if e.keys != nil {
+		e.keys.Add(id, value)
+	}
+}
+
+func unmarshalFields(payload []byte) (map[string]json.RawMessage, error) {
+	if len(payload) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &values); err != nil {
+		return nil, zerrors.ThrowInternal(err, "PII-Ys1de", "Errors.Internal")
+	}
+	return values, nil
+}
+
+func marshalFields(values map[string]json.RawMessage) ([]byte, error) {
+	payload, err := json.Marshal(values)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PII-Cz6mv", "Errors.Internal")
+	}
+	return payload, nil
+}
+
+func isNull(value json.RawMessage) bool {
+	return string(value) == "null"
+}
+
+func isEncrypted(value json.RawMessage) bool {
+	if len(value) == 0 || value[0] != '{' {
+		return false
+	}
+	encrypted := new(encryptedValue)
+	return json.Unmarshal(value, encrypted) == nil && len(encrypted.Value) > 0
+}
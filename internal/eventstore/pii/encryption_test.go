@@ -0,0 +1,121 @@
+package pii
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type memoryKeyStorage map[string]string
+
+func (s memoryKeyStorage) ReadKeys() (crypto.Keys, error) {
+	return crypto.Keys(s), nil
+}
+
+func (s memoryKeyStorage) ReadKey(id string) (*crypto.Key, error) {
+	value, ok := s[id]
+	if !ok {
+		return nil, zerrors.ThrowInternal(sql.ErrNoRows, "", "unable to read key")
+	}
+	return &crypto.Key{ID: id, Value: value}, nil
+}
+
+func (s memoryKeyStorage) CreateKeys(_ context.Context, keys ...*crypto.Key) error {
+	for _, key := range keys {
+		s[key.ID] = key.Value
+	}
+	return nil
+}
+
+func (s memoryKeyStorage) DeleteKeys(_ context.Context, ids ...string) error {
+	for _, id := range ids {
+		delete(s, id)
+	}
+	return nil
+}
+
+type emailPayload struct {
+	Email    string `json:"email,omitempty"`
+	Verified bool   `json:"verified,omitempty"`
+}
+
+func TestEncryption(t *testing.T) {
+	ctx := context.Background()
+	aggregate := &eventstore.Aggregate{ID: "user1", InstanceID: "instance1"}
+	fields := []string{"email"}
+
+	storage := make(memoryKeyStorage)
+	encryption := NewEncryption(storage, time.Minute, 10)
+
+	plain, err := json.Marshal(&emailPayload{Email: "user@example.com", Verified: true})
+	require.NoError(t, err)
+
+	encrypted, err := encryption.EncryptPayload(ctx, aggregate, fields, plain)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "user@example.com")
+	assert.Contains(t, storage, KeyID("instance1", "user1"))
+
+	t.Run("decrypt", func(t *testing.T) {
+		decrypted, err := encryption.DecryptPayload(aggregate, fields, encrypted)
+		require.NoError(t, err)
+		payload := new(emailPayload)
+		require.NoError(t, json.Unmarshal(decrypted, payload))
+		assert.Equal(t, &emailPayload{Email: "user@example.com", Verified: true}, payload)
+	})
+
+	t.Run("plain payload unchanged", func(t *testing.T) {
+		decrypted, err := encryption.DecryptPayload(aggregate, fields, plain)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(plain), string(decrypted))
+	})
+
+	t.Run("destroyed key", func(t *testing.T) {
+		require.NoError(t, encryption.DestroyKey(ctx, "instance1", "user1"))
+
+		decrypted, err := encryption.DecryptPayload(aggregate, fields, encrypted)
+		require.NoError(t, err)
+		payload := new(emailPayload)
+		require.NoError(t, json.Unmarshal(decrypted, payload))
+		assert.Equal(t, &emailPayload{Verified: true}, payload)
+	})
+}
+
+func TestEncryption_keyCacheSize(t *testing.T) {
+	ctx := context.Background()
+	user1 := &eventstore.Aggregate{ID: "user1", InstanceID: "instance1"}
+	user2 := &eventstore.Aggregate{ID: "user2", InstanceID: "instance1"}
+	fields := []string{"email"}
+
+	storage := make(memoryKeyStorage)
+	encryption := NewEncryption(storage, time.Minute, 1)
+
+	plain, err := json.Marshal(&emailPayload{Email: "user@example.com", Verified: true})
+	require.NoError(t, err)
+	encrypted1, err := encryption.EncryptPayload(ctx, user1, fields, plain)
+	require.NoError(t, err)
+	encrypted2, err := encryption.EncryptPayload(ctx, user2, fields, plain)
+	require.NoError(t, err)
+
+	// the key of user1 was evicted by the key of user2,
+	// so deleting it from the storage by another process makes the payload unreadable
+	delete(storage, KeyID("instance1", "user1"))
+	delete(storage, KeyID("instance1", "user2"))
+
+	decrypted, err := encryption.DecryptPayload(user1, fields, encrypted1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"verified":true,"email":null}`, string(decrypted))
+
+	// the key of user2 is still cached
+	decrypted, err = encryption.DecryptPayload(user2, fields, encrypted2)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(plain), string(decrypted))
+}
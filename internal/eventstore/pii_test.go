@@ -0,0 +1,92 @@
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// testPIIEncryption "encrypts" the payload by converting it to upper case
+type testPIIEncryption struct {
+	destroyed []string
+}
+
+func (e *testPIIEncryption) EncryptPayload(_ context.Context, _ *Aggregate, _ []string, payload []byte) ([]byte, error) {
+	return bytes.ToUpper(payload), nil
+}
+
+func (e *testPIIEncryption) DecryptPayload(_ *Aggregate, _ []string, payload []byte) ([]byte, error) {
+	return bytes.ToLower(payload), nil
+}
+
+func (e *testPIIEncryption) DestroyKey(_ context.Context, instanceID, aggregateID string) error {
+	e.destroyed = append(e.destroyed, instanceID+"/"+aggregateID)
+	return nil
+}
+
+func TestEventstore_PII(t *testing.T) {
+	RegisterPIIFields("test.pii.event", "name")
+	t.Cleanup(func() { delete(piiFields, "test.pii.event") })
+
+	aggregate := &Aggregate{ID: "id", Type: "test.aggregate", InstanceID: "instance"}
+	payload := func() interface{} { return map[string]string{"name": "hodor"} }
+	piiCommand := newTestEvent("id", "pii", payload, false)
+	piiCommand.EventType = "test.pii.event"
+	otherCommand := newTestEvent("id", "other", payload, false)
+
+	t.Run("encryption disabled", func(t *testing.T) {
+		es := NewEventstore(&Config{})
+		assert.False(t, es.PIIEncryptionEnabled())
+
+		cmds, err := es.encryptPII(context.Background(), []Command{piiCommand})
+		require.NoError(t, err)
+		assert.Same(t, piiCommand, cmds[0])
+
+		stored := &BaseEvent{Agg: aggregate, EventType: "test.pii.event", Data: []byte(`{"name":"hodor"}`)}
+		event, err := es.decryptPII(stored)
+		require.NoError(t, err)
+		assert.Same(t, stored, event)
+
+		err = es.DestroyPII(context.Background(), "instance", "id")
+		assert.ErrorIs(t, err, zerrors.ThrowPreconditionFailed(nil, "V2-Nc4ke", "Errors.User.PIIEncryptionDisabled"))
+	})
+
+	t.Run("decryption only", func(t *testing.T) {
+		es := NewEventstore(&Config{PIIEncryption: new(testPIIEncryption)})
+		assert.False(t, es.PIIEncryptionEnabled())
+
+		cmds, err := es.encryptPII(context.Background(), []Command{piiCommand})
+		require.NoError(t, err)
+		assert.Same(t, piiCommand, cmds[0])
+
+		event, err := es.decryptPII(&BaseEvent{Agg: aggregate, EventType: "test.pii.event", Data: []byte(`{"NAME":"HODOR"}`)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"hodor"}`, string(event.DataAsBytes()))
+	})
+
+	t.Run("encryption enabled", func(t *testing.T) {
+		encryption := new(testPIIEncryption)
+		es := NewEventstore(&Config{PII: PIIConfig{Enabled: true}, PIIEncryption: encryption})
+		assert.True(t, es.PIIEncryptionEnabled())
+
+		cmds, err := es.encryptPII(context.Background(), []Command{piiCommand, otherCommand})
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`{"NAME":"HODOR"}`), cmds[0].Payload())
+		assert.Same(t, otherCommand, cmds[1])
+
+		event, err := es.decryptPII(&BaseEvent{Agg: aggregate, EventType: "test.pii.event", Data: []byte(`{"NAME":"HODOR"}`)})
+		require.NoError(t, err)
+		decrypted := make(map[string]string)
+		require.NoError(t, event.Unmarshal(&decrypted))
+		assert.Equal(t, map[string]string{"name": "hodor"}, decrypted)
+
+		require.NoError(t, es.DestroyPII(context.Background(), "instance", "id"))
+		assert.Equal(t, []string{"instance/id"}, encryption.destroyed)
+	})
+}
@@ -57,6 +57,8 @@ const (
 	OperationJSONContains
 	//OperationNotIn checks if a stored value does not match one of the passed value list
 	OperationNotIn
+	// OperationGreaterOrEquals compares if the given values is greater than or equal to the stored one
+	OperationGreaterOrEquals
 
 	operationCount
 )
@@ -252,6 +254,10 @@ func instanceIDsFilter(builder *eventstore.SearchQueryBuilder, query *SearchQuer
 }
 
 func positionAfterFilter(builder *eventstore.SearchQueryBuilder, query *SearchQuery) *Filter {
+	if builder.GetPositionAtLeast() > 0 {
+		query.Position = NewFilter(FieldPosition, builder.GetPositionAtLeast(), OperationGreaterOrEquals)
+		return query.Position
+	}
 	if builder.GetPositionAfter() == 0 {
 		return nil
 	}
@@ -88,6 +88,15 @@ func TestCRDB_operation(t *testing.T) {
 				op: ">",
 			},
 		},
+		{
+			name: "greater or equals",
+			args: args{
+				operation: repository.OperationGreaterOrEquals,
+			},
+			res: res{
+				op: ">=",
+			},
+		},
 		{
 			name: "less",
 			args: args{
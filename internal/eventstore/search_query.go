@@ -27,6 +27,7 @@ type SearchQueryBuilder struct {
 	lockOption            LockOption
 	allowTimeTravel       bool
 	positionAfter         float64
+	positionAtLeast       float64
 	awaitOpenTransactions bool
 	creationDateAfter     time.Time
 	creationDateBefore    time.Time
@@ -85,6 +86,10 @@ func (b SearchQueryBuilder) GetPositionAfter() float64 {
 	return b.positionAfter
 }
 
+func (b SearchQueryBuilder) GetPositionAtLeast() float64 {
+	return b.positionAtLeast
+}
+
 func (b SearchQueryBuilder) GetAwaitOpenTransactions() bool {
 	return b.awaitOpenTransactions
 }
@@ -302,6 +307,13 @@ func (builder *SearchQueryBuilder) PositionAfter(position float64) *SearchQueryB
 	return builder
 }
 
+// PositionAtLeast filters for events which happened at or after the specified position.
+// In combination with [SearchQueryBuilder.Offset] the events already read at the position can be skipped.
+func (builder *SearchQueryBuilder) PositionAtLeast(position float64) *SearchQueryBuilder {
+	builder.positionAtLeast = position
+	return builder
+}
+
 // AwaitOpenTransactions filters for events which are older than the oldest transaction of the database
 func (builder *SearchQueryBuilder) AwaitOpenTransactions() *SearchQueryBuilder {
 	builder.awaitOpenTransactions = true
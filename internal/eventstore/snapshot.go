@@ -0,0 +1,168 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/zitadel/logging"
+)
+
+type SnapshotConfig struct {
+	// Enabled stores and loads snapshots of write models implementing [SnapshotReducer]
+	Enabled bool
+	// Threshold is the minimum amount of events reduced since the last snapshot,
+	// before a new snapshot is stored.
+	Threshold uint32
+}
+
+// Snapshot is the serialized state of a write model after reducing the events up to Sequence.
+type Snapshot struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	// Name identifies the write model of the snapshot, see [SnapshotReducer.SnapshotName]
+	Name string
+	// Revision of the reducer which created the snapshot, see [SnapshotReducer.SnapshotRevision]
+	Revision      uint16
+	Sequence      uint64
+	ResourceOwner string
+	ChangeDate    time.Time
+	Payload       []byte
+}
+
+type SnapshotStore interface {
+	// Snapshot returns the stored snapshot of the write model.
+	// Nil is returned if no snapshot exists.
+	Snapshot(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateID, name string) (*Snapshot, error)
+	// StoreSnapshot creates or replaces the snapshot of the write model.
+	StoreSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+// SnapshotReducer is a [QueryReducer] of a single aggregate whose state can be stored as a snapshot.
+// The state is serialized as json, so all fields required by the command must be exported and marshallable.
+// Only the events after the snapshot are reduced when the write model is filtered using [Eventstore.FilterToQueryReducer].
+//
+// The [WriteModel] must be embedded into the implementing type.
+type SnapshotReducer interface {
+	QueryReducer
+	// SnapshotName identifies the write model.
+	// It must be unique for the aggregate type of the query.
+	SnapshotName() string
+	// SnapshotRevision must be increased whenever the reducer or the state of the write model changes.
+	// Snapshots of other revisions are ignored and replaced.
+	SnapshotRevision() uint16
+
+	writeModel() *WriteModel
+}
+
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
+
+// filterWithSnapshot restores the state of the reducer from its snapshot
+// and only reduces the events after it.
+// A new snapshot is stored if at least the configured threshold of events were reduced.
+// Snapshots are an optimization, so failures of the snapshot store are only logged.
+func (es *Eventstore) filterWithSnapshot(ctx context.Context, reducer SnapshotReducer) error {
+	query := reducer.Query()
+	query.ensureInstanceID(ctx)
+	aggregateType, aggregateID, ok := snapshotAggregate(query)
+	if !ok {
+		return es.FilterToReducer(ctx, query, reducer)
+	}
+	instanceID := *query.GetInstanceID()
+	name := reducer.SnapshotName()
+
+	snapshot, err := es.snapshots.Snapshot(ctx, instanceID, aggregateType, aggregateID, name)
+	logging.WithFields("name", name, "aggregate", aggregateID).OnError(err).Warn("unable to load snapshot")
+	if snapshot != nil && restoreSnapshot(reducer, snapshot) {
+		query.SequenceGreater(snapshot.Sequence)
+	}
+
+	var reduced uint32
+	err = es.querier.FilterToReducer(ctx, query, func(event Event) error {
+		event, err := es.mapEvent(event)
+		if err != nil {
+			return err
+		}
+		reduced++
+		reducer.AppendEvents(event)
+		return reducer.Reduce()
+	})
+	if err != nil || reduced == 0 || reduced < es.snapshotThreshold {
+		return err
+	}
+
+	wm := reducer.writeModel()
+	payload, err := json.Marshal(reducer)
+	if err != nil {
+		logging.WithFields("name", name).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	err = es.snapshots.StoreSnapshot(ctx, &Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Name:          name,
+		Revision:      reducer.SnapshotRevision(),
+		Sequence:      wm.ProcessedSequence,
+		ResourceOwner: wm.ResourceOwner,
+		ChangeDate:    wm.ChangeDate,
+		Payload:       payload,
+	})
+	logging.WithFields("name", name, "aggregate", aggregateID).OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+// snapshotAggregate returns the aggregate of the query
+// if the query is restricted to a single aggregate and does not restrict the events in another way
+// than by the definition of the write model.
+func snapshotAggregate(query *SearchQueryBuilder) (aggregateType AggregateType, aggregateID string, ok bool) {
+	if query.GetColumns() != ColumnsEvent ||
+		query.GetInstanceID() == nil ||
+		query.GetTx() != nil ||
+		query.GetDesc() ||
+		query.GetLimit() > 0 ||
+		query.GetOffset() > 0 ||
+		query.GetPositionAfter() > 0 ||
+		query.GetPositionAtLeast() > 0 ||
+		query.GetEventSequenceGreater() > 0 ||
+		!query.GetCreationDateAfter().IsZero() ||
+		!query.GetCreationDateBefore().IsZero() ||
+		query.GetExcludeAggregateIDs() != nil ||
+		len(query.GetQueries()) != 1 {
+		return "", "", false
+	}
+	subQuery := query.GetQueries()[0]
+	if len(subQuery.GetAggregateTypes()) != 1 || len(subQuery.GetAggregateIDs()) != 1 {
+		return "", "", false
+	}
+	return subQuery.GetAggregateTypes()[0], subQuery.GetAggregateIDs()[0], true
+}
+
+// restoreSnapshot sets the state of the reducer to the snapshot.
+// False is returned if the snapshot cannot be applied.
+func restoreSnapshot(reducer SnapshotReducer, snapshot *Snapshot) bool {
+	if snapshot.Revision != reducer.SnapshotRevision() {
+		return false
+	}
+	// verify the payload on a new instance first, so a partially unmarshalled snapshot does not corrupt the state
+	if err := json.Unmarshal(snapshot.Payload, reflect.New(reflect.TypeOf(reducer).Elem()).Interface()); err != nil {
+		logging.WithFields("name", snapshot.Name).WithError(err).Warn("unable to unmarshal snapshot")
+		return false
+	}
+	if err := json.Unmarshal(snapshot.Payload, reducer); err != nil {
+		return false
+	}
+	wm := reducer.writeModel()
+	wm.AggregateID = snapshot.AggregateID
+	wm.InstanceID = snapshot.InstanceID
+	if wm.ResourceOwner == "" {
+		wm.ResourceOwner = snapshot.ResourceOwner
+	}
+	wm.ProcessedSequence = snapshot.Sequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return true
+}
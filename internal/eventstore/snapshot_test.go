@@ -0,0 +1,198 @@
+package eventstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSnapshotStore struct {
+	snapshots map[string]*Snapshot
+	stored    int
+}
+
+func (s *testSnapshotStore) Snapshot(_ context.Context, instanceID string, aggregateType AggregateType, aggregateID, name string) (*Snapshot, error) {
+	return s.snapshots[instanceID+string(aggregateType)+aggregateID+name], nil
+}
+
+func (s *testSnapshotStore) StoreSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.stored++
+	s.snapshots[snapshot.InstanceID+string(snapshot.AggregateType)+snapshot.AggregateID+snapshot.Name] = snapshot
+	return nil
+}
+
+// testSequenceQuerier only returns the events with a sequence greater than requested by the query.
+type testSequenceQuerier struct {
+	testQuerier
+	queried int
+}
+
+func (repo *testSequenceQuerier) FilterToReducer(_ context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	for _, event := range repo.events {
+		if event.Sequence() <= searchQuery.GetEventSequenceGreater() {
+			continue
+		}
+		repo.queried++
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testSnapshotWriteModel struct {
+	WriteModel
+
+	Reduced  int
+	revision uint16
+}
+
+func (wm *testSnapshotWriteModel) Reduce() error {
+	wm.Reduced += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotWriteModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		InstanceID("instance").
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs("id").
+		Builder()
+}
+
+func (wm *testSnapshotWriteModel) SnapshotName() string {
+	return "test"
+}
+
+func (wm *testSnapshotWriteModel) SnapshotRevision() uint16 {
+	return wm.revision
+}
+
+func testSnapshotEvents(count int) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			Agg: &Aggregate{
+				ID:            "id",
+				Type:          "test.aggregate",
+				ResourceOwner: "ro",
+				InstanceID:    "instance",
+			},
+			EventType: "test.snapshot.event",
+			Seq:       uint64(i + 1),
+			Creation:  time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+	}
+	return events
+}
+
+func TestEventstore_FilterToQueryReducer_snapshot(t *testing.T) {
+	ctx := context.Background()
+	store := &testSnapshotStore{snapshots: make(map[string]*Snapshot)}
+	querier := &testSequenceQuerier{testQuerier: testQuerier{events: testSnapshotEvents(5)}}
+	es := NewEventstore(&Config{
+		Querier:       querier,
+		SnapshotStore: store,
+		Snapshots: SnapshotConfig{
+			Enabled:   true,
+			Threshold: 3,
+		},
+	})
+
+	// all events are reduced and a snapshot is stored
+	wm := &testSnapshotWriteModel{revision: 1}
+	require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+	assert.Equal(t, 5, wm.Reduced)
+	assert.Equal(t, 5, querier.queried)
+	assert.Equal(t, 1, store.stored)
+
+	// the state is restored from the snapshot and only new events are reduced
+	querier.events = testSnapshotEvents(6)
+	querier.queried = 0
+	wm = &testSnapshotWriteModel{revision: 1}
+	require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+	assert.Equal(t, 6, wm.Reduced)
+	assert.Equal(t, 1, querier.queried)
+	assert.Equal(t, uint64(6), wm.ProcessedSequence)
+	assert.Equal(t, "id", wm.AggregateID)
+	assert.Equal(t, "ro", wm.ResourceOwner)
+	assert.Equal(t, "instance", wm.InstanceID)
+	// below the threshold, no new snapshot is stored
+	assert.Equal(t, 1, store.stored)
+
+	// snapshots of another revision are ignored and replaced
+	querier.queried = 0
+	wm = &testSnapshotWriteModel{revision: 2}
+	require.NoError(t, es.FilterToQueryReducer(ctx, wm))
+	assert.Equal(t, 6, wm.Reduced)
+	assert.Equal(t, 6, querier.queried)
+	assert.Equal(t, 2, store.stored)
+}
+
+func TestEventstore_FilterToQueryReducer_snapshotsDisabled(t *testing.T) {
+	store := &testSnapshotStore{snapshots: make(map[string]*Snapshot)}
+	es := NewEventstore(&Config{
+		Querier:       &testSequenceQuerier{testQuerier: testQuerier{events: testSnapshotEvents(5)}},
+		SnapshotStore: store,
+	})
+	wm := &testSnapshotWriteModel{revision: 1}
+	require.NoError(t, es.FilterToQueryReducer(context.Background(), wm))
+	assert.Equal(t, 5, wm.Reduced)
+	assert.Equal(t, 0, store.stored)
+}
+
+func Test_snapshotAggregate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *SearchQueryBuilder
+		want  bool
+	}{
+		{
+			name: "single aggregate",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("type").AggregateIDs("id").EventTypes("event").Builder(),
+			want: true,
+		},
+		{
+			name: "without instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().AggregateTypes("type").AggregateIDs("id").Builder(),
+			want: false,
+		},
+		{
+			name: "multiple aggregates",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("type").AggregateIDs("id", "id2").Builder(),
+			want: false,
+		},
+		{
+			name: "multiple queries",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("type").AggregateIDs("id").
+				Or().AggregateTypes("other").AggregateIDs("id").Builder(),
+			want: false,
+		},
+		{
+			name: "limit",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").Limit(1).
+				AddQuery().AggregateTypes("type").AggregateIDs("id").Builder(),
+			want: false,
+		},
+		{
+			name: "position after",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").PositionAfter(1).
+				AddQuery().AggregateTypes("type").AggregateIDs("id").Builder(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, ok := snapshotAggregate(tt.query)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
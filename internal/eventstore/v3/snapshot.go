@@ -0,0 +1,65 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	_ eventstore.SnapshotStore = (*Eventstore)(nil)
+
+	//go:embed snapshot_query.sql
+	snapshotQuery string
+	//go:embed snapshot_store.sql
+	snapshotStoreStmt string
+)
+
+// Snapshot implements [eventstore.SnapshotStore]
+func (es *Eventstore) Snapshot(ctx context.Context, instanceID string, aggregateType eventstore.AggregateType, aggregateID, name string) (*eventstore.Snapshot, error) {
+	snapshot := &eventstore.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Name:          name,
+	}
+	err := es.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(
+			&snapshot.Revision,
+			&snapshot.Sequence,
+			&snapshot.ResourceOwner,
+			&snapshot.ChangeDate,
+			&snapshot.Payload,
+		)
+	}, snapshotQuery, instanceID, string(aggregateType), aggregateID, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Snq7x", "Errors.Internal")
+	}
+	return snapshot, nil
+}
+
+// StoreSnapshot implements [eventstore.SnapshotStore]
+func (es *Eventstore) StoreSnapshot(ctx context.Context, snapshot *eventstore.Snapshot) error {
+	_, err := es.client.ExecContext(ctx, snapshotStoreStmt,
+		snapshot.InstanceID,
+		string(snapshot.AggregateType),
+		snapshot.AggregateID,
+		snapshot.Name,
+		snapshot.Revision,
+		snapshot.Sequence,
+		snapshot.ResourceOwner,
+		snapshot.ChangeDate,
+		snapshot.Payload,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V3-hC2tm", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+SELECT
+    revision
+    , "sequence"
+    , resource_owner
+    , change_date
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
+    AND name = $4
@@ -0,0 +1,23 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , name
+    , revision
+    , "sequence"
+    , resource_owner
+    , change_date
+    , payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (instance_id, aggregate_type, aggregate_id, name) DO UPDATE SET
+    revision = EXCLUDED.revision
+    , "sequence" = EXCLUDED."sequence"
+    , resource_owner = EXCLUDED.resource_owner
+    , change_date = EXCLUDED.change_date
+    , payload = EXCLUDED.payload
+    , created_at = now()
+WHERE
+    snapshots.revision <> EXCLUDED.revision
+    OR snapshots."sequence" < EXCLUDED."sequence"
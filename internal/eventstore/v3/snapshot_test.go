@@ -0,0 +1,72 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestEventstore_Snapshot(t *testing.T) {
+	changeDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		mock func(t *testing.T) *mock.SQLMock
+		want *eventstore.Snapshot
+	}{
+		{
+			name: "no snapshot",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(snapshotQuery,
+						mock.WithQueryArgs("instance", "org", "id", "org"),
+						mock.WithQueryResult([]string{"revision", "sequence", "resource_owner", "change_date", "payload"}, [][]driver.Value{}),
+					),
+				)
+			},
+			want: nil,
+		},
+		{
+			name: "snapshot",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(snapshotQuery,
+						mock.WithQueryArgs("instance", "org", "id", "org"),
+						mock.WithQueryResult([]string{"revision", "sequence", "resource_owner", "change_date", "payload"}, [][]driver.Value{
+							{1, 42, "ro", changeDate, []byte(`{"Name":"org"}`)},
+						}),
+					),
+				)
+			},
+			want: &eventstore.Snapshot{
+				InstanceID:    "instance",
+				AggregateType: "org",
+				AggregateID:   "id",
+				Name:          "org",
+				Revision:      1,
+				Sequence:      42,
+				ResourceOwner: "ro",
+				ChangeDate:    changeDate,
+				Payload:       []byte(`{"Name":"org"}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.mock(t)
+			defer dbMock.Assert(t)
+			es := &Eventstore{client: &database.DB{DB: dbMock.DB}}
+
+			got, err := es.Snapshot(context.Background(), "instance", "org", "id", "org")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+const (
+	// EventExecutionsProjectionPrefix is the prefix of the projection name of the handler of each target,
+	// the position of the delivered events is stored under this name.
+	EventExecutionsProjectionPrefix = "projections.execution_events_"
+
+	eventGroupSuffix = ".*"
+)
+
+// ContextInfoEvent is the body sent to the targets of event executions
+type ContextInfoEvent struct {
+	AggregateID   string          `json:"aggregateID,omitempty"`
+	AggregateType string          `json:"aggregateType,omitempty"`
+	ResourceOwner string          `json:"resourceOwner,omitempty"`
+	InstanceID    string          `json:"instanceID,omitempty"`
+	Version       string          `json:"version,omitempty"`
+	Sequence      uint64          `json:"sequence,omitempty"`
+	EventType     string          `json:"event_type,omitempty"`
+	CreatedAt     time.Time       `json:"created_at,omitempty"`
+	UserID        string          `json:"userID,omitempty"`
+	EventPayload  json.RawMessage `json:"event_payload,omitempty"`
+}
+
+func contextInfoFromEvent(event eventstore.Event) *ContextInfoEvent {
+	info := &ContextInfoEvent{
+		AggregateID:   event.Aggregate().ID,
+		AggregateType: string(event.Aggregate().Type),
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		InstanceID:    event.Aggregate().InstanceID,
+		Version:       string(event.Aggregate().Version),
+		Sequence:      event.Sequence(),
+		EventType:     string(event.Type()),
+		CreatedAt:     event.CreatedAt(),
+		UserID:        event.Creator(),
+	}
+	if payload := event.DataAsBytes(); len(payload) > 0 {
+		info.EventPayload = payload
+	}
+	return info
+}
+
+type EventQueries interface {
+	SearchExecutions(ctx context.Context, queries *query.ExecutionSearchQueries) (*query.Executions, error)
+	TargetsByExecutionID(ctx context.Context, ids []string) ([]*query.ExecutionTarget, error)
+	GetTargetByID(ctx context.Context, id string) (*query.Target, error)
+}
+
+// EventExecutions delivers the events matching an event execution to its targets.
+//
+// Every target is handled by its own [handler.Handler], the position is therefore persisted per target
+// and a failing target does not block the other targets.
+// The events are delivered in order, a failed call blocks the target and is retried
+// every RequeueEvery of the config until it succeeds, so no event is dropped or delivered out of order.
+// New targets start at the latest position of the instance.
+type EventExecutions struct {
+	config     handler.Config
+	queries    EventQueries
+	eventTypes []string
+
+	handlersMu sync.Mutex
+	handlers   map[string]*targetHandler
+}
+
+// targetHandler is the handler of a target of an instance
+type targetHandler struct {
+	*handler.Handler
+	instanceID string
+}
+
+func NewEventExecutions(config handler.Config, queries EventQueries, eventTypes []string) *EventExecutions {
+	return &EventExecutions{
+		config:     config,
+		queries:    queries,
+		eventTypes: eventTypes,
+		handlers:   make(map[string]*targetHandler),
+	}
+}
+
+// Start triggers the handlers of the active instances every RequeueEvery of the config.
+func (e *EventExecutions) Start(ctx context.Context) {
+	if e.config.RequeueEvery <= 0 || e.config.ActiveInstancer == nil {
+		logging.Info("event executions are not started because no requeue interval or active instances are configured")
+		return
+	}
+	go e.schedule(ctx)
+}
+
+func (e *EventExecutions) schedule(ctx context.Context) {
+	t := time.NewTicker(e.config.RequeueEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for _, instanceID := range e.config.ActiveInstancer.ActiveInstances() {
+				err := e.Trigger(authz.WithInstanceID(call.WithTimestamp(ctx), instanceID))
+				logging.WithFields("instance", instanceID).OnError(err).Debug("trigger of event executions failed")
+			}
+		}
+	}
+}
+
+// Trigger delivers the new events of the instance in the context to the targets of the event executions.
+func (e *EventExecutions) Trigger(ctx context.Context) error {
+	targetIDs, err := e.eventTargetIDs(ctx)
+	if err != nil {
+		return err
+	}
+	e.removeHandlers(authz.GetInstance(ctx).InstanceID(), targetIDs)
+	for _, targetID := range targetIDs {
+		h, err := e.handler(ctx, targetID)
+		if err != nil {
+			logging.WithFields("target", targetID).WithError(err).Info("unable to create event execution handler")
+			continue
+		}
+		_, err = h.Trigger(ctx)
+		logging.WithFields("target", targetID).OnError(err).Debug("trigger of event execution handler failed")
+	}
+	return nil
+}
+
+// eventTargetIDs returns the ids of all targets which are called by event executions, including the targets of includes.
+func (e *EventExecutions) eventTargetIDs(ctx context.Context) ([]string, error) {
+	typeQuery, err := query.NewExecutionTypeSearchQuery(domain.ExecutionTypeEvent)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := e.queries.SearchExecutions(ctx, &query.ExecutionSearchQueries{Queries: []query.SearchQuery{typeQuery}})
+	if err != nil {
+		return nil, err
+	}
+	targetIDs := make([]string, 0, len(executions.Executions))
+	for _, execution := range executions.Executions {
+		targets, err := e.queries.TargetsByExecutionID(ctx, []string{execution.ID})
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets {
+			if !slices.Contains(targetIDs, target.GetTargetID()) {
+				targetIDs = append(targetIDs, target.GetTargetID())
+			}
+		}
+	}
+	return targetIDs, nil
+}
+
+// removeHandlers removes the handlers of the targets of the instance which are no longer called by event executions
+func (e *EventExecutions) removeHandlers(instanceID string, targetIDs []string) {
+	e.handlersMu.Lock()
+	defer e.handlersMu.Unlock()
+
+	for targetID, h := range e.handlers {
+		if h.instanceID == instanceID && !slices.Contains(targetIDs, targetID) {
+			delete(e.handlers, targetID)
+		}
+	}
+}
+
+func (e *EventExecutions) handler(ctx context.Context, targetID string) (*targetHandler, error) {
+	e.handlersMu.Lock()
+	defer e.handlersMu.Unlock()
+
+	if h, ok := e.handlers[targetID]; ok {
+		return h, nil
+	}
+	target, err := e.queries.GetTargetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	h := &targetHandler{
+		Handler: handler.NewHandler(ctx, &e.config, &eventTargetProjection{
+			targetID:   targetID,
+			since:      target.CreationDate,
+			queries:    e.queries,
+			eventTypes: e.eventTypes,
+		}),
+		instanceID: authz.GetInstance(ctx).InstanceID(),
+	}
+	e.handlers[targetID] = h
+	return h, nil
+}
+
+var (
+	_ handler.Projection            = (*eventTargetProjection)(nil)
+	_ handler.LatestPositionStarter = (*eventTargetProjection)(nil)
+	_ handler.FailedEventBlocker    = (*eventTargetProjection)(nil)
+)
+
+// eventTargetProjection calls a single target for the events matching its event executions
+type eventTargetProjection struct {
+	targetID string
+	// since prevents the delivery of events which were created before the target,
+	// in case the handler of a new target starts before the target was created.
+	since      time.Time
+	queries    EventQueries
+	eventTypes []string
+}
+
+// Name implements [handler.Projection]
+func (p *eventTargetProjection) Name() string {
+	return EventExecutionsProjectionPrefix + p.targetID
+}
+
+// StartAtLatestPosition implements [handler.LatestPositionStarter],
+// targets are only called for events pushed after their creation.
+func (p *eventTargetProjection) StartAtLatestPosition() bool {
+	return true
+}
+
+// BlockOnFailedEvent implements [handler.FailedEventBlocker],
+// the events are delivered in order, so a failed call is retried until it succeeds.
+func (p *eventTargetProjection) BlockOnFailedEvent() bool {
+	return true
+}
+
+// Reducers implements [handler.Projection]
+func (p *eventTargetProjection) Reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, 0)
+	for _, eventType := range p.eventTypes {
+		typ := eventstore.EventType(eventType)
+		aggregateType := eventstore.AggregateTypeFromEventType(typ)
+		i := slices.IndexFunc(reducers, func(reducer handler.AggregateReducer) bool {
+			return reducer.Aggregate == aggregateType
+		})
+		if i < 0 {
+			reducers = append(reducers, handler.AggregateReducer{Aggregate: aggregateType})
+			i = len(reducers) - 1
+		}
+		reducers[i].EventReducers = append(reducers[i].EventReducers, handler.EventReducer{
+			Event:  typ,
+			Reduce: p.reduce,
+		})
+	}
+	return reducers
+}
+
+func (p *eventTargetProjection) reduce(event eventstore.Event) (*handler.Statement, error) {
+	if event.CreatedAt().Before(p.since) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+		targets, err := p.queries.TargetsByExecutionID(ctx, idsForEventType(string(event.Type())))
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(targets, func(target *query.ExecutionTarget) bool {
+			return target.GetTargetID() == p.targetID
+		})
+		// the event does not match an execution of the target
+		if i < 0 {
+			return nil
+		}
+		body, err := json.Marshal(contextInfoFromEvent(event))
+		if err != nil {
+			return err
+		}
+		_, err = Call(ctx, targets[i].GetEndpoint(), targets[i].GetTimeout(), body, targets[i].GetSigningKey())
+		logging.WithFields("target", p.targetID, "event", event.Type(), "sequence", event.Sequence()).OnError(err).Info("event execution failed, the event is retried")
+		return err
+	}), nil
+}
+
+// idsForEventType returns the ids of the executions matching the event type, for example:
+// [ "event/user.human.added",
+// "event/user.human.*",
+// "event/user.*",
+// "event" ]
+func idsForEventType(eventType string) []string {
+	segments := strings.Split(eventType, ".")
+	ids := make([]string, 0, len(segments)+1)
+	ids = append(ids, exec_repo.ID(domain.ExecutionTypeEvent, eventType))
+	for i := len(segments) - 1; i > 0; i-- {
+		ids = append(ids, exec_repo.ID(domain.ExecutionTypeEvent, strings.Join(segments[:i], ".")+eventGroupSuffix))
+	}
+	return append(ids, exec_repo.IDAll(domain.ExecutionTypeEvent))
+}
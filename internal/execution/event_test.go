@@ -0,0 +1,200 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_idsForEventType(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		want      []string
+	}{
+		{
+			name:      "single segment",
+			eventType: "event",
+			want:      []string{"event/event", "event"},
+		},
+		{
+			name:      "multiple segments",
+			eventType: "user.human.added",
+			want:      []string{"event/user.human.added", "event/user.human.*", "event/user.*", "event"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, idsForEventType(tt.eventType))
+		})
+	}
+}
+
+func Test_eventTargetProjection_Reducers(t *testing.T) {
+	p := &eventTargetProjection{
+		targetID:   "target",
+		eventTypes: []string{"user.human.added", "user.human.changed", "org.added"},
+	}
+	assert.Equal(t, EventExecutionsProjectionPrefix+"target", p.Name())
+
+	events := make(map[eventstore.EventType]bool)
+	for _, reducer := range p.Reducers() {
+		for _, eventReducer := range reducer.EventReducers {
+			events[eventReducer.Event] = true
+		}
+	}
+	assert.Equal(t, map[eventstore.EventType]bool{
+		"user.human.added":   true,
+		"user.human.changed": true,
+		"org.added":          true,
+	}, events)
+}
+
+func Test_eventTargetProjection_reduce(t *testing.T) {
+	created := time.Now()
+	event := &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "user",
+			Type:          "user",
+			ResourceOwner: "org",
+			InstanceID:    "instance",
+			Version:       "v2",
+		},
+		Seq:       3,
+		Creation:  created,
+		User:      "editor",
+		EventType: "user.human.added",
+		Data:      []byte(`{"userName":"user"}`),
+	}
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		targets  []*query.ExecutionTarget
+		err      error
+		status   int
+		wantCall bool
+		wantErr  func(error) bool
+	}{
+		{
+			name:  "created before target, skipped",
+			since: created.Add(time.Second),
+		},
+		{
+			name:    "query failed",
+			err:     zerrors.ThrowInternal(nil, "QUERY-bmxvqag4sy", "Errors.Internal"),
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name:    "other target",
+			targets: []*query.ExecutionTarget{{TargetID: "other", Timeout: time.Minute}},
+		},
+		{
+			name:     "call failed",
+			targets:  []*query.ExecutionTarget{{TargetID: "target", Timeout: time.Minute}},
+			status:   http.StatusInternalServerError,
+			wantCall: true,
+			wantErr:  zerrors.IsPreconditionFailed,
+		},
+		{
+			name:     "ok",
+			targets:  []*query.ExecutionTarget{{TargetID: "target", Timeout: time.Minute}},
+			status:   http.StatusOK,
+			wantCall: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				require.NoError(t, err)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			for _, target := range tt.targets {
+				target.Endpoint = server.URL
+			}
+			queries := &mockEventQueries{targets: tt.targets, err: tt.err}
+			p := &eventTargetProjection{
+				targetID: "target",
+				since:    tt.since,
+				queries:  queries,
+			}
+			stmt, err := p.reduce(event)
+			require.NoError(t, err)
+			if stmt.Execute == nil {
+				assert.Nil(t, queries.ids)
+				return
+			}
+			err = stmt.Execute(nil, p.Name())
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "instance", queries.instanceID)
+			assert.Equal(t, idsForEventType("user.human.added"), queries.ids)
+			if !tt.wantCall {
+				assert.Nil(t, body)
+				return
+			}
+			info := new(ContextInfoEvent)
+			require.NoError(t, json.Unmarshal(body, info))
+			assert.Equal(t, "user", info.AggregateID)
+			assert.Equal(t, "user.human.added", info.EventType)
+			assert.Equal(t, uint64(3), info.Sequence)
+			assert.Equal(t, "editor", info.UserID)
+			assert.JSONEq(t, `{"userName":"user"}`, string(info.EventPayload))
+		})
+	}
+}
+
+type mockEventQueries struct {
+	targets    []*query.ExecutionTarget
+	err        error
+	ids        []string
+	instanceID string
+}
+
+func (q *mockEventQueries) SearchExecutions(context.Context, *query.ExecutionSearchQueries) (*query.Executions, error) {
+	return nil, nil
+}
+
+func (q *mockEventQueries) TargetsByExecutionID(ctx context.Context, ids []string) ([]*query.ExecutionTarget, error) {
+	q.ids = ids
+	q.instanceID = authz.GetInstance(ctx).InstanceID()
+	return q.targets, q.err
+}
+
+func (q *mockEventQueries) GetTargetByID(context.Context, string) (*query.Target, error) {
+	return nil, nil
+}
+
+func TestEventExecutions_removeHandlers(t *testing.T) {
+	e := NewEventExecutions(handler.Config{}, nil, nil)
+	e.handlers = map[string]*targetHandler{
+		"active":  {instanceID: "instance"},
+		"removed": {instanceID: "instance"},
+		"other":   {instanceID: "other"},
+	}
+	e.removeHandlers("instance", []string{"active"})
+	assert.Equal(t, map[string]*targetHandler{
+		"active": {instanceID: "instance"},
+		"other":  {instanceID: "other"},
+	}, e.handlers)
+}
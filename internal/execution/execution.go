@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/riverqueue/river"
 	"github.com/zitadel/logging"
 
 	zhttp "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/queue"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	"github.com/zitadel/zitadel/pkg/actions"
@@ -24,12 +26,20 @@ type ContextInfo interface {
 }
 
 type Target interface {
+	GetExecutionID() string
 	GetTargetID() string
 	IsInterruptOnError() bool
 	GetEndpoint() string
 	GetTargetType() domain.TargetType
 	GetTimeout() time.Duration
 	GetSigningKey() string
+	GetRetryPolicy() domain.TargetRetryPolicy
+}
+
+// Queue is used to deliver requests to async and webhook targets durably.
+// If no queue is provided, async targets are called in a goroutine without retries.
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
 }
 
 // CallTargets call a list of targets in order with handling of error and responses
@@ -37,13 +47,14 @@ func CallTargets(
 	ctx context.Context,
 	targets []Target,
 	info ContextInfo,
+	queue Queue,
 ) (_ interface{}, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer span.EndWithError(err)
 
 	for _, target := range targets {
 		// call the type of target
-		resp, err := CallTarget(ctx, target, info)
+		resp, err := CallTarget(ctx, target, info, queue)
 		// handle error if interrupt is set
 		if err != nil && target.IsInterruptOnError() {
 			return nil, err
@@ -67,6 +78,7 @@ func CallTarget(
 	ctx context.Context,
 	target Target,
 	info ContextInfoRequest,
+	queue Queue,
 ) (res []byte, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer span.EndWithError(err)
@@ -74,11 +86,20 @@ func CallTarget(
 	switch target.GetTargetType() {
 	// get request, ignore response and return request and error for handling in list of targets
 	case domain.TargetTypeWebhook:
-		return nil, webhook(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody(), target.GetSigningKey())
+		err = webhook(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody(), target.GetSigningKey())
+		// the result of the webhook is not used, so failed calls are retried in the background if the execution can continue
+		if err != nil && !target.IsInterruptOnError() && queue != nil {
+			logging.WithFields("target", target.GetTargetID()).WithError(err).Info("webhook failed, retry in background")
+			return nil, enqueue(ctx, queue, target, info.GetHTTPRequestBody())
+		}
+		return nil, err
 	// get request, return response and error
 	case domain.TargetTypeCall:
 		return Call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody(), target.GetSigningKey())
 	case domain.TargetTypeAsync:
+		if queue != nil {
+			return nil, enqueue(ctx, queue, target, info.GetHTTPRequestBody())
+		}
 		go func(target Target, info ContextInfoRequest) {
 			if _, err := Call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody(), target.GetSigningKey()); err != nil {
 				logging.WithFields("target", target.GetTargetID()).OnError(err).Info(err)
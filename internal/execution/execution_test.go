@@ -11,12 +11,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/riverqueue/river"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/queue"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	"github.com/zitadel/zitadel/pkg/actions"
 )
@@ -132,10 +135,12 @@ func Test_CallTarget(t *testing.T) {
 		info   *middleware.ContextInfoRequest
 		server *callTestServer
 		target *mockTarget
+		queue  *mockQueue
 	}
 	type res struct {
-		body    []byte
-		wantErr bool
+		body     []byte
+		wantErr  bool
+		enqueued []*execution.TargetRequest
 	}
 	tests := []struct {
 		name string
@@ -183,6 +188,89 @@ func Test_CallTarget(t *testing.T) {
 				wantErr: true,
 			},
 		},
+		{
+			"webhook, error, enqueued",
+			args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance"),
+				info: requestContextInfo1,
+				server: &callTestServer{
+					timeout:     time.Second,
+					method:      http.MethodPost,
+					expectBody:  []byte("{\"request\":{\"request\":\"content1\"}}"),
+					respondBody: []byte("{\"request\":\"content2\"}"),
+					statusCode:  http.StatusInternalServerError,
+				},
+				target: &mockTarget{
+					ExecutionID: "request",
+					TargetID:    "target",
+					TargetType:  domain.TargetTypeWebhook,
+					Timeout:     time.Minute,
+					RetryPolicy: domain.TargetRetryPolicy{MaxAttempts: 3},
+				},
+				queue: new(mockQueue),
+			},
+			res{
+				body: nil,
+				enqueued: []*execution.TargetRequest{
+					{
+						InstanceID:  "instance",
+						ExecutionID: "request",
+						TargetID:    "target",
+						Body:        []byte("{\"request\":{\"request\":\"content1\"}}"),
+						RetryPolicy: domain.TargetRetryPolicy{MaxAttempts: 3},
+					},
+				},
+			},
+		},
+		{
+			"webhook, error, interrupt, not enqueued",
+			args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance"),
+				info: requestContextInfo1,
+				server: &callTestServer{
+					timeout:     time.Second,
+					method:      http.MethodPost,
+					expectBody:  []byte("{\"request\":{\"request\":\"content1\"}}"),
+					respondBody: []byte("{\"request\":\"content2\"}"),
+					statusCode:  http.StatusInternalServerError,
+				},
+				target: &mockTarget{
+					TargetType:       domain.TargetTypeWebhook,
+					Timeout:          time.Minute,
+					InterruptOnError: true,
+				},
+				queue: new(mockQueue),
+			},
+			res{
+				wantErr: true,
+			},
+		},
+		{
+			"async, enqueued",
+			args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance"),
+				info:   requestContextInfo1,
+				server: &callTestServer{},
+				target: &mockTarget{
+					ExecutionID: "request",
+					TargetID:    "target",
+					TargetType:  domain.TargetTypeAsync,
+					Timeout:     time.Minute,
+				},
+				queue: new(mockQueue),
+			},
+			res{
+				body: nil,
+				enqueued: []*execution.TargetRequest{
+					{
+						InstanceID:  "instance",
+						ExecutionID: "request",
+						TargetID:    "target",
+						Body:        []byte("{\"request\":{\"request\":\"content1\"}}"),
+					},
+				},
+			},
+		},
 		{
 			"webhook, ok",
 			args{
@@ -295,7 +383,13 @@ func Test_CallTarget(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			respBody, err := testServer(t, tt.args.server, testCallTarget(tt.args.ctx, tt.args.info, tt.args.target))
+			respBody, err := testServer(t, tt.args.server, testCallTarget(tt.args.ctx, tt.args.info, tt.args.target, tt.args.queue))
+			if tt.args.queue != nil {
+				assert.Equal(t, tt.res.enqueued, tt.args.queue.inserted)
+				for i, request := range tt.args.queue.inserted {
+					assert.JSONEq(t, `{"instanceID":"`+request.InstanceID+`"}`, string(tt.args.queue.metadata[i]))
+				}
+			}
 			if tt.res.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -457,8 +551,12 @@ type mockTarget struct {
 	Timeout          time.Duration
 	InterruptOnError bool
 	SigningKey       string
+	RetryPolicy      domain.TargetRetryPolicy
 }
 
+func (e *mockTarget) GetExecutionID() string {
+	return e.ExecutionID
+}
 func (e *mockTarget) GetTargetID() string {
 	return e.TargetID
 }
@@ -477,6 +575,26 @@ func (e *mockTarget) GetTimeout() time.Duration {
 func (e *mockTarget) GetSigningKey() string {
 	return e.SigningKey
 }
+func (e *mockTarget) GetRetryPolicy() domain.TargetRetryPolicy {
+	return e.RetryPolicy
+}
+
+var _ execution.Queue = &mockQueue{}
+
+type mockQueue struct {
+	inserted []*execution.TargetRequest
+	metadata [][]byte
+}
+
+func (q *mockQueue) Insert(_ context.Context, args river.JobArgs, opts ...queue.InsertOpt) error {
+	q.inserted = append(q.inserted, args.(*execution.TargetRequest))
+	options := new(river.InsertOpts)
+	for _, opt := range opts {
+		opt(options)
+	}
+	q.metadata = append(q.metadata, options.Metadata)
+	return nil
+}
 
 type callTestServer struct {
 	method      string
@@ -554,10 +672,14 @@ func testCall(ctx context.Context, timeout time.Duration, body []byte, signingKe
 func testCallTarget(ctx context.Context,
 	info *middleware.ContextInfoRequest,
 	target *mockTarget,
+	queue *mockQueue,
 ) func(string) ([]byte, error) {
 	return func(url string) (r []byte, err error) {
 		target.Endpoint = url
-		return execution.CallTarget(ctx, target, info)
+		if queue == nil {
+			return execution.CallTarget(ctx, target, info, nil)
+		}
+		return execution.CallTarget(ctx, target, info, queue)
 	}
 }
 
@@ -571,7 +693,7 @@ func testCallTargets(ctx context.Context,
 			t.Endpoint = urls[i]
 			targets[i] = t
 		}
-		return execution.CallTargets(ctx, targets, info)
+		return execution.CallTargets(ctx, targets, info, nil)
 	}
 }
 
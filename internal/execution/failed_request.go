@@ -0,0 +1,137 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river/rivertype"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// FailedRequest is a [TargetRequest] which was discarded after it reached its max attempts.
+type FailedRequest struct {
+	ID          int64
+	ExecutionID string
+	TargetID    string
+	Body        []byte
+	Attempts    uint32
+	MaxAttempts uint32
+	CreatedAt   time.Time
+	FailedAt    time.Time
+	Errors      []*FailedAttempt
+}
+
+type FailedAttempt struct {
+	Attempt uint32
+	At      time.Time
+	Error   string
+}
+
+type JobQueue interface {
+	SearchJobs(ctx context.Context, search *queue.JobSearch) ([]*rivertype.JobRow, error)
+	GetJob(ctx context.Context, id int64) (*rivertype.JobRow, error)
+	RetryJob(ctx context.Context, id int64) (*rivertype.JobRow, error)
+}
+
+// SearchFailedRequests returns the failed requests of the instance ordered by id.
+func SearchFailedRequests(ctx context.Context, q JobQueue, limit uint32, after int64) ([]*FailedRequest, error) {
+	if q == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "EXEC-n3hmx0b9ua", "Errors.Execution.QueueNotConfigured")
+	}
+	jobs, err := q.SearchJobs(ctx, &queue.JobSearch{
+		Kind:     new(TargetRequest).Kind(),
+		States:   []rivertype.JobState{rivertype.JobStateDiscarded},
+		Metadata: instanceMetadata(authz.GetInstance(ctx).InstanceID()),
+		Limit:    limit,
+		After:    after,
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-o4m0yz9t4j", "Errors.Internal")
+	}
+	requests := make([]*FailedRequest, len(jobs))
+	for i, job := range jobs {
+		requests[i], err = jobToFailedRequest(job)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// GetFailedRequest returns the failed request of the instance.
+func GetFailedRequest(ctx context.Context, q JobQueue, id int64) (*FailedRequest, error) {
+	job, err := getFailedJob(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	return jobToFailedRequest(job)
+}
+
+// RedeliverFailedRequest schedules the failed request to be delivered again.
+// The request is delivered once, if it fails again it's discarded.
+func RedeliverFailedRequest(ctx context.Context, q JobQueue, id int64) error {
+	if _, err := getFailedJob(ctx, q, id); err != nil {
+		return err
+	}
+	if _, err := q.RetryJob(ctx, id); err != nil {
+		return zerrors.ThrowInternal(err, "EXEC-wc2c1s8p5z", "Errors.Internal")
+	}
+	return nil
+}
+
+func getFailedJob(ctx context.Context, q JobQueue, id int64) (*rivertype.JobRow, error) {
+	if q == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "EXEC-fqb1t2y3sx", "Errors.Execution.QueueNotConfigured")
+	}
+	job, err := q.GetJob(ctx, id)
+	if errors.Is(err, rivertype.ErrNotFound) {
+		return nil, zerrors.ThrowNotFound(err, "EXEC-1ys38zfx2m", "Errors.Execution.FailedRequestNotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-lm4q8pbk2w", "Errors.Internal")
+	}
+	metadata := new(jobMetadata)
+	if err := json.Unmarshal(job.Metadata, metadata); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-5ah0bq2n1c", "Errors.Internal")
+	}
+	// jobs of other instances and jobs which are still retried are not exposed
+	if job.Kind != new(TargetRequest).Kind() ||
+		job.State != rivertype.JobStateDiscarded ||
+		metadata.InstanceID != authz.GetInstance(ctx).InstanceID() {
+		return nil, zerrors.ThrowNotFound(nil, "EXEC-7d6r3gq0bn", "Errors.Execution.FailedRequestNotFound")
+	}
+	return job, nil
+}
+
+func jobToFailedRequest(job *rivertype.JobRow) (*FailedRequest, error) {
+	args := new(TargetRequest)
+	if err := json.Unmarshal(job.EncodedArgs, args); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-b9ph3f3vnu", "Errors.Internal")
+	}
+	request := &FailedRequest{
+		ID:          job.ID,
+		ExecutionID: args.ExecutionID,
+		TargetID:    args.TargetID,
+		Body:        args.Body,
+		Attempts:    uint32(job.Attempt),
+		MaxAttempts: uint32(job.MaxAttempts),
+		CreatedAt:   job.CreatedAt,
+		Errors:      make([]*FailedAttempt, len(job.Errors)),
+	}
+	if job.FinalizedAt != nil {
+		request.FailedAt = *job.FinalizedAt
+	}
+	for i, attemptErr := range job.Errors {
+		request.Errors[i] = &FailedAttempt{
+			Attempt: uint32(attemptErr.Attempt),
+			At:      attemptErr.At,
+			Error:   attemptErr.Error,
+		}
+	}
+	return request, nil
+}
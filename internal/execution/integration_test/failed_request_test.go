@@ -0,0 +1,136 @@
+//go:build integration
+
+package execution_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/postgres"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// TestFailedRequests_queue inserts a request through the river client of the queue
+// and reads it back as failed request after its only attempt failed.
+func TestFailedRequests_queue(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer endpoint.Close()
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	target := &query.Target{
+		ObjectDetails: domain.ObjectDetails{ID: "target-" + suffix},
+		TargetType:    domain.TargetTypeAsync,
+		Endpoint:      endpoint.URL,
+		Timeout:       time.Second,
+		RetryPolicy:   domain.TargetRetryPolicy{MaxAttempts: 1},
+	}
+
+	jobs := queue.NewWithConfig(connectDB(t), &queue.Config{DiscardedJobRetention: time.Hour})
+	require.NoError(t, jobs.ExecuteMigrations(ctx))
+	jobs.AddWorkers(execution.NewWorker(execution.WorkerConfig{Workers: 1}, &targetQueries{target: target}))
+	require.NoError(t, jobs.Start(ctx))
+	defer func() {
+		assert.NoError(t, jobs.Stop(context.Background()))
+	}()
+
+	instanceCtx := authz.WithInstanceID(ctx, "instance-"+suffix)
+	_, err := execution.CallTarget(instanceCtx, &asyncTarget{executionID: "request", target: target}, requestBody(`{"request":"values"}`), jobs)
+	require.NoError(t, err)
+
+	var failed []*execution.FailedRequest
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		failed, err = execution.SearchFailedRequests(instanceCtx, jobs, 10, 0)
+		require.NoError(tt, err)
+		require.Len(tt, failed, 1)
+	}, 30*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "request", failed[0].ExecutionID)
+	assert.Equal(t, target.ID, failed[0].TargetID)
+	assert.Equal(t, []byte(`{"request":"values"}`), failed[0].Body)
+	assert.Equal(t, uint32(1), failed[0].Attempts)
+	assert.Len(t, failed[0].Errors, 1)
+
+	got, err := execution.GetFailedRequest(instanceCtx, jobs, failed[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, failed[0], got)
+
+	// the request is not exposed to other instances
+	otherCtx := authz.WithInstanceID(ctx, "other-"+suffix)
+	others, err := execution.SearchFailedRequests(otherCtx, jobs, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, others)
+	_, err = execution.GetFailedRequest(otherCtx, jobs, failed[0].ID)
+	assert.True(t, zerrors.IsNotFound(err))
+
+	require.NoError(t, execution.RedeliverFailedRequest(instanceCtx, jobs, failed[0].ID))
+}
+
+// connectDB connects to the database of the integration tests, see internal/integration/config/postgres.yaml
+func connectDB(t *testing.T) *database.DB {
+	config := database.Config{}
+	config.SetConnector(&postgres.Config{
+		Host:         "localhost",
+		Port:         5432,
+		Database:     "zitadel",
+		MaxOpenConns: 5,
+		MaxIdleConns: 5,
+		User: postgres.User{
+			Username: "zitadel",
+			SSL:      postgres.SSL{Mode: "disable"},
+		},
+	})
+	client, err := database.Connect(config, false)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return client
+}
+
+type targetQueries struct {
+	target *query.Target
+}
+
+func (q *targetQueries) GetTargetByID(_ context.Context, id string) (*query.Target, error) {
+	if id != q.target.ID {
+		return nil, zerrors.ThrowNotFound(nil, "TEST-3m9xq", "Errors.Target.NotFound")
+	}
+	return q.target, nil
+}
+
+type asyncTarget struct {
+	executionID string
+	target      *query.Target
+}
+
+func (t *asyncTarget) GetExecutionID() string                   { return t.executionID }
+func (t *asyncTarget) GetTargetID() string                      { return t.target.ID }
+func (t *asyncTarget) IsInterruptOnError() bool                 { return false }
+func (t *asyncTarget) GetEndpoint() string                      { return t.target.Endpoint }
+func (t *asyncTarget) GetTargetType() domain.TargetType         { return t.target.TargetType }
+func (t *asyncTarget) GetTimeout() time.Duration                { return t.target.Timeout }
+func (t *asyncTarget) GetSigningKey() string                    { return t.target.SigningKey }
+func (t *asyncTarget) GetRetryPolicy() domain.TargetRetryPolicy { return t.target.RetryPolicy }
+
+type requestBody []byte
+
+func (b requestBody) GetHTTPRequestBody() []byte {
+	return b
+}
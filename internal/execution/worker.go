@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	QueueName = "execution"
+
+	DefaultMaxAttempts    uint8 = 10
+	DefaultInitialBackoff       = time.Second
+	DefaultMaxBackoff           = time.Hour
+)
+
+// TargetRequest is the job to deliver a request to an async or webhook target.
+// The target is queried when the job is worked, so changes to the endpoint or the signing key are respected on retries.
+type TargetRequest struct {
+	InstanceID  string                   `json:"instanceID"`
+	ExecutionID string                   `json:"executionID"`
+	TargetID    string                   `json:"targetID"`
+	Body        []byte                   `json:"body"`
+	RetryPolicy domain.TargetRetryPolicy `json:"retryPolicy"`
+}
+
+func (*TargetRequest) Kind() string {
+	return "execution_target_request"
+}
+
+// InsertOpts implements [river.JobArgsWithInsertOpts]
+// to set the max attempts of the target.
+// River ignores the metadata of the job args, so it's passed on insert, see [enqueue].
+func (r *TargetRequest) InsertOpts() river.InsertOpts {
+	maxAttempts := r.RetryPolicy.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return river.InsertOpts{
+		Queue:       QueueName,
+		MaxAttempts: int(maxAttempts),
+	}
+}
+
+type jobMetadata struct {
+	InstanceID string `json:"instanceID"`
+}
+
+func instanceMetadata(instanceID string) []byte {
+	// marshalling a struct of strings does not fail
+	metadata, _ := json.Marshal(&jobMetadata{InstanceID: instanceID})
+	return metadata
+}
+
+// backoff calculates the exponential backoff for the given attempt, starting with 1
+func (r *TargetRequest) backoff(attempt int) time.Duration {
+	initialBackoff, maxBackoff := r.RetryPolicy.InitialBackoff, r.RetryPolicy.MaxBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = DefaultInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	backoff := float64(initialBackoff) * math.Pow(2, float64(attempt-1))
+	if backoff > float64(maxBackoff) {
+		return maxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// enqueue inserts the request with the metadata used to filter the failed requests by instance
+func enqueue(ctx context.Context, q Queue, target Target, body []byte) error {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return q.Insert(ctx,
+		&TargetRequest{
+			InstanceID:  instanceID,
+			ExecutionID: target.GetExecutionID(),
+			TargetID:    target.GetTargetID(),
+			Body:        body,
+			RetryPolicy: target.GetRetryPolicy(),
+		},
+		queue.WithMetadata(instanceMetadata(instanceID)),
+	)
+}
+
+type WorkerConfig struct {
+	// Workers is the amount of requests delivered in parallel
+	Workers uint8
+}
+
+type TargetQueries interface {
+	GetTargetByID(ctx context.Context, id string) (*query.Target, error)
+}
+
+var _ river.Worker[*TargetRequest] = (*Worker)(nil)
+
+// Worker delivers the [TargetRequest]s from the queue
+type Worker struct {
+	river.WorkerDefaults[*TargetRequest]
+
+	config  WorkerConfig
+	queries TargetQueries
+}
+
+func NewWorker(config WorkerConfig, queries TargetQueries) *Worker {
+	return &Worker{
+		config:  config,
+		queries: queries,
+	}
+}
+
+// Register implements [queue.Worker]
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: int(w.config.Workers),
+	}
+}
+
+// Work implements [river.Worker]
+func (w *Worker) Work(ctx context.Context, job *river.Job[*TargetRequest]) error {
+	ctx = authz.WithInstanceID(ctx, job.Args.InstanceID)
+	target, err := w.queries.GetTargetByID(ctx, job.Args.TargetID)
+	if err != nil {
+		// the target was removed in the meantime, there is no endpoint to deliver to anymore
+		if zerrors.IsNotFound(err) {
+			return river.JobCancel(err)
+		}
+		return err
+	}
+	_, err = Call(ctx, target.Endpoint, target.Timeout, job.Args.Body, target.SigningKey)
+	return err
+}
+
+// NextRetry implements [river.Worker]
+func (w *Worker) NextRetry(job *river.Job[*TargetRequest]) time.Time {
+	return time.Now().Add(job.Args.backoff(job.Attempt))
+}
+
+var _ queue.Worker = (*Worker)(nil)
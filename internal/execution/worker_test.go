@@ -0,0 +1,321 @@
+package execution_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestTargetRequest_InsertOpts(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *execution.TargetRequest
+		want    river.InsertOpts
+	}{
+		{
+			name: "default max attempts",
+			request: &execution.TargetRequest{
+				InstanceID: "instance",
+			},
+			want: river.InsertOpts{
+				Queue:       execution.QueueName,
+				MaxAttempts: int(execution.DefaultMaxAttempts),
+			},
+		},
+		{
+			name: "max attempts of target",
+			request: &execution.TargetRequest{
+				InstanceID:  "instance",
+				RetryPolicy: domain.TargetRetryPolicy{MaxAttempts: 3},
+			},
+			want: river.InsertOpts{
+				Queue:       execution.QueueName,
+				MaxAttempts: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.request.InsertOpts())
+		})
+	}
+}
+
+func TestWorker_NextRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		policy  domain.TargetRetryPolicy
+		want    time.Duration
+	}{
+		{
+			name:    "first attempt, default",
+			attempt: 1,
+			want:    execution.DefaultInitialBackoff,
+		},
+		{
+			name:    "third attempt, default",
+			attempt: 3,
+			want:    4 * execution.DefaultInitialBackoff,
+		},
+		{
+			name:    "exceeds default max",
+			attempt: 20,
+			want:    execution.DefaultMaxBackoff,
+		},
+		{
+			name:    "second attempt, policy",
+			attempt: 2,
+			policy: domain.TargetRetryPolicy{
+				InitialBackoff: 10 * time.Second,
+				MaxBackoff:     time.Minute,
+			},
+			want: 20 * time.Second,
+		},
+		{
+			name:    "exceeds policy max",
+			attempt: 5,
+			policy: domain.TargetRetryPolicy{
+				InitialBackoff: 10 * time.Second,
+				MaxBackoff:     time.Minute,
+			},
+			want: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := execution.NewWorker(execution.WorkerConfig{}, nil)
+			before := time.Now()
+			next := w.NextRetry(&river.Job[*execution.TargetRequest]{
+				JobRow: &rivertype.JobRow{Attempt: tt.attempt},
+				Args:   &execution.TargetRequest{RetryPolicy: tt.policy},
+			})
+			assert.WithinRange(t, next, before.Add(tt.want), time.Now().Add(tt.want))
+		})
+	}
+}
+
+func TestWorker_Work(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries *mockTargetQueries
+		server  *callTestServer
+		wantErr func(error) bool
+	}{
+		{
+			name: "target removed, cancel",
+			queries: &mockTargetQueries{
+				err: zerrors.ThrowNotFound(nil, "QUERY-hj5oaniyrz", "Errors.Target.NotFound"),
+			},
+			server: &callTestServer{},
+			wantErr: func(err error) bool {
+				return errors.Is(err, new(river.JobCancelError))
+			},
+		},
+		{
+			name:    "query failed",
+			queries: &mockTargetQueries{err: zerrors.ThrowInternal(nil, "QUERY-5qhc19sc49", "Errors.Internal")},
+			server:  &callTestServer{},
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name: "call failed",
+			queries: &mockTargetQueries{
+				target: &query.Target{Timeout: time.Minute},
+			},
+			server: &callTestServer{
+				method:     http.MethodPost,
+				expectBody: []byte(`{"request":"values"}`),
+				statusCode: http.StatusInternalServerError,
+			},
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "ok",
+			queries: &mockTargetQueries{
+				target: &query.Target{Timeout: time.Minute, SigningKey: "signingkey"},
+			},
+			server: &callTestServer{
+				method:      http.MethodPost,
+				expectBody:  []byte(`{"request":"values"}`),
+				statusCode:  http.StatusOK,
+				respondBody: []byte(`{"response":"values"}`),
+				signingKey:  "signingkey",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, closeServer := listen(t, tt.server)
+			defer closeServer()
+			if tt.queries.target != nil {
+				tt.queries.target.Endpoint = url
+			}
+			w := execution.NewWorker(execution.WorkerConfig{}, tt.queries)
+			err := w.Work(context.Background(), &river.Job[*execution.TargetRequest]{
+				JobRow: &rivertype.JobRow{Attempt: 1},
+				Args: &execution.TargetRequest{
+					InstanceID: "instance",
+					TargetID:   "target",
+					Body:       []byte(`{"request":"values"}`),
+				},
+			})
+			assert.Equal(t, "instance", tt.queries.instanceID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+type mockTargetQueries struct {
+	target     *query.Target
+	err        error
+	instanceID string
+}
+
+func (q *mockTargetQueries) GetTargetByID(ctx context.Context, _ string) (*query.Target, error) {
+	q.instanceID = authz.GetInstance(ctx).InstanceID()
+	return q.target, q.err
+}
+
+func TestFailedRequests(t *testing.T) {
+	finalized := time.Now()
+	discarded := func(id int64, instanceID string) *rivertype.JobRow {
+		args, err := json.Marshal(&execution.TargetRequest{
+			InstanceID:  instanceID,
+			ExecutionID: "request",
+			TargetID:    "target",
+			Body:        []byte(`{"request":"values"}`),
+		})
+		require.NoError(t, err)
+		return &rivertype.JobRow{
+			ID:          id,
+			Kind:        new(execution.TargetRequest).Kind(),
+			State:       rivertype.JobStateDiscarded,
+			Attempt:     2,
+			MaxAttempts: 2,
+			CreatedAt:   finalized.Add(-time.Minute),
+			FinalizedAt: &finalized,
+			EncodedArgs: args,
+			Metadata:    []byte(`{"instanceID": "` + instanceID + `"}`),
+			Errors: []rivertype.AttemptError{
+				{Attempt: 1, At: finalized.Add(-time.Minute), Error: "failed"},
+				{Attempt: 2, At: finalized, Error: "failed again"},
+			},
+		}
+	}
+	want := &execution.FailedRequest{
+		ID:          1,
+		ExecutionID: "request",
+		TargetID:    "target",
+		Body:        []byte(`{"request":"values"}`),
+		Attempts:    2,
+		MaxAttempts: 2,
+		CreatedAt:   finalized.Add(-time.Minute),
+		FailedAt:    finalized,
+		Errors: []*execution.FailedAttempt{
+			{Attempt: 1, At: finalized.Add(-time.Minute), Error: "failed"},
+			{Attempt: 2, At: finalized, Error: "failed again"},
+		},
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+
+	t.Run("search", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: discarded(1, "instance")}}
+		got, err := execution.SearchFailedRequests(ctx, q, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []*execution.FailedRequest{want}, got)
+		assert.Equal(t, &queue.JobSearch{
+			Kind:     new(execution.TargetRequest).Kind(),
+			States:   []rivertype.JobState{rivertype.JobStateDiscarded},
+			Metadata: []byte(`{"instanceID":"instance"}`),
+			Limit:    10,
+		}, q.search)
+	})
+	t.Run("search, no queue", func(t *testing.T) {
+		_, err := execution.SearchFailedRequests(ctx, nil, 10, 0)
+		assert.True(t, zerrors.IsPreconditionFailed(err))
+	})
+	t.Run("get", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: discarded(1, "instance")}}
+		got, err := execution.GetFailedRequest(ctx, q, 1)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+	t.Run("get, other instance", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: discarded(1, "other")}}
+		_, err := execution.GetFailedRequest(ctx, q, 1)
+		assert.True(t, zerrors.IsNotFound(err))
+	})
+	t.Run("get, not discarded", func(t *testing.T) {
+		job := discarded(1, "instance")
+		job.State = rivertype.JobStateRetryable
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: job}}
+		_, err := execution.GetFailedRequest(ctx, q, 1)
+		assert.True(t, zerrors.IsNotFound(err))
+	})
+	t.Run("get, not found", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{}}
+		_, err := execution.GetFailedRequest(ctx, q, 1)
+		assert.True(t, zerrors.IsNotFound(err))
+	})
+	t.Run("redeliver", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: discarded(1, "instance")}}
+		require.NoError(t, execution.RedeliverFailedRequest(ctx, q, 1))
+		assert.Equal(t, []int64{1}, q.retried)
+	})
+	t.Run("redeliver, other instance", func(t *testing.T) {
+		q := &mockJobQueue{jobs: map[int64]*rivertype.JobRow{1: discarded(1, "other")}}
+		err := execution.RedeliverFailedRequest(ctx, q, 1)
+		assert.True(t, zerrors.IsNotFound(err))
+		assert.Empty(t, q.retried)
+	})
+}
+
+var _ execution.JobQueue = (*mockJobQueue)(nil)
+
+type mockJobQueue struct {
+	jobs    map[int64]*rivertype.JobRow
+	search  *queue.JobSearch
+	retried []int64
+}
+
+func (q *mockJobQueue) SearchJobs(_ context.Context, search *queue.JobSearch) ([]*rivertype.JobRow, error) {
+	q.search = search
+	jobs := make([]*rivertype.JobRow, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (q *mockJobQueue) GetJob(_ context.Context, id int64) (*rivertype.JobRow, error) {
+	job, ok := q.jobs[id]
+	if !ok {
+		return nil, rivertype.ErrNotFound
+	}
+	return job, nil
+}
+
+func (q *mockJobQueue) RetryJob(_ context.Context, id int64) (*rivertype.JobRow, error) {
+	q.retried = append(q.retried, id)
+	return q.jobs[id], nil
+}
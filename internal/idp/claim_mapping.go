@@ -0,0 +1,534 @@
+package idp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ClaimMapper evaluates the expressions of a [domain.IDPClaimMapping] against the claims of a federated user.
+type ClaimMapper struct {
+	preferredUsername *jsonPath
+	firstName         *jsonPath
+	lastName          *jsonPath
+	displayName       *jsonPath
+	nickName          *jsonPath
+	email             *jsonPath
+	emailVerified     *jsonPath
+	phone             *jsonPath
+	phoneVerified     *jsonPath
+	preferredLanguage *jsonPath
+	avatarURL         *jsonPath
+	profile           *jsonPath
+	metadata          map[string]*jsonPath
+}
+
+// NewClaimMapper parses the expressions of the mapping.
+// It returns nil if the mapping does not define any expression.
+func NewClaimMapper(mapping *domain.IDPClaimMapping) (*ClaimMapper, error) {
+	if mapping.IsZero() {
+		return nil, nil
+	}
+	m := &ClaimMapper{
+		metadata: make(map[string]*jsonPath, len(mapping.Metadata)),
+	}
+	for _, attribute := range []struct {
+		path       **jsonPath
+		expression string
+	}{
+		{&m.preferredUsername, mapping.PreferredUsername},
+		{&m.firstName, mapping.FirstName},
+		{&m.lastName, mapping.LastName},
+		{&m.displayName, mapping.DisplayName},
+		{&m.nickName, mapping.NickName},
+		{&m.email, mapping.Email},
+		{&m.emailVerified, mapping.EmailVerified},
+		{&m.phone, mapping.Phone},
+		{&m.phoneVerified, mapping.PhoneVerified},
+		{&m.preferredLanguage, mapping.PreferredLanguage},
+		{&m.avatarURL, mapping.AvatarURL},
+		{&m.profile, mapping.Profile},
+	} {
+		if attribute.expression == "" {
+			continue
+		}
+		path, err := parseJSONPath(attribute.expression)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid")
+		}
+		*attribute.path = path
+	}
+	for key, expression := range mapping.Metadata {
+		if key == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "IDP-Vb7sk", "Errors.IDPConfig.ClaimMappingInvalid")
+		}
+		path, err := parseJSONPath(expression)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "IDP-Rz4fn", "Errors.IDPConfig.ClaimMappingInvalid")
+		}
+		m.metadata[key] = path
+	}
+	return m, nil
+}
+
+// ValidateClaimMapping checks that all expressions of the mapping can be parsed.
+func ValidateClaimMapping(mapping *domain.IDPClaimMapping) error {
+	_, err := NewClaimMapper(mapping)
+	return err
+}
+
+// Map evaluates the expressions against the claims and returns a [MappedUser].
+// The claims are marshalled to JSON before the evaluation,
+// attributes not found in the claims are taken from the passed user.
+// If the mapper is nil the user is returned unchanged.
+func (m *ClaimMapper) Map(user User, claims any) (User, error) {
+	if m == nil {
+		return user, nil
+	}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	var document any
+	if err = json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	mapped := &MappedUser{
+		User:              user,
+		preferredUsername: m.preferredUsername.evaluateString(document),
+		firstName:         m.firstName.evaluateString(document),
+		lastName:          m.lastName.evaluateString(document),
+		displayName:       m.displayName.evaluateString(document),
+		nickName:          m.nickName.evaluateString(document),
+		email:             m.email.evaluateString(document),
+		emailVerified:     m.emailVerified.evaluateBool(document),
+		phone:             m.phone.evaluateString(document),
+		phoneVerified:     m.phoneVerified.evaluateBool(document),
+		preferredLanguage: m.preferredLanguage.evaluateString(document),
+		avatarURL:         m.avatarURL.evaluateString(document),
+		profile:           m.profile.evaluateString(document),
+	}
+	for key, path := range m.metadata {
+		value := path.evaluateString(document)
+		if value == nil {
+			continue
+		}
+		if mapped.metadata == nil {
+			mapped.metadata = make(map[string][]byte, len(m.metadata))
+		}
+		mapped.metadata[key] = []byte(*value)
+	}
+	return mapped, nil
+}
+
+var (
+	_ User             = (*MappedUser)(nil)
+	_ UserWithMetadata = (*MappedUser)(nil)
+)
+
+// MappedUser is the [User] returned by the [ClaimMapper].
+// Attributes which were not mapped are taken from the wrapped user.
+type MappedUser struct {
+	User
+	preferredUsername *string
+	firstName         *string
+	lastName          *string
+	displayName       *string
+	nickName          *string
+	email             *string
+	emailVerified     *bool
+	phone             *string
+	phoneVerified     *bool
+	preferredLanguage *string
+	avatarURL         *string
+	profile           *string
+	metadata          map[string][]byte
+}
+
+// MappedAttributesKey is the key of the [MappedAttributes] in the serialized information of a [MappedUser].
+const MappedAttributesKey = "zitadelMappedAttributes"
+
+// MappedAttributes are the attributes of a [MappedUser] after the claim mapping was applied.
+type MappedAttributes struct {
+	PreferredUsername string            `json:"preferredUsername,omitempty"`
+	FirstName         string            `json:"firstName,omitempty"`
+	LastName          string            `json:"lastName,omitempty"`
+	DisplayName       string            `json:"displayName,omitempty"`
+	NickName          string            `json:"nickName,omitempty"`
+	Email             string            `json:"email,omitempty"`
+	EmailVerified     bool              `json:"emailVerified,omitempty"`
+	Phone             string            `json:"phone,omitempty"`
+	PhoneVerified     bool              `json:"phoneVerified,omitempty"`
+	PreferredLanguage string            `json:"preferredLanguage,omitempty"`
+	AvatarURL         string            `json:"avatarURL,omitempty"`
+	Profile           string            `json:"profile,omitempty"`
+	Metadata          map[string][]byte `json:"metadata,omitempty"`
+}
+
+// MarshalJSON returns the information of the wrapped user,
+// so the raw information received from the provider is kept,
+// extended by the [MappedAttributes] under the [MappedAttributesKey].
+// This way the mapped attributes are available after the user was persisted, e.g. in an intent.
+func (u *MappedUser) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(u.User)
+	if err != nil {
+		return nil, err
+	}
+	information := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(raw, &information); err != nil {
+		return nil, err
+	}
+	information[MappedAttributesKey], err = json.Marshal(u.attributes())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(information)
+}
+
+func (u *MappedUser) attributes() *MappedAttributes {
+	var preferredLanguage string
+	if lang := u.GetPreferredLanguage(); lang != language.Und {
+		preferredLanguage = lang.String()
+	}
+	return &MappedAttributes{
+		PreferredUsername: u.GetPreferredUsername(),
+		FirstName:         u.GetFirstName(),
+		LastName:          u.GetLastName(),
+		DisplayName:       u.GetDisplayName(),
+		NickName:          u.GetNickname(),
+		Email:             string(u.GetEmail()),
+		EmailVerified:     u.IsEmailVerified(),
+		Phone:             string(u.GetPhone()),
+		PhoneVerified:     u.IsPhoneVerified(),
+		PreferredLanguage: preferredLanguage,
+		AvatarURL:         u.GetAvatarURL(),
+		Profile:           u.GetProfile(),
+		Metadata:          u.GetMetadata(),
+	}
+}
+
+// MappedAttributesFromJSON returns the [MappedAttributes] of the serialized information of a user.
+// Nil is returned if the user was not mapped by a [ClaimMapper].
+func MappedAttributesFromJSON(data []byte) (*MappedAttributes, error) {
+	information := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &information); err != nil {
+		return nil, err
+	}
+	raw, ok := information[MappedAttributesKey]
+	if !ok {
+		return nil, nil
+	}
+	attributes := new(MappedAttributes)
+	if err := json.Unmarshal(raw, attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// GetFirstName is an implementation of the [User] interface.
+func (u *MappedUser) GetFirstName() string {
+	if u.firstName != nil {
+		return *u.firstName
+	}
+	return u.User.GetFirstName()
+}
+
+// GetLastName is an implementation of the [User] interface.
+func (u *MappedUser) GetLastName() string {
+	if u.lastName != nil {
+		return *u.lastName
+	}
+	return u.User.GetLastName()
+}
+
+// GetDisplayName is an implementation of the [User] interface.
+func (u *MappedUser) GetDisplayName() string {
+	if u.displayName != nil {
+		return *u.displayName
+	}
+	return u.User.GetDisplayName()
+}
+
+// GetNickname is an implementation of the [User] interface.
+func (u *MappedUser) GetNickname() string {
+	if u.nickName != nil {
+		return *u.nickName
+	}
+	return u.User.GetNickname()
+}
+
+// GetPreferredUsername is an implementation of the [User] interface.
+func (u *MappedUser) GetPreferredUsername() string {
+	if u.preferredUsername != nil {
+		return *u.preferredUsername
+	}
+	return u.User.GetPreferredUsername()
+}
+
+// GetEmail is an implementation of the [User] interface.
+func (u *MappedUser) GetEmail() domain.EmailAddress {
+	if u.email != nil {
+		return domain.EmailAddress(*u.email)
+	}
+	return u.User.GetEmail()
+}
+
+// IsEmailVerified is an implementation of the [User] interface.
+func (u *MappedUser) IsEmailVerified() bool {
+	if u.emailVerified != nil {
+		return *u.emailVerified
+	}
+	return u.User.IsEmailVerified()
+}
+
+// GetPhone is an implementation of the [User] interface.
+func (u *MappedUser) GetPhone() domain.PhoneNumber {
+	if u.phone != nil {
+		return domain.PhoneNumber(*u.phone)
+	}
+	return u.User.GetPhone()
+}
+
+// IsPhoneVerified is an implementation of the [User] interface.
+func (u *MappedUser) IsPhoneVerified() bool {
+	if u.phoneVerified != nil {
+		return *u.phoneVerified
+	}
+	return u.User.IsPhoneVerified()
+}
+
+// GetPreferredLanguage is an implementation of the [User] interface.
+func (u *MappedUser) GetPreferredLanguage() language.Tag {
+	if u.preferredLanguage != nil {
+		return language.Make(*u.preferredLanguage)
+	}
+	return u.User.GetPreferredLanguage()
+}
+
+// GetAvatarURL is an implementation of the [User] interface.
+func (u *MappedUser) GetAvatarURL() string {
+	if u.avatarURL != nil {
+		return *u.avatarURL
+	}
+	return u.User.GetAvatarURL()
+}
+
+// GetProfile is an implementation of the [User] interface.
+func (u *MappedUser) GetProfile() string {
+	if u.profile != nil {
+		return *u.profile
+	}
+	return u.User.GetProfile()
+}
+
+// GetMetadata is an implementation of the [UserWithMetadata] interface.
+func (u *MappedUser) GetMetadata() map[string][]byte {
+	return u.metadata
+}
+
+// jsonPath is a parsed expression of a subset of JSONPath.
+// Only the root `$` followed by member names (`.name` or `['name']`) and array indexes (`[0]`, `[-1]`) are supported,
+// as every expression must select a single value.
+// Other JSONPath syntax (wildcards, recursive descent, slices, unions, filters and script expressions) is rejected.
+type jsonPath struct {
+	segments []pathSegment
+}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func parseJSONPath(expression string) (*jsonPath, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(expression), "$")
+	if !ok {
+		return nil, fmt.Errorf("expression %q must start with $", expression)
+	}
+	path := new(jsonPath)
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("expression %q: recursive descent is not supported", expression)
+			}
+			if i := strings.IndexAny(rest[:end], unsupportedMemberChars); i != -1 {
+				return nil, fmt.Errorf("expression %q: unsupported character %q in member name, use the bracket notation (['name']) for special characters", expression, rest[i])
+			}
+			path.segments = append(path.segments, pathSegment{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			segment, remaining, err := parseBracket(rest)
+			if err != nil {
+				return nil, fmt.Errorf("expression %q: %w", expression, err)
+			}
+			path.segments = append(path.segments, segment)
+			rest = remaining
+		default:
+			return nil, fmt.Errorf("expression %q contains unexpected character %q", expression, rest[0])
+		}
+	}
+	return path, nil
+}
+
+// unsupportedMemberChars are not allowed in member names of the dot notation,
+// as they are used by the unsupported JSONPath syntax (e.g. `$.*` or `$.items[?(@.primary)]`).
+const unsupportedMemberChars = "*?@()$,:'\" \t"
+
+// parseBracket parses a quoted member name or an array index enclosed in brackets
+func parseBracket(rest string) (pathSegment, string, error) {
+	if len(rest) > 1 && (rest[1] == '\'' || rest[1] == '"') {
+		end := strings.IndexByte(rest[2:], rest[1])
+		if end == -1 {
+			return pathSegment{}, "", errors.New("unterminated member name")
+		}
+		key := rest[2 : 2+end]
+		rest = rest[2+end+1:]
+		if strings.HasPrefix(rest, ",") {
+			return pathSegment{}, "", errors.New("unions are not supported")
+		}
+		if !strings.HasPrefix(rest, "]") {
+			return pathSegment{}, "", errors.New("missing closing bracket")
+		}
+		return pathSegment{key: key}, rest[1:], nil
+	}
+	end := strings.IndexByte(rest, ']')
+	if end == -1 {
+		return pathSegment{}, "", errors.New("missing closing bracket")
+	}
+	selector := strings.TrimSpace(rest[1:end])
+	switch {
+	case selector == "*":
+		return pathSegment{}, "", errors.New("wildcards are not supported")
+	case strings.HasPrefix(selector, "?"), strings.HasPrefix(selector, "("):
+		return pathSegment{}, "", errors.New("filter and script expressions are not supported")
+	case strings.Contains(selector, ":"):
+		return pathSegment{}, "", errors.New("slices are not supported")
+	case strings.Contains(selector, ","):
+		return pathSegment{}, "", errors.New("unions are not supported")
+	}
+	index, err := strconv.Atoi(selector)
+	if err != nil {
+		return pathSegment{}, "", fmt.Errorf("invalid array index %q", rest[1:end])
+	}
+	return pathSegment{index: index, isIndex: true}, rest[end+1:], nil
+}
+
+// evaluate returns the value the path points to in the decoded JSON document
+func (p *jsonPath) evaluate(document any) (any, bool) {
+	if p == nil {
+		return nil, false
+	}
+	value := document
+	for _, segment := range p.segments {
+		switch v := value.(type) {
+		case map[string]any:
+			if segment.isIndex {
+				return nil, false
+			}
+			var ok bool
+			if value, ok = v[segment.key]; !ok {
+				return nil, false
+			}
+		case []any:
+			if !segment.isIndex {
+				return nil, false
+			}
+			index := segment.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+func (p *jsonPath) evaluateString(document any) *string {
+	value, ok := p.evaluate(document)
+	if !ok {
+		return nil
+	}
+	s, ok := stringValue(value)
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func (p *jsonPath) evaluateBool(document any) *bool {
+	value, ok := p.evaluate(document)
+	if !ok {
+		return nil
+	}
+	b, ok := boolValue(value)
+	if !ok {
+		return nil
+	}
+	return &b
+}
+
+// stringValue converts the value to a string,
+// the first element is used for arrays (e.g. multi valued SAML attributes)
+// and objects are returned as JSON.
+func stringValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case []any:
+		if len(v) == 0 {
+			return "", false
+		}
+		return stringValue(v[0])
+	case nil:
+		return "", false
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+}
+
+// boolValue converts the value to a bool,
+// strings are parsed (e.g. "true") and the first element is used for arrays.
+func boolValue(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	case float64:
+		return v != 0, true
+	case []any:
+		if len(v) == 0 {
+			return false, false
+		}
+		return boolValue(v[0])
+	default:
+		return false, false
+	}
+}
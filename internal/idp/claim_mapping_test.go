@@ -0,0 +1,240 @@
+package idp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestNewClaimMapper(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *domain.IDPClaimMapping
+		wantNil bool
+		wantErr error
+	}{
+		{
+			name:    "nil mapping",
+			mapping: nil,
+			wantNil: true,
+		},
+		{
+			name:    "empty mapping",
+			mapping: &domain.IDPClaimMapping{Metadata: map[string]string{}},
+			wantNil: true,
+		},
+		{
+			name: "valid expressions",
+			mapping: &domain.IDPClaimMapping{
+				FirstName:     "$.name.given",
+				Email:         "$['mail-address']",
+				EmailVerified: `$["verified"]`,
+				Phone:         "$.phones[0].number",
+				Metadata:      map[string]string{"department": "$.org.units[-1]"},
+			},
+		},
+		{
+			name:    "missing root",
+			mapping: &domain.IDPClaimMapping{FirstName: "name.given"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "empty member",
+			mapping: &domain.IDPClaimMapping{LastName: "$.name..family"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "unterminated bracket",
+			mapping: &domain.IDPClaimMapping{Email: "$.emails[0"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "invalid index",
+			mapping: &domain.IDPClaimMapping{Email: "$.emails[*]"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "wildcard member",
+			mapping: &domain.IDPClaimMapping{Email: "$.emails.*"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "filter expression",
+			mapping: &domain.IDPClaimMapping{Email: "$.emails[?(@.primary)].value"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "slice",
+			mapping: &domain.IDPClaimMapping{Email: "$.emails[0:1]"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "union",
+			mapping: &domain.IDPClaimMapping{Email: "$['mail','email']"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Jq3mx", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "empty metadata key",
+			mapping: &domain.IDPClaimMapping{Metadata: map[string]string{"": "$.org"}},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Vb7sk", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+		{
+			name:    "invalid metadata expression",
+			mapping: &domain.IDPClaimMapping{Metadata: map[string]string{"org": "org"}},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "IDP-Rz4fn", "Errors.IDPConfig.ClaimMappingInvalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewClaimMapper(tt.mapping)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
+
+func TestClaimMapper_Map(t *testing.T) {
+	claims := map[string]any{
+		"sub": "id",
+		"name": map[string]any{
+			"given":  "Given",
+			"family": "Family",
+		},
+		"mail-address":   "mail@example.com",
+		"verified":       "true",
+		"phones":         []any{map[string]any{"number": "+41791234567"}},
+		"phone_verified": 1,
+		"locale":         []string{"de-CH", "en"},
+		"employee":       12345,
+		"org":            map[string]any{"units": []any{"zitadel", "engineering"}},
+	}
+	tests := []struct {
+		name     string
+		mapping  *domain.IDPClaimMapping
+		want     func(t *testing.T, user User)
+		metadata map[string][]byte
+	}{
+		{
+			name:    "no mapping",
+			mapping: nil,
+			want: func(t *testing.T, user User) {
+				assert.Equal(t, &testUser{firstName: "first"}, user)
+			},
+		},
+		{
+			name: "mapped attributes",
+			mapping: &domain.IDPClaimMapping{
+				PreferredUsername: "$.employee",
+				FirstName:         "$.name.given",
+				LastName:          "$['name'].family",
+				DisplayName:       "$.name",
+				Email:             "$['mail-address']",
+				EmailVerified:     "$.verified",
+				Phone:             "$.phones[0].number",
+				PhoneVerified:     "$.phone_verified",
+				PreferredLanguage: "$.locale",
+				Metadata: map[string]string{
+					"unit":    "$.org.units[-1]",
+					"missing": "$.org.missing",
+				},
+			},
+			want: func(t *testing.T, user User) {
+				assert.Equal(t, "id", user.GetID())
+				assert.Equal(t, "12345", user.GetPreferredUsername())
+				assert.Equal(t, "Given", user.GetFirstName())
+				assert.Equal(t, "Family", user.GetLastName())
+				assert.Equal(t, `{"family":"Family","given":"Given"}`, user.GetDisplayName())
+				assert.Equal(t, domain.EmailAddress("mail@example.com"), user.GetEmail())
+				assert.True(t, user.IsEmailVerified())
+				assert.Equal(t, domain.PhoneNumber("+41791234567"), user.GetPhone())
+				assert.True(t, user.IsPhoneVerified())
+				assert.Equal(t, language.Make("de-CH"), user.GetPreferredLanguage())
+				assert.Equal(t, map[string][]byte{"unit": []byte("engineering")}, user.(UserWithMetadata).GetMetadata())
+			},
+		},
+		{
+			name: "fallback to user",
+			mapping: &domain.IDPClaimMapping{
+				FirstName:     "$.given_name",
+				EmailVerified: "$.name",
+				NickName:      "$.phones[1].number",
+			},
+			want: func(t *testing.T, user User) {
+				assert.Equal(t, "first", user.GetFirstName())
+				assert.False(t, user.IsEmailVerified())
+				assert.Equal(t, "", user.GetNickname())
+				assert.Nil(t, user.(UserWithMetadata).GetMetadata())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper, err := NewClaimMapper(tt.mapping)
+			require.NoError(t, err)
+			user, err := mapper.Map(&testUser{firstName: "first"}, claims)
+			require.NoError(t, err)
+			tt.want(t, user)
+		})
+	}
+}
+
+func TestMappedUser_MarshalJSON(t *testing.T) {
+	mapper, err := NewClaimMapper(&domain.IDPClaimMapping{
+		FirstName: "$.given",
+		Metadata:  map[string]string{"unit": "$.unit"},
+	})
+	require.NoError(t, err)
+	user, err := mapper.Map(&testUser{firstName: "first"}, map[string]any{"given": "Given", "unit": "engineering"})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(user)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"id","firstName":"first","zitadelMappedAttributes":{"firstName":"Given","metadata":{"unit":"ZW5naW5lZXJpbmc="}}}`, string(data))
+
+	attributes, err := MappedAttributesFromJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, &MappedAttributes{
+		FirstName: "Given",
+		Metadata:  map[string][]byte{"unit": []byte("engineering")},
+	}, attributes)
+}
+
+func TestMappedAttributesFromJSON_notMapped(t *testing.T) {
+	data, err := json.Marshal(&testUser{firstName: "first"})
+	require.NoError(t, err)
+	attributes, err := MappedAttributesFromJSON(data)
+	require.NoError(t, err)
+	assert.Nil(t, attributes)
+}
+
+type testUser struct {
+	firstName string
+}
+
+func (u *testUser) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"id": u.GetID(), "firstName": u.firstName})
+}
+
+func (u *testUser) GetID() string                      { return "id" }
+func (u *testUser) GetFirstName() string               { return u.firstName }
+func (u *testUser) GetLastName() string                { return "" }
+func (u *testUser) GetDisplayName() string             { return "" }
+func (u *testUser) GetNickname() string                { return "" }
+func (u *testUser) GetPreferredUsername() string       { return "" }
+func (u *testUser) GetEmail() domain.EmailAddress      { return "" }
+func (u *testUser) IsEmailVerified() bool              { return false }
+func (u *testUser) GetPhone() domain.PhoneNumber       { return "" }
+func (u *testUser) IsPhoneVerified() bool              { return false }
+func (u *testUser) GetPreferredLanguage() language.Tag { return language.Und }
+func (u *testUser) GetAvatarURL() string               { return "" }
+func (u *testUser) GetProfile() string                 { return "" }
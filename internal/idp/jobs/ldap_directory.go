@@ -0,0 +1,160 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/riverqueue/river"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LDAPDirectorySync is the periodic job to synchronize the users linked to LDAP identity providers with their directory.
+type LDAPDirectorySync struct{}
+
+func (*LDAPDirectorySync) Kind() string {
+	return "idp_ldap_directory_sync"
+}
+
+// InsertOpts implements [river.JobArgsWithInsertOpts]
+func (*LDAPDirectorySync) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: QueueName,
+		// the next run synchronizes the users anyway
+		MaxAttempts: 1,
+	}
+}
+
+type LDAPDirectoryQueries interface {
+	LDAPIDPsWithDirectorySync(ctx context.Context) ([]*query.LDAPIDPWithDirectorySync, error)
+	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, permissionCheck domain.PermissionCheck) (*query.IDPUserLinks, error)
+}
+
+type LDAPDirectoryCommands interface {
+	GetProvider(ctx context.Context, idpID, idpCallback, samlRootURL string) (idp.Provider, error)
+	SyncLDAPUserGrants(ctx context.Context, userID, orgID string, mapping *domain.LDAPGroupMapping, groups []string) error
+	DeactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error)
+	ReactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error)
+}
+
+// ldapDirectory is implemented by the [ldap.Provider]
+type ldapDirectory interface {
+	SearchUsers(ctx context.Context) ([]*ldap.User, error)
+}
+
+var _ river.Worker[*LDAPDirectorySync] = (*LDAPDirectoryWorker)(nil)
+
+// LDAPDirectoryWorker synchronizes the users linked to LDAP identity providers with the directory:
+// users removed from the directory are deactivated, users returning to it are reactivated
+// and the grants of all users in the directory are synchronized with their groups.
+// Only users deactivated by the sync of the same identity provider are reactivated.
+type LDAPDirectoryWorker struct {
+	river.WorkerDefaults[*LDAPDirectorySync]
+
+	queries  LDAPDirectoryQueries
+	commands LDAPDirectoryCommands
+}
+
+func NewLDAPDirectoryWorker(queries LDAPDirectoryQueries, commands LDAPDirectoryCommands) *LDAPDirectoryWorker {
+	return &LDAPDirectoryWorker{
+		queries:  queries,
+		commands: commands,
+	}
+}
+
+// Register implements [queue.Worker]
+func (w *LDAPDirectoryWorker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: 1,
+	}
+}
+
+// Work implements [river.Worker]
+// A failed sync of an identity provider or a user does not prevent the others from being synchronized.
+func (w *LDAPDirectoryWorker) Work(ctx context.Context, _ *river.Job[*LDAPDirectorySync]) error {
+	idps, err := w.queries.LDAPIDPsWithDirectorySync(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, idpConfig := range idps {
+		if err := w.sync(authz.WithInstanceID(ctx, idpConfig.InstanceID), idpConfig); err != nil {
+			logging.WithFields("instance", idpConfig.InstanceID, "idp", idpConfig.ID).WithError(err).Warn("unable to sync ldap directory")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *LDAPDirectoryWorker) sync(ctx context.Context, idpConfig *query.LDAPIDPWithDirectorySync) error {
+	provider, err := w.commands.GetProvider(ctx, idpConfig.ID, "", "")
+	if err != nil {
+		return err
+	}
+	directory, ok := provider.(ldapDirectory)
+	if !ok {
+		return zerrors.ThrowPreconditionFailed(nil, "JOBS-Wd3nb", "Errors.IDPConfig.NotExisting")
+	}
+	users, err := directory.SearchUsers(ctx)
+	if err != nil {
+		return err
+	}
+	directoryUsers := make(map[string]*ldap.User, len(users))
+	for _, user := range users {
+		if user.ID != "" {
+			directoryUsers[user.ID] = user
+		}
+	}
+	// an empty directory is most likely a misconfiguration, which must not deactivate all users
+	if len(directoryUsers) == 0 {
+		logging.WithFields("instance", idpConfig.InstanceID, "idp", idpConfig.ID).Warn("ldap directory returned no users, sync skipped")
+		return nil
+	}
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpConfig.ID)
+	if err != nil {
+		return err
+	}
+	links, err := w.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery}}, nil)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, link := range links.Links {
+		if err := w.syncUser(ctx, idpConfig, link, directoryUsers[link.ProvidedUserID]); err != nil {
+			logging.WithFields("instance", idpConfig.InstanceID, "idp", idpConfig.ID, "user", link.UserID).WithError(err).Warn("unable to sync ldap user")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *LDAPDirectoryWorker) syncUser(ctx context.Context, idpConfig *query.LDAPIDPWithDirectorySync, link *query.IDPUserLink, user *ldap.User) error {
+	if user == nil {
+		_, err := w.commands.DeactivateLDAPDirectoryUser(ctx, link.UserID, link.ResourceOwner, idpConfig.ID)
+		// users which are already inactive or not yet initialized are skipped
+		if zerrors.IsPreconditionFailed(err) || zerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := w.commands.ReactivateLDAPDirectoryUser(ctx, link.UserID, link.ResourceOwner, idpConfig.ID)
+	// users which are not inactive or were deactivated otherwise (e.g. by an administrator) are skipped
+	if err != nil && !zerrors.IsPreconditionFailed(err) {
+		return err
+	}
+	var orgID string
+	if idpConfig.OwnerType == domain.IdentityProviderTypeOrg {
+		orgID = idpConfig.ResourceOwner
+	}
+	return w.commands.SyncLDAPUserGrants(ctx, link.UserID, orgID, idpConfig.GroupMapping, user.Groups)
+}
+
+var _ queue.Worker = (*LDAPDirectoryWorker)(nil)
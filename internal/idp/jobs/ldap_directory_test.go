@@ -0,0 +1,270 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/jobs"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestLDAPDirectoryWorker_Work(t *testing.T) {
+	mapping := &domain.LDAPGroupMapping{
+		GroupAttribute: "memberOf",
+		Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project1", RoleKeys: []string{"admin"}}},
+	}
+	searchErr := errors.New("connection refused")
+	tests := []struct {
+		name     string
+		queries  *mockLDAPDirectoryQueries
+		commands *mockLDAPDirectoryCommands
+		want     []string
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "query failed",
+			queries:  &mockLDAPDirectoryQueries{err: zerrors.ThrowInternal(nil, "QUERY-Rz6tj", "Errors.Internal")},
+			commands: &mockLDAPDirectoryCommands{},
+			wantErr:  zerrors.IsInternal,
+		},
+		{
+			name:     "no idps",
+			queries:  &mockLDAPDirectoryQueries{},
+			commands: &mockLDAPDirectoryCommands{},
+		},
+		{
+			name: "users synced and deactivated",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem, GroupMapping: mapping},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance1": {
+						{IDPID: "idp1", UserID: "user1", ProvidedUserID: "alice", ResourceOwner: "org1"},
+						{IDPID: "idp1", UserID: "user2", ProvidedUserID: "bob", ResourceOwner: "org1"},
+						{IDPID: "idp1", UserID: "user3", ProvidedUserID: "carol", ResourceOwner: "org1"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp1": {
+						{ID: "alice", Groups: []string{"cn=admins"}},
+						{ID: "bob"},
+					},
+				},
+				deactivateErrs: map[string]error{
+					"user3": zerrors.ThrowPreconditionFailed(nil, "COMMAND-5M0sf", "Errors.User.AlreadyInactive"),
+				},
+				reactivateErrs: map[string]error{
+					"user1": zerrors.ThrowPreconditionFailed(nil, "COMMAND-6M0sf", "Errors.User.NotInactive"),
+				},
+			},
+			want: []string{
+				"instance1/sync//user1/cn=admins",
+				"instance1/reactivate/org1/user2/idp1",
+				"instance1/sync//user2/",
+				"instance1/deactivate/org1/user3/idp1",
+			},
+		},
+		{
+			name: "org idp, sync restricted to org",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "org1", ID: "idp1", OwnerType: domain.IdentityProviderTypeOrg, GroupMapping: mapping},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance1": {
+						{IDPID: "idp1", UserID: "user1", ProvidedUserID: "alice", ResourceOwner: "org1"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp1": {{ID: "alice", Groups: []string{"cn=admins"}}},
+				},
+				reactivateErrs: map[string]error{
+					"user1": zerrors.ThrowPreconditionFailed(nil, "COMMAND-6M0sf", "Errors.User.NotInactive"),
+				},
+			},
+			want: []string{
+				"instance1/sync/org1/user1/cn=admins",
+			},
+		},
+		{
+			name: "user deactivated by admin, not reactivated",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem, GroupMapping: mapping},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance1": {
+						{IDPID: "idp1", UserID: "user1", ProvidedUserID: "alice", ResourceOwner: "org1"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp1": {{ID: "alice", Groups: []string{"cn=admins"}}},
+				},
+				reactivateErrs: map[string]error{
+					"user1": zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wc8uj", "Errors.User.NotDeactivatedByDirectory"),
+				},
+			},
+			want: []string{
+				"instance1/sync//user1/cn=admins",
+			},
+		},
+		{
+			name: "reactivation failed",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance1": {
+						{IDPID: "idp1", UserID: "user1", ProvidedUserID: "alice", ResourceOwner: "org1"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp1": {{ID: "alice"}},
+				},
+				reactivateErrs: map[string]error{
+					"user1": searchErr,
+				},
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, searchErr)
+			},
+		},
+		{
+			name: "empty directory, sync skipped",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance1": {
+						{IDPID: "idp1", UserID: "user1", ProvidedUserID: "alice", ResourceOwner: "org1"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp1": {{ID: ""}},
+				},
+			},
+		},
+		{
+			name: "failed directory, others synced",
+			queries: &mockLDAPDirectoryQueries{
+				idps: []*query.LDAPIDPWithDirectorySync{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem},
+					{InstanceID: "instance2", ResourceOwner: "org2", ID: "idp2", OwnerType: domain.IdentityProviderTypeOrg},
+				},
+				links: map[string][]*query.IDPUserLink{
+					"instance2": {
+						{IDPID: "idp2", UserID: "user2", ProvidedUserID: "bob", ResourceOwner: "org2"},
+					},
+				},
+			},
+			commands: &mockLDAPDirectoryCommands{
+				users: map[string][]*ldap.User{
+					"idp2": {{ID: "alice"}},
+				},
+				searchErrs: map[string]error{
+					"idp1": searchErr,
+				},
+			},
+			want: []string{
+				"instance2/deactivate/org2/user2/idp2",
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, searchErr)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := jobs.NewLDAPDirectoryWorker(tt.queries, tt.commands)
+			err := w.Work(context.Background(), &river.Job[*jobs.LDAPDirectorySync]{
+				JobRow: &rivertype.JobRow{Attempt: 1},
+				Args:   &jobs.LDAPDirectorySync{},
+			})
+			assert.Equal(t, tt.want, tt.commands.calls)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+type mockLDAPDirectoryQueries struct {
+	idps []*query.LDAPIDPWithDirectorySync
+	// links by instance
+	links map[string][]*query.IDPUserLink
+	err   error
+}
+
+func (q *mockLDAPDirectoryQueries) LDAPIDPsWithDirectorySync(context.Context) ([]*query.LDAPIDPWithDirectorySync, error) {
+	return q.idps, q.err
+}
+
+func (q *mockLDAPDirectoryQueries) IDPUserLinks(ctx context.Context, _ *query.IDPUserLinksSearchQuery, _ domain.PermissionCheck) (*query.IDPUserLinks, error) {
+	return &query.IDPUserLinks{Links: q.links[authz.GetInstance(ctx).InstanceID()]}, nil
+}
+
+type mockLDAPDirectoryCommands struct {
+	users          map[string][]*ldap.User
+	searchErrs     map[string]error
+	deactivateErrs map[string]error
+	reactivateErrs map[string]error
+	calls          []string
+}
+
+func (c *mockLDAPDirectoryCommands) GetProvider(_ context.Context, idpID, _, _ string) (idp.Provider, error) {
+	return &mockLDAPDirectory{users: c.users[idpID], err: c.searchErrs[idpID]}, nil
+}
+
+func (c *mockLDAPDirectoryCommands) SyncLDAPUserGrants(ctx context.Context, userID, orgID string, _ *domain.LDAPGroupMapping, groups []string) error {
+	c.calls = append(c.calls, authz.GetInstance(ctx).InstanceID()+"/sync/"+orgID+"/"+userID+"/"+strings.Join(groups, ","))
+	return nil
+}
+
+func (c *mockLDAPDirectoryCommands) ReactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	if err, ok := c.reactivateErrs[userID]; ok {
+		return nil, err
+	}
+	c.calls = append(c.calls, authz.GetInstance(ctx).InstanceID()+"/reactivate/"+resourceOwner+"/"+userID+"/"+idpID)
+	return nil, nil
+}
+
+func (c *mockLDAPDirectoryCommands) DeactivateLDAPDirectoryUser(ctx context.Context, userID, resourceOwner, idpID string) (*domain.ObjectDetails, error) {
+	c.calls = append(c.calls, authz.GetInstance(ctx).InstanceID()+"/deactivate/"+resourceOwner+"/"+userID+"/"+idpID)
+	return nil, c.deactivateErrs[userID]
+}
+
+type mockLDAPDirectory struct {
+	idp.Provider
+	users []*ldap.User
+	err   error
+}
+
+func (d *mockLDAPDirectory) SearchUsers(context.Context) ([]*ldap.User, error) {
+	return d.users, d.err
+}
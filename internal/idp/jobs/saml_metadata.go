@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+)
+
+const QueueName = "idp"
+
+type Config struct {
+	// SAMLMetadataRefreshInterval defines how often the metadata of SAML identity providers with a metadata URL is refreshed.
+	// The refresh is disabled if the interval is 0.
+	SAMLMetadataRefreshInterval time.Duration
+	// LDAPDirectorySyncInterval defines how often the users of LDAP identity providers with the directory sync enabled are synchronized.
+	// The sync is disabled if the interval is 0.
+	LDAPDirectorySyncInterval time.Duration
+}
+
+// SAMLMetadataRefresh is the periodic job to refresh the metadata of all SAML identity providers with a metadata URL.
+type SAMLMetadataRefresh struct{}
+
+func (*SAMLMetadataRefresh) Kind() string {
+	return "idp_saml_metadata_refresh"
+}
+
+// InsertOpts implements [river.JobArgsWithInsertOpts]
+func (*SAMLMetadataRefresh) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: QueueName,
+		// the next run refreshes the metadata anyway
+		MaxAttempts: 1,
+	}
+}
+
+type SAMLMetadataQueries interface {
+	SAMLIDPsWithMetadataURL(ctx context.Context) ([]*query.SAMLIDPWithMetadataURL, error)
+}
+
+type SAMLMetadataCommands interface {
+	RefreshInstanceSAMLProviderMetadata(ctx context.Context, id string) (*domain.ObjectDetails, error)
+	RefreshOrgSAMLProviderMetadata(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error)
+}
+
+var _ river.Worker[*SAMLMetadataRefresh] = (*SAMLMetadataWorker)(nil)
+
+// SAMLMetadataWorker refreshes the metadata of the SAML identity providers
+type SAMLMetadataWorker struct {
+	river.WorkerDefaults[*SAMLMetadataRefresh]
+
+	queries  SAMLMetadataQueries
+	commands SAMLMetadataCommands
+}
+
+func NewSAMLMetadataWorker(queries SAMLMetadataQueries, commands SAMLMetadataCommands) *SAMLMetadataWorker {
+	return &SAMLMetadataWorker{
+		queries:  queries,
+		commands: commands,
+	}
+}
+
+// Register implements [queue.Worker]
+func (w *SAMLMetadataWorker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: 1,
+	}
+}
+
+// Work implements [river.Worker]
+// A failed refresh of an identity provider does not prevent the others from being refreshed,
+// the provider keeps its current metadata until the next run.
+func (w *SAMLMetadataWorker) Work(ctx context.Context, _ *river.Job[*SAMLMetadataRefresh]) error {
+	idps, err := w.queries.SAMLIDPsWithMetadataURL(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, idp := range idps {
+		if err := w.refresh(authz.WithInstanceID(ctx, idp.InstanceID), idp); err != nil {
+			logging.WithFields("instance", idp.InstanceID, "idp", idp.ID).WithError(err).Warn("unable to refresh saml metadata")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *SAMLMetadataWorker) refresh(ctx context.Context, idp *query.SAMLIDPWithMetadataURL) (err error) {
+	if idp.OwnerType == domain.IdentityProviderTypeOrg {
+		_, err = w.commands.RefreshOrgSAMLProviderMetadata(ctx, idp.ResourceOwner, idp.ID)
+		return err
+	}
+	_, err = w.commands.RefreshInstanceSAMLProviderMetadata(ctx, idp.ID)
+	return err
+}
+
+var _ queue.Worker = (*SAMLMetadataWorker)(nil)
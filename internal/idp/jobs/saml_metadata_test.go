@@ -0,0 +1,109 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/jobs"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestSAMLMetadataWorker_Work(t *testing.T) {
+	refreshErr := zerrors.ThrowUnavailable(nil, "COMMAND-Hn5ws", "Errors.Project.App.SAMLMetadataMissing")
+	tests := []struct {
+		name     string
+		queries  *mockSAMLMetadataQueries
+		commands *mockSAMLMetadataCommands
+		want     []string
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "query failed",
+			queries:  &mockSAMLMetadataQueries{err: zerrors.ThrowInternal(nil, "QUERY-Ec7tm", "Errors.Internal")},
+			commands: &mockSAMLMetadataCommands{},
+			wantErr:  zerrors.IsInternal,
+		},
+		{
+			name:     "no idps",
+			queries:  &mockSAMLMetadataQueries{},
+			commands: &mockSAMLMetadataCommands{},
+		},
+		{
+			name: "refresh instance and org idps",
+			queries: &mockSAMLMetadataQueries{
+				idps: []*query.SAMLIDPWithMetadataURL{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem},
+					{InstanceID: "instance2", ResourceOwner: "org1", ID: "idp2", OwnerType: domain.IdentityProviderTypeOrg},
+				},
+			},
+			commands: &mockSAMLMetadataCommands{},
+			want:     []string{"instance1/instance1/idp1", "instance2/org1/idp2"},
+		},
+		{
+			name: "failed refresh, others refreshed",
+			queries: &mockSAMLMetadataQueries{
+				idps: []*query.SAMLIDPWithMetadataURL{
+					{InstanceID: "instance1", ResourceOwner: "instance1", ID: "idp1", OwnerType: domain.IdentityProviderTypeSystem},
+					{InstanceID: "instance1", ResourceOwner: "org1", ID: "idp2", OwnerType: domain.IdentityProviderTypeOrg},
+				},
+			},
+			commands: &mockSAMLMetadataCommands{
+				errs: map[string]error{
+					"idp1": refreshErr,
+				},
+			},
+			want: []string{"instance1/instance1/idp1", "instance1/org1/idp2"},
+			wantErr: func(err error) bool {
+				return errors.Is(err, refreshErr)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := jobs.NewSAMLMetadataWorker(tt.queries, tt.commands)
+			err := w.Work(context.Background(), &river.Job[*jobs.SAMLMetadataRefresh]{
+				JobRow: &rivertype.JobRow{Attempt: 1},
+				Args:   &jobs.SAMLMetadataRefresh{},
+			})
+			assert.Equal(t, tt.want, tt.commands.refreshed)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+type mockSAMLMetadataQueries struct {
+	idps []*query.SAMLIDPWithMetadataURL
+	err  error
+}
+
+func (q *mockSAMLMetadataQueries) SAMLIDPsWithMetadataURL(context.Context) ([]*query.SAMLIDPWithMetadataURL, error) {
+	return q.idps, q.err
+}
+
+type mockSAMLMetadataCommands struct {
+	refreshed []string
+	errs      map[string]error
+}
+
+func (c *mockSAMLMetadataCommands) RefreshInstanceSAMLProviderMetadata(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	c.refreshed = append(c.refreshed, instanceID+"/"+instanceID+"/"+id)
+	return nil, c.errs[id]
+}
+
+func (c *mockSAMLMetadataCommands) RefreshOrgSAMLProviderMetadata(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	c.refreshed = append(c.refreshed, authz.GetInstance(ctx).InstanceID()+"/"+resourceOwner+"/"+id)
+	return nil, c.errs[id]
+}
@@ -35,6 +35,18 @@ type User interface {
 	GetProfile() string
 }
 
+// UserWithMetadata is an optional extension to the User interface.
+// It is implemented by users mapped by a [ClaimMapper], whose metadata will be set on the ZITADEL user.
+type UserWithMetadata interface {
+	GetMetadata() map[string][]byte
+}
+
+// UserWithGroups is an optional extension to the User interface.
+// It is implemented by users of directories, whose groups can be mapped to project roles.
+type UserWithGroups interface {
+	GetGroups() []string
+}
+
 // Parameter allows to pass specific parameter to the BeginAuth function
 type Parameter interface {
 	setValue()
@@ -0,0 +1,141 @@
+package kerberos
+
+import (
+	"context"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/service"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+// maxClockSkew is the accepted difference between the time of the ticket and the server
+const maxClockSkew = 5 * time.Minute
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for Kerberos authentication using SPNEGO (HTTP Negotiate)
+type Provider struct {
+	name             string
+	servicePrincipal string
+	keytab           *keytab.Keytab
+
+	loginURL string
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one.
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information.
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing.
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication.
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// New creates a Kerberos provider validating the tickets with the keys of the keytab.
+// If the servicePrincipal (e.g. `HTTP/login.example.com`) is empty, the principal of the ticket is used to find the key.
+func New(
+	name string,
+	servicePrincipal string,
+	keytabData []byte,
+	loginURL string,
+	options ...ProviderOpts,
+) (*Provider, error) {
+	kt, err := ParseKeytab(keytabData)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:             name,
+		servicePrincipal: servicePrincipal,
+		keytab:           kt,
+		loginURL:         loginURL,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	return provider, nil
+}
+
+// ParseKeytab parses the binary keytab (as generated by ktpass or ktutil)
+func ParseKeytab(data []byte) (*keytab.Keytab, error) {
+	kt := keytab.New()
+	if err := kt.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return kt, nil
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// BeginAuth returns a [Session], which redirects the user to the login, where the browser is challenged for a ticket.
+func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Parameter) (idp.Session, error) {
+	return &Session{
+		Provider: p,
+		loginURL: p.loginURL + state,
+	}, nil
+}
+
+// GetSession returns a [Session] for the SPNEGO token of the Authorization header.
+func (p *Provider) GetSession(token []byte) *Session {
+	return &Session{
+		Provider: p,
+		Token:    token,
+	}
+}
+
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
+
+func (p *Provider) settings() *service.Settings {
+	settings := []func(*service.Settings){
+		service.MaxClockSkew(maxClockSkew),
+	}
+	if p.servicePrincipal != "" {
+		settings = append(settings, service.KeytabPrincipal(p.servicePrincipal))
+	}
+	return service.NewSettings(p.keytab, settings...)
+}
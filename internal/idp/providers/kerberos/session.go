@@ -0,0 +1,78 @@
+package kerberos
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jcmturner/gokrb5/v8/service"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var (
+	ErrNoToken      = errors.New("no negotiate token provided")
+	ErrInvalidToken = errors.New("negotiate token is not a kerberos ticket")
+	ErrFailedLogin  = errors.New("kerberos ticket could not be verified")
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the Kerberos provider.
+type Session struct {
+	Provider *Provider
+	loginURL string
+	// Token is the SPNEGO (or raw Kerberos) token sent by the browser in the Authorization header
+	Token []byte
+}
+
+// GetAuth implements the [idp.Session] interface.
+func (s *Session) GetAuth(ctx context.Context) (string, bool) {
+	return idp.Redirect(s.loginURL)
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It verifies the ticket of the token against the keytab and returns the user of its client principal.
+func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
+	if len(s.Token) == 0 {
+		return nil, ErrNoToken
+	}
+	krb5Token, err := unmarshalKRB5Token(s.Token)
+	if err != nil {
+		return nil, err
+	}
+	ok, creds, err := service.VerifyAPREQ(&krb5Token.APReq, s.Provider.settings())
+	if err != nil {
+		return nil, errors.Join(ErrFailedLogin, err)
+	}
+	if !ok || creds == nil {
+		return nil, ErrFailedLogin
+	}
+	user := NewUser(creds.UserName(), creds.Domain())
+	// the full name is only known if the ticket contains a PAC (issued by Active Directory)
+	if fullName := creds.GetADCredentials().FullName; fullName != "" {
+		user.DisplayName = fullName
+	}
+	return user, nil
+}
+
+// unmarshalKRB5Token returns the Kerberos token of an SPNEGO token,
+// some clients (e.g. curl with --negotiate on some platforms) directly send the Kerberos token.
+func unmarshalKRB5Token(token []byte) (*spnego.KRB5Token, error) {
+	var spnegoToken spnego.SPNEGOToken
+	mechToken := token
+	if err := spnegoToken.Unmarshal(token); err == nil {
+		if !spnegoToken.Init {
+			return nil, ErrInvalidToken
+		}
+		mechToken = spnegoToken.NegTokenInit.MechTokenBytes
+	}
+	krb5Token := new(spnego.KRB5Token)
+	if err := krb5Token.Unmarshal(mechToken); err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+	if !krb5Token.IsAPReq() {
+		return nil, ErrInvalidToken
+	}
+	return krb5Token, nil
+}
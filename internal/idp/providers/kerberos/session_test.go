@@ -0,0 +1,217 @@
+package kerberos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	testRealm            = "EXAMPLE.COM"
+	testServicePrincipal = "HTTP/login.example.com"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	serviceKeytab := testKeytab(t, "secret")
+	type fields struct {
+		servicePrincipal string
+		keytab           []byte
+	}
+	type args struct {
+		token func(t *testing.T) []byte
+	}
+	type want struct {
+		user idp.User
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "no token",
+			fields: fields{
+				keytab: serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte { return nil },
+			},
+			want: want{
+				err: ErrNoToken,
+			},
+		},
+		{
+			name: "invalid token",
+			fields: fields{
+				keytab: serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte { return []byte("invalid") },
+			},
+			want: want{
+				err: ErrInvalidToken,
+			},
+		},
+		{
+			name: "ticket of other key",
+			fields: fields{
+				keytab: serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte {
+					return testSPNEGOToken(t, "alice", testKeytab(t, "other"))
+				},
+			},
+			want: want{
+				err: ErrFailedLogin,
+			},
+		},
+		{
+			name: "ticket of other service principal",
+			fields: fields{
+				servicePrincipal: "HTTP/other.example.com",
+				keytab:           serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte {
+					return testSPNEGOToken(t, "alice", serviceKeytab)
+				},
+			},
+			want: want{
+				err: ErrFailedLogin,
+			},
+		},
+		{
+			name: "spnego token",
+			fields: fields{
+				keytab: serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte {
+					return testSPNEGOToken(t, "alice", serviceKeytab)
+				},
+			},
+			want: want{
+				user: &User{
+					Principal:   "alice@EXAMPLE.COM",
+					Username:    "alice",
+					Realm:       testRealm,
+					DisplayName: "alice",
+				},
+			},
+		},
+		{
+			name: "kerberos token with service principal",
+			fields: fields{
+				servicePrincipal: testServicePrincipal,
+				keytab:           serviceKeytab,
+			},
+			args: args{
+				token: func(t *testing.T) []byte {
+					spnegoToken := new(spnego.SPNEGOToken)
+					require.NoError(t, spnegoToken.Unmarshal(testSPNEGOToken(t, "bob", serviceKeytab)))
+					return spnegoToken.NegTokenInit.MechTokenBytes
+				},
+			},
+			want: want{
+				user: &User{
+					Principal:   "bob@EXAMPLE.COM",
+					Username:    "bob",
+					Realm:       testRealm,
+					DisplayName: "bob",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New("kerberos", tt.fields.servicePrincipal, tt.fields.keytab, "https://localhost:8080/ui/login/login/kerberos?authRequestID=")
+			require.NoError(t, err)
+
+			session := provider.GetSession(tt.args.token(t))
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				assert.ErrorIs(t, err, tt.want.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.user, user)
+		})
+	}
+}
+
+func TestSession_FetchUser_replay(t *testing.T) {
+	serviceKeytab := testKeytab(t, "secret")
+	provider, err := New("kerberos", "", serviceKeytab, "")
+	require.NoError(t, err)
+	token := testSPNEGOToken(t, "alice", serviceKeytab)
+
+	_, err = provider.GetSession(token).FetchUser(context.Background())
+	require.NoError(t, err)
+	_, err = provider.GetSession(token).FetchUser(context.Background())
+	assert.ErrorIs(t, err, ErrFailedLogin)
+}
+
+func TestUser_GetPreferredUsername(t *testing.T) {
+	assert.Equal(t, "alice@example.com", NewUser("alice", testRealm).GetPreferredUsername())
+}
+
+func TestNew_invalidKeytab(t *testing.T) {
+	_, err := New("kerberos", "", []byte("invalid"), "")
+	assert.Error(t, err)
+}
+
+// testKeytab returns a keytab with the key of the testServicePrincipal derived from the password
+func testKeytab(t *testing.T, password string) []byte {
+	t.Helper()
+	kt := keytab.New()
+	require.NoError(t, kt.AddEntry(testServicePrincipal, testRealm, password, time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+	data, err := kt.Marshal()
+	require.NoError(t, err)
+	return data
+}
+
+// testSPNEGOToken returns the token a browser would send for the user,
+// the service ticket is directly created with the key of the keytab instead of being issued by a KDC.
+func testSPNEGOToken(t *testing.T, username string, keytabData []byte) []byte {
+	t.Helper()
+	kt, err := ParseKeytab(keytabData)
+	require.NoError(t, err)
+	cl := client.NewWithPassword(username, testRealm, "password", config.New())
+	now := time.Now().UTC()
+	ticket, sessionKey, err := messages.NewTicket(
+		cl.Credentials.CName(),
+		cl.Credentials.Domain(),
+		types.NewPrincipalName(nametype.KRB_NT_SRV_INST, testServicePrincipal),
+		testRealm,
+		types.NewKrbFlags(),
+		kt,
+		etypeID.AES256_CTS_HMAC_SHA1_96,
+		1,
+		now,
+		now,
+		now.Add(time.Hour),
+		now.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	negTokenInit, err := spnego.NewNegTokenInitKRB5(cl, ticket, sessionKey)
+	require.NoError(t, err)
+	token, err := (&spnego.SPNEGOToken{Init: true, NegTokenInit: negTokenInit}).Marshal()
+	require.NoError(t, err)
+	return token
+}
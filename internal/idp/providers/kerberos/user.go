@@ -0,0 +1,98 @@
+package kerberos
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var _ idp.User = (*User)(nil)
+
+// User represents the client principal of a verified Kerberos ticket.
+type User struct {
+	// Principal is the name of the principal including its realm, e.g. `alice@EXAMPLE.COM`
+	Principal   string `json:"principal,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Realm       string `json:"realm,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+func NewUser(username, realm string) *User {
+	return &User{
+		Principal:   username + "@" + realm,
+		Username:    username,
+		Realm:       realm,
+		DisplayName: username,
+	}
+}
+
+// GetID is an implementation of the [idp.User] interface.
+// It returns the principal name, which is unique across realms.
+func (u *User) GetID() string {
+	return u.Principal
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return ""
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return ""
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.DisplayName
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return ""
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+// It returns the username with the realm in lower case (e.g. `alice@example.com`),
+// which matches the login name of a user in an organization with the realm as domain.
+func (u *User) GetPreferredUsername() string {
+	return u.Username + "@" + strings.ToLower(u.Realm)
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return ""
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+func (u *User) IsEmailVerified() bool {
+	return false
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface.
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Und
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return ""
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return ""
+}
@@ -0,0 +1,101 @@
+package ldap
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// searchPageSize is the number of entries requested per page when listing the users of the directory
+const searchPageSize = 500
+
+// SearchUsers returns all users of the directory matching the configured object classes.
+// The servers are tried in order until one of them returns a result.
+func (p *Provider) SearchUsers(_ context.Context) (users []*User, err error) {
+	var entries []*ldap.Entry
+	for _, server := range p.servers {
+		entries, err = searchUsers(server,
+			p.startTLS,
+			p.bindDN,
+			p.bindPassword,
+			p.baseDN,
+			p.getNecessaryAttributes(),
+			p.userObjectClasses,
+			p.timeout,
+			p.rootCA)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	users = make([]*User, len(entries))
+	for i, entry := range entries {
+		users[i], err = mapLDAPEntryToUser(
+			entry,
+			p.idAttribute,
+			p.firstNameAttribute,
+			p.lastNameAttribute,
+			p.displayNameAttribute,
+			p.nickNameAttribute,
+			p.preferredUsernameAttribute,
+			p.emailAttribute,
+			p.emailVerifiedAttribute,
+			p.phoneAttribute,
+			p.phoneVerifiedAttribute,
+			p.preferredLanguageAttribute,
+			p.avatarURLAttribute,
+			p.profileAttribute,
+			p.groupAttribute,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func searchUsers(
+	server string,
+	startTLS bool,
+	bindDN string,
+	bindPassword string,
+	baseDN string,
+	attributes []string,
+	objectClasses []string,
+	timeout time.Duration,
+	rootCA []byte,
+) ([]*ldap.Entry, error) {
+	conn, err := getConnection(server, startTLS, timeout, rootCA)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(bindDN, bindPassword); err != nil {
+		return nil, err
+	}
+
+	searchQuery := "(objectClass=*)"
+	switch len(objectClasses) {
+	case 0:
+	case 1:
+		searchQuery = objectClassesToSearchQuery(objectClasses)
+	default:
+		searchQuery = "(&" + objectClassesToSearchQuery(objectClasses) + ")"
+	}
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(timeout.Seconds()), false,
+		searchQuery,
+		attributes,
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, searchPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return sr.Entries, nil
+}
@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntries() []*ldap.Entry {
+	return []*ldap.Entry{
+		ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"alice"},
+			"mail":        {"alice@example.com"},
+			"memberOf":    {"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"},
+		}),
+		ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"bob"},
+			"mail":        {"bob@example.com"},
+		}),
+		ldap.NewEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"admins"},
+		}),
+	}
+}
+
+func TestProvider_SearchUsers(t *testing.T) {
+	server := newTestServer(t,
+		map[string]string{"cn=admin,dc=example,dc=com": "password"},
+		testEntries()...,
+	)
+	tests := []struct {
+		name    string
+		servers []string
+		bindPW  string
+		want    []*User
+		wantErr bool
+	}{
+		{
+			name:    "bind failed",
+			servers: []string{server.URL()},
+			bindPW:  "wrong",
+			wantErr: true,
+		},
+		{
+			name:    "users with groups",
+			servers: []string{server.URL()},
+			bindPW:  "password",
+			want: []*User{
+				{ID: "alice", Email: "alice@example.com", Groups: []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"}},
+				{ID: "bob", Email: "bob@example.com"},
+			},
+		},
+		{
+			name:    "unavailable server skipped",
+			servers: []string{"ldap://127.0.0.1:1", server.URL()},
+			bindPW:  "password",
+			want: []*User{
+				{ID: "alice", Email: "alice@example.com", Groups: []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"}},
+				{ID: "bob", Email: "bob@example.com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := New("ldap", tt.servers, "dc=example,dc=com", "cn=admin,dc=example,dc=com", tt.bindPW,
+				"uid", []string{"person"}, []string{"uid"}, 0, nil, "",
+				WithoutStartTLS(),
+				WithCustomIDAttribute("uid"),
+				WithEmailAttribute("mail"),
+				WithGroupAttribute("memberOf"),
+			)
+			users, err := provider.SearchUsers(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, users)
+		})
+	}
+}
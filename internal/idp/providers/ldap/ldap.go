@@ -45,6 +45,7 @@ type Provider struct {
 	preferredLanguageAttribute string
 	avatarURLAttribute         string
 	profileAttribute           string
+	groupAttribute             string
 }
 
 type ProviderOpts func(provider *Provider)
@@ -176,6 +177,13 @@ func WithProfileAttribute(name string) ProviderOpts {
 	}
 }
 
+// WithGroupAttribute configures to map the LDAP attribute listing the DNs of the groups to the user
+func WithGroupAttribute(name string) ProviderOpts {
+	return func(p *Provider) {
+		p.groupAttribute = name
+	}
+}
+
 func New(
 	name string,
 	servers []string,
@@ -286,5 +294,8 @@ func (p *Provider) getNecessaryAttributes() []string {
 	if p.profileAttribute != "" {
 		attributes = append(attributes, p.profileAttribute)
 	}
+	if p.groupAttribute != "" {
+		attributes = append(attributes, p.groupAttribute)
+	}
 	return attributes
 }
@@ -0,0 +1,158 @@
+package ldap
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// testServer is a minimal in-memory LDAP server supporting simple binds and searches,
+// the search base and scope are ignored and all attributes of the matching entries are returned.
+type testServer struct {
+	listener net.Listener
+	// passwords of the bindable DNs
+	passwords map[string]string
+	entries   []*ldap.Entry
+}
+
+func newTestServer(t *testing.T, passwords map[string]string, entries ...*ldap.Entry) *testServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{
+		listener:  listener,
+		passwords: passwords,
+		entries:   entries,
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go s.serve()
+	return s
+}
+
+func (s *testServer) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			resultCode := ldap.LDAPResultInvalidCredentials
+			dn := request.Children[1].Data.String()
+			if password, ok := s.passwords[dn]; ok && password == request.Children[2].Data.String() {
+				resultCode = ldap.LDAPResultSuccess
+			}
+			s.write(conn, messageID, ldapResult(ldap.ApplicationBindResponse, resultCode))
+		case ldap.ApplicationSearchRequest:
+			for _, entry := range s.entries {
+				if matchesFilter(entry, request.Children[6]) {
+					s.write(conn, messageID, searchResultEntry(entry))
+				}
+			}
+			s.write(conn, messageID, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			return
+		}
+	}
+}
+
+func (s *testServer) write(conn net.Conn, messageID int64, response *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	packet.AppendChild(response)
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func ldapResult(application ber.Tag, resultCode int) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, application, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return result
+}
+
+func searchResultEntry(entry *ldap.Entry) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "DN"))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for _, attribute := range entry.Attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, attribute.Name, "Type"))
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range attribute.Values {
+			values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attr.AppendChild(values)
+		attributes.AppendChild(attr)
+	}
+	result.AppendChild(attributes)
+	return result
+}
+
+// matchesFilter supports the and, or, not, equality and present filters
+func matchesFilter(entry *ldap.Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchesFilter(entry, child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchesFilter(entry, child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchesFilter(entry, filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		name := filter.Children[0].Data.String()
+		value := filter.Children[1].Data.String()
+		for _, v := range entryValues(entry, name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		name := filter.Data.String()
+		return strings.EqualFold(name, "objectClass") || len(entryValues(entry, name)) > 0
+	}
+	return false
+}
+
+func entryValues(entry *ldap.Entry, name string) []string {
+	for _, attribute := range entry.Attributes {
+		if strings.EqualFold(attribute.Name, name) {
+			return attribute.Values
+		}
+	}
+	return nil
+}
@@ -83,6 +83,7 @@ func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
 		s.Provider.preferredLanguageAttribute,
 		s.Provider.avatarURLAttribute,
 		s.Provider.profileAttribute,
+		s.Provider.groupAttribute,
 	)
 }
 
@@ -269,7 +270,8 @@ func mapLDAPEntryToUser(
 	phoneVerifiedAttribute,
 	preferredLanguageAttribute,
 	avatarURLAttribute,
-	profileAttribute string,
+	profileAttribute,
+	groupAttribute string,
 ) (_ *User, err error) {
 	var emailVerified bool
 	if v := user.GetAttributeValue(emailVerifiedAttribute); v != "" {
@@ -286,7 +288,7 @@ func mapLDAPEntryToUser(
 		}
 	}
 
-	return NewUser(
+	mapped := NewUser(
 		getAttributeValue(user, idAttribute),
 		getAttributeValue(user, firstNameAttribute),
 		getAttributeValue(user, lastNameAttribute),
@@ -300,7 +302,11 @@ func mapLDAPEntryToUser(
 		language.Make(user.GetAttributeValue(preferredLanguageAttribute)),
 		user.GetAttributeValue(avatarURLAttribute),
 		user.GetAttributeValue(profileAttribute),
-	), nil
+	)
+	if groups := user.GetAttributeValues(groupAttribute); len(groups) > 0 {
+		mapped.Groups = groups
+	}
+	return mapped, nil
 }
 
 func getAttributeValue(user *ldap.Entry, attribute string) string {
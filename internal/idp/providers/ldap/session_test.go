@@ -1,6 +1,7 @@
 package ldap
 
 import (
+	"context"
 	"testing"
 
 	"github.com/go-ldap/ldap/v3"
@@ -173,6 +174,7 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 		preferredLanguageAttribute string
 		avatarURLAttribute         string
 		profileAttribute           string
+		groupAttribute             string
 	}
 	type want struct {
 		user *User
@@ -367,6 +369,25 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "user with groups",
+			fields: fields{
+				user: &ldap.Entry{
+					Attributes: []*ldap.EntryAttribute{
+						{Name: "id", Values: []string{"id"}},
+						{Name: "memberOf", Values: []string{"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"}},
+					},
+				},
+				idAttribute:    "id",
+				groupAttribute: "memberOf",
+			},
+			want: want{
+				user: &User{
+					ID:     "id",
+					Groups: []string{"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -385,6 +406,7 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 				tt.fields.preferredLanguageAttribute,
 				tt.fields.avatarURLAttribute,
 				tt.fields.profileAttribute,
+				tt.fields.groupAttribute,
 			)
 			if tt.want.err == nil {
 				assert.NoError(t, err)
@@ -398,3 +420,59 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_FetchUser(t *testing.T) {
+	server := newTestServer(t,
+		map[string]string{
+			"cn=admin,dc=example,dc=com":            "password",
+			"uid=alice,ou=people,dc=example,dc=com": "alice-password",
+		},
+		testEntries()...,
+	)
+	provider := New("ldap", []string{server.URL()}, "dc=example,dc=com", "cn=admin,dc=example,dc=com", "password",
+		"uid", []string{"person"}, []string{"uid"}, 0, nil, "",
+		WithoutStartTLS(),
+		WithCustomIDAttribute("uid"),
+		WithGroupAttribute("memberOf"),
+	)
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     *User
+		wantErr  error
+	}{
+		{
+			name:     "unknown user",
+			username: "carol",
+			password: "password",
+			wantErr:  ErrNoSingleUser,
+		},
+		{
+			name:     "wrong password",
+			username: "alice",
+			password: "wrong",
+			wantErr:  ErrFailedLogin,
+		},
+		{
+			name:     "user with groups",
+			username: "alice",
+			password: "alice-password",
+			want: &User{
+				ID:     "alice",
+				Groups: []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := provider.GetSession(tt.username, tt.password).FetchUser(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, user)
+		})
+	}
+}
@@ -20,6 +20,7 @@ type User struct {
 	PreferredLanguage language.Tag        `json:"preferredLanguage,omitempty"`
 	AvatarURL         string              `json:"avatarURL,omitempty"`
 	Profile           string              `json:"profile,omitempty"`
+	Groups            []string            `json:"groups,omitempty"`
 }
 
 func NewUser(
@@ -51,6 +52,7 @@ func NewUser(
 		preferredLanguage,
 		avatarURL,
 		profile,
+		nil,
 	}
 }
 
@@ -93,3 +95,9 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+
+// GetGroups returns the DNs of the groups the user is a member of.
+// They are only set if the provider is configured with a group attribute.
+func (u *User) GetGroups() []string {
+	return u.Groups
+}
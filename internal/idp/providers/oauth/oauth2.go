@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp"
 )
 
@@ -19,6 +20,8 @@ type Provider struct {
 	name              string
 	userEndpoint      string
 	userMapper        func() idp.User
+	claimMapping      *domain.IDPClaimMapping
+	claimMapper       *idp.ClaimMapper
 	isLinkingAllowed  bool
 	isCreationAllowed bool
 	isAutoCreation    bool
@@ -63,6 +66,13 @@ func WithRelyingPartyOption(option rp.Option) ProviderOpts {
 	}
 }
 
+// WithClaimMapping maps the information received from the userEndpoint using the expressions of the mapping.
+func WithClaimMapping(mapping *domain.IDPClaimMapping) ProviderOpts {
+	return func(p *Provider) {
+		p.claimMapping = mapping
+	}
+}
+
 // New creates a generic OAuth 2.0 provider
 func New(config *oauth2.Config, name, userEndpoint string, userMapper func() idp.User, options ...ProviderOpts) (provider *Provider, err error) {
 	provider = &Provider{
@@ -73,6 +83,10 @@ func New(config *oauth2.Config, name, userEndpoint string, userMapper func() idp
 	for _, option := range options {
 		option(provider)
 	}
+	provider.claimMapper, err = idp.NewClaimMapper(provider.claimMapping)
+	if err != nil {
+		return nil, err
+	}
 	provider.RelyingParty, err = rp.NewRelyingPartyOAuth(config, provider.options...)
 	if err != nil {
 		return nil, err
@@ -48,7 +48,14 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 	if err := httphelper.HttpRequest(s.Provider.RelyingParty.HttpClient(), req, &mapper); err != nil {
 		return nil, err
 	}
-	return mapper, nil
+	if s.Provider.claimMapper == nil {
+		return mapper, nil
+	}
+	var claims any = mapper
+	if m, ok := mapper.(*UserMapper); ok {
+		claims = m.RawInfo
+	}
+	return s.Provider.claimMapper.Map(mapper, claims)
 }
 
 func (s *Session) authorize(ctx context.Context) (err error) {
@@ -24,6 +24,7 @@ func TestProvider_FetchUser(t *testing.T) {
 		userEndpoint string
 		httpMock     func(issuer string)
 		userMapper   func() idp.User
+		options      []ProviderOpts
 		authURL      string
 		code         string
 		tokens       *oidc.Tokens[*oidc.IDTokenClaims]
@@ -167,6 +168,72 @@ func TestProvider_FetchUser(t *testing.T) {
 				profile:           "",
 			},
 		},
+		{
+			name: "successful fetch with claim mapping",
+			fields: fields{
+				config: &oauth2.Config{
+					ClientID:     "clientID",
+					ClientSecret: "clientSecret",
+					Endpoint: oauth2.Endpoint{
+						AuthURL:  "https://oauth2.com/authorize",
+						TokenURL: "https://oauth2.com/token",
+					},
+					RedirectURL: "redirectURI",
+					Scopes:      []string{"user"},
+				},
+				userEndpoint: "https://oauth2.com/user",
+				httpMock: func(issuer string) {
+					gock.New(issuer).
+						Get("/user").
+						Reply(200).
+						JSON(map[string]interface{}{
+							"userID": "id",
+							"login":  "username",
+							"name": map[string]interface{}{
+								"given":  "firstname",
+								"family": "lastname",
+							},
+							"emails": []interface{}{
+								map[string]interface{}{"value": "email", "verified": true},
+							},
+						})
+				},
+				userMapper: func() idp.User {
+					return NewUserMapper("userID")
+				},
+				options: []ProviderOpts{
+					WithClaimMapping(&domain.IDPClaimMapping{
+						PreferredUsername: "$.login",
+						FirstName:         "$.name.given",
+						LastName:          "$.name.family",
+						Email:             "$.emails[0].value",
+						EmailVerified:     "$.emails[0].verified",
+					}),
+				},
+				authURL: "https://issuer.com/authorize?client_id=clientID&redirect_uri=redirectURI&response_type=code&scope=user&state=testState",
+				tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+					Token: &oauth2.Token{
+						AccessToken: "accessToken",
+						TokenType:   oidc.BearerToken,
+					},
+				},
+			},
+			want: want{
+				id:                "id",
+				firstName:         "firstname",
+				lastName:          "lastname",
+				displayName:       "",
+				nickName:          "",
+				preferredUsername: "username",
+				email:             "email",
+				isEmailVerified:   true,
+				phone:             "",
+				isPhoneVerified:   false,
+				preferredLanguage: language.Und,
+				avatarURL:         "",
+				profile:           "",
+			},
+		},
 		{
 			name: "successful fetch with code exchange",
 			fields: fields{
@@ -238,7 +305,7 @@ func TestProvider_FetchUser(t *testing.T) {
 			tt.fields.httpMock("https://oauth2.com")
 			a := assert.New(t)
 
-			provider, err := New(tt.fields.config, tt.fields.name, tt.fields.userEndpoint, tt.fields.userMapper)
+			provider, err := New(tt.fields.config, tt.fields.name, tt.fields.userEndpoint, tt.fields.userMapper, tt.fields.options...)
 			require.NoError(t, err)
 
 			session := &Session{
@@ -254,7 +321,9 @@ func TestProvider_FetchUser(t *testing.T) {
 			}
 			if tt.want.err == nil {
 				a.NoError(err)
-				a.Equal(tt.want.user, user)
+				if tt.want.user != nil {
+					a.Equal(tt.want.user, user)
+				}
 				a.Equal(tt.want.id, user.GetID())
 				a.Equal(tt.want.firstName, user.GetFirstName())
 				a.Equal(tt.want.lastName, user.GetLastName())
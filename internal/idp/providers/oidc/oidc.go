@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp"
 )
 
@@ -23,6 +24,8 @@ type Provider struct {
 	isAutoUpdate      bool
 	useIDToken        bool
 	userInfoMapper    func(info *oidc.UserInfo) idp.User
+	claimMapping      *domain.IDPClaimMapping
+	claimMapper       *idp.ClaimMapper
 	authOptions       []func(bool) rp.AuthURLOpt
 }
 
@@ -93,6 +96,13 @@ func WithResponseMode(mode oidc.ResponseMode) ProviderOpts {
 	}
 }
 
+// WithClaimMapping maps the claims of the userinfo (or id_token) using the expressions of the mapping.
+func WithClaimMapping(mapping *domain.IDPClaimMapping) ProviderOpts {
+	return func(p *Provider) {
+		p.claimMapping = mapping
+	}
+}
+
 type UserInfoMapper func(info *oidc.UserInfo) idp.User
 
 var DefaultMapper UserInfoMapper = func(info *oidc.UserInfo) idp.User {
@@ -108,6 +118,10 @@ func New(name, issuer, clientID, clientSecret, redirectURI string, scopes []stri
 	for _, option := range options {
 		option(provider)
 	}
+	provider.claimMapper, err = idp.NewClaimMapper(provider.claimMapping)
+	if err != nil {
+		return nil, err
+	}
 	provider.RelyingParty, err = rp.NewRelyingPartyOIDC(context.TODO(), issuer, clientID, clientSecret, redirectURI, setDefaultScope(scopes), provider.options...)
 	if err != nil {
 		return nil, err
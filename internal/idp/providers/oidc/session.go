@@ -54,7 +54,7 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 		}
 	}
 	u := s.Provider.userInfoMapper(info)
-	return u, nil
+	return s.Provider.claimMapper.Map(u, info)
 }
 
 func (s *Session) Authorize(ctx context.Context) (err error) {
@@ -29,11 +29,15 @@ type Provider struct {
 	requestTracker samlsp.RequestTracker
 	Certificate    []byte
 
-	spOptions *samlsp.Options
+	spOptions      *samlsp.Options
+	additionalPEMs []*Key
+	additionalKeys []*keyPair
 
 	binding                       string
 	nameIDFormat                  saml.NameIDFormat
 	transientMappingAttributeName string
+	claimMapping                  *domain.IDPClaimMapping
+	claimMapper                   *idp.ClaimMapper
 
 	isLinkingAllowed  bool
 	isCreationAllowed bool
@@ -96,6 +100,29 @@ func WithTransientMappingAttributeName(attribute string) ProviderOpts {
 	}
 }
 
+// WithClaimMapping maps the attributes of the assertion using the expressions of the mapping.
+// Every attribute is represented as an array of its values, e.g. `$.email[0]`.
+func WithClaimMapping(mapping *domain.IDPClaimMapping) ProviderOpts {
+	return func(p *Provider) {
+		p.claimMapping = mapping
+	}
+}
+
+// Key is a PEM encoded key pair of the service provider.
+type Key struct {
+	Certificate []byte
+	Key         []byte
+}
+
+// WithAdditionalKeys adds key pairs next to the active one.
+// Their certificates are published in the metadata and the keys are used to decrypt assertions encrypted for them,
+// so the active key can be rolled over without interrupting the logins.
+func WithAdditionalKeys(keys ...*Key) ProviderOpts {
+	return func(p *Provider) {
+		p.additionalPEMs = append(p.additionalPEMs, keys...)
+	}
+}
+
 func WithCustomRequestTracker(tracker samlsp.RequestTracker) ProviderOpts {
 	return func(p *Provider) {
 		p.requestTracker = tracker
@@ -155,11 +182,7 @@ func New(
 	if err != nil {
 		return nil, err
 	}
-	keyPair, err := tls.X509KeyPair(certificate, key)
-	if err != nil {
-		return nil, err
-	}
-	keyPair.Leaf, err = x509.ParseCertificate(keyPair.Certificate[0])
+	activeKey, err := parseKeyPair(certificate, key)
 	if err != nil {
 		return nil, err
 	}
@@ -169,8 +192,8 @@ func New(
 	}
 	opts := samlsp.Options{
 		URL:         *rootURL,
-		Key:         keyPair.PrivateKey.(*rsa.PrivateKey),
-		Certificate: keyPair.Leaf,
+		Key:         activeKey.key,
+		Certificate: activeKey.certificate,
 		IDPMetadata: entityDescriptor,
 		SignRequest: false,
 	}
@@ -184,6 +207,17 @@ func New(
 	for _, option := range options {
 		option(provider)
 	}
+	provider.additionalKeys = make([]*keyPair, len(provider.additionalPEMs))
+	for i, additionalKey := range provider.additionalPEMs {
+		provider.additionalKeys[i], err = parseKeyPair(additionalKey.Certificate, additionalKey.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	provider.claimMapper, err = idp.NewClaimMapper(provider.claimMapping)
+	if err != nil {
+		return nil, err
+	}
 	return provider, nil
 }
 
@@ -223,6 +257,28 @@ func (p *Provider) GetSP() (*samlsp.Middleware, error) {
 	return sp, nil
 }
 
+// Metadata returns the metadata of the service provider,
+// which contains the certificates of the additional keys next to the active one.
+func (p *Provider) Metadata() (*saml.EntityDescriptor, error) {
+	sp, err := p.GetSP()
+	if err != nil {
+		return nil, err
+	}
+	metadata := sp.ServiceProvider.Metadata()
+	for _, additionalKey := range p.additionalKeys {
+		additionalSP := sp.ServiceProvider
+		additionalSP.Certificate = additionalKey.certificate
+		additionalSP.Intermediates = nil
+		for i := range metadata.SPSSODescriptors {
+			metadata.SPSSODescriptors[i].KeyDescriptors = append(
+				metadata.SPSSODescriptors[i].KeyDescriptors,
+				additionalSP.Metadata().SPSSODescriptors[0].KeyDescriptors...,
+			)
+		}
+	}
+	return metadata, nil
+}
+
 func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Parameter) (idp.Session, error) {
 	m, err := p.GetSP()
 	if err != nil {
@@ -232,6 +288,7 @@ func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Paramet
 	return &Session{
 		ServiceProvider: m,
 		state:           state,
+		AdditionalKeys:  p.AdditionalKeys(),
 	}, nil
 }
 
@@ -239,6 +296,43 @@ func (p *Provider) TransientMappingAttributeName() string {
 	return p.transientMappingAttributeName
 }
 
+func (p *Provider) ClaimMapper() *idp.ClaimMapper {
+	return p.claimMapper
+}
+
+// AdditionalKeys returns the private keys of the additional key pairs
+func (p *Provider) AdditionalKeys() []*rsa.PrivateKey {
+	keys := make([]*rsa.PrivateKey, len(p.additionalKeys))
+	for i, additionalKey := range p.additionalKeys {
+		keys[i] = additionalKey.key
+	}
+	return keys
+}
+
+type keyPair struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+}
+
+func parseKeyPair(certificate, key []byte) (*keyPair, error) {
+	pair, err := tls.X509KeyPair(certificate, key)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SAML-Pk3nw", "Errors.Intent.IDPInvalid")
+	}
+	return &keyPair{
+		key:         rsaKey,
+		certificate: leaf,
+	}, nil
+}
+
 func nameIDFormatFromDomain(format domain.SAMLNameIDFormat) saml.NameIDFormat {
 	switch format {
 	case domain.SAMLNameIDFormatUnspecified:
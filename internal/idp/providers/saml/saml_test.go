@@ -1,6 +1,8 @@
 package saml
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"encoding/xml"
 	"testing"
 	"time"
@@ -306,3 +308,87 @@ func TestParseMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_Metadata(t *testing.T) {
+	idpCertificate, idpKey := generateKeyPair(t)
+	activeCertificate, activeKey := generateKeyPair(t)
+	additionalCertificate, additionalKey := generateKeyPair(t)
+	idpMetadata, err := xml.Marshal(testIdentityProvider(t, idpCertificate, idpKey).Metadata())
+	require.NoError(t, err)
+
+	type want struct {
+		encryption []string
+		signing    []string
+	}
+	tests := []struct {
+		name    string
+		options []ProviderOpts
+		want    want
+	}{
+		{
+			name: "active key",
+			want: want{
+				encryption: []string{certificateData(t, activeCertificate)},
+			},
+		},
+		{
+			name: "additional key",
+			options: []ProviderOpts{
+				WithAdditionalKeys(&Key{Certificate: additionalCertificate, Key: additionalKey}),
+			},
+			want: want{
+				encryption: []string{certificateData(t, activeCertificate), certificateData(t, additionalCertificate)},
+			},
+		},
+		{
+			name: "additional key, signed request",
+			options: []ProviderOpts{
+				WithSignedRequest(),
+				WithAdditionalKeys(&Key{Certificate: additionalCertificate, Key: additionalKey}),
+			},
+			want: want{
+				encryption: []string{certificateData(t, activeCertificate), certificateData(t, additionalCertificate)},
+				signing:    []string{certificateData(t, activeCertificate), certificateData(t, additionalCertificate)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New("saml", "http://localhost:8080/idps/idp", idpMetadata, activeCertificate, activeKey, tt.options...)
+			require.NoError(t, err)
+			metadata, err := provider.Metadata()
+			require.NoError(t, err)
+
+			var got want
+			for _, descriptor := range metadata.SPSSODescriptors[0].KeyDescriptors {
+				data := descriptor.KeyInfo.X509Data.X509Certificates[0].Data
+				switch descriptor.Use {
+				case "encryption":
+					got.encryption = append(got.encryption, data)
+				case "signing":
+					got.signing = append(got.signing, data)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProvider_invalidAdditionalKey(t *testing.T) {
+	idpCertificate, idpKey := generateKeyPair(t)
+	activeCertificate, activeKey := generateKeyPair(t)
+	additionalCertificate, _ := generateKeyPair(t)
+	idpMetadata, err := xml.Marshal(testIdentityProvider(t, idpCertificate, idpKey).Metadata())
+	require.NoError(t, err)
+
+	_, err = New("saml", "http://localhost:8080/idps/idp", idpMetadata, activeCertificate, activeKey,
+		WithAdditionalKeys(&Key{Certificate: additionalCertificate, Key: activeKey}),
+	)
+	assert.Error(t, err)
+}
+
+func certificateData(t *testing.T, certificate []byte) string {
+	block, _ := pem.Decode(certificate)
+	require.NotNil(t, block)
+	return base64.StdEncoding.EncodeToString(block.Bytes)
+}
@@ -3,6 +3,7 @@ package saml
 import (
 	"bytes"
 	"context"
+	"crypto/rsa"
 	"errors"
 	"net/http"
 	"net/url"
@@ -21,6 +22,9 @@ type Session struct {
 	ServiceProvider               *samlsp.Middleware
 	state                         string
 	TransientMappingAttributeName string
+	ClaimMapper                   *idp.ClaimMapper
+	// AdditionalKeys are used to decrypt the assertion, if it was not encrypted for the active key
+	AdditionalKeys []*rsa.PrivateKey
 
 	RequestID string
 	Request   *http.Request
@@ -36,6 +40,8 @@ func NewSession(provider *Provider, requestID string, request *http.Request) (*S
 	return &Session{
 		ServiceProvider:               sp,
 		TransientMappingAttributeName: provider.TransientMappingAttributeName(),
+		ClaimMapper:                   provider.ClaimMapper(),
+		AdditionalKeys:                provider.AdditionalKeys(),
 		RequestID:                     requestID,
 		Request:                       request,
 	}, nil
@@ -66,7 +72,7 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 		return nil, zerrors.ThrowInvalidArgument(nil, "SAML-d09hy0wkex", "Errors.Intent.ResponseInvalid")
 	}
 
-	s.Assertion, err = s.ServiceProvider.ServiceProvider.ParseResponse(s.Request, []string{s.RequestID})
+	s.Assertion, err = s.parseResponse()
 	if err != nil {
 		invalidRespErr := new(saml.InvalidResponseError)
 		if errors.As(err, &invalidRespErr) {
@@ -99,7 +105,25 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 			userMapper.Attributes[attribute.Name] = values
 		}
 	}
-	return userMapper, nil
+	return s.ClaimMapper.Map(userMapper, userMapper.Attributes)
+}
+
+// parseResponse parses the response using the active key and falls back to the additional keys,
+// in case the assertion was encrypted for one of them (e.g. during a key rollover).
+// Artifacts can only be resolved once, so they're only parsed using the active key.
+func (s *Session) parseResponse() (*saml.Assertion, error) {
+	sp := s.ServiceProvider.ServiceProvider
+	assertion, err := sp.ParseResponse(s.Request, []string{s.RequestID})
+	if err == nil || s.Request.Form.Get("SAMLart") != "" {
+		return assertion, err
+	}
+	for _, key := range s.AdditionalKeys {
+		sp.Key = key
+		if assertion, keyErr := sp.ParseResponse(s.Request, []string{s.RequestID}); keyErr == nil {
+			return assertion, nil
+		}
+	}
+	return nil, err
 }
 
 func (s *Session) transientMappingID() (string, error) {
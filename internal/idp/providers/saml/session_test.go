@@ -2,13 +2,22 @@ package saml
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
 	"errors"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/beevik/etree"
 	"github.com/crewjam/saml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -326,3 +335,108 @@ func httpPostFormRequest(t *testing.T, callbackURL, relayState, response string)
 	assert.NoError(t, req.ParseForm())
 	return req
 }
+
+func TestSession_FetchUser_additionalKeys(t *testing.T) {
+	saml.TimeNow = time.Now
+	idpCertificate, idpKey := generateKeyPair(t)
+	activeCertificate, activeKey := generateKeyPair(t)
+	additionalCertificate, additionalKey := generateKeyPair(t)
+	identityProvider := testIdentityProvider(t, idpCertificate, idpKey)
+	idpMetadata, err := xml.Marshal(identityProvider.Metadata())
+	require.NoError(t, err)
+
+	// the identity provider encrypts the assertion for the additional key, e.g. after it picked up the new certificate
+	encryptingProvider, err := New("saml", "http://localhost:8080/idps/idp", idpMetadata, additionalCertificate, additionalKey)
+	require.NoError(t, err)
+	response := encryptedResponse(t, identityProvider, encryptingProvider, "request")
+
+	tests := []struct {
+		name    string
+		options []ProviderOpts
+		wantErr error
+	}{
+		{
+			name:    "active key only",
+			wantErr: zerrors.ThrowInvalidArgument(nil, "SAML-ajl3irfs", "Errors.Intent.ResponseInvalid"),
+		},
+		{
+			name: "additional key",
+			options: []ProviderOpts{
+				WithAdditionalKeys(&Key{Certificate: additionalCertificate, Key: additionalKey}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New("saml", "http://localhost:8080/idps/idp", idpMetadata, activeCertificate, activeKey, tt.options...)
+			require.NoError(t, err)
+			session, err := NewSession(provider, "request", httpPostFormRequest(t, "http://localhost:8080/idps/idp/saml/acs", "state", response))
+			require.NoError(t, err)
+
+			user, err := session.FetchUser(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", user.GetID())
+		})
+	}
+}
+
+func testIdentityProvider(t *testing.T, certificate, key []byte) *saml.IdentityProvider {
+	pair, err := parseKeyPair(certificate, key)
+	require.NoError(t, err)
+	metadataURL, _ := url.Parse("http://localhost:8000/metadata")
+	ssoURL, _ := url.Parse("http://localhost:8000/sso")
+	return &saml.IdentityProvider{
+		Key:         pair.key,
+		Certificate: pair.certificate,
+		MetadataURL: *metadataURL,
+		SSOURL:      *ssoURL,
+	}
+}
+
+func encryptedResponse(t *testing.T, identityProvider *saml.IdentityProvider, serviceProvider *Provider, requestID string) string {
+	spMetadata, err := serviceProvider.Metadata()
+	require.NoError(t, err)
+	req := &saml.IdpAuthnRequest{
+		IDP:         identityProvider,
+		HTTPRequest: &http.Request{},
+		Request: saml.AuthnRequest{
+			ID: requestID,
+		},
+		ServiceProviderMetadata: spMetadata,
+		SPSSODescriptor:         &spMetadata.SPSSODescriptors[0],
+		ACSEndpoint:             &spMetadata.SPSSODescriptors[0].AssertionConsumerServices[0],
+		Now:                     saml.TimeNow(),
+	}
+	require.NoError(t, saml.DefaultAssertionMaker{}.MakeAssertion(req, &saml.Session{
+		ID:           "session",
+		NameID:       "user",
+		NameIDFormat: string(saml.PersistentNameIDFormat),
+	}))
+	require.NoError(t, req.MakeResponse())
+	doc := etree.NewDocument()
+	doc.SetRoot(req.ResponseEl)
+	data, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "EncryptedAssertion")
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func generateKeyPair(t *testing.T) (certificate, key []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"ZITADEL"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	_ "github.com/zitadel/zitadel/internal/notification/statik"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/cibarequest"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	CIBANotificationsProjectionTable = "projections.notifications_ciba"
+)
+
+// cibaNotifier handles the notifications of Client-Initiated Backchannel Authentication:
+// The user is notified on its authentication device (by email) with a link to the login to approve or deny the request.
+// Clients using the ping mode are notified, once the user approved or denied the request.
+// The client then gets the result at the token endpoint.
+type cibaNotifier struct {
+	queries    *NotificationQueries
+	channels   types.ChannelChains
+	encryption crypto.EncryptionAlgorithm
+}
+
+func NewCIBANotifier(
+	ctx context.Context,
+	config handler.Config,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+	encryption crypto.EncryptionAlgorithm,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &cibaNotifier{
+		queries:    queries,
+		channels:   channels,
+		encryption: encryption,
+	})
+}
+
+func (*cibaNotifier) Name() string {
+	return CIBANotificationsProjectionTable
+}
+
+func (u *cibaNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: cibarequest.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  cibarequest.AddedEventType,
+					Reduce: u.reduceRequestAdded,
+				},
+				{
+					Event:  cibarequest.ApprovedEventType,
+					Reduce: u.reduceRequestHandled,
+				},
+				{
+					Event:  cibarequest.CanceledEventType,
+					Reduce: u.reduceRequestHandled,
+				},
+			},
+		},
+	}
+}
+
+// reduceRequestAdded notifies the user about the request.
+// The link to the login contains the encrypted ID of the request, since the auth_req_id is only known to the client.
+func (u *cibaNotifier) reduceRequestAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*cibarequest.AddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ro3bq", "reduce.wrong.event.type %s", cibarequest.AddedEventType)
+	}
+	// requests of older versions did not notify the user
+	if e.LoginURL == "" {
+		return handler.NewNoOpStatement(e), nil
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		if e.Expires.Before(time.Now()) {
+			return nil
+		}
+		ctx, err := u.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.UserID)
+		if err != nil {
+			return err
+		}
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+		template, err := u.queries.MailTemplateByOrg(ctx, notifyUser.ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.BackchannelAuthenticationMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		id, err := u.encryption.Encrypt([]byte(e.Aggregate().ID))
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+			SendBackchannelAuthentication(ctx, e.LoginURL+base64.RawURLEncoding.EncodeToString(id), e.BindingMessage)
+		if errors.Is(err, &channels.CancelError{}) {
+			// if the notification was canceled, we don't want to return the error, so there is no retry
+			return nil
+		}
+		return err
+	}), nil
+}
+
+type cibaPingNotification struct {
+	AuthReqID string `json:"auth_req_id"`
+}
+
+func (u *cibaNotifier) reduceRequestHandled(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *cibarequest.ApprovedEvent, *cibarequest.CanceledEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-q0ux6v3dzr", "reduce.wrong.event.type %v", []eventstore.EventType{cibarequest.ApprovedEventType, cibarequest.CanceledEventType})
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := u.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		request, err := u.queries.CIBARequestByID(ctx, event.Aggregate().ID, false)
+		if err != nil {
+			return err
+		}
+		// clients using the poll mode are not notified
+		if request.NotificationURI == "" {
+			return nil
+		}
+		return types.SendJSON(
+			ctx,
+			webhook.Config{
+				CallURL: request.NotificationURI,
+				Method:  http.MethodPost,
+				Headers: http.Header{"Authorization": []string{"Bearer " + request.ClientNotificationToken}},
+			},
+			u.channels,
+			&cibaPingNotification{AuthReqID: event.Aggregate().ID},
+			event,
+		).WithoutTemplate()
+	}), nil
+}
@@ -16,6 +16,7 @@ import (
 
 	jose "github.com/go-jose/go-jose/v4"
 	authz "github.com/zitadel/zitadel/internal/api/authz"
+	crypto "github.com/zitadel/zitadel/internal/crypto"
 	domain "github.com/zitadel/zitadel/internal/domain"
 	query "github.com/zitadel/zitadel/internal/query"
 	gomock "go.uber.org/mock/gomock"
@@ -45,6 +46,21 @@ func (m *MockQueries) EXPECT() *MockQueriesMockRecorder {
 	return m.recorder
 }
 
+// ActiveCertificates mocks base method.
+func (m *MockQueries) ActiveCertificates(arg0 context.Context, arg1 time.Time, arg2 crypto.KeyUsage) (*query.Certificates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveCertificates", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.Certificates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveCertificates indicates an expected call of ActiveCertificates.
+func (mr *MockQueriesMockRecorder) ActiveCertificates(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveCertificates", reflect.TypeOf((*MockQueries)(nil).ActiveCertificates), arg0, arg1, arg2)
+}
+
 // ActiveInstances mocks base method.
 func (m *MockQueries) ActiveInstances() []string {
 	m.ctrl.T.Helper()
@@ -89,6 +105,36 @@ func (mr *MockQueriesMockRecorder) ActivePrivateSigningKey(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePrivateSigningKey", reflect.TypeOf((*MockQueries)(nil).ActivePrivateSigningKey), arg0, arg1)
 }
 
+// ActiveSAMLServiceProviderByID mocks base method.
+func (m *MockQueries) ActiveSAMLServiceProviderByID(arg0 context.Context, arg1 string) (*query.SAMLServiceProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveSAMLServiceProviderByID", arg0, arg1)
+	ret0, _ := ret[0].(*query.SAMLServiceProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveSAMLServiceProviderByID indicates an expected call of ActiveSAMLServiceProviderByID.
+func (mr *MockQueriesMockRecorder) ActiveSAMLServiceProviderByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveSAMLServiceProviderByID", reflect.TypeOf((*MockQueries)(nil).ActiveSAMLServiceProviderByID), arg0, arg1)
+}
+
+// CIBARequestByID mocks base method.
+func (m *MockQueries) CIBARequestByID(arg0 context.Context, arg1 string, arg2 bool) (*query.CIBARequestReadModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CIBARequestByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.CIBARequestReadModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CIBARequestByID indicates an expected call of CIBARequestByID.
+func (mr *MockQueriesMockRecorder) CIBARequestByID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CIBARequestByID", reflect.TypeOf((*MockQueries)(nil).CIBARequestByID), arg0, arg1, arg2)
+}
+
 // CustomTextListByTemplate mocks base method.
 func (m *MockQueries) CustomTextListByTemplate(arg0 context.Context, arg1, arg2 string, arg3 bool) (*query.CustomTexts, error) {
 	m.ctrl.T.Helper()
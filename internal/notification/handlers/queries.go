@@ -31,6 +31,9 @@ type Queries interface {
 	InstanceByID(ctx context.Context, id string) (instance authz.Instance, err error)
 	GetActiveSigningWebKey(ctx context.Context) (*jose.JSONWebKey, error)
 	ActivePrivateSigningKey(ctx context.Context, t time.Time) (keys *query.PrivateKeys, err error)
+	ActiveCertificates(ctx context.Context, t time.Time, usage crypto.KeyUsage) (certs *query.Certificates, err error)
+	ActiveSAMLServiceProviderByID(ctx context.Context, entityID string) (sp *query.SAMLServiceProvider, err error)
+	CIBARequestByID(ctx context.Context, id string, checkPermission bool) (model *query.CIBARequestReadModel, err error)
 
 	ActiveInstances() []string
 }
@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/key"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	samlxml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	zsaml "github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/command"
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SAMLBackChannelLogoutNotificationsProjectionTable = "projections.notifications_saml_back_channel_logout"
+
+	soapEnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soapAction            = "http://www.oasis-open.org/committees/security"
+	soapMaxResponseSize   = 1 << 20
+)
+
+type samlBackChannelLogoutNotifier struct {
+	commands         *command.Commands
+	queries          *NotificationQueries
+	eventstore       *eventstore.Eventstore
+	keyEncryptionAlg zcrypto.EncryptionAlgorithm
+	requestLifetime  time.Duration
+	enabled          bool
+	client           *http.Client
+}
+
+// NewSAMLBackChannelLogoutNotifier sends a LogoutRequest (SOAP binding) to all service providers,
+// where the user is logged in through a terminated session.
+func NewSAMLBackChannelLogoutNotifier(
+	ctx context.Context,
+	config handler.Config,
+	logoutConfig zsaml.BackChannelLogoutConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	es *eventstore.Eventstore,
+	keyEncryptionAlg zcrypto.EncryptionAlgorithm,
+	requestLifetime time.Duration,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &samlBackChannelLogoutNotifier{
+		commands:         commands,
+		queries:          queries,
+		eventstore:       es,
+		keyEncryptionAlg: keyEncryptionAlg,
+		requestLifetime:  requestLifetime,
+		enabled:          logoutConfig.Enabled,
+		client:           newSOAPClient(logoutConfig.Timeout),
+	})
+}
+
+// newSOAPClient returns the client for the requests to the service providers,
+// which does not follow redirects, as the endpoint is defined by the metadata.
+func newSOAPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (*samlBackChannelLogoutNotifier) Name() string {
+	return SAMLBackChannelLogoutNotificationsProjectionTable
+}
+
+func (u *samlBackChannelLogoutNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: session.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  session.TerminateType,
+					Reduce: u.reduceSessionTerminated,
+				},
+			},
+		}, {
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: u.reduceUserSignedOut,
+				},
+			},
+		},
+	}
+}
+
+func (u *samlBackChannelLogoutNotifier) reduceUserSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-t2s8wr5qmv", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		if !u.enabled || e.SessionID == "" {
+			return nil
+		}
+		ctx, err := u.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		return u.terminateSession(ctx, e.SessionID, e)
+	}), nil
+}
+
+func (u *samlBackChannelLogoutNotifier) reduceSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.TerminateEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-b0c6xh3pze", "reduce.wrong.event.type %s", session.TerminateType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		if !u.enabled {
+			return nil
+		}
+		ctx, err := u.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		return u.terminateSession(ctx, e.Aggregate().ID, e)
+	}), nil
+}
+
+func (u *samlBackChannelLogoutNotifier) terminateSession(ctx context.Context, id string, e eventstore.Event) error {
+	sessions := &samlBackChannelLogoutSession{sessionID: id}
+	err := u.eventstore.FilterToQueryReducer(ctx, sessions)
+	if err != nil {
+		return err
+	}
+	if len(sessions.sessions) == 0 {
+		return nil
+	}
+	certAndKey, err := u.signingCertificate(ctx)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, len(sessions.sessions))
+	for _, samlSession := range sessions.sessions {
+		failure, err := u.sendLogoutRequest(ctx, samlSession, certAndKey)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if failure != "" {
+			logging.WithFields("instanceID", e.Aggregate().InstanceID, "entityID", samlSession.EntityID, "reason", failure).
+				Info("saml back-channel logout failed")
+			errs = append(errs, u.commands.SAMLLogoutFailed(ctx, samlSession.SessionID, samlSession.SAMLSessionID, e.Aggregate().InstanceID, failure))
+			continue
+		}
+		errs = append(errs, u.commands.SAMLLogoutSent(ctx, samlSession.SessionID, samlSession.SAMLSessionID, e.Aggregate().InstanceID))
+	}
+	return errors.Join(errs...)
+}
+
+func (u *samlBackChannelLogoutNotifier) signingCertificate(ctx context.Context) (*key.CertificateAndKey, error) {
+	certs, err := u.queries.ActiveCertificates(ctx, time.Now(), zcrypto.KeyUsageSAMLResponseSinging)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs.Certificates) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "HANDL-k3r9cvw0yd", "no active saml signing certificate")
+	}
+	return zsaml.CertificateToCertificateAndKey(certs.Certificates[len(certs.Certificates)-1], u.keyEncryptionAlg)
+}
+
+// sendLogoutRequest sends the LogoutRequest to the SOAP endpoint of the service provider.
+// If the service provider cannot be notified, e.g. because it does not support the SOAP binding,
+// the reason is returned as failure. An error is only returned if the request should be retried.
+func (u *samlBackChannelLogoutNotifier) sendLogoutRequest(ctx context.Context, samlSession *samlBackChannelLogoutSAMLSession, certAndKey *key.CertificateAndKey) (failure string, err error) {
+	sp, err := u.queries.ActiveSAMLServiceProviderByID(ctx, samlSession.EntityID)
+	if zerrors.IsNotFound(err) {
+		return "service provider not found", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	metadata, err := samlxml.ParseMetadataXmlIntoStruct(sp.Metadata)
+	if err != nil {
+		return "invalid metadata of service provider", nil
+	}
+	var endpoint string
+	for _, service := range metadata.SPSSODescriptor.SingleLogoutService {
+		if service.Binding == zsaml.SOAPBinding {
+			endpoint = service.Location
+			break
+		}
+	}
+	if endpoint == "" {
+		return "service provider has no single logout service with SOAP binding", nil
+	}
+	request, err := zsaml.NewLogoutRequest(samlSession.Issuer, endpoint, samlSession.NameID, samlSession.NameIDFormat, samlSession.SessionIndex, u.requestLifetime, certAndKey)
+	if err != nil {
+		return "", err
+	}
+	response, data, err := sendSOAPLogoutRequest(ctx, u.client, endpoint, request)
+	if err != nil {
+		return "", err
+	}
+	if err = verifySOAPLogoutResponse(response, data, request.Id, samlSession.EntityID, metadata); err != nil {
+		return fmt.Sprintf("invalid logout response: %v", err), nil
+	}
+	if response.Status.StatusCode.Value != provider.StatusCodeSuccess {
+		return fmt.Sprintf("service provider responded with status %s: %s", response.Status.StatusCode.Value, response.Status.StatusMessage), nil
+	}
+	return "", nil
+}
+
+type soapLogoutResponseEnvelope struct {
+	XMLName xml.Name `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Body    struct {
+		LogoutResponse *samlp.LogoutResponseType `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutResponse"`
+	} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+}
+
+// sendSOAPLogoutRequest sends the request and returns the LogoutResponse and the raw SOAP message to verify its signature.
+func sendSOAPLogoutRequest(ctx context.Context, client *http.Client, endpoint string, request *zsaml.LogoutRequest) (*samlp.LogoutResponseType, []byte, error) {
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := new(bytes.Buffer)
+	body.WriteString(`<soap-env:Envelope xmlns:soap-env="` + soapEnvelopeNamespace + `"><soap-env:Body>`)
+	body.Write(data)
+	body.WriteString(`</soap-env:Body></soap-env:Envelope>`)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", soapAction)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	respData, err := io.ReadAll(io.LimitReader(resp.Body, soapMaxResponseSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, zerrors.ThrowInternalf(nil, "HANDL-q8z1ne4vfa", "saml logout request to %s didn't return a success status: %s", endpoint, resp.Status)
+	}
+	envelope := new(soapLogoutResponseEnvelope)
+	if err = xml.Unmarshal(respData, envelope); err != nil {
+		return nil, nil, zerrors.ThrowInternal(err, "HANDL-e5m2wq7jrc", "invalid saml logout response")
+	}
+	if envelope.Body.LogoutResponse == nil {
+		return nil, nil, zerrors.ThrowInternal(nil, "HANDL-y6hx0bd1tn", "saml logout response missing")
+	}
+	return envelope.Body.LogoutResponse, respData, nil
+}
+
+// verifySOAPLogoutResponse checks that the response was issued by the service provider for the request.
+// The signature is required if the service provider has a certificate registered,
+// otherwise the response is only bound to the TLS connection to the endpoint of its metadata.
+func verifySOAPLogoutResponse(response *samlp.LogoutResponseType, data []byte, requestID, entityID string, metadata *md.EntityDescriptorType) error {
+	if response.InResponseTo != requestID {
+		return errors.New("response to another request")
+	}
+	if response.Issuer == nil || response.Issuer.Text != entityID {
+		return errors.New("issued by another entity")
+	}
+	certs, err := signature.ParseCertificates(samlxml.GetCertsFromKeyDescriptors(metadata.SPSSODescriptor.KeyDescriptor))
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return nil
+	}
+	if response.Signature == nil {
+		return errors.New("signature required but missing")
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return err
+	}
+	element := doc.FindElement("./Envelope/Body/LogoutResponse")
+	if element == nil {
+		return errors.New("response missing")
+	}
+	return signature.ValidatePost(certs, element)
+}
+
+type samlBackChannelLogoutSession struct {
+	sessionID string
+
+	// sessions contain the saml sessions, which still need to be notified
+	sessions []*samlBackChannelLogoutSAMLSession
+}
+
+type samlBackChannelLogoutSAMLSession struct {
+	SessionID     string
+	SAMLSessionID string
+	EntityID      string
+	Issuer        string
+	NameID        string
+	NameIDFormat  string
+	SessionIndex  string
+}
+
+func (b *samlBackChannelLogoutSession) Reduce() error {
+	return nil
+}
+
+func (b *samlBackChannelLogoutSession) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *sessionlogout.SAMLLogoutRegisteredEvent:
+			b.sessions = append(b.sessions, &samlBackChannelLogoutSAMLSession{
+				SessionID:     b.sessionID,
+				SAMLSessionID: e.SAMLSessionID,
+				EntityID:      e.EntityID,
+				Issuer:        e.Issuer,
+				NameID:        e.NameID,
+				NameIDFormat:  e.NameIDFormat,
+				SessionIndex:  e.SessionIndex,
+			})
+		case *sessionlogout.SAMLLogoutRequestedEvent:
+			b.removeSession(e.SAMLSessionID)
+		case *sessionlogout.SAMLLogoutSentEvent:
+			b.removeSession(e.SAMLSessionID)
+		case *sessionlogout.SAMLLogoutFailedEvent:
+			b.removeSession(e.SAMLSessionID)
+		case *sessionlogout.SAMLFrontChannelLogoutStartedEvent:
+			for _, request := range e.Requests {
+				b.removeSession(request.SAMLSessionID)
+			}
+		}
+	}
+}
+
+func (b *samlBackChannelLogoutSession) removeSession(samlSessionID string) {
+	b.sessions = slices.DeleteFunc(b.sessions, func(session *samlBackChannelLogoutSAMLSession) bool {
+		return session.SAMLSessionID == samlSessionID
+	})
+}
+
+func (b *samlBackChannelLogoutSession) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(sessionlogout.AggregateType).
+		AggregateIDs(b.sessionID).
+		EventTypes(
+			sessionlogout.SAMLLogoutRegisteredType,
+			sessionlogout.SAMLLogoutRequestedType,
+			sessionlogout.SAMLLogoutSentType,
+			sessionlogout.SAMLLogoutFailedType,
+			sessionlogout.SAMLFrontChannelLogoutStartedType,
+		).
+		Builder()
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	zsaml "github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
+)
+
+func Test_sendSOAPLogoutRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			response: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>` +
+				`<samlp:LogoutResponse xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="id" InResponseTo="requestID" Version="2.0" IssueInstant="2024-01-01T00:00:00Z">` +
+				`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+				`</samlp:LogoutResponse></soap:Body></soap:Envelope>`,
+			wantStatus: provider.StatusCodeSuccess,
+		},
+		{
+			name:   "denied",
+			status: http.StatusOK,
+			response: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>` +
+				`<samlp:LogoutResponse xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="id" InResponseTo="requestID" Version="2.0" IssueInstant="2024-01-01T00:00:00Z">` +
+				`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:RequestDenied"/></samlp:Status>` +
+				`</samlp:LogoutResponse></soap:Body></soap:Envelope>`,
+			wantStatus: provider.StatusCodeRequestDenied,
+		},
+		{
+			name:     "missing response, error",
+			status:   http.StatusOK,
+			response: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body></soap:Body></soap:Envelope>`,
+			wantErr:  true,
+		},
+		{
+			name:    "server error, error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "text/xml; charset=utf-8", r.Header.Get("Content-Type"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				envelope := new(struct {
+					Body struct {
+						LogoutRequest *zsaml.LogoutRequest `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+					} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+				})
+				require.NoError(t, xml.Unmarshal(body, envelope))
+				require.NotNil(t, envelope.Body.LogoutRequest)
+				assert.Equal(t, "user@example.com", envelope.Body.LogoutRequest.NameID.Text)
+				assert.Equal(t, []string{"sessionIndex"}, envelope.Body.LogoutRequest.SessionIndex)
+
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			got, _, err := sendSOAPLogoutRequest(context.Background(), server.Client(), server.URL, &zsaml.LogoutRequest{
+				Id:           "requestID",
+				Version:      "2.0",
+				Issuer:       &saml.NameIDType{Text: "issuer"},
+				NameID:       &saml.NameIDType{Text: "user@example.com"},
+				SessionIndex: []string{"sessionIndex"},
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "requestID", got.InResponseTo)
+			assert.Equal(t, tt.wantStatus, got.Status.StatusCode.Value)
+		})
+	}
+}
+
+func Test_samlBackChannelLogoutSession_AppendEvents(t *testing.T) {
+	aggregate := &sessionlogout.NewAggregate("sessionID", "instanceID").Aggregate
+	sessions := &samlBackChannelLogoutSession{sessionID: "sessionID"}
+	sessions.AppendEvents(
+		registeredEvent(aggregate, "samlSession1"),
+		registeredEvent(aggregate, "samlSession2"),
+		registeredEvent(aggregate, "samlSession3"),
+		registeredEvent(aggregate, "samlSession4"),
+		sessionlogout.NewSAMLLogoutRequestedEvent(context.Background(), aggregate, "samlSession1"),
+		sessionlogout.NewSAMLLogoutSentEvent(context.Background(), aggregate, "samlSession2"),
+		sessionlogout.NewSAMLLogoutFailedEvent(context.Background(), aggregate, "samlSession3", "reason"),
+		registeredEvent(aggregate, "samlSession5"),
+		sessionlogout.NewSAMLFrontChannelLogoutStartedEvent(context.Background(), aggregate, "userAgentID", "entityID", "requestID", "responseURL", "binding", "",
+			[]*sessionlogout.SAMLFrontChannelLogoutRequest{{SAMLSessionID: "samlSession5", RequestID: "_id"}},
+		),
+	)
+	require.Len(t, sessions.sessions, 1)
+	assert.Equal(t, &samlBackChannelLogoutSAMLSession{
+		SessionID:     "sessionID",
+		SAMLSessionID: "samlSession4",
+		EntityID:      "entityID",
+		Issuer:        "issuer",
+		NameID:        "user@example.com",
+		SessionIndex:  "sessionIndex",
+	}, sessions.sessions[0])
+}
+
+func Test_verifySOAPLogoutResponse(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "entityID"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	newResponse := func(signed bool) (*samlp.LogoutResponseType, []byte) {
+		response := &samlp.LogoutResponseType{
+			Id:           "_responseID",
+			InResponseTo: "requestID",
+			Version:      "2.0",
+			IssueInstant: "2024-01-01T00:00:00Z",
+			Issuer:       &saml.NameIDType{Text: "entityID"},
+			Status: samlp.StatusType{
+				StatusCode: samlp.StatusCodeType{Value: provider.StatusCodeSuccess},
+			},
+		}
+		if signed {
+			signer, err := signature.GetSigner(certDER, privateKey, "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256")
+			require.NoError(t, err)
+			response.Signature, err = signature.Create(signer, response)
+			require.NoError(t, err)
+		}
+		data, err := xml.Marshal(response)
+		require.NoError(t, err)
+		return response, []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>` + string(data) + `</soap:Body></soap:Envelope>`)
+	}
+	metadata := func(certificates ...string) *md.EntityDescriptorType {
+		descriptors := make([]md.KeyDescriptorType, len(certificates))
+		for i, certificate := range certificates {
+			descriptors[i] = md.KeyDescriptorType{
+				KeyInfo: xml_dsig.KeyInfoType{X509Data: []xml_dsig.X509DataType{{X509Certificate: certificate}}},
+			}
+		}
+		return &md.EntityDescriptorType{SPSSODescriptor: &md.SPSSODescriptorType{KeyDescriptor: descriptors}}
+	}
+	certificate := base64.StdEncoding.EncodeToString(certDER)
+
+	tests := []struct {
+		name      string
+		signed    bool
+		requestID string
+		entityID  string
+		metadata  *md.EntityDescriptorType
+		wantErr   bool
+	}{
+		{
+			name:      "other request, error",
+			requestID: "otherRequestID",
+			entityID:  "entityID",
+			metadata:  metadata(),
+			wantErr:   true,
+		},
+		{
+			name:      "other issuer, error",
+			requestID: "requestID",
+			entityID:  "otherEntityID",
+			metadata:  metadata(),
+			wantErr:   true,
+		},
+		{
+			name:      "unsigned without certificate, ok",
+			requestID: "requestID",
+			entityID:  "entityID",
+			metadata:  metadata(),
+		},
+		{
+			name:      "unsigned with certificate, error",
+			requestID: "requestID",
+			entityID:  "entityID",
+			metadata:  metadata(certificate),
+			wantErr:   true,
+		},
+		{
+			name:      "signed with certificate, ok",
+			signed:    true,
+			requestID: "requestID",
+			entityID:  "entityID",
+			metadata:  metadata(certificate),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, data := newResponse(tt.signed)
+			err := verifySOAPLogoutResponse(response, data, tt.requestID, tt.entityID, tt.metadata)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func registeredEvent(aggregate *eventstore.Aggregate, samlSessionID string) eventstore.Event {
+	return sessionlogout.NewSAMLLogoutRegisteredEvent(context.Background(), aggregate, samlSessionID, "userID", "entityID", "issuer", "user@example.com", "", "sessionIndex")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
@@ -22,9 +23,10 @@ var (
 
 func Register(
 	ctx context.Context,
-	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, backChannelLogoutHandlerCustomConfig projection.CustomConfig,
+	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, backChannelLogoutHandlerCustomConfig, cibaHandlerCustomConfig projection.CustomConfig,
 	notificationWorkerConfig handlers.WorkerConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	samlBackChannelLogoutConfig saml.BackChannelLogoutConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -50,6 +52,17 @@ func Register(
 		c,
 		tokenLifetime,
 	))
+	projections = append(projections, handlers.NewSAMLBackChannelLogoutNotifier(
+		ctx,
+		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
+		samlBackChannelLogoutConfig,
+		commands,
+		q,
+		es,
+		keysEncryptionAlg,
+		tokenLifetime,
+	))
+	projections = append(projections, handlers.NewCIBANotifier(ctx, projection.ApplyCustomConfig(cibaHandlerCustomConfig), q, c, keysEncryptionAlg))
 	if telemetryCfg.Enabled {
 		projections = append(projections, handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c))
 	}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func (notify Notify) SendBackchannelAuthentication(ctx context.Context, url, bindingMessage string) error {
+	args := make(map[string]interface{})
+	args["BindingMessage"] = bindingMessage
+	return notify(url, args, domain.BackchannelAuthenticationMessageType, false)
+}
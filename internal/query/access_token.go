@@ -35,6 +35,8 @@ type OIDCSessionAccessTokenReadModel struct {
 	UserAgent             *domain.UserAgent
 	Reason                domain.TokenReason
 	Actor                 *domain.TokenActor
+	DPoPJKT               string
+	AuthorizationDetails  domain.AuthorizationDetails
 }
 
 func newOIDCSessionAccessTokenReadModel(id string) *OIDCSessionAccessTokenReadModel {
@@ -94,6 +96,8 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceAccessTokenAdded(e *oidcsession
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.Reason = e.Reason
 	wm.Actor = e.Actor
+	wm.DPoPJKT = e.DPoPJKT
+	wm.AuthorizationDetails = e.AuthorizationDetails
 }
 
 func (wm *OIDCSessionAccessTokenReadModel) reduceTokenRevoked(e eventstore.Event) {
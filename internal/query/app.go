@@ -63,6 +63,9 @@ type OIDCApp struct {
 	BackChannelLogoutURI     string
 	LoginVersion             domain.LoginVersion
 	LoginBaseURI             *string
+	RequirePAR               bool
+	RequireDPoP              bool
+	CIBANotificationURI      string
 }
 
 type SAMLApp struct {
@@ -277,6 +280,18 @@ var (
 		name:  projection.AppOIDCConfigColumnLoginBaseURI,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRequirePAR = Column{
+		name:  projection.AppOIDCConfigColumnRequirePAR,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireDPoP = Column{
+		name:  projection.AppOIDCConfigColumnRequireDPoP,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnCIBANotificationURI = Column{
+		name:  projection.AppOIDCConfigColumnCIBANotificationURI,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -481,10 +496,20 @@ func (q *Queries) AppByOIDCClientID(ctx context.Context, clientID string) (app *
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if cached, ok := q.caches.app.Get(ctx, appIndexByOIDCClientID, appCacheKey(instanceID, clientID)); ok {
+		return cached.App, nil
+	}
+	defer func() {
+		if err == nil && app != nil {
+			q.caches.app.Set(ctx, &cachedApp{InstanceID: instanceID, App: app})
+		}
+	}()
+
 	stmt, scan := prepareOIDCAppQuery()
 	eq := sq.Eq{
 		AppOIDCConfigColumnClientID.identifier(): clientID,
-		AppColumnInstanceID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		AppColumnInstanceID.identifier():         instanceID,
 	}
 	query, args, err := stmt.Where(eq).ToSql()
 	if err != nil {
@@ -668,6 +693,9 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase, activeOnly bool) (
 		AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 		AppOIDCConfigColumnLoginVersion.identifier(),
 		AppOIDCConfigColumnLoginBaseURI.identifier(),
+		AppOIDCConfigColumnRequirePAR.identifier(),
+		AppOIDCConfigColumnRequireDPoP.identifier(),
+		AppOIDCConfigColumnCIBANotificationURI.identifier(),
 
 		AppSAMLConfigColumnAppID.identifier(),
 		AppSAMLConfigColumnEntityID.identifier(),
@@ -737,6 +765,9 @@ func scanApp(row *sql.Row) (*App, error) {
 		&oidcConfig.backChannelLogoutURI,
 		&oidcConfig.loginVersion,
 		&oidcConfig.loginBaseURI,
+		&oidcConfig.requirePAR,
+		&oidcConfig.requireDPoP,
+		&oidcConfig.cibaNotificationURI,
 
 		&samlConfig.appID,
 		&samlConfig.entityID,
@@ -791,6 +822,9 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRequirePAR.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnCIBANotificationURI.identifier(),
 		).From(appsTable.identifier()).
 			Join(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*App, error) {
@@ -830,6 +864,9 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.backChannelLogoutURI,
 				&oidcConfig.loginVersion,
 				&oidcConfig.loginBaseURI,
+				&oidcConfig.requirePAR,
+				&oidcConfig.requireDPoP,
+				&oidcConfig.cibaNotificationURI,
 			)
 
 			if err != nil {
@@ -881,6 +918,7 @@ func prepareProjectByOIDCAppQuery(ctx context.Context, db prepareDatabase) (sq.S
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
 			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAuthorizationDetailsTypes.identifier(),
 		).From(projectsTable.identifier()).
 			Join(join(AppColumnProjectID, ProjectColumnID)).
 			Join(join(AppOIDCConfigColumnAppID, AppColumnID)).
@@ -899,6 +937,7 @@ func prepareProjectByOIDCAppQuery(ctx context.Context, db prepareDatabase) (sq.S
 				&p.ProjectRoleCheck,
 				&p.HasProjectCheck,
 				&p.PrivateLabelingSetting,
+				&p.AuthorizationDetailsTypes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -923,6 +962,7 @@ func prepareProjectByAppQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
 			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAuthorizationDetailsTypes.identifier(),
 		).From(projectsTable.identifier()).
 			Join(join(AppColumnProjectID, ProjectColumnID)).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
@@ -943,6 +983,7 @@ func prepareProjectByAppQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				&p.ProjectRoleCheck,
 				&p.HasProjectCheck,
 				&p.PrivateLabelingSetting,
+				&p.AuthorizationDetailsTypes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -989,6 +1030,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRequirePAR.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnCIBANotificationURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -1046,6 +1090,9 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.backChannelLogoutURI,
 					&oidcConfig.loginVersion,
 					&oidcConfig.loginBaseURI,
+					&oidcConfig.requirePAR,
+					&oidcConfig.requireDPoP,
+					&oidcConfig.cibaNotificationURI,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -1153,6 +1200,9 @@ type sqlOIDCConfig struct {
 	backChannelLogoutURI     sql.NullString
 	loginVersion             sql.NullInt16
 	loginBaseURI             sql.NullString
+	requirePAR               sql.NullBool
+	requireDPoP              sql.NullBool
+	cibaNotificationURI      sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -1178,6 +1228,9 @@ func (c sqlOIDCConfig) set(app *App) {
 		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
 		BackChannelLogoutURI:     c.backChannelLogoutURI.String,
 		LoginVersion:             domain.LoginVersion(c.loginVersion.Int16),
+		RequirePAR:               c.requirePAR.Bool,
+		RequireDPoP:              c.requireDPoP.Bool,
+		CIBANotificationURI:      c.cibaNotificationURI.String,
 	}
 	if c.loginBaseURI.Valid {
 		app.OIDCConfig.LoginBaseURI = &c.loginBaseURI.String
@@ -1229,3 +1282,46 @@ func (c sqlAPIConfig) set(app *App) {
 		AuthMethodType: domain.APIAuthMethodType(c.authMethod.Int16),
 	}
 }
+
+type appIndex int
+
+//go:generate enumer -type appIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	appIndexUnspecified appIndex = iota //
+	appIndexByOIDCClientID
+	appIndexByProjectID
+)
+
+// cachedApp is the cache entry of an application.
+// Client IDs are only unique inside an instance, so they are combined with the instance ID.
+type cachedApp struct {
+	InstanceID string `json:"instance_id"`
+	App        *App   `json:"app"`
+}
+
+func appCacheKey(instanceID, clientID string) string {
+	return instanceID + "/" + clientID
+}
+
+// Keys implements [cache.Entry]
+func (a *cachedApp) Keys(index appIndex) []string {
+	switch index {
+	case appIndexByOIDCClientID:
+		if a.App.OIDCConfig == nil {
+			return nil
+		}
+		return []string{appCacheKey(a.InstanceID, a.App.OIDCConfig.ClientID)}
+	case appIndexByProjectID:
+		return []string{a.App.ProjectID}
+	case appIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerAppInvalidation() {
+	// apps are events of the project aggregate.
+	invalidate := cacheInvalidationFunc(c.app, appIndexByProjectID, getAggregateID)
+	projection.AppProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+}
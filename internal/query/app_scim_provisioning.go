@@ -0,0 +1,381 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	appSCIMProvisioningTable = table{
+		name:          projection.AppSCIMProvisioningTable,
+		instanceIDCol: projection.AppSCIMProvisioningInstanceIDCol,
+	}
+	AppSCIMProvisioningColumnAppID = Column{
+		name:  projection.AppSCIMProvisioningAppIDCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnInstanceID = Column{
+		name:  projection.AppSCIMProvisioningInstanceIDCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnProjectID = Column{
+		name:  projection.AppSCIMProvisioningProjectIDCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnResourceOwner = Column{
+		name:  projection.AppSCIMProvisioningResourceOwnerCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnCreationDate = Column{
+		name:  projection.AppSCIMProvisioningCreationDateCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnChangeDate = Column{
+		name:  projection.AppSCIMProvisioningChangeDateCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnSequence = Column{
+		name:  projection.AppSCIMProvisioningSequenceCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnEndpoint = Column{
+		name:  projection.AppSCIMProvisioningEndpointCol,
+		table: appSCIMProvisioningTable,
+	}
+	AppSCIMProvisioningColumnToken = Column{
+		name:  projection.AppSCIMProvisioningTokenCol,
+		table: appSCIMProvisioningTable,
+	}
+)
+
+var (
+	appSCIMProvisioningUserTable = table{
+		name:          projection.AppSCIMProvisioningTable + "_" + projection.AppSCIMProvisioningUserTableSuffix,
+		instanceIDCol: projection.AppSCIMProvisioningUserInstanceIDCol,
+	}
+	AppSCIMProvisioningUserColumnAppID = Column{
+		name:  projection.AppSCIMProvisioningUserAppIDCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnInstanceID = Column{
+		name:  projection.AppSCIMProvisioningUserInstanceIDCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnUserID = Column{
+		name:  projection.AppSCIMProvisioningUserUserIDCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnRemoteID = Column{
+		name:  projection.AppSCIMProvisioningUserRemoteIDCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnState = Column{
+		name:  projection.AppSCIMProvisioningUserStateCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnError = Column{
+		name:  projection.AppSCIMProvisioningUserErrorCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnCreationDate = Column{
+		name:  projection.AppSCIMProvisioningUserCreationDateCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnChangeDate = Column{
+		name:  projection.AppSCIMProvisioningUserChangeDateCol,
+		table: appSCIMProvisioningUserTable,
+	}
+	AppSCIMProvisioningUserColumnSequence = Column{
+		name:  projection.AppSCIMProvisioningUserSequenceCol,
+		table: appSCIMProvisioningUserTable,
+	}
+)
+
+// SCIMProvisioning is the scim service provider the users granted on the project of the app are provisioned to.
+type SCIMProvisioning struct {
+	AppID         string
+	ProjectID     string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	Endpoint      string
+	token         *crypto.CryptoValue
+	// Token is the decrypted bearer token sent to the scim service provider
+	Token string
+}
+
+func (p *SCIMProvisioning) decryptToken(alg crypto.EncryptionAlgorithm) error {
+	if p.token == nil {
+		return nil
+	}
+	token, err := crypto.DecryptString(p.token, alg)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "QUERY-p2y8cvd0ur", "Errors.Internal")
+	}
+	p.Token = token
+	return nil
+}
+
+type SCIMProvisionings struct {
+	SearchResponse
+	Provisionings []*SCIMProvisioning
+}
+
+// SCIMProvisioningUserState is the result of the last provisioning of a user to the scim service provider of an app.
+type SCIMProvisioningUserState struct {
+	AppID string
+	// UserID is the id of the user in zitadel
+	UserID string
+	// RemoteID is the id assigned to the user by the scim service provider
+	RemoteID     string
+	State        domain.SCIMProvisioningState
+	Error        string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+}
+
+type SCIMProvisioningUserStates struct {
+	SearchResponse
+	UserStates []*SCIMProvisioningUserState
+}
+
+func (s *SCIMProvisioningUserStates) SetState(state *State) {
+	s.State = state
+}
+
+type SCIMProvisioningUserStateSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SCIMProvisioningUserStateSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SCIMProvisioningByAppID returns the scim provisioning of the app including the decrypted token.
+func (q *Queries) SCIMProvisioningByAppID(ctx context.Context, appID string) (_ *SCIMProvisioning, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSCIMProvisioningQuery(ctx, q.client)
+	provisioning, err := genericRowQuery[*SCIMProvisioning](ctx, q.client, query.Where(sq.Eq{
+		AppSCIMProvisioningColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		AppSCIMProvisioningColumnAppID.identifier():      appID,
+	}), scan)
+	if err != nil {
+		return nil, err
+	}
+	if err := provisioning.decryptToken(q.targetEncryptionAlgorithm); err != nil {
+		return nil, err
+	}
+	return provisioning, nil
+}
+
+// SCIMProvisioningsByProjectIDs returns the scim provisionings of all apps of the projects including the decrypted tokens.
+func (q *Queries) SCIMProvisioningsByProjectIDs(ctx context.Context, shouldTriggerBulk bool, projectIDs []string) (_ *SCIMProvisionings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = q.triggerAppSCIMProvisioningProjection(ctx)
+	}
+
+	query, scan := prepareSCIMProvisioningsQuery(ctx, q.client)
+	provisionings, err := genericRowsQuery[*SCIMProvisionings](ctx, q.client, query.Where(sq.Eq{
+		AppSCIMProvisioningColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		AppSCIMProvisioningColumnProjectID.identifier():  projectIDs,
+	}), scan)
+	if err != nil {
+		return nil, err
+	}
+	for _, provisioning := range provisionings.Provisionings {
+		if err := provisioning.decryptToken(q.targetEncryptionAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+	return provisionings, nil
+}
+
+func (q *Queries) SearchSCIMProvisioningUserStates(ctx context.Context, shouldTriggerBulk bool, queries *SCIMProvisioningUserStateSearchQueries) (_ *SCIMProvisioningUserStates, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = q.triggerAppSCIMProvisioningProjection(ctx)
+	}
+
+	eq := sq.Eq{
+		AppSCIMProvisioningUserColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareSCIMProvisioningUserStatesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*SCIMProvisioningUserStates](ctx, q.client, appSCIMProvisioningTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func (q *Queries) triggerAppSCIMProvisioningProjection(ctx context.Context) context.Context {
+	_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerAppSCIMProvisioningProjection")
+	ctx, err := projection.AppSCIMProvisioningProjection.Trigger(ctx, handler.WithAwaitRunning())
+	logging.OnError(err).Debug("unable to trigger")
+	traceSpan.EndWithError(err)
+	return ctx
+}
+
+func NewSCIMProvisioningUserStateAppIDSearchQuery(appID string) (SearchQuery, error) {
+	return NewTextQuery(AppSCIMProvisioningUserColumnAppID, appID, TextEquals)
+}
+
+func NewSCIMProvisioningUserStateUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(AppSCIMProvisioningUserColumnUserID, userID, TextEquals)
+}
+
+func NewSCIMProvisioningUserStateStateSearchQuery(state domain.SCIMProvisioningState) (SearchQuery, error) {
+	return NewNumberQuery(AppSCIMProvisioningUserColumnState, state, NumberEquals)
+}
+
+func prepareSCIMProvisioningQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*SCIMProvisioning, error)) {
+	return sq.Select(
+			AppSCIMProvisioningColumnAppID.identifier(),
+			AppSCIMProvisioningColumnProjectID.identifier(),
+			AppSCIMProvisioningColumnResourceOwner.identifier(),
+			AppSCIMProvisioningColumnCreationDate.identifier(),
+			AppSCIMProvisioningColumnChangeDate.identifier(),
+			AppSCIMProvisioningColumnSequence.identifier(),
+			AppSCIMProvisioningColumnEndpoint.identifier(),
+			AppSCIMProvisioningColumnToken.identifier(),
+		).From(appSCIMProvisioningTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*SCIMProvisioning, error) {
+			provisioning := new(SCIMProvisioning)
+			err := row.Scan(
+				&provisioning.AppID,
+				&provisioning.ProjectID,
+				&provisioning.ResourceOwner,
+				&provisioning.CreationDate,
+				&provisioning.ChangeDate,
+				&provisioning.Sequence,
+				&provisioning.Endpoint,
+				&provisioning.token,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-9zcrj1qd2x", "Errors.Project.App.SCIMProvisioning.NotExisting")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-uo3x9rq5vk", "Errors.Internal")
+			}
+			return provisioning, nil
+		}
+}
+
+func prepareSCIMProvisioningsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*SCIMProvisionings, error)) {
+	return sq.Select(
+			AppSCIMProvisioningColumnAppID.identifier(),
+			AppSCIMProvisioningColumnProjectID.identifier(),
+			AppSCIMProvisioningColumnResourceOwner.identifier(),
+			AppSCIMProvisioningColumnCreationDate.identifier(),
+			AppSCIMProvisioningColumnChangeDate.identifier(),
+			AppSCIMProvisioningColumnSequence.identifier(),
+			AppSCIMProvisioningColumnEndpoint.identifier(),
+			AppSCIMProvisioningColumnToken.identifier(),
+			countColumn.identifier(),
+		).From(appSCIMProvisioningTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SCIMProvisionings, error) {
+			provisionings := make([]*SCIMProvisioning, 0)
+			var count uint64
+			for rows.Next() {
+				provisioning := new(SCIMProvisioning)
+				err := rows.Scan(
+					&provisioning.AppID,
+					&provisioning.ProjectID,
+					&provisioning.ResourceOwner,
+					&provisioning.CreationDate,
+					&provisioning.ChangeDate,
+					&provisioning.Sequence,
+					&provisioning.Endpoint,
+					&provisioning.token,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				provisionings = append(provisionings, provisioning)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-h4t0mdb7ew", "Errors.Query.CloseRows")
+			}
+
+			return &SCIMProvisionings{
+				Provisionings: provisionings,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareSCIMProvisioningUserStatesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*SCIMProvisioningUserStates, error)) {
+	return sq.Select(
+			AppSCIMProvisioningUserColumnAppID.identifier(),
+			AppSCIMProvisioningUserColumnUserID.identifier(),
+			AppSCIMProvisioningUserColumnRemoteID.identifier(),
+			AppSCIMProvisioningUserColumnState.identifier(),
+			AppSCIMProvisioningUserColumnError.identifier(),
+			AppSCIMProvisioningUserColumnCreationDate.identifier(),
+			AppSCIMProvisioningUserColumnChangeDate.identifier(),
+			AppSCIMProvisioningUserColumnSequence.identifier(),
+			countColumn.identifier(),
+		).From(appSCIMProvisioningUserTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SCIMProvisioningUserStates, error) {
+			states := make([]*SCIMProvisioningUserState, 0)
+			var count uint64
+			for rows.Next() {
+				state := new(SCIMProvisioningUserState)
+				err := rows.Scan(
+					&state.AppID,
+					&state.UserID,
+					&state.RemoteID,
+					&state.State,
+					&state.Error,
+					&state.CreationDate,
+					&state.ChangeDate,
+					&state.Sequence,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				states = append(states, state)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-6bq1wz0y8n", "Errors.Query.CloseRows")
+			}
+
+			return &SCIMProvisioningUserStates{
+				UserStates: states,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,302 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareSCIMProvisioningStmt = `SELECT projections.apps_scim_provisioning.app_id,` +
+		` projections.apps_scim_provisioning.project_id,` +
+		` projections.apps_scim_provisioning.resource_owner,` +
+		` projections.apps_scim_provisioning.creation_date,` +
+		` projections.apps_scim_provisioning.change_date,` +
+		` projections.apps_scim_provisioning.sequence,` +
+		` projections.apps_scim_provisioning.endpoint,` +
+		` projections.apps_scim_provisioning.token` +
+		` FROM projections.apps_scim_provisioning`
+	prepareSCIMProvisioningCols = []string{
+		"app_id",
+		"project_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"endpoint",
+		"token",
+	}
+
+	prepareSCIMProvisioningsStmt = `SELECT projections.apps_scim_provisioning.app_id,` +
+		` projections.apps_scim_provisioning.project_id,` +
+		` projections.apps_scim_provisioning.resource_owner,` +
+		` projections.apps_scim_provisioning.creation_date,` +
+		` projections.apps_scim_provisioning.change_date,` +
+		` projections.apps_scim_provisioning.sequence,` +
+		` projections.apps_scim_provisioning.endpoint,` +
+		` projections.apps_scim_provisioning.token,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.apps_scim_provisioning`
+	prepareSCIMProvisioningsCols = append(prepareSCIMProvisioningCols, "count")
+
+	prepareSCIMProvisioningUserStatesStmt = `SELECT projections.apps_scim_provisioning_users.app_id,` +
+		` projections.apps_scim_provisioning_users.user_id,` +
+		` projections.apps_scim_provisioning_users.remote_id,` +
+		` projections.apps_scim_provisioning_users.state,` +
+		` projections.apps_scim_provisioning_users.error,` +
+		` projections.apps_scim_provisioning_users.creation_date,` +
+		` projections.apps_scim_provisioning_users.change_date,` +
+		` projections.apps_scim_provisioning_users.sequence,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.apps_scim_provisioning_users`
+	prepareSCIMProvisioningUserStatesCols = []string{
+		"app_id",
+		"user_id",
+		"remote_id",
+		"state",
+		"error",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"count",
+	}
+)
+
+func Test_SCIMProvisioningPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	token := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "alg",
+		KeyID:      "encKey",
+		Crypted:    []byte("crypted"),
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSCIMProvisioningQuery no result",
+			prepare: prepareSCIMProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareSCIMProvisioningStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SCIMProvisioning)(nil),
+		},
+		{
+			name:    "prepareSCIMProvisioningQuery found",
+			prepare: prepareSCIMProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSCIMProvisioningStmt),
+					prepareSCIMProvisioningCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"https://example.com/scim/v2",
+						token,
+					},
+				),
+			},
+			object: &SCIMProvisioning{
+				AppID:         "app-id",
+				ProjectID:     "project-id",
+				ResourceOwner: "ro",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				Endpoint:      "https://example.com/scim/v2",
+				token:         token,
+			},
+		},
+		{
+			name:    "prepareSCIMProvisioningQuery sql err",
+			prepare: prepareSCIMProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSCIMProvisioningStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SCIMProvisioning)(nil),
+		},
+		{
+			name:    "prepareSCIMProvisioningsQuery no result",
+			prepare: prepareSCIMProvisioningsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSCIMProvisioningsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SCIMProvisionings{Provisionings: []*SCIMProvisioning{}},
+		},
+		{
+			name:    "prepareSCIMProvisioningsQuery one result",
+			prepare: prepareSCIMProvisioningsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSCIMProvisioningsStmt),
+					prepareSCIMProvisioningsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"project-id",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"https://example.com/scim/v2",
+							token,
+						},
+					},
+				),
+			},
+			object: &SCIMProvisionings{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Provisionings: []*SCIMProvisioning{
+					{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						ResourceOwner: "ro",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211109,
+						Endpoint:      "https://example.com/scim/v2",
+						token:         token,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSCIMProvisioningsQuery sql err",
+			prepare: prepareSCIMProvisioningsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSCIMProvisioningsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SCIMProvisionings)(nil),
+		},
+		{
+			name:    "prepareSCIMProvisioningUserStatesQuery multiple result",
+			prepare: prepareSCIMProvisioningUserStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSCIMProvisioningUserStatesStmt),
+					prepareSCIMProvisioningUserStatesCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"user-id-1",
+							"remote-id",
+							domain.SCIMProvisioningStateProvisioned,
+							"",
+							testNow,
+							testNow,
+							uint64(20211109),
+						},
+						{
+							"app-id",
+							"user-id-2",
+							"",
+							domain.SCIMProvisioningStateFailed,
+							"status 500",
+							testNow,
+							testNow,
+							uint64(20211109),
+						},
+					},
+				),
+			},
+			object: &SCIMProvisioningUserStates{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				UserStates: []*SCIMProvisioningUserState{
+					{
+						AppID:        "app-id",
+						UserID:       "user-id-1",
+						RemoteID:     "remote-id",
+						State:        domain.SCIMProvisioningStateProvisioned,
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211109,
+					},
+					{
+						AppID:        "app-id",
+						UserID:       "user-id-2",
+						State:        domain.SCIMProvisioningStateFailed,
+						Error:        "status 500",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211109,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSCIMProvisioningUserStatesQuery sql err",
+			prepare: prepareSCIMProvisioningUserStatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSCIMProvisioningUserStatesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SCIMProvisioningUserStates)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
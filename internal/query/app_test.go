@@ -52,6 +52,9 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.require_par,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.ciba_notification_uri,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -100,6 +103,9 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.require_par,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.ciba_notification_uri,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -135,7 +141,8 @@ var (
 		` projections.projects4.project_role_assertion,` +
 		` projections.projects4.project_role_check,` +
 		` projections.projects4.has_project_check,` +
-		` projections.projects4.private_labeling_setting` +
+		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.authorization_details_types` +
 		` FROM projections.projects4` +
 		` JOIN projections.apps7 ON projections.projects4.id = projections.apps7.project_id AND projections.projects4.instance_id = projections.apps7.instance_id` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
@@ -177,6 +184,9 @@ var (
 		"back_channel_logout_uri",
 		"login_version",
 		"login_base_uri",
+		"require_par",
+		"require_dpop",
+		"ciba_notification_uri",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -253,6 +263,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -324,6 +337,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -398,6 +414,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -474,6 +493,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -568,6 +590,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -662,6 +687,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -756,6 +784,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -850,6 +881,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -944,6 +978,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1038,6 +1075,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersion2,
 							"https://login.ch/",
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1080,6 +1120,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1122,6 +1165,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1307,6 +1353,9 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1372,6 +1421,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1442,6 +1494,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1531,6 +1586,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1620,6 +1678,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1693,6 +1754,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1782,6 +1846,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1871,6 +1938,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -1960,6 +2030,9 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							"",
 							// saml config
 							nil,
 							nil,
@@ -2232,21 +2305,23 @@ func Test_ProjectByAppPrepare(t *testing.T) {
 						true,
 						true,
 						domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "project-id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				Sequence:               20211109,
-				Name:                   "project-name",
-				State:                  domain.ProjectStateInactive,
-				ProjectRoleAssertion:   true,
-				ProjectRoleCheck:       true,
-				HasProjectCheck:        true,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				ID:                        "project-id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				Sequence:                  20211109,
+				Name:                      "project-name",
+				State:                     domain.ProjectStateInactive,
+				ProjectRoleAssertion:      true,
+				ProjectRoleCheck:          true,
+				HasProjectCheck:           true,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
@@ -2268,21 +2343,23 @@ func Test_ProjectByAppPrepare(t *testing.T) {
 						true,
 						true,
 						domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "project-id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				Sequence:               20211109,
-				Name:                   "project-name",
-				State:                  domain.ProjectStateInactive,
-				ProjectRoleAssertion:   false,
-				ProjectRoleCheck:       true,
-				HasProjectCheck:        true,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				ID:                        "project-id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				Sequence:                  20211109,
+				Name:                      "project-name",
+				State:                     domain.ProjectStateInactive,
+				ProjectRoleAssertion:      false,
+				ProjectRoleCheck:          true,
+				HasProjectCheck:           true,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
@@ -2304,21 +2381,23 @@ func Test_ProjectByAppPrepare(t *testing.T) {
 						false,
 						true,
 						domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "project-id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				Sequence:               20211109,
-				Name:                   "project-name",
-				State:                  domain.ProjectStateInactive,
-				ProjectRoleAssertion:   true,
-				ProjectRoleCheck:       false,
-				HasProjectCheck:        true,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				ID:                        "project-id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				Sequence:                  20211109,
+				Name:                      "project-name",
+				State:                     domain.ProjectStateInactive,
+				ProjectRoleAssertion:      true,
+				ProjectRoleCheck:          false,
+				HasProjectCheck:           true,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
@@ -2340,21 +2419,23 @@ func Test_ProjectByAppPrepare(t *testing.T) {
 						true,
 						false,
 						domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "project-id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				Sequence:               20211109,
-				Name:                   "project-name",
-				State:                  domain.ProjectStateInactive,
-				ProjectRoleAssertion:   true,
-				ProjectRoleCheck:       true,
-				HasProjectCheck:        false,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				ID:                        "project-id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				Sequence:                  20211109,
+				Name:                      "project-name",
+				State:                     domain.ProjectStateInactive,
+				ProjectRoleAssertion:      true,
+				ProjectRoleCheck:          true,
+				HasProjectCheck:           false,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
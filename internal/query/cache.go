@@ -9,12 +9,19 @@ import (
 
 	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/cache/connector"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
 type Caches struct {
-	instance cache.Cache[instanceIndex, string, *authzInstance]
-	org      cache.Cache[orgIndex, string, *Org]
+	instance     cache.Cache[instanceIndex, string, *authzInstance]
+	org          cache.Cache[orgIndex, string, *Org]
+	oidcClient   cache.Cache[oidcClientIndex, string, *cachedOIDCClient]
+	project      cache.Cache[projectIndex, string, *cachedProject]
+	app          cache.Cache[appIndex, string, *cachedApp]
+	oidcUserInfo cache.Cache[oidcUserInfoIndex, string, *cachedOIDCUserInfo]
+	projectRoles cache.Cache[projectRolesIndex, string, *cachedProjectRoles]
+	userGrants   cache.Cache[userGrantsIndex, string, *cachedUserGrants]
 
 	activeInstances *expirable.LRU[string, bool]
 }
@@ -24,7 +31,7 @@ type ActiveInstanceConfig struct {
 	TTL        time.Duration
 }
 
-func startCaches(background context.Context, connectors connector.Connectors, instanceConfig ActiveInstanceConfig) (_ *Caches, err error) {
+func startCaches(background context.Context, connectors connector.Connectors, instanceConfig ActiveInstanceConfig, client *database.DB) (_ *Caches, err error) {
 	caches := new(Caches)
 	caches.instance, err = connector.StartCache[instanceIndex, string, *authzInstance](background, instanceIndexValues(), cache.PurposeAuthzInstance, connectors.Config.Instance, connectors)
 	if err != nil {
@@ -35,10 +42,41 @@ func startCaches(background context.Context, connectors connector.Connectors, in
 		return nil, err
 	}
 
+	caches.oidcClient, err = connector.StartCache[oidcClientIndex, string, *cachedOIDCClient](background, oidcClientIndexValues(), cache.PurposeOIDCClient, connectors.Config.OIDCClient, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.project, err = connector.StartCache[projectIndex, string, *cachedProject](background, projectIndexValues(), cache.PurposeProject, connectors.Config.Project, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.app, err = connector.StartCache[appIndex, string, *cachedApp](background, appIndexValues(), cache.PurposeApp, connectors.Config.App, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.oidcUserInfo, err = connector.StartCache[oidcUserInfoIndex, string, *cachedOIDCUserInfo](background, oidcUserInfoIndexValues(), cache.PurposeOIDCUserInfo, connectors.Config.OIDCUserInfo, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.projectRoles, err = connector.StartCache[projectRolesIndex, string, *cachedProjectRoles](background, projectRolesIndexValues(), cache.PurposeProjectRoles, connectors.Config.ProjectRoles, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.userGrants, err = connector.StartCache[userGrantsIndex, string, *cachedUserGrants](background, userGrantsIndexValues(), cache.PurposeUserGrants, connectors.Config.UserGrants, connectors)
+	if err != nil {
+		return nil, err
+	}
+
 	caches.activeInstances = expirable.NewLRU[string, bool](instanceConfig.MaxEntries, nil, instanceConfig.TTL)
 
 	caches.registerInstanceInvalidation()
 	caches.registerOrgInvalidation()
+	caches.registerOIDCClientInvalidation()
+	caches.registerProjectInvalidation()
+	caches.registerAppInvalidation()
+	caches.registerOIDCUserInfoInvalidation(client)
+	caches.registerProjectRolesInvalidation()
+	caches.registerUserGrantsInvalidation(client)
 	return caches, nil
 }
 
@@ -64,3 +102,7 @@ func getAggregateID(aggregate *eventstore.Aggregate) string {
 func getResourceOwner(aggregate *eventstore.Aggregate) string {
 	return aggregate.ResourceOwner
 }
+
+func getInstanceID(aggregate *eventstore.Aggregate) string {
+	return aggregate.InstanceID
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/cibarequest"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// CIBARequestReadModel is the state of a Client-Initiated Backchannel Authentication request.
+type CIBARequestReadModel struct {
+	eventstore.ReadModel
+
+	ClientID                string
+	UserID                  string
+	UserOrgID               string
+	Scopes                  []string
+	BindingMessage          string
+	NotificationURI         string
+	ClientNotificationToken string
+	Expires                 time.Time
+	State                   domain.CIBARequestState
+}
+
+func newCIBARequestReadModel(id, instanceID string) *CIBARequestReadModel {
+	return &CIBARequestReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID:   id,
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+	}
+}
+
+func (rm *CIBARequestReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *cibarequest.AddedEvent:
+			rm.ClientID = e.ClientID
+			rm.UserID = e.UserID
+			rm.UserOrgID = e.UserOrgID
+			rm.Scopes = e.Scopes
+			rm.BindingMessage = e.BindingMessage
+			rm.NotificationURI = e.NotificationURI
+			rm.ClientNotificationToken = e.ClientNotificationToken
+			rm.Expires = e.Expires
+			rm.State = domain.CIBARequestStateInitiated
+		case *cibarequest.ApprovedEvent:
+			rm.State = domain.CIBARequestStateApproved
+		case *cibarequest.CanceledEvent:
+			rm.State = e.Reason.State()
+		case *cibarequest.DoneEvent:
+			rm.State = domain.CIBARequestStateDone
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *CIBARequestReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(rm.InstanceID).
+		AddQuery().
+		AggregateTypes(cibarequest.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			cibarequest.AddedEventType,
+			cibarequest.ApprovedEventType,
+			cibarequest.CanceledEventType,
+			cibarequest.DoneEventType,
+		).
+		Builder()
+}
+
+// CIBARequestByID returns the backchannel authentication request by its ID (auth_req_id).
+// If checkPermission is set, the caller needs the permission to link sessions (login client),
+// as the request reveals the user and the binding message.
+func (q *Queries) CIBARequestByID(ctx context.Context, id string, checkPermission bool) (model *CIBARequestReadModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model = newCIBARequestReadModel(id, authz.GetInstance(ctx).InstanceID())
+	if err = q.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-c7xr2m9vkq", "Errors.CIBARequest.NotFound")
+	}
+	if checkPermission {
+		if err = q.checkPermission(ctx, domain.PermissionSessionLink, model.ResourceOwner, ""); err != nil {
+			return nil, err
+		}
+	}
+	return model, nil
+}
@@ -14,6 +14,7 @@ type Event struct {
 	Editor       *EventEditor
 	Aggregate    *eventstore.Aggregate
 	Sequence     uint64
+	Position     float64
 	CreationDate time.Time
 	Type         string
 	Payload      []byte
@@ -104,6 +105,7 @@ func (er *eventsReducer) convertEvent(ctx context.Context, event eventstore.Even
 		},
 		Aggregate:    event.Aggregate(),
 		Sequence:     event.Sequence(),
+		Position:     event.Position(),
 		CreationDate: event.CreatedAt(),
 		Type:         string(event.Type()),
 		Payload:      event.DataAsBytes(),
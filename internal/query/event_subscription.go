@@ -0,0 +1,94 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// EventCursor marks the last event received by a subscription.
+// Multiple events can have the same position,
+// so the offset counts the events already received at the position.
+type EventCursor struct {
+	Position float64
+	Offset   uint32
+}
+
+func (c EventCursor) next(event *Event) EventCursor {
+	if event.Position == c.Position {
+		c.Offset++
+		return c
+	}
+	return EventCursor{Position: event.Position, Offset: 1}
+}
+
+type EventSubscription struct {
+	AggregateTypes []eventstore.AggregateType
+	AggregateID    string
+	EventTypes     []eventstore.EventType
+	// Cursor of the last received event, the zero value starts at the first event
+	Cursor EventCursor
+	// BatchSize is the maximum amount of events queried at once
+	BatchSize uint64
+	// PollInterval is the time waited for new events once all events were sent
+	PollInterval time.Duration
+}
+
+func (s *EventSubscription) query(cursor EventCursor) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		Limit(s.BatchSize).
+		OrderAsc()
+	if cursor.Position > 0 {
+		// events are ordered by position and their order in the position,
+		// the offset skips the events already received at the position of the cursor
+		builder = builder.PositionAtLeast(cursor.Position).Offset(cursor.Offset)
+	}
+	if len(s.AggregateTypes) == 0 && len(s.EventTypes) == 0 && s.AggregateID == "" {
+		return builder
+	}
+	query := builder.AddQuery().
+		AggregateTypes(s.AggregateTypes...).
+		EventTypes(s.EventTypes...)
+	if s.AggregateID != "" {
+		query = query.AggregateIDs(s.AggregateID)
+	}
+	return query.Builder()
+}
+
+// SubscribeEvents calls send for every event of the subscription after its cursor, ordered by position.
+// Once all stored events are sent, new events are polled in the interval of the subscription.
+// SubscribeEvents blocks until the context is done or send returns an error.
+// The cursor passed to send can be used to resume the subscription.
+func (q *Queries) SubscribeEvents(ctx context.Context, subscription *EventSubscription, send func(event *Event, cursor EventCursor) error) error {
+	cursor := subscription.Cursor
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		events, err := q.SearchEvents(ctx, subscription.query(cursor))
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			cursor = cursor.next(event)
+			if err = send(event, cursor); err != nil {
+				return err
+			}
+		}
+		// more events are available if the batch is full
+		if subscription.BatchSize > 0 && uint64(len(events)) == subscription.BatchSize {
+			timer.Reset(0)
+			continue
+		}
+		timer.Reset(subscription.PollInterval)
+	}
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestEventCursor_next(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor EventCursor
+		event  *Event
+		want   EventCursor
+	}{
+		{
+			name:   "first event",
+			cursor: EventCursor{},
+			event:  &Event{Position: 1.5},
+			want:   EventCursor{Position: 1.5, Offset: 1},
+		},
+		{
+			name:   "same position",
+			cursor: EventCursor{Position: 1.5, Offset: 1},
+			event:  &Event{Position: 1.5},
+			want:   EventCursor{Position: 1.5, Offset: 2},
+		},
+		{
+			name:   "next position",
+			cursor: EventCursor{Position: 1.5, Offset: 2},
+			event:  &Event{Position: 2},
+			want:   EventCursor{Position: 2, Offset: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cursor.next(tt.event))
+		})
+	}
+}
+
+func TestEventSubscription_query(t *testing.T) {
+	subscription := &EventSubscription{
+		AggregateTypes: []eventstore.AggregateType{"user"},
+		AggregateID:    "id",
+		EventTypes:     []eventstore.EventType{"user.added"},
+		BatchSize:      10,
+	}
+
+	query := subscription.query(EventCursor{})
+	assert.Zero(t, query.GetPositionAtLeast())
+	assert.Zero(t, query.GetOffset())
+	assert.Equal(t, uint64(10), query.GetLimit())
+	assert.False(t, query.GetDesc())
+	require.Len(t, query.GetQueries(), 1)
+	assert.Equal(t, []eventstore.AggregateType{"user"}, query.GetQueries()[0].GetAggregateTypes())
+	assert.Equal(t, []string{"id"}, query.GetQueries()[0].GetAggregateIDs())
+	assert.Equal(t, []eventstore.EventType{"user.added"}, query.GetQueries()[0].GetEventTypes())
+
+	query = subscription.query(EventCursor{Position: 1.5, Offset: 2})
+	assert.Equal(t, 1.5, query.GetPositionAtLeast())
+	assert.Zero(t, query.GetPositionAfter())
+	assert.Equal(t, uint32(2), query.GetOffset())
+
+	query = (&EventSubscription{BatchSize: 10}).query(EventCursor{})
+	assert.Empty(t, query.GetQueries())
+}
+
+func TestQueries_SubscribeEvents(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		q := &Queries{
+			eventstore: expectEventstore(expectFilterError(io.ErrClosedPipe))(t),
+		}
+		err := q.SubscribeEvents(context.Background(), &EventSubscription{BatchSize: 10}, func(*Event, EventCursor) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+	})
+	t.Run("context done", func(t *testing.T) {
+		q := &Queries{
+			eventstore: expectEventstore(expectFilter())(t),
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- q.SubscribeEvents(ctx, &EventSubscription{BatchSize: 10, PollInterval: time.Hour}, func(*Event, EventCursor) error {
+				return nil
+			})
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
@@ -363,6 +363,7 @@ type ExecutionTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	RetryPolicy      domain.TargetRetryPolicy
 	signingKey       *crypto.CryptoValue
 	SigningKey       string
 }
@@ -388,6 +389,9 @@ func (e *ExecutionTarget) GetTimeout() time.Duration {
 func (e *ExecutionTarget) GetSigningKey() string {
 	return e.SigningKey
 }
+func (e *ExecutionTarget) GetRetryPolicy() domain.TargetRetryPolicy {
+	return e.RetryPolicy
+}
 
 func (t *ExecutionTarget) decryptSigningKey(alg crypto.EncryptionAlgorithm) error {
 	if t.signingKey == nil {
@@ -415,6 +419,9 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			timeout          = &sql.NullInt64{}
 			interruptOnError = &sql.NullBool{}
 			signingKey       = &crypto.CryptoValue{}
+			maxAttempts      = &sql.NullInt16{}
+			initialBackoff   = &sql.NullInt64{}
+			maxBackoff       = &sql.NullInt64{}
 		)
 
 		err := rows.Scan(
@@ -426,6 +433,9 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			timeout,
 			interruptOnError,
 			signingKey,
+			maxAttempts,
+			initialBackoff,
+			maxBackoff,
 		)
 
 		if err != nil {
@@ -440,6 +450,11 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 		target.Timeout = time.Duration(timeout.Int64)
 		target.InterruptOnError = interruptOnError.Bool
 		target.signingKey = signingKey
+		target.RetryPolicy = domain.TargetRetryPolicy{
+			MaxAttempts:    uint8(maxAttempts.Int16),
+			InitialBackoff: time.Duration(initialBackoff.Int64),
+			MaxBackoff:     time.Duration(maxBackoff.Int64),
+		}
 
 		targets = append(targets, target)
 	}
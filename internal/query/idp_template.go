@@ -48,6 +48,7 @@ type IDPTemplate struct {
 	*LDAPIDPTemplate
 	*AppleIDPTemplate
 	*SAMLIDPTemplate
+	*KerberosIDPTemplate
 }
 
 type IDPTemplates struct {
@@ -64,6 +65,7 @@ type OAuthIDPTemplate struct {
 	UserEndpoint          string
 	Scopes                database.TextArray[string]
 	IDAttribute           string
+	ClaimMapping          *domain.IDPClaimMapping
 }
 
 type OIDCIDPTemplate struct {
@@ -73,6 +75,7 @@ type OIDCIDPTemplate struct {
 	Issuer           string
 	Scopes           database.TextArray[string]
 	IsIDTokenMapping bool
+	ClaimMapping     *domain.IDPClaimMapping
 }
 
 type JWTIDPTemplate struct {
@@ -143,6 +146,8 @@ type LDAPIDPTemplate struct {
 	UserFilters       []string
 	Timeout           time.Duration
 	RootCA            []byte
+	GroupMapping      *domain.LDAPGroupMapping
+	DirectorySync     bool
 	idp.LDAPAttributes
 }
 
@@ -155,6 +160,12 @@ type AppleIDPTemplate struct {
 	Scopes     database.TextArray[string]
 }
 
+type KerberosIDPTemplate struct {
+	IDPID            string
+	ServicePrincipal string
+	Keytab           *crypto.CryptoValue
+}
+
 type SAMLIDPTemplate struct {
 	IDPID                         string
 	Metadata                      []byte
@@ -164,6 +175,9 @@ type SAMLIDPTemplate struct {
 	WithSignedRequest             bool
 	NameIDFormat                  sql.Null[domain.SAMLNameIDFormat]
 	TransientMappingAttributeName string
+	ClaimMapping                  *domain.IDPClaimMapping
+	MetadataURL                   string
+	AdditionalKeys                domain.SAMLKeys
 }
 
 var (
@@ -278,6 +292,10 @@ var (
 		name:  projection.OAuthIDAttributeCol,
 		table: oauthIdpTemplateTable,
 	}
+	OAuthClaimMappingCol = Column{
+		name:  projection.OAuthClaimMappingCol,
+		table: oauthIdpTemplateTable,
+	}
 )
 
 var (
@@ -313,6 +331,10 @@ var (
 		name:  projection.OIDCIDTokenMappingCol,
 		table: oidcIdpTemplateTable,
 	}
+	OIDCClaimMappingCol = Column{
+		name:  projection.OIDCClaimMappingCol,
+		table: oidcIdpTemplateTable,
+	}
 )
 
 var (
@@ -637,6 +659,14 @@ var (
 		name:  projection.LDAPProfileAttributeCol,
 		table: ldapIdpTemplateTable,
 	}
+	LDAPGroupMappingCol = Column{
+		name:  projection.LDAPGroupMappingCol,
+		table: ldapIdpTemplateTable,
+	}
+	LDAPDirectorySyncCol = Column{
+		name:  projection.LDAPDirectorySyncCol,
+		table: ldapIdpTemplateTable,
+	}
 )
 
 var (
@@ -715,6 +745,41 @@ var (
 		name:  projection.SAMLTransientMappingAttributeName,
 		table: samlIdpTemplateTable,
 	}
+	SAMLClaimMappingCol = Column{
+		name:  projection.SAMLClaimMappingCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLMetadataURLCol = Column{
+		name:  projection.SAMLMetadataURLCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLAdditionalKeysCol = Column{
+		name:  projection.SAMLAdditionalKeysCol,
+		table: samlIdpTemplateTable,
+	}
+)
+
+var (
+	kerberosIdpTemplateTable = table{
+		name:          projection.IDPTemplateKerberosTable,
+		instanceIDCol: projection.KerberosInstanceIDCol,
+	}
+	KerberosIDCol = Column{
+		name:  projection.KerberosIDCol,
+		table: kerberosIdpTemplateTable,
+	}
+	KerberosInstanceIDCol = Column{
+		name:  projection.KerberosInstanceIDCol,
+		table: kerberosIdpTemplateTable,
+	}
+	KerberosServicePrincipalCol = Column{
+		name:  projection.KerberosServicePrincipalCol,
+		table: kerberosIdpTemplateTable,
+	}
+	KerberosKeytabCol = Column{
+		name:  projection.KerberosKeytabCol,
+		table: kerberosIdpTemplateTable,
+	}
 )
 
 // IDPTemplateByID searches for the requested id with permission check if necessary
@@ -801,6 +866,79 @@ func (q *Queries) IDPTemplates(ctx context.Context, queries *IDPTemplateSearchQu
 	return idps, err
 }
 
+// SAMLIDPWithMetadataURL identifies an active SAML identity provider which loads its metadata from a URL.
+type SAMLIDPWithMetadataURL struct {
+	InstanceID    string
+	ResourceOwner string
+	ID            string
+	OwnerType     domain.IdentityProviderType
+}
+
+// SAMLIDPsWithMetadataURL returns the active SAML identity providers of all instances, which have a metadata URL configured.
+// It is used to periodically refresh their metadata.
+func (q *Queries) SAMLIDPsWithMetadataURL(ctx context.Context) (idps []*SAMLIDPWithMetadataURL, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSAMLIDPsWithMetadataURLQuery()
+	stmt, args, err := query.Where(sq.And{
+		sq.Eq{
+			IDPTemplateStateCol.identifier():        domain.IDPStateActive,
+			IDPTemplateOwnerRemovedCol.identifier(): false,
+		},
+		sq.NotEq{
+			SAMLMetadataURLCol.identifier(): "",
+		},
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Wn4rd", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		idps, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ec7tm", "Errors.Internal")
+	}
+	return idps, nil
+}
+
+// LDAPIDPWithDirectorySync identifies an active LDAP identity provider whose users are synchronized with the directory.
+type LDAPIDPWithDirectorySync struct {
+	InstanceID    string
+	ResourceOwner string
+	ID            string
+	OwnerType     domain.IdentityProviderType
+	GroupMapping  *domain.LDAPGroupMapping
+}
+
+// LDAPIDPsWithDirectorySync returns the active LDAP identity providers of all instances, which have the directory sync enabled.
+// It is used to periodically synchronize the linked users with the directory.
+func (q *Queries) LDAPIDPsWithDirectorySync(ctx context.Context) (idps []*LDAPIDPWithDirectorySync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPIDPsWithDirectorySyncQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		IDPTemplateStateCol.identifier():        domain.IDPStateActive,
+		IDPTemplateOwnerRemovedCol.identifier(): false,
+		LDAPDirectorySyncCol.identifier():       true,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Dm3wq", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		idps, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Rz6tj", "Errors.Internal")
+	}
+	return idps, nil
+}
+
 type IDPTemplateSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -879,6 +1017,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			OAuthUserEndpointCol.identifier(),
 			OAuthScopesCol.identifier(),
 			OAuthIDAttributeCol.identifier(),
+			OAuthClaimMappingCol.identifier(),
 			// oidc
 			OIDCIDCol.identifier(),
 			OIDCIssuerCol.identifier(),
@@ -886,6 +1025,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			OIDCClientSecretCol.identifier(),
 			OIDCScopesCol.identifier(),
 			OIDCIDTokenMappingCol.identifier(),
+			OIDCClaimMappingCol.identifier(),
 			// jwt
 			JWTIDCol.identifier(),
 			JWTIssuerCol.identifier(),
@@ -937,6 +1077,9 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			SAMLWithSignedRequestCol.identifier(),
 			SAMLNameIDFormatCol.identifier(),
 			SAMLTransientMappingAttributeNameCol.identifier(),
+			SAMLClaimMappingCol.identifier(),
+			SAMLMetadataURLCol.identifier(),
+			SAMLAdditionalKeysCol.identifier(),
 			// ldap
 			LDAPIDCol.identifier(),
 			LDAPServersCol.identifier(),
@@ -962,6 +1105,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LDAPPreferredLanguageAttributeCol.identifier(),
 			LDAPAvatarURLAttributeCol.identifier(),
 			LDAPProfileAttributeCol.identifier(),
+			LDAPGroupMappingCol.identifier(),
+			LDAPDirectorySyncCol.identifier(),
 			// apple
 			AppleIDCol.identifier(),
 			AppleClientIDCol.identifier(),
@@ -969,6 +1114,10 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// kerberos
+			KerberosIDCol.identifier(),
+			KerberosServicePrincipalCol.identifier(),
+			KerberosKeytabCol.identifier(),
 		).From(idpTemplateTable.identifier()).
 			LeftJoin(join(OAuthIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(OIDCIDCol, IDPTemplateIDCol)).
@@ -981,7 +1130,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(KerberosIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDPTemplate, error) {
 			idpTemplate := new(IDPTemplate)
@@ -996,6 +1146,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			oauthUserEndpoint := sql.NullString{}
 			oauthScopes := database.TextArray[string]{}
 			oauthIDAttribute := sql.NullString{}
+			oauthClaimMapping := new(domain.IDPClaimMapping)
 
 			oidcID := sql.NullString{}
 			oidcIssuer := sql.NullString{}
@@ -1003,6 +1154,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			oidcClientSecret := new(crypto.CryptoValue)
 			oidcScopes := database.TextArray[string]{}
 			oidcIDTokenMapping := sql.NullBool{}
+			oidcClaimMapping := new(domain.IDPClaimMapping)
 
 			jwtID := sql.NullString{}
 			jwtIssuer := sql.NullString{}
@@ -1054,6 +1206,9 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			samlWithSignedRequest := sql.NullBool{}
 			samlNameIDFormat := sql.Null[domain.SAMLNameIDFormat]{}
 			samlTransientMappingAttributeName := sql.NullString{}
+			samlClaimMapping := new(domain.IDPClaimMapping)
+			samlMetadataURL := sql.NullString{}
+			var samlAdditionalKeys domain.SAMLKeys
 
 			ldapID := sql.NullString{}
 			ldapServers := database.TextArray[string]{}
@@ -1079,6 +1234,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			ldapPreferredLanguageAttribute := sql.NullString{}
 			ldapAvatarURLAttribute := sql.NullString{}
 			ldapProfileAttribute := sql.NullString{}
+			ldapGroupMapping := new(domain.LDAPGroupMapping)
+			ldapDirectorySync := sql.NullBool{}
 
 			appleID := sql.NullString{}
 			appleClientID := sql.NullString{}
@@ -1087,6 +1244,10 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			applePrivateKey := new(crypto.CryptoValue)
 			appleScopes := database.TextArray[string]{}
 
+			kerberosID := sql.NullString{}
+			kerberosServicePrincipal := sql.NullString{}
+			kerberosKeytab := new(crypto.CryptoValue)
+
 			err := row.Scan(
 				&idpTemplate.ID,
 				&idpTemplate.ResourceOwner,
@@ -1111,6 +1272,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&oauthUserEndpoint,
 				&oauthScopes,
 				&oauthIDAttribute,
+				&oauthClaimMapping,
 				// oidc
 				&oidcID,
 				&oidcIssuer,
@@ -1118,6 +1280,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&oidcClientSecret,
 				&oidcScopes,
 				&oidcIDTokenMapping,
+				&oidcClaimMapping,
 				// jwt
 				&jwtID,
 				&jwtIssuer,
@@ -1169,6 +1332,9 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&samlWithSignedRequest,
 				&samlNameIDFormat,
 				&samlTransientMappingAttributeName,
+				&samlClaimMapping,
+				&samlMetadataURL,
+				&samlAdditionalKeys,
 				// ldap
 				&ldapID,
 				&ldapServers,
@@ -1194,6 +1360,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&ldapPreferredLanguageAttribute,
 				&ldapAvatarURLAttribute,
 				&ldapProfileAttribute,
+				&ldapGroupMapping,
+				&ldapDirectorySync,
 				// apple
 				&appleID,
 				&appleClientID,
@@ -1201,6 +1369,10 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&appleKeyID,
 				&applePrivateKey,
 				&appleScopes,
+				// kerberos
+				&kerberosID,
+				&kerberosServicePrincipal,
+				&kerberosKeytab,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -1221,6 +1393,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					UserEndpoint:          oauthUserEndpoint.String,
 					Scopes:                oauthScopes,
 					IDAttribute:           oauthIDAttribute.String,
+					ClaimMapping:          oauthClaimMapping,
 				}
 			}
 			if oidcID.Valid {
@@ -1231,6 +1404,7 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					Issuer:           oidcIssuer.String,
 					Scopes:           oidcScopes,
 					IsIDTokenMapping: oidcIDTokenMapping.Bool,
+					ClaimMapping:     oidcClaimMapping,
 				}
 			}
 			if jwtID.Valid {
@@ -1306,6 +1480,9 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					WithSignedRequest:             samlWithSignedRequest.Bool,
 					NameIDFormat:                  samlNameIDFormat,
 					TransientMappingAttributeName: samlTransientMappingAttributeName.String,
+					ClaimMapping:                  samlClaimMapping,
+					MetadataURL:                   samlMetadataURL.String,
+					AdditionalKeys:                samlAdditionalKeys,
 				}
 			}
 			if ldapID.Valid {
@@ -1321,6 +1498,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					UserFilters:       ldapUserFilters,
 					Timeout:           time.Duration(ldapTimeout.Int64),
 					RootCA:            ldapRootCA,
+					GroupMapping:      ldapGroupMapping,
+					DirectorySync:     ldapDirectorySync.Bool,
 					LDAPAttributes: idp.LDAPAttributes{
 						IDAttribute:                ldapIDAttribute.String,
 						FirstNameAttribute:         ldapFirstNameAttribute.String,
@@ -1348,6 +1527,13 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					Scopes:     appleScopes,
 				}
 			}
+			if kerberosID.Valid {
+				idpTemplate.KerberosIDPTemplate = &KerberosIDPTemplate{
+					IDPID:            kerberosID.String,
+					ServicePrincipal: kerberosServicePrincipal.String,
+					Keytab:           kerberosKeytab,
+				}
+			}
 
 			return idpTemplate, nil
 		}
@@ -1378,6 +1564,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			OAuthUserEndpointCol.identifier(),
 			OAuthScopesCol.identifier(),
 			OAuthIDAttributeCol.identifier(),
+			OAuthClaimMappingCol.identifier(),
 			// oidc
 			OIDCIDCol.identifier(),
 			OIDCIssuerCol.identifier(),
@@ -1385,6 +1572,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			OIDCClientSecretCol.identifier(),
 			OIDCScopesCol.identifier(),
 			OIDCIDTokenMappingCol.identifier(),
+			OIDCClaimMappingCol.identifier(),
 			// jwt
 			JWTIDCol.identifier(),
 			JWTIssuerCol.identifier(),
@@ -1436,6 +1624,9 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			SAMLWithSignedRequestCol.identifier(),
 			SAMLNameIDFormatCol.identifier(),
 			SAMLTransientMappingAttributeNameCol.identifier(),
+			SAMLClaimMappingCol.identifier(),
+			SAMLMetadataURLCol.identifier(),
+			SAMLAdditionalKeysCol.identifier(),
 			// ldap
 			LDAPIDCol.identifier(),
 			LDAPServersCol.identifier(),
@@ -1461,6 +1652,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LDAPPreferredLanguageAttributeCol.identifier(),
 			LDAPAvatarURLAttributeCol.identifier(),
 			LDAPProfileAttributeCol.identifier(),
+			LDAPGroupMappingCol.identifier(),
+			LDAPDirectorySyncCol.identifier(),
 			// apple
 			AppleIDCol.identifier(),
 			AppleClientIDCol.identifier(),
@@ -1468,6 +1661,10 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// kerberos
+			KerberosIDCol.identifier(),
+			KerberosServicePrincipalCol.identifier(),
+			KerberosKeytabCol.identifier(),
 			// count
 			countColumn.identifier(),
 		).From(idpTemplateTable.identifier()).
@@ -1482,7 +1679,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(KerberosIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPTemplates, error) {
 			templates := make([]*IDPTemplate, 0)
@@ -1500,6 +1698,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				oauthUserEndpoint := sql.NullString{}
 				oauthScopes := database.TextArray[string]{}
 				oauthIDAttribute := sql.NullString{}
+				oauthClaimMapping := new(domain.IDPClaimMapping)
 
 				oidcID := sql.NullString{}
 				oidcIssuer := sql.NullString{}
@@ -1507,6 +1706,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				oidcClientSecret := new(crypto.CryptoValue)
 				oidcScopes := database.TextArray[string]{}
 				oidcIDTokenMapping := sql.NullBool{}
+				oidcClaimMapping := new(domain.IDPClaimMapping)
 
 				jwtID := sql.NullString{}
 				jwtIssuer := sql.NullString{}
@@ -1558,6 +1758,9 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				samlWithSignedRequest := sql.NullBool{}
 				samlNameIDFormat := sql.Null[domain.SAMLNameIDFormat]{}
 				samlTransientMappingAttributeName := sql.NullString{}
+				samlClaimMapping := new(domain.IDPClaimMapping)
+				samlMetadataURL := sql.NullString{}
+				var samlAdditionalKeys domain.SAMLKeys
 
 				ldapID := sql.NullString{}
 				ldapServers := database.TextArray[string]{}
@@ -1583,6 +1786,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				ldapPreferredLanguageAttribute := sql.NullString{}
 				ldapAvatarURLAttribute := sql.NullString{}
 				ldapProfileAttribute := sql.NullString{}
+				ldapGroupMapping := new(domain.LDAPGroupMapping)
+				ldapDirectorySync := sql.NullBool{}
 
 				appleID := sql.NullString{}
 				appleClientID := sql.NullString{}
@@ -1591,6 +1796,10 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				applePrivateKey := new(crypto.CryptoValue)
 				appleScopes := database.TextArray[string]{}
 
+				kerberosID := sql.NullString{}
+				kerberosServicePrincipal := sql.NullString{}
+				kerberosKeytab := new(crypto.CryptoValue)
+
 				err := rows.Scan(
 					&idpTemplate.ID,
 					&idpTemplate.ResourceOwner,
@@ -1615,6 +1824,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&oauthUserEndpoint,
 					&oauthScopes,
 					&oauthIDAttribute,
+					&oauthClaimMapping,
 					// oidc
 					&oidcID,
 					&oidcIssuer,
@@ -1622,6 +1832,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&oidcClientSecret,
 					&oidcScopes,
 					&oidcIDTokenMapping,
+					&oidcClaimMapping,
 					// jwt
 					&jwtID,
 					&jwtIssuer,
@@ -1673,6 +1884,9 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&samlWithSignedRequest,
 					&samlNameIDFormat,
 					&samlTransientMappingAttributeName,
+					&samlClaimMapping,
+					&samlMetadataURL,
+					&samlAdditionalKeys,
 					// ldap
 					&ldapID,
 					&ldapServers,
@@ -1698,6 +1912,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&ldapPreferredLanguageAttribute,
 					&ldapAvatarURLAttribute,
 					&ldapProfileAttribute,
+					&ldapGroupMapping,
+					&ldapDirectorySync,
 					// apple
 					&appleID,
 					&appleClientID,
@@ -1705,6 +1921,10 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&appleKeyID,
 					&applePrivateKey,
 					&appleScopes,
+					// kerberos
+					&kerberosID,
+					&kerberosServicePrincipal,
+					&kerberosKeytab,
 					&count,
 				)
 
@@ -1724,6 +1944,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						UserEndpoint:          oauthUserEndpoint.String,
 						Scopes:                oauthScopes,
 						IDAttribute:           oauthIDAttribute.String,
+						ClaimMapping:          oauthClaimMapping,
 					}
 				}
 				if oidcID.Valid {
@@ -1734,6 +1955,7 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						Issuer:           oidcIssuer.String,
 						Scopes:           oidcScopes,
 						IsIDTokenMapping: oidcIDTokenMapping.Bool,
+						ClaimMapping:     oidcClaimMapping,
 					}
 				}
 				if jwtID.Valid {
@@ -1809,6 +2031,9 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						WithSignedRequest:             samlWithSignedRequest.Bool,
 						NameIDFormat:                  samlNameIDFormat,
 						TransientMappingAttributeName: samlTransientMappingAttributeName.String,
+						ClaimMapping:                  samlClaimMapping,
+						MetadataURL:                   samlMetadataURL.String,
+						AdditionalKeys:                samlAdditionalKeys,
 					}
 				}
 				if ldapID.Valid {
@@ -1824,6 +2049,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						UserFilters:       ldapUserFilters,
 						Timeout:           time.Duration(ldapTimeout.Int64),
 						RootCA:            ldapRootCA,
+						GroupMapping:      ldapGroupMapping,
+						DirectorySync:     ldapDirectorySync.Bool,
 						LDAPAttributes: idp.LDAPAttributes{
 							IDAttribute:                ldapIDAttribute.String,
 							FirstNameAttribute:         ldapFirstNameAttribute.String,
@@ -1851,6 +2078,13 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						Scopes:     appleScopes,
 					}
 				}
+				if kerberosID.Valid {
+					idpTemplate.KerberosIDPTemplate = &KerberosIDPTemplate{
+						IDPID:            kerberosID.String,
+						ServicePrincipal: kerberosServicePrincipal.String,
+						Keytab:           kerberosKeytab,
+					}
+				}
 				templates = append(templates, idpTemplate)
 			}
 
@@ -1866,3 +2100,67 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			}, nil
 		}
 }
+
+func prepareSAMLIDPsWithMetadataURLQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*SAMLIDPWithMetadataURL, error)) {
+	return sq.Select(
+			IDPTemplateInstanceIDCol.identifier(),
+			IDPTemplateResourceOwnerCol.identifier(),
+			IDPTemplateIDCol.identifier(),
+			IDPTemplateOwnerTypeCol.identifier(),
+		).From(idpTemplateTable.identifier()).
+			Join(join(SAMLIDCol, IDPTemplateIDCol)).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*SAMLIDPWithMetadataURL, error) {
+			idps := make([]*SAMLIDPWithMetadataURL, 0)
+			for rows.Next() {
+				template := new(SAMLIDPWithMetadataURL)
+				err := rows.Scan(
+					&template.InstanceID,
+					&template.ResourceOwner,
+					&template.ID,
+					&template.OwnerType,
+				)
+				if err != nil {
+					return nil, err
+				}
+				idps = append(idps, template)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Lf8ze", "Errors.Query.CloseRows")
+			}
+			return idps, nil
+		}
+}
+
+func prepareLDAPIDPsWithDirectorySyncQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*LDAPIDPWithDirectorySync, error)) {
+	return sq.Select(
+			IDPTemplateInstanceIDCol.identifier(),
+			IDPTemplateResourceOwnerCol.identifier(),
+			IDPTemplateIDCol.identifier(),
+			IDPTemplateOwnerTypeCol.identifier(),
+			LDAPGroupMappingCol.identifier(),
+		).From(idpTemplateTable.identifier()).
+			Join(join(LDAPIDCol, IDPTemplateIDCol)).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*LDAPIDPWithDirectorySync, error) {
+			idps := make([]*LDAPIDPWithDirectorySync, 0)
+			for rows.Next() {
+				template := new(LDAPIDPWithDirectorySync)
+				err := rows.Scan(
+					&template.InstanceID,
+					&template.ResourceOwner,
+					&template.ID,
+					&template.OwnerType,
+					&template.GroupMapping,
+				)
+				if err != nil {
+					return nil, err
+				}
+				idps = append(idps, template)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Pv9ha", "Errors.Query.CloseRows")
+			}
+			return idps, nil
+		}
+}
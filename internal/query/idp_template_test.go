@@ -39,6 +39,7 @@ var (
 		` projections.idp_templates6_oauth2.user_endpoint,` +
 		` projections.idp_templates6_oauth2.scopes,` +
 		` projections.idp_templates6_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.claim_mapping,` +
 		// oidc
 		` projections.idp_templates6_oidc.idp_id,` +
 		` projections.idp_templates6_oidc.issuer,` +
@@ -46,6 +47,7 @@ var (
 		` projections.idp_templates6_oidc.client_secret,` +
 		` projections.idp_templates6_oidc.scopes,` +
 		` projections.idp_templates6_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.claim_mapping,` +
 		// jwt
 		` projections.idp_templates6_jwt.idp_id,` +
 		` projections.idp_templates6_jwt.issuer,` +
@@ -97,6 +99,9 @@ var (
 		` projections.idp_templates6_saml.with_signed_request,` +
 		` projections.idp_templates6_saml.name_id_format,` +
 		` projections.idp_templates6_saml.transient_mapping_attribute_name,` +
+		` projections.idp_templates6_saml.claim_mapping,` +
+		` projections.idp_templates6_saml.metadata_url,` +
+		` projections.idp_templates6_saml.additional_keys,` +
 		// ldap
 		` projections.idp_templates6_ldap3.idp_id,` +
 		` projections.idp_templates6_ldap3.servers,` +
@@ -122,13 +127,19 @@ var (
 		` projections.idp_templates6_ldap3.preferred_language_attribute,` +
 		` projections.idp_templates6_ldap3.avatar_url_attribute,` +
 		` projections.idp_templates6_ldap3.profile_attribute,` +
+		` projections.idp_templates6_ldap3.group_mapping,` +
+		` projections.idp_templates6_ldap3.directory_sync,` +
 		// apple
 		` projections.idp_templates6_apple.idp_id,` +
 		` projections.idp_templates6_apple.client_id,` +
 		` projections.idp_templates6_apple.team_id,` +
 		` projections.idp_templates6_apple.key_id,` +
 		` projections.idp_templates6_apple.private_key,` +
-		` projections.idp_templates6_apple.scopes` +
+		` projections.idp_templates6_apple.scopes,` +
+		// kerberos
+		` projections.idp_templates6_kerberos.idp_id,` +
+		` projections.idp_templates6_kerberos.service_principal,` +
+		` projections.idp_templates6_kerberos.keytab` +
 		` FROM projections.idp_templates6` +
 		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
 		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
@@ -142,6 +153,7 @@ var (
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap3 ON projections.idp_templates6.id = projections.idp_templates6_ldap3.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap3.instance_id` +
 		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_kerberos ON projections.idp_templates6.id = projections.idp_templates6_kerberos.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_kerberos.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplateCols = []string{
 		"id",
@@ -167,6 +179,7 @@ var (
 		"user_endpoint",
 		"scopes",
 		"id_attribute",
+		"claim_mapping",
 		// oidc config
 		"id_id",
 		"issuer",
@@ -174,6 +187,7 @@ var (
 		"client_secret",
 		"scopes",
 		"id_token_mapping",
+		"claim_mapping",
 		// jwt
 		"idp_id",
 		"issuer",
@@ -225,6 +239,9 @@ var (
 		"with_signed_request",
 		"name_id_format",
 		"transient_mapping_attribute_name",
+		"claim_mapping",
+		"metadata_url",
+		"additional_keys",
 		// ldap config
 		"idp_id",
 		"servers",
@@ -250,6 +267,8 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		"group_mapping",
+		"directory_sync",
 		// apple config
 		"idp_id",
 		"client_id",
@@ -257,6 +276,10 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// kerberos config
+		"idp_id",
+		"service_principal",
+		"keytab",
 	}
 	idpTemplatesQuery = `SELECT projections.idp_templates6.id,` +
 		` projections.idp_templates6.resource_owner,` +
@@ -281,6 +304,7 @@ var (
 		` projections.idp_templates6_oauth2.user_endpoint,` +
 		` projections.idp_templates6_oauth2.scopes,` +
 		` projections.idp_templates6_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.claim_mapping,` +
 		// oidc
 		` projections.idp_templates6_oidc.idp_id,` +
 		` projections.idp_templates6_oidc.issuer,` +
@@ -288,6 +312,7 @@ var (
 		` projections.idp_templates6_oidc.client_secret,` +
 		` projections.idp_templates6_oidc.scopes,` +
 		` projections.idp_templates6_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.claim_mapping,` +
 		// jwt
 		` projections.idp_templates6_jwt.idp_id,` +
 		` projections.idp_templates6_jwt.issuer,` +
@@ -339,6 +364,9 @@ var (
 		` projections.idp_templates6_saml.with_signed_request,` +
 		` projections.idp_templates6_saml.name_id_format,` +
 		` projections.idp_templates6_saml.transient_mapping_attribute_name,` +
+		` projections.idp_templates6_saml.claim_mapping,` +
+		` projections.idp_templates6_saml.metadata_url,` +
+		` projections.idp_templates6_saml.additional_keys,` +
 		// ldap
 		` projections.idp_templates6_ldap3.idp_id,` +
 		` projections.idp_templates6_ldap3.servers,` +
@@ -364,6 +392,8 @@ var (
 		` projections.idp_templates6_ldap3.preferred_language_attribute,` +
 		` projections.idp_templates6_ldap3.avatar_url_attribute,` +
 		` projections.idp_templates6_ldap3.profile_attribute,` +
+		` projections.idp_templates6_ldap3.group_mapping,` +
+		` projections.idp_templates6_ldap3.directory_sync,` +
 		// apple
 		` projections.idp_templates6_apple.idp_id,` +
 		` projections.idp_templates6_apple.client_id,` +
@@ -371,6 +401,10 @@ var (
 		` projections.idp_templates6_apple.key_id,` +
 		` projections.idp_templates6_apple.private_key,` +
 		` projections.idp_templates6_apple.scopes,` +
+		// kerberos
+		` projections.idp_templates6_kerberos.idp_id,` +
+		` projections.idp_templates6_kerberos.service_principal,` +
+		` projections.idp_templates6_kerberos.keytab,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.idp_templates6` +
 		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
@@ -385,6 +419,7 @@ var (
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap3 ON projections.idp_templates6.id = projections.idp_templates6_ldap3.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap3.instance_id` +
 		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_kerberos ON projections.idp_templates6.id = projections.idp_templates6_kerberos.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_kerberos.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplatesCols = []string{
 		"id",
@@ -410,6 +445,7 @@ var (
 		"user_endpoint",
 		"scopes",
 		"id_attribute",
+		"claim_mapping",
 		// oidc config
 		"id_id",
 		"issuer",
@@ -417,6 +453,7 @@ var (
 		"client_secret",
 		"scopes",
 		"id_token_mapping",
+		"claim_mapping",
 		// jwt
 		"idp_id",
 		"issuer",
@@ -468,6 +505,9 @@ var (
 		"with_signed_request",
 		"name_id_format",
 		"transient_mapping_attribute_name",
+		"claim_mapping",
+		"metadata_url",
+		"additional_keys",
 		// ldap config
 		"idp_id",
 		"servers",
@@ -493,6 +533,8 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		"group_mapping",
+		"directory_sync",
 		// apple config
 		"idp_id",
 		"client_id",
@@ -500,6 +542,10 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// kerberos config
+		"idp_id",
+		"service_principal",
+		"keytab",
 		"count",
 	}
 )
@@ -564,6 +610,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"user",
 						database.TextArray[string]{"profile"},
 						"id-attribute",
+						[]byte(`{"email":"$.mail"}`),
 						// oidc
 						nil,
 						nil,
@@ -571,6 +618,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -622,6 +670,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -647,6 +698,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -654,6 +707,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -681,6 +738,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 					UserEndpoint:          "user",
 					Scopes:                []string{"profile"},
 					IDAttribute:           "id-attribute",
+					ClaimMapping:          &domain.IDPClaimMapping{Email: "$.mail"},
 				},
 			},
 		},
@@ -715,6 +773,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						"idp-id",
 						"issuer",
@@ -722,6 +781,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						database.TextArray[string]{"profile"},
 						true,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -773,6 +833,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -798,6 +861,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -805,6 +870,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -864,6 +933,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -871,6 +941,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						"idp-id",
 						"issuer",
@@ -922,6 +993,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -947,6 +1021,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -954,6 +1030,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1012,6 +1092,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1019,6 +1100,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1070,6 +1152,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1095,6 +1180,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -1102,6 +1189,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1159,6 +1250,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1166,6 +1258,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1217,6 +1310,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1242,6 +1338,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -1249,6 +1347,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1306,6 +1408,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1313,6 +1416,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1364,6 +1468,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1389,6 +1496,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -1396,6 +1505,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1454,6 +1567,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1461,6 +1575,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1512,6 +1627,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1537,6 +1655,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -1544,6 +1664,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1601,6 +1725,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1608,6 +1733,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1659,6 +1785,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						false,
 						domain.SAMLNameIDFormatTransient,
 						"customAttribute",
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1684,6 +1813,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -1691,6 +1822,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1752,6 +1887,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1759,6 +1895,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1810,6 +1947,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						"idp-id",
 						database.TextArray[string]{"server"},
@@ -1835,6 +1975,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"lang",
 						"avatar",
 						"profile",
+						[]byte(`{"groupAttribute": "memberOf", "groups": [{"group": "cn=admins", "projectId": "project", "roleKeys": ["admin"]}]}`),
+						true,
 						// apple
 						nil,
 						nil,
@@ -1842,6 +1984,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1871,6 +2017,11 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 					UserFilters:       []string{"filter"},
 					Timeout:           time.Duration(30000000000),
 					RootCA:            []byte("certificate"),
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}},
+					},
+					DirectorySync: true,
 					LDAPAttributes: idp.LDAPAttributes{
 						IDAttribute:                "id",
 						FirstNameAttribute:         "first",
@@ -1920,6 +2071,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1927,6 +2079,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1978,6 +2131,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -2003,6 +2159,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						"idp-id",
 						"client_id",
@@ -2010,6 +2168,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"key_id",
 						nil,
 						database.TextArray[string]{"profile"},
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -2038,6 +2200,162 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareIDPTemplateByIDQuery kerberos idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeKerberos,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// kerberos
+						"idp-id",
+						"HTTP/login.example.com",
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeKerberos,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				KerberosIDPTemplate: &KerberosIDPTemplate{
+					IDPID:            "idp-id",
+					ServicePrincipal: "HTTP/login.example.com",
+				},
+			},
+		},
 		{
 			name:    "prepareIDPTemplateByIDQuery no config",
 			prepare: prepareIDPTemplateByIDQuery,
@@ -2069,6 +2387,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -2076,6 +2395,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -2127,6 +2447,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -2152,6 +2475,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 						// apple
 						nil,
 						nil,
@@ -2159,6 +2484,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// kerberos
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -2246,6 +2575,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2253,6 +2583,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2304,6 +2635,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							"idp-id",
 							database.TextArray[string]{"server"},
@@ -2329,6 +2663,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -2336,6 +2672,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2423,6 +2763,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2430,6 +2771,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2481,6 +2823,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2506,6 +2851,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -2513,6 +2860,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2573,6 +2924,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2580,6 +2932,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2631,6 +2984,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							"idp-id-ldap",
 							database.TextArray[string]{"server"},
@@ -2656,6 +3012,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -2663,6 +3021,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-saml",
@@ -2688,6 +3050,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2695,6 +3058,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2746,6 +3110,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							false,
 							domain.SAMLNameIDFormatTransient,
 							"customAttribute",
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2771,6 +3138,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -2778,6 +3147,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-google",
@@ -2803,6 +3176,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2810,6 +3184,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2861,6 +3236,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2886,6 +3264,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -2893,6 +3273,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oauth",
@@ -2918,6 +3302,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"user",
 							database.TextArray[string]{"profile"},
 							"id-attribute",
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2925,6 +3310,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2976,6 +3362,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -3001,6 +3390,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -3008,6 +3399,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oidc",
@@ -3033,6 +3428,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							"idp-id-oidc",
 							"issuer",
@@ -3040,6 +3436,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							database.TextArray[string]{"profile"},
 							true,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -3091,6 +3488,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -3116,6 +3516,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -3123,6 +3525,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-jwt",
@@ -3148,6 +3554,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -3155,6 +3562,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							"idp-id-jwt",
 							"issuer",
@@ -3206,6 +3614,9 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -3231,6 +3642,8 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 							// apple
 							nil,
 							nil,
@@ -3238,6 +3651,10 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// kerberos
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -3439,3 +3856,207 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 		})
 	}
 }
+
+func Test_SAMLIDPsWithMetadataURLPrepares(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT projections.idp_templates6.instance_id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.id,` +
+		` projections.idp_templates6.owner_type` +
+		` FROM projections.idp_templates6` +
+		` JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id`)
+	cols := []string{
+		"instance_id",
+		"resource_owner",
+		"id",
+		"owner_type",
+	}
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSAMLIDPsWithMetadataURLQuery no result",
+			prepare: prepareSAMLIDPsWithMetadataURLQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					query,
+					nil,
+					nil,
+				),
+			},
+			object: []*SAMLIDPWithMetadataURL{},
+		},
+		{
+			name:    "prepareSAMLIDPsWithMetadataURLQuery found",
+			prepare: prepareSAMLIDPsWithMetadataURLQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					query,
+					cols,
+					[][]driver.Value{
+						{
+							"instance-id",
+							"instance-id",
+							"idp-id-instance",
+							domain.IdentityProviderTypeSystem,
+						},
+						{
+							"instance-id",
+							"org-id",
+							"idp-id-org",
+							domain.IdentityProviderTypeOrg,
+						},
+					},
+				),
+			},
+			object: []*SAMLIDPWithMetadataURL{
+				{
+					InstanceID:    "instance-id",
+					ResourceOwner: "instance-id",
+					ID:            "idp-id-instance",
+					OwnerType:     domain.IdentityProviderTypeSystem,
+				},
+				{
+					InstanceID:    "instance-id",
+					ResourceOwner: "org-id",
+					ID:            "idp-id-org",
+					OwnerType:     domain.IdentityProviderTypeOrg,
+				},
+			},
+		},
+		{
+			name:    "prepareSAMLIDPsWithMetadataURLQuery sql err",
+			prepare: prepareSAMLIDPsWithMetadataURLQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					query,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*SAMLIDPWithMetadataURL)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
+
+func Test_LDAPIDPsWithDirectorySyncPrepares(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT projections.idp_templates6.instance_id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.id,` +
+		` projections.idp_templates6.owner_type,` +
+		` projections.idp_templates6_ldap3.group_mapping` +
+		` FROM projections.idp_templates6` +
+		` JOIN projections.idp_templates6_ldap3 ON projections.idp_templates6.id = projections.idp_templates6_ldap3.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap3.instance_id`)
+	cols := []string{
+		"instance_id",
+		"resource_owner",
+		"id",
+		"owner_type",
+		"group_mapping",
+	}
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareLDAPIDPsWithDirectorySyncQuery no result",
+			prepare: prepareLDAPIDPsWithDirectorySyncQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					query,
+					nil,
+					nil,
+				),
+			},
+			object: []*LDAPIDPWithDirectorySync{},
+		},
+		{
+			name:    "prepareLDAPIDPsWithDirectorySyncQuery found",
+			prepare: prepareLDAPIDPsWithDirectorySyncQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					query,
+					cols,
+					[][]driver.Value{
+						{
+							"instance-id",
+							"instance-id",
+							"idp-id-instance",
+							domain.IdentityProviderTypeSystem,
+							nil,
+						},
+						{
+							"instance-id",
+							"org-id",
+							"idp-id-org",
+							domain.IdentityProviderTypeOrg,
+							[]byte(`{"groupAttribute": "memberOf", "groups": [{"group": "cn=admins", "projectId": "project", "roleKeys": ["admin"]}]}`),
+						},
+					},
+				),
+			},
+			object: []*LDAPIDPWithDirectorySync{
+				{
+					InstanceID:    "instance-id",
+					ResourceOwner: "instance-id",
+					ID:            "idp-id-instance",
+					OwnerType:     domain.IdentityProviderTypeSystem,
+				},
+				{
+					InstanceID:    "instance-id",
+					ResourceOwner: "org-id",
+					ID:            "idp-id-org",
+					OwnerType:     domain.IdentityProviderTypeOrg,
+					GroupMapping: &domain.LDAPGroupMapping{
+						GroupAttribute: "memberOf",
+						Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPIDPsWithDirectorySyncQuery sql err",
+			prepare: prepareLDAPIDPsWithDirectorySyncQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					query,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*LDAPIDPWithDirectorySync)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -24,16 +24,17 @@ import (
 )
 
 type MessageTexts struct {
-	InitCode                 MessageText
-	PasswordReset            MessageText
-	VerifyEmail              MessageText
-	VerifyPhone              MessageText
-	VerifySMSOTP             MessageText
-	VerifyEmailOTP           MessageText
-	DomainClaimed            MessageText
-	PasswordlessRegistration MessageText
-	PasswordChange           MessageText
-	InviteUser               MessageText
+	InitCode                  MessageText
+	PasswordReset             MessageText
+	VerifyEmail               MessageText
+	VerifyPhone               MessageText
+	VerifySMSOTP              MessageText
+	VerifyEmailOTP            MessageText
+	DomainClaimed             MessageText
+	PasswordlessRegistration  MessageText
+	PasswordChange            MessageText
+	InviteUser                MessageText
+	BackchannelAuthentication MessageText
 }
 
 type MessageText struct {
@@ -349,6 +350,8 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordChange
 	case domain.InviteUserMessageType:
 		return &m.InviteUser
+	case domain.BackchannelAuthenticationMessageType:
+		return &m.BackchannelAuthentication
 	}
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net/url"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -14,37 +16,43 @@ import (
 	"github.com/zitadel/zitadel/internal/api/ui/console/path"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type OIDCClient struct {
-	InstanceID               string                     `json:"instance_id,omitempty"`
-	AppID                    string                     `json:"app_id,omitempty"`
-	State                    domain.AppState            `json:"state,omitempty"`
-	ClientID                 string                     `json:"client_id,omitempty"`
-	BackChannelLogoutURI     string                     `json:"back_channel_logout_uri,omitempty"`
-	HashedSecret             string                     `json:"client_secret,omitempty"`
-	RedirectURIs             []string                   `json:"redirect_uris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"response_types,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grant_types,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"application_type,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"auth_method_type,omitempty"`
-	PostLogoutRedirectURIs   []string                   `json:"post_logout_redirect_uris,omitempty"`
-	IsDevMode                bool                       `json:"is_dev_mode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"access_token_type,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"access_token_role_assertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"id_token_role_assertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"id_token_userinfo_assertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clock_skew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additional_origins,omitempty"`
-	PublicKeys               map[string][]byte          `json:"public_keys,omitempty"`
-	ProjectID                string                     `json:"project_id,omitempty"`
-	ProjectRoleAssertion     bool                       `json:"project_role_assertion,omitempty"`
-	LoginVersion             domain.LoginVersion        `json:"login_version,omitempty"`
-	LoginBaseURI             *URL                       `json:"login_base_uri,omitempty"`
-	ProjectRoleKeys          []string                   `json:"project_role_keys,omitempty"`
-	Settings                 *OIDCSettings              `json:"settings,omitempty"`
+	InstanceID                string                     `json:"instance_id,omitempty"`
+	AppID                     string                     `json:"app_id,omitempty"`
+	State                     domain.AppState            `json:"state,omitempty"`
+	ClientID                  string                     `json:"client_id,omitempty"`
+	BackChannelLogoutURI      string                     `json:"back_channel_logout_uri,omitempty"`
+	HashedSecret              string                     `json:"client_secret,omitempty"`
+	RedirectURIs              []string                   `json:"redirect_uris,omitempty"`
+	ResponseTypes             []domain.OIDCResponseType  `json:"response_types,omitempty"`
+	GrantTypes                []domain.OIDCGrantType     `json:"grant_types,omitempty"`
+	ApplicationType           domain.OIDCApplicationType `json:"application_type,omitempty"`
+	AuthMethodType            domain.OIDCAuthMethodType  `json:"auth_method_type,omitempty"`
+	PostLogoutRedirectURIs    []string                   `json:"post_logout_redirect_uris,omitempty"`
+	IsDevMode                 bool                       `json:"is_dev_mode,omitempty"`
+	AccessTokenType           domain.OIDCTokenType       `json:"access_token_type,omitempty"`
+	AccessTokenRoleAssertion  bool                       `json:"access_token_role_assertion,omitempty"`
+	IDTokenRoleAssertion      bool                       `json:"id_token_role_assertion,omitempty"`
+	IDTokenUserinfoAssertion  bool                       `json:"id_token_userinfo_assertion,omitempty"`
+	ClockSkew                 time.Duration              `json:"clock_skew,omitempty"`
+	AdditionalOrigins         []string                   `json:"additional_origins,omitempty"`
+	PublicKeys                map[string][]byte          `json:"public_keys,omitempty"`
+	PublicKeyExpirations      map[string]time.Time       `json:"public_key_expirations,omitempty"`
+	ProjectID                 string                     `json:"project_id,omitempty"`
+	ProjectRoleAssertion      bool                       `json:"project_role_assertion,omitempty"`
+	AuthorizationDetailsTypes []string                   `json:"authorization_details_types,omitempty"`
+	LoginVersion              domain.LoginVersion        `json:"login_version,omitempty"`
+	LoginBaseURI              *URL                       `json:"login_base_uri,omitempty"`
+	RequirePAR                bool                       `json:"require_par,omitempty"`
+	RequireDPoP               bool                       `json:"require_dpop,omitempty"`
+	CIBANotificationURI       string                     `json:"ciba_notification_uri,omitempty"`
+	ProjectRoleKeys           []string                   `json:"project_role_keys,omitempty"`
+	Settings                  *OIDCSettings              `json:"settings,omitempty"`
 }
 
 type URL url.URL
@@ -74,16 +82,11 @@ func (q *Queries) ActiveOIDCClientByID(ctx context.Context, clientID string, get
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	client, err = database.QueryJSONObject[OIDCClient](ctx, q.client, oidcClientQuery,
-		authz.GetInstance(ctx).InstanceID(), clientID, getKeys,
-	)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, zerrors.ThrowNotFound(err, "QUERY-wu6Ee", "Errors.App.NotFound")
-	}
+	instance := authz.GetInstance(ctx)
+	client, err = q.activeOIDCClientByID(ctx, instance.InstanceID(), clientID, getKeys)
 	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "QUERY-ieR7R", "Errors.Internal")
+		return nil, err
 	}
-	instance := authz.GetInstance(ctx)
 	loginV2 := instance.Features().LoginV2
 	if loginV2.Required {
 		client.LoginVersion = domain.LoginVersion2
@@ -93,5 +96,101 @@ func (q *Queries) ActiveOIDCClientByID(ctx context.Context, clientID string, get
 		client.RedirectURIs = append(client.RedirectURIs, http_util.DomainContext(ctx).Origin()+path.RedirectPath)
 		client.PostLogoutRedirectURIs = append(client.PostLogoutRedirectURIs, http_util.DomainContext(ctx).Origin()+path.PostLogoutPath)
 	}
-	return client, err
+	return client, nil
+}
+
+// activeOIDCClientByID returns the client from the cache or the database.
+// The returned client is a copy which can safely be modified by the caller.
+func (q *Queries) activeOIDCClientByID(ctx context.Context, instanceID, clientID string, getKeys bool) (_ *OIDCClient, err error) {
+	if cached, ok := q.caches.oidcClient.Get(ctx, oidcClientIndexByClientID, oidcClientCacheKey(instanceID, clientID, getKeys)); ok {
+		return cached.Client.copy(time.Now()), nil
+	}
+	client, err := database.QueryJSONObject[OIDCClient](ctx, q.client, oidcClientQuery,
+		instanceID, clientID, getKeys,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, zerrors.ThrowNotFound(err, "QUERY-wu6Ee", "Errors.App.NotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ieR7R", "Errors.Internal")
+	}
+	q.caches.oidcClient.Set(ctx, &cachedOIDCClient{
+		InstanceID: instanceID,
+		ClientID:   clientID,
+		WithKeys:   getKeys,
+		Client:     client,
+	})
+	return client.copy(time.Now()), nil
+}
+
+// copy returns a shallow copy of the client,
+// with copies of the slices modified by [Queries.ActiveOIDCClientByID].
+// Public keys which expired before now are removed,
+// as the client might have been cached before they expired.
+func (c *OIDCClient) copy(now time.Time) *OIDCClient {
+	client := *c
+	client.RedirectURIs = slices.Clone(c.RedirectURIs)
+	client.PostLogoutRedirectURIs = slices.Clone(c.PostLogoutRedirectURIs)
+	if c.PublicKeys != nil {
+		client.PublicKeys = make(map[string][]byte, len(c.PublicKeys))
+		for id, key := range c.PublicKeys {
+			if expiration, ok := c.PublicKeyExpirations[id]; ok && !expiration.After(now) {
+				continue
+			}
+			client.PublicKeys[id] = key
+		}
+	}
+	return &client
+}
+
+type oidcClientIndex int
+
+//go:generate enumer -type oidcClientIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	oidcClientIndexUnspecified oidcClientIndex = iota //
+	oidcClientIndexByClientID
+	oidcClientIndexByProjectID
+	oidcClientIndexByInstanceID
+)
+
+// cachedOIDCClient is the cache entry of an active OIDC client.
+// Clients are cached separately with and without public keys.
+type cachedOIDCClient struct {
+	InstanceID string      `json:"instance_id"`
+	ClientID   string      `json:"client_id"`
+	WithKeys   bool        `json:"with_keys"`
+	Client     *OIDCClient `json:"client"`
+}
+
+func oidcClientCacheKey(instanceID, clientID string, withKeys bool) string {
+	return instanceID + "/" + clientID + "/" + strconv.FormatBool(withKeys)
+}
+
+// Keys implements [cache.Entry]
+func (c *cachedOIDCClient) Keys(index oidcClientIndex) []string {
+	switch index {
+	case oidcClientIndexByClientID:
+		return []string{oidcClientCacheKey(c.InstanceID, c.ClientID, c.WithKeys)}
+	case oidcClientIndexByProjectID:
+		return []string{c.Client.ProjectID}
+	case oidcClientIndexByInstanceID:
+		return []string{c.InstanceID}
+	case oidcClientIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerOIDCClientInvalidation() {
+	// apps, their keys and the project roles are events of the project aggregate.
+	invalidate := cacheInvalidationFunc(c.oidcClient, oidcClientIndexByProjectID, getAggregateID)
+	projection.AppProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectRoleProjection.RegisterCacheInvalidation(invalidate)
+	projection.AuthNKeyProjection.RegisterCacheInvalidation(invalidate)
+
+	// The state of the organization and the OIDC settings affect all clients of the instance.
+	invalidate = cacheInvalidationFunc(c.oidcClient, oidcClientIndexByInstanceID, getInstanceID)
+	projection.OrgProjection.RegisterCacheInvalidation(invalidate)
+	projection.OIDCSettingsProjection.RegisterCacheInvalidation(invalidate)
 }
@@ -4,7 +4,7 @@ with client as (
 		c.grant_types, c.application_type, c.auth_method_type, c.post_logout_redirect_uris, c.is_dev_mode,
 		c.access_token_type, c.access_token_role_assertion, c.id_token_role_assertion,
 		c.id_token_userinfo_assertion, c.clock_skew, c.additional_origins, a.project_id, p.project_role_assertion,
-		c.login_version, c.login_base_uri
+		c.login_version, c.login_base_uri, c.require_par, c.require_dpop, c.ciba_notification_uri, p.authorization_details_types
 	from projections.apps7_oidc_configs c
 	join projections.apps7 a on a.id = c.app_id and a.instance_id = c.instance_id and a.state = 1
 	join projections.projects4 p on p.id = a.project_id and p.instance_id = a.instance_id and p.state = 1
@@ -20,7 +20,8 @@ roles as (
 	group by p.project_id
 ),
 keys as (
-	select identifier as client_id, json_object_agg(id, encode(public_key, 'base64')) as public_keys
+	select identifier as client_id, json_object_agg(id, encode(public_key, 'base64')) as public_keys,
+		json_object_agg(id, expiration) as public_key_expirations
 	from projections.authn_keys2
 	where $3 = true -- when argument is false, don't waste time on trying to query for keys.
 		and instance_id = $1
@@ -41,7 +42,7 @@ settings as (
 )
 
 select row_to_json(r) as client from (
-	select c.*, r.project_role_keys, k.public_keys, s.settings
+	select c.*, r.project_role_keys, k.public_keys, k.public_key_expirations, s.settings
 	from client c
 	left join roles r on r.project_id = c.project_id
 	left join keys k on k.client_id = c.client_id
@@ -7,11 +7,15 @@ import (
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -271,6 +275,9 @@ low2kyJov38V4Uk2I8kuXpLcnrpw5Tio2ooiUE27b0vHZqBKOei9Uo88qCrn3EKx
 						DB:       db,
 						Database: &prepareDB{},
 					},
+					caches: &Caches{
+						oidcClient: noop.NewCache[oidcClientIndex, string, *cachedOIDCClient](),
+					},
 				}
 				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
 				got, err := q.ActiveOIDCClientByID(ctx, "clientID", true)
@@ -280,3 +287,49 @@ low2kyJov38V4Uk2I8kuXpLcnrpw5Tio2ooiUE27b0vHZqBKOei9Uo88qCrn3EKx
 		})
 	}
 }
+
+func TestQueries_ActiveOIDCClientByID_cache(t *testing.T) {
+	expQuery := regexp.QuoteMeta(oidcClientQuery)
+	cols := []string{"client"}
+	ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+
+	// the database is only queried once, the second call must be served from the cache.
+	execMock(t, mockQuery(expQuery, cols, []driver.Value{testdataOidcClientJWT}, "instanceID", "clientID", true), func(db *sql.DB) {
+		q := &Queries{
+			client: &database.DB{
+				DB:       db,
+				Database: &prepareDB{},
+			},
+			caches: &Caches{
+				oidcClient: gomap.NewCache[oidcClientIndex, string, *cachedOIDCClient](ctx, oidcClientIndexValues(), cache.Config{}),
+			},
+		}
+		first, err := q.ActiveOIDCClientByID(ctx, "clientID", true)
+		require.NoError(t, err)
+		// modifications of the returned client must not change the cached client.
+		first.RedirectURIs = append(first.RedirectURIs, "https://example.com/modified")
+
+		second, err := q.ActiveOIDCClientByID(ctx, "clientID", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://localhost:9999/auth/callback"}, second.RedirectURIs)
+		assert.Equal(t, "236647088211951618", second.ClientID)
+	})
+}
+
+func TestOIDCClient_copy_expiredKeys(t *testing.T) {
+	now := time.Now()
+	client := &OIDCClient{
+		PublicKeys: map[string][]byte{
+			"expired": []byte("expired"),
+			"valid":   []byte("valid"),
+		},
+		PublicKeyExpirations: map[string]time.Time{
+			"expired": now.Add(-time.Minute),
+			"valid":   now.Add(time.Hour),
+		},
+	}
+	got := client.copy(now)
+	assert.Equal(t, map[string][]byte{"valid": []byte("valid")}, got.PublicKeys)
+	// the cached client must not be modified.
+	assert.Len(t, client.PublicKeys, 2)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -47,6 +48,10 @@ var (
 		name:  projection.ProjectColumnPrivateLabelingSetting,
 		table: projectsTable,
 	}
+	ProjectColumnAuthorizationDetailsTypes = Column{
+		name:  projection.ProjectColumnAuthorizationDetailsTypes,
+		table: projectsTable,
+	}
 	ProjectColumnCreationDate = Column{
 		name:  projection.ProjectColumnCreationDate,
 		table: projectsTable,
@@ -86,11 +91,12 @@ type Project struct {
 	State         domain.ProjectState
 	Sequence      uint64
 
-	Name                   string
-	ProjectRoleAssertion   bool
-	ProjectRoleCheck       bool
-	HasProjectCheck        bool
-	PrivateLabelingSetting domain.PrivateLabelingSetting
+	Name                      string
+	ProjectRoleAssertion      bool
+	ProjectRoleCheck          bool
+	HasProjectCheck           bool
+	PrivateLabelingSetting    domain.PrivateLabelingSetting
+	AuthorizationDetailsTypes database.TextArray[string]
 }
 
 type ProjectSearchQueries struct {
@@ -109,10 +115,20 @@ func (q *Queries) ProjectByID(ctx context.Context, shouldTriggerBulk bool, id st
 		traceSpan.EndWithError(err)
 	}
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if cached, ok := q.caches.project.Get(ctx, projectIndexByID, projectCacheKey(instanceID, id)); ok {
+		return cached.Project, nil
+	}
+	defer func() {
+		if err == nil && project != nil {
+			q.caches.project.Set(ctx, &cachedProject{InstanceID: instanceID, Project: project})
+		}
+	}()
+
 	stmt, scan := prepareProjectQuery(ctx, q.client)
 	eq := sq.Eq{
 		ProjectColumnID.identifier():         id,
-		ProjectColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		ProjectColumnInstanceID.identifier(): instanceID,
 	}
 	query, args, err := stmt.Where(eq).ToSql()
 	if err != nil {
@@ -205,7 +221,8 @@ func prepareProjectQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ProjectColumnProjectRoleAssertion.identifier(),
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
-			ProjectColumnPrivateLabelingSetting.identifier()).
+			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAuthorizationDetailsTypes.identifier()).
 			From(projectsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Project, error) {
@@ -222,6 +239,7 @@ func prepareProjectQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&p.ProjectRoleCheck,
 				&p.HasProjectCheck,
 				&p.PrivateLabelingSetting,
+				&p.AuthorizationDetailsTypes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -246,6 +264,7 @@ func prepareProjectsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
 			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAuthorizationDetailsTypes.identifier(),
 			countColumn.identifier()).
 			From(projectsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -266,6 +285,7 @@ func prepareProjectsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&project.ProjectRoleCheck,
 					&project.HasProjectCheck,
 					&project.PrivateLabelingSetting,
+					&project.AuthorizationDetailsTypes,
 					&count,
 				)
 				if err != nil {
@@ -286,3 +306,41 @@ func prepareProjectsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			}, nil
 		}
 }
+
+type projectIndex int
+
+//go:generate enumer -type projectIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	projectIndexUnspecified projectIndex = iota //
+	projectIndexByID
+	projectIndexByAggregateID
+)
+
+// cachedProject is the cache entry of a project.
+// Project IDs are only unique inside an instance, so the ID is combined with the instance ID.
+type cachedProject struct {
+	InstanceID string   `json:"instance_id"`
+	Project    *Project `json:"project"`
+}
+
+func projectCacheKey(instanceID, projectID string) string {
+	return instanceID + "/" + projectID
+}
+
+// Keys implements [cache.Entry]
+func (p *cachedProject) Keys(index projectIndex) []string {
+	switch index {
+	case projectIndexByID:
+		return []string{projectCacheKey(p.InstanceID, p.Project.ID)}
+	case projectIndexByAggregateID:
+		return []string{p.Project.ID}
+	case projectIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerProjectInvalidation() {
+	invalidate := cacheInvalidationFunc(c.project, projectIndexByAggregateID, getAggregateID)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+}
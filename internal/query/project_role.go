@@ -254,3 +254,68 @@ func prepareProjectRolesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			}, nil
 		}
 }
+
+// ProjectRolesByProjectID returns all roles of the project.
+// The roles are cached, because they are asserted for every token of projects with role assertion.
+func (q *Queries) ProjectRolesByProjectID(ctx context.Context, shouldTriggerBulk bool, projectID string) (roles *ProjectRoles, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if cached, ok := q.caches.projectRoles.Get(ctx, projectRolesIndexByProjectID, projectCacheKey(instanceID, projectID)); ok {
+		return &ProjectRoles{
+			SearchResponse: SearchResponse{Count: uint64(len(cached.Roles))},
+			ProjectRoles:   cached.Roles,
+		}, nil
+	}
+	projectIDQuery, err := NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err = q.SearchProjectRoles(ctx, shouldTriggerBulk, &ProjectRoleSearchQueries{Queries: []SearchQuery{projectIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	q.caches.projectRoles.Set(ctx, &cachedProjectRoles{
+		InstanceID: instanceID,
+		ProjectID:  projectID,
+		Roles:      roles.ProjectRoles,
+	})
+	return roles, nil
+}
+
+type projectRolesIndex int
+
+//go:generate enumer -type projectRolesIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	projectRolesIndexUnspecified projectRolesIndex = iota //
+	projectRolesIndexByProjectID
+	projectRolesIndexByAggregateID
+)
+
+// cachedProjectRoles is the cache entry of all roles of a project.
+type cachedProjectRoles struct {
+	InstanceID string         `json:"instance_id"`
+	ProjectID  string         `json:"project_id"`
+	Roles      []*ProjectRole `json:"roles,omitempty"`
+}
+
+// Keys implements [cache.Entry]
+func (r *cachedProjectRoles) Keys(index projectRolesIndex) []string {
+	switch index {
+	case projectRolesIndexByProjectID:
+		return []string{projectCacheKey(r.InstanceID, r.ProjectID)}
+	case projectRolesIndexByAggregateID:
+		return []string{r.ProjectID}
+	case projectRolesIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerProjectRolesInvalidation() {
+	// roles are part of the project aggregate
+	invalidate := cacheInvalidationFunc(c.projectRoles, projectRolesIndexByAggregateID, getAggregateID)
+	projection.ProjectRoleProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+}
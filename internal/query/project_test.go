@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -25,6 +26,7 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"authorization_details_types",
 	}
 
 	prepareProjectsStmt = `SELECT projections.projects4.id,` +
@@ -38,6 +40,7 @@ var (
 		` projections.projects4.project_role_check,` +
 		` projections.projects4.has_project_check,` +
 		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.authorization_details_types,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.projects4` +
 		` AS OF SYSTEM TIME '-1 ms'`
@@ -53,6 +56,7 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"authorization_details_types",
 		"count",
 	}
 
@@ -66,7 +70,8 @@ var (
 		` projections.projects4.project_role_assertion,` +
 		` projections.projects4.project_role_check,` +
 		` projections.projects4.has_project_check,` +
-		` projections.projects4.private_labeling_setting` +
+		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.authorization_details_types` +
 		` FROM projections.projects4` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareProjectCols = []string{
@@ -81,6 +86,7 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"authorization_details_types",
 	}
 )
 
@@ -127,6 +133,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							true,
 							true,
 							domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+							database.TextArray[string]{"payment_initiation"},
 						},
 					},
 				),
@@ -137,17 +144,18 @@ func Test_ProjectPrepares(t *testing.T) {
 				},
 				Projects: []*Project{
 					{
-						ID:                     "id",
-						CreationDate:           testNow,
-						ChangeDate:             testNow,
-						ResourceOwner:          "ro",
-						State:                  domain.ProjectStateActive,
-						Sequence:               20211108,
-						Name:                   "project-name",
-						ProjectRoleAssertion:   true,
-						ProjectRoleCheck:       true,
-						HasProjectCheck:        true,
-						PrivateLabelingSetting: domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						ID:                        "id",
+						CreationDate:              testNow,
+						ChangeDate:                testNow,
+						ResourceOwner:             "ro",
+						State:                     domain.ProjectStateActive,
+						Sequence:                  20211108,
+						Name:                      "project-name",
+						ProjectRoleAssertion:      true,
+						ProjectRoleCheck:          true,
+						HasProjectCheck:           true,
+						PrivateLabelingSetting:    domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 					},
 				},
 			},
@@ -172,6 +180,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							true,
 							true,
 							domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+							database.TextArray[string]{"payment_initiation"},
 						},
 						{
 							"id-2",
@@ -185,6 +194,7 @@ func Test_ProjectPrepares(t *testing.T) {
 							false,
 							false,
 							domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+							database.TextArray[string]{"payment_initiation"},
 						},
 					},
 				),
@@ -195,30 +205,32 @@ func Test_ProjectPrepares(t *testing.T) {
 				},
 				Projects: []*Project{
 					{
-						ID:                     "id-1",
-						CreationDate:           testNow,
-						ChangeDate:             testNow,
-						ResourceOwner:          "ro",
-						State:                  domain.ProjectStateActive,
-						Sequence:               20211108,
-						Name:                   "project-name-1",
-						ProjectRoleAssertion:   true,
-						ProjectRoleCheck:       true,
-						HasProjectCheck:        true,
-						PrivateLabelingSetting: domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						ID:                        "id-1",
+						CreationDate:              testNow,
+						ChangeDate:                testNow,
+						ResourceOwner:             "ro",
+						State:                     domain.ProjectStateActive,
+						Sequence:                  20211108,
+						Name:                      "project-name-1",
+						ProjectRoleAssertion:      true,
+						ProjectRoleCheck:          true,
+						HasProjectCheck:           true,
+						PrivateLabelingSetting:    domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 					},
 					{
-						ID:                     "id-2",
-						CreationDate:           testNow,
-						ChangeDate:             testNow,
-						ResourceOwner:          "ro",
-						State:                  domain.ProjectStateActive,
-						Sequence:               20211108,
-						Name:                   "project-name-2",
-						ProjectRoleAssertion:   false,
-						ProjectRoleCheck:       false,
-						HasProjectCheck:        false,
-						PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						ID:                        "id-2",
+						CreationDate:              testNow,
+						ChangeDate:                testNow,
+						ResourceOwner:             "ro",
+						State:                     domain.ProjectStateActive,
+						Sequence:                  20211108,
+						Name:                      "project-name-2",
+						ProjectRoleAssertion:      false,
+						ProjectRoleCheck:          false,
+						HasProjectCheck:           false,
+						PrivateLabelingSetting:    domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+						AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 					},
 				},
 			},
@@ -277,21 +289,23 @@ func Test_ProjectPrepares(t *testing.T) {
 						true,
 						true,
 						domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				State:                  domain.ProjectStateActive,
-				Sequence:               20211108,
-				Name:                   "project-name",
-				ProjectRoleAssertion:   true,
-				ProjectRoleCheck:       true,
-				HasProjectCheck:        true,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+				ID:                        "id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				State:                     domain.ProjectStateActive,
+				Sequence:                  20211108,
+				Name:                      "project-name",
+				ProjectRoleAssertion:      true,
+				ProjectRoleCheck:          true,
+				HasProjectCheck:           true,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
@@ -61,6 +61,9 @@ const (
 	AppOIDCConfigColumnBackChannelLogoutURI     = "back_channel_logout_uri"
 	AppOIDCConfigColumnLoginVersion             = "login_version"
 	AppOIDCConfigColumnLoginBaseURI             = "login_base_uri"
+	AppOIDCConfigColumnRequirePAR               = "require_par"
+	AppOIDCConfigColumnRequireDPoP              = "require_dpop"
+	AppOIDCConfigColumnCIBANotificationURI      = "ciba_notification_uri"
 
 	appSAMLTableSuffix              = "saml_configs"
 	AppSAMLConfigColumnAppID        = "app_id"
@@ -133,6 +136,9 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginVersion, handler.ColumnTypeEnum, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginBaseURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnRequirePAR, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnRequireDPoP, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnCIBANotificationURI, handler.ColumnTypeText, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -513,6 +519,9 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
 				handler.NewCol(AppOIDCConfigColumnLoginVersion, e.LoginVersion),
 				handler.NewCol(AppOIDCConfigColumnLoginBaseURI, e.LoginBaseURI),
+				handler.NewCol(AppOIDCConfigColumnRequirePAR, e.RequirePAR),
+				handler.NewCol(AppOIDCConfigColumnRequireDPoP, e.RequireDPoP),
+				handler.NewCol(AppOIDCConfigColumnCIBANotificationURI, e.CIBANotificationURI),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -590,6 +599,15 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.LoginBaseURI != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnLoginBaseURI, *e.LoginBaseURI))
 	}
+	if e.RequirePAR != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePAR, *e.RequirePAR))
+	}
+	if e.RequireDPoP != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireDPoP, *e.RequireDPoP))
+	}
+	if e.CIBANotificationURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnCIBANotificationURI, *e.CIBANotificationURI))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -0,0 +1,262 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	AppSCIMProvisioningTable               = "projections.apps_scim_provisioning"
+	AppSCIMProvisioningAppIDCol            = "app_id"
+	AppSCIMProvisioningInstanceIDCol       = "instance_id"
+	AppSCIMProvisioningProjectIDCol        = "project_id"
+	AppSCIMProvisioningResourceOwnerCol    = "resource_owner"
+	AppSCIMProvisioningCreationDateCol     = "creation_date"
+	AppSCIMProvisioningChangeDateCol       = "change_date"
+	AppSCIMProvisioningSequenceCol         = "sequence"
+	AppSCIMProvisioningEndpointCol         = "endpoint"
+	AppSCIMProvisioningTokenCol            = "token"
+	AppSCIMProvisioningUserTableSuffix     = "users"
+	AppSCIMProvisioningUserAppIDCol        = "app_id"
+	AppSCIMProvisioningUserInstanceIDCol   = "instance_id"
+	AppSCIMProvisioningUserUserIDCol       = "user_id"
+	AppSCIMProvisioningUserRemoteIDCol     = "remote_id"
+	AppSCIMProvisioningUserStateCol        = "state"
+	AppSCIMProvisioningUserErrorCol        = "error"
+	AppSCIMProvisioningUserChangeDateCol   = "change_date"
+	AppSCIMProvisioningUserSequenceCol     = "sequence"
+	AppSCIMProvisioningUserCreationDateCol = "creation_date"
+)
+
+type appSCIMProvisioningProjection struct{}
+
+func newAppSCIMProvisioningProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(appSCIMProvisioningProjection))
+}
+
+func (*appSCIMProvisioningProjection) Name() string {
+	return AppSCIMProvisioningTable
+}
+
+func (*appSCIMProvisioningProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(AppSCIMProvisioningAppIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningProjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppSCIMProvisioningChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppSCIMProvisioningSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(AppSCIMProvisioningEndpointCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningTokenCol, handler.ColumnTypeJSONB),
+		},
+			handler.NewPrimaryKey(AppSCIMProvisioningInstanceIDCol, AppSCIMProvisioningAppIDCol),
+			handler.WithIndex(handler.NewIndex("project_id", []string{AppSCIMProvisioningProjectIDCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppSCIMProvisioningUserAppIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningUserInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningUserUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AppSCIMProvisioningUserRemoteIDCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppSCIMProvisioningUserStateCol, handler.ColumnTypeEnum),
+			handler.NewColumn(AppSCIMProvisioningUserErrorCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppSCIMProvisioningUserCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppSCIMProvisioningUserChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppSCIMProvisioningUserSequenceCol, handler.ColumnTypeInt64),
+		},
+			handler.NewPrimaryKey(AppSCIMProvisioningUserInstanceIDCol, AppSCIMProvisioningUserAppIDCol, AppSCIMProvisioningUserUserIDCol),
+			AppSCIMProvisioningUserTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKey(
+				"app",
+				[]string{AppSCIMProvisioningUserInstanceIDCol, AppSCIMProvisioningUserAppIDCol},
+				[]string{AppSCIMProvisioningInstanceIDCol, AppSCIMProvisioningAppIDCol},
+			)),
+			handler.WithIndex(handler.NewIndex("user_id", []string{AppSCIMProvisioningUserUserIDCol})),
+		),
+	)
+}
+
+func (p *appSCIMProvisioningProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.SCIMProvisioningSetType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  project.SCIMProvisioningRemovedType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  project.SCIMProvisioningUserSyncedType,
+					Reduce: p.reduceUserSynced,
+				},
+				{
+					Event:  project.SCIMProvisioningUserFailedType,
+					Reduce: p.reduceUserFailed,
+				},
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceAppRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(AppSCIMProvisioningInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *appSCIMProvisioningProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.SCIMProvisioningSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(AppSCIMProvisioningAppIDCol, e.AppID),
+		},
+		[]handler.Column{
+			handler.NewCol(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(AppSCIMProvisioningAppIDCol, e.AppID),
+			handler.NewCol(AppSCIMProvisioningProjectIDCol, e.Aggregate().ID),
+			handler.NewCol(AppSCIMProvisioningResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(AppSCIMProvisioningCreationDateCol, handler.OnlySetValueOnInsert(AppSCIMProvisioningTable, e.CreationDate())),
+			handler.NewCol(AppSCIMProvisioningChangeDateCol, e.CreationDate()),
+			handler.NewCol(AppSCIMProvisioningSequenceCol, e.Sequence()),
+			handler.NewCol(AppSCIMProvisioningEndpointCol, e.Endpoint),
+			handler.NewCol(AppSCIMProvisioningTokenCol, e.Token),
+		},
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.SCIMProvisioningRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(AppSCIMProvisioningAppIDCol, e.AppID),
+		},
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) reduceUserSynced(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.SCIMProvisioningUserSyncedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.userStatement(e, e.AppID, e.UserID,
+		handler.NewCol(AppSCIMProvisioningUserRemoteIDCol, e.RemoteID),
+		handler.NewCol(AppSCIMProvisioningUserStateCol, e.State),
+		handler.NewCol(AppSCIMProvisioningUserErrorCol, ""),
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) reduceUserFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.SCIMProvisioningUserFailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	// the remote id is kept, the next sync of the user updates the existing user of the service provider
+	return p.userStatement(e, e.AppID, e.UserID,
+		handler.NewCol(AppSCIMProvisioningUserStateCol, domain.SCIMProvisioningStateFailed),
+		handler.NewCol(AppSCIMProvisioningUserErrorCol, e.Error),
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) userStatement(e eventstore.Event, appID, userID string, cols ...handler.Column) *handler.Statement {
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AppSCIMProvisioningUserInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(AppSCIMProvisioningUserAppIDCol, appID),
+			handler.NewCol(AppSCIMProvisioningUserUserIDCol, userID),
+		},
+		append([]handler.Column{
+			handler.NewCol(AppSCIMProvisioningUserInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(AppSCIMProvisioningUserAppIDCol, appID),
+			handler.NewCol(AppSCIMProvisioningUserUserIDCol, userID),
+			handler.NewCol(AppSCIMProvisioningUserCreationDateCol, handler.OnlySetValueOnInsert(AppSCIMProvisioningTable+"_"+AppSCIMProvisioningUserTableSuffix, e.CreatedAt())),
+			handler.NewCol(AppSCIMProvisioningUserChangeDateCol, e.CreatedAt()),
+			handler.NewCol(AppSCIMProvisioningUserSequenceCol, e.Sequence()),
+		}, cols...),
+		handler.WithTableSuffix(AppSCIMProvisioningUserTableSuffix),
+	)
+}
+
+func (p *appSCIMProvisioningProjection) reduceAppRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ApplicationRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(AppSCIMProvisioningAppIDCol, e.AppID),
+		},
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ProjectRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(AppSCIMProvisioningProjectIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *appSCIMProvisioningProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppSCIMProvisioningInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(AppSCIMProvisioningResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,261 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestAppSCIMProvisioningProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.SCIMProvisioningSetType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id", "endpoint": "https://example.com/scim/v2", "token": { "cryptoType": 0, "algorithm": "enc", "keyId": "key-id" }}`),
+					),
+					eventstore.GenericEventMapper[project.SCIMProvisioningSetEvent],
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps_scim_provisioning (instance_id, app_id, project_id, resource_owner, creation_date, change_date, sequence, endpoint, token) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, app_id) DO UPDATE SET (project_id, resource_owner, creation_date, change_date, sequence, endpoint, token) = (EXCLUDED.project_id, EXCLUDED.resource_owner, projections.apps_scim_provisioning.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.endpoint, EXCLUDED.token)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"https://example.com/scim/v2",
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.SCIMProvisioningRemovedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id"}`),
+					),
+					eventstore.GenericEventMapper[project.SCIMProvisioningRemovedEvent],
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.apps_scim_provisioning WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserSynced",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.SCIMProvisioningUserSyncedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id", "userId": "user-id", "remoteId": "remote-id", "state": 1}`),
+					),
+					eventstore.GenericEventMapper[project.SCIMProvisioningUserSyncedEvent],
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceUserSynced,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps_scim_provisioning_users (instance_id, app_id, user_id, creation_date, change_date, sequence, remote_id, state, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, app_id, user_id) DO UPDATE SET (creation_date, change_date, sequence, remote_id, state, error) = (projections.apps_scim_provisioning_users.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.remote_id, EXCLUDED.state, EXCLUDED.error)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"user-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"remote-id",
+								domain.SCIMProvisioningStateProvisioned,
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.SCIMProvisioningUserFailedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id", "userId": "user-id", "error": "status 500"}`),
+					),
+					eventstore.GenericEventMapper[project.SCIMProvisioningUserFailedEvent],
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceUserFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps_scim_provisioning_users (instance_id, app_id, user_id, creation_date, change_date, sequence, state, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, app_id, user_id) DO UPDATE SET (creation_date, change_date, sequence, state, error) = (projections.apps_scim_provisioning_users.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.state, EXCLUDED.error)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"user-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.SCIMProvisioningStateFailed,
+								"status 500",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAppRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ApplicationRemovedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id"}`),
+					),
+					project.ApplicationRemovedEventMapper,
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceAppRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.apps_scim_provisioning WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectRemovedType,
+						project.AggregateType,
+						[]byte(`{}`),
+					),
+					project.ProjectRemovedEventMapper,
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.apps_scim_provisioning WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					),
+					org.OrgRemovedEventMapper,
+				),
+			},
+			reduce: (&appSCIMProvisioningProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.apps_scim_provisioning WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, AppSCIMProvisioningTable, tt.want)
+		})
+	}
+}
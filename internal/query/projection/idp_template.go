@@ -30,6 +30,7 @@ const (
 	IDPTemplateLDAPTable             = IDPTemplateTable + "_" + IDPTemplateLDAPSuffix
 	IDPTemplateAppleTable            = IDPTemplateTable + "_" + IDPTemplateAppleSuffix
 	IDPTemplateSAMLTable             = IDPTemplateTable + "_" + IDPTemplateSAMLSuffix
+	IDPTemplateKerberosTable         = IDPTemplateTable + "_" + IDPTemplateKerberosSuffix
 
 	IDPTemplateOAuthSuffix            = "oauth2"
 	IDPTemplateOIDCSuffix             = "oidc"
@@ -43,6 +44,7 @@ const (
 	IDPTemplateLDAPSuffix             = "ldap3"
 	IDPTemplateAppleSuffix            = "apple"
 	IDPTemplateSAMLSuffix             = "saml"
+	IDPTemplateKerberosSuffix         = "kerberos"
 
 	IDPTemplateIDCol                = "id"
 	IDPTemplateCreationDateCol      = "creation_date"
@@ -70,6 +72,7 @@ const (
 	OAuthUserEndpointCol          = "user_endpoint"
 	OAuthScopesCol                = "scopes"
 	OAuthIDAttributeCol           = "id_attribute"
+	OAuthClaimMappingCol          = "claim_mapping"
 
 	OIDCIDCol             = "idp_id"
 	OIDCInstanceIDCol     = "instance_id"
@@ -78,6 +81,7 @@ const (
 	OIDCClientSecretCol   = "client_secret"
 	OIDCScopesCol         = "scopes"
 	OIDCIDTokenMappingCol = "id_token_mapping"
+	OIDCClaimMappingCol   = "claim_mapping"
 
 	JWTIDCol           = "idp_id"
 	JWTInstanceIDCol   = "instance_id"
@@ -153,6 +157,8 @@ const (
 	LDAPPreferredLanguageAttributeCol = "preferred_language_attribute"
 	LDAPAvatarURLAttributeCol         = "avatar_url_attribute"
 	LDAPProfileAttributeCol           = "profile_attribute"
+	LDAPGroupMappingCol               = "group_mapping"
+	LDAPDirectorySyncCol              = "directory_sync"
 
 	AppleIDCol         = "idp_id"
 	AppleInstanceIDCol = "instance_id"
@@ -165,12 +171,20 @@ const (
 	SAMLIDCol                         = "idp_id"
 	SAMLInstanceIDCol                 = "instance_id"
 	SAMLMetadataCol                   = "metadata"
+	SAMLMetadataURLCol                = "metadata_url"
 	SAMLKeyCol                        = "key"
 	SAMLCertificateCol                = "certificate"
+	SAMLAdditionalKeysCol             = "additional_keys"
 	SAMLBindingCol                    = "binding"
 	SAMLWithSignedRequestCol          = "with_signed_request"
 	SAMLNameIDFormatCol               = "name_id_format"
 	SAMLTransientMappingAttributeName = "transient_mapping_attribute_name"
+	SAMLClaimMappingCol               = "claim_mapping"
+
+	KerberosIDCol               = "idp_id"
+	KerberosInstanceIDCol       = "instance_id"
+	KerberosServicePrincipalCol = "service_principal"
+	KerberosKeytabCol           = "keytab"
 )
 
 type idpTemplateProjection struct{}
@@ -217,6 +231,7 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(OAuthUserEndpointCol, handler.ColumnTypeText),
 			handler.NewColumn(OAuthScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(OAuthIDAttributeCol, handler.ColumnTypeText),
+			handler.NewColumn(OAuthClaimMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(OAuthInstanceIDCol, OAuthIDCol),
 			IDPTemplateOAuthSuffix,
@@ -230,6 +245,7 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(OIDCClientSecretCol, handler.ColumnTypeJSONB),
 			handler.NewColumn(OIDCScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(OIDCIDTokenMappingCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(OIDCClaimMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(OIDCInstanceIDCol, OIDCIDCol),
 			IDPTemplateOIDCSuffix,
@@ -345,6 +361,8 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(LDAPPreferredLanguageAttributeCol, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(LDAPAvatarURLAttributeCol, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(LDAPProfileAttributeCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPGroupMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(LDAPDirectorySyncCol, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(LDAPInstanceIDCol, LDAPIDCol),
 			IDPTemplateLDAPSuffix,
@@ -373,11 +391,24 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(SAMLWithSignedRequestCol, handler.ColumnTypeBool, handler.Nullable()),
 			handler.NewColumn(SAMLNameIDFormatCol, handler.ColumnTypeEnum, handler.Nullable()),
 			handler.NewColumn(SAMLTransientMappingAttributeName, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SAMLClaimMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SAMLMetadataURLCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SAMLAdditionalKeysCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SAMLInstanceIDCol, SAMLIDCol),
 			IDPTemplateSAMLSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(KerberosIDCol, handler.ColumnTypeText),
+			handler.NewColumn(KerberosInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(KerberosServicePrincipalCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(KerberosKeytabCol, handler.ColumnTypeJSONB),
+		},
+			handler.NewPrimaryKey(KerberosInstanceIDCol, KerberosIDCol),
+			IDPTemplateKerberosSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -514,6 +545,14 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SAMLIDPChangedEventType,
 					Reduce: p.reduceSAMLIDPChanged,
 				},
+				{
+					Event:  instance.KerberosIDPAddedEventType,
+					Reduce: p.reduceKerberosIDPAdded,
+				},
+				{
+					Event:  instance.KerberosIDPChangedEventType,
+					Reduce: p.reduceKerberosIDPChanged,
+				},
 				{
 					Event:  instance.IDPConfigRemovedEventType,
 					Reduce: p.reduceIDPConfigRemoved,
@@ -659,6 +698,14 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  org.SAMLIDPChangedEventType,
 					Reduce: p.reduceSAMLIDPChanged,
 				},
+				{
+					Event:  org.KerberosIDPAddedEventType,
+					Reduce: p.reduceKerberosIDPAdded,
+				},
+				{
+					Event:  org.KerberosIDPChangedEventType,
+					Reduce: p.reduceKerberosIDPChanged,
+				},
 				{
 					Event:  org.IDPConfigRemovedEventType,
 					Reduce: p.reduceIDPConfigRemoved,
@@ -722,6 +769,7 @@ func (p *idpTemplateProjection) reduceOAuthIDPAdded(event eventstore.Event) (*ha
 				handler.NewCol(OAuthUserEndpointCol, idpEvent.UserEndpoint),
 				handler.NewCol(OAuthScopesCol, database.TextArray[string](idpEvent.Scopes)),
 				handler.NewCol(OAuthIDAttributeCol, idpEvent.IDAttribute),
+				handler.NewCol(OAuthClaimMappingCol, idpEvent.ClaimMapping),
 			},
 			handler.WithTableSuffix(IDPTemplateOAuthSuffix),
 		),
@@ -813,6 +861,7 @@ func (p *idpTemplateProjection) reduceOIDCIDPAdded(event eventstore.Event) (*han
 				handler.NewCol(OIDCClientSecretCol, idpEvent.ClientSecret),
 				handler.NewCol(OIDCScopesCol, database.TextArray[string](idpEvent.Scopes)),
 				handler.NewCol(OIDCIDTokenMappingCol, idpEvent.IsIDTokenMapping),
+				handler.NewCol(OIDCClaimMappingCol, idpEvent.ClaimMapping),
 			},
 			handler.WithTableSuffix(IDPTemplateOIDCSuffix),
 		),
@@ -1912,6 +1961,8 @@ func (p *idpTemplateProjection) reduceLDAPIDPAdded(event eventstore.Event) (*han
 				handler.NewCol(LDAPPreferredLanguageAttributeCol, idpEvent.PreferredLanguageAttribute),
 				handler.NewCol(LDAPAvatarURLAttributeCol, idpEvent.AvatarURLAttribute),
 				handler.NewCol(LDAPProfileAttributeCol, idpEvent.ProfileAttribute),
+				handler.NewCol(LDAPGroupMappingCol, idpEvent.GroupMapping),
+				handler.NewCol(LDAPDirectorySyncCol, idpEvent.DirectorySync),
 			},
 			handler.WithTableSuffix(IDPTemplateLDAPSuffix),
 		),
@@ -1978,11 +2029,13 @@ func (p *idpTemplateProjection) reduceSAMLIDPAdded(event eventstore.Event) (*han
 		handler.NewCol(SAMLIDCol, idpEvent.ID),
 		handler.NewCol(SAMLInstanceIDCol, idpEvent.Aggregate().InstanceID),
 		handler.NewCol(SAMLMetadataCol, idpEvent.Metadata),
+		handler.NewCol(SAMLMetadataURLCol, idpEvent.MetadataURL),
 		handler.NewCol(SAMLKeyCol, idpEvent.Key),
 		handler.NewCol(SAMLCertificateCol, idpEvent.Certificate),
 		handler.NewCol(SAMLBindingCol, idpEvent.Binding),
 		handler.NewCol(SAMLWithSignedRequestCol, idpEvent.WithSignedRequest),
 		handler.NewCol(SAMLTransientMappingAttributeName, idpEvent.TransientMappingAttributeName),
+		handler.NewCol(SAMLClaimMappingCol, idpEvent.ClaimMapping),
 	}
 	if idpEvent.NameIDFormat != nil {
 		columns = append(columns, handler.NewCol(SAMLNameIDFormatCol, *idpEvent.NameIDFormat))
@@ -2149,6 +2202,94 @@ func (p *idpTemplateProjection) reduceAppleIDPChanged(event eventstore.Event) (*
 	), nil
 }
 
+func (p *idpTemplateProjection) reduceKerberosIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.KerberosIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.KerberosIDPAddedEvent:
+		idpEvent = e.KerberosIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.KerberosIDPAddedEvent:
+		idpEvent = e.KerberosIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Kq3nb", "reduce.wrong.event.type %v", []eventstore.EventType{org.KerberosIDPAddedEventType, instance.KerberosIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeKerberos),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(KerberosIDCol, idpEvent.ID),
+				handler.NewCol(KerberosInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(KerberosServicePrincipalCol, idpEvent.ServicePrincipal),
+				handler.NewCol(KerberosKeytabCol, idpEvent.Keytab),
+			},
+			handler.WithTableSuffix(IDPTemplateKerberosSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceKerberosIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.KerberosIDPChangedEvent
+	switch e := event.(type) {
+	case *org.KerberosIDPChangedEvent:
+		idpEvent = e.KerberosIDPChangedEvent
+	case *instance.KerberosIDPChangedEvent:
+		idpEvent = e.KerberosIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Kz8vt", "reduce.wrong.event.type %v", []eventstore.EventType{org.KerberosIDPChangedEventType, instance.KerberosIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	kerberosCols := reduceKerberosIDPChangedColumns(idpEvent)
+	if len(kerberosCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				kerberosCols,
+				[]handler.Condition{
+					handler.NewCond(KerberosIDCol, idpEvent.ID),
+					handler.NewCond(KerberosInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateKerberosSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
 func (p *idpTemplateProjection) reduceIDPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
 	var idpEvent idpconfig.IDPConfigRemovedEvent
 	switch e := event.(type) {
@@ -2253,6 +2394,9 @@ func reduceOAuthIDPChangedColumns(idpEvent idp.OAuthIDPChangedEvent) []handler.C
 	if idpEvent.IDAttribute != nil {
 		oauthCols = append(oauthCols, handler.NewCol(OAuthIDAttributeCol, *idpEvent.IDAttribute))
 	}
+	if idpEvent.ClaimMapping != nil {
+		oauthCols = append(oauthCols, handler.NewCol(OAuthClaimMappingCol, idpEvent.ClaimMapping))
+	}
 	return oauthCols
 }
 
@@ -2273,6 +2417,9 @@ func reduceOIDCIDPChangedColumns(idpEvent idp.OIDCIDPChangedEvent) []handler.Col
 	if idpEvent.IsIDTokenMapping != nil {
 		oidcCols = append(oidcCols, handler.NewCol(OIDCIDTokenMappingCol, *idpEvent.IsIDTokenMapping))
 	}
+	if idpEvent.ClaimMapping != nil {
+		oidcCols = append(oidcCols, handler.NewCol(OIDCClaimMappingCol, idpEvent.ClaimMapping))
+	}
 	return oidcCols
 }
 
@@ -2396,7 +2543,7 @@ func reduceGoogleIDPChangedColumns(idpEvent idp.GoogleIDPChangedEvent) []handler
 }
 
 func reduceLDAPIDPChangedColumns(idpEvent idp.LDAPIDPChangedEvent) []handler.Column {
-	ldapCols := make([]handler.Column, 0, 22)
+	ldapCols := make([]handler.Column, 0, 24)
 	if idpEvent.Servers != nil {
 		ldapCols = append(ldapCols, handler.NewCol(LDAPServersCol, database.TextArray[string](idpEvent.Servers)))
 	}
@@ -2427,6 +2574,12 @@ func reduceLDAPIDPChangedColumns(idpEvent idp.LDAPIDPChangedEvent) []handler.Col
 	if idpEvent.RootCA != nil {
 		ldapCols = append(ldapCols, handler.NewCol(LDAPRootCACol, idpEvent.RootCA))
 	}
+	if idpEvent.GroupMapping != nil {
+		ldapCols = append(ldapCols, handler.NewCol(LDAPGroupMappingCol, idpEvent.GroupMapping))
+	}
+	if idpEvent.DirectorySync != nil {
+		ldapCols = append(ldapCols, handler.NewCol(LDAPDirectorySyncCol, *idpEvent.DirectorySync))
+	}
 	if idpEvent.IDAttribute != nil {
 		ldapCols = append(ldapCols, handler.NewCol(LDAPIDAttributeCol, *idpEvent.IDAttribute))
 	}
@@ -2469,6 +2622,17 @@ func reduceLDAPIDPChangedColumns(idpEvent idp.LDAPIDPChangedEvent) []handler.Col
 	return ldapCols
 }
 
+func reduceKerberosIDPChangedColumns(idpEvent idp.KerberosIDPChangedEvent) []handler.Column {
+	kerberosCols := make([]handler.Column, 0, 2)
+	if idpEvent.ServicePrincipal != nil {
+		kerberosCols = append(kerberosCols, handler.NewCol(KerberosServicePrincipalCol, *idpEvent.ServicePrincipal))
+	}
+	if idpEvent.Keytab != nil {
+		kerberosCols = append(kerberosCols, handler.NewCol(KerberosKeytabCol, idpEvent.Keytab))
+	}
+	return kerberosCols
+}
+
 func reduceAppleIDPChangedColumns(idpEvent idp.AppleIDPChangedEvent) []handler.Column {
 	appleCols := make([]handler.Column, 0, 5)
 	if idpEvent.ClientID != nil {
@@ -2490,16 +2654,22 @@ func reduceAppleIDPChangedColumns(idpEvent idp.AppleIDPChangedEvent) []handler.C
 }
 
 func reduceSAMLIDPChangedColumns(idpEvent idp.SAMLIDPChangedEvent) []handler.Column {
-	SAMLCols := make([]handler.Column, 0, 5)
+	SAMLCols := make([]handler.Column, 0, 11)
 	if idpEvent.Metadata != nil {
 		SAMLCols = append(SAMLCols, handler.NewCol(SAMLMetadataCol, idpEvent.Metadata))
 	}
+	if idpEvent.MetadataURL != nil {
+		SAMLCols = append(SAMLCols, handler.NewCol(SAMLMetadataURLCol, *idpEvent.MetadataURL))
+	}
 	if idpEvent.Key != nil {
 		SAMLCols = append(SAMLCols, handler.NewCol(SAMLKeyCol, idpEvent.Key))
 	}
 	if idpEvent.Certificate != nil {
 		SAMLCols = append(SAMLCols, handler.NewCol(SAMLCertificateCol, idpEvent.Certificate))
 	}
+	if idpEvent.AdditionalKeys != nil {
+		SAMLCols = append(SAMLCols, handler.NewCol(SAMLAdditionalKeysCol, *idpEvent.AdditionalKeys))
+	}
 	if idpEvent.Binding != nil {
 		SAMLCols = append(SAMLCols, handler.NewCol(SAMLBindingCol, *idpEvent.Binding))
 	}
@@ -2512,5 +2682,8 @@ func reduceSAMLIDPChangedColumns(idpEvent idp.SAMLIDPChangedEvent) []handler.Col
 	if idpEvent.TransientMappingAttributeName != nil {
 		SAMLCols = append(SAMLCols, handler.NewCol(SAMLTransientMappingAttributeName, *idpEvent.TransientMappingAttributeName))
 	}
+	if idpEvent.ClaimMapping != nil {
+		SAMLCols = append(SAMLCols, handler.NewCol(SAMLClaimMappingCol, idpEvent.ClaimMapping))
+	}
 	return SAMLCols
 }
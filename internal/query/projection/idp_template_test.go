@@ -227,7 +227,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_oauth2 (idp_id, instance_id, client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_oauth2 (idp_id, instance_id, client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute, claim_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -238,6 +238,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 								"user",
 								database.TextArray[string]{"profile"},
 								"id-attribute",
+								(*domain.IDPClaimMapping)(nil),
 							},
 						},
 					},
@@ -300,7 +301,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_oauth2 (idp_id, instance_id, client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_oauth2 (idp_id, instance_id, client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute, claim_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -311,6 +312,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 								"user",
 								database.TextArray[string]{"profile"},
 								"id-attribute",
+								(*domain.IDPClaimMapping)(nil),
 							},
 						},
 					},
@@ -380,6 +382,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
  	"userEndpoint": "user",
 	"scopes": ["profile"],
 	"idAttribute": "id-attribute",
+	"claimMapping": {"email": "$.mail"},
 	"isCreationAllowed": true,
 	"isLinkingAllowed": true,
 	"isAutoCreation": true,
@@ -410,7 +413,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "UPDATE projections.idp_templates6_oauth2 SET (client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute) = ($1, $2, $3, $4, $5, $6, $7) WHERE (idp_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.idp_templates6_oauth2 SET (client_id, client_secret, authorization_endpoint, token_endpoint, user_endpoint, scopes, id_attribute, claim_mapping) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (idp_id = $9) AND (instance_id = $10)",
 							expectedArgs: []interface{}{
 								"client_id",
 								anyArg{},
@@ -419,6 +422,7 @@ func TestIDPTemplateProjection_reducesOAuth(t *testing.T) {
 								"user",
 								database.TextArray[string]{"profile"},
 								"id-attribute",
+								&domain.IDPClaimMapping{Email: "$.mail"},
 								"idp-id",
 								"instance-id",
 							},
@@ -2131,6 +2135,8 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 	"preferredLanguageAttribute": "lang",
 	"avatarURLAttribute": "avatar",
 	"profileAttribute": "profile",
+	"groupMapping": {"groupAttribute": "memberOf", "groups": [{"group": "cn=admins", "projectId": "project", "roleKeys": ["admin"]}]},
+	"directorySync": true,
 	"isCreationAllowed": true,
 	"isLinkingAllowed": true,
 	"isAutoCreation": true,
@@ -2166,7 +2172,7 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_ldap3 (idp_id, instance_id, servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_ldap3 (idp_id, instance_id, servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute, group_mapping, directory_sync) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -2193,6 +2199,11 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 								"lang",
 								"avatar",
 								"profile",
+								&domain.LDAPGroupMapping{
+									GroupAttribute: "memberOf",
+									Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}},
+								},
+								true,
 							},
 						},
 					},
@@ -2271,7 +2282,7 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_ldap3 (idp_id, instance_id, servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_ldap3 (idp_id, instance_id, servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute, group_mapping, directory_sync) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -2298,6 +2309,8 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 								"lang",
 								"avatar",
 								"profile",
+								(*domain.LDAPGroupMapping)(nil),
+								false,
 							},
 						},
 					},
@@ -2383,6 +2396,8 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 	"preferredLanguageAttribute": "lang",
 	"avatarURLAttribute": "avatar",
 	"profileAttribute": "profile",
+	"groupMapping": {"groupAttribute": "memberOf", "groups": [{"group": "cn=admins", "projectId": "project", "roleKeys": ["admin"]}]},
+	"directorySync": true,
 	"isCreationAllowed": true,
 	"isLinkingAllowed": true,
 	"isAutoCreation": true,
@@ -2413,7 +2428,7 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "UPDATE projections.idp_templates6_ldap3 SET (servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23) WHERE (idp_id = $24) AND (instance_id = $25)",
+							expectedStmt: "UPDATE projections.idp_templates6_ldap3 SET (servers, start_tls, base_dn, bind_dn, bind_password, user_base, user_object_classes, user_filters, timeout, rootCA, group_mapping, directory_sync, id_attribute, first_name_attribute, last_name_attribute, display_name_attribute, nick_name_attribute, preferred_username_attribute, email_attribute, email_verified, phone_attribute, phone_verified_attribute, preferred_language_attribute, avatar_url_attribute, profile_attribute) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25) WHERE (idp_id = $26) AND (instance_id = $27)",
 							expectedArgs: []interface{}{
 								database.TextArray[string]{"server"},
 								false,
@@ -2425,6 +2440,11 @@ func TestIDPTemplateProjection_reducesLDAP(t *testing.T) {
 								database.TextArray[string]{"filter"},
 								time.Duration(30000000000),
 								[]byte("certificate"),
+								&domain.LDAPGroupMapping{
+									GroupAttribute: "memberOf",
+									Groups:         []*domain.LDAPGroupRole{{Group: "cn=admins", ProjectID: "project", RoleKeys: []string{"admin"}}},
+								},
+								true,
 								"id",
 								"first",
 								"last",
@@ -2753,6 +2773,253 @@ func TestIDPTemplateProjection_reducesApple(t *testing.T) {
 	}
 }
 
+func TestIDPTemplateProjection_reducesKerberos(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceKerberosIDPAdded",
+			args: args{
+				event: getEvent(testEvent(
+					instance.KerberosIDPAddedEventType,
+					instance.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"name": "name",
+	"servicePrincipal": "HTTP/login.example.com",
+	"keytab": {
+        "cryptoType": 0,
+        "algorithm": "RSA-265",
+        "keyId": "key-id"
+    },
+	"isCreationAllowed": true,
+	"isLinkingAllowed": true,
+	"isAutoCreation": true,
+	"isAutoUpdate": true,
+	"autoLinkingOption": 1
+}`),
+				), instance.KerberosIDPAddedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceKerberosIDPAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateInsertStmt,
+							expectedArgs: []interface{}{
+								"idp-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.IDPStateActive,
+								"name",
+								domain.IdentityProviderTypeSystem,
+								domain.IDPTypeKerberos,
+								true,
+								true,
+								true,
+								true,
+								domain.AutoLinkingOptionUsername,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_kerberos (idp_id, instance_id, service_principal, keytab) VALUES ($1, $2, $3, $4)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"HTTP/login.example.com",
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceKerberosIDPAdded",
+			args: args{
+				event: getEvent(testEvent(
+					org.KerberosIDPAddedEventType,
+					org.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"name": "name",
+	"keytab": {
+        "cryptoType": 0,
+        "algorithm": "RSA-265",
+        "keyId": "key-id"
+    },
+	"isCreationAllowed": true,
+	"isLinkingAllowed": true,
+	"isAutoCreation": true,
+	"isAutoUpdate": true,
+	"autoLinkingOption": 1
+}`),
+				), org.KerberosIDPAddedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceKerberosIDPAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateInsertStmt,
+							expectedArgs: []interface{}{
+								"idp-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.IDPStateActive,
+								"name",
+								domain.IdentityProviderTypeOrg,
+								domain.IDPTypeKerberos,
+								true,
+								true,
+								true,
+								true,
+								domain.AutoLinkingOptionUsername,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_kerberos (idp_id, instance_id, service_principal, keytab) VALUES ($1, $2, $3, $4)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"",
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceKerberosIDPChanged minimal",
+			args: args{
+				event: getEvent(testEvent(
+					instance.KerberosIDPChangedEventType,
+					instance.AggregateType,
+					[]byte(`{
+			"id": "idp-id",
+			"isCreationAllowed": true,
+			"servicePrincipal": "HTTP/login.example.com"
+		}`),
+				), instance.KerberosIDPChangedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceKerberosIDPChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateUpdateMinimalStmt,
+							expectedArgs: []interface{}{
+								true,
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_templates6_kerberos SET service_principal = $1 WHERE (idp_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"HTTP/login.example.com",
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceKerberosIDPChanged",
+			args: args{
+				event: getEvent(testEvent(
+					instance.KerberosIDPChangedEventType,
+					instance.AggregateType,
+					[]byte(`{
+			"id": "idp-id",
+			"name": "name",
+			"servicePrincipal": "HTTP/login.example.com",
+			"keytab": {
+				"cryptoType": 0,
+				"algorithm": "RSA-265",
+				"keyId": "key-id"
+			},
+			"isCreationAllowed": true,
+			"isLinkingAllowed": true,
+			"isAutoCreation": true,
+			"isAutoUpdate": true,
+			"autoLinkingOption": 1
+		}`),
+				), instance.KerberosIDPChangedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceKerberosIDPChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateUpdateStmt,
+							expectedArgs: []interface{}{
+								"name",
+								true,
+								true,
+								true,
+								true,
+								domain.AutoLinkingOptionUsername,
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_templates6_kerberos SET (service_principal, keytab) = ($1, $2) WHERE (idp_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"HTTP/login.example.com",
+								anyArg{},
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPTemplateTable, tt.want)
+		})
+	}
+}
+
 func TestIDPTemplateProjection_reducesSAML(t *testing.T) {
 	type args struct {
 		event func(t *testing.T) eventstore.Event
@@ -2818,16 +3085,18 @@ func TestIDPTemplateProjection_reducesSAML(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_saml (idp_id, instance_id, metadata, key, certificate, binding, with_signed_request, transient_mapping_attribute_name, name_id_format) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_saml (idp_id, instance_id, metadata, metadata_url, key, certificate, binding, with_signed_request, transient_mapping_attribute_name, claim_mapping, name_id_format) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
 								[]byte("metadata"),
+								"",
 								anyArg{},
 								anyArg{},
 								"binding",
 								true,
 								"customAttribute",
+								(*domain.IDPClaimMapping)(nil),
 								domain.SAMLNameIDFormatTransient,
 							},
 						},
@@ -2890,16 +3159,18 @@ func TestIDPTemplateProjection_reducesSAML(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_saml (idp_id, instance_id, metadata, key, certificate, binding, with_signed_request, transient_mapping_attribute_name, name_id_format) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_saml (idp_id, instance_id, metadata, metadata_url, key, certificate, binding, with_signed_request, transient_mapping_attribute_name, claim_mapping, name_id_format) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
 								[]byte("metadata"),
+								"",
 								anyArg{},
 								anyArg{},
 								"binding",
 								true,
 								"customAttribute",
+								(*domain.IDPClaimMapping)(nil),
 								domain.SAMLNameIDFormatTransient,
 							},
 						},
@@ -3116,7 +3387,7 @@ func TestIDPTemplateProjection_reducesOIDC(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_oidc (idp_id, instance_id, issuer, client_id, client_secret, scopes, id_token_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_oidc (idp_id, instance_id, issuer, client_id, client_secret, scopes, id_token_mapping, claim_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -3125,6 +3396,7 @@ func TestIDPTemplateProjection_reducesOIDC(t *testing.T) {
 								anyArg{},
 								database.TextArray[string]{"profile"},
 								true,
+								(*domain.IDPClaimMapping)(nil),
 							},
 						},
 					},
@@ -3184,7 +3456,7 @@ func TestIDPTemplateProjection_reducesOIDC(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.idp_templates6_oidc (idp_id, instance_id, issuer, client_id, client_secret, scopes, id_token_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedStmt: "INSERT INTO projections.idp_templates6_oidc (idp_id, instance_id, issuer, client_id, client_secret, scopes, id_token_mapping, claim_mapping) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"idp-id",
 								"instance-id",
@@ -3193,6 +3465,7 @@ func TestIDPTemplateProjection_reducesOIDC(t *testing.T) {
 								anyArg{},
 								database.TextArray[string]{"profile"},
 								true,
+								(*domain.IDPClaimMapping)(nil),
 							},
 						},
 					},
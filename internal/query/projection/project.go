@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -16,18 +17,19 @@ import (
 const (
 	ProjectProjectionTable = "projections.projects4"
 
-	ProjectColumnID                     = "id"
-	ProjectColumnCreationDate           = "creation_date"
-	ProjectColumnChangeDate             = "change_date"
-	ProjectColumnSequence               = "sequence"
-	ProjectColumnState                  = "state"
-	ProjectColumnResourceOwner          = "resource_owner"
-	ProjectColumnInstanceID             = "instance_id"
-	ProjectColumnName                   = "name"
-	ProjectColumnProjectRoleAssertion   = "project_role_assertion"
-	ProjectColumnProjectRoleCheck       = "project_role_check"
-	ProjectColumnHasProjectCheck        = "has_project_check"
-	ProjectColumnPrivateLabelingSetting = "private_labeling_setting"
+	ProjectColumnID                        = "id"
+	ProjectColumnCreationDate              = "creation_date"
+	ProjectColumnChangeDate                = "change_date"
+	ProjectColumnSequence                  = "sequence"
+	ProjectColumnState                     = "state"
+	ProjectColumnResourceOwner             = "resource_owner"
+	ProjectColumnInstanceID                = "instance_id"
+	ProjectColumnName                      = "name"
+	ProjectColumnProjectRoleAssertion      = "project_role_assertion"
+	ProjectColumnProjectRoleCheck          = "project_role_check"
+	ProjectColumnHasProjectCheck           = "has_project_check"
+	ProjectColumnPrivateLabelingSetting    = "private_labeling_setting"
+	ProjectColumnAuthorizationDetailsTypes = "authorization_details_types"
 )
 
 type projectProjection struct{}
@@ -55,6 +57,7 @@ func (*projectProjection) Init() *old_handler.Check {
 			handler.NewColumn(ProjectColumnProjectRoleCheck, handler.ColumnTypeBool),
 			handler.NewColumn(ProjectColumnHasProjectCheck, handler.ColumnTypeBool),
 			handler.NewColumn(ProjectColumnPrivateLabelingSetting, handler.ColumnTypeEnum),
+			handler.NewColumn(ProjectColumnAuthorizationDetailsTypes, handler.ColumnTypeTextArray, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(ProjectColumnInstanceID, ProjectColumnID),
 			handler.WithIndex(handler.NewIndex("resource_owner", []string{ProjectColumnResourceOwner})),
@@ -129,6 +132,7 @@ func (p *projectProjection) reduceProjectAdded(event eventstore.Event) (*handler
 			handler.NewCol(ProjectColumnProjectRoleCheck, e.ProjectRoleCheck),
 			handler.NewCol(ProjectColumnHasProjectCheck, e.HasProjectCheck),
 			handler.NewCol(ProjectColumnPrivateLabelingSetting, e.PrivateLabelingSetting),
+			handler.NewCol(ProjectColumnAuthorizationDetailsTypes, database.TextArray[string](e.AuthorizationDetailsTypes)),
 			handler.NewCol(ProjectColumnState, domain.ProjectStateActive),
 		},
 	), nil
@@ -139,11 +143,11 @@ func (p *projectProjection) reduceProjectChanged(event eventstore.Event) (*handl
 	if !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-s00Fs", "reduce.wrong.event.type %s", project.ProjectChangedType)
 	}
-	if e.Name == nil && e.HasProjectCheck == nil && e.ProjectRoleAssertion == nil && e.ProjectRoleCheck == nil && e.PrivateLabelingSetting == nil {
+	if e.Name == nil && e.HasProjectCheck == nil && e.ProjectRoleAssertion == nil && e.ProjectRoleCheck == nil && e.PrivateLabelingSetting == nil && e.AuthorizationDetailsTypes == nil {
 		return handler.NewNoOpStatement(e), nil
 	}
 
-	columns := make([]handler.Column, 0, 7)
+	columns := make([]handler.Column, 0, 8)
 	columns = append(columns, handler.NewCol(ProjectColumnChangeDate, e.CreationDate()),
 		handler.NewCol(ProjectColumnSequence, e.Sequence()))
 	if e.Name != nil {
@@ -161,6 +165,9 @@ func (p *projectProjection) reduceProjectChanged(event eventstore.Event) (*handl
 	if e.PrivateLabelingSetting != nil {
 		columns = append(columns, handler.NewCol(ProjectColumnPrivateLabelingSetting, *e.PrivateLabelingSetting))
 	}
+	if e.AuthorizationDetailsTypes != nil {
+		columns = append(columns, handler.NewCol(ProjectColumnAuthorizationDetailsTypes, database.TextArray[string](*e.AuthorizationDetailsTypes)))
+	}
 	return handler.NewUpdateStatement(
 		e,
 		columns,
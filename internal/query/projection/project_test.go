@@ -3,6 +3,7 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -193,7 +194,7 @@ func TestProjectProjection_reduces(t *testing.T) {
 					testEvent(
 						project.ProjectAddedType,
 						project.AggregateType,
-						[]byte(`{"name": "name", "projectRoleAssertion": true, "projectRoleCheck": true, "hasProjectCheck": true, "privateLabelingSetting": 1}`),
+						[]byte(`{"name": "name", "projectRoleAssertion": true, "projectRoleCheck": true, "hasProjectCheck": true, "privateLabelingSetting": 1, "authorizationDetailsTypes": ["payment_initiation"]}`),
 					), project.ProjectAddedEventMapper),
 			},
 			reduce: (&projectProjection{}).reduceProjectAdded,
@@ -203,7 +204,7 @@ func TestProjectProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.projects4 (id, creation_date, change_date, resource_owner, instance_id, sequence, name, project_role_assertion, project_role_check, has_project_check, private_labeling_setting, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedStmt: "INSERT INTO projections.projects4 (id, creation_date, change_date, resource_owner, instance_id, sequence, name, project_role_assertion, project_role_check, has_project_check, private_labeling_setting, authorization_details_types, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								anyArg{},
@@ -216,6 +217,7 @@ func TestProjectProjection_reduces(t *testing.T) {
 								true,
 								true,
 								domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+								database.TextArray[string]{"payment_initiation"},
 								domain.ProjectStateActive,
 							},
 						},
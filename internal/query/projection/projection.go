@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/migration"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
@@ -78,6 +79,7 @@ var (
 	InstanceFeatureProjection           *handler.Handler
 	TargetProjection                    *handler.Handler
 	ExecutionProjection                 *handler.Handler
+	AppSCIMProvisioningProjection       *handler.Handler
 	UserSchemaProjection                *handler.Handler
 	WebKeyProjection                    *handler.Handler
 	DebugEventsProjection               *handler.Handler
@@ -168,6 +170,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	InstanceFeatureProjection = newInstanceFeatureProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["instance_features"]))
 	TargetProjection = newTargetProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["targets"]))
 	ExecutionProjection = newExecutionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["executions"]))
+	AppSCIMProvisioningProjection = newAppSCIMProvisioningProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["apps_scim_provisioning"]))
 	UserSchemaProjection = newUserSchemaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_schemas"]))
 	WebKeyProjection = newWebKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["web_keys"]))
 	DebugEventsProjection = newDebugEventsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_events"]))
@@ -210,6 +213,24 @@ func ProjectInstance(ctx context.Context) error {
 	return nil
 }
 
+// Rebuild rebuilds the projection with the given name into shadow tables
+// and replaces the live tables as soon as the shadow caught up.
+func Rebuild(ctx context.Context, name string) error {
+	for _, projection := range projections {
+		if projection.String() != name {
+			continue
+		}
+		rebuilder, ok := projection.(interface {
+			Rebuild(ctx context.Context) error
+		})
+		if !ok {
+			return zerrors.ThrowPreconditionFailedf(nil, "PROJE-Rq4bn", "projection %s cannot be rebuilt", name)
+		}
+		return rebuilder.Rebuild(ctx)
+	}
+	return zerrors.ThrowNotFoundf(nil, "PROJE-Wj8xo", "projection %s not found", name)
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) handler.Config {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -299,6 +320,7 @@ func newProjectionsList() {
 		InstanceFeatureProjection,
 		TargetProjection,
 		ExecutionProjection,
+		AppSCIMProvisioningProjection,
 		UserSchemaProjection,
 		WebKeyProjection,
 		DebugEventsProjection,
@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -11,7 +12,7 @@ import (
 )
 
 const (
-	TargetTable               = "projections.targets2"
+	TargetTable               = "projections.targets3"
 	TargetIDCol               = "id"
 	TargetCreationDateCol     = "creation_date"
 	TargetChangeDateCol       = "change_date"
@@ -24,6 +25,9 @@ const (
 	TargetTimeoutCol          = "timeout"
 	TargetInterruptOnErrorCol = "interrupt_on_error"
 	TargetSigningKey          = "signing_key"
+	TargetMaxAttemptsCol      = "max_attempts"
+	TargetInitialBackoffCol   = "initial_backoff"
+	TargetMaxBackoffCol       = "max_backoff"
 )
 
 type targetProjection struct{}
@@ -51,6 +55,9 @@ func (*targetProjection) Init() *old_handler.Check {
 			handler.NewColumn(TargetTimeoutCol, handler.ColumnTypeInt64),
 			handler.NewColumn(TargetInterruptOnErrorCol, handler.ColumnTypeBool),
 			handler.NewColumn(TargetSigningKey, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(TargetMaxAttemptsCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(TargetInitialBackoffCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(TargetMaxBackoffCol, handler.ColumnTypeInt64, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(TargetInstanceIDCol, TargetIDCol),
 		),
@@ -95,7 +102,7 @@ func (p *targetProjection) reduceTargetAdded(event eventstore.Event) (*handler.S
 	}
 	return handler.NewCreateStatement(
 		e,
-		[]handler.Column{
+		append([]handler.Column{
 			handler.NewCol(TargetInstanceIDCol, e.Aggregate().InstanceID),
 			handler.NewCol(TargetResourceOwnerCol, e.Aggregate().ResourceOwner),
 			handler.NewCol(TargetIDCol, e.Aggregate().ID),
@@ -108,7 +115,7 @@ func (p *targetProjection) reduceTargetAdded(event eventstore.Event) (*handler.S
 			handler.NewCol(TargetTimeoutCol, e.Timeout),
 			handler.NewCol(TargetInterruptOnErrorCol, e.InterruptOnError),
 			handler.NewCol(TargetSigningKey, e.SigningKey),
-		},
+		}, retryPolicyCols(e.RetryPolicy)...),
 	), nil
 }
 
@@ -140,6 +147,9 @@ func (p *targetProjection) reduceTargetChanged(event eventstore.Event) (*handler
 	if e.SigningKey != nil {
 		values = append(values, handler.NewCol(TargetSigningKey, e.SigningKey))
 	}
+	if e.RetryPolicy != nil {
+		values = append(values, retryPolicyCols(e.RetryPolicy)...)
+	}
 	return handler.NewUpdateStatement(
 		e,
 		values,
@@ -163,3 +173,14 @@ func (p *targetProjection) reduceTargetRemoved(event eventstore.Event) (*handler
 		},
 	), nil
 }
+
+func retryPolicyCols(policy *domain.TargetRetryPolicy) []handler.Column {
+	if policy == nil {
+		policy = new(domain.TargetRetryPolicy)
+	}
+	return []handler.Column{
+		handler.NewCol(TargetMaxAttemptsCol, policy.MaxAttempts),
+		handler.NewCol(TargetInitialBackoffCol, policy.InitialBackoff),
+		handler.NewCol(TargetMaxBackoffCol, policy.MaxBackoff),
+	}
+}
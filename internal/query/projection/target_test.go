@@ -29,7 +29,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 					testEvent(
 						target.AddedEventType,
 						target.AggregateType,
-						[]byte(`{"name": "name", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true, "signingKey": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }}`),
+						[]byte(`{"name": "name", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true, "signingKey": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }, "retryPolicy": {"maxAttempts": 5, "initialBackoff": 1000000000, "maxBackoff": 60000000000}}`),
 					),
 					eventstore.GenericEventMapper[target.AddedEvent],
 				),
@@ -41,7 +41,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.targets2 (instance_id, resource_owner, id, creation_date, change_date, sequence, name, endpoint, target_type, timeout, interrupt_on_error, signing_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedStmt: "INSERT INTO projections.targets3 (instance_id, resource_owner, id, creation_date, change_date, sequence, name, endpoint, target_type, timeout, interrupt_on_error, signing_key, max_attempts, initial_backoff, max_backoff) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -55,6 +55,9 @@ func TestTargetProjection_reduces(t *testing.T) {
 								3 * time.Second,
 								true,
 								anyArg{},
+								uint8(5),
+								time.Second,
+								time.Minute,
 							},
 						},
 					},
@@ -80,7 +83,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.targets2 SET (change_date, sequence, resource_owner, name, target_type, endpoint, timeout, interrupt_on_error, signing_key) = ($1, $2, $3, $4, $5, $6, $7, $8, $9) WHERE (instance_id = $10) AND (id = $11)",
+							expectedStmt: "UPDATE projections.targets3 SET (change_date, sequence, resource_owner, name, target_type, endpoint, timeout, interrupt_on_error, signing_key) = ($1, $2, $3, $4, $5, $6, $7, $8, $9) WHERE (instance_id = $10) AND (id = $11)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -99,6 +102,41 @@ func TestTargetProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceTargetChanged retry policy",
+			args: args{
+				event: getEvent(
+					testEvent(
+						target.ChangedEventType,
+						target.AggregateType,
+						[]byte(`{"retryPolicy": {"maxAttempts": 10}}`),
+					),
+					eventstore.GenericEventMapper[target.ChangedEvent],
+				),
+			},
+			reduce: (&targetProjection{}).reduceTargetChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("target"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.targets3 SET (change_date, sequence, resource_owner, max_attempts, initial_backoff, max_backoff) = ($1, $2, $3, $4, $5, $6) WHERE (instance_id = $7) AND (id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								uint8(10),
+								time.Duration(0),
+								time.Duration(0),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceTargetRemoved",
 			args: args{
@@ -118,7 +156,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.targets2 WHERE (instance_id = $1) AND (id = $2)",
+							expectedStmt: "DELETE FROM projections.targets3 WHERE (instance_id = $1) AND (id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -147,7 +185,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.targets2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.targets3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
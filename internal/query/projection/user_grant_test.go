@@ -76,6 +76,7 @@ func TestUserGrantProjection_reduces(t *testing.T) {
 						false,
 						false,
 						domain.PrivateLabelingSettingUnspecified,
+						nil,
 					),
 				}),
 		}).reduceAdded,
@@ -143,6 +144,7 @@ func TestUserGrantProjection_reduces(t *testing.T) {
 							false,
 							false,
 							domain.PrivateLabelingSettingUnspecified,
+							nil,
 						),
 					}),
 			}).reduceAdded,
@@ -208,6 +210,7 @@ func TestUserGrantProjection_reduces(t *testing.T) {
 							&project.NewAggregate("project-id", "org2").Aggregate,
 							"project", true, true, true,
 							domain.PrivateLabelingSettingUnspecified,
+							nil,
 						),
 						project.NewGrantAddedEvent(context.Background(),
 							&project.NewAggregate("project-id", "org2").Aggregate,
@@ -810,6 +813,7 @@ func Test_getResourceOwners(t *testing.T) {
 								false,
 								false,
 								domain.PrivateLabelingSettingUnspecified,
+								nil,
 							),
 						),
 					),
@@ -611,6 +611,9 @@ type BytesComparison int
 const (
 	BytesEquals BytesComparison = iota
 	BytesNotEquals
+	BytesStartsWith
+	BytesEndsWith
+	BytesContains
 	bytesCompareMax
 )
 
@@ -628,6 +631,17 @@ func NewBytesQuery(col Column, values []byte, comparison BytesComparison) (*Byte
 	if comparison < 0 || comparison >= bytesCompareMax {
 		return nil, ErrInvalidCompare
 	}
+	// handle the comparisons which use like and therefore need to escape potential wildcards in the value
+	switch comparison {
+	case BytesStartsWith,
+		BytesEndsWith,
+		BytesContains:
+		values = []byte(database.EscapeLikeWildcards(string(values)))
+	case BytesEquals,
+		BytesNotEquals,
+		bytesCompareMax:
+		// do nothing
+	}
 
 	return &BytesQuery{
 		Column:  col,
@@ -650,6 +664,12 @@ func (q *BytesQuery) comp() sq.Sqlizer {
 		return sq.Eq{q.Column.identifier(): q.Value}
 	case BytesNotEquals:
 		return sq.NotEq{q.Column.identifier(): q.Value}
+	case BytesStartsWith:
+		return sq.Like{q.Column.identifier(): []byte(string(q.Value) + "%")}
+	case BytesEndsWith:
+		return sq.Like{q.Column.identifier(): []byte("%" + string(q.Value))}
+	case BytesContains:
+		return sq.Like{q.Column.identifier(): []byte("%" + string(q.Value) + "%")}
 	case bytesCompareMax:
 		return nil
 	}
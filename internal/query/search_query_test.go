@@ -2290,6 +2290,50 @@ func TestBytesQuery_comp(t *testing.T) {
 				query: sq.NotEq{"test_table.test_col": []byte("foo")},
 			},
 		},
+		{
+			name: "starts with",
+			fields: fields{
+				Column:  testCol,
+				Value:   []byte("foo"),
+				Compare: BytesStartsWith,
+			},
+			want: want{
+				query: sq.Like{"test_table.test_col": []byte("foo%")},
+			},
+		},
+		{
+			name: "ends with",
+			fields: fields{
+				Column:  testCol,
+				Value:   []byte("foo"),
+				Compare: BytesEndsWith,
+			},
+			want: want{
+				query: sq.Like{"test_table.test_col": []byte("%foo")},
+			},
+		},
+		{
+			name: "contains",
+			fields: fields{
+				Column:  testCol,
+				Value:   []byte("foo"),
+				Compare: BytesContains,
+			},
+			want: want{
+				query: sq.Like{"test_table.test_col": []byte("%foo%")},
+			},
+		},
+		{
+			name: "contains escaped wildcards",
+			fields: fields{
+				Column:  testCol,
+				Value:   []byte("f%o_o"),
+				Compare: BytesContains,
+			},
+			want: want{
+				query: sq.Like{"test_table.test_col": []byte(`%f\%o\_o%`)},
+			},
+		},
 		{
 			name: "unknown comparison",
 			fields: fields{
@@ -64,6 +64,18 @@ var (
 		name:  projection.TargetSigningKey,
 		table: targetTable,
 	}
+	TargetColumnMaxAttempts = Column{
+		name:  projection.TargetMaxAttemptsCol,
+		table: targetTable,
+	}
+	TargetColumnInitialBackoff = Column{
+		name:  projection.TargetInitialBackoffCol,
+		table: targetTable,
+	}
+	TargetColumnMaxBackoff = Column{
+		name:  projection.TargetMaxBackoffCol,
+		table: targetTable,
+	}
 )
 
 type Targets struct {
@@ -83,6 +95,7 @@ type Target struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	RetryPolicy      domain.TargetRetryPolicy
 	signingKey       *crypto.CryptoValue
 	SigningKey       string
 }
@@ -165,6 +178,9 @@ func prepareTargetsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, fu
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
 			TargetColumnSigningKey.identifier(),
+			TargetColumnMaxAttempts.identifier(),
+			TargetColumnInitialBackoff.identifier(),
+			TargetColumnMaxBackoff.identifier(),
 			countColumn.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
@@ -184,6 +200,9 @@ func prepareTargetsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, fu
 					&target.Endpoint,
 					&target.InterruptOnError,
 					&target.signingKey,
+					&target.RetryPolicy.MaxAttempts,
+					&target.RetryPolicy.InitialBackoff,
+					&target.RetryPolicy.MaxBackoff,
 					&count,
 				)
 				if err != nil {
@@ -217,6 +236,9 @@ func prepareTargetQuery(context.Context, prepareDatabase) (sq.SelectBuilder, fun
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
 			TargetColumnSigningKey.identifier(),
+			TargetColumnMaxAttempts.identifier(),
+			TargetColumnInitialBackoff.identifier(),
+			TargetColumnMaxBackoff.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Target, error) {
@@ -232,6 +254,9 @@ func prepareTargetQuery(context.Context, prepareDatabase) (sq.SelectBuilder, fun
 				&target.Endpoint,
 				&target.InterruptOnError,
 				&target.signingKey,
+				&target.RetryPolicy.MaxAttempts,
+				&target.RetryPolicy.InitialBackoff,
+				&target.RetryPolicy.MaxBackoff,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -15,18 +15,21 @@ import (
 )
 
 var (
-	prepareTargetsStmt = `SELECT projections.targets2.id,` +
-		` projections.targets2.creation_date,` +
-		` projections.targets2.change_date,` +
-		` projections.targets2.resource_owner,` +
-		` projections.targets2.name,` +
-		` projections.targets2.target_type,` +
-		` projections.targets2.timeout,` +
-		` projections.targets2.endpoint,` +
-		` projections.targets2.interrupt_on_error,` +
-		` projections.targets2.signing_key,` +
+	prepareTargetsStmt = `SELECT projections.targets3.id,` +
+		` projections.targets3.creation_date,` +
+		` projections.targets3.change_date,` +
+		` projections.targets3.resource_owner,` +
+		` projections.targets3.name,` +
+		` projections.targets3.target_type,` +
+		` projections.targets3.timeout,` +
+		` projections.targets3.endpoint,` +
+		` projections.targets3.interrupt_on_error,` +
+		` projections.targets3.signing_key,` +
+		` projections.targets3.max_attempts,` +
+		` projections.targets3.initial_backoff,` +
+		` projections.targets3.max_backoff,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.targets2`
+		` FROM projections.targets3`
 	prepareTargetsCols = []string{
 		"id",
 		"creation_date",
@@ -38,20 +41,26 @@ var (
 		"endpoint",
 		"interrupt_on_error",
 		"signing_key",
+		"max_attempts",
+		"initial_backoff",
+		"max_backoff",
 		"count",
 	}
 
-	prepareTargetStmt = `SELECT projections.targets2.id,` +
-		` projections.targets2.creation_date,` +
-		` projections.targets2.change_date,` +
-		` projections.targets2.resource_owner,` +
-		` projections.targets2.name,` +
-		` projections.targets2.target_type,` +
-		` projections.targets2.timeout,` +
-		` projections.targets2.endpoint,` +
-		` projections.targets2.interrupt_on_error,` +
-		` projections.targets2.signing_key` +
-		` FROM projections.targets2`
+	prepareTargetStmt = `SELECT projections.targets3.id,` +
+		` projections.targets3.creation_date,` +
+		` projections.targets3.change_date,` +
+		` projections.targets3.resource_owner,` +
+		` projections.targets3.name,` +
+		` projections.targets3.target_type,` +
+		` projections.targets3.timeout,` +
+		` projections.targets3.endpoint,` +
+		` projections.targets3.interrupt_on_error,` +
+		` projections.targets3.signing_key,` +
+		` projections.targets3.max_attempts,` +
+		` projections.targets3.initial_backoff,` +
+		` projections.targets3.max_backoff` +
+		` FROM projections.targets3`
 	prepareTargetCols = []string{
 		"id",
 		"creation_date",
@@ -63,6 +72,9 @@ var (
 		"endpoint",
 		"interrupt_on_error",
 		"signing_key",
+		"max_attempts",
+		"initial_backoff",
+		"max_backoff",
 	}
 )
 
@@ -113,6 +125,9 @@ func Test_TargetPrepares(t *testing.T) {
 								KeyID:      "encKey",
 								Crypted:    []byte("crypted"),
 							},
+							uint8(5),
+							time.Second,
+							time.Minute,
 						},
 					},
 				),
@@ -140,6 +155,11 @@ func Test_TargetPrepares(t *testing.T) {
 							KeyID:      "encKey",
 							Crypted:    []byte("crypted"),
 						},
+						RetryPolicy: domain.TargetRetryPolicy{
+							MaxAttempts:    5,
+							InitialBackoff: time.Second,
+							MaxBackoff:     time.Minute,
+						},
 					},
 				},
 			},
@@ -168,6 +188,9 @@ func Test_TargetPrepares(t *testing.T) {
 								KeyID:      "encKey",
 								Crypted:    []byte("crypted"),
 							},
+							uint8(5),
+							time.Second,
+							time.Minute,
 						},
 						{
 							"id-2",
@@ -185,6 +208,9 @@ func Test_TargetPrepares(t *testing.T) {
 								KeyID:      "encKey",
 								Crypted:    []byte("crypted"),
 							},
+							uint8(5),
+							time.Second,
+							time.Minute,
 						},
 						{
 							"id-3",
@@ -202,6 +228,9 @@ func Test_TargetPrepares(t *testing.T) {
 								KeyID:      "encKey",
 								Crypted:    []byte("crypted"),
 							},
+							uint8(5),
+							time.Second,
+							time.Minute,
 						},
 					},
 				),
@@ -229,6 +258,11 @@ func Test_TargetPrepares(t *testing.T) {
 							KeyID:      "encKey",
 							Crypted:    []byte("crypted"),
 						},
+						RetryPolicy: domain.TargetRetryPolicy{
+							MaxAttempts:    5,
+							InitialBackoff: time.Second,
+							MaxBackoff:     time.Minute,
+						},
 					},
 					{
 						ObjectDetails: domain.ObjectDetails{
@@ -248,6 +282,11 @@ func Test_TargetPrepares(t *testing.T) {
 							KeyID:      "encKey",
 							Crypted:    []byte("crypted"),
 						},
+						RetryPolicy: domain.TargetRetryPolicy{
+							MaxAttempts:    5,
+							InitialBackoff: time.Second,
+							MaxBackoff:     time.Minute,
+						},
 					},
 					{
 						ObjectDetails: domain.ObjectDetails{
@@ -267,6 +306,11 @@ func Test_TargetPrepares(t *testing.T) {
 							KeyID:      "encKey",
 							Crypted:    []byte("crypted"),
 						},
+						RetryPolicy: domain.TargetRetryPolicy{
+							MaxAttempts:    5,
+							InitialBackoff: time.Second,
+							MaxBackoff:     time.Minute,
+						},
 					},
 				},
 			},
@@ -329,6 +373,9 @@ func Test_TargetPrepares(t *testing.T) {
 							KeyID:      "encKey",
 							Crypted:    []byte("crypted"),
 						},
+						uint8(5),
+						time.Second,
+						time.Minute,
 					},
 				),
 			},
@@ -350,6 +397,11 @@ func Test_TargetPrepares(t *testing.T) {
 					KeyID:      "encKey",
 					Crypted:    []byte("crypted"),
 				},
+				RetryPolicy: domain.TargetRetryPolicy{
+					MaxAttempts:    5,
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Minute,
+				},
 			},
 		},
 		{
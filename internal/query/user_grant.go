@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -13,8 +14,10 @@ import (
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -560,3 +563,161 @@ func prepareUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			}, nil
 		}
 }
+
+// ActiveUserGrantsOfUser returns the active user grants of the user on the projects.
+// All active grants of the user are cached and filtered by the projects,
+// because they are asserted for every token of the user.
+func (q *Queries) ActiveUserGrantsOfUser(ctx context.Context, userID string, projectIDs []string, shouldTriggerBulk bool) (grants *UserGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	cached, ok := q.caches.userGrants.Get(ctx, userGrantsIndexByUserID, userGrantsCacheKey(instanceID, userID))
+	if !ok {
+		cached, err = q.activeUserGrantsOfUser(ctx, instanceID, userID, shouldTriggerBulk)
+		if err != nil {
+			return nil, err
+		}
+		q.caches.userGrants.Set(ctx, cached)
+	}
+	grants = &UserGrants{UserGrants: make([]*UserGrant, 0, len(cached.Grants))}
+	for _, grant := range cached.Grants {
+		if slices.Contains(projectIDs, grant.ProjectID) {
+			grants.UserGrants = append(grants.UserGrants, grant)
+		}
+	}
+	grants.Count = uint64(len(grants.UserGrants))
+	return grants, nil
+}
+
+func (q *Queries) activeUserGrantsOfUser(ctx context.Context, instanceID, userID string, shouldTriggerBulk bool) (*cachedUserGrants, error) {
+	userIDQuery, err := NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	activeQuery, err := NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{userIDQuery, activeQuery}}, shouldTriggerBulk)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedUserGrants{
+		InstanceID: instanceID,
+		UserID:     userID,
+		Grants:     grants.UserGrants,
+	}, nil
+}
+
+type userGrantsIndex int
+
+//go:generate enumer -type userGrantsIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	userGrantsIndexUnspecified userGrantsIndex = iota //
+	userGrantsIndexByUserID
+	userGrantsIndexByUserAggregateID
+	userGrantsIndexByUserGrantID
+	userGrantsIndexByProjectID
+	userGrantsIndexByOrgID
+)
+
+// cachedUserGrants is the cache entry of all active user grants of a user.
+type cachedUserGrants struct {
+	InstanceID string       `json:"instance_id"`
+	UserID     string       `json:"user_id"`
+	Grants     []*UserGrant `json:"grants,omitempty"`
+}
+
+func userGrantsCacheKey(instanceID, userID string) string {
+	return instanceID + "/" + userID
+}
+
+// Keys implements [cache.Entry]
+func (g *cachedUserGrants) Keys(index userGrantsIndex) []string {
+	switch index {
+	case userGrantsIndexByUserID:
+		return []string{userGrantsCacheKey(g.InstanceID, g.UserID)}
+	case userGrantsIndexByUserAggregateID:
+		return []string{g.UserID}
+	case userGrantsIndexByUserGrantID:
+		ids := make([]string, len(g.Grants))
+		for i, grant := range g.Grants {
+			ids[i] = grant.ID
+		}
+		return ids
+	case userGrantsIndexByProjectID:
+		ids := make([]string, len(g.Grants))
+		for i, grant := range g.Grants {
+			ids[i] = grant.ProjectID
+		}
+		return ids
+	case userGrantsIndexByOrgID:
+		ids := make([]string, 0, len(g.Grants)*3)
+		for _, grant := range g.Grants {
+			ids = append(ids, grant.ResourceOwner, grant.UserResourceOwner)
+			if grant.GrantedOrgID != "" {
+				ids = append(ids, grant.GrantedOrgID)
+			}
+		}
+		return ids
+	case userGrantsIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerUserGrantsInvalidation(client *database.DB) {
+	invalidate := cacheInvalidationFunc(c.userGrants, userGrantsIndexByUserAggregateID, getAggregateID)
+	projection.UserProjection.RegisterCacheInvalidation(invalidate)
+	projection.LoginNameProjection.RegisterCacheInvalidation(invalidate)
+
+	invalidate = cacheInvalidationFunc(c.userGrants, userGrantsIndexByOrgID, getAggregateID)
+	projection.OrgProjection.RegisterCacheInvalidation(invalidate)
+
+	invalidate = cacheInvalidationFunc(c.userGrants, userGrantsIndexByProjectID, getAggregateID)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+
+	projection.UserGrantProjection.RegisterCacheInvalidation(c.invalidateUserGrantsOfUserGrants(client))
+}
+
+// invalidateUserGrantsOfUserGrants returns the invalidation of the user grants affected by the user grant projection.
+// Like for the user infos, added grants are matched to the cached grants of the user
+// by looking up the users of the grants in the projection.
+func (c *Caches) invalidateUserGrantsOfUserGrants(client *database.DB) func(context.Context, []*eventstore.Aggregate) {
+	return func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		grantIDs := make(map[string][]string)
+		var otherIDs []string
+		for _, aggregate := range aggregates {
+			if aggregate.Type == usergrant.AggregateType {
+				grantIDs[aggregate.InstanceID] = append(grantIDs[aggregate.InstanceID], aggregate.ID)
+				continue
+			}
+			otherIDs = append(otherIDs, aggregate.ID)
+		}
+		if len(otherIDs) > 0 {
+			for _, index := range []userGrantsIndex{userGrantsIndexByUserAggregateID, userGrantsIndexByProjectID, userGrantsIndexByOrgID} {
+				err := c.userGrants.Invalidate(ctx, index, otherIDs...)
+				logging.OnError(err).Warn("cache invalidation failed")
+			}
+		}
+		for instanceID, ids := range grantIDs {
+			err := c.userGrants.Invalidate(ctx, userGrantsIndexByUserGrantID, ids...)
+			logging.OnError(err).Warn("cache invalidation failed")
+
+			userIDs, err := userIDsOfUserGrants(ctx, client, instanceID, ids)
+			if err != nil {
+				logging.WithError(err).Warn("cache invalidation failed")
+				continue
+			}
+			if len(userIDs) > 0 {
+				keys := make([]string, len(userIDs))
+				for i, userID := range userIDs {
+					keys[i] = userGrantsCacheKey(instanceID, userID)
+				}
+				err = c.userGrants.Invalidate(ctx, userGrantsIndexByUserID, keys...)
+				logging.OnError(err).Warn("cache invalidation failed")
+			}
+		}
+	}
+}
@@ -8,8 +8,16 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -1012,3 +1020,74 @@ func Test_UserGrantPrepares(t *testing.T) {
 		})
 	}
 }
+
+func TestQueries_ActiveUserGrantsOfUser_cached(t *testing.T) {
+	ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	q := &Queries{
+		caches: &Caches{
+			userGrants: gomap.NewCache[userGrantsIndex, string, *cachedUserGrants](ctx, userGrantsIndexValues(), cache.Config{}),
+		},
+	}
+	q.caches.userGrants.Set(ctx, &cachedUserGrants{
+		InstanceID: "instanceID",
+		UserID:     "user1",
+		Grants: []*UserGrant{
+			{ID: "grant1", UserID: "user1", ProjectID: "project1"},
+			{ID: "grant2", UserID: "user1", ProjectID: "project2"},
+		},
+	})
+
+	grants, err := q.ActiveUserGrantsOfUser(ctx, "user1", []string{"project2", "project3"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), grants.Count)
+	assert.Equal(t, []*UserGrant{{ID: "grant2", UserID: "user1", ProjectID: "project2"}}, grants.UserGrants)
+}
+
+func Test_cachedUserGrants_Keys(t *testing.T) {
+	entry := &cachedUserGrants{
+		InstanceID: "instanceID",
+		UserID:     "user1",
+		Grants: []*UserGrant{
+			{ID: "grant1", ProjectID: "project1", ResourceOwner: "org1", UserResourceOwner: "org2"},
+			{ID: "grant2", ProjectID: "project2", ResourceOwner: "org1", UserResourceOwner: "org2", GrantedOrgID: "org3"},
+		},
+	}
+	assert.Equal(t, []string{"instanceID/user1"}, entry.Keys(userGrantsIndexByUserID))
+	assert.Equal(t, []string{"user1"}, entry.Keys(userGrantsIndexByUserAggregateID))
+	assert.Equal(t, []string{"grant1", "grant2"}, entry.Keys(userGrantsIndexByUserGrantID))
+	assert.Equal(t, []string{"project1", "project2"}, entry.Keys(userGrantsIndexByProjectID))
+	assert.Equal(t, []string{"org1", "org2", "org1", "org2", "org3"}, entry.Keys(userGrantsIndexByOrgID))
+	assert.Nil(t, entry.Keys(userGrantsIndexUnspecified))
+}
+
+func TestCaches_invalidateUserGrantsOfUserGrants(t *testing.T) {
+	ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	entry := func(userID string, grantIDs ...string) *cachedUserGrants {
+		grants := make([]*UserGrant, len(grantIDs))
+		for i, id := range grantIDs {
+			grants[i] = &UserGrant{ID: id, UserID: userID}
+		}
+		return &cachedUserGrants{InstanceID: "instanceID", UserID: userID, Grants: grants}
+	}
+
+	// grant2 was just added to user2, its user is resolved from the projection.
+	execMock(t, mockQueries(regexp.QuoteMeta(userIDsOfUserGrantsQuery), []string{"user_id"}, [][]driver.Value{{"user2"}}, "instanceID", database.TextArray[string]{"grant1", "grant2"}), func(db *sql.DB) {
+		c := &Caches{
+			userGrants: gomap.NewCache[userGrantsIndex, string, *cachedUserGrants](ctx, userGrantsIndexValues(), cache.Config{}),
+		}
+		for _, e := range []*cachedUserGrants{entry("user1", "grant1"), entry("user2"), entry("user3", "grant3")} {
+			c.userGrants.Set(ctx, e)
+		}
+		c.invalidateUserGrantsOfUserGrants(&database.DB{DB: db})(ctx, []*eventstore.Aggregate{
+			{ID: "grant1", Type: usergrant.AggregateType, InstanceID: "instanceID"},
+			{ID: "grant2", Type: usergrant.AggregateType, InstanceID: "instanceID"},
+		})
+
+		_, ok := c.userGrants.Get(ctx, userGrantsIndexByUserID, "instanceID/user1")
+		assert.False(t, ok)
+		_, ok = c.userGrants.Get(ctx, userGrantsIndexByUserID, "instanceID/user2")
+		assert.False(t, ok)
+		_, ok = c.userGrants.Get(ctx, userGrantsIndexByUserID, "instanceID/user3")
+		assert.True(t, ok, "grants of other users must stay cached")
+	})
+}
@@ -0,0 +1,4 @@
+select distinct user_id
+from projections.user_grants5
+where instance_id = $1
+	and id = any($2);
@@ -5,14 +5,19 @@ import (
 	"database/sql"
 	_ "embed"
 	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -65,13 +70,23 @@ func (q *Queries) GetOIDCUserInfo(ctx context.Context, userID string, roleAudien
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	entry := &cachedOIDCUserInfo{
+		InstanceID:   authz.GetInstance(ctx).InstanceID(),
+		UserID:       userID,
+		RoleAudience: roleAudience,
+		RoleOrgIDs:   roleOrgIDs,
+	}
+	if cached, ok := q.caches.oidcUserInfo.Get(ctx, oidcUserInfoIndexByRequest, entry.requestKey()); ok {
+		return cached.UserInfo, nil
+	}
+
 	if len(roleOrgIDs) > 0 {
 		userInfo, err = database.QueryJSONObject[OIDCUserInfo](ctx, q.client, oidcUserInfoWithRoleOrgIDsQuery,
-			userID, authz.GetInstance(ctx).InstanceID(), database.TextArray[string](roleAudience), database.TextArray[string](roleOrgIDs),
+			userID, entry.InstanceID, database.TextArray[string](roleAudience), database.TextArray[string](roleOrgIDs),
 		)
 	} else {
 		userInfo, err = database.QueryJSONObject[OIDCUserInfo](ctx, q.client, oidcUserInfoQuery,
-			userID, authz.GetInstance(ctx).InstanceID(), database.TextArray[string](roleAudience),
+			userID, entry.InstanceID, database.TextArray[string](roleAudience),
 		)
 	}
 	if errors.Is(err, sql.ErrNoRows) {
@@ -84,6 +99,8 @@ func (q *Queries) GetOIDCUserInfo(ctx context.Context, userID string, roleAudien
 		return nil, zerrors.ThrowNotFound(nil, "QUERY-ahs4S", "Errors.User.NotFound")
 	}
 
+	entry.UserInfo = userInfo
+	q.caches.oidcUserInfo.Set(ctx, entry)
 	return userInfo, nil
 }
 
@@ -121,3 +138,148 @@ func (q *Queries) GetOIDCUserinfoClientByID(ctx context.Context, clientID string
 	}
 	return projectID, projectRoleAssertion, nil
 }
+
+type oidcUserInfoIndex int
+
+//go:generate enumer -type oidcUserInfoIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	oidcUserInfoIndexUnspecified oidcUserInfoIndex = iota //
+	oidcUserInfoIndexByRequest
+	oidcUserInfoIndexByUserID
+	oidcUserInfoIndexByOrgID
+	oidcUserInfoIndexByProjectID
+	oidcUserInfoIndexByInstanceID
+	oidcUserInfoIndexByUserGrantID
+)
+
+// cachedOIDCUserInfo is the cache entry of the user info including the user grants
+// for the requested role audience and organizations.
+type cachedOIDCUserInfo struct {
+	InstanceID   string        `json:"instance_id"`
+	UserID       string        `json:"user_id"`
+	RoleAudience []string      `json:"role_audience,omitempty"`
+	RoleOrgIDs   []string      `json:"role_org_ids,omitempty"`
+	UserInfo     *OIDCUserInfo `json:"user_info"`
+}
+
+// requestKey identifies the user info by the arguments of [Queries.GetOIDCUserInfo].
+// The order of the role audience and organizations does not affect the result,
+// so they are sorted.
+func (c *cachedOIDCUserInfo) requestKey() string {
+	roleAudience := slices.Sorted(slices.Values(c.RoleAudience))
+	roleOrgIDs := slices.Sorted(slices.Values(c.RoleOrgIDs))
+	return strings.Join([]string{
+		c.InstanceID,
+		c.UserID,
+		strings.Join(roleAudience, ","),
+		strings.Join(roleOrgIDs, ","),
+	}, "/")
+}
+
+// Keys implements [cache.Entry]
+func (c *cachedOIDCUserInfo) Keys(index oidcUserInfoIndex) []string {
+	switch index {
+	case oidcUserInfoIndexByRequest:
+		return []string{c.requestKey()}
+	case oidcUserInfoIndexByUserID:
+		return []string{c.UserID}
+	case oidcUserInfoIndexByOrgID:
+		orgIDs := make([]string, 0, len(c.UserInfo.UserGrants)+1)
+		if c.UserInfo.Org != nil {
+			orgIDs = append(orgIDs, c.UserInfo.Org.ID)
+		}
+		for _, grant := range c.UserInfo.UserGrants {
+			orgIDs = append(orgIDs, grant.ResourceOwner)
+		}
+		return orgIDs
+	case oidcUserInfoIndexByProjectID:
+		return c.RoleAudience
+	case oidcUserInfoIndexByInstanceID:
+		return []string{c.InstanceID}
+	case oidcUserInfoIndexByUserGrantID:
+		grantIDs := make([]string, len(c.UserInfo.UserGrants))
+		for i, grant := range c.UserInfo.UserGrants {
+			grantIDs[i] = grant.ID
+		}
+		return grantIDs
+	case oidcUserInfoIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerOIDCUserInfoInvalidation(client *database.DB) {
+	invalidate := cacheInvalidationFunc(c.oidcUserInfo, oidcUserInfoIndexByUserID, getAggregateID)
+	projection.UserProjection.RegisterCacheInvalidation(invalidate)
+	projection.UserMetadataProjection.RegisterCacheInvalidation(invalidate)
+	projection.LoginNameProjection.RegisterCacheInvalidation(invalidate)
+
+	invalidate = cacheInvalidationFunc(c.oidcUserInfo, oidcUserInfoIndexByOrgID, getAggregateID)
+	projection.OrgProjection.RegisterCacheInvalidation(invalidate)
+	// login names depend on the domains and policies of the organization.
+	projection.LoginNameProjection.RegisterCacheInvalidation(invalidate)
+
+	invalidate = cacheInvalidationFunc(c.oidcUserInfo, oidcUserInfoIndexByProjectID, getAggregateID)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+
+	projection.UserGrantProjection.RegisterCacheInvalidation(c.invalidateOIDCUserInfoOfUserGrants(client))
+	// the default login policy of the instance affects the login names.
+	projection.LoginNameProjection.RegisterCacheInvalidation(cacheInvalidationFunc(c.oidcUserInfo, oidcUserInfoIndexByInstanceID, getAggregateID))
+}
+
+//go:embed user_ids_of_user_grants.sql
+var userIDsOfUserGrantsQuery string
+
+// invalidateOIDCUserInfoOfUserGrants returns the invalidation of the user infos affected by the user grant projection.
+// Events of the user grant aggregate do not identify the user in the aggregate,
+// so the user infos are invalidated by the ids of the cached grants
+// and by the users of the grants, which are looked up in the projection.
+// This also covers added grants, which are not part of any cached user info yet.
+// Other aggregates (user, project, organization) are invalidated by their id.
+func (c *Caches) invalidateOIDCUserInfoOfUserGrants(client *database.DB) func(context.Context, []*eventstore.Aggregate) {
+	return func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		grantIDs := make(map[string][]string)
+		var otherIDs []string
+		for _, aggregate := range aggregates {
+			if aggregate.Type == usergrant.AggregateType {
+				grantIDs[aggregate.InstanceID] = append(grantIDs[aggregate.InstanceID], aggregate.ID)
+				continue
+			}
+			otherIDs = append(otherIDs, aggregate.ID)
+		}
+		if len(otherIDs) > 0 {
+			for _, index := range []oidcUserInfoIndex{oidcUserInfoIndexByUserID, oidcUserInfoIndexByProjectID, oidcUserInfoIndexByOrgID} {
+				err := c.oidcUserInfo.Invalidate(ctx, index, otherIDs...)
+				logging.OnError(err).Warn("cache invalidation failed")
+			}
+		}
+		for instanceID, ids := range grantIDs {
+			err := c.oidcUserInfo.Invalidate(ctx, oidcUserInfoIndexByUserGrantID, ids...)
+			logging.OnError(err).Warn("cache invalidation failed")
+
+			userIDs, err := userIDsOfUserGrants(ctx, client, instanceID, ids)
+			if err != nil {
+				logging.WithError(err).Warn("cache invalidation failed")
+				continue
+			}
+			if len(userIDs) > 0 {
+				err = c.oidcUserInfo.Invalidate(ctx, oidcUserInfoIndexByUserID, userIDs...)
+				logging.OnError(err).Warn("cache invalidation failed")
+			}
+		}
+	}
+}
+
+func userIDsOfUserGrants(ctx context.Context, client *database.DB, instanceID string, grantIDs []string) (userIDs []string, err error) {
+	err = client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var userID string
+			if err := rows.Scan(&userID); err != nil {
+				return err
+			}
+			userIDs = append(userIDs, userID)
+		}
+		return rows.Err()
+	}, userIDsOfUserGrantsQuery, instanceID, database.TextArray[string](grantIDs))
+	return userIDs, err
+}
@@ -14,8 +14,13 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -432,6 +437,9 @@ func TestQueries_GetOIDCUserInfo(t *testing.T) {
 						DB:       db,
 						Database: &prepareDB{},
 					},
+					caches: &Caches{
+						oidcUserInfo: noop.NewCache[oidcUserInfoIndex, string, *cachedOIDCUserInfo](),
+					},
 				}
 				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
 
@@ -489,3 +497,66 @@ func TestQueries_GetOIDCUserinfoClientByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_cachedOIDCUserInfo_Keys(t *testing.T) {
+	entry := &cachedOIDCUserInfo{
+		InstanceID:   "instanceID",
+		UserID:       "userID",
+		RoleAudience: []string{"project2", "project1"},
+		RoleOrgIDs:   []string{"org2", "org1"},
+		UserInfo: &OIDCUserInfo{
+			User: &User{ID: "userID"},
+			Org:  &UserInfoOrg{ID: "org1"},
+			UserGrants: []UserGrant{
+				{ID: "grant1", ResourceOwner: "org2", ProjectID: "project1"},
+			},
+		},
+	}
+	// the order of the arguments does not change the key
+	assert.Equal(t, []string{"instanceID/userID/project1,project2/org1,org2"}, entry.Keys(oidcUserInfoIndexByRequest))
+	assert.Equal(t, []string{"userID"}, entry.Keys(oidcUserInfoIndexByUserID))
+	assert.Equal(t, []string{"org1", "org2"}, entry.Keys(oidcUserInfoIndexByOrgID))
+	assert.Equal(t, []string{"project2", "project1"}, entry.Keys(oidcUserInfoIndexByProjectID))
+	assert.Equal(t, []string{"instanceID"}, entry.Keys(oidcUserInfoIndexByInstanceID))
+	assert.Equal(t, []string{"grant1"}, entry.Keys(oidcUserInfoIndexByUserGrantID))
+	assert.Nil(t, entry.Keys(oidcUserInfoIndexUnspecified))
+}
+
+func TestCaches_invalidateOIDCUserInfoOfUserGrants(t *testing.T) {
+	ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	entry := func(userID string, grantIDs ...string) *cachedOIDCUserInfo {
+		grants := make([]UserGrant, len(grantIDs))
+		for i, id := range grantIDs {
+			grants[i] = UserGrant{ID: id}
+		}
+		return &cachedOIDCUserInfo{
+			InstanceID: "instanceID",
+			UserID:     userID,
+			UserInfo: &OIDCUserInfo{
+				User:       &User{ID: userID},
+				UserGrants: grants,
+			},
+		}
+	}
+
+	// grant2 is not cached yet (e.g. it was just added), its user is resolved from the projection.
+	execMock(t, mockQueries(regexp.QuoteMeta(userIDsOfUserGrantsQuery), []string{"user_id"}, [][]driver.Value{{"user2"}}, "instanceID", database.TextArray[string]{"grant1", "grant2"}), func(db *sql.DB) {
+		c := &Caches{
+			oidcUserInfo: gomap.NewCache[oidcUserInfoIndex, string, *cachedOIDCUserInfo](ctx, oidcUserInfoIndexValues(), cache.Config{}),
+		}
+		for _, e := range []*cachedOIDCUserInfo{entry("user1", "grant1"), entry("user2"), entry("user3", "grant3")} {
+			c.oidcUserInfo.Set(ctx, e)
+		}
+		c.invalidateOIDCUserInfoOfUserGrants(&database.DB{DB: db})(ctx, []*eventstore.Aggregate{
+			{ID: "grant1", Type: usergrant.AggregateType, InstanceID: "instanceID"},
+			{ID: "grant2", Type: usergrant.AggregateType, InstanceID: "instanceID"},
+		})
+
+		_, ok := c.oidcUserInfo.Get(ctx, oidcUserInfoIndexByUserID, "user1")
+		assert.False(t, ok)
+		_, ok = c.oidcUserInfo.Get(ctx, oidcUserInfoIndexByUserID, "user2")
+		assert.False(t, ok)
+		_, ok = c.oidcUserInfo.Get(ctx, oidcUserInfoIndexByUserID, "user3")
+		assert.True(t, ok, "user infos of other users must stay cached")
+	})
+}
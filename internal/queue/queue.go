@@ -3,11 +3,15 @@ package queue
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
 	"github.com/riverqueue/river/rivermigrate"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/dialect"
@@ -48,15 +52,40 @@ func init() {
 	})
 }
 
+type Config struct {
+	// DiscardedJobRetention defines how long jobs which exceeded their max attempts are kept.
+	// Discarded jobs can be inspected and retried during this period.
+	DiscardedJobRetention time.Duration
+}
+
 // Queue abstracts the underlying queuing library
 // For more information see github.com/riverqueue/river
 // TODO(adlerhurst): maybe it makes more sense to split the effective queue from the migrator.
 type Queue struct {
 	driver riverdriver.Driver[pgx.Tx]
+	client *river.Client[pgx.Tx]
+
+	config      *river.Config
+	shouldStart bool
 }
 
 func New(client *database.DB) *Queue {
-	return &Queue{driver: riverpgxv5.New(client.Pool)}
+	return NewWithConfig(client, nil)
+}
+
+func NewWithConfig(client *database.DB, config *Config) *Queue {
+	riverConfig := &river.Config{
+		Workers:    river.NewWorkers(),
+		Queues:     make(map[string]river.QueueConfig),
+		JobTimeout: -1,
+	}
+	if config != nil {
+		riverConfig.DiscardedJobRetentionPeriod = config.DiscardedJobRetention
+	}
+	return &Queue{
+		driver: riverpgxv5.New(client.Pool),
+		config: riverConfig,
+	}
 }
 
 func (q *Queue) ExecuteMigrations(ctx context.Context) error {
@@ -73,3 +102,147 @@ func (q *Queue) ExecuteMigrations(ctx context.Context) error {
 	_, err = migrator.Migrate(ctx, rivermigrate.DirectionUp, nil)
 	return err
 }
+
+// Worker registers itself and the queues it processes
+type Worker interface {
+	Register(workers *river.Workers, queues map[string]river.QueueConfig)
+}
+
+// AddWorkers registers the workers on the queue.
+// The workers start processing jobs as soon as [Queue.Start] is called.
+func (q *Queue) AddWorkers(w ...Worker) {
+	if q == nil {
+		logging.Info("skip adding workers because queue is not set")
+		return
+	}
+	for _, worker := range w {
+		worker.Register(q.config.Workers, q.config.Queues)
+	}
+	q.shouldStart = true
+}
+
+// AddPeriodicJob inserts the job in the interval, starting as soon as [Queue.Start] is called.
+// Only the leader of all running queues inserts the job, so it is worked once per interval.
+// The worker of the job must be added using [Queue.AddWorkers].
+func (q *Queue) AddPeriodicJob(interval time.Duration, args river.JobArgs, opts ...InsertOpt) {
+	if q == nil {
+		logging.Info("skip adding periodic job because queue is not set")
+		return
+	}
+	options := &river.InsertOpts{
+		// prevents duplicates if the leader changes within the interval
+		UniqueOpts: river.UniqueOpts{ByPeriod: interval},
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	q.config.PeriodicJobs = append(q.config.PeriodicJobs, river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return args, options
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	))
+}
+
+// Start creates the client used to insert and work jobs.
+// Jobs are only processed if at least one worker was added before.
+func (q *Queue) Start(ctx context.Context) (err error) {
+	if q == nil {
+		return nil
+	}
+	ctx = WithQueue(ctx)
+
+	q.client, err = river.NewClient(q.driver, q.config)
+	if err != nil {
+		return err
+	}
+	if !q.shouldStart {
+		return nil
+	}
+	return q.client.Start(ctx)
+}
+
+// Stop waits for running jobs to complete and stops fetching new jobs.
+func (q *Queue) Stop(ctx context.Context) error {
+	if q == nil || q.client == nil || !q.shouldStart {
+		return nil
+	}
+	return q.client.Stop(WithQueue(ctx))
+}
+
+type InsertOpt func(*river.InsertOpts)
+
+func WithMaxAttempts(maxAttempts uint8) InsertOpt {
+	return func(opts *river.InsertOpts) {
+		opts.MaxAttempts = int(maxAttempts)
+	}
+}
+
+func WithQueueName(name string) InsertOpt {
+	return func(opts *river.InsertOpts) {
+		opts.Queue = name
+	}
+}
+
+// WithMetadata sets metadata of the job which can be used to filter jobs, see [JobSearch.Metadata].
+func WithMetadata(metadata []byte) InsertOpt {
+	return func(opts *river.InsertOpts) {
+		opts.Metadata = metadata
+	}
+}
+
+func (q *Queue) Insert(ctx context.Context, args river.JobArgs, opts ...InsertOpt) error {
+	options := new(river.InsertOpts)
+	ctx = WithQueue(ctx)
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	_, err := q.client.Insert(ctx, args, options)
+	return err
+}
+
+// JobSearch filters the jobs returned by [Queue.SearchJobs]
+type JobSearch struct {
+	Kind     string
+	States   []rivertype.JobState
+	Metadata []byte
+	Limit    uint32
+	// After is the id of the last job of the previous page
+	After int64
+}
+
+// SearchJobs returns the jobs matching the search ordered by id.
+func (q *Queue) SearchJobs(ctx context.Context, search *JobSearch) ([]*rivertype.JobRow, error) {
+	params := river.NewJobListParams().
+		Kinds(search.Kind).
+		OrderBy(river.JobListOrderByID, river.SortOrderAsc)
+	if len(search.States) > 0 {
+		params = params.States(search.States...)
+	}
+	if len(search.Metadata) > 0 {
+		params = params.Metadata(string(search.Metadata))
+	}
+	if search.Limit > 0 {
+		params = params.First(int(search.Limit))
+	}
+	if search.After > 0 {
+		params = params.After(river.JobListCursorFromJob(&rivertype.JobRow{ID: search.After}))
+	}
+	res, err := q.client.JobList(WithQueue(ctx), params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Jobs, nil
+}
+
+// GetJob returns the job or [rivertype.ErrNotFound].
+func (q *Queue) GetJob(ctx context.Context, id int64) (*rivertype.JobRow, error) {
+	return q.client.JobGet(WithQueue(ctx), id)
+}
+
+// RetryJob schedules the job to be worked immediately, the attempts are not reset.
+func (q *Queue) RetryJob(ctx context.Context, id int64) (*rivertype.JobRow, error) {
+	return q.client.JobRetry(WithQueue(ctx), id)
+}
@@ -17,27 +17,30 @@ const (
 	SessionLinkedType      = authRequestEventPrefix + "session.linked"
 	CodeExchangedType      = authRequestEventPrefix + "code.exchanged"
 	SucceededType          = authRequestEventPrefix + "succeeded"
+	PushedType             = authRequestEventPrefix + "pushed"
+	PushedUsedType         = authRequestEventPrefix + "pushed.used"
 )
 
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	LoginClient      string                    `json:"login_client"`
-	ClientID         string                    `json:"client_id"`
-	RedirectURI      string                    `json:"redirect_uri"`
-	State            string                    `json:"state,omitempty"`
-	Nonce            string                    `json:"nonce,omitempty"`
-	Scope            []string                  `json:"scope,omitempty"`
-	Audience         []string                  `json:"audience,omitempty"`
-	ResponseType     domain.OIDCResponseType   `json:"response_type,omitempty"`
-	ResponseMode     domain.OIDCResponseMode   `json:"response_mode,omitempty"`
-	CodeChallenge    *domain.OIDCCodeChallenge `json:"code_challenge,omitempty"`
-	Prompt           []domain.Prompt           `json:"prompt,omitempty"`
-	UILocales        []string                  `json:"ui_locales,omitempty"`
-	MaxAge           *time.Duration            `json:"max_age,omitempty"`
-	LoginHint        *string                   `json:"login_hint,omitempty"`
-	HintUserID       *string                   `json:"hint_user_id,omitempty"`
-	NeedRefreshToken bool                      `json:"need_refresh_token,omitempty"`
+	LoginClient          string                      `json:"login_client"`
+	ClientID             string                      `json:"client_id"`
+	RedirectURI          string                      `json:"redirect_uri"`
+	State                string                      `json:"state,omitempty"`
+	Nonce                string                      `json:"nonce,omitempty"`
+	Scope                []string                    `json:"scope,omitempty"`
+	Audience             []string                    `json:"audience,omitempty"`
+	ResponseType         domain.OIDCResponseType     `json:"response_type,omitempty"`
+	ResponseMode         domain.OIDCResponseMode     `json:"response_mode,omitempty"`
+	CodeChallenge        *domain.OIDCCodeChallenge   `json:"code_challenge,omitempty"`
+	Prompt               []domain.Prompt             `json:"prompt,omitempty"`
+	UILocales            []string                    `json:"ui_locales,omitempty"`
+	MaxAge               *time.Duration              `json:"max_age,omitempty"`
+	LoginHint            *string                     `json:"login_hint,omitempty"`
+	HintUserID           *string                     `json:"hint_user_id,omitempty"`
+	NeedRefreshToken     bool                        `json:"need_refresh_token,omitempty"`
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorization_details,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -66,6 +69,7 @@ func NewAddedEvent(ctx context.Context,
 	loginHint,
 	hintUserID *string,
 	needRefreshToken bool,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -73,22 +77,23 @@ func NewAddedEvent(ctx context.Context,
 			aggregate,
 			AddedType,
 		),
-		LoginClient:      loginClient,
-		ClientID:         clientID,
-		RedirectURI:      redirectURI,
-		State:            state,
-		Nonce:            nonce,
-		Scope:            scope,
-		Audience:         audience,
-		ResponseType:     responseType,
-		ResponseMode:     responseMode,
-		CodeChallenge:    codeChallenge,
-		Prompt:           prompt,
-		UILocales:        uiLocales,
-		MaxAge:           maxAge,
-		LoginHint:        loginHint,
-		HintUserID:       hintUserID,
-		NeedRefreshToken: needRefreshToken,
+		LoginClient:          loginClient,
+		ClientID:             clientID,
+		RedirectURI:          redirectURI,
+		State:                state,
+		Nonce:                nonce,
+		Scope:                scope,
+		Audience:             audience,
+		ResponseType:         responseType,
+		ResponseMode:         responseMode,
+		CodeChallenge:        codeChallenge,
+		Prompt:               prompt,
+		UILocales:            uiLocales,
+		MaxAge:               maxAge,
+		LoginHint:            loginHint,
+		HintUserID:           hintUserID,
+		NeedRefreshToken:     needRefreshToken,
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
@@ -289,3 +294,90 @@ func SucceededEventMapper(event eventstore.Event) (eventstore.Event, error) {
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+// PushedEvent stores the parameters of a Pushed Authorization Request (RFC 9126),
+// which are used by the client through the request_uri until the expiration.
+type PushedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID   string              `json:"client_id"`
+	Parameters map[string][]string `json:"parameters,omitempty"`
+	Expiration time.Time           `json:"expiration"`
+}
+
+func (e *PushedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewPushedEvent(ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	parameters map[string][]string,
+	expiration time.Time,
+) *PushedEvent {
+	return &PushedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedType,
+		),
+		ClientID:   clientID,
+		Parameters: parameters,
+		Expiration: expiration,
+	}
+}
+
+func PushedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	added := &PushedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(added)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "AUTHR-x7vq2m4kpe", "unable to unmarshal pushed auth request")
+	}
+
+	return added, nil
+}
+
+const (
+	// UniquePushedUsed ensures a pushed authorization request is used once,
+	// as the sequence of the aggregate is not checked on push.
+	UniquePushedUsed    = "pushed_auth_request_used"
+	DuplicatePushedUsed = "Errors.AuthRequest.AlreadyHandled"
+)
+
+type PushedUsedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *PushedUsedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *PushedUsedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		eventstore.NewAddEventUniqueConstraint(UniquePushedUsed, e.Aggregate().ID, DuplicatePushedUsed),
+	}
+}
+
+func NewPushedUsedEvent(ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PushedUsedEvent {
+	return &PushedUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedUsedType,
+		),
+	}
+}
+
+func PushedUsedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &PushedUsedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type KerberosIDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID               string              `json:"id"`
+	Name             string              `json:"name,omitempty"`
+	ServicePrincipal string              `json:"servicePrincipal,omitempty"`
+	Keytab           *crypto.CryptoValue `json:"keytab"`
+	Options
+}
+
+func NewKerberosIDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	servicePrincipal string,
+	keytab *crypto.CryptoValue,
+	options Options,
+) *KerberosIDPAddedEvent {
+	return &KerberosIDPAddedEvent{
+		BaseEvent:        *base,
+		ID:               id,
+		Name:             name,
+		ServicePrincipal: servicePrincipal,
+		Keytab:           keytab,
+		Options:          options,
+	}
+}
+
+func (e *KerberosIDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *KerberosIDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func KerberosIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &KerberosIDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Kr3bq", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type KerberosIDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID               string              `json:"id"`
+	Name             *string             `json:"name,omitempty"`
+	ServicePrincipal *string             `json:"servicePrincipal,omitempty"`
+	Keytab           *crypto.CryptoValue `json:"keytab,omitempty"`
+	OptionChanges
+}
+
+func NewKerberosIDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []KerberosIDPChanges,
+) (*KerberosIDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-Hs8vd", "Errors.NoChangesFound")
+	}
+	changedEvent := &KerberosIDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type KerberosIDPChanges func(*KerberosIDPChangedEvent)
+
+func ChangeKerberosName(name string) func(*KerberosIDPChangedEvent) {
+	return func(e *KerberosIDPChangedEvent) {
+		e.Name = &name
+	}
+}
+
+func ChangeKerberosServicePrincipal(servicePrincipal string) func(*KerberosIDPChangedEvent) {
+	return func(e *KerberosIDPChangedEvent) {
+		e.ServicePrincipal = &servicePrincipal
+	}
+}
+
+func ChangeKerberosKeytab(keytab *crypto.CryptoValue) func(*KerberosIDPChangedEvent) {
+	return func(e *KerberosIDPChangedEvent) {
+		e.Keytab = keytab
+	}
+}
+
+func ChangeKerberosOptions(options OptionChanges) func(*KerberosIDPChangedEvent) {
+	return func(e *KerberosIDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func (e *KerberosIDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *KerberosIDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func KerberosIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &KerberosIDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Wp2zn", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
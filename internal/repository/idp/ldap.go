@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -23,6 +24,10 @@ type LDAPIDPAddedEvent struct {
 	UserFilters       []string            `json:"userFilters"`
 	Timeout           time.Duration       `json:"timeout"`
 	RootCA            []byte              `json:"rootCA"`
+	// GroupMapping grants project roles based on the groups of the user
+	GroupMapping *domain.LDAPGroupMapping `json:"groupMapping,omitempty"`
+	// DirectorySync enables the periodic sync of the users with the directory
+	DirectorySync bool `json:"directorySync,omitempty"`
 
 	LDAPAttributes
 	Options
@@ -145,6 +150,8 @@ func NewLDAPIDPAddedEvent(
 	timeout time.Duration,
 	rootCA []byte,
 	attributes LDAPAttributes,
+	groupMapping *domain.LDAPGroupMapping,
+	directorySync bool,
 	options Options,
 ) *LDAPIDPAddedEvent {
 	return &LDAPIDPAddedEvent{
@@ -162,6 +169,8 @@ func NewLDAPIDPAddedEvent(
 		Timeout:           timeout,
 		RootCA:            rootCA,
 		LDAPAttributes:    attributes,
+		GroupMapping:      groupMapping,
+		DirectorySync:     directorySync,
 		Options:           options,
 	}
 }
@@ -190,18 +199,20 @@ func LDAPIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type LDAPIDPChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID                string              `json:"id"`
-	Name              *string             `json:"name,omitempty"`
-	Servers           []string            `json:"servers,omitempty"`
-	StartTLS          *bool               `json:"startTLS,omitempty"`
-	BaseDN            *string             `json:"baseDN,omitempty"`
-	BindDN            *string             `json:"bindDN,omitempty"`
-	BindPassword      *crypto.CryptoValue `json:"bindPassword,omitempty"`
-	UserBase          *string             `json:"userBase,omitempty"`
-	UserObjectClasses []string            `json:"userObjectClasses,omitempty"`
-	UserFilters       []string            `json:"userFilters,omitempty"`
-	Timeout           *time.Duration      `json:"timeout,omitempty"`
-	RootCA            []byte              `json:"rootCA,omitempty"`
+	ID                string                   `json:"id"`
+	Name              *string                  `json:"name,omitempty"`
+	Servers           []string                 `json:"servers,omitempty"`
+	StartTLS          *bool                    `json:"startTLS,omitempty"`
+	BaseDN            *string                  `json:"baseDN,omitempty"`
+	BindDN            *string                  `json:"bindDN,omitempty"`
+	BindPassword      *crypto.CryptoValue      `json:"bindPassword,omitempty"`
+	UserBase          *string                  `json:"userBase,omitempty"`
+	UserObjectClasses []string                 `json:"userObjectClasses,omitempty"`
+	UserFilters       []string                 `json:"userFilters,omitempty"`
+	Timeout           *time.Duration           `json:"timeout,omitempty"`
+	RootCA            []byte                   `json:"rootCA,omitempty"`
+	GroupMapping      *domain.LDAPGroupMapping `json:"groupMapping,omitempty"`
+	DirectorySync     *bool                    `json:"directorySync,omitempty"`
 
 	LDAPAttributeChanges
 	OptionChanges
@@ -325,6 +336,22 @@ func ChangeLDAPRootCA(rootCA []byte) func(*LDAPIDPChangedEvent) {
 	}
 }
 
+func ChangeLDAPGroupMapping(groupMapping *domain.LDAPGroupMapping) func(*LDAPIDPChangedEvent) {
+	// an empty mapping removes the existing one
+	if groupMapping == nil {
+		groupMapping = new(domain.LDAPGroupMapping)
+	}
+	return func(e *LDAPIDPChangedEvent) {
+		e.GroupMapping = groupMapping
+	}
+}
+
+func ChangeLDAPDirectorySync(directorySync bool) func(*LDAPIDPChangedEvent) {
+	return func(e *LDAPIDPChangedEvent) {
+		e.DirectorySync = &directorySync
+	}
+}
+
 func ChangeLDAPAttributes(attributes LDAPAttributeChanges) func(*LDAPIDPChangedEvent) {
 	return func(e *LDAPIDPChangedEvent) {
 		e.LDAPAttributeChanges = attributes
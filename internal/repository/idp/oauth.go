@@ -2,6 +2,7 @@ package idp
 
 import (
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -9,15 +10,16 @@ import (
 type OAuthIDPAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID                    string              `json:"id"`
-	Name                  string              `json:"name,omitempty"`
-	ClientID              string              `json:"clientId,omitempty"`
-	ClientSecret          *crypto.CryptoValue `json:"clientSecret,omitempty"`
-	AuthorizationEndpoint string              `json:"authorizationEndpoint,omitempty"`
-	TokenEndpoint         string              `json:"tokenEndpoint,omitempty"`
-	UserEndpoint          string              `json:"userEndpoint,omitempty"`
-	Scopes                []string            `json:"scopes,omitempty"`
-	IDAttribute           string              `json:"idAttribute,omitempty"`
+	ID                    string                  `json:"id"`
+	Name                  string                  `json:"name,omitempty"`
+	ClientID              string                  `json:"clientId,omitempty"`
+	ClientSecret          *crypto.CryptoValue     `json:"clientSecret,omitempty"`
+	AuthorizationEndpoint string                  `json:"authorizationEndpoint,omitempty"`
+	TokenEndpoint         string                  `json:"tokenEndpoint,omitempty"`
+	UserEndpoint          string                  `json:"userEndpoint,omitempty"`
+	Scopes                []string                `json:"scopes,omitempty"`
+	IDAttribute           string                  `json:"idAttribute,omitempty"`
+	ClaimMapping          *domain.IDPClaimMapping `json:"claimMapping,omitempty"`
 	Options
 }
 
@@ -32,6 +34,7 @@ func NewOAuthIDPAddedEvent(
 	userEndpoint,
 	idAttribute string,
 	scopes []string,
+	claimMapping *domain.IDPClaimMapping,
 	options Options,
 ) *OAuthIDPAddedEvent {
 	return &OAuthIDPAddedEvent{
@@ -45,6 +48,7 @@ func NewOAuthIDPAddedEvent(
 		UserEndpoint:          userEndpoint,
 		Scopes:                scopes,
 		IDAttribute:           idAttribute,
+		ClaimMapping:          claimMapping,
 		Options:               options,
 	}
 }
@@ -73,15 +77,16 @@ func OAuthIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error)
 type OAuthIDPChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID                    string              `json:"id"`
-	Name                  *string             `json:"name,omitempty"`
-	ClientID              *string             `json:"clientId,omitempty"`
-	ClientSecret          *crypto.CryptoValue `json:"clientSecret,omitempty"`
-	AuthorizationEndpoint *string             `json:"authorizationEndpoint,omitempty"`
-	TokenEndpoint         *string             `json:"tokenEndpoint,omitempty"`
-	UserEndpoint          *string             `json:"userEndpoint,omitempty"`
-	Scopes                []string            `json:"scopes,omitempty"`
-	IDAttribute           *string             `json:"idAttribute,omitempty"`
+	ID                    string                  `json:"id"`
+	Name                  *string                 `json:"name,omitempty"`
+	ClientID              *string                 `json:"clientId,omitempty"`
+	ClientSecret          *crypto.CryptoValue     `json:"clientSecret,omitempty"`
+	AuthorizationEndpoint *string                 `json:"authorizationEndpoint,omitempty"`
+	TokenEndpoint         *string                 `json:"tokenEndpoint,omitempty"`
+	UserEndpoint          *string                 `json:"userEndpoint,omitempty"`
+	Scopes                []string                `json:"scopes,omitempty"`
+	IDAttribute           *string                 `json:"idAttribute,omitempty"`
+	ClaimMapping          *domain.IDPClaimMapping `json:"claimMapping,omitempty"`
 	OptionChanges
 }
 
@@ -158,6 +163,16 @@ func ChangeOAuthIDAttribute(idAttribute string) func(*OAuthIDPChangedEvent) {
 	}
 }
 
+func ChangeOAuthClaimMapping(claimMapping *domain.IDPClaimMapping) func(*OAuthIDPChangedEvent) {
+	// an empty mapping removes the existing one
+	if claimMapping == nil {
+		claimMapping = new(domain.IDPClaimMapping)
+	}
+	return func(e *OAuthIDPChangedEvent) {
+		e.ClaimMapping = claimMapping
+	}
+}
+
 func (e *OAuthIDPChangedEvent) Payload() interface{} {
 	return e
 }
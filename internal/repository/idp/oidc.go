@@ -2,6 +2,7 @@ package idp
 
 import (
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -9,13 +10,14 @@ import (
 type OIDCIDPAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID               string              `json:"id"`
-	Name             string              `json:"name"`
-	Issuer           string              `json:"issuer"`
-	ClientID         string              `json:"clientId"`
-	ClientSecret     *crypto.CryptoValue `json:"clientSecret"`
-	Scopes           []string            `json:"scopes,omitempty"`
-	IsIDTokenMapping bool                `json:"idTokenMapping,omitempty"`
+	ID               string                  `json:"id"`
+	Name             string                  `json:"name"`
+	Issuer           string                  `json:"issuer"`
+	ClientID         string                  `json:"clientId"`
+	ClientSecret     *crypto.CryptoValue     `json:"clientSecret"`
+	Scopes           []string                `json:"scopes,omitempty"`
+	IsIDTokenMapping bool                    `json:"idTokenMapping,omitempty"`
+	ClaimMapping     *domain.IDPClaimMapping `json:"claimMapping,omitempty"`
 	Options
 }
 
@@ -28,6 +30,7 @@ func NewOIDCIDPAddedEvent(
 	clientSecret *crypto.CryptoValue,
 	scopes []string,
 	isIDTokenMapping bool,
+	claimMapping *domain.IDPClaimMapping,
 	options Options,
 ) *OIDCIDPAddedEvent {
 	return &OIDCIDPAddedEvent{
@@ -39,6 +42,7 @@ func NewOIDCIDPAddedEvent(
 		ClientSecret:     clientSecret,
 		Scopes:           scopes,
 		IsIDTokenMapping: isIDTokenMapping,
+		ClaimMapping:     claimMapping,
 		Options:          options,
 	}
 }
@@ -67,13 +71,14 @@ func OIDCIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type OIDCIDPChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID               string              `json:"id"`
-	Name             *string             `json:"name,omitempty"`
-	Issuer           *string             `json:"issuer,omitempty"`
-	ClientID         *string             `json:"clientId,omitempty"`
-	ClientSecret     *crypto.CryptoValue `json:"clientSecret,omitempty"`
-	Scopes           []string            `json:"scopes,omitempty"`
-	IsIDTokenMapping *bool               `json:"idTokenMapping,omitempty"`
+	ID               string                  `json:"id"`
+	Name             *string                 `json:"name,omitempty"`
+	Issuer           *string                 `json:"issuer,omitempty"`
+	ClientID         *string                 `json:"clientId,omitempty"`
+	ClientSecret     *crypto.CryptoValue     `json:"clientSecret,omitempty"`
+	Scopes           []string                `json:"scopes,omitempty"`
+	IsIDTokenMapping *bool                   `json:"idTokenMapping,omitempty"`
+	ClaimMapping     *domain.IDPClaimMapping `json:"claimMapping,omitempty"`
 	OptionChanges
 }
 
@@ -139,6 +144,16 @@ func ChangeOIDCIsIDTokenMapping(idTokenMapping bool) func(*OIDCIDPChangedEvent)
 	}
 }
 
+func ChangeOIDCClaimMapping(claimMapping *domain.IDPClaimMapping) func(*OIDCIDPChangedEvent) {
+	// an empty mapping removes the existing one
+	if claimMapping == nil {
+		claimMapping = new(domain.IDPClaimMapping)
+	}
+	return func(e *OIDCIDPChangedEvent) {
+		e.ClaimMapping = claimMapping
+	}
+}
+
 func (e *OIDCIDPChangedEvent) Payload() interface{} {
 	return e
 }
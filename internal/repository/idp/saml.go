@@ -13,12 +13,14 @@ type SAMLIDPAddedEvent struct {
 	ID                            string                   `json:"id"`
 	Name                          string                   `json:"name,omitempty"`
 	Metadata                      []byte                   `json:"metadata,omitempty"`
+	MetadataURL                   string                   `json:"metadataUrl,omitempty"`
 	Key                           *crypto.CryptoValue      `json:"key,omitempty"`
 	Certificate                   []byte                   `json:"certificate,omitempty"`
 	Binding                       string                   `json:"binding,omitempty"`
 	WithSignedRequest             bool                     `json:"withSignedRequest,omitempty"`
 	NameIDFormat                  *domain.SAMLNameIDFormat `json:"nameIDFormat,omitempty"`
 	TransientMappingAttributeName string                   `json:"transientMappingAttributeName,omitempty"`
+	ClaimMapping                  *domain.IDPClaimMapping  `json:"claimMapping,omitempty"`
 	Options
 }
 
@@ -27,12 +29,14 @@ func NewSAMLIDPAddedEvent(
 	id,
 	name string,
 	metadata []byte,
+	metadataURL string,
 	key *crypto.CryptoValue,
 	certificate []byte,
 	binding string,
 	withSignedRequest bool,
 	nameIDFormat *domain.SAMLNameIDFormat,
 	transientMappingAttributeName string,
+	claimMapping *domain.IDPClaimMapping,
 	options Options,
 ) *SAMLIDPAddedEvent {
 	return &SAMLIDPAddedEvent{
@@ -40,12 +44,14 @@ func NewSAMLIDPAddedEvent(
 		ID:                            id,
 		Name:                          name,
 		Metadata:                      metadata,
+		MetadataURL:                   metadataURL,
 		Key:                           key,
 		Certificate:                   certificate,
 		Binding:                       binding,
 		WithSignedRequest:             withSignedRequest,
 		NameIDFormat:                  nameIDFormat,
 		TransientMappingAttributeName: transientMappingAttributeName,
+		ClaimMapping:                  claimMapping,
 		Options:                       options,
 	}
 }
@@ -77,12 +83,15 @@ type SAMLIDPChangedEvent struct {
 	ID                            string                   `json:"id"`
 	Name                          *string                  `json:"name,omitempty"`
 	Metadata                      []byte                   `json:"metadata,omitempty"`
+	MetadataURL                   *string                  `json:"metadataUrl,omitempty"`
 	Key                           *crypto.CryptoValue      `json:"key,omitempty"`
 	Certificate                   []byte                   `json:"certificate,omitempty"`
+	AdditionalKeys                *domain.SAMLKeys         `json:"additionalKeys,omitempty"`
 	Binding                       *string                  `json:"binding,omitempty"`
 	WithSignedRequest             *bool                    `json:"withSignedRequest,omitempty"`
 	NameIDFormat                  *domain.SAMLNameIDFormat `json:"nameIDFormat,omitempty"`
 	TransientMappingAttributeName *string                  `json:"transientMappingAttributeName,omitempty"`
+	ClaimMapping                  *domain.IDPClaimMapping  `json:"claimMapping,omitempty"`
 	OptionChanges
 }
 
@@ -118,6 +127,12 @@ func ChangeSAMLMetadata(metadata []byte) func(*SAMLIDPChangedEvent) {
 	}
 }
 
+func ChangeSAMLMetadataURL(metadataURL string) func(*SAMLIDPChangedEvent) {
+	return func(e *SAMLIDPChangedEvent) {
+		e.MetadataURL = &metadataURL
+	}
+}
+
 func ChangeSAMLKey(key *crypto.CryptoValue) func(*SAMLIDPChangedEvent) {
 	return func(e *SAMLIDPChangedEvent) {
 		e.Key = key
@@ -130,6 +145,16 @@ func ChangeSAMLCertificate(certificate []byte) func(*SAMLIDPChangedEvent) {
 	}
 }
 
+// ChangeSAMLAdditionalKeys replaces all additional keys, no keys remove the existing ones
+func ChangeSAMLAdditionalKeys(keys domain.SAMLKeys) func(*SAMLIDPChangedEvent) {
+	if keys == nil {
+		keys = domain.SAMLKeys{}
+	}
+	return func(e *SAMLIDPChangedEvent) {
+		e.AdditionalKeys = &keys
+	}
+}
+
 func ChangeSAMLBinding(binding string) func(*SAMLIDPChangedEvent) {
 	return func(e *SAMLIDPChangedEvent) {
 		e.Binding = &binding
@@ -154,6 +179,16 @@ func ChangeSAMLTransientMappingAttributeName(name string) func(*SAMLIDPChangedEv
 	}
 }
 
+func ChangeSAMLClaimMapping(claimMapping *domain.IDPClaimMapping) func(*SAMLIDPChangedEvent) {
+	// an empty mapping removes the existing one
+	if claimMapping == nil {
+		claimMapping = new(domain.IDPClaimMapping)
+	}
+	return func(e *SAMLIDPChangedEvent) {
+		e.ClaimMapping = claimMapping
+	}
+}
+
 func ChangeSAMLOptions(options OptionChanges) func(*SAMLIDPChangedEvent) {
 	return func(e *SAMLIDPChangedEvent) {
 		e.OptionChanges = options
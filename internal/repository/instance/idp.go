@@ -35,6 +35,8 @@ const (
 	LDAPIDPChangedEventType             eventstore.EventType = "instance.idp.ldap.v2.changed"
 	AppleIDPAddedEventType              eventstore.EventType = "instance.idp.apple.added"
 	AppleIDPChangedEventType            eventstore.EventType = "instance.idp.apple.changed"
+	KerberosIDPAddedEventType           eventstore.EventType = "instance.idp.kerberos.added"
+	KerberosIDPChangedEventType         eventstore.EventType = "instance.idp.kerberos.changed"
 	SAMLIDPAddedEventType               eventstore.EventType = "instance.idp.saml.added"
 	SAMLIDPChangedEventType             eventstore.EventType = "instance.idp.saml.changed"
 	IDPRemovedEventType                 eventstore.EventType = "instance.idp.removed"
@@ -56,6 +58,7 @@ func NewOAuthIDPAddedEvent(
 	userEndpoint,
 	idAttribute string,
 	scopes []string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) *OAuthIDPAddedEvent {
 
@@ -75,6 +78,7 @@ func NewOAuthIDPAddedEvent(
 			userEndpoint,
 			idAttribute,
 			scopes,
+			claimMapping,
 			options,
 		),
 	}
@@ -138,6 +142,7 @@ func NewOIDCIDPAddedEvent(
 	clientSecret *crypto.CryptoValue,
 	scopes []string,
 	isIDTokenMapping bool,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) *OIDCIDPAddedEvent {
 
@@ -155,6 +160,7 @@ func NewOIDCIDPAddedEvent(
 			clientSecret,
 			scopes,
 			isIDTokenMapping,
+			claimMapping,
 			options,
 		),
 	}
@@ -854,6 +860,8 @@ func NewLDAPIDPAddedEvent(
 	timeout time.Duration,
 	rootCA []byte,
 	attributes idp.LDAPAttributes,
+	groupMapping *domain.LDAPGroupMapping,
+	directorySync bool,
 	options idp.Options,
 ) *LDAPIDPAddedEvent {
 
@@ -877,6 +885,8 @@ func NewLDAPIDPAddedEvent(
 			timeout,
 			rootCA,
 			attributes,
+			groupMapping,
+			directorySync,
 			options,
 		),
 	}
@@ -1005,6 +1015,80 @@ func AppleIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error
 	return &AppleIDPChangedEvent{AppleIDPChangedEvent: *e.(*idp.AppleIDPChangedEvent)}, nil
 }
 
+type KerberosIDPAddedEvent struct {
+	idp.KerberosIDPAddedEvent
+}
+
+func NewKerberosIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	servicePrincipal string,
+	keytab *crypto.CryptoValue,
+	options idp.Options,
+) *KerberosIDPAddedEvent {
+
+	return &KerberosIDPAddedEvent{
+		KerberosIDPAddedEvent: *idp.NewKerberosIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				KerberosIDPAddedEventType,
+			),
+			id,
+			name,
+			servicePrincipal,
+			keytab,
+			options,
+		),
+	}
+}
+
+func KerberosIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.KerberosIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KerberosIDPAddedEvent{KerberosIDPAddedEvent: *e.(*idp.KerberosIDPAddedEvent)}, nil
+}
+
+type KerberosIDPChangedEvent struct {
+	idp.KerberosIDPChangedEvent
+}
+
+func NewKerberosIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.KerberosIDPChanges,
+) (*KerberosIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewKerberosIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			KerberosIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &KerberosIDPChangedEvent{KerberosIDPChangedEvent: *changedEvent}, nil
+}
+
+func KerberosIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.KerberosIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KerberosIDPChangedEvent{KerberosIDPChangedEvent: *e.(*idp.KerberosIDPChangedEvent)}, nil
+}
+
 type SAMLIDPAddedEvent struct {
 	idp.SAMLIDPAddedEvent
 }
@@ -1015,12 +1099,14 @@ func NewSAMLIDPAddedEvent(
 	id,
 	name string,
 	metadata []byte,
+	metadataURL string,
 	key *crypto.CryptoValue,
 	certificate []byte,
 	binding string,
 	withSignedRequest bool,
 	nameIDFormat *domain.SAMLNameIDFormat,
 	transientMappingAttributeName string,
+	claimMapping *domain.IDPClaimMapping,
 	options idp.Options,
 ) *SAMLIDPAddedEvent {
 	return &SAMLIDPAddedEvent{
@@ -1033,12 +1119,14 @@ func NewSAMLIDPAddedEvent(
 			id,
 			name,
 			metadata,
+			metadataURL,
 			key,
 			certificate,
 			binding,
 			withSignedRequest,
 			nameIDFormat,
 			transientMappingAttributeName,
+			claimMapping,
 			options,
 		),
 	}
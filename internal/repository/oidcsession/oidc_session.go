@@ -23,17 +23,19 @@ const (
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	UserID            string                      `json:"userID"`
-	UserResourceOwner string                      `json:"userResourceOwner"`
-	SessionID         string                      `json:"sessionID"`
-	ClientID          string                      `json:"clientID"`
-	Audience          []string                    `json:"audience"`
-	Scope             []string                    `json:"scope"`
-	AuthMethods       []domain.UserAuthMethodType `json:"authMethods"`
-	AuthTime          time.Time                   `json:"authTime"`
-	Nonce             string                      `json:"nonce,omitempty"`
-	PreferredLanguage *language.Tag               `json:"preferredLanguage,omitempty"`
-	UserAgent         *domain.UserAgent           `json:"userAgent,omitempty"`
+	UserID               string                      `json:"userID"`
+	UserResourceOwner    string                      `json:"userResourceOwner"`
+	SessionID            string                      `json:"sessionID"`
+	ClientID             string                      `json:"clientID"`
+	Audience             []string                    `json:"audience"`
+	Scope                []string                    `json:"scope"`
+	AuthMethods          []domain.UserAuthMethodType `json:"authMethods"`
+	AuthTime             time.Time                   `json:"authTime"`
+	Nonce                string                      `json:"nonce,omitempty"`
+	PreferredLanguage    *language.Tag               `json:"preferredLanguage,omitempty"`
+	UserAgent            *domain.UserAgent           `json:"userAgent,omitempty"`
+	DPoPJKT              string                      `json:"dpopJKT,omitempty"`
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -61,6 +63,8 @@ func NewAddedEvent(ctx context.Context,
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	dpopJKT string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -68,28 +72,32 @@ func NewAddedEvent(ctx context.Context,
 			aggregate,
 			AddedType,
 		),
-		UserID:            userID,
-		UserResourceOwner: userResourceOwner,
-		SessionID:         sessionID,
-		ClientID:          clientID,
-		Audience:          audience,
-		Scope:             scope,
-		AuthMethods:       authMethods,
-		AuthTime:          authTime,
-		Nonce:             nonce,
-		PreferredLanguage: preferredLanguage,
-		UserAgent:         userAgent,
+		UserID:               userID,
+		UserResourceOwner:    userResourceOwner,
+		SessionID:            sessionID,
+		ClientID:             clientID,
+		Audience:             audience,
+		Scope:                scope,
+		AuthMethods:          authMethods,
+		AuthTime:             authTime,
+		Nonce:                nonce,
+		PreferredLanguage:    preferredLanguage,
+		UserAgent:            userAgent,
+		DPoPJKT:              dpopJKT,
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
 type AccessTokenAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	ID       string             `json:"id,omitempty"`
-	Scope    []string           `json:"scope,omitempty"`
-	Lifetime time.Duration      `json:"lifetime,omitempty"`
-	Reason   domain.TokenReason `json:"reason,omitempty"`
-	Actor    *domain.TokenActor `json:"actor,omitempty"`
+	ID                   string                      `json:"id,omitempty"`
+	Scope                []string                    `json:"scope,omitempty"`
+	Lifetime             time.Duration               `json:"lifetime,omitempty"`
+	Reason               domain.TokenReason          `json:"reason,omitempty"`
+	Actor                *domain.TokenActor          `json:"actor,omitempty"`
+	DPoPJKT              string                      `json:"dpopJKT,omitempty"`
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *AccessTokenAddedEvent) Payload() interface{} {
@@ -112,6 +120,8 @@ func NewAccessTokenAddedEvent(
 	lifetime time.Duration,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
+	dpopJKT string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AccessTokenAddedEvent {
 	return &AccessTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -119,11 +129,13 @@ func NewAccessTokenAddedEvent(
 			aggregate,
 			AccessTokenAddedType,
 		),
-		ID:       id,
-		Scope:    scope,
-		Lifetime: lifetime,
-		Reason:   reason,
-		Actor:    actor,
+		ID:                   id,
+		Scope:                scope,
+		Lifetime:             lifetime,
+		Reason:               reason,
+		Actor:                actor,
+		DPoPJKT:              dpopJKT,
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
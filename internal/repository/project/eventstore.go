@@ -44,4 +44,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SCIMProvisioningSetType, eventstore.GenericEventMapper[SCIMProvisioningSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SCIMProvisioningRemovedType, eventstore.GenericEventMapper[SCIMProvisioningRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SCIMProvisioningUserSyncedType, eventstore.GenericEventMapper[SCIMProvisioningUserSyncedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SCIMProvisioningUserFailedType, eventstore.GenericEventMapper[SCIMProvisioningUserFailedEvent])
 }
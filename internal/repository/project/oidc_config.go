@@ -46,6 +46,9 @@ type OIDCConfigAddedEvent struct {
 	BackChannelLogoutURI     string                     `json:"backChannelLogoutURI,omitempty"`
 	LoginVersion             domain.LoginVersion        `json:"loginVersion,omitempty"`
 	LoginBaseURI             string                     `json:"loginBaseURI,omitempty"`
+	RequirePAR               bool                       `json:"requirePAR,omitempty"`
+	RequireDPoP              bool                       `json:"requireDPoP,omitempty"`
+	CIBANotificationURI      string                     `json:"cibaNotificationURI,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Payload() interface{} {
@@ -80,6 +83,9 @@ func NewOIDCConfigAddedEvent(
 	backChannelLogoutURI string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	requirePAR bool,
+	requireDPoP bool,
+	cibaNotificationURI string,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -108,6 +114,9 @@ func NewOIDCConfigAddedEvent(
 		BackChannelLogoutURI:     backChannelLogoutURI,
 		LoginVersion:             loginVersion,
 		LoginBaseURI:             loginBaseURI,
+		RequirePAR:               requirePAR,
+		RequireDPoP:              requireDPoP,
+		CIBANotificationURI:      cibaNotificationURI,
 	}
 }
 
@@ -202,7 +211,16 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 	if e.LoginVersion != c.LoginVersion {
 		return false
 	}
-	return e.LoginBaseURI == c.LoginBaseURI
+	if e.LoginBaseURI != c.LoginBaseURI {
+		return false
+	}
+	if e.RequirePAR != c.RequirePAR {
+		return false
+	}
+	if e.RequireDPoP != c.RequireDPoP {
+		return false
+	}
+	return e.CIBANotificationURI == c.CIBANotificationURI
 }
 
 func OIDCConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
@@ -240,6 +258,9 @@ type OIDCConfigChangedEvent struct {
 	BackChannelLogoutURI     *string                     `json:"backChannelLogoutURI,omitempty"`
 	LoginVersion             *domain.LoginVersion        `json:"loginVersion,omitempty"`
 	LoginBaseURI             *string                     `json:"loginBaseURI,omitempty"`
+	RequirePAR               *bool                       `json:"requirePAR,omitempty"`
+	RequireDPoP              *bool                       `json:"requireDPoP,omitempty"`
+	CIBANotificationURI      *string                     `json:"cibaNotificationURI,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Payload() interface{} {
@@ -396,6 +417,24 @@ func ChangeOIDCLoginBaseURI(loginBaseURI string) func(event *OIDCConfigChangedEv
 	}
 }
 
+func ChangeRequirePAR(requirePAR bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePAR = &requirePAR
+	}
+}
+
+func ChangeRequireDPoP(requireDPoP bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireDPoP = &requireDPoP
+	}
+}
+
+func ChangeCIBANotificationURI(cibaNotificationURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.CIBANotificationURI = &cibaNotificationURI
+	}
+}
+
 func OIDCConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -40,11 +40,12 @@ func NewRemoveProjectNameUniqueConstraint(projectName, resourceOwner string) *ev
 type ProjectAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name                   string                        `json:"name,omitempty"`
-	ProjectRoleAssertion   bool                          `json:"projectRoleAssertion,omitempty"`
-	ProjectRoleCheck       bool                          `json:"projectRoleCheck,omitempty"`
-	HasProjectCheck        bool                          `json:"hasProjectCheck,omitempty"`
-	PrivateLabelingSetting domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
+	Name                      string                        `json:"name,omitempty"`
+	ProjectRoleAssertion      bool                          `json:"projectRoleAssertion,omitempty"`
+	ProjectRoleCheck          bool                          `json:"projectRoleCheck,omitempty"`
+	HasProjectCheck           bool                          `json:"hasProjectCheck,omitempty"`
+	PrivateLabelingSetting    domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
+	AuthorizationDetailsTypes []string                      `json:"authorizationDetailsTypes,omitempty"`
 }
 
 func (e *ProjectAddedEvent) Payload() interface{} {
@@ -102,6 +103,7 @@ func NewProjectAddedEvent(
 	projectRoleCheck,
 	hasProjectCheck bool,
 	privateLabelingSetting domain.PrivateLabelingSetting,
+	authorizationDetailsTypes []string,
 ) *ProjectAddedEvent {
 	return &ProjectAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -109,11 +111,12 @@ func NewProjectAddedEvent(
 			aggregate,
 			ProjectAddedType,
 		),
-		Name:                   name,
-		ProjectRoleAssertion:   projectRoleAssertion,
-		ProjectRoleCheck:       projectRoleCheck,
-		HasProjectCheck:        hasProjectCheck,
-		PrivateLabelingSetting: privateLabelingSetting,
+		Name:                      name,
+		ProjectRoleAssertion:      projectRoleAssertion,
+		ProjectRoleCheck:          projectRoleCheck,
+		HasProjectCheck:           hasProjectCheck,
+		PrivateLabelingSetting:    privateLabelingSetting,
+		AuthorizationDetailsTypes: authorizationDetailsTypes,
 	}
 }
 
@@ -133,12 +136,13 @@ func ProjectAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type ProjectChangeEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name                   *string                        `json:"name,omitempty"`
-	ProjectRoleAssertion   *bool                          `json:"projectRoleAssertion,omitempty"`
-	ProjectRoleCheck       *bool                          `json:"projectRoleCheck,omitempty"`
-	HasProjectCheck        *bool                          `json:"hasProjectCheck,omitempty"`
-	PrivateLabelingSetting *domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
-	oldName                string
+	Name                      *string                        `json:"name,omitempty"`
+	ProjectRoleAssertion      *bool                          `json:"projectRoleAssertion,omitempty"`
+	ProjectRoleCheck          *bool                          `json:"projectRoleCheck,omitempty"`
+	HasProjectCheck           *bool                          `json:"hasProjectCheck,omitempty"`
+	PrivateLabelingSetting    *domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
+	AuthorizationDetailsTypes *[]string                      `json:"authorizationDetailsTypes,omitempty"`
+	oldName                   string
 }
 
 func (e *ProjectChangeEvent) Payload() interface{} {
@@ -234,6 +238,12 @@ func ChangePrivateLabelingSetting(ChangePrivateLabelingSetting domain.PrivateLab
 	}
 }
 
+func ChangeAuthorizationDetailsTypes(authorizationDetailsTypes []string) func(event *ProjectChangeEvent) {
+	return func(e *ProjectChangeEvent) {
+		e.AuthorizationDetailsTypes = &authorizationDetailsTypes
+	}
+}
+
 func ProjectChangeEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &ProjectChangeEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,174 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	SCIMProvisioningSetType        = applicationEventTypePrefix + "scim.provisioning.set"
+	SCIMProvisioningRemovedType    = applicationEventTypePrefix + "scim.provisioning.removed"
+	SCIMProvisioningUserSyncedType = applicationEventTypePrefix + "scim.provisioning.user.synced"
+	SCIMProvisioningUserFailedType = applicationEventTypePrefix + "scim.provisioning.user.failed"
+)
+
+// SCIMProvisioningSetEvent configures the scim service provider of an application,
+// the users granted on the project are provisioned to it.
+type SCIMProvisioningSetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	AppID    string              `json:"appId"`
+	Endpoint string              `json:"endpoint,omitempty"`
+	Token    *crypto.CryptoValue `json:"token,omitempty"`
+}
+
+func NewSCIMProvisioningSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	endpoint string,
+	token *crypto.CryptoValue,
+) *SCIMProvisioningSetEvent {
+	return &SCIMProvisioningSetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SCIMProvisioningSetType,
+		),
+		AppID:    appID,
+		Endpoint: endpoint,
+		Token:    token,
+	}
+}
+
+func (e *SCIMProvisioningSetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *SCIMProvisioningSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SCIMProvisioningSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SCIMProvisioningRemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	AppID string `json:"appId"`
+}
+
+func NewSCIMProvisioningRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+) *SCIMProvisioningRemovedEvent {
+	return &SCIMProvisioningRemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SCIMProvisioningRemovedType,
+		),
+		AppID: appID,
+	}
+}
+
+func (e *SCIMProvisioningRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *SCIMProvisioningRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SCIMProvisioningRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// SCIMProvisioningUserSyncedEvent records the successful provisioning of a user to the scim service provider of an application.
+type SCIMProvisioningUserSyncedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	AppID  string `json:"appId"`
+	UserID string `json:"userId"`
+	// RemoteID is the id of the user assigned by the scim service provider
+	RemoteID string                       `json:"remoteId,omitempty"`
+	State    domain.SCIMProvisioningState `json:"state,omitempty"`
+}
+
+func NewSCIMProvisioningUserSyncedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	userID,
+	remoteID string,
+	state domain.SCIMProvisioningState,
+) *SCIMProvisioningUserSyncedEvent {
+	return &SCIMProvisioningUserSyncedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SCIMProvisioningUserSyncedType,
+		),
+		AppID:    appID,
+		UserID:   userID,
+		RemoteID: remoteID,
+		State:    state,
+	}
+}
+
+func (e *SCIMProvisioningUserSyncedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *SCIMProvisioningUserSyncedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SCIMProvisioningUserSyncedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// SCIMProvisioningUserFailedEvent records a failed call to the scim service provider of an application for a user.
+type SCIMProvisioningUserFailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	AppID  string `json:"appId"`
+	UserID string `json:"userId"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewSCIMProvisioningUserFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	userID,
+	err string,
+) *SCIMProvisioningUserFailedEvent {
+	return &SCIMProvisioningUserFailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SCIMProvisioningUserFailedType,
+		),
+		AppID:  appID,
+		UserID: userID,
+		Error:  err,
+	}
+}
+
+func (e *SCIMProvisioningUserFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *SCIMProvisioningUserFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SCIMProvisioningUserFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
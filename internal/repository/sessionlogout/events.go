@@ -77,3 +77,217 @@ func NewBackChannelLogoutSentEvent(ctx context.Context, aggregate *eventstore.Ag
 		OIDCSessionID: oidcSessionID,
 	}
 }
+
+const (
+	samlEventTypePrefix      = eventTypePrefix + "saml."
+	SAMLLogoutRegisteredType = samlEventTypePrefix + "registered"
+	SAMLLogoutRequestedType  = samlEventTypePrefix + "requested"
+	SAMLLogoutSentType       = samlEventTypePrefix + "sent"
+	SAMLLogoutFailedType     = samlEventTypePrefix + "failed"
+
+	SAMLFrontChannelLogoutStartedType = samlEventTypePrefix + "front_channel.started"
+)
+
+// SAMLLogoutRegisteredEvent registers a saml session for single logout at the service provider.
+// The NameID and SessionIndex are the ones sent in the assertion to the service provider.
+type SAMLLogoutRegisteredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SAMLSessionID string `json:"saml_session_id"`
+	UserID        string `json:"user_id"`
+	EntityID      string `json:"entity_id"`
+	Issuer        string `json:"issuer"`
+	NameID        string `json:"name_id"`
+	NameIDFormat  string `json:"name_id_format,omitempty"`
+	SessionIndex  string `json:"session_index,omitempty"`
+}
+
+func (e *SAMLLogoutRegisteredEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLLogoutRegisteredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SAMLLogoutRegisteredEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSAMLLogoutRegisteredEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	samlSessionID,
+	userID,
+	entityID,
+	issuer,
+	nameID,
+	nameIDFormat,
+	sessionIndex string,
+) *SAMLLogoutRegisteredEvent {
+	return &SAMLLogoutRegisteredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLLogoutRegisteredType,
+		),
+		SAMLSessionID: samlSessionID,
+		UserID:        userID,
+		EntityID:      entityID,
+		Issuer:        issuer,
+		NameID:        nameID,
+		NameIDFormat:  nameIDFormat,
+		SessionIndex:  sessionIndex,
+	}
+}
+
+// SAMLLogoutRequestedEvent is pushed if the service provider requested the logout itself,
+// so no LogoutRequest has to be sent to it.
+type SAMLLogoutRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SAMLSessionID string `json:"saml_session_id"`
+}
+
+func (e *SAMLLogoutRequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLLogoutRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SAMLLogoutRequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSAMLLogoutRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate, samlSessionID string) *SAMLLogoutRequestedEvent {
+	return &SAMLLogoutRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLLogoutRequestedType,
+		),
+		SAMLSessionID: samlSessionID,
+	}
+}
+
+type SAMLLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SAMLSessionID string `json:"saml_session_id"`
+}
+
+func (e *SAMLLogoutSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLLogoutSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SAMLLogoutSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSAMLLogoutSentEvent(ctx context.Context, aggregate *eventstore.Aggregate, samlSessionID string) *SAMLLogoutSentEvent {
+	return &SAMLLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLLogoutSentType,
+		),
+		SAMLSessionID: samlSessionID,
+	}
+}
+
+type SAMLLogoutFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SAMLSessionID string `json:"saml_session_id"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+func (e *SAMLLogoutFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLLogoutFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SAMLLogoutFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSAMLLogoutFailedEvent(ctx context.Context, aggregate *eventstore.Aggregate, samlSessionID, reason string) *SAMLLogoutFailedEvent {
+	return &SAMLLogoutFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLLogoutFailedType,
+		),
+		SAMLSessionID: samlSessionID,
+		Reason:        reason,
+	}
+}
+
+// SAMLFrontChannelLogoutStartedEvent is pushed if the logout requested by a service provider
+// is propagated to the other service providers through the user agent.
+// The LogoutResponse to the requesting service provider is sent after all requests got a response.
+type SAMLFrontChannelLogoutStartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID  string                           `json:"user_agent_id"`
+	EntityID     string                           `json:"entity_id"`
+	InResponseTo string                           `json:"in_response_to"`
+	ResponseURL  string                           `json:"response_url"`
+	Binding      string                           `json:"binding"`
+	RelayState   string                           `json:"relay_state,omitempty"`
+	Requests     []*SAMLFrontChannelLogoutRequest `json:"requests"`
+}
+
+// SAMLFrontChannelLogoutRequest is the LogoutRequest sent through the user agent for the saml session.
+type SAMLFrontChannelLogoutRequest struct {
+	SAMLSessionID string `json:"saml_session_id"`
+	RequestID     string `json:"request_id"`
+}
+
+func (e *SAMLFrontChannelLogoutStartedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLFrontChannelLogoutStartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SAMLFrontChannelLogoutStartedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSAMLFrontChannelLogoutStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	entityID,
+	inResponseTo,
+	responseURL,
+	binding,
+	relayState string,
+	requests []*SAMLFrontChannelLogoutRequest,
+) *SAMLFrontChannelLogoutStartedEvent {
+	return &SAMLFrontChannelLogoutStartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLFrontChannelLogoutStartedType,
+		),
+		UserAgentID:  userAgentID,
+		EntityID:     entityID,
+		InResponseTo: inResponseTo,
+		ResponseURL:  responseURL,
+		Binding:      binding,
+		RelayState:   relayState,
+		Requests:     requests,
+	}
+}
@@ -5,11 +5,21 @@ import (
 )
 
 var (
-	BackChannelLogoutRegisteredEventMapper = eventstore.GenericEventMapper[BackChannelLogoutRegisteredEvent]
-	BackChannelLogoutSentEventMapper       = eventstore.GenericEventMapper[BackChannelLogoutSentEvent]
+	BackChannelLogoutRegisteredEventMapper   = eventstore.GenericEventMapper[BackChannelLogoutRegisteredEvent]
+	BackChannelLogoutSentEventMapper         = eventstore.GenericEventMapper[BackChannelLogoutSentEvent]
+	SAMLLogoutRegisteredEventMapper          = eventstore.GenericEventMapper[SAMLLogoutRegisteredEvent]
+	SAMLLogoutRequestedEventMapper           = eventstore.GenericEventMapper[SAMLLogoutRequestedEvent]
+	SAMLLogoutSentEventMapper                = eventstore.GenericEventMapper[SAMLLogoutSentEvent]
+	SAMLLogoutFailedEventMapper              = eventstore.GenericEventMapper[SAMLLogoutFailedEvent]
+	SAMLFrontChannelLogoutStartedEventMapper = eventstore.GenericEventMapper[SAMLFrontChannelLogoutStartedEvent]
 )
 
 func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, BackChannelLogoutRegisteredType, BackChannelLogoutRegisteredEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, BackChannelLogoutSentType, BackChannelLogoutSentEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLLogoutRegisteredType, SAMLLogoutRegisteredEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLLogoutRequestedType, SAMLLogoutRequestedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLLogoutSentType, SAMLLogoutSentEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLLogoutFailedType, SAMLLogoutFailedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SAMLFrontChannelLogoutStartedType, SAMLFrontChannelLogoutStartedEventMapper)
 }
@@ -25,6 +25,8 @@ type AddedEvent struct {
 	Timeout          time.Duration       `json:"timeout"`
 	InterruptOnError bool                `json:"interruptOnError"`
 	SigningKey       *crypto.CryptoValue `json:"signingKey"`
+
+	RetryPolicy *domain.TargetRetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
@@ -48,12 +50,13 @@ func NewAddedEvent(
 	timeout time.Duration,
 	interruptOnError bool,
 	signingKey *crypto.CryptoValue,
+	retryPolicy *domain.TargetRetryPolicy,
 ) *AddedEvent {
 	return &AddedEvent{
 		*eventstore.NewBaseEventForPush(
 			ctx, aggregate, AddedEventType,
 		),
-		name, targetType, endpoint, timeout, interruptOnError, signingKey}
+		name, targetType, endpoint, timeout, interruptOnError, signingKey, retryPolicy}
 }
 
 type ChangedEvent struct {
@@ -66,6 +69,8 @@ type ChangedEvent struct {
 	InterruptOnError *bool               `json:"interruptOnError,omitempty"`
 	SigningKey       *crypto.CryptoValue `json:"signingKey,omitempty"`
 
+	RetryPolicy *domain.TargetRetryPolicy `json:"retryPolicy,omitempty"`
+
 	oldName string
 }
 
@@ -144,6 +149,12 @@ func ChangeSigningKey(signingKey *crypto.CryptoValue) func(event *ChangedEvent)
 	}
 }
 
+func ChangeRetryPolicy(retryPolicy *domain.TargetRetryPolicy) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.RetryPolicy = retryPolicy
+	}
+}
+
 type RemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"slices"
+
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
@@ -41,6 +43,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, UserDeactivatedType, UserDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserReactivatedType, UserReactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, UserForgottenType, UserForgottenEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserTokenV2AddedType, eventstore.GenericEventMapper[UserTokenV2AddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, UserImpersonatedType, eventstore.GenericEventMapper[UserImpersonatedEvent])
@@ -141,4 +144,34 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCodeSentType, eventstore.GenericEventMapper[HumanInviteCodeSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckSucceededType, eventstore.GenericEventMapper[HumanInviteCheckSucceededEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckFailedType, eventstore.GenericEventMapper[HumanInviteCheckFailedEvent])
+
+	registerPIIFields()
+}
+
+var (
+	profilePIIFields = []string{"firstName", "lastName", "nickName", "displayName"}
+	emailPIIFields   = []string{"email"}
+	phonePIIFields   = []string{"phone"}
+	addressPIIFields = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanPIIFields   = slices.Concat(profilePIIFields, emailPIIFields, phonePIIFields, addressPIIFields)
+)
+
+// registerPIIFields registers the fields containing personal data,
+// which are encrypted if the encryption of personal data is enabled
+func registerPIIFields() {
+	for _, typ := range []eventstore.EventType{HumanAddedType, HumanRegisteredType, UserV1AddedType, UserV1RegisteredType} {
+		eventstore.RegisterPIIFields(typ, humanPIIFields...)
+	}
+	for _, typ := range []eventstore.EventType{HumanProfileChangedType, UserV1ProfileChangedType} {
+		eventstore.RegisterPIIFields(typ, profilePIIFields...)
+	}
+	for _, typ := range []eventstore.EventType{HumanEmailChangedType, UserV1EmailChangedType} {
+		eventstore.RegisterPIIFields(typ, emailPIIFields...)
+	}
+	for _, typ := range []eventstore.EventType{HumanPhoneChangedType, UserV1PhoneChangedType} {
+		eventstore.RegisterPIIFields(typ, phonePIIFields...)
+	}
+	for _, typ := range []eventstore.EventType{HumanAddressChangedType, UserV1AddressChangedType} {
+		eventstore.RegisterPIIFields(typ, addressPIIFields...)
+	}
 }
@@ -18,6 +18,7 @@ const (
 	UserDeactivatedType       = userEventTypePrefix + "deactivated"
 	UserReactivatedType       = userEventTypePrefix + "reactivated"
 	UserRemovedType           = userEventTypePrefix + "removed"
+	UserForgottenType         = userEventTypePrefix + "forgotten"
 	UserTokenAddedType        = userEventTypePrefix + "token.added"
 	UserTokenV2AddedType      = userEventTypePrefix + "token.v2.added"
 	UserTokenRemovedType      = userEventTypePrefix + "token.removed"
@@ -106,10 +107,17 @@ func UserUnlockedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 
 type UserDeactivatedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// DirectoryIDPID is set if the user was deactivated by the directory sync of the identity provider,
+	// because it was removed from the directory.
+	DirectoryIDPID string `json:"directoryIdpId,omitempty"`
 }
 
 func (e *UserDeactivatedEvent) Payload() interface{} {
-	return nil
+	if e.DirectoryIDPID == "" {
+		return nil
+	}
+	return e
 }
 
 func (e *UserDeactivatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
@@ -126,10 +134,23 @@ func NewUserDeactivatedEvent(ctx context.Context, aggregate *eventstore.Aggregat
 	}
 }
 
+// NewUserDeactivatedByDirectoryEvent deactivates a user removed from the directory of the identity provider.
+func NewUserDeactivatedByDirectoryEvent(ctx context.Context, aggregate *eventstore.Aggregate, idpID string) *UserDeactivatedEvent {
+	event := NewUserDeactivatedEvent(ctx, aggregate)
+	event.DirectoryIDPID = idpID
+	return event
+}
+
 func UserDeactivatedEventMapper(event eventstore.Event) (eventstore.Event, error) {
-	return &UserDeactivatedEvent{
+	deactivated := &UserDeactivatedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	err := event.Unmarshal(deactivated)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-Dq4vn", "unable to unmarshal user deactivated")
+	}
+
+	return deactivated, nil
 }
 
 type UserReactivatedEvent struct {
@@ -208,6 +229,36 @@ func UserRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	}, nil
 }
 
+// UserForgottenEvent records that the key of the personal data of the user was destroyed.
+// The personal data of all events of the user cannot be read anymore.
+type UserForgottenEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserForgottenEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *UserForgottenEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserForgottenEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserForgottenEvent {
+	return &UserForgottenEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserForgottenType,
+		),
+	}
+}
+
+func UserForgottenEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &UserForgottenEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
 type UserTokenAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -52,7 +52,10 @@ func (u *webUser) WebAuthnCredentials() []webauthn.Credential {
 	return u.credentials
 }
 
-func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
+// BeginRegistration creates the options to register a new credential for the user.
+// If residentKey is set, the authenticator must create a discoverable credential (passkey),
+// so the user can be resolved from it on a login without a username.
+func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, residentKey bool, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
 	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
 	if err != nil {
 		return nil, err
@@ -65,16 +68,21 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 			CredentialID: cred.ID,
 		}
 	}
+	selection := protocol.AuthenticatorSelection{
+		UserVerification:        UserVerificationFromDomain(userVerification),
+		AuthenticatorAttachment: AuthenticatorAttachmentFromDomain(authType),
+	}
+	if residentKey {
+		selection.ResidentKey = protocol.ResidentKeyRequirementRequired
+		selection.RequireResidentKey = protocol.ResidentKeyRequired()
+	}
 	credentialOptions, sessionData, err := webAuthNServer.BeginRegistration(
 		&webUser{
 			Human:       user,
 			accountName: accountName,
 			credentials: creds,
 		},
-		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
-			UserVerification:        UserVerificationFromDomain(userVerification),
-			AuthenticatorAttachment: AuthenticatorAttachmentFromDomain(authType),
-		}),
+		webauthn.WithAuthenticatorSelection(selection),
 		webauthn.WithConveyancePreference(protocol.PreferNoAttestation),
 		webauthn.WithExclusions(existing),
 	)
@@ -181,6 +189,85 @@ func (w *Config) FinishLogin(ctx context.Context, user *domain.Human, webAuthN *
 	return credential, nil
 }
 
+// BeginDiscoverableLogin starts a login without a known user.
+// No credentials are allowed explicitly, so the authenticator can offer all discoverable credentials (passkeys) of the relying party.
+func (w *Config) BeginDiscoverableLogin(ctx context.Context, userVerification domain.UserVerificationRequirement, rpID string) (*domain.WebAuthNLogin, error) {
+	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
+	if err != nil {
+		return nil, err
+	}
+	assertion, sessionData, err := webAuthNServer.BeginDiscoverableLogin(webauthn.WithUserVerification(UserVerificationFromDomain(userVerification)))
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn discoverable login could not be started")
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Rk3ve", "Errors.User.WebAuthN.BeginLoginFailed")
+	}
+	cred, err := json.Marshal(assertion)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Bq8nW", "Errors.User.WebAuthN.MarshalError")
+	}
+	return &domain.WebAuthNLogin{
+		Challenge:               sessionData.Challenge,
+		CredentialAssertionData: cred,
+		UserVerification:        userVerification,
+		RPID:                    webAuthNServer.Config.RPID,
+	}, nil
+}
+
+// UserIDFromAssertion returns the id of the user, the credential of the assertion was created for.
+// It is taken from the user handle, which is returned by the authenticator for discoverable credentials.
+// The assertion is not validated, which must be done using [Config.FinishDiscoverableLogin].
+func UserIDFromAssertion(credData []byte) (string, error) {
+	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn assertion could not be parsed")
+		return "", zerrors.ThrowInvalidArgument(err, "WEBAU-Hn5wk", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	if len(assertionData.Response.UserHandle) == 0 {
+		return "", zerrors.ThrowInvalidArgument(nil, "WEBAU-y7Gxe", "Errors.User.WebAuthN.UserHandleMissing")
+	}
+	return string(assertionData.Response.UserHandle), nil
+}
+
+// FinishDiscoverableLogin validates the assertion of a login started with [Config.BeginDiscoverableLogin].
+// The user has to match the user handle of the assertion.
+func (w *Config) FinishDiscoverableLogin(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNLogin, credData []byte, webAuthNs ...*domain.WebAuthNToken) (*webauthn.Credential, error) {
+	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn assertion could not be parsed")
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Lw2cz", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	webUser := &webUser{
+		Human:       user,
+		credentials: WebAuthNsToCredentials(webAuthNs, webAuthN.RPID),
+	}
+	webAuthNServer, err := w.serverFromContext(ctx, webAuthN.RPID, assertionData.Response.CollectedClientData.Origin)
+	if err != nil {
+		return nil, err
+	}
+	sessionData := WebAuthNLoginToSessionData(webAuthN)
+	// the challenge was not created for a specific user
+	sessionData.UserID = nil
+	credential, err := webAuthNServer.ValidateDiscoverableLogin(
+		func(_, userHandle []byte) (webauthn.User, error) {
+			if !bytes.Equal(userHandle, webUser.WebAuthnID()) {
+				return nil, errors.New("user handle does not match user")
+			}
+			return webUser, nil
+		},
+		sessionData,
+		assertionData,
+	)
+	if err != nil {
+		logging.WithFields("error", tryExtractProtocolErrMsg(err)).Debug("webauthn discoverable assertion failed")
+		return nil, zerrors.ThrowInternal(err, "WEBAU-Tp6jd", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+
+	if credential.Authenticator.CloneWarning {
+		return credential, zerrors.ThrowInternal(nil, "WEBAU-Vf4ko", "Errors.User.WebAuthN.CloneWarning")
+	}
+	return credential, nil
+}
+
 func (w *Config) serverFromContext(ctx context.Context, id, origin string) (*webauthn.WebAuthn, error) {
 	config := w.config(id, origin)
 	if id == "" {
@@ -2,13 +2,17 @@ package webauthn
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -77,3 +81,82 @@ func TestConfig_serverFromContext(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_BeginDiscoverableLogin(t *testing.T) {
+	w := &Config{
+		DisplayName:    "DisplayName",
+		ExternalSecure: true,
+	}
+	ctx := http_util.WithDomainContext(context.Background(), &http_util.DomainCtx{InstanceHost: "example.com", Protocol: "https"})
+	got, err := w.BeginDiscoverableLogin(ctx, domain.UserVerificationRequirementRequired, "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, got.Challenge)
+	assert.Empty(t, got.AllowedCredentialIDs)
+	assert.Equal(t, domain.UserVerificationRequirementRequired, got.UserVerification)
+	assert.Equal(t, "example.com", got.RPID)
+
+	assertion := new(protocol.CredentialAssertion)
+	require.NoError(t, json.Unmarshal(got.CredentialAssertionData, assertion))
+	assert.Empty(t, assertion.Response.AllowedCredentials)
+	assert.Equal(t, protocol.VerificationRequired, assertion.Response.UserVerification)
+}
+
+func TestUserIDFromAssertion(t *testing.T) {
+	tests := []struct {
+		name     string
+		credData []byte
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "invalid data",
+			credData: []byte("invalid"),
+			wantErr:  zerrors.ThrowInvalidArgument(nil, "WEBAU-Hn5wk", "Errors.User.WebAuthN.ValidateLoginFailed"),
+		},
+		{
+			name:     "missing user handle",
+			credData: testAssertion(t, nil),
+			wantErr:  zerrors.ThrowInvalidArgument(nil, "WEBAU-y7Gxe", "Errors.User.WebAuthN.UserHandleMissing"),
+		},
+		{
+			name:     "user handle",
+			credData: testAssertion(t, []byte("userID")),
+			want:     "userID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserIDFromAssertion(tt.credData)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func testAssertion(t *testing.T, userHandle []byte) []byte {
+	clientData, err := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": base64.RawURLEncoding.EncodeToString([]byte("challenge")),
+		"origin":    "https://example.com",
+	})
+	require.NoError(t, err)
+	// rp id hash (32 bytes), flags (user present and verified) and sign count (4 bytes)
+	authData := append(make([]byte, 32), 0x05, 0, 0, 0, 1)
+	rawID := base64.RawURLEncoding.EncodeToString([]byte("credentialID"))
+	response := map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString([]byte("signature")),
+	}
+	if userHandle != nil {
+		response["userHandle"] = base64.RawURLEncoding.EncodeToString(userHandle)
+	}
+	data, err := json.Marshal(map[string]any{
+		"id":       rawID,
+		"rawId":    rawID,
+		"type":     "public-key",
+		"response": response,
+	})
+	require.NoError(t, err)
+	return data
+}